@@ -10,6 +10,10 @@ import (
 // @host localhost:8089
 // @BasePath /
 // @schemes http
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin token as "Bearer <token>"
 func main() {
 	app.Run()
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"songLibrary/internal/musicinfomock"
+	"songLibrary/pkg/logger/sl"
+)
+
+// mockmusicinfo serves a mock of the external MusicInfo API
+func main() {
+	addr := flag.String("addr", "localhost:8088", "address to listen on")
+	errorRate := flag.Float64("error-rate", 0.3, "share of requests from 0 to 1 answered with 400")
+	latency := flag.Duration("latency", 0, "delay of every response")
+	jitter := flag.Duration("jitter", 0, "random extra delay of a response up to this value")
+	dateLayout := flag.String("date-layout", musicinfomock.ReleaseDateLayout, "Go time layout of release dates")
+	seed := flag.Int64("seed", 0, "seed of failures and generated songs, 0 seeds from the current time")
+	flag.Parse()
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	if *errorRate < 0 || *errorRate > 1 || *latency < 0 || *jitter < 0 {
+		log.Error("error-rate must be between 0 and 1, latency and jitter can't be negative")
+		os.Exit(1)
+	}
+
+	srv := musicinfomock.New(musicinfomock.Config{
+		ErrorRate: *errorRate,
+		Latency:   *latency,
+		Jitter:    *jitter,
+		Seed:      *seed,
+
+		ReleaseDateLayout: *dateLayout,
+	}, log)
+
+	log.Info("mock music info server started",
+		slog.String("address", *addr),
+		slog.Float64("error_rate", *errorRate),
+		slog.Duration("latency", *latency),
+		slog.Duration("jitter", *jitter),
+		slog.Int64("seed", *seed),
+	)
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		log.Error("server stopped", sl.Err(err))
+		os.Exit(1)
+	}
+}
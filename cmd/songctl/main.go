@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"songLibrary/internal/app"
+	"songLibrary/internal/config"
+	"songLibrary/internal/songctl"
+	"songLibrary/pkg/migrator"
+	"syscall"
+)
+
+// songctl administers a song library from the terminal, see songctl help
+func main() {
+	// storage settings are only needed by migrate, which reports a failed
+	// connection itself, so they aren't required like for the server
+	cfg := config.MustLoad(true)
+
+	addr := flag.String("addr", cfg.HTTP.Address, "address of the song library API")
+	adminAddr := flag.String("admin-addr", cfg.Admin.Address, "address of the admin routes, if served apart from the API")
+	token := flag.String("token", cfg.Admin.Token, "admin token")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, songctl.Usage)
+	}
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := songctl.NewClient(*addr, *token)
+	if *adminAddr != "" {
+		client.WithAdminAddress(*adminAddr)
+	}
+
+	cli := &songctl.CLI{
+		Client: client,
+		MigrationStatus: func(ctx context.Context) (*migrator.Status, error) {
+			return app.ReadMigrationStatus(cfg)
+		},
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+
+	if err := cli.Run(ctx, flag.Args()); err != nil {
+		if !errors.Is(err, songctl.ErrUsage) {
+			fmt.Fprintf(os.Stderr, "songctl: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
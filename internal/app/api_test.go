@@ -0,0 +1,256 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"songLibrary/internal/config"
+	deliveryHttp "songLibrary/internal/delivery/http"
+	musicapi "songLibrary/internal/delivery/music_info"
+	"songLibrary/internal/repository"
+	"songLibrary/internal/repository/memory"
+	"songLibrary/internal/service"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the current responses:
+// go test ./internal/app -run TestAPI -update
+var update = flag.Bool("update", false, "rewrite the golden files of the API tests")
+
+// newTestAPI собирает роутер так же, как Run в dev-режиме: хранилище в
+// памяти, mock-провайдер информации о песнях и все промежуточные слои API
+func newTestAPI(t *testing.T) http.Handler {
+	t.Helper()
+
+	var cfg config.Config
+	require.NoError(t, cleanenv.ReadEnv(&cfg))
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	db := memory.NewStore()
+	cache := memory.NewCache()
+	repo := repository.NewRepository(db, cache, nil, cfg.Cache.EarlyRefresh, log)
+	libraryService := service.NewLibraryService(repository.NewLibraryRepository(db, log), log)
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db, log), log)
+
+	songService := service.NewService(repo, musicapi.NewMock(), log)
+	songService.Split = lyricsSplit(&cfg, log)
+
+	handler := deliveryHttp.NewHandler(songService, log)
+	handler.Register(
+		deliveryHttp.NewAlbumHandler(service.NewAlbumService(repository.NewAlbumRepository(db, cache, log), log), log),
+		deliveryHttp.NewTagHandler(service.NewTagService(repository.NewTagRepository(db, cache, log), repo, log), log),
+		deliveryHttp.NewRatingHandler(service.NewRatingService(repository.NewRatingRepository(db, cache, log), log), log),
+		deliveryHttp.NewHealthHandler(map[string]deliveryHttp.HealthCheck{}, log),
+	)
+	handler.Use(apiMiddlewares(&cfg, libraryService, apiKeyService, log)...)
+
+	return handler.InitRoutes()
+}
+
+// apiStep is a request of a scenario, its response is compared with the
+// golden file when golden is set
+type apiStep struct {
+	method, path, body string
+	header             map[string]string
+	golden             string
+}
+
+func TestAPI(t *testing.T) {
+	const song = `{"group": "Muse", "name": "Supermassive Black Hole"}`
+
+	tests := []struct {
+		name  string
+		steps []apiStep
+	}{
+		{
+			name: "добавление и чтение песни",
+			steps: []apiStep{
+				{method: http.MethodPost, path: "/songs", body: song, golden: "add_song"},
+				{method: http.MethodGet, path: "/songs/{id}", golden: "get_song"},
+				{method: http.MethodGet, path: "/songs/{id}/text?verses_per_page=1&page=1", golden: "get_song_text"},
+				{method: http.MethodGet, path: "/songs/{id}/text/1", golden: "get_verse"},
+			},
+		},
+		{
+			name: "список с фильтром",
+			steps: []apiStep{
+				{method: http.MethodPost, path: "/songs", body: song},
+				{method: http.MethodPost, path: "/songs", body: `{"group": "Radiohead", "name": "Creep"}`},
+				{method: http.MethodGet, path: "/songs?group=muse&include_text=false", golden: "list_songs_filtered"},
+			},
+		},
+		{
+			name: "ошибки",
+			steps: []apiStep{
+				{method: http.MethodPost, path: "/songs", body: song},
+				{method: http.MethodPost, path: "/songs", body: song, golden: "add_song_duplicate"},
+				{method: http.MethodPost, path: "/songs", body: `{"group": "Muse"}`, golden: "add_song_invalid"},
+				{method: http.MethodGet, path: "/songs/not-an-id", golden: "get_song_invalid_id"},
+				{method: http.MethodGet, path: "/songs/4b7e1c2a-9a3f-4d7e-8f1b-2c5d6e7f8a9b", golden: "get_song_not_found"},
+				{method: http.MethodDelete, path: "/songs", golden: "method_not_allowed"},
+				{method: http.MethodGet, path: "/songs?sort=name", golden: "list_songs_invalid_sort"},
+			},
+		},
+		{
+			name: "библиотека из заголовка",
+			steps: []apiStep{
+				{method: http.MethodGet, path: "/songs", header: map[string]string{"X-Library-ID": "bad"}, golden: "library_invalid"},
+				{method: http.MethodGet, path: "/songs", header: map[string]string{"X-Library-ID": "4b7e1c2a-9a3f-4d7e-8f1b-2c5d6e7f8a9b"}, golden: "library_not_found"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t)
+
+			// ID первой добавленной песни подставляется вместо {id}
+			var songID string
+			for _, step := range tt.steps {
+				path := strings.ReplaceAll(step.path, "{id}", songID)
+				req := httptest.NewRequest(step.method, path, strings.NewReader(step.body))
+				req.Header.Set("X-Request-Id", "golden")
+				if step.body != "" {
+					req.Header.Set("Content-Type", "application/json")
+				}
+				for key, value := range step.header {
+					req.Header.Set(key, value)
+				}
+
+				w := httptest.NewRecorder()
+				api.ServeHTTP(w, req)
+
+				if songID == "" && step.method == http.MethodPost && w.Code == http.StatusCreated {
+					var created struct {
+						ID string `json:"id"`
+					}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+					songID = created.ID
+				}
+				if step.golden != "" {
+					assertGolden(t, step.golden, w)
+				}
+			}
+		})
+	}
+}
+
+// TestAPI_RatingRevalidatesSong проверяет, что оценка песни меняет её ETag:
+// клиент с закэшированной до оценки песней получает её заново, а не 304
+func TestAPI_RatingRevalidatesSong(t *testing.T) {
+	api := newTestAPI(t)
+
+	serve := func(method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		api.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/songs", `{"group": "Muse", "name": "Hysteria"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	w = serve(http.MethodGet, "/songs/"+created.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	w = serve(http.MethodGet, "/songs/"+created.ID, "", map[string]string{"If-None-Match": etag})
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	w = serve(http.MethodPut, "/songs/"+created.ID+"/rating", `{"rating": 5}`,
+		map[string]string{"X-User-ID": "4b7e1c2a-9a3f-4d7e-8f1b-2c5d6e7f8a9b"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve(http.MethodGet, "/songs/"+created.ID, "", map[string]string{"If-None-Match": etag})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEqual(t, etag, w.Header().Get("ETag"))
+	require.Contains(t, w.Body.String(), `"ratings_count":1`)
+}
+
+var (
+	uuidPattern      = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	etagPattern      = regexp.MustCompile(`"[0-9a-f]{16,}"`)
+)
+
+const nilUUID = "00000000-0000-0000-0000-000000000000"
+
+// renderResponse writes the status, the content type and the indented body
+// of the response. IDs and timestamps generated by the store are replaced
+// with placeholders, the same ID gets the same placeholder.
+func renderResponse(t *testing.T, w *httptest.ResponseRecorder) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%d %s\n", w.Code, http.StatusText(w.Code))
+	fmt.Fprintf(&out, "Content-Type: %s\n\n", w.Header().Get("Content-Type"))
+
+	body := w.Body.Bytes()
+	if json.Valid(body) && len(bytes.TrimSpace(body)) > 0 {
+		var indented bytes.Buffer
+		require.NoError(t, json.Indent(&indented, body, "", "  "))
+		body = indented.Bytes()
+	}
+
+	ids := map[string]string{}
+	body = uuidPattern.ReplaceAllFunc(body, func(id []byte) []byte {
+		if string(id) == nilUUID {
+			return id
+		}
+		placeholder, ok := ids[string(id)]
+		if !ok {
+			placeholder = fmt.Sprintf("<id-%d>", len(ids)+1)
+			ids[string(id)] = placeholder
+		}
+		return []byte(placeholder)
+	})
+	body = timestampPattern.ReplaceAll(body, []byte("<time>"))
+	body = etagPattern.ReplaceAll(body, []byte(`"<etag>"`))
+
+	if body = bytes.TrimRight(body, "\n"); len(body) > 0 {
+		out.Write(body)
+		out.WriteByte('\n')
+	}
+	return out.Bytes()
+}
+
+// assertGolden compares the response with testdata/api/<name>.golden
+func assertGolden(t *testing.T, name string, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	got := renderResponse(t, w)
+	path := filepath.Join("testdata", "api", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run the tests with -update to create the golden file")
+	require.Equal(t, string(want), string(got), name)
+}
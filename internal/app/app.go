@@ -2,31 +2,53 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
-	"embed"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"songLibrary/internal/app/migrations"
+	"songLibrary/internal/backup"
+	"songLibrary/internal/blob"
 	"songLibrary/internal/config"
+	"songLibrary/internal/consumer"
+	"songLibrary/internal/delivery/broker"
+	"songLibrary/internal/delivery/embedding"
 	deliveryHttp "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/middleware/admin"
+	"songLibrary/internal/delivery/http/middleware/allowlist"
+	"songLibrary/internal/delivery/http/middleware/apikey"
+	"songLibrary/internal/delivery/http/middleware/bodylimit"
+	"songLibrary/internal/delivery/http/middleware/compress"
+	"songLibrary/internal/delivery/http/middleware/httpcache"
+	"songLibrary/internal/delivery/http/middleware/library"
+	"songLibrary/internal/delivery/http/middleware/ratelimit"
+	"songLibrary/internal/delivery/http/middleware/recoverer"
+	"songLibrary/internal/delivery/http/middleware/role"
+	"songLibrary/internal/delivery/http/middleware/timeout"
+	"songLibrary/internal/delivery/http/middleware/user"
 	musicapi "songLibrary/internal/delivery/music_info"
+	"songLibrary/internal/delivery/webhook"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/events"
+	"songLibrary/internal/metrics"
 	"songLibrary/internal/repository"
+	"songLibrary/internal/repository/memory"
 	"songLibrary/internal/repository/postgres"
 	redi "songLibrary/internal/repository/redis"
+	"songLibrary/internal/seed"
 	"songLibrary/internal/service"
-	"songLibrary/pkg/logger/handlers/slogpretty"
 	"songLibrary/pkg/logger/sl"
 	"songLibrary/pkg/migrator"
 	"syscall"
 	"time"
 
-	_ "songLibrary/docs"
-
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
-	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 const (
@@ -35,20 +57,140 @@ const (
 	envProd  = "prod"
 )
 
-const migrationsDir = "migrations"
+const migrationsDir = migrations.Dir
+
+// eventBufferSize is how many song events an event stream client can lag behind
+const eventBufferSize = 64
+
+// Paths with limits of their own that the common request limits skip:
+// CSV import, covers, audio and restored backups limit the uploaded file,
+// the event stream stays open, audio streams as long as the client listens,
+// backups, lyrics normalization and content scans take as long as the
+// database needs
+const (
+	importPath    = "/songs/import"
+	coverPath     = "/songs/*/cover"
+	audioPath     = "/songs/*/audio"
+	eventsPath    = "/songs/events"
+	backupPath    = "/admin/backup"
+	restorePath   = "/admin/restore"
+	normalizePath = "/admin/lyrics/normalize"
+	scanPath      = "/admin/lyrics/scan"
+)
+
+// roleRules are the routes needing another role than viewer to read and
+// editor to write. Plays, favorites, ratings, smart playlists and their
+// share links belong to the user, not to the catalog, so viewers keep them;
+// share links are opened by anyone holding the token; a batch get only reads
+// despite being a POST; webhooks receive the events of every library and bulk
+// updates change many songs at once, so they are left to admins. Admin
+// routes are checked by the admin middleware, which lets admins through
+// without the token.
+var roleRules = []role.Rule{
+	{Pattern: "/admin/*"},
+	{Pattern: "/admin/*/*"},
+	{Pattern: "/admin/*/*/*"},
+	{Method: http.MethodPatch, Pattern: "/songs", Role: domain.RoleAdmin},
+	{Pattern: "/webhooks", Role: domain.RoleAdmin},
+	{Pattern: "/webhooks/*", Role: domain.RoleAdmin},
+	{Method: http.MethodPost, Pattern: "/songs/batch-get", Role: domain.RoleViewer},
+	{Method: http.MethodPost, Pattern: "/songs/*/play", Role: domain.RoleViewer},
+	{Pattern: "/songs/*/favorite", Role: domain.RoleViewer},
+	{Pattern: "/songs/*/rating", Role: domain.RoleViewer},
+	{Method: http.MethodPost, Pattern: "/songs/*/share", Role: domain.RoleViewer},
+	{Pattern: "/smart-playlists", Role: domain.RoleViewer},
+	{Pattern: "/smart-playlists/*", Role: domain.RoleViewer},
+	{Pattern: "/smart-playlists/*/share", Role: domain.RoleViewer},
+	{Pattern: "/shares", Role: domain.RoleViewer},
+	{Pattern: "/shares/*", Role: domain.RoleViewer},
+	{Method: http.MethodGet, Pattern: "/shared/*"},
+}
 
-//go:embed migrations/*.sql
-var MigrationsFS embed.FS
+// MigrationsFS holds the migrations applied on start
+var MigrationsFS = migrations.FS
+
+// storage is the database used by the repositories, PostgreSQL or the
+// in-memory store of dev mode
+type storage interface {
+	repository.Database
+	repository.AlbumDatabase
+	repository.ArtistDatabase
+	repository.FavoriteDatabase
+	repository.RatingDatabase
+	repository.PlayDatabase
+	repository.WebhookDatabase
+	repository.AuditDatabase
+	repository.RevisionDatabase
+	repository.TagDatabase
+	repository.EnrichmentDatabase
+	repository.AudioDatabase
+	repository.SuggestionDatabase
+	repository.SearchDatabase
+	repository.EmbeddingDatabase
+	repository.SimilarDatabase
+	repository.CalendarDatabase
+	repository.RandomDatabase
+	repository.StatsDatabase
+	repository.GroupDatabase
+	repository.OutboxDatabase
+	repository.LibraryDatabase
+	repository.UserDatabase
+	repository.APIKeyDatabase
+	repository.PlaylistDatabase
+	repository.ShareDatabase
+}
+
+// cacheStorage is the cache used by the repositories, Redis or the
+// in-memory cache of dev mode
+type cacheStorage interface {
+	repository.Cache
+	repository.PlayBuffer
+	repository.SuggestionCache
+	repository.DailyPickCache
+	repository.StatsCache
+	service.MusicInfoCache
+}
 
 // Run starts the application
 func Run() {
+	dev := flag.Bool("dev", false, "use in-memory storage instead of PostgreSQL and Redis")
+	backupFile := flag.String("backup", "", "write a backup of the database to the file and exit")
+	restoreFile := flag.String("restore", "", "restore the database from the backup file (- for stdin) and exit")
+	dryRun := flag.Bool("dry-run", false, "with -restore, validate the backup without changing the database")
+	seedSongs := flag.Bool("seed", false, "load seed songs into an empty library on startup")
+	seedFile := flag.String("seed-file", "", "JSON or CSV file of seed songs, implies -seed")
+	migrateCommand := flag.String("migrate", "", "run a migration command and exit: up, down N, status or version")
+	waitForDeps := flag.Bool("wait-for-deps", false, "retry connecting to PostgreSQL and Redis on startup up to startup.max_wait")
+	flag.Parse()
+
 	// load configuration
-	cfg := config.MustLoad()
+	cfg := config.MustLoad(*dev)
+	if *waitForDeps {
+		cfg.Startup.WaitForDeps = true
+	}
 
 	// setup logger
-	log := setupLogger(cfg.Env)
+	log, closeLog, err := setupLogger(cfg.Env, cfg.Log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up logger: %s\n", err)
+		os.Exit(1)
+	}
+	defer closeLog()
 	log.Info("starting song library", slog.String("env", cfg.Env))
 
+	// migration commands run before connecting, which applies migrations
+	if *migrateCommand != "" {
+		if *dev {
+			log.Error("-migrate can't be used in dev mode")
+			os.Exit(1)
+		}
+		if err := runMigrateCommand(cfg, *migrateCommand, flag.Args(), log); err != nil {
+			log.Error("migrate command failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	// setup context and handle graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -56,64 +198,667 @@ func Run() {
 	// setup signal handler for graceful shutdown
 	gracefulShutdown(ctx, cancel, log)
 
-	// connect to PostgreSQL
-	connString := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable",
-		cfg.Postgres.User,
-		cfg.Postgres.Password,
-		cfg.Postgres.Address,
-		cfg.Postgres.DBName,
+	// connect to the storage, dev mode keeps everything in memory
+	var (
+		db         storage
+		cache      cacheStorage
+		client     *redis.Client
+		backupDB   backup.Database
+		writeQueue repository.WriteQueue
+
+		healthChecks = map[string]deliveryHttp.HealthCheck{}
 	)
-	log.Info("connecting to PostgreSQL", slog.String("connection_string", connString))
+	if *dev {
+		log.Warn("dev mode: using in-memory storage instead of PostgreSQL and Redis, data is lost on exit")
+		db = memory.NewStore()
+		cache = memory.NewCache()
+	} else {
+		pg, closePostgres := connectPostgres(ctx, cfg, log)
+		defer closePostgres()
+
+		client = connectRedis(ctx, cfg, log)
+		defer client.Close()
+
+		db = pg
+		backupDB = pg
+		healthChecks["postgres"] = pg.Ping
+		healthChecks["redis"] = func(ctx context.Context) error { return client.Ping(ctx).Err() }
+		redisCache := redi.NewRedis(client, cfg.Cache.SongTTL)
+		cache = redisCache
+		metrics.PublishFunc("cache_decode", func() any { return redisCache.DecodeStats() })
+		if cfg.Cache.WriteBehind.Enabled {
+			writeQueue = redisCache
+		}
+	}
+	if cfg.Cache.WriteBehind.Enabled && *dev {
+		log.Info("write-behind cache is not available in dev mode")
+	}
 
-	poolConfig, err := pgxpool.ParseConfig(connString)
+	// create the chain of music info providers
+	clientOptions := musicapi.ClientOptions{
+		ConnectTimeout: cfg.MusicInfo.ConnectTimeout,
+		RequestTimeout: cfg.MusicInfo.RequestTimeout,
+		MaxIdleConns:   cfg.MusicInfo.MaxIdleConns,
+
+		ReleaseDateLayouts: cfg.MusicInfo.ReleaseDateLayouts,
+	}
+	providers := make([]service.MusicInfoProvider, 0, len(cfg.MusicInfo.Providers))
+	for _, p := range cfg.MusicInfo.Providers {
+		var musicInfo service.MusicInfo
+		switch p.Type {
+		case config.MusicInfoMock:
+			musicInfo = musicapi.NewMock()
+		default:
+			musicInfo = musicapi.NewMusicInfo(p.Address, clientOptions, log)
+		}
+		providers = append(providers, service.MusicInfoProvider{Name: p.Name, MusicInfo: musicInfo})
+		log.Info("music info provider", slog.String("name", p.Name), slog.String("type", p.Type), slog.String("address", p.Address))
+	}
+	var musicServiceAPI service.MusicInfo = service.NewMusicInfoChain(providers, log)
+
+	// create repositories, services, and handlers
+	if cfg.MusicInfo.Cache.Enabled {
+		musicServiceAPI = service.NewCachedMusicInfo(musicServiceAPI, cache, cfg.MusicInfo.Cache.TTL, log)
+	}
+	var songCache repository.Cache = cache
+	var resilientCache *repository.ResilientCache
+	if cfg.Cache.Resilience.Enabled && client != nil {
+		resilientCache = repository.NewResilientCache(cache, func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		}, log)
+		songCache = resilientCache
+		metrics.PublishFunc("cache_resilience", func() any { return resilientCache.ResilienceStats() })
+		log.Info("cache resilience enabled", slog.Duration("probe_interval", cfg.Cache.Resilience.ProbeInterval))
+	}
+	repo := repository.NewRepository(db, songCache, writeQueue, cfg.Cache.EarlyRefresh, log)
+	libraryService := service.NewLibraryService(repository.NewLibraryRepository(db, log), log)
+	userService := service.NewUserService(repository.NewUserRepository(db, log), log)
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db, log), log)
+	normalizeService := service.NewNormalizeService(repo, libraryService, log)
+	contentFilter := newContentFilter(cfg, log)
+	contentScanService := service.NewContentScanService(repo, libraryService, contentFilter, log)
+	albumRepo := repository.NewAlbumRepository(db, cache, log)
+	albumService := service.NewAlbumService(albumRepo, log)
+	artistRepo := repository.NewArtistRepository(db, cache, log)
+	artistService := service.NewArtistService(artistRepo, log)
+	groupService := service.NewGroupService(repository.NewGroupRepository(db, log), log)
+	favoriteRepo := repository.NewFavoriteRepository(db, cache, log)
+	favoriteService := service.NewFavoriteService(favoriteRepo, log)
+	ratingService := service.NewRatingService(repository.NewRatingRepository(db, cache, log), log)
+	playRepo := repository.NewPlayRepository(db, cache, log)
+	playService := service.NewPlayService(playRepo, repo, cfg.Plays.TrendingWindow, cfg.Plays.TrendingLimit, log)
+	webhookRepo := repository.NewWebhookRepository(db, log)
+	webhookService := service.NewWebhookService(webhookRepo, log)
+	webhookDispatcher := service.NewWebhookDispatcher(
+		webhookRepo, webhook.NewClient(cfg.Webhooks.Timeout, log),
+		cfg.Webhooks.MaxAttempts, cfg.Webhooks.RetryBackoff, log,
+	)
+	auditRepo := repository.NewAuditRepository(db, log)
+	auditService := service.NewAuditService(auditRepo, log)
+	revisionRepo := repository.NewRevisionRepository(db, log)
+	revisionService := service.NewRevisionService(revisionRepo, repo, log)
+	tagRepo := repository.NewTagRepository(db, cache, log)
+	tagService := service.NewTagService(tagRepo, repo, log)
+	blobStorage := newBlobStorage(cfg, log)
+	coverService := service.NewCoverService(repo, blobStorage, cfg.Covers.MaxSize, log)
+	audioService := service.NewAudioService(repository.NewAudioRepository(db, log), repo, blobStorage, cfg.Audio.MaxSize, log)
+	suggestRepo := repository.NewSuggestionRepository(db, cache, cfg.Suggest.CacheTTL, log)
+	suggestService := service.NewSuggestService(suggestRepo, cfg.Suggest.Limit, cfg.Suggest.MaxLimit, log)
+	searchService := service.NewSearchService(repository.NewSearchRepository(db, log), cfg.Search.Snippets, cfg.Search.MaxSnippets, log)
+	embeddingIndexer := newEmbeddingIndexer(ctx, cfg, db, searchService, log)
+	similarService := service.NewSimilarService(repository.NewSimilarRepository(db, log), repo, log)
+	recentService := service.NewRecentService(repo, log)
+	calendarService := service.NewCalendarService(repository.NewCalendarRepository(db, log), log)
+	playlistRepo := repository.NewPlaylistRepository(db, log)
+	playlistService := service.NewPlaylistService(playlistRepo, repo, log)
+	shareService := service.NewShareService(
+		repository.NewShareRepository(db, log), repo, repo, playlistRepo,
+		shareSecret(cfg, log), cfg.Sharing.DefaultTTL, cfg.Sharing.MaxTTL, log,
+	)
+	randomService := service.NewRandomService(repository.NewRandomRepository(db, cache, log), repo, log)
+	statsRepo := repository.NewStatsRepository(db, cache, cfg.Stats.CacheTTL, log)
+	statsService := service.NewStatsService(statsRepo, cfg.Stats.Days, cfg.Stats.Weeks, log)
+	enrichmentService := service.NewEnrichmentService(
+		repository.NewEnrichmentRepository(db, log), nil,
+		cfg.Enrichment.StaleAfter, cfg.Enrichment.BatchSize, cfg.Enrichment.RequestsPerSecond, log,
+	)
+	cacheService := service.NewCacheService(repo, cfg.Cache.BatchSize, cfg.Cache.Limit, log)
+	writeFlusher := service.NewWriteBehindFlusher(repo, cfg.Cache.WriteBehind.BatchSize, log)
+	backups := backup.New(backupDB, cacheService, log)
+	if *backupFile != "" || *restoreFile != "" {
+		if err := runBackupCommand(ctx, backups, *backupFile, *restoreFile, *dryRun, log); err != nil {
+			log.Error("backup command failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+	if *seedSongs || *seedFile != "" || cfg.Seed.Enabled {
+		file := cfg.Seed.File
+		if *seedFile != "" {
+			file = *seedFile
+		}
+		if _, err := seed.New(repo, log).Load(ctx, file); err != nil {
+			log.Error("failed to load seed", sl.Err(err))
+			os.Exit(1)
+		}
+	}
+	bus := events.NewBus(eventBufferSize, log)
+	outboxRelay := service.NewOutboxRelay(repository.NewOutboxRepository(db, log), bus, cfg.Outbox.BatchSize, log)
+	if cfg.Broker.Type != "" {
+		eventBroker, closeBroker := connectBroker(cfg, log)
+		defer closeBroker()
+		outboxRelay.Broker = eventBroker
+	}
+	var pendingEnrichment *service.PendingEnrichment
+	if cfg.MusicInfo.Pending.Enabled {
+		pendingEnrichment = service.NewPendingEnrichment(nil, cfg.MusicInfo.Pending.RetryBackoff, cfg.MusicInfo.Pending.MaxAttempts, log)
+		metrics.PublishFunc("pending_enrichment", func() any { return pendingEnrichment.Stats() })
+	}
+	service := service.NewService(repo, musicServiceAPI, log)
+	service.MusicInfoTimeout = cfg.MusicInfo.FetchTimeout
+	service.Split = lyricsSplit(cfg, log)
+	service.ContentFilter = contentFilter
+	if pendingEnrichment != nil {
+		pendingEnrichment.Songs = service
+		service.Pending = pendingEnrichment
+	}
+	enrichmentService.Songs = service
+	handler := deliveryHttp.NewHandler(service, log)
+	metrics.PublishFunc("http_panics", func() any { return recoverer.Panics() })
+	// operational routes are served on the admin address to allowed clients
+	// instead of the token when it is set
+	adminAuth := admin.New(log, cfg.Admin.Token)
+	if cfg.Admin.Address != "" {
+		adminAuth = func(next http.Handler) http.Handler { return next }
+	}
+	adminHandler := deliveryHttp.NewAdminHandler(cacheService, adminAuth, log)
+	adminHandler.BackupService = backups
+	adminHandler.LibraryService = libraryService
+	adminHandler.UserService = userService
+	adminHandler.APIKeyService = apiKeyService
+	adminHandler.NormalizeService = normalizeService
+	adminHandler.ContentScanService = contentScanService
+	adminHandler.MaxRestoreSize = cfg.Backup.MaxRestoreSize
+	handler.Register(
+		deliveryHttp.NewAlbumHandler(albumService, log),
+		deliveryHttp.NewArtistHandler(artistService, log),
+		deliveryHttp.NewGroupHandler(groupService, log),
+		deliveryHttp.NewFavoriteHandler(favoriteService, log),
+		deliveryHttp.NewRatingHandler(ratingService, log),
+		deliveryHttp.NewPlaylistHandler(playlistService, log),
+		deliveryHttp.NewShareHandler(shareService, cfg.Feeds.BaseURL, log),
+		deliveryHttp.NewPlayHandler(playService, log),
+		deliveryHttp.NewEventsHandler(bus, log),
+		deliveryHttp.NewWebhookHandler(webhookService, log),
+		deliveryHttp.NewAuditHandler(auditService, log),
+		deliveryHttp.NewRevisionHandler(revisionService, log),
+		deliveryHttp.NewTagHandler(tagService, log),
+		deliveryHttp.NewCoverHandler(coverService, log),
+		deliveryHttp.NewAudioHandler(audioService, log),
+		deliveryHttp.NewSuggestHandler(suggestService, log),
+		deliveryHttp.NewSearchHandler(searchService, log),
+		deliveryHttp.NewSimilarHandler(similarService, log),
+		deliveryHttp.NewRecentHandler(recentService, cfg.Feeds.Title, cfg.Feeds.BaseURL, log),
+		deliveryHttp.NewCalendarHandler(calendarService, cfg.Feeds.BaseURL, log),
+		deliveryHttp.NewRandomHandler(randomService, log),
+		deliveryHttp.NewStatsHandler(statsService, log),
+	)
+	internalRouters := []deliveryHttp.Router{
+		deliveryHttp.NewMetricsHandler(),
+		deliveryHttp.NewHealthHandler(healthChecks, log),
+		adminHandler,
+	}
+	var internalRoutes http.Handler
+	if cfg.Admin.Address == "" {
+		handler.Register(internalRouters...)
+	} else {
+		allowed, err := allowlist.Parse(cfg.Admin.AllowedIPs)
+		if err != nil {
+			log.Error("invalid admin allowed_ips", sl.Err(err))
+			os.Exit(1)
+		}
+		internalRoutes = deliveryHttp.InitInternalRoutes(log, []func(http.Handler) http.Handler{
+			allowlist.New(log, allowed),
+			bodylimit.New(log, cfg.HTTP.MaxBodySize, restorePath),
+			timeout.New(log, cfg.HTTP.RequestTimeout, nil, backupPath, restorePath, normalizePath, scanPath),
+		}, internalRouters...)
+	}
+	if cfg.HTTP.Compression.Enabled {
+		handler.Use(compress.New(log, cfg.HTTP.Compression.MinSize, cfg.HTTP.Compression.Level))
+	}
+	handler.Use(apiMiddlewares(cfg, libraryService, apiKeyService, log)...)
+	if cfg.RBAC.Enabled {
+		handler.Use(role.New(log, userService, domain.Role(cfg.RBAC.DefaultRole), roleRules...))
+	}
+
+	switch {
+	case cfg.RateLimit.Enabled && *dev:
+		log.Info("rate limiting is not available in dev mode")
+	case cfg.RateLimit.Enabled:
+		limiter := ratelimit.NewRedisLimiter(client, cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+		handler.Use(ratelimit.New(log, limiter))
+		log.Info("rate limiting enabled",
+			slog.Float64("requests_per_second", cfg.RateLimit.RequestsPerSecond),
+			slog.Int("burst", cfg.RateLimit.Burst),
+		)
+	}
+
+	var responseCache *httpcache.RedisStore
+	switch {
+	case cfg.HTTP.ResponseCache.Enabled && *dev:
+		log.Info("response cache is not available in dev mode")
+	case cfg.HTTP.ResponseCache.Enabled:
+		responseCache = httpcache.NewRedisStore(client)
+		routes := make([]httpcache.Route, 0, len(cfg.HTTP.ResponseCache.Routes))
+		for _, route := range cfg.HTTP.ResponseCache.Routes {
+			routes = append(routes, httpcache.Route{Pattern: route.Pattern, TTL: route.TTL})
+		}
+		handler.Use(httpcache.New(log, responseCache, cfg.HTTP.ResponseCache.MaxSize, routes...))
+	}
+
+	// start background flusher of buffered plays
+	flusherDone := make(chan struct{})
+	go func() {
+		defer close(flusherDone)
+		playService.RunFlusher(ctx, cfg.Plays.FlushInterval)
+	}()
+
+	// start background flusher of songs written behind the cache
+	writeFlusherDone := make(chan struct{})
+	go func() {
+		defer close(writeFlusherDone)
+		if writeQueue != nil {
+			metrics.PublishFunc("write_behind", func() any { return repo.WriteBehindStats() })
+			writeFlusher.Run(ctx, cfg.Cache.WriteBehind.FlushInterval)
+		}
+	}()
+
+	// start probing the cache while it is unavailable
+	resilienceDone := make(chan struct{})
+	go func() {
+		defer close(resilienceDone)
+		if resilientCache != nil {
+			resilientCache.Run(ctx, cfg.Cache.Resilience.ProbeInterval)
+		}
+	}()
+
+	// start retrying songs added while MusicInfo was unavailable
+	pendingDone := make(chan struct{})
+	go func() {
+		defer close(pendingDone)
+		if pendingEnrichment != nil {
+			pendingEnrichment.Run(ctx)
+		}
+	}()
+
+	// start publishing song events stored in the outbox
+	metrics.PublishFunc("outbox", func() any { return outboxRelay.Stats() })
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		outboxRelay.Run(ctx, cfg.Outbox.PollInterval)
+	}()
+
+	// start webhook delivery of song events
+	webhookEvents, unsubscribe := bus.Subscribe()
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		defer unsubscribe()
+		webhookDispatcher.Run(ctx, webhookEvents)
+	}()
+
+	// start embedding songs for semantic search
+	embeddingEvents, unsubscribeEmbeddings := bus.Subscribe()
+	indexerDone := make(chan struct{})
+	go func() {
+		defer close(indexerDone)
+		defer unsubscribeEmbeddings()
+		if embeddingIndexer != nil {
+			embeddingIndexer.Run(ctx, embeddingEvents)
+		}
+	}()
+
+	// start invalidating cached responses on song events
+	responseCacheDone := make(chan struct{})
+	go func() {
+		defer close(responseCacheDone)
+		if responseCache != nil {
+			cacheEvents, unsubscribe := bus.Subscribe()
+			defer unsubscribe()
+			httpcache.InvalidateOn(ctx, log, responseCache, cacheEvents)
+		}
+	}()
+
+	// warm up the song cache without delaying the start
+	warmUpDone := make(chan struct{})
+	go func() {
+		defer close(warmUpDone)
+		if cfg.Cache.WarmUp {
+			cacheService.Rebuild(ctx)
+		}
+	}()
+
+	// start periodic jobs
+	jobs := newScheduler(log)
+	if cfg.Enrichment.Enabled {
+		jobs.every("enrichment", cfg.Enrichment.Interval, enrichmentService.Run)
+		metrics.PublishFunc("enrichment", func() any { return enrichmentService.Stats() })
+	}
+	if embeddingIndexer != nil {
+		jobs.every("embeddings", cfg.Embeddings.Interval, embeddingIndexer.Backfill)
+	}
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		jobs.run(ctx)
+	}()
+
+	// start applying enrichment results from the queue
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		if cfg.Enrichment.Consumer.Enabled {
+			subscription := subscribeEnrichment(cfg, log)
+			defer subscription.Close()
+
+			enrichmentConsumer := consumer.New(service, musicapi.NewDateParser(cfg.MusicInfo.ReleaseDateLayouts...), cfg.Enrichment.Consumer.DedupeWindow, log)
+			metrics.PublishFunc("enrichment_consumer", func() any { return enrichmentConsumer.Stats() })
+			enrichmentConsumer.Run(ctx, subscription)
+		}
+	}()
+
+	// start HTTP servers
+	serverDone := startServer(ctx, handler, internalRoutes, cfg, log)
+
+	// wait for graceful shutdown
+	<-ctx.Done()
+	log.Info("shutting down gracefully")
+
+	<-flusherDone
+	<-writeFlusherDone
+	<-resilienceDone
+	<-pendingDone
+	<-relayDone
+	<-dispatcherDone
+	<-indexerDone
+	<-responseCacheDone
+	<-warmUpDone
+	<-schedulerDone
+	<-consumerDone
+	<-serverDone
+}
+
+// apiMiddlewares returns the middlewares every route of the API goes through
+// in any mode, they limit the request and resolve who makes it
+func apiMiddlewares(cfg *config.Config, libraries library.Resolver, apiKeys apikey.Authenticator, log *slog.Logger) []func(http.Handler) http.Handler {
+	return []func(http.Handler) http.Handler{
+		bodylimit.New(log, cfg.HTTP.MaxBodySize, importPath, coverPath, audioPath, restorePath),
+		timeout.New(log, cfg.HTTP.RequestTimeout, routeTimeouts(cfg), eventsPath, audioPath, backupPath, restorePath, normalizePath, scanPath),
+		user.New(log),
+		library.New(log, libraries, cfg.Libraries.JWTSecret, cfg.Libraries.JWTClaim),
+		apikey.New(log, apiKeys),
+	}
+}
+
+// routeTimeouts reads the deadlines of the routes with their own timeout
+func routeTimeouts(cfg *config.Config) []timeout.Route {
+	routes := make([]timeout.Route, 0, len(cfg.HTTP.RouteTimeouts))
+	for _, route := range cfg.HTTP.RouteTimeouts {
+		routes = append(routes, timeout.Route{Pattern: route.Pattern, Timeout: route.Timeout})
+	}
+	return routes
+}
+
+// shareSecret returns the key share tokens are signed with, a random one
+// when none is configured
+func shareSecret(cfg *config.Config, log *slog.Logger) []byte {
+	if cfg.Sharing.Secret != "" {
+		return []byte(cfg.Sharing.Secret)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error("failed to generate sharing secret", sl.Err(err))
+		os.Exit(1)
+	}
+	log.Warn("sharing secret is not set, share links stop working on restart")
+	return secret
+}
+
+// lyricsSplit reads the split strategies of song texts
+func lyricsSplit(cfg *config.Config, log *slog.Logger) service.LyricsSplit {
+	split := service.LyricsSplit{
+		Default:       domain.SplitStrategy(cfg.Lyrics.Split),
+		Libraries:     make(map[uuid.UUID]domain.SplitStrategy, len(cfg.Lyrics.Libraries)),
+		LinesPerVerse: cfg.Lyrics.LinesPerVerse,
+	}
+	for library, strategy := range cfg.Lyrics.Libraries {
+		id, err := uuid.Parse(library)
+		if err != nil {
+			log.Error("invalid library id in lyrics libraries", slog.String("library_id", library), sl.Err(err))
+			os.Exit(1)
+		}
+		split.Libraries[id] = domain.SplitStrategy(strategy)
+	}
+	return split
+}
+
+// newContentFilter compiles the word list of the content filter, nil when it
+// is disabled
+func newContentFilter(cfg *config.Config, log *slog.Logger) *service.ContentFilter {
+	if !cfg.Content.Enabled {
+		return nil
+	}
+
+	filter, err := service.NewContentFilter(cfg.Content.Words)
 	if err != nil {
-		log.Error("unable to parse PostgreSQL connection config", sl.Err(err))
+		log.Error("invalid content filter words", sl.Err(err))
+		os.Exit(1)
+	}
+	log.Info("content filter enabled", slog.Int("words", len(cfg.Content.Words)))
+	return filter
+}
+
+// newEmbeddingIndexer enables semantic search of searchService and returns
+// the indexer keeping the embeddings up to date, nil when semantic search is
+// disabled
+func newEmbeddingIndexer(ctx context.Context, cfg *config.Config, db storage, searchService *service.SearchService, log *slog.Logger) *service.EmbeddingIndexer {
+	if !cfg.Embeddings.Enabled {
+		return nil
+	}
+
+	if err := db.EnsureEmbeddings(ctx, cfg.Embeddings.Dimensions); err != nil {
+		log.Error("failed to prepare embeddings, semantic search needs the pgvector extension", sl.Err(err))
 		os.Exit(1)
 	}
 
-	conn, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	embeddingRepo := repository.NewEmbeddingRepository(db, log)
+	client := embedding.NewClient(
+		cfg.Embeddings.URL, cfg.Embeddings.Model, cfg.Embeddings.APIKey,
+		cfg.Embeddings.Dimensions, cfg.Embeddings.Timeout, log,
+	)
+	searchService.Embedder = client
+	searchService.Vectors = embeddingRepo
+
+	log.Info("semantic search enabled", slog.String("model", cfg.Embeddings.Model), slog.Int("dimensions", cfg.Embeddings.Dimensions))
+	return service.NewEmbeddingIndexer(embeddingRepo, client, cfg.Embeddings.BatchSize, log)
+}
+
+// newBlobStorage creates the storage of song covers and audio
+func newBlobStorage(cfg *config.Config, log *slog.Logger) service.BlobStorage {
+	if cfg.Blob.Type == config.BlobS3 {
+		log.Info("storing blobs in S3",
+			slog.String("endpoint", cfg.Blob.S3.Endpoint),
+			slog.String("bucket", cfg.Blob.S3.Bucket),
+		)
+		return blob.NewS3(blob.S3Options{
+			Endpoint:  cfg.Blob.S3.Endpoint,
+			Region:    cfg.Blob.S3.Region,
+			Bucket:    cfg.Blob.S3.Bucket,
+			AccessKey: cfg.Blob.S3.AccessKey,
+			SecretKey: cfg.Blob.S3.SecretKey,
+		}, &http.Client{Timeout: cfg.Blob.S3.Timeout})
+	}
+
+	local, err := blob.NewLocal(cfg.Blob.Local.Path)
+	if err != nil {
+		log.Error("failed to create blob storage", sl.Err(err))
+		os.Exit(1)
+	}
+	log.Info("storing blobs on disk", slog.String("path", cfg.Blob.Local.Path))
+	return local
+}
+
+// connectBroker connects to the message broker song events are published
+// to. The returned function closes the connection.
+func connectBroker(cfg *config.Config, log *slog.Logger) (service.Broker, func()) {
+	log = log.With(slog.String("broker", cfg.Broker.Type), slog.String("topic", cfg.Broker.Topic))
+
+	if cfg.Broker.Type == config.BrokerKafka {
+		log.Info("publishing song events to Kafka", slog.String("url", cfg.Broker.URL))
+		kafka := broker.NewKafka(cfg.Broker.URL, cfg.Broker.Topic, &http.Client{Timeout: cfg.Broker.Timeout}, log)
+		return kafka, func() {}
+	}
+
+	log.Info("connecting to NATS", slog.String("url", cfg.Broker.URL))
+	nats, err := broker.NewNATS(cfg.Broker.URL, cfg.Broker.Topic, cfg.Broker.Timeout, log)
+	if err != nil {
+		log.Error("failed to connect to NATS", sl.Err(err))
+		os.Exit(1)
+	}
+	return nats, func() {
+		if err := nats.Close(); err != nil {
+			log.Error("failed to close NATS connection", sl.Err(err))
+		}
+	}
+}
+
+// subscribeEnrichment subscribes to the enrichment results published to NATS
+func subscribeEnrichment(cfg *config.Config, log *slog.Logger) *broker.NATSSubscription {
+	consumerCfg := cfg.Enrichment.Consumer
+	log.Info("subscribing to enrichment results",
+		slog.String("url", consumerCfg.URL),
+		slog.String("subject", consumerCfg.Subject),
+		slog.String("queue", consumerCfg.Queue),
+	)
+
+	subscription, err := broker.SubscribeNATS(consumerCfg.URL, consumerCfg.Subject, consumerCfg.Queue, consumerCfg.Timeout, log)
+	if err != nil {
+		log.Error("failed to subscribe to enrichment results", sl.Err(err))
+		os.Exit(1)
+	}
+	return subscription
+}
+
+// connectPostgres connects to the primary and the read replicas and applies
+// migrations. The returned function closes the pools.
+func connectPostgres(ctx context.Context, cfg *config.Config, log *slog.Logger) (*postgres.Postgres, func()) {
+	connString := postgresConnString(cfg, cfg.Postgres.Address)
+	log.Info("connecting to PostgreSQL", slog.String("address", cfg.Postgres.Address))
+
+	// slow queries of the primary and the replicas are logged by one tracer,
+	// it explains them on the primary
+	var tracer *postgres.SlowQueryTracer
+	if cfg.Postgres.SlowQuery.Enabled {
+		tracer = postgres.NewSlowQueryTracer(cfg.Postgres.SlowQuery.Threshold, cfg.Postgres.SlowQuery.Explain, cfg.Postgres.SlowQuery.ExplainTimeout, log)
+		metrics.PublishFunc("postgres_slow_queries", func() any { return tracer.Stats() })
+		log.Info("slow query logging enabled", slog.Duration("threshold", tracer.Threshold), slog.Bool("explain", tracer.Explain))
+	}
+
+	conn, err := newPostgresPool(ctx, cfg, connString, tracer)
 	if err != nil {
 		log.Error("unable to establish connection to PostgreSQL", sl.Err(err))
 		os.Exit(1)
 	}
-	defer conn.Close()
+	if err := waitFor(ctx, cfg.Startup, log, "PostgreSQL", conn.Ping); err != nil {
+		log.Error("unable to establish connection to PostgreSQL", sl.Err(err))
+		os.Exit(1)
+	}
+	pools := []*pgxpool.Pool{conn}
+	if tracer != nil {
+		tracer.DB = conn
+	}
 
-	log.Info("PostgreSQL connection established")
+	log.Info("PostgreSQL connection established",
+		slog.Int("max_conns", int(cfg.Postgres.MaxConns)),
+		slog.Int("min_conns", int(cfg.Postgres.MinConns)),
+	)
+	metrics.PublishPool("postgres_pool", conn)
+
+	// connect to read replicas, an unreachable replica is not fatal as
+	// reads fall back to the primary
+	replicas := make([]*pgxpool.Pool, 0, len(cfg.Postgres.Replicas))
+	for i, address := range cfg.Postgres.Replicas {
+		replica, err := newPostgresPool(ctx, cfg, postgresConnString(cfg, address), tracer)
+		if err != nil {
+			log.Error("unable to configure PostgreSQL replica", slog.String("address", address), sl.Err(err))
+			os.Exit(1)
+		}
+
+		replicas = append(replicas, replica)
+		pools = append(pools, replica)
+		metrics.PublishPool(fmt.Sprintf("postgres_replica_%d_pool", i), replica)
+		log.Info("PostgreSQL replica configured", slog.String("address", address))
+	}
 
 	// apply database migrations
 	applyMigrations(log, connString)
 
-	// connect to Redis
+	return postgres.NewPostgres(conn, replicas...), func() {
+		for _, pool := range pools {
+			pool.Close()
+		}
+	}
+}
+
+// connectRedis connects to Redis and checks the connection
+func connectRedis(ctx context.Context, cfg *config.Config, log *slog.Logger) *redis.Client {
 	client := redis.NewClient(&redis.Options{
 		Addr:     cfg.Redis.Address,
 		Password: cfg.Redis.Password,
 		DB:       cfg.Redis.DB,
 	})
 
-	pong, err := client.Ping(ctx).Result()
+	err := waitFor(ctx, cfg.Startup, log, "Redis", func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
 	if err != nil {
 		log.Error("unable to connect to Redis", sl.Err(err))
 		os.Exit(1)
 	}
-	log.Info("Redis connection established", slog.String("ping", pong))
+	log.Info("Redis connection established")
 
-	// create new music service API client
-	musicServiceAPI := musicapi.NewMusicInfo(cfg.MusicInfo.Address, log)
-	log.Info("music service address", slog.String("address", cfg.MusicInfo.Address))
+	return client
+}
 
-	// create repositories, services, and handlers
-	db := postgres.NewPostgres(conn)
-	cache := redi.NewRedis(client)
-	repo := repository.NewRepository(db, cache, log)
-	service := service.NewService(repo, musicServiceAPI, log)
-	handler := deliveryHttp.NewHandler(service, log)
+func postgresConnString(cfg *config.Config, address string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable",
+		cfg.Postgres.User,
+		cfg.Postgres.Password,
+		address,
+		cfg.Postgres.DBName,
+	)
+}
+
+// newPostgresPool creates a pool with the settings of the postgres config
+func newPostgresPool(ctx context.Context, cfg *config.Config, connString string, tracer *postgres.SlowQueryTracer) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection config: %w", err)
+	}
 
-	// start HTTP server
-	startServer(handler, cfg, log)
+	poolConfig.MaxConns = cfg.Postgres.MaxConns
+	poolConfig.MinConns = cfg.Postgres.MinConns
+	poolConfig.MaxConnLifetime = cfg.Postgres.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.Postgres.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.Postgres.HealthCheckPeriod
+	if tracer != nil {
+		poolConfig.ConnConfig.Tracer = tracer
+	}
+	postgres.CancelQueriesOnServer(&poolConfig.ConnConfig.Config)
 
-	// wait for graceful shutdown
-	<-ctx.Done()
-	log.Info("shutting down gracefully")
+	return pgxpool.NewWithConfig(ctx, poolConfig)
 }
 
 // applyMigrations applies database migrations
@@ -134,26 +879,6 @@ func applyMigrations(log *slog.Logger, connString string) {
 	log.Info("migrations applied successfully")
 }
 
-// startServer starts the HTTP server and swagger
-func startServer(handler *deliveryHttp.Handler, cfg *config.Config, log *slog.Logger) {
-	routes := handler.InitRoutes()
-	routes.Get("/swagger/*", httpSwagger.WrapHandler)
-	log.Info("swagger documentation available")
-
-	srv := &http.Server{
-		Addr:    cfg.HTTP.Address,
-		Handler: routes,
-	}
-
-	go func() {
-		log.Info("server started on address", slog.String("address", srv.Addr))
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Error("server error", sl.Err(err))
-			os.Exit(1)
-		}
-	}()
-}
-
 // gracefulShutdown handles the graceful shutdown process
 func gracefulShutdown(ctx context.Context, cancel context.CancelFunc, log *slog.Logger) {
 	signalChan := make(chan os.Signal, 1)
@@ -172,38 +897,3 @@ func gracefulShutdown(ctx context.Context, cancel context.CancelFunc, log *slog.
 		log.Info("shutdown complete")
 	}()
 }
-
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	default:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	}
-
-	return log
-}
-
-func setupPrettySlog() *slog.Logger {
-	opts := slogpretty.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
-	}
-
-	handler := opts.NewPrettyHandler(os.Stdout)
-
-	return slog.New(handler)
-}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/internal/delivery/http/middleware/role"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type viewers struct{}
+
+func (viewers) Get(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	return &domain.User{ID: id, Role: domain.RoleViewer}, nil
+}
+
+func TestRoleRules_Viewer(t *testing.T) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := role.New(log, viewers{}, domain.RoleEditor, roleRules...)(next)
+
+	songID := uuid.NewString()
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{name: "делится песней", method: http.MethodPost, target: "/songs/" + songID + "/share", want: http.StatusOK},
+		{name: "делится подборкой", method: http.MethodPost, target: "/smart-playlists/" + songID + "/share", want: http.StatusOK},
+		{name: "оценивает песню", method: http.MethodPut, target: "/songs/" + songID + "/rating", want: http.StatusOK},
+		{name: "не меняет песню", method: http.MethodPut, target: "/songs/" + songID, want: http.StatusForbidden},
+		{name: "не загружает обложку", method: http.MethodPut, target: "/songs/" + songID + "/cover", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithUserID(context.Background(), uuid.New())
+			req := httptest.NewRequest(tt.method, tt.target, nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"songLibrary/internal/backup"
+)
+
+// runBackupCommand writes a backup to backupFile or restores the one in
+// restoreFile, "-" reads it from stdin. Backups aren't written to stdout,
+// which the logs go to by default.
+func runBackupCommand(ctx context.Context, b *backup.Backup, backupFile, restoreFile string, dryRun bool, log *slog.Logger) error {
+	if backupFile != "" && restoreFile != "" {
+		return errors.New("-backup and -restore can't be used together")
+	}
+
+	if backupFile != "" {
+		out, err := os.Create(backupFile)
+		if err != nil {
+			return fmt.Errorf("failed to create backup file: %w", err)
+		}
+
+		if err := b.Write(ctx, out); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to write backup file: %w", err)
+		}
+
+		log.Info("backup written", slog.String("file", backupFile))
+		return nil
+	}
+
+	in := io.ReadCloser(os.Stdin)
+	if restoreFile != "-" {
+		file, err := os.Open(restoreFile)
+		if err != nil {
+			return fmt.Errorf("failed to open backup file: %w", err)
+		}
+		in = file
+	}
+	defer in.Close()
+
+	report, err := b.Restore(ctx, in, dryRun)
+	if err != nil {
+		return err
+	}
+
+	log.Info("backup restored",
+		slog.String("file", restoreFile),
+		slog.Bool("dry_run", report.DryRun),
+		slog.Any("tables", report.Tables),
+	)
+	return nil
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"songLibrary/internal/config"
+	"songLibrary/pkg/logger"
+	"songLibrary/pkg/logger/handlers/slogpretty"
+)
+
+// setupLogger builds the logger for env writing to the configured outputs.
+// The returned function closes log files.
+func setupLogger(env string, cfg config.LogConfig) (*slog.Logger, func(), error) {
+	out, closeOutputs, err := openLogOutputs(cfg.Outputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the base handler writes every record, levels are checked by the level
+	// handler so modules can be more verbose than the default
+	var handler slog.Handler
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if env == envLocal {
+		handler = slogpretty.PrettyHandlerOptions{SlogOpts: opts}.NewPrettyHandler(out)
+	} else {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+
+	level := envLevel(env)
+	if cfg.Level != "" {
+		// validated when the config is loaded
+		_ = level.UnmarshalText([]byte(cfg.Level))
+	}
+
+	modules := make(map[string]slog.Level, len(cfg.Modules))
+	for module, name := range cfg.Modules {
+		var moduleLevel slog.Level
+		_ = moduleLevel.UnmarshalText([]byte(name))
+		modules[module] = moduleLevel
+	}
+
+	handler = logger.NewLevelHandler(handler, level, modules)
+	if cfg.Sampling.Enabled {
+		handler = logger.NewSamplingHandler(handler, logger.SamplingOptions{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+			Tick:       cfg.Sampling.Tick,
+		})
+	}
+
+	return slog.New(handler), closeOutputs, nil
+}
+
+// envLevel returns the default log level of env
+func envLevel(env string) slog.Level {
+	switch env {
+	case envLocal, envDev:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// openLogOutputs opens the log destinations and returns a writer writing to
+// all of them and a function closing the files
+func openLogOutputs(outputs []config.LogOutputConfig) (io.Writer, func(), error) {
+	writers := make([]io.Writer, 0, len(outputs))
+	var files []io.Closer
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	for _, output := range outputs {
+		switch output.Type {
+		case config.LogStderr:
+			writers = append(writers, os.Stderr)
+		case config.LogFile:
+			f, err := logger.NewRotatingFile(output.Path, int64(output.MaxSizeMB)<<20, output.MaxBackups)
+			if err != nil {
+				closeFiles()
+				return nil, nil, err
+			}
+			writers = append(writers, f)
+			files = append(files, f)
+		default:
+			writers = append(writers, os.Stdout)
+		}
+	}
+
+	return io.MultiWriter(writers...), closeFiles, nil
+}
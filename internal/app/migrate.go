@@ -0,0 +1,96 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/config"
+	"songLibrary/pkg/migrator"
+	"strconv"
+)
+
+// ReadMigrationStatus reads the schema version of the primary database of cfg
+// without applying migrations
+func ReadMigrationStatus(cfg *config.Config) (*migrator.Status, error) {
+	const op = "app.ReadMigrationStatus"
+
+	sqlDB, err := sql.Open("postgres", postgresConnString(cfg, cfg.Postgres.Address))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer sqlDB.Close()
+
+	status, err := migrator.MustGetNewMigrator(MigrationsFS, migrationsDir).Status(sqlDB)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return status, nil
+}
+
+// runMigrateCommand runs the -migrate command on the primary database:
+// up, down N, status or version
+func runMigrateCommand(cfg *config.Config, command string, args []string, log *slog.Logger) error {
+	if command != "down" && len(args) > 0 {
+		return fmt.Errorf("-migrate %s takes no arguments", command)
+	}
+
+	switch command {
+	case "status":
+		status, err := ReadMigrationStatus(cfg)
+		if err != nil {
+			return err
+		}
+		log.Info("migration status",
+			slog.Uint64("version", uint64(status.Version)),
+			slog.Uint64("latest", uint64(status.Latest)),
+			slog.Bool("dirty", status.Dirty),
+			slog.Bool("pending", status.Pending()),
+		)
+		return nil
+	case "version":
+		status, err := ReadMigrationStatus(cfg)
+		if err != nil {
+			return err
+		}
+		log.Info("schema version", slog.Uint64("version", uint64(status.Version)), slog.Bool("dirty", status.Dirty))
+		return nil
+	case "up":
+		return migrate(cfg, func(migr *migrator.Migrator, db *sql.DB) error {
+			if err := migr.ApplyMigrations(db); err != nil {
+				return err
+			}
+			log.Info("migrations applied successfully")
+			return nil
+		})
+	case "down":
+		if len(args) != 1 {
+			return errors.New("-migrate down takes the number of migrations to roll back")
+		}
+		steps, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid number of migrations %q", args[0])
+		}
+		return migrate(cfg, func(migr *migrator.Migrator, db *sql.DB) error {
+			if err := migr.Down(db, steps); err != nil {
+				return err
+			}
+			log.Info("migrations rolled back", slog.Int("steps", steps))
+			return nil
+		})
+	default:
+		return fmt.Errorf("unknown -migrate command %q, use up, down N, status or version", command)
+	}
+}
+
+// migrate runs fn with a connection to the primary database
+func migrate(cfg *config.Config, fn func(migr *migrator.Migrator, db *sql.DB) error) error {
+	sqlDB, err := sql.Open("postgres", postgresConnString(cfg, cfg.Postgres.Address))
+	if err != nil {
+		return fmt.Errorf("unable to open SQL connection: %w", err)
+	}
+	defer sqlDB.Close()
+
+	return fn(migrator.MustGetNewMigrator(MigrationsFS, migrationsDir), sqlDB)
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_songs_name_group_trgm;
+DROP INDEX IF EXISTS idx_songs_name_group_unique;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_name_group_unique ON songs (lower(name), lower(group_name));
+
+CREATE INDEX IF NOT EXISTS idx_songs_name_group_trgm ON songs USING gin ((name || ' ' || group_name) gin_trgm_ops);
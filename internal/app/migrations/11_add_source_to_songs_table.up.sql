@@ -0,0 +1 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS source VARCHAR(64) NOT NULL DEFAULT '';
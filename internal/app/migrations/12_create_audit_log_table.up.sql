@@ -0,0 +1,12 @@
+-- song_id has no foreign key, the history of a deleted song is kept
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    song_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    user_id UUID,
+    old_value JSONB,
+    new_value JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_song_id ON audit_log (song_id, id);
@@ -0,0 +1,13 @@
+-- revision is the version of the song the row was copied from
+CREATE TABLE IF NOT EXISTS song_revisions (
+    song_id UUID NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    link TEXT,
+    release_date TIMESTAMP NOT NULL,
+    album_id UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (song_id, revision)
+);
@@ -0,0 +1,2 @@
+-- lyrics holds the text split into typed sections, text stays the source of truth
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS lyrics JSONB;
@@ -0,0 +1,2 @@
+-- locked_fields is a bit mask of the fields edited locally: 1 text, 2 link, 4 release date
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS locked_fields SMALLINT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- song_audio describes the audio file of a song, the file is kept in the blob storage
+CREATE TABLE IF NOT EXISTS song_audio (
+    song_id UUID PRIMARY KEY REFERENCES songs (id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    bitrate INTEGER NOT NULL,
+    sample_rate INTEGER NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_songs_genre;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS explicit;
+ALTER TABLE songs DROP COLUMN IF EXISTS album;
+ALTER TABLE songs DROP COLUMN IF EXISTS track_number;
+ALTER TABLE songs DROP COLUMN IF EXISTS genre;
+ALTER TABLE songs DROP COLUMN IF EXISTS duration_ms;
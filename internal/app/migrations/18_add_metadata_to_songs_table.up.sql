@@ -0,0 +1,9 @@
+-- details supplied by MusicInfo, explicit is NULL while it is unknown;
+-- locked_fields gains 8 duration, 16 genre, 32 track number, 64 album, 128 explicit
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS duration_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS genre VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS track_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS album VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS explicit BOOLEAN;
+
+CREATE INDEX IF NOT EXISTS idx_songs_genre ON songs (lower(genre));
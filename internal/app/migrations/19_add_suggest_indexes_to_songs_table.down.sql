@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_songs_group_suggest;
+DROP INDEX IF EXISTS idx_songs_name_suggest;
@@ -0,0 +1,4 @@
+-- trigram indexes of the lower-cased names serve both the prefix match
+-- (LIKE 'query%') and the similarity match (%) of search suggestions
+CREATE INDEX IF NOT EXISTS idx_songs_name_suggest ON songs USING gin (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_group_suggest ON songs USING gin (lower(group_name) gin_trgm_ops);
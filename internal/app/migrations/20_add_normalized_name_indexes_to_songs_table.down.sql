@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_songs_name_group_normalized_trgm;
+CREATE INDEX IF NOT EXISTS idx_songs_name_group_trgm ON songs USING gin ((name || ' ' || group_name) gin_trgm_ops);
+
+DROP INDEX IF EXISTS idx_songs_group_normalized_trgm;
+DROP INDEX IF EXISTS idx_songs_name_normalized_trgm;
+DROP INDEX IF EXISTS idx_songs_name_group_normalized;
+
+DROP FUNCTION IF EXISTS normalize_name(text);
+DROP EXTENSION IF EXISTS unaccent;
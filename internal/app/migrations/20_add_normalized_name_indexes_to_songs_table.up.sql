@@ -0,0 +1,19 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- normalize_name folds case and accents, so "muse" matches "Müse". unaccent()
+-- is only stable because its dictionary can be changed, naming the dictionary
+-- makes the wrapper immutable and usable in indexes
+CREATE OR REPLACE FUNCTION normalize_name(value text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT lower(public.unaccent('public.unaccent'::regdictionary, value)) $$;
+
+CREATE INDEX IF NOT EXISTS idx_songs_name_group_normalized ON songs (normalize_name(name), normalize_name(group_name));
+
+-- trigram indexes serve the substring filters of song listings
+CREATE INDEX IF NOT EXISTS idx_songs_name_normalized_trgm ON songs USING gin (normalize_name(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_group_normalized_trgm ON songs USING gin (normalize_name(group_name) gin_trgm_ops);
+
+-- duplicate detection compares the normalized name and group
+DROP INDEX IF EXISTS idx_songs_name_group_trgm;
+CREATE INDEX IF NOT EXISTS idx_songs_name_group_normalized_trgm ON songs
+    USING gin ((normalize_name(name) || ' ' || normalize_name(group_name)) gin_trgm_ops);
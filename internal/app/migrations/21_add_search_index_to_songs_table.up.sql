@@ -0,0 +1,7 @@
+-- full-text search weighs matches in the name most and in the lyrics least,
+-- the expression must stay the same as searchDocument of the repository
+CREATE INDEX IF NOT EXISTS idx_songs_search ON songs USING gin ((
+    setweight(to_tsvector('simple', name), 'A') ||
+    setweight(to_tsvector('simple', group_name), 'B') ||
+    setweight(to_tsvector('simple', coalesce(text, '')), 'D')
+));
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_songs_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_songs_created_at_id ON songs (created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_songs_name_group_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_name_group_unique ON songs (lower(name), lower(group_name));
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS library_id;
+ALTER TABLE songs DROP COLUMN IF EXISTS library_id;
+
+DROP TABLE IF EXISTS libraries;
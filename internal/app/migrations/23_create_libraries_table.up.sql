@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS libraries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_libraries_name_unique ON libraries (lower(name));
+
+-- songs saved before libraries existed belong to the default library
+INSERT INTO libraries (id, name) VALUES ('00000000-0000-0000-0000-000000000000', 'default')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS library_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES libraries (id);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS library_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000';
+
+-- name and group are unique within a library, listings are per library
+DROP INDEX IF EXISTS idx_songs_name_group_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_name_group_unique ON songs (library_id, lower(name), lower(group_name));
+DROP INDEX IF EXISTS idx_songs_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_songs_created_at_id ON songs (library_id, created_at DESC, id DESC);
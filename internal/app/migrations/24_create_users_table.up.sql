@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
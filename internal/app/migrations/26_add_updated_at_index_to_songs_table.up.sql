@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_songs_updated_at ON songs (library_id, updated_at DESC);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS smart_playlists (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    library_id UUID NOT NULL REFERENCES libraries (id),
+    name TEXT NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- playlists are listed per user within a library
+CREATE INDEX IF NOT EXISTS idx_smart_playlists_user_library ON smart_playlists (user_id, library_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_songs_status;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS status;
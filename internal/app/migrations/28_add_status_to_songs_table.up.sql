@@ -0,0 +1,8 @@
+-- lifecycle status of a song; songs saved without text were added while
+-- MusicInfo was unavailable and still wait for their details
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'enriched'
+    CHECK (status IN ('pending', 'enriched', 'failed', 'archived'));
+
+UPDATE songs SET status = 'pending' WHERE text = '' OR text IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_songs_status ON songs (library_id, status);
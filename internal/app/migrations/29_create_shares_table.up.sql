@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS shares (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    library_id UUID NOT NULL REFERENCES libraries (id),
+    song_id UUID REFERENCES songs (id) ON DELETE CASCADE,
+    playlist_id UUID REFERENCES smart_playlists (id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+    -- a share links either a song or a smart playlist
+    CHECK ((song_id IS NULL) <> (playlist_id IS NULL))
+);
+
+-- shares are listed per user within a library
+CREATE INDEX IF NOT EXISTS idx_shares_user_library ON shares (user_id, library_id);
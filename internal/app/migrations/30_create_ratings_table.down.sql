@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_songs_rating;
+ALTER TABLE songs DROP COLUMN IF EXISTS rating_average;
+ALTER TABLE songs DROP COLUMN IF EXISTS ratings_count;
+DROP TABLE IF EXISTS ratings;
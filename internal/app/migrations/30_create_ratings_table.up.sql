@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS ratings (
+    user_id UUID NOT NULL,
+    song_id UUID NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    rated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ratings_song_id ON ratings (song_id);
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS ratings_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS rating_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_songs_rating ON songs (rating_average, ratings_count);
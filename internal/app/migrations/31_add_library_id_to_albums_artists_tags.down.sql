@@ -0,0 +1,26 @@
+-- the copies of an artist or tag made for each library are merged back into
+-- the oldest one, copies of albums stay separate albums
+UPDATE songs SET artist_id = kept.id
+FROM artists, (SELECT DISTINCT ON (name) id, name FROM artists ORDER BY name, created_at, id) kept
+WHERE artists.id = songs.artist_id AND kept.name = artists.name AND kept.id <> artists.id;
+DELETE FROM artists
+WHERE id NOT IN (SELECT DISTINCT ON (name) id FROM artists ORDER BY name, created_at, id);
+
+INSERT INTO song_tags (song_id, tag_id)
+SELECT song_tags.song_id, kept.id
+FROM song_tags JOIN tags ON tags.id = song_tags.tag_id,
+     (SELECT DISTINCT ON (name) id, name FROM tags ORDER BY name, id) kept
+WHERE kept.name = tags.name AND kept.id <> tags.id
+ON CONFLICT DO NOTHING;
+DELETE FROM tags
+WHERE id NOT IN (SELECT DISTINCT ON (name) id FROM tags ORDER BY name, id);
+
+DROP INDEX IF EXISTS idx_albums_library_id;
+DROP INDEX IF EXISTS idx_tags_library_name_unique;
+ALTER TABLE tags ADD CONSTRAINT tags_name_key UNIQUE (name);
+DROP INDEX IF EXISTS idx_artists_library_name_unique;
+ALTER TABLE artists ADD CONSTRAINT artists_name_key UNIQUE (name);
+
+ALTER TABLE tags DROP COLUMN IF EXISTS library_id;
+ALTER TABLE artists DROP COLUMN IF EXISTS library_id;
+ALTER TABLE albums DROP COLUMN IF EXISTS library_id;
@@ -0,0 +1,76 @@
+ALTER TABLE albums ADD COLUMN IF NOT EXISTS library_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES libraries (id);
+ALTER TABLE artists ADD COLUMN IF NOT EXISTS library_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES libraries (id);
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS library_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES libraries (id);
+
+-- artist and tag names are unique within a library
+ALTER TABLE artists DROP CONSTRAINT IF EXISTS artists_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_library_name_unique ON artists (library_id, name);
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_library_name_unique ON tags (library_id, name);
+CREATE INDEX IF NOT EXISTS idx_albums_library_id ON albums (library_id);
+
+-- an album, artist or tag moves to the library of its songs, one shared by
+-- the songs of several libraries is copied into each further library
+UPDATE albums SET library_id = song.library_id
+FROM (SELECT DISTINCT ON (album_id) album_id, library_id FROM songs
+      WHERE album_id IS NOT NULL ORDER BY album_id, library_id) song
+WHERE albums.id = song.album_id;
+
+WITH copy AS (
+    SELECT album_id, library_id, gen_random_uuid() AS id
+    FROM (SELECT DISTINCT songs.album_id, songs.library_id
+          FROM songs JOIN albums ON albums.id = songs.album_id
+          WHERE songs.library_id <> albums.library_id) shared
+), inserted AS (
+    INSERT INTO albums (id, title, group_name, release_date, cover_link, created_at, updated_at, library_id)
+    SELECT copy.id, albums.title, albums.group_name, albums.release_date, albums.cover_link,
+           albums.created_at, albums.updated_at, copy.library_id
+    FROM copy JOIN albums ON albums.id = copy.album_id
+)
+UPDATE songs SET album_id = copy.id
+FROM copy
+WHERE songs.album_id = copy.album_id AND songs.library_id = copy.library_id;
+
+UPDATE artists SET library_id = song.library_id
+FROM (SELECT DISTINCT ON (artist_id) artist_id, library_id FROM songs
+      ORDER BY artist_id, library_id) song
+WHERE artists.id = song.artist_id;
+
+WITH copy AS (
+    SELECT artist_id, library_id, gen_random_uuid() AS id
+    FROM (SELECT DISTINCT songs.artist_id, songs.library_id
+          FROM songs JOIN artists ON artists.id = songs.artist_id
+          WHERE songs.library_id <> artists.library_id) shared
+), inserted AS (
+    INSERT INTO artists (id, name, created_at, updated_at, library_id)
+    SELECT copy.id, artists.name, artists.created_at, artists.updated_at, copy.library_id
+    FROM copy JOIN artists ON artists.id = copy.artist_id
+)
+UPDATE songs SET artist_id = copy.id
+FROM copy
+WHERE songs.artist_id = copy.artist_id AND songs.library_id = copy.library_id;
+
+UPDATE tags SET library_id = song.library_id
+FROM (SELECT DISTINCT ON (song_tags.tag_id) song_tags.tag_id, songs.library_id
+      FROM song_tags JOIN songs ON songs.id = song_tags.song_id
+      ORDER BY song_tags.tag_id, songs.library_id) song
+WHERE tags.id = song.tag_id;
+
+WITH copy AS (
+    SELECT tag_id, library_id, nextval(pg_get_serial_sequence('tags', 'id'))::integer AS id
+    FROM (SELECT DISTINCT song_tags.tag_id, songs.library_id
+          FROM song_tags
+          JOIN songs ON songs.id = song_tags.song_id
+          JOIN tags ON tags.id = song_tags.tag_id
+          WHERE songs.library_id <> tags.library_id) shared
+), inserted AS (
+    INSERT INTO tags (id, name, library_id)
+    SELECT copy.id, tags.name, copy.library_id
+    FROM copy JOIN tags ON tags.id = copy.tag_id
+)
+UPDATE song_tags SET tag_id = copy.id
+FROM copy, songs
+WHERE song_tags.tag_id = copy.tag_id AND songs.id = song_tags.song_id AND songs.library_id = copy.library_id;
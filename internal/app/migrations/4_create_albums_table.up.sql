@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS albums (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    release_date TIMESTAMP NOT NULL,
+    cover_link TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_albums_group_name ON albums (group_name);
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS album_id UUID REFERENCES albums (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_songs_album_id ON songs (album_id);
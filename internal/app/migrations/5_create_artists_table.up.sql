@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS artists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO artists (name)
+SELECT DISTINCT group_name FROM songs
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS artist_id UUID REFERENCES artists (id);
+
+UPDATE songs SET artist_id = artists.id
+FROM artists
+WHERE artists.name = songs.group_name AND songs.artist_id IS NULL;
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_songs_artist_id ON songs (artist_id);
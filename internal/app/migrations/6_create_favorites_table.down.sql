@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_songs_favorites_count;
+ALTER TABLE songs DROP COLUMN IF EXISTS favorites_count;
+DROP TABLE IF EXISTS favorites;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS favorites (
+    user_id UUID NOT NULL,
+    song_id UUID NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    favorited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorites_song_id ON favorites (song_id);
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS favorites_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_songs_favorites_count ON songs (favorites_count);
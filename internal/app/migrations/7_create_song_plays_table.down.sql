@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_song_plays_bucket;
+DROP TABLE IF EXISTS song_plays;
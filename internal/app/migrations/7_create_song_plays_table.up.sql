@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS song_plays (
+    song_id UUID NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    bucket TIMESTAMP NOT NULL,
+    plays INTEGER NOT NULL,
+    PRIMARY KEY (song_id, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_song_plays_bucket ON song_plays (bucket);
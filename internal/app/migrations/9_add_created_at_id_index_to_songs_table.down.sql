@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_songs_created_at_id;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_songs_created_at_id ON songs (created_at DESC, id DESC);
@@ -0,0 +1,12 @@
+// Package migrations embeds the migrations of the database schema, the
+// application applies them on start and the repository tests load the
+// schema from them
+package migrations
+
+import "embed"
+
+// Dir is the directory of FS holding the migrations
+const Dir = "."
+
+//go:embed *.sql
+var FS embed.FS
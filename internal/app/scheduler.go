@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// scheduledJob is a job run every interval
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context)
+}
+
+// scheduler runs background jobs periodically. A job never overlaps with
+// itself: the next run is due an interval after the previous one finished.
+type scheduler struct {
+	jobs []scheduledJob
+	log  *slog.Logger
+}
+
+func newScheduler(log *slog.Logger) *scheduler {
+	return &scheduler{log: log}
+}
+
+// every schedules job to run every interval, the first run is an interval
+// after the start
+func (s *scheduler) every(name string, interval time.Duration, job func(ctx context.Context)) {
+	s.jobs = append(s.jobs, scheduledJob{name: name, interval: interval, run: job})
+}
+
+// run starts the jobs and blocks until ctx is cancelled and the running
+// jobs returned
+func (s *scheduler) run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			log := s.log.With(slog.String("job", job.name), slog.Duration("interval", job.interval))
+			log.Info("scheduled job started")
+
+			timer := time.NewTimer(job.interval)
+			defer timer.Stop()
+
+			for {
+				select {
+				case <-timer.C:
+					job.run(ctx)
+					timer.Reset(job.interval)
+				case <-ctx.Done():
+					log.Info("scheduled job stopped")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"songLibrary/docs"
+	"songLibrary/internal/config"
+	deliveryHttp "songLibrary/internal/delivery/http"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"sync"
+	"time"
+
+	httpSwagger "github.com/swaggo/http-swagger"
+)
+
+// shutdownTimeout limits how long in-flight requests are waited for on
+// shutdown
+const shutdownTimeout = 5 * time.Second
+
+// startServer starts an HTTP server with swagger on every listener and, with
+// internal routes, a server of them on the admin address. The servers are
+// shut down when ctx is done, then the returned channel is closed.
+func startServer(ctx context.Context, handler *deliveryHttp.Handler, internalRoutes http.Handler, cfg *config.Config, log *slog.Logger) <-chan struct{} {
+	routes := handler.InitRoutes()
+	routes.Get("/swagger/*", httpSwagger.WrapHandler)
+	routes.Get("/openapi.json", serveOpenAPI)
+	log.Info("swagger documentation available")
+
+	var wg sync.WaitGroup
+	for _, listenerCfg := range cfg.HTTP.Listeners {
+		serve(ctx, &wg, listenerCfg, servePaths(routes, listenerCfg.Paths), log)
+	}
+	if internalRoutes != nil {
+		listenerCfg := config.ListenerConfig{Name: "admin", Network: config.ListenerTCP, Address: cfg.Admin.Address}
+		serve(ctx, &wg, listenerCfg, internalRoutes, log)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+// @Summary Get the API document
+// @Description Get the API description generated from the code, clients can be generated from it
+// @Tags docs
+// @Produce  json
+// @Success 200 {object} object "API document"
+// @Router /openapi.json [get]
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, docs.SwaggerInfo.ReadDoc())
+}
+
+// serve serves the handler on the listener until ctx is done
+func serve(ctx context.Context, wg *sync.WaitGroup, listenerCfg config.ListenerConfig, handler http.Handler, log *slog.Logger) {
+	log = log.With(
+		slog.String("listener", listenerCfg.Name),
+		slog.String("network", listenerCfg.Network),
+		slog.String("address", listenerCfg.Address),
+	)
+
+	listener, err := listen(listenerCfg)
+	if err != nil {
+		log.Error("failed to listen", sl.Err(err))
+		os.Exit(1)
+	}
+
+	srv := &http.Server{Handler: handler}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		log.Info("server started on address", slog.Any("paths", listenerCfg.Paths))
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error("server error", sl.Err(err))
+			os.Exit(1)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error("failed to shut down server", sl.Err(err))
+		}
+	}()
+}
+
+// listen opens the listener, a socket left by a previous run is replaced
+func listen(cfg config.ListenerConfig) (net.Listener, error) {
+	if cfg.Network != config.ListenerUnix {
+		return net.Listen(cfg.Network, cfg.Address)
+	}
+
+	if info, err := os.Lstat(cfg.Address); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", cfg.Address)
+		}
+		if err := os.Remove(cfg.Address); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	listener, err := net.Listen(cfg.Network, cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(cfg.Address, cfg.SocketMode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket mode: %w", err)
+	}
+	return listener, nil
+}
+
+// servePaths lets through only requests to the paths or under them, others
+// get 404. Without paths every request is let through.
+func servePaths(next http.Handler, paths []string) http.Handler {
+	if len(paths) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, path := range paths {
+			path = strings.TrimSuffix(path, "/")
+			if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+}
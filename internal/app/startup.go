@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/config"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// waitFor calls connect until it succeeds. With wait_for_deps the calls are
+// retried with a growing backoff until max_wait has passed, otherwise the
+// first error is returned.
+func waitFor(ctx context.Context, cfg config.StartupConfig, log *slog.Logger, name string, connect func(ctx context.Context) error) error {
+	if !cfg.WaitForDeps {
+		return connect(ctx)
+	}
+
+	log = log.With(slog.String("dependency", name))
+	deadline := time.Now().Add(cfg.MaxWait)
+	backoff := cfg.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			return fmt.Errorf("%s is not available after %s: %w", name, cfg.MaxWait, err)
+		}
+		log.Warn("dependency is not available yet, retrying",
+			slog.Int("attempt", attempt), slog.Duration("retry_in", wait), sl.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, cfg.MaxBackoff)
+	}
+}
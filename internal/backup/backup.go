@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// FormatVersion is the version of the layout of backup files:
+//
+//	{"format": 1, "schema_version": 19, "created_at": "...", "tables": {"songs": [{...}, ...], ...}}
+//
+// Rows are objects keyed by column. tables is the last key, so a backup can be
+// restored while it is read.
+const FormatVersion = 1
+
+// Database is the storage a backup is taken from and restored to
+type Database interface {
+	// SchemaVersion is the version of the last applied migration
+	SchemaVersion(ctx context.Context) (int, error)
+	// DumpTables calls row for every row of every table, all read from one
+	// snapshot and grouped by table
+	DumpTables(ctx context.Context, row func(row domain.BackupRow) error) error
+	// RestoreTables replaces the content of all tables with the rows returned
+	// by next until io.EOF, nothing is changed with dryRun
+	RestoreTables(ctx context.Context, next func() (*domain.BackupRow, error), dryRun bool) (map[string]int, error)
+}
+
+// Cache is emptied after a restore, so the songs it holds aren't served
+// instead of the restored ones
+type Cache interface {
+	Flush(ctx context.Context) (int64, error)
+}
+
+type Backup struct {
+	DB    Database
+	Cache Cache
+	// Now returns the time a backup is taken at
+	Now func() time.Time
+	log *slog.Logger
+}
+
+// New creates a Backup of db. A nil db, as in dev mode, makes every call fail
+// with domain.ErrBackupUnsupported.
+func New(db Database, cache Cache, log *slog.Logger) *Backup {
+	return &Backup{
+		DB:    db,
+		Cache: cache,
+		Now:   time.Now,
+		log:   log,
+	}
+}
+
+// header is the part of a backup file before its tables
+type header struct {
+	Format        int       `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Write streams a backup of all tables to w.
+func (b *Backup) Write(ctx context.Context, w io.Writer) error {
+	const op = "Backup.Write"
+
+	log := b.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	if b.DB == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrBackupUnsupported)
+	}
+
+	version, err := b.DB.SchemaVersion(ctx)
+	if err != nil {
+		log.Error("failed to read schema version", sl.Err(err))
+		return fmt.Errorf("%s: failed to read schema version: %w", op, err)
+	}
+
+	head, err := json.Marshal(header{Format: FormatVersion, SchemaVersion: version, CreatedAt: b.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	buf := bufio.NewWriter(w)
+	// the header is written without its closing brace, tables follow it
+	buf.Write(head[:len(head)-1])
+	buf.WriteString(`,"tables":{`)
+
+	var (
+		table string
+		rows  int
+	)
+	err = b.DB.DumpTables(ctx, func(row domain.BackupRow) error {
+		if row.Table != table {
+			if table != "" {
+				buf.WriteString("],")
+			}
+			name, _ := json.Marshal(row.Table)
+			buf.Write(name)
+			buf.WriteString(":[\n")
+			table = row.Table
+		} else {
+			buf.WriteString(",\n")
+		}
+		rows++
+		_, err := buf.Write(row.Data)
+		return err
+	})
+	if err != nil {
+		log.Error("failed to dump tables", sl.Err(err))
+		return fmt.Errorf("%s: failed to dump tables: %w", op, err)
+	}
+
+	if table != "" {
+		buf.WriteString("]")
+	}
+	buf.WriteString("}}\n")
+	if err := buf.Flush(); err != nil {
+		log.Error("failed to write backup", sl.Err(err))
+		return fmt.Errorf("%s: failed to write backup: %w", op, err)
+	}
+
+	log.Info("backup successfully written", slog.Int("schema_version", version), slog.Int("rows", rows))
+	return nil
+}
+
+// Restore replaces all tables with the backup read from r. The backup must
+// come from the same schema version as the database. With dryRun the backup is
+// validated, including by the database constraints, and nothing is changed.
+func (b *Backup) Restore(ctx context.Context, r io.Reader, dryRun bool) (*domain.BackupReport, error) {
+	const op = "Backup.Restore"
+
+	log := b.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Bool("dry_run", dryRun))
+
+	if b.DB == nil {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrBackupUnsupported)
+	}
+
+	version, err := b.DB.SchemaVersion(ctx)
+	if err != nil {
+		log.Error("failed to read schema version", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read schema version: %w", op, err)
+	}
+
+	dec := &decoder{dec: json.NewDecoder(r)}
+	head, err := dec.header()
+	if err != nil {
+		log.Warn("invalid backup header", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if head.Format != FormatVersion {
+		log.Warn("unsupported backup format", slog.Int("format", head.Format))
+		return nil, fmt.Errorf("%s: %w: unsupported format %d", op, domain.ErrBackupInvalid, head.Format)
+	}
+	if head.SchemaVersion != version {
+		log.Warn("backup schema version mismatch", slog.Int("backup", head.SchemaVersion), slog.Int("database", version))
+		return nil, fmt.Errorf("%s: %w: backup %d, database %d", op, domain.ErrBackupSchemaMismatch, head.SchemaVersion, version)
+	}
+
+	log.Info("attempting to restore backup", slog.Time("created_at", head.CreatedAt))
+	tables, err := b.DB.RestoreTables(ctx, dec.next, dryRun)
+	if err != nil {
+		if errors.Is(err, domain.ErrBackupInvalid) {
+			log.Warn("invalid backup", sl.Err(err))
+		} else {
+			log.Error("failed to restore backup", sl.Err(err))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !dryRun && b.Cache != nil {
+		if _, err := b.Cache.Flush(ctx); err != nil {
+			log.Error("failed to flush cache after restore", sl.Err(err))
+		}
+	}
+
+	log.Info("backup successfully restored", slog.Any("tables", tables))
+	return &domain.BackupReport{SchemaVersion: version, Tables: tables, DryRun: dryRun}, nil
+}
@@ -0,0 +1,175 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB keeps the rows of a backup in memory
+type fakeDB struct {
+	version  int
+	rows     []domain.BackupRow
+	restored []domain.BackupRow
+	dumpErr  error
+}
+
+func (db *fakeDB) SchemaVersion(_ context.Context) (int, error) {
+	return db.version, nil
+}
+
+func (db *fakeDB) DumpTables(_ context.Context, row func(row domain.BackupRow) error) error {
+	for _, r := range db.rows {
+		if err := row(r); err != nil {
+			return err
+		}
+	}
+	return db.dumpErr
+}
+
+func (db *fakeDB) RestoreTables(_ context.Context, next func() (*domain.BackupRow, error), dryRun bool) (map[string]int, error) {
+	var rows []domain.BackupRow
+	tables := make(map[string]int)
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, *row)
+		tables[row.Table]++
+	}
+	if !dryRun {
+		db.restored = rows
+	}
+	return tables, nil
+}
+
+type fakeCache struct {
+	flushed int
+}
+
+func (c *fakeCache) Flush(_ context.Context) (int64, error) {
+	c.flushed++
+	return 0, nil
+}
+
+func newTestBackup(db Database, cache Cache) *Backup {
+	b := New(db, cache, slog.New(slogdiscard.NewDiscardHandler()))
+	b.Now = func() time.Time { return time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC) }
+	return b
+}
+
+func testRows() []domain.BackupRow {
+	return []domain.BackupRow{
+		{Table: "artists", Data: json.RawMessage(`{"id":"a1","name":"Muse"}`)},
+		{Table: "songs", Data: json.RawMessage(`{"id":"s1","name":"Hysteria"}`)},
+		{Table: "songs", Data: json.RawMessage(`{"id":"s2","name":"Uprising"}`)},
+	}
+}
+
+func TestBackup_Write(t *testing.T) {
+	b := newTestBackup(&fakeDB{version: 19, rows: testRows()}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(context.Background(), &buf))
+
+	// Бэкап — обычный JSON с заголовком и строками по таблицам
+	var dump struct {
+		Format        int                          `json:"format"`
+		SchemaVersion int                          `json:"schema_version"`
+		CreatedAt     time.Time                    `json:"created_at"`
+		Tables        map[string][]json.RawMessage `json:"tables"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &dump))
+	assert.Equal(t, FormatVersion, dump.Format)
+	assert.Equal(t, 19, dump.SchemaVersion)
+	assert.Equal(t, time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), dump.CreatedAt)
+	assert.Len(t, dump.Tables["artists"], 1)
+	assert.Len(t, dump.Tables["songs"], 2)
+}
+
+func TestBackup_Write_Empty(t *testing.T) {
+	b := newTestBackup(&fakeDB{version: 19}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(context.Background(), &buf))
+	assert.True(t, json.Valid(buf.Bytes()), buf.String())
+}
+
+func TestBackup_RoundTrip(t *testing.T) {
+	source := &fakeDB{version: 19, rows: testRows()}
+	var buf bytes.Buffer
+	require.NoError(t, newTestBackup(source, nil).Write(context.Background(), &buf))
+
+	target := &fakeDB{version: 19}
+	cache := &fakeCache{}
+	b := newTestBackup(target, cache)
+
+	// Пробный прогон ничего не меняет и не сбрасывает кэш
+	report, err := b.Restore(context.Background(), bytes.NewReader(buf.Bytes()), true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, map[string]int{"artists": 1, "songs": 2}, report.Tables)
+	assert.Empty(t, target.restored)
+	assert.Equal(t, 0, cache.flushed)
+
+	report, err = b.Restore(context.Background(), bytes.NewReader(buf.Bytes()), false)
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, 19, report.SchemaVersion)
+	assert.Equal(t, testRows(), target.restored)
+	assert.Equal(t, 1, cache.flushed)
+}
+
+func TestBackup_Restore_SchemaMismatch(t *testing.T) {
+	b := newTestBackup(&fakeDB{version: 20}, nil)
+
+	_, err := b.Restore(context.Background(), strings.NewReader(`{"format":1,"schema_version":19,"tables":{}}`), false)
+	assert.ErrorIs(t, err, domain.ErrBackupSchemaMismatch)
+}
+
+func TestBackup_Restore_Invalid(t *testing.T) {
+	for name, input := range map[string]string{
+		"not json":        `backup`,
+		"empty":           ``,
+		"unknown format":  `{"format":2,"schema_version":19,"tables":{}}`,
+		"unknown key":     `{"format":1,"schema_version":19,"comment":"x","tables":{}}`,
+		"tables first":    `{"tables":{},"format":1,"schema_version":19}`,
+		"no tables":       `{"format":1,"schema_version":19}`,
+		"row not object":  `{"format":1,"schema_version":19,"tables":{"songs":[1]}}`,
+		"table not array": `{"format":1,"schema_version":19,"tables":{"songs":{}}}`,
+		"truncated":       `{"format":1,"schema_version":19,"tables":{"songs":[{"id":"s1"},`,
+		"trailing data":   `{"format":1,"schema_version":19,"tables":{}} {}`,
+	} {
+		db := &fakeDB{version: 19}
+		b := newTestBackup(db, nil)
+
+		_, err := b.Restore(context.Background(), strings.NewReader(input), false)
+		assert.ErrorIs(t, err, domain.ErrBackupInvalid, name)
+		assert.Empty(t, db.restored, name)
+	}
+}
+
+func TestBackup_Unsupported(t *testing.T) {
+	b := newTestBackup(nil, nil)
+
+	assert.ErrorIs(t, b.Write(context.Background(), io.Discard), domain.ErrBackupUnsupported)
+
+	_, err := b.Restore(context.Background(), strings.NewReader(`{}`), true)
+	assert.ErrorIs(t, err, domain.ErrBackupUnsupported)
+}
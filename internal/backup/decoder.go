@@ -0,0 +1,146 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"songLibrary/internal/domain"
+)
+
+// decoder reads a backup file token by token, so rows are restored without
+// loading the whole file into memory
+type decoder struct {
+	dec *json.Decoder
+	// table is the table whose rows are being read, empty between tables
+	table   string
+	started bool
+}
+
+func invalid(err error) error {
+	return fmt.Errorf("%w: %w", domain.ErrBackupInvalid, err)
+}
+
+// header reads the keys of a backup up to its tables
+func (d *decoder) header() (*header, error) {
+	if err := d.delim('{'); err != nil {
+		return nil, err
+	}
+
+	var (
+		head           header
+		format, schema bool
+	)
+	for d.dec.More() {
+		key, err := d.key()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "format":
+			format = true
+			err = d.dec.Decode(&head.Format)
+		case "schema_version":
+			schema = true
+			err = d.dec.Decode(&head.SchemaVersion)
+		case "created_at":
+			err = d.dec.Decode(&head.CreatedAt)
+		case "tables":
+			if !format || !schema {
+				return nil, invalid(errors.New("format and schema_version must precede tables"))
+			}
+			return &head, nil
+		default:
+			return nil, invalid(fmt.Errorf("unknown key %q", key))
+		}
+		if err != nil {
+			return nil, invalid(fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return nil, invalid(errors.New("tables are missing"))
+}
+
+// next returns the next row of the tables, io.EOF once the whole file is read
+func (d *decoder) next() (*domain.BackupRow, error) {
+	if !d.started {
+		if err := d.delim('{'); err != nil {
+			return nil, err
+		}
+		d.started = true
+	}
+
+	for {
+		if d.table != "" {
+			if d.dec.More() {
+				var data json.RawMessage
+				if err := d.dec.Decode(&data); err != nil {
+					return nil, invalid(fmt.Errorf("%s: %w", d.table, err))
+				}
+				if len(data) == 0 || data[0] != '{' {
+					return nil, invalid(fmt.Errorf("%s: row is not an object", d.table))
+				}
+				return &domain.BackupRow{Table: d.table, Data: data}, nil
+			}
+			if err := d.delim(']'); err != nil {
+				return nil, err
+			}
+			d.table = ""
+		}
+
+		if !d.dec.More() {
+			return nil, d.end()
+		}
+
+		table, err := d.key()
+		if err != nil {
+			return nil, err
+		}
+		if err := d.delim('['); err != nil {
+			return nil, err
+		}
+		d.table = table
+	}
+}
+
+// end reads the closing braces of the tables and the file and checks nothing
+// follows them
+func (d *decoder) end() error {
+	if err := d.delim('}'); err != nil {
+		return err
+	}
+	if err := d.delim('}'); err != nil {
+		return err
+	}
+	if _, err := d.dec.Token(); !errors.Is(err, io.EOF) {
+		return invalid(errors.New("unexpected data after the backup"))
+	}
+	return io.EOF
+}
+
+func (d *decoder) key() (string, error) {
+	token, err := d.dec.Token()
+	if err != nil {
+		return "", invalid(err)
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", invalid(fmt.Errorf("expected a key, got %v", token))
+	}
+	return key, nil
+}
+
+func (d *decoder) delim(want json.Delim) error {
+	token, err := d.dec.Token()
+	if errors.Is(err, io.EOF) {
+		return invalid(io.ErrUnexpectedEOF)
+	}
+	if err != nil {
+		return invalid(err)
+	}
+	if token != want {
+		return invalid(fmt.Errorf("expected %v, got %v", want, token))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at
+const sniffLen = 512
+
+// Local stores blobs as files under a root directory. The content type is
+// not stored but detected from the content when a blob is read.
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &Local{root: root}, nil
+}
+
+// Put writes the blob to a temporary file and moves it in place, so readers
+// never see a partly written blob
+func (l *Local) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	const op = "blob.Local.Put"
+
+	path, err := l.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%s: failed to create directory: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: failed to create file: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: failed to write file: %w", op, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: failed to write file: %w", op, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: failed to move file: %w", op, err)
+	}
+	return nil
+}
+
+func (l *Local) Get(_ context.Context, key string) (*domain.Blob, error) {
+	const op = "blob.Local.Get"
+
+	path, err := l.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrBlobNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to open file: %w", op, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: failed to stat file: %w", op, err)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		f.Close()
+		return nil, fmt.Errorf("%s: failed to read file: %w", op, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: failed to read file: %w", op, err)
+	}
+
+	return &domain.Blob{
+		Body:        f,
+		ContentType: http.DetectContentType(head[:n]),
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// GetRange returns length bytes of a blob starting at offset
+func (l *Local) GetRange(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	const op = "blob.Local.GetRange"
+
+	path, err := l.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrBlobNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to open file: %w", op, err)
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: failed to seek file: %w", op, err)
+	}
+
+	return readCloser{Reader: io.LimitReader(f, length), Closer: f}, nil
+}
+
+// readCloser closes the source of a reader wrapping it
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// path returns the file of a key, keys can't point outside of the root
+func (l *Local) path(key string) (string, error) {
+	path := filepath.Join(l.root, filepath.FromSlash(key))
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return path, nil
+}
@@ -0,0 +1,73 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"songLibrary/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_PutGet(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	content := buf.Bytes()
+
+	ctx := context.Background()
+	assert.NoError(t, local.Put(ctx, "covers/1", bytes.NewReader(content), int64(len(content)), "image/png"))
+
+	blob, err := local.Get(ctx, "covers/1")
+	assert.NoError(t, err)
+	defer blob.Body.Close()
+
+	// Тип определяется по содержимому, тело читается с начала
+	assert.Equal(t, "image/png", blob.ContentType)
+	assert.Equal(t, int64(len(content)), blob.Size)
+	body, err := io.ReadAll(blob.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, content, body)
+}
+
+func TestLocal_NotFound(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	_, err = local.Get(context.Background(), "covers/1")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+func TestLocal_InvalidKey(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	err = local.Put(context.Background(), "../outside", strings.NewReader("x"), 1, "text/plain")
+	assert.Error(t, err)
+}
+
+func TestLocal_GetRange(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, local.Put(ctx, "audio/1", strings.NewReader("0123456789"), 10, "audio/mpeg"))
+
+	body, err := local.GetRange(ctx, "audio/1", 3, 4)
+	assert.NoError(t, err)
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "3456", string(data))
+
+	_, err = local.GetRange(ctx, "audio/2", 0, 1)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
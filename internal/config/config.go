@@ -2,44 +2,506 @@ package config
 
 import (
 	"log"
+	"log/slog"
 	"os"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
+// Types of MusicInfo providers
+const (
+	MusicInfoHTTP = "http"
+	MusicInfoMock = "mock"
+)
+
+// Networks HTTP listeners listen on
+const (
+	ListenerTCP  = "tcp"
+	ListenerUnix = "unix"
+)
+
+// Types of blob storages
+const (
+	BlobLocal = "local"
+	BlobS3    = "s3"
+)
+
+// Types of message brokers
+const (
+	BrokerKafka = "kafka"
+	BrokerNATS  = "nats"
+)
+
+// Types of log outputs
+const (
+	LogStdout = "stdout"
+	LogStderr = "stderr"
+	LogFile   = "file"
+)
+
 type (
 	Config struct {
-		Env       string          `yaml:"env" env-default:"local"`
-		Postgres  PostgresConfig  `yaml:"postgres"`
-		Redis     RedisConfig     `yaml:"redis"`
-		HTTP      HTTPConfig      `yaml:"http"`
-		MusicInfo MusicInfoConfig `yaml:"music_info"`
+		Env        string           `yaml:"env" env-default:"local"`
+		Postgres   PostgresConfig   `yaml:"postgres"`
+		Redis      RedisConfig      `yaml:"redis"`
+		HTTP       HTTPConfig       `yaml:"http"`
+		MusicInfo  MusicInfoConfig  `yaml:"music_info"`
+		RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+		Plays      PlaysConfig      `yaml:"plays"`
+		Webhooks   WebhooksConfig   `yaml:"webhooks"`
+		Outbox     OutboxConfig     `yaml:"outbox"`
+		Broker     BrokerConfig     `yaml:"broker"`
+		Cache      CacheConfig      `yaml:"cache"`
+		Admin      AdminConfig      `yaml:"admin"`
+		Libraries  LibrariesConfig  `yaml:"libraries"`
+		RBAC       RBACConfig       `yaml:"rbac"`
+		Enrichment EnrichmentConfig `yaml:"enrichment"`
+		Log        LogConfig        `yaml:"log"`
+		Blob       BlobConfig       `yaml:"blob"`
+		Covers     CoversConfig     `yaml:"covers"`
+		Audio      AudioConfig      `yaml:"audio"`
+		Suggest    SuggestConfig    `yaml:"suggest"`
+		Search     SearchConfig     `yaml:"search"`
+		Embeddings EmbeddingsConfig `yaml:"embeddings"`
+		Feeds      FeedsConfig      `yaml:"feeds"`
+		Sharing    SharingConfig    `yaml:"sharing"`
+		Lyrics     LyricsConfig     `yaml:"lyrics"`
+		Content    ContentConfig    `yaml:"content_filter"`
+		Stats      StatsConfig      `yaml:"stats"`
+		Backup     BackupConfig     `yaml:"backup"`
+		Seed       SeedConfig       `yaml:"seed"`
+		Startup    StartupConfig    `yaml:"startup"`
 	}
 
+	// PostgresConfig and RedisConfig are required unless the application
+	// runs in dev mode with in-memory storage
 	PostgresConfig struct {
-		Address  string `yaml:"address" env-required:"true"`
-		User     string `yaml:"user" env-required:"true"`
-		Password string `yaml:"password" env-required:"true" env:"POSTGRES_PASSWORD"`
-		DBName   string `yaml:"dbname" env-required:"true"`
+		Address  string `yaml:"address"`
+		User     string `yaml:"user"`
+		Password string `yaml:"password" env:"POSTGRES_PASSWORD"`
+		DBName   string `yaml:"dbname"`
+
+		// Pool settings applied to pgxpool.Config
+		MaxConns          int32         `yaml:"max_conns" env-default:"20"`
+		MinConns          int32         `yaml:"min_conns" env-default:"2"`
+		MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env-default:"1h"`
+		MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env-default:"30m"`
+		HealthCheckPeriod time.Duration `yaml:"health_check_period" env-default:"1m"`
+
+		// Replicas are addresses of read replicas sharing the credentials
+		// and database name of the primary
+		Replicas []string `yaml:"replicas"`
+
+		SlowQuery SlowQueryConfig `yaml:"slow_query"`
+	}
+
+	// SlowQueryConfig logs queries taking at least Threshold, with Explain
+	// their plans are logged too, each fetched within ExplainTimeout
+	SlowQueryConfig struct {
+		Enabled        bool          `yaml:"enabled" env-default:"false"`
+		Threshold      time.Duration `yaml:"threshold" env-default:"500ms"`
+		Explain        bool          `yaml:"explain" env-default:"false"`
+		ExplainTimeout time.Duration `yaml:"explain_timeout" env-default:"5s"`
 	}
 
 	RedisConfig struct {
-		Address  string `yaml:"address" env-required:"true"`
-		Password string `yaml:"password" env-required:"true" env:"REDIS_PASSWORD"`
+		Address  string `yaml:"address"`
+		Password string `yaml:"password" env:"REDIS_PASSWORD"`
 		DB       int    `yaml:"db" env-default:"0"`
 	}
 
+	// HTTPConfig sets where the API is served. Address is a TCP address
+	// serving every route, Listeners add more addresses or Unix sockets, each
+	// serving every route or only the routes under its paths.
 	HTTPConfig struct {
-		Address string `yaml:"address" env-required:"true"`
+		Address       string              `yaml:"address"`
+		Listeners     []ListenerConfig    `yaml:"listeners"`
+		Compression   CompressionConfig   `yaml:"compression"`
+		ResponseCache ResponseCacheConfig `yaml:"response_cache"`
+
+		// MaxBodySize limits request bodies in bytes, RequestTimeout limits
+		// the handling of a request unless one of RouteTimeouts matches its
+		// path. CSV import and the event stream have their own limits.
+		MaxBodySize    int64                `yaml:"max_body_size" env-default:"1048576"`
+		RequestTimeout time.Duration        `yaml:"request_timeout" env-default:"30s"`
+		RouteTimeouts  []RouteTimeoutConfig `yaml:"route_timeouts"`
+	}
+
+	// RouteTimeoutConfig limits the handling of requests to paths matching
+	// Pattern, as path.Match sees it, to Timeout. The first route matching a
+	// path wins.
+	RouteTimeoutConfig struct {
+		Pattern string        `yaml:"pattern"`
+		Timeout time.Duration `yaml:"timeout"`
+	}
+
+	// ListenerConfig is an address the API is served on. Network is tcp or
+	// unix, for unix the address is the path of the socket, created with
+	// SocketMode permissions. Paths are the route prefixes served, e.g.
+	// "/admin", all routes are served without them.
+	ListenerConfig struct {
+		Name       string      `yaml:"name"`
+		Network    string      `yaml:"network"`
+		Address    string      `yaml:"address"`
+		SocketMode os.FileMode `yaml:"socket_mode"`
+		Paths      []string    `yaml:"paths"`
+	}
+
+	// CompressionConfig controls gzip and deflate compression of responses
+	// of at least MinSize bytes, Level is from 1 (fastest) to 9 (smallest)
+	CompressionConfig struct {
+		Enabled bool `yaml:"enabled" env-default:"true"`
+		MinSize int  `yaml:"min_size" env-default:"1024"`
+		Level   int  `yaml:"level" env-default:"5"`
+	}
+
+	// ResponseCacheConfig caches successful responses to GET requests of the
+	// routes in Redis, responses larger than MaxSize bytes aren't cached
+	ResponseCacheConfig struct {
+		Enabled bool                       `yaml:"enabled" env-default:"false"`
+		MaxSize int                        `yaml:"max_size" env-default:"262144"`
+		Routes  []ResponseCacheRouteConfig `yaml:"routes"`
+	}
+
+	// ResponseCacheRouteConfig sets how long responses to paths matching
+	// Pattern, as path.Match sees it, are cached, zero TTL leaves them
+	// uncached. The first route matching a path wins.
+	ResponseCacheRouteConfig struct {
+		Pattern string        `yaml:"pattern"`
+		TTL     time.Duration `yaml:"ttl"`
 	}
 
+	// MusicInfoConfig lists the providers of song details in the order they
+	// are asked. Without providers a single http provider at Address is used.
 	MusicInfoConfig struct {
-		Address string `yaml:"address" env-required:"true"`
+		Address   string                    `yaml:"address"`
+		Providers []MusicInfoProviderConfig `yaml:"providers"`
+		Cache     MusicInfoCacheConfig      `yaml:"cache"`
+
+		// ConnectTimeout and RequestTimeout limit a single call to an http
+		// provider, FetchTimeout limits the whole chain when a song is added
+		ConnectTimeout time.Duration `yaml:"connect_timeout" env-default:"2s"`
+		RequestTimeout time.Duration `yaml:"request_timeout" env-default:"5s"`
+		FetchTimeout   time.Duration `yaml:"fetch_timeout" env-default:"10s"`
+		MaxIdleConns   int           `yaml:"max_idle_conns" env-default:"10"`
+
+		// ReleaseDateLayouts are the Go time layouts release dates of http
+		// providers are parsed with, tried in order
+		ReleaseDateLayouts []string `yaml:"release_date_layouts"`
+
+		Pending MusicInfoPendingConfig `yaml:"pending"`
+	}
+
+	// MusicInfoPendingConfig lets songs be added without details while the
+	// providers time out or fail with 5xx, the details are fetched again
+	// after RetryBackoff, doubled after each failure, up to MaxAttempts times
+	MusicInfoPendingConfig struct {
+		Enabled      bool          `yaml:"enabled" env-default:"false"`
+		RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"30s"`
+		MaxAttempts  int           `yaml:"max_attempts" env-default:"5"`
+	}
+
+	// MusicInfoCacheConfig controls caching of provider responses in Redis
+	MusicInfoCacheConfig struct {
+		Enabled bool          `yaml:"enabled" env-default:"false"`
+		TTL     time.Duration `yaml:"ttl" env-default:"24h"`
+	}
+
+	MusicInfoProviderConfig struct {
+		Name    string `yaml:"name"`
+		Type    string `yaml:"type"`
+		Address string `yaml:"address"`
+	}
+
+	RateLimitConfig struct {
+		Enabled           bool    `yaml:"enabled" env-default:"false"`
+		RequestsPerSecond float64 `yaml:"requests_per_second" env-default:"10"`
+		Burst             int     `yaml:"burst" env-default:"20"`
+	}
+
+	PlaysConfig struct {
+		FlushInterval  time.Duration `yaml:"flush_interval" env-default:"10s"`
+		TrendingWindow time.Duration `yaml:"trending_window" env-default:"168h"`
+		TrendingLimit  int           `yaml:"trending_limit" env-default:"10"`
+	}
+
+	WebhooksConfig struct {
+		Timeout      time.Duration `yaml:"timeout" env-default:"5s"`
+		MaxAttempts  int           `yaml:"max_attempts" env-default:"5"`
+		RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"1s"`
+	}
+
+	// OutboxConfig sets how often the events stored with song changes are
+	// published and how many are read at once
+	OutboxConfig struct {
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"500ms"`
+		BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	}
+
+	// BrokerConfig selects the message broker song events are published to
+	// for other services, none when Type is empty. URL is the address of the
+	// NATS server or of the Kafka REST Proxy, Topic the subject or topic.
+	BrokerConfig struct {
+		Type    string        `yaml:"type"`
+		URL     string        `yaml:"url" env:"BROKER_URL"`
+		Topic   string        `yaml:"topic" env-default:"songs"`
+		Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	}
+
+	// AdminConfig holds the token required by /admin routes, they are
+	// disabled when it is empty. With Address set /admin, /metrics and
+	// /healthz are served only there, to clients from AllowedIPs, and the
+	// token isn't required.
+	AdminConfig struct {
+		Token      string   `yaml:"token" env:"ADMIN_TOKEN"`
+		Address    string   `yaml:"address" env:"ADMIN_ADDRESS"`
+		AllowedIPs []string `yaml:"allowed_ips" env-default:"127.0.0.1,::1"`
+	}
+
+	// LibrariesConfig controls how requests are scoped to a library. With a
+	// JWT secret the library is taken from JWTClaim of the bearer token,
+	// otherwise from the X-Library-ID header set by the gateway.
+	LibrariesConfig struct {
+		JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET"`
+		JWTClaim  string `yaml:"jwt_claim" env-default:"library_id"`
+	}
+
+	// RBACConfig enables roles: viewers read, editors change songs, albums
+	// and artists, admins also manage the service. Users get DefaultRole
+	// unless an admin assigned them another one.
+	RBACConfig struct {
+		Enabled     bool   `yaml:"enabled"`
+		DefaultRole string `yaml:"default_role" env-default:"viewer"`
+	}
+
+	// EnrichmentConfig controls the periodic refresh of songs without text
+	// or not updated for StaleAfter from MusicInfo
+	EnrichmentConfig struct {
+		Enabled           bool                     `yaml:"enabled" env-default:"false"`
+		Interval          time.Duration            `yaml:"interval" env-default:"24h"`
+		StaleAfter        time.Duration            `yaml:"stale_after" env-default:"720h"`
+		BatchSize         int                      `yaml:"batch_size" env-default:"100"`
+		RequestsPerSecond float64                  `yaml:"requests_per_second" env-default:"2"`
+		Consumer          EnrichmentConsumerConfig `yaml:"consumer"`
+	}
+
+	// EnrichmentConsumerConfig controls the consumer of enrichment results
+	// published by workers to Subject of the NATS server at URL. Instances in
+	// one Queue share the results, a result repeated for a song within
+	// DedupeWindow is skipped.
+	EnrichmentConsumerConfig struct {
+		Enabled      bool          `yaml:"enabled" env-default:"false"`
+		URL          string        `yaml:"url" env:"ENRICHMENT_CONSUMER_URL"`
+		Subject      string        `yaml:"subject" env-default:"songs.enrichment"`
+		Queue        string        `yaml:"queue" env-default:"songLibrary"`
+		DedupeWindow time.Duration `yaml:"dedupe_window" env-default:"10m"`
+		Timeout      time.Duration `yaml:"timeout" env-default:"5s"`
+	}
+
+	// LogConfig controls which logs are written and where. Level overrides
+	// the level implied by Env, Modules set levels of single modules named
+	// by the op or component of their loggers, e.g. "AlbumService" or
+	// "middleware/ratelimit". Logs go to stdout when no outputs are set.
+	LogConfig struct {
+		Level    string            `yaml:"level"`
+		Modules  map[string]string `yaml:"modules"`
+		Outputs  []LogOutputConfig `yaml:"outputs"`
+		Sampling LogSamplingConfig `yaml:"sampling"`
+	}
+
+	// LogOutputConfig is a log destination. A file is rotated when it grows
+	// over MaxSizeMB megabytes, MaxBackups rotated files are kept.
+	LogOutputConfig struct {
+		Type       string `yaml:"type"`
+		Path       string `yaml:"path"`
+		MaxSizeMB  int    `yaml:"max_size_mb"`
+		MaxBackups int    `yaml:"max_backups"`
+	}
+
+	// LogSamplingConfig limits repeating Debug records: in every Tick the
+	// first Initial records with the same message are written and after
+	// them every Thereafter-th one
+	LogSamplingConfig struct {
+		Enabled    bool          `yaml:"enabled" env-default:"false"`
+		Initial    int           `yaml:"initial" env-default:"100"`
+		Thereafter int           `yaml:"thereafter" env-default:"100"`
+		Tick       time.Duration `yaml:"tick" env-default:"1s"`
+	}
+
+	// BlobConfig selects where files such as song covers and audio are
+	// stored: in a local directory or in a bucket of an S3-compatible storage
+	BlobConfig struct {
+		Type  string          `yaml:"type" env-default:"local"`
+		Local LocalBlobConfig `yaml:"local"`
+		S3    S3BlobConfig    `yaml:"s3"`
+	}
+
+	LocalBlobConfig struct {
+		Path string `yaml:"path" env-default:"./data/blobs"`
+	}
+
+	S3BlobConfig struct {
+		Endpoint  string        `yaml:"endpoint"`
+		Region    string        `yaml:"region" env-default:"us-east-1"`
+		Bucket    string        `yaml:"bucket"`
+		AccessKey string        `yaml:"access_key" env:"S3_ACCESS_KEY"`
+		SecretKey string        `yaml:"secret_key" env:"S3_SECRET_KEY"`
+		Timeout   time.Duration `yaml:"timeout" env-default:"30s"`
+	}
+
+	// CoversConfig limits the size of song covers in bytes
+	CoversConfig struct {
+		MaxSize int64 `yaml:"max_size" env-default:"5242880"`
+	}
+
+	// AudioConfig limits the size of song audio files in bytes
+	AudioConfig struct {
+		MaxSize int64 `yaml:"max_size" env-default:"52428800"`
+	}
+
+	// SuggestConfig controls search suggestions: Limit is the number of
+	// suggestions returned by default, MaxLimit the most a request can ask
+	// for. Results are cached for CacheTTL, zero disables caching.
+	SuggestConfig struct {
+		Limit    int           `yaml:"limit" env-default:"10"`
+		MaxLimit int           `yaml:"max_limit" env-default:"50"`
+		CacheTTL time.Duration `yaml:"cache_ttl" env-default:"1m"`
+	}
+
+	// SearchConfig controls full-text search: Snippets is the number of
+	// highlighted lyrics snippets returned per song by default, MaxSnippets
+	// the most a request can ask for.
+	SearchConfig struct {
+		Snippets    int `yaml:"snippets" env-default:"3"`
+		MaxSnippets int `yaml:"max_snippets" env-default:"10"`
+	}
+
+	// EmbeddingsConfig enables semantic search with embeddings of Dimensions
+	// returned for Model by the OpenAI-compatible embeddings API at URL. The
+	// database needs the pgvector extension. Songs are embedded as they are
+	// saved, songs left without embeddings are embedded every Interval in
+	// batches of BatchSize.
+	EmbeddingsConfig struct {
+		Enabled    bool          `yaml:"enabled" env-default:"false"`
+		URL        string        `yaml:"url" env:"EMBEDDINGS_URL"`
+		Model      string        `yaml:"model"`
+		APIKey     string        `yaml:"api_key" env:"EMBEDDINGS_API_KEY"`
+		Dimensions int           `yaml:"dimensions"`
+		Timeout    time.Duration `yaml:"timeout" env-default:"10s"`
+		BatchSize  int           `yaml:"batch_size" env-default:"50"`
+		Interval   time.Duration `yaml:"interval" env-default:"5m"`
+	}
+
+	// FeedsConfig sets the title of the feed of recent songs and the address
+	// links of feeds, calendar exports and share links start with, e.g.
+	// "https://songs.example.com". Without it links point to the address a
+	// feed was requested at.
+	FeedsConfig struct {
+		Title   string `yaml:"title" env-default:"songLibrary"`
+		BaseURL string `yaml:"base_url" env:"FEEDS_BASE_URL"`
+	}
+
+	// SharingConfig signs the tokens of share links with Secret. Links stay
+	// valid for DefaultTTL unless the request asks for at most MaxTTL. Without
+	// a secret one is generated on start and links stop working on restart.
+	SharingConfig struct {
+		Secret     string        `yaml:"secret" env:"SHARING_SECRET"`
+		DefaultTTL time.Duration `yaml:"default_ttl" env-default:"168h"`
+		MaxTTL     time.Duration `yaml:"max_ttl" env-default:"2160h"`
+	}
+
+	// LyricsConfig sets how song texts are split into sections when a request
+	// doesn't choose: Split by default, Libraries by library ID. Verses of the
+	// lines strategy have LinesPerVerse lines.
+	LyricsConfig struct {
+		Split         string            `yaml:"split" env-default:"blank_lines"`
+		LinesPerVerse int               `yaml:"lines_per_verse" env-default:"4"`
+		Libraries     map[string]string `yaml:"libraries"`
+	}
+
+	// ContentConfig flags the songs whose texts contain a word or phrase of
+	// Words explicit when they are added, updated or imported. A trailing *
+	// matches any ending of a word.
+	ContentConfig struct {
+		Enabled bool     `yaml:"enabled" env:"CONTENT_FILTER_ENABLED"`
+		Words   []string `yaml:"words" env:"CONTENT_FILTER_WORDS"`
+	}
+
+	// StatsConfig controls the library statistics: the songs added are
+	// counted for each of the last Days days and Weeks weeks. Statistics are
+	// cached for CacheTTL, zero disables caching.
+	StatsConfig struct {
+		Days     int           `yaml:"days" env-default:"30"`
+		Weeks    int           `yaml:"weeks" env-default:"12"`
+		CacheTTL time.Duration `yaml:"cache_ttl" env-default:"1m"`
+	}
+
+	// BackupConfig limits the size of a backup restored over HTTP in bytes
+	BackupConfig struct {
+		MaxRestoreSize int64 `yaml:"max_restore_size" env-default:"268435456"`
+	}
+
+	// SeedConfig loads songs into an empty library on startup. File is a
+	// JSON or CSV file, the built-in sample is loaded when it is empty.
+	SeedConfig struct {
+		Enabled bool   `yaml:"enabled"`
+		File    string `yaml:"file"`
+	}
+
+	// StartupConfig makes startup wait for PostgreSQL and Redis, e.g. when
+	// they are started together with the application. Connecting is retried
+	// with a backoff doubling from InitialBackoff up to MaxBackoff until
+	// MaxWait has passed, without WaitForDeps it is tried once.
+	StartupConfig struct {
+		WaitForDeps    bool          `yaml:"wait_for_deps" env:"WAIT_FOR_DEPS"`
+		MaxWait        time.Duration `yaml:"max_wait" env-default:"1m"`
+		InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"500ms"`
+		MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"5s"`
+	}
+
+	// CacheConfig controls copying songs from the database to the cache.
+	// A limit of zero copies every song.
+	CacheConfig struct {
+		WarmUp    bool `yaml:"warm_up" env-default:"false"`
+		BatchSize int  `yaml:"batch_size" env-default:"500"`
+		Limit     int  `yaml:"limit" env-default:"10000"`
+
+		// SongTTL limits how long a song stays in Redis, zero keeps it until
+		// it is invalidated. EarlyRefresh is the beta of the probabilistic
+		// refresh of songs about to expire, zero disables it
+		SongTTL      time.Duration `yaml:"song_ttl" env-default:"0s"`
+		EarlyRefresh float64       `yaml:"early_refresh" env-default:"1"`
+
+		WriteBehind WriteBehindConfig `yaml:"write_behind"`
+		Resilience  ResilienceConfig  `yaml:"resilience"`
+	}
+
+	// WriteBehindConfig makes song creates and updates return once the song
+	// is cached and queued in Redis, the queue is flushed to PostgreSQL every
+	// FlushInterval in batches of BatchSize
+	WriteBehindConfig struct {
+		Enabled       bool          `yaml:"enabled" env-default:"false"`
+		FlushInterval time.Duration `yaml:"flush_interval" env-default:"1s"`
+		BatchSize     int           `yaml:"batch_size" env-default:"100"`
+	}
+
+	// ResilienceConfig keeps songs served from PostgreSQL while Redis is
+	// unavailable: failed cache operations are logged and counted instead
+	// of failing the request, and Redis is probed every ProbeInterval until
+	// it can be used again
+	ResilienceConfig struct {
+		Enabled       bool          `yaml:"enabled" env-default:"false"`
+		ProbeInterval time.Duration `yaml:"probe_interval" env-default:"5s"`
 	}
 )
 
-func MustLoad() *Config {
+// MustLoad reads the config from CONFIG_PATH. In dev mode the PostgreSQL and
+// Redis settings may be omitted.
+func MustLoad(dev bool) *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: No .env file found")
 	}
@@ -60,5 +522,288 @@ func MustLoad() *Config {
 		log.Fatalf("cannot read config: %s", err)
 	}
 
+	if !dev {
+		if cfg.Postgres.Address == "" || cfg.Postgres.User == "" || cfg.Postgres.Password == "" || cfg.Postgres.DBName == "" {
+			log.Fatal("postgres: address, user, password and dbname are required")
+		}
+
+		if cfg.Redis.Address == "" || cfg.Redis.Password == "" {
+			log.Fatal("redis: address and password are required")
+		}
+	}
+
+	if cfg.Postgres.MaxConns <= 0 || cfg.Postgres.MinConns < 0 || cfg.Postgres.MinConns > cfg.Postgres.MaxConns {
+		log.Fatal("postgres: max_conns must be positive and min_conns between 0 and max_conns")
+	}
+
+	if cfg.Postgres.MaxConnLifetime <= 0 || cfg.Postgres.MaxConnIdleTime <= 0 || cfg.Postgres.HealthCheckPeriod <= 0 {
+		log.Fatal("postgres: max_conn_lifetime, max_conn_idle_time and health_check_period must be positive")
+	}
+
+	if cfg.Startup.MaxWait <= 0 || cfg.Startup.InitialBackoff <= 0 || cfg.Startup.MaxBackoff < cfg.Startup.InitialBackoff {
+		log.Fatal("startup: max_wait and initial_backoff must be positive and max_backoff at least initial_backoff")
+	}
+
+	validateListeners(&cfg.HTTP)
+
+	for _, listener := range cfg.HTTP.Listeners {
+		if cfg.Admin.Address != "" && listener.Address == cfg.Admin.Address {
+			log.Fatalf("admin: address %s is already used by http listener %s", cfg.Admin.Address, listener.Name)
+		}
+	}
+
+	if cfg.HTTP.MaxBodySize <= 0 || cfg.HTTP.RequestTimeout <= 0 {
+		log.Fatal("http: max_body_size and request_timeout must be positive")
+	}
+
+	for _, route := range cfg.HTTP.RouteTimeouts {
+		if _, err := path.Match(route.Pattern, ""); err != nil || route.Pattern == "" || route.Timeout <= 0 {
+			log.Fatalf("http: route_timeouts: invalid pattern %q or non-positive timeout", route.Pattern)
+		}
+	}
+
+	if cfg.HTTP.Compression.Enabled && (cfg.HTTP.Compression.MinSize < 0 || cfg.HTTP.Compression.Level < 1 || cfg.HTTP.Compression.Level > 9) {
+		log.Fatal("http: compression min_size must not be negative and level must be between 1 and 9")
+	}
+
+	if cfg.RateLimit.Enabled && (cfg.RateLimit.RequestsPerSecond <= 0 || cfg.RateLimit.Burst <= 0) {
+		log.Fatal("rate_limit: requests_per_second and burst must be positive")
+	}
+
+	if cfg.Plays.FlushInterval <= 0 || cfg.Plays.TrendingWindow <= 0 || cfg.Plays.TrendingLimit <= 0 {
+		log.Fatal("plays: flush_interval, trending_window and trending_limit must be positive")
+	}
+
+	if cfg.MusicInfo.Pending.Enabled && (cfg.MusicInfo.Pending.RetryBackoff <= 0 || cfg.MusicInfo.Pending.MaxAttempts <= 0) {
+		log.Fatal("music_info.pending: retry_backoff and max_attempts must be positive")
+	}
+
+	if cfg.Webhooks.Timeout <= 0 || cfg.Webhooks.MaxAttempts <= 0 || cfg.Webhooks.RetryBackoff <= 0 {
+		log.Fatal("webhooks: timeout, max_attempts and retry_backoff must be positive")
+	}
+
+	if cfg.Outbox.PollInterval <= 0 || cfg.Outbox.BatchSize <= 0 {
+		log.Fatal("outbox: poll_interval and batch_size must be positive")
+	}
+
+	switch cfg.Broker.Type {
+	case "":
+	case BrokerKafka, BrokerNATS:
+		if cfg.Broker.URL == "" || cfg.Broker.Topic == "" || cfg.Broker.Timeout <= 0 {
+			log.Fatal("broker: url, topic and a positive timeout are required")
+		}
+	default:
+		log.Fatalf("broker: unknown broker type %q", cfg.Broker.Type)
+	}
+
+	if cfg.Cache.BatchSize <= 0 || cfg.Cache.Limit < 0 {
+		log.Fatal("cache: batch_size must be positive and limit must not be negative")
+	}
+
+	if cfg.Cache.SongTTL < 0 || cfg.Cache.EarlyRefresh < 0 {
+		log.Fatal("cache: song_ttl and early_refresh must not be negative")
+	}
+
+	if cfg.Cache.WriteBehind.Enabled && (cfg.Cache.WriteBehind.FlushInterval <= 0 || cfg.Cache.WriteBehind.BatchSize <= 0) {
+		log.Fatal("cache.write_behind: flush_interval and batch_size must be positive")
+	}
+
+	if cfg.Cache.Resilience.Enabled && cfg.Cache.Resilience.ProbeInterval <= 0 {
+		log.Fatal("cache.resilience: probe_interval must be positive")
+	}
+
+	if cfg.Cache.Resilience.Enabled && cfg.Cache.WriteBehind.Enabled {
+		log.Fatal("cache: resilience can't be used with write_behind, which keeps writes in Redis")
+	}
+
+	if cfg.Enrichment.Enabled && (cfg.Enrichment.Interval <= 0 || cfg.Enrichment.StaleAfter <= 0 || cfg.Enrichment.BatchSize <= 0 || cfg.Enrichment.RequestsPerSecond <= 0) {
+		log.Fatal("enrichment: interval, stale_after, batch_size and requests_per_second must be positive")
+	}
+
+	if consumer := cfg.Enrichment.Consumer; consumer.Enabled && (consumer.URL == "" || consumer.Subject == "" || consumer.Queue == "" || consumer.DedupeWindow < 0 || consumer.Timeout <= 0) {
+		log.Fatal("enrichment: consumer url, subject, queue and a positive timeout are required, dedupe_window must not be negative")
+	}
+
+	validateLog(&cfg.Log)
+
+	switch cfg.Blob.Type {
+	case BlobLocal:
+		if cfg.Blob.Local.Path == "" {
+			log.Fatal("blob: local path is required")
+		}
+	case BlobS3:
+		s3 := cfg.Blob.S3
+		if s3.Endpoint == "" || s3.Bucket == "" || s3.AccessKey == "" || s3.SecretKey == "" || s3.Timeout <= 0 {
+			log.Fatal("blob: s3 endpoint, bucket, access_key, secret_key and a positive timeout are required")
+		}
+	default:
+		log.Fatalf("blob: unknown storage type %q", cfg.Blob.Type)
+	}
+
+	if cfg.Covers.MaxSize <= 0 {
+		log.Fatal("covers: max_size must be positive")
+	}
+
+	if cfg.Audio.MaxSize <= 0 {
+		log.Fatal("audio: max_size must be positive")
+	}
+
+	if cfg.Search.Snippets < 0 || cfg.Search.MaxSnippets < cfg.Search.Snippets {
+		log.Fatal("search: snippets must not be negative and max_snippets at least snippets")
+	}
+
+	if cfg.Sharing.DefaultTTL <= 0 || cfg.Sharing.MaxTTL < cfg.Sharing.DefaultTTL {
+		log.Fatal("sharing: default_ttl must be positive and max_ttl at least default_ttl")
+	}
+
+	if cfg.Lyrics.LinesPerVerse <= 0 {
+		log.Fatal("lyrics: lines_per_verse must be positive")
+	}
+	validateSplit("split", cfg.Lyrics.Split)
+	for library, split := range cfg.Lyrics.Libraries {
+		validateSplit("split of library "+library, split)
+	}
+
+	if cfg.Content.Enabled && len(cfg.Content.Words) == 0 {
+		log.Fatal("content_filter: words must not be empty when the filter is enabled")
+	}
+
+	if e := cfg.Embeddings; e.Enabled && (e.URL == "" || e.Model == "" || e.Dimensions <= 0 || e.Timeout <= 0 || e.BatchSize <= 0 || e.Interval <= 0) {
+		log.Fatal("embeddings: url, model and positive dimensions, timeout, batch_size and interval are required when enabled")
+	}
+
+	if cfg.Suggest.Limit <= 0 || cfg.Suggest.MaxLimit < cfg.Suggest.Limit || cfg.Suggest.CacheTTL < 0 {
+		log.Fatal("suggest: limit must be positive, max_limit at least limit and cache_ttl not negative")
+	}
+
+	if cfg.Stats.Days <= 0 || cfg.Stats.Weeks <= 0 || cfg.Stats.CacheTTL < 0 {
+		log.Fatal("stats: days and weeks must be positive and cache_ttl not negative")
+	}
+
+	// the default role applies to anonymous requests, so it can't be admin
+	if cfg.RBAC.Enabled && cfg.RBAC.DefaultRole != "viewer" && cfg.RBAC.DefaultRole != "editor" {
+		log.Fatal("rbac: default_role must be viewer or editor")
+	}
+
+	if cfg.Backup.MaxRestoreSize <= 0 {
+		log.Fatal("backup: max_restore_size must be positive")
+	}
+
+	if cfg.MusicInfo.ConnectTimeout <= 0 || cfg.MusicInfo.RequestTimeout <= 0 || cfg.MusicInfo.FetchTimeout <= 0 || cfg.MusicInfo.MaxIdleConns <= 0 {
+		log.Fatal("music_info: connect_timeout, request_timeout, fetch_timeout and max_idle_conns must be positive")
+	}
+
+	if cfg.MusicInfo.Cache.Enabled && cfg.MusicInfo.Cache.TTL <= 0 {
+		log.Fatal("music_info: cache ttl must be positive")
+	}
+
+	if len(cfg.MusicInfo.Providers) == 0 {
+		if cfg.MusicInfo.Address == "" {
+			log.Fatal("music_info: address or providers must be set")
+		}
+		cfg.MusicInfo.Providers = []MusicInfoProviderConfig{
+			{Name: "music_info", Type: MusicInfoHTTP, Address: cfg.MusicInfo.Address},
+		}
+	}
+
+	for i := range cfg.MusicInfo.Providers {
+		provider := &cfg.MusicInfo.Providers[i]
+		if provider.Type == "" {
+			provider.Type = MusicInfoHTTP
+		}
+		if provider.Name == "" {
+			log.Fatalf("music_info: provider %d has no name", i)
+		}
+		switch provider.Type {
+		case MusicInfoHTTP:
+			if provider.Address == "" {
+				log.Fatalf("music_info: provider %s has no address", provider.Name)
+			}
+		case MusicInfoMock:
+		default:
+			log.Fatalf("music_info: provider %s has unknown type %s", provider.Name, provider.Type)
+		}
+	}
+
 	return &cfg
 }
+
+// validateListeners checks the HTTP listeners and adds the one at the
+// address to them
+func validateListeners(cfg *HTTPConfig) {
+	if cfg.Address != "" {
+		cfg.Listeners = append([]ListenerConfig{{Name: "http", Network: ListenerTCP, Address: cfg.Address}}, cfg.Listeners...)
+	}
+	if len(cfg.Listeners) == 0 {
+		log.Fatal("http: address or listeners must be set")
+	}
+
+	for i := range cfg.Listeners {
+		listener := &cfg.Listeners[i]
+		if listener.Network == "" {
+			listener.Network = ListenerTCP
+		}
+		if listener.Name == "" {
+			listener.Name = listener.Network + ":" + listener.Address
+		}
+		if listener.Address == "" {
+			log.Fatalf("http: listener %d has no address", i)
+		}
+		switch listener.Network {
+		case ListenerTCP:
+		case ListenerUnix:
+			if listener.SocketMode == 0 {
+				listener.SocketMode = 0o660
+			}
+		default:
+			log.Fatalf("http: listener %s has unknown network %s", listener.Name, listener.Network)
+		}
+		for _, path := range listener.Paths {
+			if !strings.HasPrefix(path, "/") {
+				log.Fatalf("http: listener %s path %s must start with /", listener.Name, path)
+			}
+		}
+	}
+}
+
+func validateSplit(name, split string) {
+	switch split {
+	case "blank_lines", "markers", "lines":
+	default:
+		log.Fatalf("lyrics: unknown %s %s, must be blank_lines, markers or lines", name, split)
+	}
+}
+
+// validateLog checks the log levels and outputs and sets the default output
+func validateLog(cfg *LogConfig) {
+	var level slog.Level
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			log.Fatalf("log: invalid level %q", cfg.Level)
+		}
+	}
+	for module, moduleLevel := range cfg.Modules {
+		if err := level.UnmarshalText([]byte(moduleLevel)); err != nil {
+			log.Fatalf("log: invalid level %q of module %s", moduleLevel, module)
+		}
+	}
+
+	if len(cfg.Outputs) == 0 {
+		cfg.Outputs = []LogOutputConfig{{Type: LogStdout}}
+	}
+	for i, output := range cfg.Outputs {
+		switch output.Type {
+		case LogStdout, LogStderr:
+		case LogFile:
+			if output.Path == "" || output.MaxSizeMB < 0 || output.MaxBackups < 0 {
+				log.Fatalf("log: file output %d needs a path and non-negative max_size_mb and max_backups", i)
+			}
+		default:
+			log.Fatalf("log: unknown output type %q", output.Type)
+		}
+	}
+
+	if cfg.Sampling.Enabled && (cfg.Sampling.Initial < 0 || cfg.Sampling.Thereafter < 0 || cfg.Sampling.Tick <= 0) {
+		log.Fatal("log: sampling initial and thereafter must not be negative and tick must be positive")
+	}
+}
@@ -0,0 +1,228 @@
+package consumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	musicapi "songLibrary/internal/delivery/music_info"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxConflictRetries limits how often a result is applied again when the
+	// song was updated concurrently
+	maxConflictRetries = 3
+	// receiveRetryDelay is the pause after the source failed to deliver
+	receiveRetryDelay = time.Second
+)
+
+// Source delivers the messages of a queue topic
+type Source interface {
+	Receive(ctx context.Context) ([]byte, error)
+}
+
+// SongEnricher applies enrichment results to songs, it is satisfied by
+// service.Service
+type SongEnricher interface {
+	Enrich(ctx context.Context, song *domain.SongInfo, details *domain.Song) (*domain.Song, domain.SongFields, error)
+}
+
+// Result is an enrichment result: the details of the song SongID in the
+// schema of the MusicInfo API, supplied by Source. A result without LibraryID
+// applies to a song of the default library.
+type Result struct {
+	SongID    uuid.UUID `json:"song_id"`
+	LibraryID uuid.UUID `json:"library_id"`
+	Source    string    `json:"source"`
+	musicapi.SongResponse
+}
+
+// Consumer applies enrichment results received from a queue. Invalid results
+// are dropped, a result repeating the last one applied to its song within
+// DedupeWindow is skipped, so redelivered messages don't update songs twice.
+type Consumer struct {
+	Songs        SongEnricher
+	Dates        *musicapi.DateParser
+	DedupeWindow time.Duration
+
+	mu       sync.Mutex
+	seen     map[uuid.UUID]appliedResult
+	prunedAt time.Time
+	now      func() time.Time
+
+	applied    atomic.Int64
+	unchanged  atomic.Int64
+	duplicates atomic.Int64
+	invalid    atomic.Int64
+	failed     atomic.Int64
+
+	log *slog.Logger
+}
+
+// appliedResult is the digest of the last result applied to a song
+type appliedResult struct {
+	digest [sha256.Size]byte
+	at     time.Time
+}
+
+func New(songs SongEnricher, dates *musicapi.DateParser, dedupeWindow time.Duration, log *slog.Logger) *Consumer {
+	return &Consumer{
+		Songs:        songs,
+		Dates:        dates,
+		DedupeWindow: dedupeWindow,
+		seen:         make(map[uuid.UUID]appliedResult),
+		now:          time.Now,
+		log:          log,
+	}
+}
+
+// Run applies the results delivered by src until ctx is cancelled
+func (c *Consumer) Run(ctx context.Context, src Source) {
+	const op = "consumer.Consumer.Run"
+
+	log := c.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Info("enrichment consumer started")
+	for {
+		data, err := src.Receive(ctx)
+		if ctx.Err() != nil {
+			log.Info("enrichment consumer stopped")
+			return
+		}
+		if err != nil {
+			log.Error("failed to receive enrichment result", sl.Err(err))
+			select {
+			case <-time.After(receiveRetryDelay):
+			case <-ctx.Done():
+			}
+			continue
+		}
+
+		if err := c.Handle(ctx, data); err != nil {
+			log.Warn("enrichment result not applied", sl.Err(err))
+		}
+	}
+}
+
+// Handle validates one result and applies it to its song
+func (c *Consumer) Handle(ctx context.Context, data []byte) error {
+	const op = "consumer.Consumer.Handle"
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		c.invalid.Add(1)
+		return fmt.Errorf("%s: %w: %w", op, domain.ErrMusicInfoMalformed, err)
+	}
+	if result.SongID == uuid.Nil {
+		c.invalid.Add(1)
+		return fmt.Errorf("%s: %w", op, domain.ErrInvalidSongID)
+	}
+
+	details, err := musicapi.ConvertResponseToSong(&result.SongResponse, c.Dates)
+	if err != nil {
+		c.invalid.Add(1)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	details.Source = result.Source
+
+	log := c.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", result.SongID.String()),
+		slog.String("library_id", result.LibraryID.String()),
+		slog.String("source", result.Source),
+	)
+
+	digest := sha256.Sum256(data)
+	if c.isDuplicate(result.SongID, digest) {
+		log.Debug("skipping enrichment result applied before")
+		c.duplicates.Add(1)
+		return nil
+	}
+
+	ctx = domain.WithLibraryID(ctx, result.LibraryID)
+	changed, err := c.enrich(ctx, &domain.SongInfo{ID: result.SongID}, details)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) || errors.Is(err, domain.ErrEnrichmentMismatch) {
+			c.invalid.Add(1)
+		} else {
+			c.failed.Add(1)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.remember(result.SongID, digest)
+	if changed == 0 {
+		c.unchanged.Add(1)
+		return nil
+	}
+
+	log.Info("enrichment result applied", slog.Any("changed", changed.Names()))
+	c.applied.Add(1)
+	return nil
+}
+
+// enrich applies the details, again if the song was updated meanwhile
+func (c *Consumer) enrich(ctx context.Context, song *domain.SongInfo, details *domain.Song) (domain.SongFields, error) {
+	for attempt := 1; ; attempt++ {
+		_, changed, err := c.Songs.Enrich(ctx, song, details)
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt == maxConflictRetries {
+			return changed, err
+		}
+	}
+}
+
+// isDuplicate reports whether the result with digest is the last one applied
+// to the song within DedupeWindow
+func (c *Consumer) isDuplicate(songID uuid.UUID, digest [sha256.Size]byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last, ok := c.seen[songID]
+	return ok && last.digest == digest && c.now().Sub(last.at) < c.DedupeWindow
+}
+
+// remember stores the digest of the result applied to the song and forgets
+// the results older than DedupeWindow once per window
+func (c *Consumer) remember(songID uuid.UUID, digest [sha256.Size]byte) {
+	if c.DedupeWindow <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.seen[songID] = appliedResult{digest: digest, at: now}
+
+	if now.Sub(c.prunedAt) < c.DedupeWindow {
+		return
+	}
+	for id, last := range c.seen {
+		if now.Sub(last.at) >= c.DedupeWindow {
+			delete(c.seen, id)
+		}
+	}
+	c.prunedAt = now
+}
+
+// Stats returns the number of results applied, that changed nothing, that
+// were duplicates, invalid or failed since the start
+func (c *Consumer) Stats() map[string]int64 {
+	return map[string]int64{
+		"applied":    c.applied.Load(),
+		"unchanged":  c.unchanged.Load(),
+		"duplicates": c.duplicates.Load(),
+		"invalid":    c.invalid.Load(),
+		"failed":     c.failed.Load(),
+	}
+}
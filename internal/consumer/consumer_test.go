@@ -0,0 +1,192 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	musicapi "songLibrary/internal/delivery/music_info"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEnricher struct {
+	mu       sync.Mutex
+	errs     []error
+	enriched []*domain.Song
+}
+
+func (s *stubEnricher) Enrich(ctx context.Context, song *domain.SongInfo, details *domain.Song) (*domain.Song, domain.SongFields, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	enriched := *details
+	enriched.ID = song.ID
+	enriched.LibraryID = domain.LibraryIDFromContext(ctx)
+	s.enriched = append(s.enriched, &enriched)
+	return &enriched, domain.FieldText, nil
+}
+
+func newConsumer(songs SongEnricher) *Consumer {
+	return New(songs, musicapi.NewDateParser(), 10*time.Minute, slog.New(slogdiscard.NewDiscardHandler()))
+}
+
+func resultJSON(songID uuid.UUID, text string) []byte {
+	return []byte(fmt.Sprintf(`{"song_id":%q,"source":"worker","name":"Hysteria","group":"Muse","text":%q,"releaseDate":"15.09.2003"}`, songID, text))
+}
+
+func TestConsumer_Handle(t *testing.T) {
+	songs := &stubEnricher{}
+	c := newConsumer(songs)
+	songID := uuid.New()
+
+	require.NoError(t, c.Handle(context.Background(), resultJSON(songID, "It's bugging me")))
+
+	require.Len(t, songs.enriched, 1)
+	assert.Equal(t, songID, songs.enriched[0].ID)
+	assert.Equal(t, "It's bugging me", songs.enriched[0].Text)
+	assert.Equal(t, "worker", songs.enriched[0].Source)
+	assert.Equal(t, time.Date(2003, 9, 15, 0, 0, 0, 0, time.UTC), songs.enriched[0].ReleaseDate)
+	assert.Equal(t, int64(1), c.Stats()["applied"])
+}
+
+func TestConsumer_Handle_Library(t *testing.T) {
+	songs := &stubEnricher{}
+	c := newConsumer(songs)
+	songID, libraryID := uuid.New(), uuid.New()
+
+	data := fmt.Sprintf(`{"song_id":%q,"library_id":%q,"source":"worker","name":"Hysteria","group":"Muse","text":"text"}`, songID, libraryID)
+	require.NoError(t, c.Handle(context.Background(), []byte(data)))
+
+	// Результат применяется к песне своей библиотеки
+	require.Len(t, songs.enriched, 1)
+	assert.Equal(t, libraryID, songs.enriched[0].LibraryID)
+}
+
+func TestConsumer_Handle_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "not json", data: `{`, wantErr: domain.ErrMusicInfoMalformed},
+		{name: "no song id", data: `{"name":"Hysteria","group":"Muse","text":"text"}`, wantErr: domain.ErrInvalidSongID},
+		{name: "no text", data: fmt.Sprintf(`{"song_id":%q,"name":"Hysteria","group":"Muse"}`, uuid.New()), wantErr: domain.ErrInvalidSongText},
+		{name: "bad release date", data: fmt.Sprintf(`{"song_id":%q,"name":"Hysteria","group":"Muse","text":"text","releaseDate":"soon"}`, uuid.New()), wantErr: domain.ErrInvalidReleaseDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs := &stubEnricher{}
+			c := newConsumer(songs)
+
+			err := c.Handle(context.Background(), []byte(tt.data))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, songs.enriched)
+			assert.Equal(t, int64(1), c.Stats()["invalid"])
+		})
+	}
+}
+
+func TestConsumer_Handle_Dedupe(t *testing.T) {
+	songs := &stubEnricher{}
+	c := newConsumer(songs)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	songID := uuid.New()
+
+	// Повторная доставка того же результата пропускается
+	require.NoError(t, c.Handle(context.Background(), resultJSON(songID, "text")))
+	require.NoError(t, c.Handle(context.Background(), resultJSON(songID, "text")))
+	assert.Len(t, songs.enriched, 1)
+	assert.Equal(t, int64(1), c.Stats()["duplicates"])
+
+	// Новый результат для той же песни применяется
+	require.NoError(t, c.Handle(context.Background(), resultJSON(songID, "new text")))
+	assert.Len(t, songs.enriched, 2)
+
+	// После окна дедупликации результат применяется снова
+	now = now.Add(11 * time.Minute)
+	require.NoError(t, c.Handle(context.Background(), resultJSON(songID, "new text")))
+	assert.Len(t, songs.enriched, 3)
+	assert.Len(t, c.seen, 1)
+}
+
+func TestConsumer_Handle_FailedNotRemembered(t *testing.T) {
+	songs := &stubEnricher{errs: []error{errors.New("connection refused")}}
+	c := newConsumer(songs)
+	songID := uuid.New()
+
+	// Неудачный результат не считается применённым и принимается повторно
+	assert.Error(t, c.Handle(context.Background(), resultJSON(songID, "text")))
+	assert.NoError(t, c.Handle(context.Background(), resultJSON(songID, "text")))
+	assert.Len(t, songs.enriched, 1)
+	assert.Equal(t, map[string]int64{"applied": 1, "unchanged": 0, "duplicates": 0, "invalid": 0, "failed": 1}, c.Stats())
+}
+
+func TestConsumer_Handle_VersionConflict(t *testing.T) {
+	songs := &stubEnricher{errs: []error{domain.ErrVersionConflict, domain.ErrVersionConflict}}
+	c := newConsumer(songs)
+
+	// Песня изменилась во время применения, результат применяется заново
+	require.NoError(t, c.Handle(context.Background(), resultJSON(uuid.New(), "text")))
+	assert.Len(t, songs.enriched, 1)
+
+	songs.errs = []error{domain.ErrVersionConflict, domain.ErrVersionConflict, domain.ErrVersionConflict}
+	assert.ErrorIs(t, c.Handle(context.Background(), resultJSON(uuid.New(), "text")), domain.ErrVersionConflict)
+}
+
+type chanSource chan []byte
+
+func (s chanSource) Receive(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-s:
+		return data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestConsumer_Run(t *testing.T) {
+	songs := &stubEnricher{}
+	c := newConsumer(songs)
+	src := make(chanSource)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(ctx, src)
+	}()
+
+	src <- []byte(`{`)
+	src <- resultJSON(uuid.New(), "text")
+	// Результаты обрабатываются по одному, третий принят после второго
+	src <- resultJSON(uuid.New(), "text")
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("consumer did not stop")
+	}
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats["invalid"])
+	assert.GreaterOrEqual(t, stats["applied"], int64(1))
+}
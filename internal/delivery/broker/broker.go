@@ -0,0 +1,16 @@
+package broker
+
+import (
+	"encoding/json"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+)
+
+// EventHeader carries the event type of the message where the broker
+// supports headers
+const EventHeader = "Song-Event"
+
+// encode returns the JSON payload of the event, the same one webhooks get
+func encode(event domain.SongEvent) ([]byte, error) {
+	return json.Marshal(dto.SongEventToPayload(event))
+}
@@ -0,0 +1,87 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafka_Publish(t *testing.T) {
+	var (
+		path        string
+		contentType string
+		request     kafkaProduceRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", kafkaAccept)
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":42,"error_code":null,"error":null}]}`))
+	}))
+	defer srv.Close()
+
+	kafka := NewKafka(srv.URL+"/", "songs", srv.Client(), slog.New(slogdiscard.NewDiscardHandler()))
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	err := kafka.Publish(context.Background(), domain.SongEvent{Type: domain.SongCreated, Song: song, OccurredAt: time.Now()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/topics/songs", path)
+	assert.Equal(t, kafkaContentType, contentType)
+
+	// Ключ сообщения — ID песни, значение — то же описание, что у вебхуков
+	require.Len(t, request.Records, 1)
+	assert.Equal(t, song.ID.String(), request.Records[0].Key)
+
+	var payload dto.WebhookPayload
+	require.NoError(t, json.Unmarshal(request.Records[0].Value, &payload))
+	assert.Equal(t, "song.created", payload.Event)
+	assert.Equal(t, song.ID, payload.Song.ID)
+	assert.Equal(t, "Hysteria", payload.Song.Name)
+}
+
+func TestKafka_Publish_RecordRejected(t *testing.T) {
+	// Прокси отвечает 200, но брокер не принял запись
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"Kafka error: timeout"}]}`))
+	}))
+	defer srv.Close()
+
+	kafka := NewKafka(srv.URL, "songs", srv.Client(), slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := kafka.Publish(context.Background(), domain.SongEvent{Type: domain.SongDeleted, Song: &domain.Song{ID: uuid.New()}})
+	assert.ErrorContains(t, err, "50003")
+}
+
+func TestKafka_Publish_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	kafka := NewKafka(srv.URL, "missing", srv.Client(), slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := kafka.Publish(context.Background(), domain.SongEvent{Type: domain.SongUpdated, Song: &domain.Song{ID: uuid.New()}})
+	assert.Error(t, err)
+}
+
+func TestNewNATS_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	_, err := NewNATS("nats://"+srv.Listener.Addr().String(), "songs", time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+	assert.Error(t, err)
+}
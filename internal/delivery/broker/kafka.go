@@ -0,0 +1,114 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+)
+
+// Kafka publishes song events to a Kafka topic through the Confluent REST
+// Proxy. Messages are keyed by song ID, so the events of a song go to one
+// partition and are consumed in order.
+type Kafka struct {
+	Client *http.Client
+	url    string
+	topic  string
+	log    *slog.Logger
+}
+
+func NewKafka(proxyURL, topic string, client *http.Client, log *slog.Logger) *Kafka {
+	return &Kafka{
+		Client: client,
+		url:    strings.TrimRight(proxyURL, "/"),
+		topic:  topic,
+		log:    log,
+	}
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int     `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (k *Kafka) Publish(ctx context.Context, event domain.SongEvent) error {
+	const op = "broker.Kafka.Publish"
+
+	value, err := encode(event)
+	if err != nil {
+		return fmt.Errorf("%s: failed to encode payload: %w", op, err)
+	}
+
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: event.Song.ID.String(), Value: value}},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to encode records: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.url+"/topics/"+url.PathEscape(k.topic), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAccept)
+
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status code: %d", op, resp.StatusCode)
+	}
+
+	// the proxy answers 200 even if a record was rejected by the broker
+	var produced kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("%s: failed to decode response: %w", op, err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			reason := ""
+			if offset.Error != nil {
+				reason = *offset.Error
+			}
+			return fmt.Errorf("%s: record rejected with code %d: %s", op, *offset.ErrorCode, reason)
+		}
+	}
+
+	k.log.Debug("event published to Kafka",
+		slog.String("op", op),
+		slog.String("event", string(event.Type)),
+		slog.String("topic", k.topic),
+	)
+	return nil
+}
+
+func (k *Kafka) Close() error {
+	return nil
+}
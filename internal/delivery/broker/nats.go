@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATS publishes song events to a NATS subject. A publish returns once the
+// server has received the message.
+type NATS struct {
+	conn    *nats.Conn
+	subject string
+	timeout time.Duration
+	log     *slog.Logger
+}
+
+// NewNATS connects to the NATS server at url to publish to subject
+func NewNATS(url, subject string, timeout time.Duration, log *slog.Logger) (*NATS, error) {
+	const op = "broker.NewNATS"
+
+	conn, err := connectNATS(url, timeout, log)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &NATS{
+		conn:    conn,
+		subject: subject,
+		timeout: timeout,
+		log:     log,
+	}, nil
+}
+
+// connectNATS connects to the NATS server at url, the connection is
+// restored automatically when it is lost
+func connectNATS(url string, timeout time.Duration, log *slog.Logger) (*nats.Conn, error) {
+	return nats.Connect(url,
+		nats.Name("songLibrary"),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			log.Warn("disconnected from NATS", slog.Any("error", err))
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info("reconnected to NATS", slog.String("url", conn.ConnectedUrl()))
+		}),
+	)
+}
+
+func (n *NATS) Publish(ctx context.Context, event domain.SongEvent) error {
+	const op = "broker.NATS.Publish"
+
+	body, err := encode(event)
+	if err != nil {
+		return fmt.Errorf("%s: failed to encode payload: %w", op, err)
+	}
+
+	msg := nats.NewMsg(n.subject)
+	msg.Header.Set(EventHeader, string(event.Type))
+	msg.Data = body
+
+	if err := n.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a flush waits for the server to process everything published before it
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	if err := n.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Close publishes the buffered messages and closes the connection
+func (n *NATS) Close() error {
+	return n.conn.Drain()
+}
@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSSubscription receives the messages of a NATS subject. Subscribers of
+// one queue group share the messages, each is delivered to one of them.
+type NATSSubscription struct {
+	conn *nats.Conn
+	sub  *nats.Subscription
+}
+
+// SubscribeNATS connects to the NATS server at url and subscribes to subject
+// in queue
+func SubscribeNATS(url, subject, queue string, timeout time.Duration, log *slog.Logger) (*NATSSubscription, error) {
+	const op = "broker.SubscribeNATS"
+
+	conn, err := connectNATS(url, timeout, log)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sub, err := conn.QueueSubscribeSync(subject, queue)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &NATSSubscription{conn: conn, sub: sub}, nil
+}
+
+// Receive waits for the next message until ctx is done
+func (s *NATSSubscription) Receive(ctx context.Context) ([]byte, error) {
+	const op = "broker.NATSSubscription.Receive"
+
+	msg, err := s.sub.NextMsgWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg.Data, nil
+}
+
+// Close stops the subscription once the received messages are taken and
+// closes the connection
+func (s *NATSSubscription) Close() error {
+	return s.conn.Drain()
+}
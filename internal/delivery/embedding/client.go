@@ -0,0 +1,97 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Client embeds texts with an OpenAI compatible embeddings API: the text is
+// posted with the model to URL and the first embedding of the response is
+// returned. Vectors of another length than Dimensions are rejected, they
+// don't fit the stored ones.
+type Client struct {
+	URL        string
+	Model      string
+	APIKey     string
+	Dimensions int
+	Client     *http.Client
+	log        *slog.Logger
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func NewClient(url, model, apiKey string, dimensions int, timeout time.Duration, log *slog.Logger) *Client {
+	return &Client{
+		URL:        url,
+		Model:      model,
+		APIKey:     apiKey,
+		Dimensions: dimensions,
+		Client:     &http.Client{Timeout: timeout},
+		log:        log,
+	}
+}
+
+// Embed returns the embedding of text, any status other than 2xx is an error
+func (c *Client) Embed(ctx context.Context, text string) ([]float32, error) {
+	const op = "embedding.Client.Embed"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.String("model", c.Model),
+	)
+
+	body, err := json.Marshal(embeddingRequest{Model: c.Model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encode request: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	log.Debug("embedding text", slog.Int("length", len(text)))
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: unexpected status code: %d", op, resp.StatusCode)
+	}
+
+	var response embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode response: %w", op, err)
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("%s: response has no embedding", op)
+	}
+
+	embedding := response.Data[0].Embedding
+	if len(embedding) != c.Dimensions {
+		return nil, fmt.Errorf("%s: embedding has %d dimensions, %d expected", op, len(embedding), c.Dimensions)
+	}
+
+	return embedding, nil
+}
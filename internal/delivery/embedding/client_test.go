@@ -0,0 +1,65 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Embed(t *testing.T) {
+	var (
+		request       embeddingRequest
+		authorization string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"data":[{"embedding":[0.5,-1,2]}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "text-embedding", "s3cret", 3, time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+
+	embedding, err := client.Embed(context.Background(), "It's bugging me")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, -1, 2}, embedding)
+	assert.Equal(t, embeddingRequest{Model: "text-embedding", Input: "It's bugging me"}, request)
+	assert.Equal(t, "Bearer s3cret", authorization)
+}
+
+func TestClient_Embed_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "статус ошибки", status: http.StatusTooManyRequests, body: `{}`},
+		{name: "нет эмбеддинга", status: http.StatusOK, body: `{"data":[]}`},
+		{name: "другая размерность", status: http.StatusOK, body: `{"data":[{"embedding":[0.5,-1]}]}`},
+		{name: "не JSON", status: http.StatusOK, body: `<html>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, "text-embedding", "", 3, time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+
+			_, err := client.Embed(context.Background(), "It's bugging me")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package graphql serves the song Service over GraphQL, so clients fetch
+// exactly the song fields they need. The executable schema is generated by
+// gqlgen from schema.graphqls.
+package graphql
+
+import (
+	"context"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Service is the part of the song service the resolvers use
+type Service interface {
+	Add(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
+	Delete(ctx context.Context, song *domain.SongInfo) error
+	GetAllWithFilter(ctx context.Context, filter *domain.SongFilter, page, pageSize int) ([]*domain.Song, error)
+	GetAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, pageSize int) ([]*domain.Song, *domain.SongCursor, error)
+}
+
+type Resolver struct {
+	Service Service
+}
+
+func NewResolver(service Service) *Resolver {
+	return &Resolver{Service: service}
+}
+
+// ServiceError is an error the Service failed a resolver with, the error
+// presenter renders it like the REST API renders service errors
+type ServiceError struct {
+	Op  string
+	Err error
+}
+
+func (e *ServiceError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// invalidArgument returns the error of an argument the resolver rejects,
+// coded like the validation errors of the REST API
+func invalidArgument(message string) error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]any{"code": dto.CodeValidationFailed},
+	}
+}
+
+var songSorts = map[SongSort]domain.SongSort{
+	SongSortCreatedAt:  domain.SortByCreatedAt,
+	SongSortPopularity: domain.SortByPopularity,
+	SongSortRating:     domain.SortByRating,
+}
+
+// songFilter converts the filter argument of the song listings, validating
+// it like GET /songs validates its query parameters
+func songFilter(filter *SongFilter) (*domain.SongFilter, error) {
+	if filter == nil {
+		return &domain.SongFilter{TagMode: domain.TagModeAll, ExcludeArchived: true}, nil
+	}
+
+	f := &domain.SongFilter{
+		Name:            value(filter.Name),
+		Group:           value(filter.Group),
+		Text:            value(filter.Text),
+		Link:            value(filter.Link),
+		ArtistID:        value(filter.ArtistID),
+		Genre:           value(filter.Genre),
+		Album:           value(filter.Album),
+		Explicit:        filter.Explicit,
+		ReleaseDate:     value(filter.ReleaseDate),
+		ReleasedFrom:    value(filter.ReleasedFrom),
+		ReleasedTo:      value(filter.ReleasedTo),
+		MinRating:       value(filter.MinRating),
+		TagMode:         domain.TagModeAll,
+		ExcludeExplicit: value(filter.ExcludeExplicit),
+		ExcludeArchived: filter.Status == nil && !value(filter.IncludeArchived),
+	}
+
+	if filter.Status != nil {
+		f.Status = domain.SongStatus(strings.ToLower(string(*filter.Status)))
+	}
+	if filter.TagMode != nil {
+		f.TagMode = domain.TagMode(strings.ToLower(string(*filter.TagMode)))
+	}
+
+	if !f.ReleasedFrom.IsZero() && !f.ReleasedTo.IsZero() && f.ReleasedFrom.After(f.ReleasedTo) {
+		return nil, invalidArgument("releasedFrom must not be after releasedTo")
+	}
+
+	if f.ExcludeExplicit && f.Explicit != nil && *f.Explicit {
+		return nil, invalidArgument("explicit: true can't be combined with excludeExplicit")
+	}
+
+	minSeconds, maxSeconds := value(filter.MinDurationSeconds), value(filter.MaxDurationSeconds)
+	if minSeconds < 0 {
+		return nil, invalidArgument("minDurationSeconds must not be negative")
+	}
+	if maxSeconds < 0 || (maxSeconds > 0 && maxSeconds < minSeconds) {
+		return nil, invalidArgument("maxDurationSeconds must not be negative or below minDurationSeconds")
+	}
+	f.MinDuration = time.Duration(minSeconds) * time.Second
+	f.MaxDuration = time.Duration(maxSeconds) * time.Second
+
+	if filter.MinRating != nil && (f.MinRating < domain.MinRating || f.MinRating > domain.MaxRating) {
+		return nil, invalidArgument("minRating must be from 1 to 5")
+	}
+
+	if len(filter.Tags) > 0 {
+		tags, err := domain.NormalizeTags(filter.Tags)
+		if err != nil {
+			return nil, invalidArgument("tags must be 1 to 50 characters long and can't contain commas")
+		}
+		f.Tags = tags
+	}
+
+	return f, nil
+}
+
+// selectsField reports whether the query selects the field at path below
+// the field being resolved
+func selectsField(ctx context.Context, path ...string) bool {
+	opCtx := graphql.GetOperationContext(ctx)
+	fields := graphql.CollectFieldsCtx(ctx, nil)
+
+	for i, name := range path {
+		var selections []graphql.CollectedField
+		found := false
+		for _, field := range fields {
+			if field.Name != name {
+				continue
+			}
+			found = true
+			selections = append(selections, graphql.CollectFields(opCtx, field.Selections, nil)...)
+		}
+		if !found {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		fields = selections
+	}
+
+	return false
+}
+
+// value returns the value p points to, the zero value for nil
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
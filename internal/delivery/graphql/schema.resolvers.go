@@ -0,0 +1,203 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+	"errors"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddSong is the resolver for the addSong field.
+func (r *mutationResolver) AddSong(ctx context.Context, input AddSongInput) (*domain.Song, error) {
+	const op = "graphql.AddSong"
+
+	if input.Name == "" || input.Group == "" {
+		return nil, invalidArgument("name and group are required")
+	}
+
+	song, err := r.Service.Add(ctx, &domain.SongInfo{Name: input.Name, Group: input.Group})
+	if err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	return song, nil
+}
+
+// UpdateSong is the resolver for the updateSong field.
+func (r *mutationResolver) UpdateSong(ctx context.Context, id uuid.UUID, input UpdateSongInput) (*domain.Song, error) {
+	const op = "graphql.UpdateSong"
+
+	if value(input.DurationMs) < 0 || value(input.TrackNumber) < 0 {
+		return nil, invalidArgument("durationMs and trackNumber must not be negative")
+	}
+
+	song := &domain.Song{
+		Name:        value(input.Name),
+		Group:       value(input.Group),
+		Text:        value(input.Text),
+		Link:        value(input.Link),
+		Version:     value(input.Version),
+		AlbumID:     input.AlbumID,
+		Duration:    time.Duration(value(input.DurationMs)) * time.Millisecond,
+		Genre:       value(input.Genre),
+		TrackNumber: value(input.TrackNumber),
+		Album:       value(input.Album),
+		Explicit:    input.Explicit,
+	}
+
+	songInfo := &domain.SongInfo{ID: id}
+	if err := r.Service.Update(ctx, songInfo, song); err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	updated, err := r.Service.Get(ctx, songInfo)
+	if err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	return updated, nil
+}
+
+// DeleteSong is the resolver for the deleteSong field.
+func (r *mutationResolver) DeleteSong(ctx context.Context, id uuid.UUID) (bool, error) {
+	const op = "graphql.DeleteSong"
+
+	if err := r.Service.Delete(ctx, &domain.SongInfo{ID: id}); err != nil {
+		return false, &ServiceError{Op: op, Err: err}
+	}
+
+	return true, nil
+}
+
+// Song is the resolver for the song field.
+func (r *queryResolver) Song(ctx context.Context, id uuid.UUID) (*domain.Song, error) {
+	const op = "graphql.Song"
+
+	song, err := r.Service.Get(ctx, &domain.SongInfo{ID: id})
+	if errors.Is(err, domain.ErrSongNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	return song, nil
+}
+
+// Songs is the resolver for the songs field.
+func (r *queryResolver) Songs(ctx context.Context, filter *SongFilter, sort *SongSort, page *int, pageSize *int) ([]*domain.Song, error) {
+	const op = "graphql.Songs"
+
+	if (page != nil && *page <= 0) || (pageSize != nil && *pageSize <= 0) {
+		return nil, invalidArgument("page and pageSize must be positive")
+	}
+
+	f, err := songFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	f.Sort = domain.SortByCreatedAt
+	if sort != nil {
+		f.Sort = songSorts[*sort]
+	}
+	f.WithoutText = !selectsField(ctx, "text")
+
+	// pageSize alone asks for the first page
+	p := value(page)
+	if p == 0 && pageSize != nil {
+		p = 1
+	}
+
+	songs, err := r.Service.GetAllWithFilter(ctx, f, p, value(pageSize))
+	if err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	return songs, nil
+}
+
+// SongsAfter is the resolver for the songsAfter field.
+func (r *queryResolver) SongsAfter(ctx context.Context, filter *SongFilter, cursor *string, pageSize *int) (*SongPage, error) {
+	const op = "graphql.SongsAfter"
+
+	size := value(pageSize)
+	if size <= 0 {
+		return nil, invalidArgument("pageSize must be positive")
+	}
+
+	var after *domain.SongCursor
+	if value(cursor) != "" {
+		var err error
+		after, err = domain.ParseSongCursor(*cursor)
+		if err != nil {
+			return nil, invalidArgument("invalid cursor")
+		}
+	}
+
+	f, err := songFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	f.WithoutText = !selectsField(ctx, "songs", "text")
+
+	songs, next, err := r.Service.GetAllAfter(ctx, f, after, size)
+	if err != nil {
+		return nil, &ServiceError{Op: op, Err: err}
+	}
+
+	result := &SongPage{Songs: songs}
+	if result.Songs == nil {
+		result.Songs = []*domain.Song{}
+	}
+	if next != nil {
+		nextCursor := next.String()
+		result.NextCursor = &nextCursor
+	}
+
+	return result, nil
+}
+
+// ReleaseDate is the resolver for the releaseDate field.
+func (r *songResolver) ReleaseDate(ctx context.Context, obj *domain.Song) (*time.Time, error) {
+	if obj.ReleaseDate.IsZero() {
+		return nil, nil
+	}
+	return &obj.ReleaseDate, nil
+}
+
+// DurationMs is the resolver for the durationMs field.
+func (r *songResolver) DurationMs(ctx context.Context, obj *domain.Song) (*int, error) {
+	if obj.Duration == 0 {
+		return nil, nil
+	}
+	ms := int(obj.Duration.Milliseconds())
+	return &ms, nil
+}
+
+// Status is the resolver for the status field.
+func (r *songResolver) Status(ctx context.Context, obj *domain.Song) (SongStatus, error) {
+	if obj.Status == "" {
+		return SongStatusEnriched, nil
+	}
+	return SongStatus(strings.ToUpper(string(obj.Status))), nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Song returns SongResolver implementation.
+func (r *Resolver) Song() SongResolver { return &songResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type songResolver struct{ *Resolver }
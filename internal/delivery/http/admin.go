@@ -0,0 +1,424 @@
+package deliveryHttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type CacheService interface {
+	StartRebuild(ctx context.Context) error
+	Stats(ctx context.Context) (*domain.CacheStats, error)
+	Invalidate(ctx context.Context, id uuid.UUID) error
+	Flush(ctx context.Context) (int64, error)
+}
+
+type BackupService interface {
+	Write(ctx context.Context, w io.Writer) error
+	Restore(ctx context.Context, r io.Reader, dryRun bool) (*domain.BackupReport, error)
+}
+
+type LibraryService interface {
+	Add(ctx context.Context, library *domain.Library) error
+	GetAll(ctx context.Context) ([]*domain.Library, error)
+}
+
+type UserService interface {
+	SetRole(ctx context.Context, user *domain.User) error
+	GetAll(ctx context.Context) ([]*domain.User, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, key *domain.APIKey) (string, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	GetAll(ctx context.Context) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+}
+
+type NormalizeService interface {
+	Normalize(ctx context.Context, dryRun bool) (*domain.NormalizeReport, error)
+}
+
+type ContentScanService interface {
+	Scan(ctx context.Context, dryRun bool) (*domain.ContentScanReport, error)
+}
+
+// AdminHandler serves maintenance endpoints, every route passes auth first
+type AdminHandler struct {
+	CacheService CacheService
+	// BackupService takes and restores backups, without it the backup
+	// routes respond with 501
+	BackupService BackupService
+	// LibraryService manages the libraries songs are scoped to
+	LibraryService LibraryService
+	// UserService manages the roles of users
+	UserService UserService
+	// APIKeyService issues and revokes API keys
+	APIKeyService APIKeyService
+	// NormalizeService normalizes the texts of saved songs
+	NormalizeService NormalizeService
+	// ContentScanService flags the saved songs matching the content filter
+	ContentScanService ContentScanService
+	// MaxRestoreSize limits the size of a restored backup in bytes
+	MaxRestoreSize int64
+	auth           func(http.Handler) http.Handler
+	log            *slog.Logger
+}
+
+func NewAdminHandler(cacheService CacheService, auth func(http.Handler) http.Handler, log *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		CacheService: cacheService,
+		auth:         auth,
+		log:          log,
+	}
+}
+
+func (h *AdminHandler) Routes(r chi.Router) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(h.auth)
+
+		r.Get("/cache", h.CacheStats)
+		r.Delete("/cache", h.FlushCache)
+		r.Delete("/cache/{id}", h.InvalidateCache)
+		r.Post("/cache/rebuild", h.RebuildCache)
+		r.Post("/backup", h.Backup)
+		r.Post("/restore", h.Restore)
+		r.Get("/libraries", h.GetLibraries)
+		r.Post("/libraries", h.AddLibrary)
+		r.Get("/users", h.GetUsers)
+		r.Put("/users/{id}", h.SetUserRole)
+		r.Delete("/users/{id}", h.DeleteUser)
+		r.Get("/apikeys", h.GetAPIKeys)
+		r.Post("/apikeys", h.CreateAPIKey)
+		r.Get("/apikeys/{id}", h.GetAPIKey)
+		r.Delete("/apikeys/{id}", h.RevokeAPIKey)
+		r.Post("/lyrics/normalize", h.NormalizeLyrics)
+		r.Post("/lyrics/scan", h.ScanLyrics)
+	})
+}
+
+// @Summary Get cache stats
+// @Description Get the number of keys, hit and miss counters and memory usage of the cache server
+// @Tags admin
+// @Produce  json,xml,application/yaml
+// @Security AdminToken
+// @Success 200 {object} dto.CacheStatsResponse
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/cache [get]
+func (h *AdminHandler) CacheStats(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.CacheStats"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stats, err := h.CacheService.Stats(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to get cache stats", err)
+		return
+	}
+
+	respond(w, r, dto.CacheStatsToResponse(stats))
+}
+
+// @Summary Invalidate a cached song
+// @Description Evict a song from the cache, the next read loads it from the database
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.MessageResponse "song invalidated"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/cache/{id} [delete]
+func (h *AdminHandler) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.InvalidateCache"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Info("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	if err := h.CacheService.Invalidate(r.Context(), id); err != nil {
+		respondError(w, r, log, "failed to invalidate cached song", err)
+		return
+	}
+
+	respond(w, r, OkResp("song invalidated"))
+}
+
+// @Summary Flush the cache
+// @Description Evict every cached song and MusicInfo response. Buffered plays and rate limits are kept.
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Success 200 {object} dto.CacheFlushResponse
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/cache [delete]
+func (h *AdminHandler) FlushCache(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.FlushCache"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	deleted, err := h.CacheService.Flush(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to flush cache", err)
+		return
+	}
+
+	respond(w, r, dto.CacheFlushResponse{Deleted: deleted})
+}
+
+// @Summary Rebuild the song cache
+// @Description Start copying the newest songs from the database to the cache in the background, progress is logged
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Success 202 {object} dto.MessageResponse "cache rebuild started"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 409 {object} dto.ErrorResponse "cache rebuild is already running"
+// @Router /admin/cache/rebuild [post]
+func (h *AdminHandler) RebuildCache(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.RebuildCache"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	if err := h.CacheService.StartRebuild(r.Context()); err != nil {
+		respondError(w, r, log, "failed to start cache rebuild", err)
+		return
+	}
+
+	log.Info("cache rebuild started")
+	render.Status(r, http.StatusAccepted)
+	respond(w, r, OkResp("cache rebuild started"))
+}
+
+// @Summary Back up the database
+// @Description Stream a JSON dump of all tables read from one snapshot. The dump can be loaded with POST /admin/restore into a database with the same schema version.
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Success 200 {file} file "backup"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 501 {object} dto.ErrorResponse "backups need PostgreSQL storage"
+// @Router /admin/backup [post]
+func (h *AdminHandler) Backup(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.Backup"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	if h.BackupService == nil {
+		respondError(w, r, log, "backups are not available", domain.ErrBackupUnsupported)
+		return
+	}
+
+	out := &backupWriter{w: w}
+	if err := h.BackupService.Write(r.Context(), out); err != nil {
+		// once the dump is streaming the status can't change anymore, the
+		// client gets a truncated file that fails to restore
+		if !out.started {
+			respondError(w, r, log, "failed to back up database", err)
+			return
+		}
+		log.Error("backup interrupted", sl.Err(err))
+		return
+	}
+
+	log.Info("backup successfully sent")
+}
+
+// backupWriter sends the headers of a backup with its first bytes, so an
+// error before the dump starts can still be rendered
+type backupWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (b *backupWriter) Write(p []byte) (int, error) {
+	if !b.started {
+		b.started = true
+		name := fmt.Sprintf("songlibrary-%s.json", time.Now().UTC().Format("20060102-150405"))
+		b.w.Header().Set("Content-Type", "application/json")
+		b.w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+		b.w.WriteHeader(http.StatusOK)
+	}
+	return b.w.Write(p)
+}
+
+// @Summary Restore the database
+// @Description Replace the content of all tables with a backup taken by POST /admin/backup, in one transaction. The backup must have the schema version of the database. With dry_run the backup is validated, including by the database constraints, and nothing is changed. The cache is flushed after a restore.
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Security AdminToken
+// @Param backup body object true "Backup"
+// @Param dry_run query bool false "Validate the backup without restoring it"
+// @Success 200 {object} dto.BackupReportResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid backup or dry_run parameter"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 409 {object} dto.ErrorResponse "backup schema version does not match the database"
+// @Failure 413 {object} dto.ErrorResponse "backup is too large"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 501 {object} dto.ErrorResponse "backups need PostgreSQL storage"
+// @Router /admin/restore [post]
+func (h *AdminHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.Restore"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			log.Warn("invalid dry_run parameter", slog.String("dry_run", dryRunStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid dry_run parameter", nil)
+			return
+		}
+	}
+
+	if h.BackupService == nil {
+		respondError(w, r, log, "backups are not available", domain.ErrBackupUnsupported)
+		return
+	}
+
+	if h.MaxRestoreSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.MaxRestoreSize)
+	}
+
+	report, err := h.BackupService.Restore(r.Context(), r.Body, dryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondDecodeError(w, r, log, err)
+			return
+		}
+		respondError(w, r, log, "failed to restore backup", err)
+		return
+	}
+
+	log.Info("backup restored", slog.Bool("dry_run", report.DryRun))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.BackupReportToResponse(report))
+}
+
+// @Summary Normalize song texts
+// @Description Normalize the texts of all songs like texts are normalized when saved: drop the byte order mark, use \n line endings and Unicode NFC, trim trailing whitespace and collapse blank lines. With dry_run the songs that would change are only counted.
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Param dry_run query bool false "Count the songs without saving them"
+// @Success 200 {object} dto.NormalizeReportResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid dry_run parameter"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/lyrics/normalize [post]
+func (h *AdminHandler) NormalizeLyrics(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.NormalizeLyrics"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			log.Warn("invalid dry_run parameter", slog.String("dry_run", dryRunStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid dry_run parameter", nil)
+			return
+		}
+	}
+
+	report, err := h.NormalizeService.Normalize(r.Context(), dryRun)
+	if err != nil {
+		respondError(w, r, log, "failed to normalize song texts", err)
+		return
+	}
+
+	log.Info("song texts normalized", slog.Int("changed", report.Changed), slog.Bool("dry_run", report.DryRun))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.NormalizeReportToResponse(report))
+}
+
+// @Summary Flag explicit songs
+// @Description Scan the texts of all songs with the content filter and flag the songs containing a word of its list explicit, like songs are flagged when added or updated. Flags are only added, songs whose explicit flag was edited keep it. With dry_run the songs that would be flagged are only counted.
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Param dry_run query bool false "Count the songs without saving them"
+// @Success 200 {object} dto.ContentScanReportResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid dry_run parameter"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 501 {object} dto.ErrorResponse "content filter is disabled"
+// @Router /admin/lyrics/scan [post]
+func (h *AdminHandler) ScanLyrics(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.ScanLyrics"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			log.Warn("invalid dry_run parameter", slog.String("dry_run", dryRunStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid dry_run parameter", nil)
+			return
+		}
+	}
+
+	report, err := h.ContentScanService.Scan(r.Context(), dryRun)
+	if err != nil {
+		respondError(w, r, log, "failed to scan song texts", err)
+		return
+	}
+
+	log.Info("song texts scanned", slog.Int("flagged", report.Flagged), slog.Bool("dry_run", report.DryRun))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.ContentScanReportToResponse(report))
+}
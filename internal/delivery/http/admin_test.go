@@ -0,0 +1,407 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// allow stands in for the admin auth middleware
+func allow(next http.Handler) http.Handler {
+	return next
+}
+
+func TestAdminHandler_RebuildCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, allow, mockLog).Routes(r)
+
+	mockService.EXPECT().StartRebuild(gomock.Any()).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/cache/rebuild", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestAdminHandler_RebuildCache_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, allow, mockLog).Routes(r)
+
+	mockService.EXPECT().StartRebuild(gomock.Any()).Return(fmt.Errorf("CacheService.StartRebuild: %w", domain.ErrCacheRebuildRunning))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/cache/rebuild", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeCacheRebuilding, resp.Code)
+}
+
+func TestAdminHandler_RequiresAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	deny := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, deny, mockLog).Routes(r)
+
+	// Сервис не вызывается ни на одном маршруте без авторизации
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/cache"},
+		{http.MethodDelete, "/admin/cache"},
+		{http.MethodDelete, "/admin/cache/" + uuid.New().String()},
+		{http.MethodPost, "/admin/cache/rebuild"},
+		{http.MethodPost, "/admin/backup"},
+		{http.MethodPost, "/admin/restore"},
+	} {
+		req := httptest.NewRequest(route.method, route.path, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, route.path)
+	}
+}
+
+func TestAdminHandler_CacheStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, allow, mockLog).Routes(r)
+
+	mockService.EXPECT().Stats(gomock.Any()).Return(&domain.CacheStats{Keys: 10, Hits: 3, Misses: 1, UsedMemory: 2048}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cache", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.CacheStatsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, int64(10), resp.Keys)
+	assert.Equal(t, 0.75, resp.HitRate)
+	assert.Equal(t, int64(2048), resp.UsedMemoryBytes)
+}
+
+func TestAdminHandler_InvalidateCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, allow, mockLog).Routes(r)
+
+	songID := uuid.New()
+	mockService.EXPECT().Invalidate(gomock.Any(), songID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/cache/"+songID.String(), nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Некорректный ID отклоняется до обращения к сервису
+	req = httptest.NewRequest(http.MethodDelete, "/admin/cache/not-a-uuid", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAdminHandler_FlushCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCacheService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mockService, allow, mockLog).Routes(r)
+
+	mockService.EXPECT().Flush(gomock.Any()).Return(int64(7), nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/cache", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.CacheFlushResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, int64(7), resp.Deleted)
+}
+
+func TestAdminHandler_AddLibrary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLibraries := mocks.NewMockLibraryService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.LibraryService = mockLibraries
+	adminHandler.Routes(r)
+
+	libraryID := uuid.New()
+	mockLibraries.EXPECT().Add(gomock.Any(), &domain.Library{Name: "Team A"}).
+		DoAndReturn(func(_ context.Context, library *domain.Library) error {
+			library.ID = libraryID
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/libraries", strings.NewReader(`{"name":"Team A"}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.LibraryResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, libraryID.String(), resp.ID)
+	assert.Equal(t, "Team A", resp.Name)
+}
+
+func TestAdminHandler_AddLibrary_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLibraries := mocks.NewMockLibraryService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.LibraryService = mockLibraries
+	adminHandler.Routes(r)
+
+	mockLibraries.EXPECT().Add(gomock.Any(), gomock.Any()).Return(fmt.Errorf("LibraryService.Add: %w", domain.ErrLibraryExists))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/libraries", strings.NewReader(`{"name":"Team A"}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeLibraryExists, resp.Code)
+}
+
+func TestAdminHandler_SetUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mocks.NewMockUserService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.UserService = mockUsers
+	adminHandler.Routes(r)
+
+	userID := uuid.New()
+	mockUsers.EXPECT().SetRole(gomock.Any(), &domain.User{ID: userID, Role: domain.RoleEditor}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/"+userID.String(), strings.NewReader(`{"role":"editor"}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.UserResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "editor", resp.Role)
+}
+
+func TestAdminHandler_SetUserRole_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mocks.NewMockUserService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.UserService = mockUsers
+	adminHandler.Routes(r)
+
+	mockUsers.EXPECT().SetRole(gomock.Any(), gomock.Any()).Return(fmt.Errorf("UserService.SetRole: %w", domain.ErrInvalidRole))
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/"+uuid.NewString(), strings.NewReader(`{"role":"owner"}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAdminHandler_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := mocks.NewMockAPIKeyService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.APIKeyService = mockKeys
+	adminHandler.Routes(r)
+
+	libraryID := uuid.New()
+	mockKeys.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key *domain.APIKey) (string, error) {
+			assert.Equal(t, domain.RoleViewer, key.Role)
+			assert.Equal(t, &libraryID, key.LibraryID)
+			key.ID = uuid.New()
+			key.Prefix = "sl_01234567"
+			return "sl_0123456789abcdef", nil
+		})
+
+	body := fmt.Sprintf(`{"name":"mobile","role":"viewer","library_id":%q}`, libraryID)
+	req := httptest.NewRequest(http.MethodPost, "/admin/apikeys", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// Ключ возвращается только при создании
+	var resp dto.APIKeyResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "sl_0123456789abcdef", resp.Key)
+	assert.Equal(t, "sl_01234567", resp.Prefix)
+}
+
+func TestAdminHandler_GetAPIKeys_HidesKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := mocks.NewMockAPIKeyService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.APIKeyService = mockKeys
+	adminHandler.Routes(r)
+
+	mockKeys.EXPECT().GetAll(gomock.Any()).Return([]*domain.APIKey{
+		{ID: uuid.New(), Name: "ci", Prefix: "sl_01234567", Hash: "secret-hash", Role: domain.RoleEditor},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/apikeys", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret-hash")
+	assert.NotContains(t, rec.Body.String(), `"key"`)
+}
+
+func TestAdminHandler_NormalizeLyrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNormalize := mocks.NewMockNormalizeService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.NormalizeService = mockNormalize
+	adminHandler.Routes(r)
+
+	mockNormalize.EXPECT().Normalize(gomock.Any(), true).
+		Return(&domain.NormalizeReport{Scanned: 10, Changed: 3, DryRun: true}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/lyrics/normalize?dry_run=true", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.NormalizeReportResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.NormalizeReportResponse{Scanned: 10, Changed: 3, DryRun: true}, resp)
+
+	// Некорректный dry_run отклоняется до запуска
+	req = httptest.NewRequest(http.MethodPost, "/admin/lyrics/normalize?dry_run=maybe", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAdminHandler_ScanLyrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScan := mocks.NewMockContentScanService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	adminHandler := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	adminHandler.ContentScanService = mockScan
+	adminHandler.Routes(r)
+
+	mockScan.EXPECT().Scan(gomock.Any(), false).
+		Return(&domain.ContentScanReport{Scanned: 10, Flagged: 2}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/lyrics/scan", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.ContentScanReportResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.ContentScanReportResponse{Scanned: 10, Flagged: 2}, resp)
+
+	// Без списка слов сканирование недоступно
+	mockScan.EXPECT().Scan(gomock.Any(), true).Return(nil, domain.ErrContentFilterDisabled)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/lyrics/scan?dry_run=true", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
@@ -0,0 +1,343 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type AlbumService interface {
+	Add(ctx context.Context, album *domain.Album) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.Album, error)
+	GetAll(ctx context.Context, group string, page, pageSize int) ([]*domain.Album, error)
+	Update(ctx context.Context, album *domain.Album) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	GetSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+type AlbumHandler struct {
+	Service AlbumService
+	log     *slog.Logger
+}
+
+func NewAlbumHandler(service AlbumService, log *slog.Logger) *AlbumHandler {
+	return &AlbumHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *AlbumHandler) Routes(r chi.Router) {
+	r.Route("/albums", func(r chi.Router) {
+		r.Post("/", h.Add)
+		r.Get("/", h.GetAll)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+		r.Get("/{id}/songs", h.GetSongs)
+	})
+}
+
+// @Summary Add a new album
+// @Description Add a new album to the library
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param album body dto.AlbumRequest true "Add album request"
+// @Success 201 {object} dto.AlbumResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums [post]
+func (h *AlbumHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	album, ok := decodeAlbumRequest(w, r, log)
+	if !ok {
+		return
+	}
+
+	if album.Title == "" || album.Group == "" {
+		log.Info("title or group is missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "title and group are required", nil)
+		return
+	}
+
+	if err := h.Service.Add(r.Context(), album); err != nil {
+		respondError(w, r, log, "failed to add album", err)
+		return
+	}
+
+	log.Info("album successfully added", slog.String("album_id", album.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.AlbumToResponse(album))
+}
+
+// @Summary Get an album
+// @Description Get album by ID
+// @Tags albums
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Album ID"
+// @Success 200 {object} dto.AlbumResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid album id"
+// @Failure 404 {object} dto.ErrorResponse "album not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums/{id} [get]
+func (h *AlbumHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := albumIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	album, err := h.Service.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to get album", err)
+		return
+	}
+
+	log.Info("album successfully fetched", slog.String("album_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.AlbumToResponse(album))
+}
+
+// @Summary Get all albums
+// @Description Get a list of albums with optional group filter and pagination
+// @Tags albums
+// @Produce  json,xml,application/yaml
+// @Param group query string false "Filter by group"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of albums per page"
+// @Success 200 {array} dto.AlbumResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums [get]
+func (h *AlbumHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	group := r.URL.Query().Get("group")
+
+	albums, err := h.Service.GetAll(r.Context(), group, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch albums", err)
+		return
+	}
+
+	albumsResponse := make([]*dto.AlbumResponse, 0, len(albums))
+	for _, album := range albums {
+		albumsResponse = append(albumsResponse, dto.AlbumToResponse(album))
+	}
+
+	log.Info("albums successfully fetched", slog.Int("count", len(albumsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, albumsResponse)
+}
+
+// @Summary Update an album
+// @Description Update an album by ID, omitted fields keep their values
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Album ID"
+// @Param album body dto.AlbumRequest true "Update album request"
+// @Success 200 {object} dto.AlbumResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request or invalid album id"
+// @Failure 404 {object} dto.ErrorResponse "album not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums/{id} [put]
+func (h *AlbumHandler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := albumIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	album, ok := decodeAlbumRequest(w, r, log)
+	if !ok {
+		return
+	}
+	album.ID = id
+
+	if err := h.Service.Update(r.Context(), album); err != nil {
+		respondError(w, r, log, "failed to update album", err)
+		return
+	}
+
+	log.Info("album successfully updated", slog.String("album_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.AlbumToResponse(album))
+}
+
+// @Summary Delete an album
+// @Description Delete an album by ID, its songs stay in the library
+// @Tags albums
+// @Produce  json
+// @Param id path string true "Album ID"
+// @Success 200 {object} dto.MessageResponse "album deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid album id"
+// @Failure 404 {object} dto.ErrorResponse "album not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums/{id} [delete]
+func (h *AlbumHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := albumIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), id); err != nil {
+		respondError(w, r, log, "failed to delete album", err)
+		return
+	}
+
+	log.Info("album successfully deleted", slog.String("album_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("album deleted successfully"))
+}
+
+// @Summary Get songs of an album
+// @Description Get all songs that belong to the album
+// @Tags albums
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Album ID"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {array} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid album id"
+// @Failure 404 {object} dto.ErrorResponse "album not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /albums/{id}/songs [get]
+func (h *AlbumHandler) GetSongs(w http.ResponseWriter, r *http.Request) {
+	const op = "AlbumHandler.GetSongs"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := albumIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	songs, err := h.Service.GetSongs(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch album songs", err)
+		return
+	}
+
+	songsResponse := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songsResponse = append(songsResponse, *MustConvertSongToResponse(song))
+	}
+
+	log.Info("album songs successfully fetched", slog.Int("count", len(songsResponse)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, songsResponse, fields)
+}
+
+func albumIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid album id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid album id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func decodeAlbumRequest(w http.ResponseWriter, r *http.Request, log *slog.Logger) (*domain.Album, bool) {
+	var req dto.AlbumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return nil, false
+	}
+
+	album := &domain.Album{
+		Title:     req.Title,
+		Group:     req.Group,
+		CoverLink: req.CoverLink,
+	}
+
+	if req.ReleaseDate != "" {
+		releaseDate, err := time.Parse("2006-01-02", req.ReleaseDate)
+		if err != nil {
+			log.Warn("invalid release_date", slog.String("release_date", req.ReleaseDate))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid release_date", nil)
+			return nil, false
+		}
+		album.ReleaseDate = releaseDate
+	}
+
+	return album, true
+}
+
+// paginationParams parses the optional page and page_size query parameters
+func paginationParams(w http.ResponseWriter, r *http.Request, log *slog.Logger) (int, int, bool) {
+	values := make(map[string]int, 2)
+
+	for _, name := range []string{"page", "page_size"} {
+		str := r.URL.Query().Get(name)
+		if str == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(str)
+		if err != nil || value <= 0 {
+			log.Warn("invalid "+name+" parameter", slog.String(name, str))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid "+name+" parameter", nil)
+			return 0, 0, false
+		}
+		values[name] = value
+	}
+
+	return values["page"], values["page_size"], true
+}
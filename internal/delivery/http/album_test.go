@@ -0,0 +1,85 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumHandler_Add_MissingFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAlbumService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewAlbumHandler(mockService, mockLog)
+
+	// Сервис не должен вызываться без названия и группы
+	req := httptest.NewRequest(http.MethodPost, "/albums", strings.NewReader(`{"title": "Absolution"}`))
+	rec := httptest.NewRecorder()
+
+	h.Add(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeValidationFailed, resp.Code)
+}
+
+func TestAlbumHandler_Add_InvalidReleaseDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAlbumService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewAlbumHandler(mockService, mockLog)
+
+	body := `{"title": "Absolution", "group": "Muse", "release_date": "15.09.2003"}`
+	req := httptest.NewRequest(http.MethodPost, "/albums", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.Add(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAlbumHandler_Routes_GetSongsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAlbumService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAlbumHandler(mockService, mockLog).Routes(r)
+
+	albumID := uuid.New()
+	mockService.EXPECT().GetSongs(gomock.Any(), albumID).Return(nil, domain.ErrAlbumNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/albums/"+albumID.String()+"/songs", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeAlbumNotFound, resp.Code)
+}
@@ -0,0 +1,186 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// @Summary Create an API key
+// @Description Issue an API key with a role, optionally scoped to one library and expiring. The key is only returned in this response, clients send it in the X-API-Key header.
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Security AdminToken
+// @Param key body dto.APIKeyRequest true "Create API key request"
+// @Success 201 {object} dto.APIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request, name is missing, unknown role or expiry in the past"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 404 {object} dto.ErrorResponse "library not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/apikeys [post]
+func (h *AdminHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.CreateAPIKey"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req dto.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	key := &domain.APIKey{
+		Name:      req.Name,
+		Role:      domain.Role(req.Role),
+		ExpiresAt: req.ExpiresAt,
+	}
+	if req.LibraryID != nil {
+		libraryID, err := uuid.Parse(*req.LibraryID)
+		if err != nil {
+			log.Info("invalid library id", sl.Err(err))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid library id", nil)
+			return
+		}
+		key.LibraryID = &libraryID
+	}
+
+	raw, err := h.APIKeyService.Create(r.Context(), key)
+	if err != nil {
+		respondError(w, r, log, "failed to create api key", err)
+		return
+	}
+
+	response := dto.APIKeyToResponse(key)
+	response.Key = raw
+
+	log.Info("api key successfully created", slog.String("api_key_id", key.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, response)
+}
+
+// @Summary Get all API keys
+// @Description Get all API keys including revoked ones, the keys themselves are not returned
+// @Tags admin
+// @Produce  json,xml,application/yaml
+// @Security AdminToken
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/apikeys [get]
+func (h *AdminHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.GetAPIKeys"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	keys, err := h.APIKeyService.GetAll(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch api keys", err)
+		return
+	}
+
+	keysResponse := make([]*dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		keysResponse = append(keysResponse, dto.APIKeyToResponse(key))
+	}
+
+	log.Info("api keys successfully fetched", slog.Int("count", len(keysResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, keysResponse)
+}
+
+// @Summary Get an API key
+// @Description Get an API key by ID, the key itself is not returned
+// @Tags admin
+// @Produce  json,xml,application/yaml
+// @Security AdminToken
+// @Param id path string true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid api key id"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 404 {object} dto.ErrorResponse "api key not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/apikeys/{id} [get]
+func (h *AdminHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.GetAPIKey"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := apiKeyIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	key, err := h.APIKeyService.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to get api key", err)
+		return
+	}
+
+	log.Info("api key successfully fetched", slog.String("api_key_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.APIKeyToResponse(key))
+}
+
+// @Summary Revoke an API key
+// @Description Revoke an API key, it is rejected from then on and stays listed with its revocation time
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Param id path string true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid api key id"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 404 {object} dto.ErrorResponse "api key not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/apikeys/{id} [delete]
+func (h *AdminHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.RevokeAPIKey"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := apiKeyIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	key, err := h.APIKeyService.Revoke(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to revoke api key", err)
+		return
+	}
+
+	log.Info("api key successfully revoked", slog.String("api_key_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.APIKeyToResponse(key))
+}
+
+func apiKeyIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Info("invalid api key id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid api key id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,71 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// @Summary Archive a song
+// @Description Hide a song from listings, search, recent, random and smart playlists without deleting it. The song gets the archived status and can still be read by its ID; GET /songs lists it with include_archived=true or status=archived.
+// @Tags songs
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 409 {object} dto.ErrorResponse "song was modified by another request"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/archive [post]
+func (h *Handler) Archive(w http.ResponseWriter, r *http.Request) {
+	h.changeArchived(w, r, "Handler.Archive", h.Service.Archive)
+}
+
+// @Summary Unarchive a song
+// @Description Bring an archived song back to listings. It becomes enriched, or pending if it has no text yet, in which case its details are fetched again. Songs that aren't archived are returned unchanged.
+// @Tags songs
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 409 {object} dto.ErrorResponse "song was modified by another request"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/unarchive [post]
+func (h *Handler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	h.changeArchived(w, r, "Handler.Unarchive", h.Service.Unarchive)
+}
+
+// changeArchived applies change to the song of the request and responds
+// with the song
+func (h *Handler) changeArchived(w http.ResponseWriter, r *http.Request, op string, change func(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	song, err := change(r.Context(), &domain.SongInfo{ID: id})
+	if err != nil {
+		respondError(w, r, log, "failed to change song status", err)
+		return
+	}
+
+	log.Info("song status changed", slog.String("song_id", id.String()), slog.String("status", string(song.Status)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, songToResponse(song))
+}
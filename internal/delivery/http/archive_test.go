@@ -0,0 +1,110 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Archive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().Archive(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(&domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Status: domain.SongStatusArchived}, nil)
+	mockService.EXPECT().Unarchive(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(&domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Status: domain.SongStatusEnriched}, nil)
+
+	for action, status := range map[string]string{"archive": "archived", "unarchive": "enriched"} {
+		req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/"+action, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, action)
+
+		var resp dto.SongResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, status, resp.Status, action)
+	}
+}
+
+func TestHandler_Archive_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	// Некорректный ID не доходит до сервиса
+	req := httptest.NewRequest(http.MethodPost, "/songs/not-a-uuid/archive", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	songID := uuid.New()
+	mockService.EXPECT().Archive(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(nil, fmt.Errorf("Service.Archive: %w", domain.ErrSongNotFound))
+
+	req = httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/archive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetAllWithFilter_Archived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	// Архивные песни скрыты по умолчанию и показываются с include_archived или при выборе статуса
+	for query, exclude := range map[string]bool{
+		"":                      true,
+		"include_archived=true": false,
+		"status=archived":       false,
+		"status=pending":        false,
+	} {
+		mockService.EXPECT().
+			GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+			DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+				assert.Equal(t, exclude, filter.ExcludeArchived, query)
+				return nil, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+		rec := httptest.NewRecorder()
+		h.GetAllWithFilter(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, query)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?include_archived=maybe", nil)
+	rec := httptest.NewRecorder()
+	h.GetAllWithFilter(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
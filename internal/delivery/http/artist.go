@@ -0,0 +1,306 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type ArtistService interface {
+	Add(ctx context.Context, artist *domain.Artist) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.Artist, error)
+	GetAll(ctx context.Context, name string, page, pageSize int) ([]*domain.Artist, error)
+	Update(ctx context.Context, artist *domain.Artist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	GetSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+type ArtistHandler struct {
+	Service ArtistService
+	log     *slog.Logger
+}
+
+func NewArtistHandler(service ArtistService, log *slog.Logger) *ArtistHandler {
+	return &ArtistHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *ArtistHandler) Routes(r chi.Router) {
+	r.Route("/artists", func(r chi.Router) {
+		r.Post("/", h.Add)
+		r.Get("/", h.GetAll)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+		r.Get("/{id}/songs", h.GetSongs)
+	})
+}
+
+// @Summary Add a new artist
+// @Description Add a new artist to the library
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param artist body dto.ArtistRequest true "Add artist request"
+// @Success 201 {object} dto.ArtistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 409 {object} dto.ErrorResponse "artist already exists"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists [post]
+func (h *ArtistHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	artist, ok := decodeArtistRequest(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Add(r.Context(), artist); err != nil {
+		respondError(w, r, log, "failed to add artist", err)
+		return
+	}
+
+	log.Info("artist successfully added", slog.String("artist_id", artist.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.ArtistToResponse(artist))
+}
+
+// @Summary Get an artist
+// @Description Get artist by ID
+// @Tags artists
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Artist ID"
+// @Success 200 {object} dto.ArtistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid artist id"
+// @Failure 404 {object} dto.ErrorResponse "artist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists/{id} [get]
+func (h *ArtistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := artistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	artist, err := h.Service.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to get artist", err)
+		return
+	}
+
+	log.Info("artist successfully fetched", slog.String("artist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.ArtistToResponse(artist))
+}
+
+// @Summary Get all artists
+// @Description Get a list of artists with optional name filter and pagination
+// @Tags artists
+// @Produce  json,xml,application/yaml
+// @Param name query string false "Filter by name"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of artists per page"
+// @Success 200 {array} dto.ArtistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists [get]
+func (h *ArtistHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+
+	artists, err := h.Service.GetAll(r.Context(), name, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch artists", err)
+		return
+	}
+
+	artistsResponse := make([]*dto.ArtistResponse, 0, len(artists))
+	for _, artist := range artists {
+		artistsResponse = append(artistsResponse, dto.ArtistToResponse(artist))
+	}
+
+	log.Info("artists successfully fetched", slog.Int("count", len(artistsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, artistsResponse)
+}
+
+// @Summary Rename an artist
+// @Description Rename an artist by ID, the new name is applied to all of its songs
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Artist ID"
+// @Param artist body dto.ArtistRequest true "Update artist request"
+// @Success 200 {object} dto.ArtistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request or invalid artist id"
+// @Failure 404 {object} dto.ErrorResponse "artist not found"
+// @Failure 409 {object} dto.ErrorResponse "artist name is already taken"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists/{id} [put]
+func (h *ArtistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := artistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	artist, ok := decodeArtistRequest(w, r, log)
+	if !ok {
+		return
+	}
+	artist.ID = id
+
+	if err := h.Service.Update(r.Context(), artist); err != nil {
+		respondError(w, r, log, "failed to update artist", err)
+		return
+	}
+
+	log.Info("artist successfully updated", slog.String("artist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.ArtistToResponse(artist))
+}
+
+// @Summary Delete an artist
+// @Description Delete an artist by ID, only artists without songs can be deleted
+// @Tags artists
+// @Produce  json
+// @Param id path string true "Artist ID"
+// @Success 200 {object} dto.MessageResponse "artist deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid artist id"
+// @Failure 404 {object} dto.ErrorResponse "artist not found"
+// @Failure 409 {object} dto.ErrorResponse "artist still has songs"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists/{id} [delete]
+func (h *ArtistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := artistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), id); err != nil {
+		respondError(w, r, log, "failed to delete artist", err)
+		return
+	}
+
+	log.Info("artist successfully deleted", slog.String("artist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("artist deleted successfully"))
+}
+
+// @Summary Get songs of an artist
+// @Description Get all songs of the artist
+// @Tags artists
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Artist ID"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {array} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid artist id"
+// @Failure 404 {object} dto.ErrorResponse "artist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /artists/{id}/songs [get]
+func (h *ArtistHandler) GetSongs(w http.ResponseWriter, r *http.Request) {
+	const op = "ArtistHandler.GetSongs"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := artistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	songs, err := h.Service.GetSongs(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch artist songs", err)
+		return
+	}
+
+	songsResponse := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songsResponse = append(songsResponse, *MustConvertSongToResponse(song))
+	}
+
+	log.Info("artist songs successfully fetched", slog.Int("count", len(songsResponse)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, songsResponse, fields)
+}
+
+func artistIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid artist id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid artist id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func decodeArtistRequest(w http.ResponseWriter, r *http.Request, log *slog.Logger) (*domain.Artist, bool) {
+	var req dto.ArtistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return nil, false
+	}
+
+	if req.Name == "" {
+		log.Info("name is missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "name is required", nil)
+		return nil, false
+	}
+
+	return &domain.Artist{Name: req.Name}, true
+}
@@ -0,0 +1,88 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtistHandler_Update_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockArtistService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewArtistHandler(mockService, mockLog).Routes(r)
+
+	artistID := uuid.New()
+	mockService.EXPECT().Update(gomock.Any(), &domain.Artist{ID: artistID, Name: "MUSE"}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/artists/"+artistID.String(), strings.NewReader(`{"name": "MUSE"}`))
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.ArtistResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, artistID.String(), resp.ID)
+	assert.Equal(t, "MUSE", resp.Name)
+}
+
+func TestArtistHandler_Delete_HasSongs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockArtistService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewArtistHandler(mockService, mockLog).Routes(r)
+
+	artistID := uuid.New()
+	mockService.EXPECT().Delete(gomock.Any(), artistID).Return(domain.ErrArtistHasSongs)
+
+	req := httptest.NewRequest(http.MethodDelete, "/artists/"+artistID.String(), nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeArtistHasSongs, resp.Code)
+}
+
+func TestHandler_GetAllWithFilter_InvalidArtistID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?artist_id=muse", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
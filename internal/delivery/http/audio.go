@@ -0,0 +1,127 @@
+package deliveryHttp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type AudioService interface {
+	Upload(ctx context.Context, songID uuid.UUID, body io.Reader) (*domain.Audio, error)
+	Open(ctx context.Context, songID uuid.UUID) (*domain.Audio, io.ReadSeekCloser, error)
+}
+
+type AudioHandler struct {
+	Service AudioService
+	log     *slog.Logger
+}
+
+func NewAudioHandler(service AudioService, log *slog.Logger) *AudioHandler {
+	return &AudioHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *AudioHandler) Routes(r chi.Router) {
+	r.Put("/songs/{id}/audio", h.Upload)
+	r.Get("/songs/{id}/audio", h.Get)
+}
+
+// @Summary Upload the audio of a song
+// @Description Store the audio file sent as the request body for the song, replacing the previous one. MP3, WAV and FLAC files are accepted, the format, duration and bitrate are read from the file.
+// @Tags audio
+// @Accept  audio/mpeg,audio/wav,audio/flac
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.AudioResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 413 {object} dto.ErrorResponse "audio is too large"
+// @Failure 415 {object} dto.ErrorResponse "audio is not a supported format"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/audio [put]
+func (h *AudioHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	const op = "AudioHandler.Upload"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	audio, err := h.Service.Upload(r.Context(), songID, r.Body)
+	if err != nil {
+		respondError(w, r, log, "failed to upload audio", err)
+		return
+	}
+
+	log.Info("audio successfully uploaded")
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.AudioResponse{
+		SongID:      audio.SongID.String(),
+		Format:      audio.Format,
+		ContentType: audio.ContentType,
+		Size:        audio.Size,
+		DurationMs:  audio.Duration.Milliseconds(),
+		Bitrate:     audio.Bitrate,
+		SampleRate:  audio.SampleRate,
+		UploadedAt:  audio.UploadedAt,
+	})
+}
+
+// @Summary Stream the audio of a song
+// @Description Get the audio file of the song. A Range header requests a part of the file, so players can seek.
+// @Tags audio
+// @Produce  audio/mpeg,audio/wav,audio/flac
+// @Param id path string true "Song ID"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200 {file} binary "audio file"
+// @Success 206 {file} binary "requested range of the audio file"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song has no audio"
+// @Failure 416 {string} string "range not satisfiable"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/audio [get]
+func (h *AudioHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "AudioHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	audio, content, err := h.Service.Open(r.Context(), songID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch audio", err)
+		return
+	}
+	defer content.Close()
+
+	// ServeContent answers Range and If-Range requests and If-Modified-Since
+	// with the upload time
+	w.Header().Set("Content-Type", audio.ContentType)
+	http.ServeContent(w, r, "", audio.UploadedAt, content)
+}
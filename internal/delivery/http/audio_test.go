@@ -0,0 +1,191 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAudioRouter(t *testing.T) (http.Handler, *mocks.MockAudioService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAudio := mocks.NewMockAudioService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewAudioHandler(mockAudio, mockLog))
+
+	return h.InitRoutes(), mockAudio
+}
+
+// nopSeekCloser добавляет Close к strings.Reader
+type nopSeekCloser struct {
+	*strings.Reader
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func TestAudioHandler_Upload(t *testing.T) {
+	router, mockAudio := newAudioRouter(t)
+
+	songID := uuid.New()
+	uploadedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockAudio.EXPECT().Upload(gomock.Any(), songID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, body io.Reader) (*domain.Audio, error) {
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, "audio", string(data))
+			return &domain.Audio{
+				SongID:      songID,
+				Format:      "mp3",
+				ContentType: "audio/mpeg",
+				Size:        5,
+				Duration:    183500 * time.Millisecond,
+				Bitrate:     128000,
+				SampleRate:  44100,
+				UploadedAt:  uploadedAt,
+			}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/audio", strings.NewReader("audio"))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.AudioResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.AudioResponse{
+		SongID:      songID.String(),
+		Format:      "mp3",
+		ContentType: "audio/mpeg",
+		Size:        5,
+		DurationMs:  183500,
+		Bitrate:     128000,
+		SampleRate:  44100,
+		UploadedAt:  uploadedAt,
+	}, resp)
+}
+
+func TestAudioHandler_Upload_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   dto.ErrorCode
+	}{
+		{name: "песня не найдена", err: domain.ErrSongNotFound, status: http.StatusNotFound, code: dto.CodeSongNotFound},
+		{name: "слишком большой файл", err: domain.ErrAudioTooLarge, status: http.StatusRequestEntityTooLarge, code: dto.CodeRequestTooLarge},
+		{name: "неподдерживаемый формат", err: domain.ErrAudioInvalidFormat, status: http.StatusUnsupportedMediaType, code: dto.CodeUnsupportedMedia},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockAudio := newAudioRouter(t)
+
+			songID := uuid.New()
+			mockAudio.EXPECT().Upload(gomock.Any(), songID, gomock.Any()).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/audio", strings.NewReader("audio"))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+
+			var resp dto.ErrorResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.code, resp.Code)
+		})
+	}
+}
+
+func TestAudioHandler_Get(t *testing.T) {
+	songID := uuid.New()
+	audio := &domain.Audio{
+		SongID:      songID,
+		ContentType: "audio/mpeg",
+		Size:        10,
+		UploadedAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name         string
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{name: "весь файл", status: http.StatusOK, body: "0123456789"},
+		{name: "диапазон", rangeHeader: "bytes=2-5", status: http.StatusPartialContent, body: "2345", contentRange: "bytes 2-5/10"},
+		{name: "хвост", rangeHeader: "bytes=-3", status: http.StatusPartialContent, body: "789", contentRange: "bytes 7-9/10"},
+		{name: "за концом файла", rangeHeader: "bytes=20-", status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockAudio := newAudioRouter(t)
+
+			mockAudio.EXPECT().Open(gomock.Any(), songID).
+				Return(audio, nopSeekCloser{strings.NewReader("0123456789")}, nil)
+
+			// Настоящий сервер: ServeContent пишет тело через ReadFrom,
+			// которого нет у httptest.ResponseRecorder
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/songs/"+songID.String()+"/audio", nil)
+			assert.NoError(t, err)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+
+			resp, err := server.Client().Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.contentRange, resp.Header.Get("Content-Range"))
+			if tt.body != "" {
+				assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+				assert.Equal(t, "audio/mpeg", resp.Header.Get("Content-Type"))
+				assert.Equal(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func TestAudioHandler_Get_NotFound(t *testing.T) {
+	router, mockAudio := newAudioRouter(t)
+
+	songID := uuid.New()
+	mockAudio.EXPECT().Open(gomock.Any(), songID).Return(nil, nil, domain.ErrAudioNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/audio", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeAudioNotFound, resp.Code)
+}
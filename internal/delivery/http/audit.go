@@ -0,0 +1,103 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type AuditService interface {
+	History(ctx context.Context, songID uuid.UUID, page, pageSize int) ([]*domain.AuditEntry, error)
+}
+
+type AuditHandler struct {
+	Service AuditService
+	log     *slog.Logger
+}
+
+func NewAuditHandler(service AuditService, log *slog.Logger) *AuditHandler {
+	return &AuditHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *AuditHandler) Routes(r chi.Router) {
+	r.Get("/songs/{id}/history", h.History)
+}
+
+// @Summary Get the change history of a song
+// @Description Get who created, updated or deleted the song and when, with the song before and after each change, oldest first. The history of deleted songs is kept.
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of entries per page"
+// @Success 200 {array} dto.AuditEntryResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id, page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/history [get]
+func (h *AuditHandler) History(w http.ResponseWriter, r *http.Request) {
+	const op = "AuditHandler.History"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	entries, err := h.Service.History(r.Context(), songID, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch song history", err)
+		return
+	}
+
+	historyResponse := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		historyResponse = append(historyResponse, auditEntryToResponse(entry))
+	}
+
+	log.Info("song history successfully fetched", slog.String("song_id", songID.String()), slog.Int("count", len(historyResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, historyResponse)
+}
+
+func auditEntryToResponse(entry *domain.AuditEntry) dto.AuditEntryResponse {
+	response := dto.AuditEntryResponse{
+		ID:        entry.ID,
+		SongID:    entry.SongID.String(),
+		Action:    string(entry.Action),
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.UserID != nil {
+		response.UserID = entry.UserID.String()
+	}
+	if entry.Old != nil {
+		response.Old = songToResponse(entry.Old)
+	}
+	if entry.New != nil {
+		response.New = songToResponse(entry.New)
+	}
+
+	return response
+}
@@ -0,0 +1,75 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuditRouter(t *testing.T) (http.Handler, *mocks.MockAuditService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAudit := mocks.NewMockAuditService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewAuditHandler(mockAudit, mockLog))
+
+	return h.InitRoutes(), mockAudit
+}
+
+func TestAuditHandler_History(t *testing.T) {
+	router, mockAudit := newAuditRouter(t)
+
+	songID, userID := uuid.New(), uuid.New()
+	song := &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Version: 1}
+	updated := &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Version: 2}
+	mockAudit.EXPECT().History(gomock.Any(), songID, 2, 10).Return([]*domain.AuditEntry{
+		{ID: 1, SongID: songID, Action: domain.AuditCreate, New: song},
+		{ID: 2, SongID: songID, Action: domain.AuditUpdate, UserID: &userID, Old: song, New: updated},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/history?page=2&page_size=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.AuditEntryResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 2) {
+		// У созданной песни нет прежней версии и автора запроса
+		assert.Equal(t, "create", resp[0].Action)
+		assert.Nil(t, resp[0].Old)
+		assert.Empty(t, resp[0].UserID)
+
+		assert.Equal(t, "update", resp[1].Action)
+		assert.Equal(t, userID.String(), resp[1].UserID)
+		assert.Equal(t, 1, resp[1].Old.Version)
+		assert.Equal(t, "It's bugging me", resp[1].New.Text)
+	}
+}
+
+func TestAuditHandler_History_InvalidID(t *testing.T) {
+	router, _ := newAuditRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/not-a-uuid/history", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,169 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBackupRouter(ctrl *gomock.Controller) (*mocks.MockBackupService, chi.Router) {
+	mockBackup := mocks.NewMockBackupService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog)
+	h.BackupService = mockBackup
+	h.MaxRestoreSize = 64
+
+	r := chi.NewRouter()
+	h.Routes(r)
+	return mockBackup, r
+}
+
+func TestAdminHandler_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackup, r := newBackupRouter(ctrl)
+	mockBackup.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, `{"format":1}`)
+		return err
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/backup", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), `attachment; filename="songlibrary-`)
+	assert.Equal(t, `{"format":1}`, rec.Body.String())
+}
+
+func TestAdminHandler_Backup_FailsBeforeStreaming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackup, r := newBackupRouter(ctrl)
+	mockBackup.EXPECT().Write(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/backup", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	// Пока данные не отправлены, ошибку ещё можно вернуть клиенту
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+}
+
+func TestAdminHandler_Backup_NotAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewAdminHandler(mocks.NewMockCacheService(ctrl), allow, mockLog).Routes(r)
+
+	for _, path := range []string{"/admin/backup", "/admin/restore"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code, path)
+	}
+}
+
+func TestAdminHandler_Restore_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackup, r := newBackupRouter(ctrl)
+	mockBackup.EXPECT().Restore(gomock.Any(), gomock.Any(), true).
+		Return(&domain.BackupReport{SchemaVersion: 19, Tables: map[string]int{"songs": 2}, DryRun: true}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/restore?dry_run=true", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.BackupReportResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.BackupReportResponse{SchemaVersion: 19, Tables: map[string]int{"songs": 2}, DryRun: true}, resp)
+}
+
+func TestAdminHandler_Restore_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackup, r := newBackupRouter(ctrl)
+
+	for _, tc := range []struct {
+		err    error
+		status int
+		code   dto.ErrorCode
+	}{
+		{domain.ErrBackupInvalid, http.StatusBadRequest, dto.CodeValidationFailed},
+		{domain.ErrBackupSchemaMismatch, http.StatusConflict, dto.CodeSchemaMismatch},
+	} {
+		mockBackup.EXPECT().Restore(gomock.Any(), gomock.Any(), false).Return(nil, fmt.Errorf("Backup.Restore: %w", tc.err))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/restore", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.status, rec.Code)
+
+		var resp dto.ErrorResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, tc.code, resp.Code)
+	}
+}
+
+func TestAdminHandler_Restore_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBackup, r := newBackupRouter(ctrl)
+	mockBackup.EXPECT().Restore(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, body io.Reader, _ bool) (*domain.BackupReport, error) {
+			_, err := io.ReadAll(body)
+			return nil, fmt.Errorf("%w: %w", domain.ErrBackupInvalid, err)
+		})
+
+	// Тело больше MaxRestoreSize (64 байта)
+	req := httptest.NewRequest(http.MethodPost, "/admin/restore", strings.NewReader(strings.Repeat(" ", 100)))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestAdminHandler_Restore_InvalidDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, r := newBackupRouter(ctrl)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/restore?dry_run=maybe", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,69 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// @Summary Get songs by IDs
+// @Description Get up to 100 songs by their IDs in one request. Songs are looked up in the cache first and the rest are read in a single query. Found songs are returned in the order of the IDs, the IDs of missing songs are listed in not_found instead of failing the request.
+// @Tags songs
+// @Accept  json
+// @Produce  json,xml,application/yaml
+// @Param request body dto.BatchGetSongsRequest true "Song IDs"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {object} dto.BatchGetSongsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request, no ids or too many ids"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/batch-get [post]
+func (h *Handler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.BatchGet"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	var req dto.BatchGetSongsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		log.Info("ids are missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "ids are required", nil)
+		return
+	}
+
+	songs, notFound, err := h.Service.GetByIDs(r.Context(), req.IDs)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch songs", err)
+		return
+	}
+
+	response := dto.BatchGetSongsResponse{
+		Songs:    make([]dto.SongResponse, 0, len(songs)),
+		NotFound: make([]string, 0, len(notFound)),
+	}
+	for _, song := range songs {
+		response.Songs = append(response.Songs, *songToResponse(song))
+	}
+	for _, id := range notFound {
+		response.NotFound = append(response.NotFound, id.String())
+	}
+
+	log.Info("songs successfully fetched", slog.Int("found", len(songs)), slog.Int("not_found", len(notFound)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, response, fields, "songs")
+}
@@ -0,0 +1,72 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchRouter(t *testing.T) (http.Handler, *mocks.MockService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockService := mocks.NewMockService(ctrl)
+	h := handler.NewHandler(mockService, slog.New(slogdiscard.NewDiscardHandler()))
+
+	return h.InitRoutes(), mockService
+}
+
+func TestHandler_BatchGet(t *testing.T) {
+	router, mockService := newBatchRouter(t)
+
+	found, missing := uuid.New(), uuid.New()
+	mockService.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{found, missing}).
+		Return([]*domain.Song{{ID: found, Name: "Hysteria", Group: "Muse"}}, []uuid.UUID{missing}, nil)
+
+	// Маршрут /songs/batch-get не должен перекрываться маршрутом /songs/{id}
+	body := `{"ids": ["` + found.String() + `", "` + missing.String() + `"]}`
+	req := httptest.NewRequest(http.MethodPost, "/songs/batch-get", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.BatchGetSongsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Songs, 1)
+	assert.Equal(t, found.String(), resp.Songs[0].ID)
+	assert.Equal(t, []string{missing.String()}, resp.NotFound)
+}
+
+func TestHandler_BatchGet_InvalidRequest(t *testing.T) {
+	router, mockService := newBatchRouter(t)
+
+	mockService.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return(nil, nil, domain.ErrTooManySongIDs)
+
+	for _, body := range []string{
+		`{"ids": []}`,
+		`{"ids": ["not-a-uuid"]}`,
+		`{"ids": ["` + uuid.NewString() + `"]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/songs/batch-get", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
@@ -0,0 +1,92 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// @Summary Update songs by filter
+// @Description Set the changes on every song matching the filter in a single update, e.g. rename a group across all its songs. The filter works like the query parameters of GET /songs and must not be empty. Changed genre, album and explicit are locked against MusicInfo refreshes, every song gets a revision and an audit entry and is evicted from the cache.
+// @Tags songs
+// @Accept  json
+// @Produce  json,xml,application/yaml
+// @Param request body dto.BulkUpdateSongsRequest true "Filter and changes"
+// @Success 200 {object} dto.BulkUpdateSongsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request, empty filter or empty changes"
+// @Failure 409 {object} dto.ErrorResponse "an updated song clashes with an existing one"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs [patch]
+func (h *Handler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.BulkUpdate"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req dto.BulkUpdateSongsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	filter := &domain.SongFilter{
+		Name:     req.Filter.Song,
+		Group:    req.Filter.Group,
+		ArtistID: req.Filter.ArtistID,
+		Genre:    req.Filter.Genre,
+		Album:    req.Filter.Album,
+		Explicit: req.Filter.Explicit,
+		TagMode:  domain.TagMode(req.Filter.TagsMode),
+	}
+
+	if len(req.Filter.Tags) > 0 {
+		tags, err := domain.NormalizeTags(req.Filter.Tags)
+		if err != nil {
+			log.Warn("invalid filter tags", slog.Any("tags", req.Filter.Tags))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid filter tags", nil)
+			return
+		}
+		filter.Tags = tags
+	}
+
+	switch filter.TagMode {
+	case "":
+		filter.TagMode = domain.TagModeAll
+	case domain.TagModeAll, domain.TagModeAny:
+	default:
+		log.Warn("invalid filter tags_mode", slog.String("tags_mode", req.Filter.TagsMode))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid filter tags_mode", nil)
+		return
+	}
+
+	if req.Changes.Group != nil && strings.TrimSpace(*req.Changes.Group) == "" {
+		log.Warn("empty group in changes")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "changed group must not be empty", nil)
+		return
+	}
+
+	changes := &domain.SongChanges{
+		Group:    req.Changes.Group,
+		Genre:    req.Changes.Genre,
+		Album:    req.Changes.Album,
+		Explicit: req.Changes.Explicit,
+	}
+
+	updated, err := h.Service.BulkUpdate(r.Context(), filter, changes)
+	if err != nil {
+		respondError(w, r, log, "failed to update songs", err)
+		return
+	}
+
+	log.Info("songs successfully updated", slog.Int("count", updated))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.BulkUpdateSongsResponse{Updated: updated})
+}
@@ -0,0 +1,86 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBulkRouter(t *testing.T) (http.Handler, *mocks.MockService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockService := mocks.NewMockService(ctrl)
+	h := handler.NewHandler(mockService, slog.New(slogdiscard.NewDiscardHandler()))
+
+	return h.InitRoutes(), mockService
+}
+
+func TestHandler_BulkUpdate(t *testing.T) {
+	router, mockService := newBulkRouter(t)
+
+	group := "MUSE"
+	mockService.EXPECT().BulkUpdate(gomock.Any(),
+		&domain.SongFilter{Group: "Muse", Tags: []string{"rock"}, TagMode: domain.TagModeAll},
+		&domain.SongChanges{Group: &group},
+	).Return(2, nil)
+
+	body := `{"filter": {"group": "Muse", "tags": ["Rock"]}, "changes": {"group": "MUSE"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/songs", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.BulkUpdateSongsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 2, resp.Updated)
+}
+
+func TestHandler_BulkUpdate_InvalidRequest(t *testing.T) {
+	router, _ := newBulkRouter(t)
+
+	for _, body := range []string{
+		`{"filter": `,
+		`{"filter": {"group": "Muse", "tags_mode": "some"}, "changes": {"genre": "rock"}}`,
+		`{"filter": {"group": "Muse", "tags": ["a,b"]}, "changes": {"genre": "rock"}}`,
+		`{"filter": {"group": "Muse"}, "changes": {"group": " "}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPatch, "/songs", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestHandler_BulkUpdate_ServiceErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		domain.ErrBulkFilterEmpty:  http.StatusBadRequest,
+		domain.ErrBulkChangesEmpty: http.StatusBadRequest,
+		domain.ErrSongExists:       http.StatusConflict,
+	} {
+		router, mockService := newBulkRouter(t)
+		mockService.EXPECT().BulkUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, err)
+
+		req := httptest.NewRequest(http.MethodPatch, "/songs", strings.NewReader(`{"changes": {"genre": "rock"}}`))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, status, rec.Code, err.Error())
+	}
+}
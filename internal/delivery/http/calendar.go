@@ -0,0 +1,170 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/internal/ical"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type CalendarService interface {
+	Calendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error)
+}
+
+// CalendarHandler serves the release calendar. Links of the iCalendar export
+// start with BaseURL, the address the request was sent to when it is empty.
+type CalendarHandler struct {
+	Service CalendarService
+	BaseURL string
+	log     *slog.Logger
+}
+
+func NewCalendarHandler(service CalendarService, baseURL string, log *slog.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		Service: service,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		log:     log,
+	}
+}
+
+func (h *CalendarHandler) Routes(r chi.Router) {
+	r.Get("/songs/calendar", h.Calendar)
+	r.Get("/songs/calendar.ics", h.ExportCalendar)
+}
+
+// @Summary Get the release calendar
+// @Description Get the days songs were released on with the songs of each day, in calendar order, for "on this day" lists. Without year songs of every year are grouped by the day of their release, songs without a release date are left out.
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param year query int false "Release year, every year when empty"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Success 200 {array} dto.CalendarDayResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid year or exclude_explicit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/calendar [get]
+func (h *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.Calendar"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	days, _, ok := h.calendar(w, r, log)
+	if !ok {
+		return
+	}
+
+	daysResponse := make([]dto.CalendarDayResponse, 0, len(days))
+	for _, day := range days {
+		songs := make([]dto.CalendarSongResponse, 0, len(day.Songs))
+		for _, song := range day.Songs {
+			songs = append(songs, dto.CalendarSongResponse{
+				ID:          song.ID.String(),
+				Name:        song.Name,
+				Group:       song.Group,
+				ReleaseDate: song.ReleaseDate.Format(time.DateOnly),
+			})
+		}
+		daysResponse = append(daysResponse, dto.CalendarDayResponse{
+			Month: int(day.Month),
+			Day:   day.Day,
+			Songs: songs,
+		})
+	}
+
+	log.Debug("release calendar successfully fetched", slog.Int("days", len(daysResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, daysResponse)
+}
+
+// @Summary Export the release calendar
+// @Description Export the release calendar as an iCalendar file with an all-day event for every song. Without year the events recur every year on the anniversary of the release.
+// @Tags songs
+// @Produce  text/calendar
+// @Param year query int false "Release year, every year when empty"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse "invalid year or exclude_explicit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/calendar.ics [get]
+func (h *CalendarHandler) ExportCalendar(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.ExportCalendar"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	days, year, ok := h.calendar(w, r, log)
+	if !ok {
+		return
+	}
+
+	baseURL := baseURL(h.BaseURL, r)
+	cal := &ical.Calendar{
+		ProdID: "-//songLibrary//Release calendar//EN",
+		Name:   "Song releases",
+		Stamp:  time.Now(),
+	}
+	if year != 0 {
+		cal.Name += " " + strconv.Itoa(year)
+	}
+	for _, day := range days {
+		for _, song := range day.Songs {
+			cal.Events = append(cal.Events, ical.Event{
+				UID:         song.ID.String() + "@songLibrary",
+				Summary:     song.Group + " — " + song.Name,
+				Description: "Released " + song.ReleaseDate.Format(time.DateOnly),
+				URL:         baseURL + "/songs/" + song.ID.String(),
+				Date:        song.ReleaseDate,
+				Yearly:      year == 0,
+			})
+		}
+	}
+
+	body := ical.Render(cal)
+
+	log.Debug("release calendar successfully exported", slog.Int("events", len(cal.Events)))
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Header().Set("Content-Disposition", `attachment; filename="releases.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// calendar fetches the calendar selected by the year and exclude_explicit
+// parameters, it responds with an error when it fails
+func (h *CalendarHandler) calendar(w http.ResponseWriter, r *http.Request, log *slog.Logger) ([]*domain.CalendarDay, int, bool) {
+	var year int
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		var err error
+		year, err = strconv.Atoi(yearStr)
+		if err != nil || year < 1 || year > 9999 {
+			log.Warn("invalid year parameter", slog.String("year", yearStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "year must be a number from 1 to 9999", nil)
+			return nil, 0, false
+		}
+	}
+
+	excludeExplicit, ok := excludeExplicitParam(w, r, log)
+	if !ok {
+		return nil, 0, false
+	}
+
+	days, err := h.Service.Calendar(r.Context(), year, excludeExplicit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch release calendar", err)
+		return nil, 0, false
+	}
+	return days, year, true
+}
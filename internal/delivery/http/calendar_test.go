@@ -0,0 +1,123 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCalendarRouter(t *testing.T) (http.Handler, *mocks.MockCalendarService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockCalendar := mocks.NewMockCalendarService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewCalendarHandler(mockCalendar, "https://songs.example.com", mockLog))
+
+	return h.InitRoutes(), mockCalendar
+}
+
+func testCalendar() []*domain.CalendarDay {
+	return []*domain.CalendarDay{{
+		Month: time.December,
+		Day:   1,
+		Songs: []*domain.Song{
+			{ID: uuid.New(), Name: "Hysteria", Group: "Muse", ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), Name: "Starlight", Group: "Muse", ReleaseDate: time.Date(2006, 12, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}}
+}
+
+func TestCalendarHandler_Calendar(t *testing.T) {
+	router, mockCalendar := newCalendarRouter(t)
+
+	days := testCalendar()
+	mockCalendar.EXPECT().Calendar(gomock.Any(), 2003, true).Return(days, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/calendar?year=2003&exclude_explicit=true", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.CalendarDayResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, 12, resp[0].Month)
+	assert.Equal(t, 1, resp[0].Day)
+	require.Len(t, resp[0].Songs, 2)
+	assert.Equal(t, dto.CalendarSongResponse{
+		ID: days[0].Songs[0].ID.String(), Name: "Hysteria", Group: "Muse", ReleaseDate: "2003-12-01",
+	}, resp[0].Songs[0])
+}
+
+func TestCalendarHandler_ExportCalendar(t *testing.T) {
+	router, mockCalendar := newCalendarRouter(t)
+
+	days := testCalendar()
+	mockCalendar.EXPECT().Calendar(gomock.Any(), 0, false).Return(days, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/calendar.ics", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"))
+	assert.Contains(t, body, "UID:"+days[0].Songs[0].ID.String()+"@songLibrary\r\n")
+	assert.Contains(t, body, "DTSTART;VALUE=DATE:20031201\r\n")
+	assert.Contains(t, body, "URL:https://songs.example.com/songs/"+days[0].Songs[1].ID.String()+"\r\n")
+	// Без года события повторяются каждый год
+	assert.Equal(t, 2, strings.Count(body, "RRULE:FREQ=YEARLY"))
+}
+
+func TestCalendarHandler_ExportCalendar_Year(t *testing.T) {
+	router, mockCalendar := newCalendarRouter(t)
+
+	mockCalendar.EXPECT().Calendar(gomock.Any(), 2003, false).Return(testCalendar(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/calendar.ics?year=2003", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "X-WR-CALNAME:Song releases 2003\r\n")
+	assert.NotContains(t, rec.Body.String(), "RRULE")
+}
+
+func TestCalendarHandler_InvalidParams(t *testing.T) {
+	router, _ := newCalendarRouter(t)
+
+	for _, target := range []string{
+		"/songs/calendar?year=0", "/songs/calendar?year=10000", "/songs/calendar?year=last",
+		"/songs/calendar.ics?year=-1", "/songs/calendar.ics?exclude_explicit=maybe",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
@@ -0,0 +1,128 @@
+package deliveryHttp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type CoverService interface {
+	Upload(ctx context.Context, songID uuid.UUID, body io.Reader) (*domain.Blob, error)
+	Get(ctx context.Context, songID uuid.UUID) (*domain.Blob, error)
+}
+
+type CoverHandler struct {
+	Service CoverService
+	log     *slog.Logger
+}
+
+func NewCoverHandler(service CoverService, log *slog.Logger) *CoverHandler {
+	return &CoverHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *CoverHandler) Routes(r chi.Router) {
+	r.Put("/songs/{id}/cover", h.Upload)
+	r.Get("/songs/{id}/cover", h.Get)
+}
+
+// @Summary Upload the cover of a song
+// @Description Store the image sent as the request body as the cover of the song, replacing the previous one. JPEG, PNG, GIF and WebP images are accepted, the type is detected from the content.
+// @Tags covers
+// @Accept  image/jpeg,image/png,image/gif,image/webp
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.CoverResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 413 {object} dto.ErrorResponse "cover is too large"
+// @Failure 415 {object} dto.ErrorResponse "cover is not a supported image"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/cover [put]
+func (h *CoverHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	const op = "CoverHandler.Upload"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	cover, err := h.Service.Upload(r.Context(), songID, r.Body)
+	if err != nil {
+		respondError(w, r, log, "failed to upload cover", err)
+		return
+	}
+
+	log.Info("cover successfully uploaded")
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.CoverResponse{
+		SongID:      songID.String(),
+		ContentType: cover.ContentType,
+		Size:        cover.Size,
+	})
+}
+
+// @Summary Get the cover of a song
+// @Description Get the cover image of the song
+// @Tags covers
+// @Produce  image/jpeg,image/png,image/gif,image/webp
+// @Param id path string true "Song ID"
+// @Success 200 {file} binary "cover image"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song has no cover"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/cover [get]
+func (h *CoverHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "CoverHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	cover, err := h.Service.Get(r.Context(), songID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch cover", err)
+		return
+	}
+	defer cover.Body.Close()
+
+	w.Header().Set("Content-Type", cover.ContentType)
+	if cover.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(cover.Size, 10))
+	}
+	if !cover.ModTime.IsZero() {
+		w.Header().Set("Last-Modified", cover.ModTime.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, cover.Body); err != nil {
+		log.Error("failed to send cover", sl.Err(err))
+	}
+}
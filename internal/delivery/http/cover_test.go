@@ -0,0 +1,134 @@
+package deliveryHttp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCoverRouter(t *testing.T) (http.Handler, *mocks.MockCoverService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockCovers := mocks.NewMockCoverService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewCoverHandler(mockCovers, mockLog))
+
+	return h.InitRoutes(), mockCovers
+}
+
+func TestCoverHandler_Upload(t *testing.T) {
+	router, mockCovers := newCoverRouter(t)
+
+	songID := uuid.New()
+	mockCovers.EXPECT().Upload(gomock.Any(), songID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, body io.Reader) (*domain.Blob, error) {
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, "image", string(data))
+			return &domain.Blob{ContentType: "image/png", Size: 5}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/cover", strings.NewReader("image"))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.CoverResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CoverResponse{SongID: songID.String(), ContentType: "image/png", Size: 5}, resp)
+}
+
+func TestCoverHandler_Upload_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   dto.ErrorCode
+	}{
+		{name: "песня не найдена", err: domain.ErrSongNotFound, status: http.StatusNotFound, code: dto.CodeSongNotFound},
+		{name: "слишком большой файл", err: domain.ErrCoverTooLarge, status: http.StatusRequestEntityTooLarge, code: dto.CodeRequestTooLarge},
+		{name: "не изображение", err: domain.ErrCoverInvalidType, status: http.StatusUnsupportedMediaType, code: dto.CodeUnsupportedMedia},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockCovers := newCoverRouter(t)
+
+			songID := uuid.New()
+			mockCovers.EXPECT().Upload(gomock.Any(), songID, gomock.Any()).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/cover", strings.NewReader("image"))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+
+			var resp dto.ErrorResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.code, resp.Code)
+		})
+	}
+}
+
+func TestCoverHandler_Get(t *testing.T) {
+	router, mockCovers := newCoverRouter(t)
+
+	songID := uuid.New()
+	modTime := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockCovers.EXPECT().Get(gomock.Any(), songID).Return(&domain.Blob{
+		Body:        io.NopCloser(bytes.NewReader([]byte("image"))),
+		ContentType: "image/png",
+		Size:        5,
+		ModTime:     modTime,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/cover", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "5", rec.Header().Get("Content-Length"))
+	assert.Equal(t, modTime.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "image", rec.Body.String())
+}
+
+func TestCoverHandler_Get_NotFound(t *testing.T) {
+	router, mockCovers := newCoverRouter(t)
+
+	songID := uuid.New()
+	mockCovers.EXPECT().Get(gomock.Any(), songID).Return(nil, domain.ErrCoverNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/cover", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeCoverNotFound, resp.Code)
+}
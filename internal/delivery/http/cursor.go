@@ -0,0 +1,4 @@
+package deliveryHttp
+
+// defaultCursorPageSize is used when a cursor request has no page_size
+const defaultCursorPageSize = 20
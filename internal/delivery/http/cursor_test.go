@@ -0,0 +1,158 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetAllWithFilter_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."}
+	next := &domain.SongCursor{CreatedAt: time.Date(2024, 10, 14, 23, 36, 29, 170294000, time.UTC), ID: song.ID}
+
+	gomock.InOrder(
+		mockService.EXPECT().
+			GetAllAfter(gomock.Any(), gomock.Any(), nil, 1).
+			Return([]*domain.Song{song}, next, nil),
+		mockService.EXPECT().
+			GetAllAfter(gomock.Any(), gomock.Any(), next, 1).
+			Return(nil, nil, nil),
+	)
+
+	// Пустой cursor запрашивает первую страницу
+	req := httptest.NewRequest(http.MethodGet, "/songs?cursor=&page_size=1", nil)
+	w := httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var page dto.SongPageResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Len(t, page.Songs, 1)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// Курсор из ответа указывает на следующую страницу
+	req = httptest.NewRequest(http.MethodGet, "/songs?page_size=1&cursor="+page.NextCursor, nil)
+	w = httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	page = dto.SongPageResponse{}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Empty(t, page.Songs)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestHandler_GetAllWithFilter_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{"cursor=not-a-cursor", "cursor=&page=2", "cursor=&sort=popularity"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+			w := httptest.NewRecorder()
+			h.GetAllWithFilter(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp dto.ErrorResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, dto.CodeValidationFailed, resp.Code)
+		})
+	}
+}
+
+func TestHandler_GetAllWithFilter_IfModifiedSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	modified := time.Date(2024, 10, 14, 23, 36, 29, 170294000, time.UTC)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(modified, nil).Times(3)
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		Return(nil, nil).
+		Times(2)
+
+	// Первый запрос получает Last-Modified с точностью до секунды
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+	w := httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	lastModified := w.Header().Get("Last-Modified")
+	assert.Equal(t, "Mon, 14 Oct 2024 23:36:29 GMT", lastModified)
+
+	// Библиотека не менялась с If-Modified-Since
+	req = httptest.NewRequest(http.MethodGet, "/songs?group=Muse", nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	w = httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// Более ранняя дата получает список целиком
+	req = httptest.NewRequest(http.MethodGet, "/songs", nil)
+	req.Header.Set("If-Modified-Since", "Mon, 14 Oct 2024 23:36:28 GMT")
+	w = httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_GetAllWithFilter_ModifiedThisSecond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	// Изменение в текущей секунде: Last-Modified не отдаётся, 304 не возвращается
+	now := time.Now()
+	mockService.EXPECT().LastModified(gomock.Any()).Return(now, nil)
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+	req.Header.Set("If-Modified-Since", now.Add(time.Hour).UTC().Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
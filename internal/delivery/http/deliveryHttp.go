@@ -3,15 +3,22 @@ package deliveryHttp
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"slices"
 	mwLogger "songLibrary/internal/delivery/http/middleware/logger"
+	"songLibrary/internal/delivery/http/middleware/methods"
+	"songLibrary/internal/delivery/http/middleware/recoverer"
+	mwRequestID "songLibrary/internal/delivery/http/middleware/requestid"
 	"songLibrary/internal/domain"
 	"songLibrary/internal/dto"
+	"songLibrary/internal/exporter"
 	"songLibrary/pkg/logger/sl"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
@@ -20,18 +27,39 @@ import (
 )
 
 type Service interface {
-	Add(ctx context.Context, song *domain.SongInfo) error
+	Add(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, []uuid.UUID, error)
+	GetByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
 	Delete(ctx context.Context, song *domain.SongInfo) error
+	BulkUpdate(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) (int, error)
+	Refresh(ctx context.Context, song *domain.SongInfo, force bool) (*domain.Song, domain.SongFields, error)
+	Archive(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	Unarchive(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+
+	GetAllWithFilter(ctx context.Context, filter *domain.SongFilter, page, pageSize int) ([]*domain.Song, error)
+	GetAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, pageSize int) ([]*domain.Song, *domain.SongCursor, error)
+	LastModified(ctx context.Context) (time.Time, error)
+	GetDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error)
+	GetPaginatedText(ctx context.Context, song *domain.SongInfo, split domain.SplitStrategy) (*domain.Lyrics, error)
+	GetTextPage(ctx context.Context, song *domain.SongInfo, split domain.SplitStrategy, page, versesPerPage int) (*domain.LyricsPage, error)
+	SearchText(ctx context.Context, song *domain.SongInfo, query string, split domain.SplitStrategy) ([]*domain.VerseMatch, error)
+
+	Import(ctx context.Context, file io.Reader, dryRun bool) (*domain.ImportReport, error)
+	Export(ctx context.Context, song *domain.SongInfo, format exporter.Format) (*exporter.Document, error)
+}
 
-	GetAllWithFilter(ctx context.Context, song *domain.Song, page, pageSize int) ([]*domain.Song, error)
-	GetPaginatedText(ctx context.Context, song *domain.SongInfo) ([]string, error)
+// Router registers the routes of a separate resource handler
+type Router interface {
+	Routes(r chi.Router)
 }
 
 type Handler struct {
-	Service Service
-	log     *slog.Logger
+	Service     Service
+	log         *slog.Logger
+	middlewares []func(http.Handler) http.Handler
+	routers     []Router
 }
 
 func NewHandler(service Service, log *slog.Logger) *Handler {
@@ -41,37 +69,93 @@ func NewHandler(service Service, log *slog.Logger) *Handler {
 	}
 }
 
-func (h *Handler) InitRoutes() *chi.Mux {
-	r := chi.NewRouter()
+// Use registers middlewares applied to every route after the default ones.
+// It must be called before InitRoutes.
+func (h *Handler) Use(middlewares ...func(http.Handler) http.Handler) {
+	h.middlewares = append(h.middlewares, middlewares...)
+}
 
-	// r.Use(middleware.RequestID)
-	r.Use(middleware.Logger)
-	r.Use(mwLogger.New(h.log))
-	r.Use(middleware.Recoverer)
+// Register adds handlers of other resources to the router built by InitRoutes.
+// It must be called before InitRoutes.
+func (h *Handler) Register(routers ...Router) {
+	h.routers = append(h.routers, routers...)
+}
+
+func (h *Handler) InitRoutes() *chi.Mux {
+	r := newRouter(h.log)
+	r.Use(methods.New(h.log, r))
+	r.Use(h.middlewares...)
 
 	r.Route("/songs", func(r chi.Router) {
 		r.Post("/", h.Add)
+		r.Post("/import", h.Import)
+		r.Post("/batch-get", h.BatchGet)
+		r.Get("/duplicates", h.GetDuplicates)
+		r.Get("/lookup", h.Lookup)
 		r.Get("/{id}", h.Get)
 		r.Put("/{id}", h.Update)
 		r.Delete("/{id}", h.Delete)
+		r.Post("/{id}/refresh", h.Refresh)
+		r.Post("/{id}/archive", h.Archive)
+		r.Post("/{id}/unarchive", h.Unarchive)
 		r.Get("/", h.GetAllWithFilter)
+		r.Patch("/", h.BulkUpdate)
 		r.Get("/{id}/text", h.GetPaginatedText)
+		r.Get("/{id}/text/search", h.SearchText)
+		r.Get("/{id}/text/{verse_number}", h.GetVerse)
+		r.Get("/{id}/export", h.Export)
 	})
 
+	r.Handle("/graphql", h.graphQL())
+
+	for _, router := range h.routers {
+		router.Routes(r)
+	}
+
 	r.Get("/ping", h.Ping)
 
 	return r
 }
 
+// InitInternalRoutes builds the router of operational routes, like /admin
+// and /metrics, served apart from the public API with their own middlewares
+func InitInternalRoutes(log *slog.Logger, middlewares []func(http.Handler) http.Handler, routers ...Router) *chi.Mux {
+	r := newRouter(log)
+	r.Use(middlewares...)
+
+	for _, router := range routers {
+		router.Routes(r)
+	}
+
+	return r
+}
+
+// newRouter creates a router with the default middlewares
+func newRouter(log *slog.Logger) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequestID)
+	r.Use(mwRequestID.New())
+	r.Use(middleware.Logger)
+	r.Use(mwLogger.New(log))
+	r.Use(recoverer.New(log))
+
+	return r
+}
+
 // @Summary Add a new song
 // @Description Add a new song to the library
 // @Tags songs
 // @Accept  json
 // @Produce  json
 // @Param song body dto.AddSongRequest true "Add song request"
-// @Success 201 {object} map[string]string "song added successfully"
-// @Failure 400 {object} map[string]string "invalid request"
-// @Failure 500 {object} map[string]string "internal error"
+// @Success 201 {object} dto.AddSongResponse "song added successfully"
+// @Success 202 {object} dto.AddSongResponse "song added, its details are fetched later as the provider is unavailable"
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 422 {object} dto.ErrorResponse "song details provider does not know the song"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 502 {object} dto.ErrorResponse "song details provider returned an invalid response"
+// @Failure 504 {object} dto.ErrorResponse "song details provider did not respond in time"
 // @Router /songs [post]
 func (h *Handler) Add(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.Add"
@@ -83,16 +167,13 @@ func (h *Handler) Add(w http.ResponseWriter, r *http.Request) {
 
 	var req dto.AddSongRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error("failed to decode request", sl.Err(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("invalid request"))
+		respondDecodeError(w, r, log, err)
 		return
 	}
 
 	if req.Name == "" || req.Group == "" {
 		log.Info("name or group is missing in request")
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("name and group are required"))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "name and group are required", nil)
 		return
 	}
 
@@ -101,28 +182,42 @@ func (h *Handler) Add(w http.ResponseWriter, r *http.Request) {
 		Group: req.Group,
 	}
 
-	if err := h.Service.Add(r.Context(), songInfo); err != nil {
-		log.Error("failed to add song", sl.Err(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrResp("internal error"))
+	song, err := h.Service.Add(r.Context(), songInfo)
+	if err != nil {
+		respondError(w, r, log, "failed to add song", err)
+		return
+	}
+
+	if song.Status == domain.SongStatusPending {
+		log.Info("song added, details pending", slog.String("song_name", songInfo.Name))
+		render.Status(r, http.StatusAccepted)
+		respond(w, r, &dto.AddSongResponse{
+			ID:      song.ID.String(),
+			Status:  dto.SongStatusPendingEnrichment,
+			Message: "song added, details will be fetched when the song details provider is available",
+		})
 		return
 	}
 
 	log.Info("song successfully added", slog.String("song_name", songInfo.Name))
 	render.Status(r, http.StatusCreated)
-	render.JSON(w, r, OkResp("song added successfully"))
+	respond(w, r, &dto.AddSongResponse{ID: song.ID.String(), Status: dto.SongStatusAdded, Message: "song added successfully"})
 }
 
 // @Summary Get a song
 // @Description Get song by ID
 // @Tags songs
 // @Accept  json
-// @Produce  json
+// @Produce  json,xml,application/yaml
 // @Param id path string true "Song ID"
+// @Param If-None-Match header string false "ETag of a cached revision"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
 // @Success 200 {object} dto.SongResponse
-// @Failure 400 {object} map[string]string "invalid song id"
-// @Failure 404 {object} map[string]string "song not found"
-// @Failure 500 {object} map[string]string "internal error"
+// @Header 200 {string} ETag "revision of the song"
+// @Success 304 "song not modified"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
 // @Router /songs/{id} [get]
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.Get"
@@ -132,37 +227,110 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
 	idParam := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
 		log.Error("invalid song id", sl.Err(err))
-		render.JSON(w, r, ErrResp("invalid song id"))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
 		return
 	}
 
 	songInfo := &domain.SongInfo{ID: id}
 	song, err := h.Service.Get(r.Context(), songInfo)
-	if errors.Is(err, domain.ErrSongNotFound) {
-		log.Info("song not found", slog.String("id", id.String()))
-		render.JSON(w, r, ErrResp("song not found"))
+	if err != nil {
+		respondError(w, r, log, "failed to get song", err)
 		return
 	}
-	if err != nil {
-		log.Error("failed to get song", sl.Err(err))
-		render.JSON(w, r, ErrResp("internal error"))
+
+	etag := SongETag(song, negotiateContentType(r))
+	w.Header().Set("ETag", etag)
+	varyAccept(w)
+	if notModified(r, etag) {
+		log.Info("song not modified", slog.String("id", id.String()))
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
 	convSong, err := ConvertSongToResponse(song)
 	if err != nil {
-		log.Error("failed to convert song into response", sl.Err(err))
-		render.JSON(w, r, ErrResp("conversion error"))
+		respondError(w, r, log, "failed to convert song into response", err)
+		return
 	}
 
 	log.Info("song successfully fetched", slog.String("song_name", song.Name))
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, convSong)
+	respondFields(w, r, log, convSong, fields)
+}
+
+// @Summary Look up a song
+// @Description Get song by its name and group, both compared ignoring case
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param name query string true "Song name"
+// @Param group query string true "Group"
+// @Param If-None-Match header string false "ETag of a cached revision"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "revision of the song"
+// @Success 304 "song not modified"
+// @Failure 400 {object} dto.ErrorResponse "name or group is missing"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/lookup [get]
+func (h *Handler) Lookup(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.Lookup"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	songInfo := &domain.SongInfo{
+		Name:  r.URL.Query().Get("name"),
+		Group: r.URL.Query().Get("group"),
+	}
+	if songInfo.Name == "" || songInfo.Group == "" {
+		log.Info("name or group is missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "name and group are required", nil)
+		return
+	}
+
+	song, err := h.Service.GetByNameAndGroup(r.Context(), songInfo)
+	if err != nil {
+		respondError(w, r, log, "failed to look up song", err)
+		return
+	}
+
+	etag := SongETag(song, negotiateContentType(r))
+	w.Header().Set("ETag", etag)
+	varyAccept(w)
+	if notModified(r, etag) {
+		log.Info("song not modified", slog.String("id", song.ID.String()))
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	convSong, err := ConvertSongToResponse(song)
+	if err != nil {
+		respondError(w, r, log, "failed to convert song into response", err)
+		return
+	}
+
+	log.Info("song successfully looked up", slog.String("id", song.ID.String()))
+
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, convSong, fields)
 }
 
 // @Summary Update a song
@@ -172,10 +340,13 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path string true "Song ID"
 // @Param song body dto.UpdateSongRequest true "Update song request"
-// @Success 200 {object} map[string]string "song updated successfully"
-// @Failure 400 {object} map[string]string "invalid request or invalid song id"
-// @Failure 404 {object} map[string]string "song not found"
-// @Failure 500 {object} map[string]string "internal error"
+// @Param If-Match header string false "ETag the update is based on"
+// @Success 200 {object} dto.MessageResponse "song updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid request or invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song or album not found"
+// @Failure 409 {object} dto.ErrorResponse "song was modified by another request"
+// @Failure 412 {object} dto.ErrorResponse "song was modified"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
 // @Router /songs/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.Update"
@@ -189,43 +360,62 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(idParam)
 	if err != nil {
 		log.Error("invalid song id", sl.Err(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("invalid song id"))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
 		return
 	}
 
 	var req dto.UpdateSongRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error("failed to decode request", sl.Err(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("invalid request"))
+		respondDecodeError(w, r, log, err)
 		return
 	}
 
 	songInfo := &domain.SongInfo{ID: id}
+
+	// Reject the update if the client edited a stale revision of the song
+	if r.Header.Get("If-Match") != "" {
+		current, err := h.Service.Get(r.Context(), songInfo)
+		if err != nil {
+			respondError(w, r, log, "failed to get song", err)
+			return
+		}
+
+		if preconditionFailed(r, SongETag(current, negotiateContentType(r))) {
+			log.Info("song was modified since the client read it", slog.String("id", id.String()))
+			render.Status(r, http.StatusPreconditionFailed)
+			respond(w, r, ErrResp(r, dto.CodePreconditionFailed, "song was modified", nil))
+			return
+		}
+	}
+
+	if req.DurationMs < 0 || req.TrackNumber < 0 {
+		log.Warn("negative duration or track number", slog.Int64("duration_ms", req.DurationMs), slog.Int("track_number", req.TrackNumber))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "duration_ms and track_number must not be negative", nil)
+		return
+	}
+
 	song := &domain.Song{
-		Name:  req.Name,
-		Group: req.Group,
-		Text:  req.Text,
-		Link:  req.Link,
+		Name:        req.Name,
+		Group:       req.Group,
+		Text:        req.Text,
+		Link:        req.Link,
+		Version:     req.Version,
+		AlbumID:     req.AlbumID,
+		Duration:    time.Duration(req.DurationMs) * time.Millisecond,
+		Genre:       req.Genre,
+		TrackNumber: req.TrackNumber,
+		Album:       req.Album,
+		Explicit:    req.Explicit,
 	}
 
 	if err := h.Service.Update(r.Context(), songInfo, song); err != nil {
-		if errors.Is(err, domain.ErrSongNotFound) {
-			log.Info("song not found during update", sl.Err(err))
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, ErrResp("song not found"))
-			return
-		}
-		log.Error("failed to update song", sl.Err(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrResp("internal error"))
+		respondError(w, r, log, "failed to update song", err)
 		return
 	}
 
 	log.Info("song successfully updated", slog.String("song_name", songInfo.Name))
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, OkResp("song updated successfully"))
+	respond(w, r, OkResp("song updated successfully"))
 }
 
 // @Summary Delete a song
@@ -234,10 +424,10 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Song ID"
-// @Success 200 {object} map[string]string "song deleted successfully"
-// @Failure 400 {object} map[string]string "invalid song id"
-// @Failure 404 {object} map[string]string "song not found"
-// @Failure 500 {object} map[string]string "internal error"
+// @Success 200 {object} dto.MessageResponse "song deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
 // @Router /songs/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.Delete"
@@ -251,44 +441,110 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(idParam)
 	if err != nil {
 		log.Error("invalid song id", sl.Err(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("invalid song id"))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
 		return
 	}
 
 	songInfo := &domain.SongInfo{ID: id}
 
 	if err := h.Service.Delete(r.Context(), songInfo); err != nil {
-		if errors.Is(err, domain.ErrSongNotFound) {
-			log.Info("song not found during deletion", sl.Err(err))
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, ErrResp("song not found"))
+		respondError(w, r, log, "failed to delete song", err)
+		return
+	}
+
+	log.Info("song successfully deleted", slog.String("song_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("song deleted successfully"))
+}
+
+// @Summary Refresh a song from MusicInfo
+// @Description Fetch the song details from MusicInfo again and take the text, link, release date and metadata (duration, genre, track number, album, explicit flag) that changed there. Fields edited through PUT /songs/{id} are kept unless force is set, which overwrites and unlocks them.
+// @Tags songs
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param force query bool false "Overwrite locally edited fields too"
+// @Success 200 {object} dto.RefreshSongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or force parameter"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 409 {object} dto.ErrorResponse "song was modified by another request"
+// @Failure 422 {object} dto.ErrorResponse "song details provider does not know the song"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 502 {object} dto.ErrorResponse "song details provider returned an invalid response"
+// @Failure 504 {object} dto.ErrorResponse "song details provider did not respond in time"
+// @Router /songs/{id}/refresh [post]
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.Refresh"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	force := false
+	if forceStr := r.URL.Query().Get("force"); forceStr != "" {
+		force, err = strconv.ParseBool(forceStr)
+		if err != nil {
+			log.Warn("invalid force parameter", slog.String("force", forceStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid force parameter", nil)
 			return
 		}
-		log.Error("failed to delete song", sl.Err(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrResp("internal error"))
+	}
+
+	song, changed, err := h.Service.Refresh(r.Context(), &domain.SongInfo{ID: id}, force)
+	if err != nil {
+		respondError(w, r, log, "failed to refresh song", err)
 		return
 	}
 
-	log.Info("song successfully deleted", slog.String("song_id", id.String()))
+	log.Info("song successfully refreshed", slog.String("song_id", id.String()), slog.Any("changed", changed.Names()))
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, OkResp("song deleted successfully"))
+	respond(w, r, dto.RefreshSongResponse{Song: *songToResponse(song), Changed: changed.Names()})
 }
 
 // @Summary Get all songs with filters
-// @Description Get a list of songs with optional filters for group, name, and release date, with pagination
+// @Description Get a list of songs with optional filters for group, name, text, link, release date or range, genre, album, explicit flag, status, duration, rating and tags, with pagination.
+// @Description Passing cursor (empty for the first page) switches to keyset pagination: the response is a dto.SongPageResponse with next_cursor for the following page instead of an array, page and sort=popularity or sort=rating aren't allowed.
 // @Tags songs
 // @Accept  json
-// @Produce  json
+// @Produce  json,xml,application/yaml
 // @Param group query string false "Filter by group"
+// @Param artist_id query string false "Filter by artist ID"
 // @Param song query string false "Filter by song name"
+// @Param text query string false "Filter by a substring of the song text, case-insensitive"
+// @Param link query string false "Filter by a substring of the link, case-insensitive"
 // @Param release_date query string false "Filter by release date (YYYY-MM-DD)"
+// @Param released_from query string false "Released on or after the date (YYYY-MM-DD)"
+// @Param released_to query string false "Released on or before the date (YYYY-MM-DD)"
+// @Param genre query string false "Filter by genre, case-insensitive"
+// @Param album query string false "Filter by album title"
+// @Param explicit query bool false "Filter by the explicit flag"
+// @Param status query string false "Filter by the lifecycle status" Enums(pending, enriched, failed, archived)
+// @Param include_archived query bool false "List archived songs too, they are left out unless status is set"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Param min_duration query int false "Minimum duration in seconds"
+// @Param max_duration query int false "Maximum duration in seconds"
+// @Param min_rating query number false "Minimum average rating from 1 to 5, unrated songs are left out"
+// @Param tags query string false "Filter by comma separated tags"
+// @Param tags_mode query string false "Whether songs must have all of the tags or any of them (default all)" Enums(all, any)
+// @Param sort query string false "Sort order, rating puts the best rated songs first" Enums(created_at, popularity, rating)
 // @Param page query int false "Page number"
 // @Param page_size query int false "Number of songs per page"
+// @Param cursor query string false "Cursor of the page, next_cursor of the previous response"
+// @Param include_text query bool false "Include the text of the songs, left out by default"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Param If-Modified-Since header string false "Last-Modified of a cached listing"
 // @Success 200 {array} dto.SongResponse
-// @Failure 400 {object} map[string]string "invalid page or page_size parameter"
-// @Failure 500 {object} map[string]string "internal error"
+// @Header 200 {string} Last-Modified "time the songs of the library were last modified"
+// @Success 304 "songs not modified"
+// @Failure 400 {object} dto.ErrorResponse "invalid filter, page, page_size or cursor parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
 // @Router /songs [get]
 func (h *Handler) GetAllWithFilter(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.GetAllWithFilter"
@@ -300,7 +556,20 @@ func (h *Handler) GetAllWithFilter(w http.ResponseWriter, r *http.Request) {
 
 	group := r.URL.Query().Get("group")
 	name := r.URL.Query().Get("song")
+	text := r.URL.Query().Get("text")
+	link := r.URL.Query().Get("link")
 	releaseDateStr := r.URL.Query().Get("release_date")
+	artistIDStr := r.URL.Query().Get("artist_id")
+	genre := r.URL.Query().Get("genre")
+	album := r.URL.Query().Get("album")
+	explicitStr := r.URL.Query().Get("explicit")
+	status := domain.SongStatus(r.URL.Query().Get("status"))
+	minDurationStr := r.URL.Query().Get("min_duration")
+	maxDurationStr := r.URL.Query().Get("max_duration")
+	minRatingStr := r.URL.Query().Get("min_rating")
+	tagsStr := r.URL.Query().Get("tags")
+	tagMode := domain.TagMode(r.URL.Query().Get("tags_mode"))
+	sort := domain.SongSort(r.URL.Query().Get("sort"))
 
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("page_size")
@@ -314,8 +583,7 @@ func (h *Handler) GetAllWithFilter(w http.ResponseWriter, r *http.Request) {
 		page, err = strconv.Atoi(pageStr)
 		if err != nil || page <= 0 {
 			log.Warn("invalid page parameter", slog.String("page", pageStr))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, ErrResp("invalid page parameter"))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid page parameter", nil)
 			return
 		}
 	}
@@ -325,8 +593,7 @@ func (h *Handler) GetAllWithFilter(w http.ResponseWriter, r *http.Request) {
 		pageSize, err = strconv.Atoi(pageSizeStr)
 		if err != nil || pageSize <= 0 {
 			log.Warn("invalid page_size parameter", slog.String("page_size", pageSizeStr))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, ErrResp("invalid page_size parameter"))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid page_size parameter", nil)
 			return
 		}
 	}
@@ -337,55 +604,288 @@ func (h *Handler) GetAllWithFilter(w http.ResponseWriter, r *http.Request) {
 		releaseDate, err = time.Parse("2006-01-02", releaseDateStr) // Используем формат YYYY-MM-DD
 		if err != nil {
 			log.Warn("invalid release_date parameter", slog.String("release_date", releaseDateStr))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, ErrResp("invalid release_date parameter"))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid release_date parameter", nil)
 			return
 		}
 	}
 
-	songSearch := &domain.Song{
-		Name:        name,
-		Group:       group,
-		ReleaseDate: releaseDate, // Передаем дату релиза в объект поиска
+	// Обработка параметров released_from и released_to (включительный диапазон дат релиза)
+	var releasedFrom, releasedTo time.Time
+	for _, date := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"released_from", &releasedFrom},
+		{"released_to", &releasedTo},
+	} {
+		value := r.URL.Query().Get(date.name)
+		if value == "" {
+			continue
+		}
+		*date.dst, err = time.Parse(time.DateOnly, value)
+		if err != nil {
+			log.Warn("invalid "+date.name+" parameter", slog.String(date.name, value))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid "+date.name+" parameter", nil)
+			return
+		}
+	}
+	if !releasedFrom.IsZero() && !releasedTo.IsZero() && releasedFrom.After(releasedTo) {
+		log.Warn("released_from is after released_to")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "released_from must not be after released_to", nil)
+		return
+	}
+
+	// Обработка параметра artist_id
+	var artistID uuid.UUID
+	if artistIDStr != "" {
+		artistID, err = uuid.Parse(artistIDStr)
+		if err != nil {
+			log.Warn("invalid artist_id parameter", slog.String("artist_id", artistIDStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid artist_id parameter", nil)
+			return
+		}
+	}
+
+	// Обработка параметра explicit
+	var explicit *bool
+	if explicitStr != "" {
+		value, err := strconv.ParseBool(explicitStr)
+		if err != nil {
+			log.Warn("invalid explicit parameter", slog.String("explicit", explicitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid explicit parameter", nil)
+			return
+		}
+		explicit = &value
+	}
+
+	// Обработка параметра status
+	if status != "" && !status.Valid() {
+		log.Warn("invalid status parameter", slog.String("status", string(status)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid status parameter", nil)
+		return
+	}
+
+	// Обработка параметра include_archived, архивные песни скрыты, если статус не задан
+	includeArchived := false
+	if includeArchivedStr := r.URL.Query().Get("include_archived"); includeArchivedStr != "" {
+		includeArchived, err = strconv.ParseBool(includeArchivedStr)
+		if err != nil {
+			log.Warn("invalid include_archived parameter", slog.String("include_archived", includeArchivedStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid include_archived parameter", nil)
+			return
+		}
+	}
+
+	excludeExplicit, ok := excludeExplicitParam(w, r, log)
+	if !ok {
+		return
+	}
+	if excludeExplicit && explicit != nil && *explicit {
+		log.Warn("explicit=true is combined with exclude_explicit")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "explicit=true can't be combined with exclude_explicit", nil)
+		return
+	}
+
+	// Обработка параметров min_duration и max_duration (в секундах)
+	minDuration, ok := parseDurationParam(minDurationStr)
+	if !ok {
+		log.Warn("invalid min_duration parameter", slog.String("min_duration", minDurationStr))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid min_duration parameter", nil)
+		return
+	}
+	maxDuration, ok := parseDurationParam(maxDurationStr)
+	if !ok || (maxDuration > 0 && maxDuration < minDuration) {
+		log.Warn("invalid max_duration parameter", slog.String("max_duration", maxDurationStr))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid max_duration parameter", nil)
+		return
+	}
+
+	// Обработка параметра min_rating
+	var minRating float64
+	if minRatingStr != "" {
+		minRating, err = strconv.ParseFloat(minRatingStr, 64)
+		if err != nil || minRating < domain.MinRating || minRating > domain.MaxRating {
+			log.Warn("invalid min_rating parameter", slog.String("min_rating", minRatingStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid min_rating parameter", nil)
+			return
+		}
+	}
+
+	// Обработка параметров tags и tags_mode
+	var tags []string
+	if tagsStr != "" {
+		tags, err = domain.NormalizeTags(strings.Split(tagsStr, ","))
+		if err != nil {
+			log.Warn("invalid tags parameter", slog.String("tags", tagsStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid tags parameter", nil)
+			return
+		}
+	}
+
+	switch tagMode {
+	case "":
+		tagMode = domain.TagModeAll
+	case domain.TagModeAll, domain.TagModeAny:
+	default:
+		log.Warn("invalid tags_mode parameter", slog.String("tags_mode", string(tagMode)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid tags_mode parameter", nil)
+		return
+	}
+
+	// Обработка параметра sort
+	switch sort {
+	case "":
+		sort = domain.SortByCreatedAt
+	case domain.SortByCreatedAt, domain.SortByPopularity, domain.SortByRating:
+	default:
+		log.Warn("invalid sort parameter", slog.String("sort", string(sort)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid sort parameter", nil)
+		return
+	}
+
+	// Обработка параметра cursor, пустое значение запрашивает первую страницу
+	useCursor := r.URL.Query().Has("cursor")
+	var cursor *domain.SongCursor
+	if useCursor {
+		if page != 0 || sort != domain.SortByCreatedAt {
+			log.Warn("cursor is combined with page or sort")
+			respondBadRequest(w, r, dto.CodeValidationFailed, "cursor can't be combined with page or sort", nil)
+			return
+		}
+
+		if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+			cursor, err = domain.ParseSongCursor(cursorStr)
+			if err != nil {
+				log.Warn("invalid cursor parameter", slog.String("cursor", cursorStr))
+				respondBadRequest(w, r, dto.CodeValidationFailed, "invalid cursor parameter", nil)
+				return
+			}
+		}
+
+		if pageSize == 0 {
+			pageSize = defaultCursorPageSize
+		}
+	}
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	// Обработка параметра include_text, текст читается и при его выборе в fields
+	includeText := slices.Contains(fields, "text")
+	if includeTextStr := r.URL.Query().Get("include_text"); includeTextStr != "" {
+		include, err := strconv.ParseBool(includeTextStr)
+		if err != nil {
+			log.Warn("invalid include_text parameter", slog.String("include_text", includeTextStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid include_text parameter", nil)
+			return
+		}
+		includeText = includeText || include
+	}
+
+	filter := &domain.SongFilter{
+		Name:         name,
+		Group:        group,
+		Text:         text,
+		Link:         link,
+		ArtistID:     artistID,
+		ReleaseDate:  releaseDate, // Передаем дату релиза в фильтр
+		ReleasedFrom: releasedFrom,
+		ReleasedTo:   releasedTo,
+		Genre:        genre,
+		Album:        album,
+		Explicit:     explicit,
+		Status:       status,
+		Tags:         tags,
+		TagMode:      tagMode,
+		MinDuration:  minDuration,
+		MaxDuration:  maxDuration,
+		MinRating:    minRating,
+
+		ExcludeExplicit: excludeExplicit,
+		ExcludeArchived: status == "" && !includeArchived,
+		Sort:            sort,
+		WithoutText:     !includeText,
 	}
 
 	log.Info("attempting to fetch songs with filters",
 		slog.String("group", group),
 		slog.String("name", name),
+		slog.Bool("text", text != ""),
+		slog.String("link", link),
 		slog.String("release_date", releaseDateStr),
+		slog.String("genre", genre),
+		slog.String("album", album),
+		slog.String("explicit", explicitStr),
+		slog.String("status", string(status)),
+		slog.Bool("include_archived", includeArchived),
+		slog.Bool("exclude_explicit", excludeExplicit),
+		slog.String("min_rating", minRatingStr),
+		slog.String("tags", tagsStr),
+		slog.Bool("include_text", includeText),
 		slog.Int("page", page),
 		slog.Int("page_size", pageSize),
 	)
 
-	songs, err := h.Service.GetAllWithFilter(r.Context(), songSearch, page, pageSize)
+	// Условный запрос: библиотека не менялась с If-Modified-Since
+	modified, err := h.Service.LastModified(r.Context())
 	if err != nil {
-		log.Error("failed to fetch songs with filter", sl.Err(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrResp("internal error"))
+		log.Warn("failed to fetch library modification time", sl.Err(err))
+	} else if notModifiedSince(w, r, modified) {
+		log.Info("songs not modified")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	var songs []*domain.Song
+	var next *domain.SongCursor
+	if useCursor {
+		songs, next, err = h.Service.GetAllAfter(r.Context(), filter, cursor, pageSize)
+	} else {
+		songs, err = h.Service.GetAllWithFilter(r.Context(), filter, page, pageSize)
+	}
+	if err != nil {
+		respondError(w, r, log, "failed to fetch songs with filter", err)
 		return
 	}
 
 	var songsResponse []dto.SongResponse
 	for _, song := range songs {
-		songsResponse = append(songsResponse, *MustConvertSongToResponse(song))
+		songsResponse = append(songsResponse, *songToResponse(song))
 	}
 
 	log.Info("songs successfully fetched", slog.Int("count", len(songsResponse)))
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, songsResponse)
+	if !useCursor {
+		respondFields(w, r, log, songsResponse, fields)
+		return
+	}
+
+	pageResponse := dto.SongPageResponse{Songs: songsResponse}
+	if pageResponse.Songs == nil {
+		pageResponse.Songs = []dto.SongResponse{}
+	}
+	if next != nil {
+		pageResponse.NextCursor = next.String()
+	}
+	respondFields(w, r, log, pageResponse, fields, "songs")
 }
 
 // @Summary Get paginated text of a song
-// @Description Get paginated text of the song by ID
+// @Description Get the text of the song by ID split into sections. text lists the sections without their markers, sections also give the type (intro, verse, chorus, bridge or outro) and index of each. With verses_per_page the sections are split into pages of that many sections and only the requested page is returned, page describes it and links to the neighbouring pages.
 // @Tags songs
 // @Accept  json
-// @Produce  json
+// @Produce  json,xml,application/yaml
 // @Param id path string true "Song ID"
+// @Param split query string false "How the text is split into sections, the default of the library if not set" Enums(blank_lines, markers, lines)
+// @Param verses_per_page query int false "Number of sections per page, the whole text if not set" minimum(1)
+// @Param page query int false "Page number, from 1, requires verses_per_page" minimum(1) default(1)
 // @Success 200 {object} dto.PaginatedTextResponse
-// @Failure 400 {object} map[string]string "invalid song id"
-// @Failure 404 {object} map[string]string "song not found"
-// @Failure 500 {object} map[string]string "internal error"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id, verses per page or page number"
+// @Failure 404 {object} dto.ErrorResponse "song or page not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
 // @Router /songs/{id}/text [get]
 func (h *Handler) GetPaginatedText(w http.ResponseWriter, r *http.Request) {
 	const op = "Handler.GetPaginatedText"
@@ -399,30 +899,200 @@ func (h *Handler) GetPaginatedText(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(idParam)
 	if err != nil {
 		log.Error("invalid song id", sl.Err(err))
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrResp("invalid song id"))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	split, ok := splitParam(w, r, log)
+	if !ok {
 		return
 	}
 
 	songInfo := &domain.SongInfo{ID: id}
 
-	verses, err := h.Service.GetPaginatedText(r.Context(), songInfo)
-	if err != nil {
-		if errors.Is(err, domain.ErrSongNotFound) {
-			log.Info("song not found", sl.Err(err))
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, ErrResp("song not found"))
+	// Параметры разбиения на страницы по нескольку куплетов
+	query := r.URL.Query()
+	if versesParam := query.Get("verses_per_page"); versesParam != "" {
+		versesPerPage, err := strconv.Atoi(versesParam)
+		if err != nil || versesPerPage < 1 {
+			log.Info("invalid verses per page", slog.String("verses_per_page", versesParam))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "verses_per_page must be a positive integer", nil)
 			return
 		}
-		log.Error("failed to paginate song text", sl.Err(err))
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrResp("internal error"))
+
+		page := 1
+		if pageParam := query.Get("page"); pageParam != "" {
+			page, err = strconv.Atoi(pageParam)
+			if err != nil || page < 1 {
+				log.Info("invalid page number", slog.String("page", pageParam))
+				respondBadRequest(w, r, dto.CodeValidationFailed, "page must be a positive integer", nil)
+				return
+			}
+		}
+
+		lyricsPage, err := h.Service.GetTextPage(r.Context(), songInfo, split, page, versesPerPage)
+		if err != nil {
+			respondError(w, r, log, "failed to paginate song text", err)
+			return
+		}
+
+		log.Info("song text page successfully fetched", slog.String("song_id", id.String()), slog.Int("page", page))
+		render.Status(r, http.StatusOK)
+		respond(w, r, dto.LyricsPageToPaginatedText(lyricsPage, "/songs/"+id.String()+"/text", query))
+		return
+	}
+	if query.Get("page") != "" {
+		log.Info("page without verses per page")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "page requires verses_per_page", nil)
+		return
+	}
+
+	lyrics, err := h.Service.GetPaginatedText(r.Context(), songInfo, split)
+	if err != nil {
+		respondError(w, r, log, "failed to paginate song text", err)
 		return
 	}
 
 	log.Info("song text successfully paginated", slog.String("song_id", id.String()))
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, dto.PaginatedTextResponse{Text: verses})
+	respond(w, r, dto.LyricsToPaginatedText(lyrics))
+}
+
+// @Summary Get a verse of a song
+// @Description Get one section of the text of the song by ID, numbered from 1 in the order of the text list of GET /songs/{id}/text. prev and next link to the neighbouring sections and are left out at the ends.
+// @Tags songs
+// @Accept  json
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param verse_number path int true "Verse number, from 1"
+// @Param split query string false "How the text is split into sections, the default of the library if not set" Enums(blank_lines, markers, lines)
+// @Success 200 {object} dto.VerseResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or verse number"
+// @Failure 404 {object} dto.ErrorResponse "song or verse not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/text/{verse_number} [get]
+func (h *Handler) GetVerse(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.GetVerse"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	number, err := strconv.Atoi(chi.URLParam(r, "verse_number"))
+	if err != nil || number < 1 {
+		log.Info("invalid verse number", slog.String("verse_number", chi.URLParam(r, "verse_number")))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "verse number must be a positive integer", nil)
+		return
+	}
+
+	split, ok := splitParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	lyrics, err := h.Service.GetPaginatedText(r.Context(), &domain.SongInfo{ID: id}, split)
+	if err != nil {
+		respondError(w, r, log, "failed to paginate song text", err)
+		return
+	}
+
+	verse, err := lyrics.Verse(number)
+	if err != nil {
+		respondError(w, r, log, "failed to get song verse", err)
+		return
+	}
+
+	log.Info("song verse successfully fetched", slog.String("song_id", id.String()), slog.Int("verse_number", number))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.LyricsToVerse(lyrics, verse, number, "/songs/"+id.String()+"/text"))
+}
+
+// @Summary Search the text of a song
+// @Description Find the sections of the text of the song by ID with lines containing q, ignoring case and repeated whitespace. Sections are numbered like in GET /songs/{id}/text/{verse_number} and lines from 1 within their section.
+// @Tags songs
+// @Accept  json
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param q query string true "Text to find, at most 200 characters"
+// @Param split query string false "How the text is split into sections, the default of the library if not set" Enums(blank_lines, markers, lines)
+// @Success 200 {object} dto.VerseSearchResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or q"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/text/search [get]
+func (h *Handler) SearchText(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.SearchText"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	if strings.TrimSpace(query) == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		log.Warn("invalid q parameter", slog.String("q", query))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "q is required and must be at most 200 characters", nil)
+		return
+	}
+
+	split, ok := splitParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	matches, err := h.Service.SearchText(r.Context(), &domain.SongInfo{ID: id}, query, split)
+	if err != nil {
+		respondError(w, r, log, "failed to search song text", err)
+		return
+	}
+
+	log.Info("song text successfully searched", slog.String("song_id", id.String()), slog.Int("count", len(matches)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.VerseMatchesToResponse(matches, "/songs/"+id.String()+"/text"))
+}
+
+// splitParam reads the split strategy of the song text, empty if the request
+// doesn't choose one
+func splitParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (domain.SplitStrategy, bool) {
+	split := domain.SplitStrategy(r.URL.Query().Get("split"))
+	if split != "" && !split.Valid() {
+		log.Warn("invalid split parameter", slog.String("split", string(split)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "split must be blank_lines, markers or lines", nil)
+		return "", false
+	}
+	return split, true
+}
+
+// excludeExplicitParam reads whether the songs flagged explicit are dropped
+func excludeExplicitParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (bool, bool) {
+	excludeStr := r.URL.Query().Get("exclude_explicit")
+	if excludeStr == "" {
+		return false, true
+	}
+	exclude, err := strconv.ParseBool(excludeStr)
+	if err != nil {
+		log.Warn("invalid exclude_explicit parameter", slog.String("exclude_explicit", excludeStr))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid exclude_explicit parameter", nil)
+		return false, false
+	}
+	return exclude, true
 }
 
 func (h *Handler) Ping(w http.ResponseWriter, r *http.Request) {
@@ -434,7 +1104,7 @@ func (h *Handler) Ping(w http.ResponseWriter, r *http.Request) {
 	)
 	log.Info("ping sent")
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, "pong")
+	respond(w, r, "pong")
 }
 
 func ConvertSongToResponse(song *domain.Song) (*dto.SongResponse, error) {
@@ -450,10 +1120,33 @@ func ConvertSongToResponse(song *domain.Song) (*dto.SongResponse, error) {
 		return nil, domain.ErrInvalidSongGroup
 	}
 
-	if song.Text == "" {
-		return nil, domain.ErrInvalidSongText
+	return songToResponse(song), nil
+}
+
+func MustConvertSongToResponse(song *domain.Song) *dto.SongResponse {
+	songResponse, _ := ConvertSongToResponse(song)
+	return songResponse
+}
+
+// parseDurationParam parses a non-negative number of seconds, an empty
+// parameter is zero
+func parseDurationParam(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, true
 	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func OkResp(msg string) *dto.MessageResponse {
+	return &dto.MessageResponse{Message: msg}
+}
 
+// songToResponse converts a song without validating it
+func songToResponse(song *domain.Song) *dto.SongResponse {
 	response := &dto.SongResponse{
 		ID:          song.ID.String(),
 		Name:        song.Name,
@@ -463,20 +1156,27 @@ func ConvertSongToResponse(song *domain.Song) (*dto.SongResponse, error) {
 		ReleaseDate: song.ReleaseDate,
 		CreatedAt:   song.CreatedAt,
 		UpdatedAt:   song.UpdatedAt,
+		Version:     song.Version,
+		Source:      song.Source,
+		DurationMs:  song.Duration.Milliseconds(),
+		Genre:       song.Genre,
+		TrackNumber: song.TrackNumber,
+		Album:       song.Album,
+		Explicit:    song.Explicit,
+		Status:      string(song.Status),
+
+		FavoritesCount: song.FavoritesCount,
+		RatingAverage:  song.RatingAverage,
+		RatingsCount:   song.RatingsCount,
 	}
 
-	return response, nil
-}
-
-func MustConvertSongToResponse(song *domain.Song) *dto.SongResponse {
-	songResponse, _ := ConvertSongToResponse(song)
-	return songResponse
-}
+	if song.AlbumID != nil {
+		response.AlbumID = song.AlbumID.String()
+	}
 
-func ErrResp(err string) map[string]string {
-	return map[string]string{"error": err}
-}
+	if song.ArtistID != uuid.Nil {
+		response.ArtistID = song.ArtistID.String()
+	}
 
-func OkResp(msg string) map[string]string {
-	return map[string]string{"message": msg}
+	return response
 }
@@ -2,13 +2,14 @@ package deliveryHttp_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	handler "songLibrary/internal/delivery/http"
 	"songLibrary/internal/delivery/http/mocks"
 	"songLibrary/internal/domain"
@@ -16,12 +17,21 @@ import (
 	"songLibrary/pkg/logger/handlers/slogdiscard"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+// withURLParam attaches a chi URL parameter to the request so handlers can be called directly
+func withURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
 func TestAddSong_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -41,10 +51,11 @@ func TestAddSong_Success(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
+	songID := uuid.New()
 	mockService.EXPECT().Add(gomock.Any(), &domain.SongInfo{
 		Name:  reqBody.Name,
 		Group: reqBody.Group,
-	}).Return(nil)
+	}).Return(&domain.Song{ID: songID, Name: reqBody.Name, Group: reqBody.Group}, nil)
 
 	h.Add(w, req)
 
@@ -57,6 +68,35 @@ func TestAddSong_Success(t *testing.T) {
 	err := json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.NoError(t, err)
 	assert.Equal(t, "song added successfully", respBody["message"])
+	assert.Equal(t, dto.SongStatusAdded, respBody["status"])
+	assert.Equal(t, songID.String(), respBody["id"])
+}
+
+func TestAddSong_PendingEnrichment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	songID := uuid.New()
+	mockService.EXPECT().Add(gomock.Any(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"}).
+		Return(&domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Status: domain.SongStatusPending}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs", strings.NewReader(`{"name":"Hysteria","group":"Muse"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.Add(w, req)
+
+	// MusicInfo недоступен: песня принята, детали будут получены позже
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var respBody dto.AddSongResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, songID.String(), respBody.ID)
+	assert.Equal(t, dto.SongStatusPendingEnrichment, respBody.Status)
 }
 
 func TestAddSong_MissingFields(t *testing.T) {
@@ -79,10 +119,11 @@ func TestAddSong_MissingFields(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
-	var respBody map[string]string
+	var respBody dto.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "name and group are required", respBody["error"])
+	assert.Equal(t, dto.CodeValidationFailed, respBody.Code)
+	assert.Equal(t, "name and group are required", respBody.Message)
 }
 
 func TestAddSong_Failure_DecodeError(t *testing.T) {
@@ -104,10 +145,35 @@ func TestAddSong_Failure_DecodeError(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
-	var respBody map[string]string
+	var respBody dto.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "invalid request", respBody["error"])
+	assert.Equal(t, dto.CodeInvalidRequest, respBody.Code)
+	assert.Equal(t, "invalid request", respBody.Message)
+}
+
+func TestAddSong_Failure_BodyTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	body := `{"group": "Muse", "song": "` + strings.Repeat("a", 100) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/songs", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	// Тело длиннее лимита не дочитывается, сервис не вызывается
+	req.Body = http.MaxBytesReader(w, req.Body, 32)
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, dto.CodeRequestTooLarge, respBody.Code)
 }
 
 func TestAddSong_Failure_ServiceError(t *testing.T) {
@@ -132,7 +198,7 @@ func TestAddSong_Failure_ServiceError(t *testing.T) {
 	mockService.EXPECT().Add(gomock.Any(), &domain.SongInfo{
 		Name:  reqBody.Name,
 		Group: reqBody.Group,
-	}).Return(errors.New("service error"))
+	}).Return(nil, errors.New("service error"))
 
 	h.Add(w, req)
 
@@ -141,10 +207,11 @@ func TestAddSong_Failure_ServiceError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 
-	var respBody map[string]string
+	var respBody dto.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "internal error", respBody["error"])
+	assert.Equal(t, dto.CodeInternal, respBody.Code)
+	assert.Equal(t, "internal error", respBody.Message)
 }
 
 func TestHandler_Update(t *testing.T) {
@@ -152,18 +219,18 @@ func TestHandler_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	logger := slog.New(slog.NewTextHandler(os.Stdout))
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
 
-	handler := NewHandler(mockService, logger)
+	h := handler.NewHandler(mockService, mockLog)
 	songID := uuid.New()
 	reqBody := `{"name": "Updated Song", "group": "Updated Group"}`
 	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String(), strings.NewReader(reqBody))
-	req = mux.SetURLVars(req, map[string]string{"id": songID.String()})
+	req = withURLParam(req, "id", songID.String())
 	w := httptest.NewRecorder()
 
 	mockService.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-	handler.Update(w, req)
+	h.Update(w, req)
 
 	resp := w.Result()
 	body, _ := io.ReadAll(resp.Body)
@@ -177,18 +244,18 @@ func TestHandler_Update_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	logger := slog.New(slog.NewTextHandler(os.Stdout))
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
 
-	handler := NewHandler(mockService, logger)
+	h := handler.NewHandler(mockService, mockLog)
 	songID := uuid.New()
 	reqBody := `{"name": "Updated Song", "group": "Updated Group"}`
 	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String(), strings.NewReader(reqBody))
-	req = mux.SetURLVars(req, map[string]string{"id": songID.String()})
+	req = withURLParam(req, "id", songID.String())
 	w := httptest.NewRecorder()
 
 	mockService.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrSongNotFound)
 
-	handler.Update(w, req)
+	h.Update(w, req)
 
 	resp := w.Result()
 	body, _ := io.ReadAll(resp.Body)
@@ -196,3 +263,789 @@ func TestHandler_Update_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	assert.Contains(t, string(body), "song not found")
 }
+
+func TestHandler_Get_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{
+		ID:        uuid.New(),
+		Name:      "Hysteria",
+		Group:     "Muse",
+		Text:      "It's bugging me...",
+		UpdatedAt: time.Now(),
+	}
+	etag := handler.SongETag(song, "application/json")
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+song.ID.String(), nil)
+	req.Header.Set("If-None-Match", etag)
+	req = withURLParam(req, "id", song.ID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	h.Get(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+	assert.Empty(t, body)
+}
+
+func TestHandler_Get_ETagPerContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", UpdatedAt: time.Now()}
+	jsonETag := handler.SongETag(song, "application/json")
+
+	// ETag JSON-представления не подходит к XML, клиент получает XML целиком
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+song.ID.String(), nil)
+	req.Header.Set("Accept", "application/xml")
+	req.Header.Set("If-None-Match", jsonETag)
+	req = withURLParam(req, "id", song.ID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	h.Get(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+	assert.Equal(t, handler.SongETag(song, "application/xml"), w.Header().Get("ETag"))
+	assert.NotEqual(t, jsonETag, w.Header().Get("ETag"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+}
+
+func TestHandler_Get_ETagChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{
+		ID:        uuid.New(),
+		Name:      "Hysteria",
+		Group:     "Muse",
+		Text:      "It's bugging me...",
+		UpdatedAt: time.Now(),
+	}
+	staleETag := handler.SongETag(&domain.Song{ID: song.ID, UpdatedAt: song.UpdatedAt.Add(-time.Hour)}, "application/json")
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+song.ID.String(), nil)
+	req.Header.Set("If-None-Match", staleETag)
+	req = withURLParam(req, "id", song.ID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	h.Get(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, handler.SongETag(song, "application/json"), resp.Header.Get("ETag"))
+	assert.Contains(t, string(body), "Hysteria")
+}
+
+func TestSongETag_TimeZone(t *testing.T) {
+	updatedAt := time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	song := &domain.Song{ID: uuid.New(), UpdatedAt: updatedAt}
+
+	// Тот же момент в другом часовом поясе — та же ревизия песни
+	local := &domain.Song{ID: song.ID, UpdatedAt: updatedAt.In(time.FixedZone("MSK", 3*60*60))}
+
+	assert.Equal(t, handler.SongETag(song, "application/json"), handler.SongETag(local, "application/json"))
+}
+
+func TestHandler_Update_PreconditionFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	current := &domain.Song{
+		ID:        uuid.New(),
+		Name:      "Hysteria",
+		Group:     "Muse",
+		UpdatedAt: time.Now(),
+	}
+	staleETag := handler.SongETag(&domain.Song{ID: current.ID, UpdatedAt: current.UpdatedAt.Add(-time.Hour)}, "application/json")
+
+	reqBody := `{"name": "Updated Song", "group": "Updated Group"}`
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+current.ID.String(), strings.NewReader(reqBody))
+	req.Header.Set("If-Match", staleETag)
+	req = withURLParam(req, "id", current.ID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: current.ID}).Return(current, nil)
+
+	h.Update(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	assert.Contains(t, string(body), "song was modified")
+}
+
+func TestHandler_Update_IfMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	current := &domain.Song{
+		ID:        uuid.New(),
+		Name:      "Hysteria",
+		Group:     "Muse",
+		UpdatedAt: time.Now(),
+	}
+
+	reqBody := `{"name": "Updated Song", "group": "Updated Group"}`
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+current.ID.String(), strings.NewReader(reqBody))
+	req.Header.Set("If-Match", handler.SongETag(current, "application/json"))
+	req = withURLParam(req, "id", current.ID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: current.ID}).Return(current, nil)
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	h.Update(w, req)
+
+	resp := w.Result()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_Update_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+	reqBody := `{"name": "Updated Song", "version": 2}`
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String(), strings.NewReader(reqBody))
+	req = withURLParam(req, "id", songID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().
+		Update(gomock.Any(), &domain.SongInfo{ID: songID}, &domain.Song{Name: "Updated Song", Version: 2}).
+		Return(domain.ErrVersionConflict)
+
+	h.Update(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Contains(t, string(body), "song was modified by another request")
+}
+
+func TestHandler_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String(), nil)
+	req = withURLParam(req, "id", songID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().
+		Get(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(nil, fmt.Errorf("Service.Get: song not found: %w", domain.ErrSongNotFound))
+
+	h.Get(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	var respBody dto.ErrorResponse
+	err := json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.CodeSongNotFound, respBody.Code)
+	assert.Equal(t, "song not found", respBody.Message)
+}
+
+func TestHandler_Get_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	req := httptest.NewRequest(http.MethodGet, "/songs/not-a-uuid", nil)
+	req = withURLParam(req, "id", "not-a-uuid")
+	w := httptest.NewRecorder()
+
+	h.Get(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var respBody dto.ErrorResponse
+	err := json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.CodeValidationFailed, respBody.Code)
+	assert.Equal(t, "invalid song id", respBody.Message)
+}
+
+func TestHandler_Lookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	song := &domain.Song{ID: uuid.New(), Name: "Mr. Blue Sky", Group: "ELO", Text: "Sun is shinin' in the sky", Version: 1}
+	mockService.EXPECT().
+		GetByNameAndGroup(gomock.Any(), &domain.SongInfo{Name: "mr. blue sky", Group: "elo"}).
+		Return(song, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/lookup?name=mr.+blue+sky&group=elo", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, handler.SongETag(song, "application/json"), w.Header().Get("ETag"))
+
+	var respBody dto.SongResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, song.ID.String(), respBody.ID)
+}
+
+func TestHandler_Lookup_MissingGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/lookup?name=Hysteria", nil)
+	w := httptest.NewRecorder()
+	h.Lookup(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, dto.CodeValidationFailed, respBody.Code)
+}
+
+func TestHandler_GetPaginatedText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		GetPaginatedText(gomock.Any(), &domain.SongInfo{ID: songID}, domain.SplitStrategy("")).
+		Return(&domain.Lyrics{Sections: []domain.LyricsSection{
+			{Type: domain.SectionVerse, Index: 1, Text: "It's bugging me"},
+			{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+		}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/text", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Плоский список секций сохраняется рядом с типизированными секциями
+	var respBody dto.PaginatedTextResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, []string{"It's bugging me", "'Cause I want it now"}, respBody.Text)
+	assert.Equal(t, []dto.LyricsSectionResponse{
+		{Type: "verse", Index: 1, Text: "It's bugging me"},
+		{Type: "chorus", Index: 1, Text: "'Cause I want it now"},
+	}, respBody.Sections)
+}
+
+func TestHandler_GetPaginatedText_VersesPerPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		GetTextPage(gomock.Any(), &domain.SongInfo{ID: songID}, domain.SplitStrategy(""), 2, 2).
+		Return(&domain.LyricsPage{
+			Lyrics: domain.Lyrics{Sections: []domain.LyricsSection{
+				{Type: domain.SectionVerse, Index: 2, Text: "Give me your heart"},
+				{Type: domain.SectionChorus, Index: 2, Text: "'Cause I want it now"},
+			}},
+			Number:        2,
+			VersesPerPage: 2,
+			TotalVerses:   5,
+			TotalPages:    3,
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/text?verses_per_page=2&page=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Ссылки на соседние страницы сохраняют остальные параметры запроса
+	textPath := "/songs/" + songID.String() + "/text"
+	var respBody dto.PaginatedTextResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, []string{"Give me your heart", "'Cause I want it now"}, respBody.Text)
+	assert.Equal(t, &dto.TextPageResponse{
+		Number:        2,
+		VersesPerPage: 2,
+		TotalPages:    3,
+		TotalVerses:   5,
+		FirstVerse:    3,
+		Prev:          textPath + "?page=1&verses_per_page=2",
+		Next:          textPath + "?page=3&verses_per_page=2",
+	}, respBody.Page)
+}
+
+func TestHandler_GetPaginatedText_InvalidPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	// Сервис не вызывается для некорректных параметров страницы
+	for _, query := range []string{"verses_per_page=0", "verses_per_page=two", "verses_per_page=2&page=0", "page=2"} {
+		req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/text?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	// Страница за концом текста не найдена
+	mockService.EXPECT().
+		GetTextPage(gomock.Any(), gomock.Any(), domain.SplitStrategy(""), 9, 2).
+		Return(nil, fmt.Errorf("Service.GetTextPage: %w", domain.ErrLyricsPageNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/text?verses_per_page=2&page=9", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, dto.CodeTextPageNotFound, respBody.Code)
+}
+
+func TestHandler_GetVerse(t *testing.T) {
+	songID := uuid.New()
+	lyrics := &domain.Lyrics{Sections: []domain.LyricsSection{
+		{Type: domain.SectionVerse, Index: 1, Text: "It's bugging me"},
+		{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+		{Type: domain.SectionVerse, Index: 2, Text: "Give me your heart"},
+	}}
+	textPath := "/songs/" + songID.String() + "/text"
+
+	tests := []struct {
+		name       string
+		number     string
+		wantStatus int
+		want       *dto.VerseResponse
+		wantCode   dto.ErrorCode
+	}{
+		{
+			name:       "первый куплет",
+			number:     "1",
+			wantStatus: http.StatusOK,
+			want:       &dto.VerseResponse{Number: 1, Total: 3, Type: "verse", Index: 1, Text: "It's bugging me", Next: textPath + "/2"},
+		},
+		{
+			name:       "середина текста",
+			number:     "2",
+			wantStatus: http.StatusOK,
+			want: &dto.VerseResponse{Number: 2, Total: 3, Type: "chorus", Index: 1, Text: "'Cause I want it now",
+				Prev: textPath + "/1", Next: textPath + "/3"},
+		},
+		{
+			name:       "последний куплет",
+			number:     "3",
+			wantStatus: http.StatusOK,
+			want:       &dto.VerseResponse{Number: 3, Total: 3, Type: "verse", Index: 2, Text: "Give me your heart", Prev: textPath + "/2"},
+		},
+		{name: "за концом текста", number: "4", wantStatus: http.StatusNotFound, wantCode: dto.CodeVerseNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockService(ctrl)
+			mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+			router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+			mockService.EXPECT().
+				GetPaginatedText(gomock.Any(), &domain.SongInfo{ID: songID}, domain.SplitStrategy("")).
+				Return(lyrics, nil)
+
+			req := httptest.NewRequest(http.MethodGet, textPath+"/"+tt.number, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.want == nil {
+				var respBody dto.ErrorResponse
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+				assert.Equal(t, tt.wantCode, respBody.Code)
+				return
+			}
+
+			var respBody dto.VerseResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+			assert.Equal(t, *tt.want, respBody)
+		})
+	}
+}
+
+func TestHandler_GetVerse_InvalidNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	// Сервис не вызывается для некорректного номера
+	for _, number := range []string{"0", "-1", "first"} {
+		req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/text/"+number, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, number)
+	}
+}
+
+func TestHandler_SearchText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		SearchText(gomock.Any(), &domain.SongInfo{ID: songID}, "want it", domain.SplitStrategy("")).
+		Return([]*domain.VerseMatch{{
+			Number:  2,
+			Section: domain.LyricsSection{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+			Lines:   []domain.LineMatch{{Number: 1, Text: "'Cause I want it now"}},
+		}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/text/search?q=want+it", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Маршрут поиска не перекрывается маршрутом секции по номеру
+	var respBody dto.VerseSearchResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, []dto.VerseMatchResponse{{
+		Number: 2,
+		Type:   "chorus",
+		Index:  1,
+		Link:   "/songs/" + songID.String() + "/text/2",
+		Lines:  []dto.LineMatchResponse{{Line: 1, Text: "'Cause I want it now"}},
+	}}, respBody.Matches)
+}
+
+func TestHandler_SearchText_EmptyQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/text/search?q=+", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_GetPaginatedText_Split(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		GetPaginatedText(gomock.Any(), &domain.SongInfo{ID: songID}, domain.SplitMarkers).
+		Return(&domain.Lyrics{Sections: []domain.LyricsSection{{Type: domain.SectionVerse, Index: 1, Text: "It's bugging me"}}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/text?split=markers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Неизвестная стратегия отклоняется до обращения к сервису
+	req = httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/text?split=words", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	song := &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Link: "https://new", Version: 3}
+	mockService.EXPECT().
+		Refresh(gomock.Any(), &domain.SongInfo{ID: songID}, true).
+		Return(song, domain.FieldLink|domain.FieldReleaseDate, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/refresh?force=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var respBody dto.RefreshSongResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, []string{"link", "release_date"}, respBody.Changed)
+	assert.Equal(t, "https://new", respBody.Song.Link)
+}
+
+func TestHandler_Refresh_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		Refresh(gomock.Any(), &domain.SongInfo{ID: songID}, false).
+		Return(nil, domain.SongFields(0), fmt.Errorf("Service.Refresh: %w", domain.ErrMusicInfoTimeout))
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/refresh", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestAddSong_Failure_MusicInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   dto.ErrorCode
+	}{
+		{"unknown song", domain.ErrMusicInfoBadRequest, http.StatusUnprocessableEntity, dto.CodeMusicInfoRejected},
+		{"malformed response", domain.ErrMusicInfoMalformed, http.StatusBadGateway, dto.CodeMusicInfoMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockService(ctrl)
+			mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+			h := handler.NewHandler(mockService, mockLog)
+
+			mockService.EXPECT().
+				Add(gomock.Any(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"}).
+				Return(nil, fmt.Errorf("Service.Add: %w", tt.err))
+
+			req := httptest.NewRequest(http.MethodPost, "/songs", strings.NewReader(`{"name": "Hysteria", "group": "Muse"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			h.Add(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+
+			var respBody dto.ErrorResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+			assert.Equal(t, tt.code, respBody.Code)
+		})
+	}
+}
+
+func TestHandler_Get_DeadlineExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	songID := uuid.New()
+	mockService.EXPECT().
+		Get(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(nil, fmt.Errorf("Service.Get: %w", context.DeadlineExceeded))
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, dto.CodeRequestTimeout, respBody.Code)
+}
+
+func TestHandler_Get_StatementCancelledAtDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	// Отменённый сервером запрос к базе возвращает свою ошибку, а не ошибку
+	// контекста, но это тоже истёкший дедлайн запроса
+	songID := uuid.New()
+	mockService.EXPECT().
+		Get(gomock.Any(), &domain.SongInfo{ID: songID}).
+		DoAndReturn(func(ctx context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+			<-ctx.Done()
+			return nil, errors.New("Service.Get: ERROR: canceling statement due to user request (SQLSTATE 57014)")
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String(), nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, dto.CodeRequestTimeout, respBody.Code)
+}
+
+func TestHandler_ErrorResponse_RequestID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/invalid-id", nil)
+	req.Header.Set("X-Request-ID", "gateway-42")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "gateway-42", w.Header().Get("X-Request-ID"))
+
+	// ID запроса возвращается в теле ошибки
+	var respBody dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&respBody))
+	assert.Equal(t, "gateway-42", respBody.RequestID)
+}
+
+func TestHandler_HeadAndOptions(t *testing.T) {
+	router, mockService := newBatchRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", UpdatedAt: time.Now()}
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	// HEAD отвечает заголовками GET без тела
+	req := httptest.NewRequest(http.MethodHead, "/songs/"+song.ID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, handler.SongETag(song, "application/json"), w.Header().Get("ETag"))
+	assert.NotEmpty(t, w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Body.String())
+
+	tests := []struct {
+		path  string
+		allow string
+	}{
+		{path: "/songs", allow: "GET, HEAD, POST, PATCH, OPTIONS"},
+		{path: "/songs/" + song.ID.String(), allow: "GET, HEAD, PUT, DELETE, OPTIONS"},
+	}
+	for _, tt := range tests {
+		req = httptest.NewRequest(http.MethodOptions, tt.path, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code, tt.path)
+		assert.Equal(t, tt.allow, w.Header().Get("Allow"), tt.path)
+	}
+}
@@ -0,0 +1,75 @@
+package deliveryHttp
+
+import (
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/dto"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// maxDuplicatesLimit caps the number of pairs a duplicates request can ask for
+const maxDuplicatesLimit = 100
+
+// @Summary Find duplicate songs
+// @Description Get pairs of songs with similar names and groups by trigram similarity, most similar first
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param threshold query number false "Minimal similarity from 0.3 to 1, 0.6 by default"
+// @Param limit query int false "Number of pairs, 20 by default"
+// @Success 200 {array} dto.DuplicateSongsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid threshold or limit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/duplicates [get]
+func (h *Handler) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.GetDuplicates"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	// Ниже порога pg_trgm по умолчанию индекс отбрасывает пары раньше фильтра
+	var threshold float64
+	if thresholdStr := r.URL.Query().Get("threshold"); thresholdStr != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || threshold < 0.3 || threshold > 1 {
+			log.Warn("invalid threshold parameter", slog.String("threshold", thresholdStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid threshold parameter", nil)
+			return
+		}
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxDuplicatesLimit {
+			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid limit parameter", nil)
+			return
+		}
+	}
+
+	duplicates, err := h.Service.GetDuplicates(r.Context(), threshold, limit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch duplicate songs", err)
+		return
+	}
+
+	duplicatesResponse := make([]dto.DuplicateSongsResponse, 0, len(duplicates))
+	for _, d := range duplicates {
+		duplicatesResponse = append(duplicatesResponse, dto.DuplicateSongsResponse{
+			Song:       *songToResponse(d.Song),
+			Duplicate:  *songToResponse(d.Duplicate),
+			Similarity: d.Similarity,
+		})
+	}
+
+	log.Info("duplicate songs successfully fetched", slog.Int("count", len(duplicatesResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, duplicatesResponse)
+}
@@ -0,0 +1,68 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	router := handler.NewHandler(mockService, mockLog).InitRoutes()
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	duplicate := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse."}
+
+	// Маршрут не должен перехватываться /songs/{id}
+	mockService.EXPECT().
+		GetDuplicates(gomock.Any(), 0.8, 0).
+		Return([]*domain.DuplicateSongs{{Song: song, Duplicate: duplicate, Similarity: 0.875}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/duplicates?threshold=0.8", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp []dto.DuplicateSongsResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, duplicate.ID.String(), resp[0].Duplicate.ID)
+	assert.Equal(t, 0.875, resp[0].Similarity)
+}
+
+func TestHandler_GetDuplicates_InvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{"threshold=0.1", "threshold=2", "threshold=abc", "limit=0", "limit=101"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/songs/duplicates?"+query, nil)
+			w := httptest.NewRecorder()
+			h.GetDuplicates(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
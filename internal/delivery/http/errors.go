@@ -0,0 +1,145 @@
+package deliveryHttp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/internal/importer"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+type apiError struct {
+	status  int
+	code    dto.ErrorCode
+	message string
+}
+
+var internalError = apiError{
+	status:  http.StatusInternalServerError,
+	code:    dto.CodeInternal,
+	message: "internal error",
+}
+
+// errorMapping translates errors returned by the service layer to API errors.
+// The first entry matched with errors.Is wins.
+var errorMapping = []struct {
+	err error
+	apiError
+}{
+	{domain.ErrSongNotFound, apiError{http.StatusNotFound, dto.CodeSongNotFound, "song not found"}},
+	{domain.ErrAlbumNotFound, apiError{http.StatusNotFound, dto.CodeAlbumNotFound, "album not found"}},
+	{domain.ErrArtistNotFound, apiError{http.StatusNotFound, dto.CodeArtistNotFound, "artist not found"}},
+	{domain.ErrArtistExists, apiError{http.StatusConflict, dto.CodeArtistExists, "artist already exists"}},
+	{domain.ErrArtistHasSongs, apiError{http.StatusConflict, dto.CodeArtistHasSongs, "artist still has songs"}},
+	{domain.ErrRevisionNotFound, apiError{http.StatusNotFound, dto.CodeRevisionNotFound, "song revision not found"}},
+	{domain.ErrVerseNotFound, apiError{http.StatusNotFound, dto.CodeVerseNotFound, "song verse not found"}},
+	{domain.ErrLyricsPageNotFound, apiError{http.StatusNotFound, dto.CodeTextPageNotFound, "song text page not found"}},
+	{domain.ErrWebhookNotFound, apiError{http.StatusNotFound, dto.CodeWebhookNotFound, "webhook not found"}},
+	{domain.ErrLibraryNotFound, apiError{http.StatusNotFound, dto.CodeLibraryNotFound, "library not found"}},
+	{domain.ErrLibraryExists, apiError{http.StatusConflict, dto.CodeLibraryExists, "library already exists"}},
+	{domain.ErrUserNotFound, apiError{http.StatusNotFound, dto.CodeUserNotFound, "user not found"}},
+	{domain.ErrAPIKeyNotFound, apiError{http.StatusNotFound, dto.CodeAPIKeyNotFound, "api key not found"}},
+	{domain.ErrPlaylistNotFound, apiError{http.StatusNotFound, dto.CodePlaylistNotFound, "smart playlist not found"}},
+	{domain.ErrShareNotFound, apiError{http.StatusNotFound, dto.CodeShareNotFound, "share not found"}},
+	{domain.ErrShareExpired, apiError{http.StatusGone, dto.CodeShareExpired, "share is revoked or expired"}},
+	{domain.ErrSongExists, apiError{http.StatusConflict, dto.CodeSongExists, "song already exists"}},
+	{domain.ErrVersionConflict, apiError{http.StatusConflict, dto.CodeVersionConflict, "song was modified by another request"}},
+	{domain.ErrTooManySongIDs, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "too many song ids, at most 100 are fetched at once"}},
+	{domain.ErrBulkFilterEmpty, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "filter must select songs, it can't be empty"}},
+	{domain.ErrBulkChangesEmpty, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "changes must set at least one field"}},
+	{domain.ErrArtistNameIsNull, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "artist name is required"}},
+	{domain.ErrLibraryNameIsNull, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "library name is required"}},
+	{domain.ErrInvalidRole, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "role must be viewer, editor or admin"}},
+	{domain.ErrAPIKeyNameIsNull, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "api key name is required"}},
+	{domain.ErrAPIKeyExpiryInPast, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "api key expiry must be in the future"}},
+	{domain.ErrPlaylistNameIsNull, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "smart playlist name is required"}},
+	{domain.ErrPlaylistFilterEmpty, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "smart playlist filter must select songs, it can't be empty"}},
+	{domain.ErrPlaylistDateRangeInvalid, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "released_from must not be after released_to"}},
+	{domain.ErrInvalidRating, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "rating must be from 1 to 5"}},
+	{domain.ErrShareTTLInvalid, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "expires_in must be positive and within the longest share lifetime"}},
+	{domain.ErrInvalidTag, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "tags must be 1 to 50 characters long and can't contain commas"}},
+	{domain.ErrWebhookURLIsInvalid, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "webhook url must be an absolute http or https url"}},
+	{domain.ErrWebhookEventIsInvalid, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "unknown webhook event"}},
+	{domain.ErrCoverNotFound, apiError{http.StatusNotFound, dto.CodeCoverNotFound, "song has no cover"}},
+	{domain.ErrCoverTooLarge, apiError{http.StatusRequestEntityTooLarge, dto.CodeRequestTooLarge, "cover is too large"}},
+	{domain.ErrCoverInvalidType, apiError{http.StatusUnsupportedMediaType, dto.CodeUnsupportedMedia, "cover must be a jpeg, png, gif or webp image"}},
+	{domain.ErrAudioNotFound, apiError{http.StatusNotFound, dto.CodeAudioNotFound, "song has no audio"}},
+	{domain.ErrAudioTooLarge, apiError{http.StatusRequestEntityTooLarge, dto.CodeRequestTooLarge, "audio is too large"}},
+	{domain.ErrAudioInvalidFormat, apiError{http.StatusUnsupportedMediaType, dto.CodeUnsupportedMedia, "audio must be an mp3, wav or flac file"}},
+	{domain.ErrMusicInfoTimeout, apiError{http.StatusGatewayTimeout, dto.CodeMusicInfoTimeout, "song details provider did not respond in time"}},
+	{domain.ErrMusicInfoBadRequest, apiError{http.StatusUnprocessableEntity, dto.CodeMusicInfoRejected, "song details provider does not know the song"}},
+	{domain.ErrMusicInfoMalformed, apiError{http.StatusBadGateway, dto.CodeMusicInfoMalformed, "song details provider returned an invalid response"}},
+	{domain.ErrCacheRebuildRunning, apiError{http.StatusConflict, dto.CodeCacheRebuilding, "cache rebuild is already running"}},
+	{domain.ErrBackupInvalid, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "invalid backup"}},
+	{domain.ErrBackupSchemaMismatch, apiError{http.StatusConflict, dto.CodeSchemaMismatch, "backup schema version does not match the database"}},
+	{domain.ErrContentFilterDisabled, apiError{http.StatusNotImplemented, dto.CodeNotImplemented, "content filter is disabled"}},
+	{domain.ErrSemanticSearchDisabled, apiError{http.StatusNotImplemented, dto.CodeNotImplemented, "semantic search is disabled"}},
+	{domain.ErrBackupUnsupported, apiError{http.StatusNotImplemented, dto.CodeNotImplemented, "backups need PostgreSQL storage"}},
+	{importer.ErrInvalidHeader, apiError{http.StatusBadRequest, dto.CodeValidationFailed, "invalid csv header"}},
+	{context.DeadlineExceeded, apiError{http.StatusServiceUnavailable, dto.CodeRequestTimeout, "request timed out"}},
+}
+
+func mapError(err error) apiError {
+	for _, m := range errorMapping {
+		if errors.Is(err, m.err) {
+			return m.apiError
+		}
+	}
+	return internalError
+}
+
+// respondError renders an error returned by the service. Client errors are
+// logged at info level, everything unexpected as an error.
+func respondError(w http.ResponseWriter, r *http.Request, log *slog.Logger, msg string, err error) {
+	apiErr := mapError(err)
+	// a statement cancelled by the server at the deadline of the request
+	// fails with an error of its own instead of the context one
+	if apiErr == internalError && errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		apiErr = mapError(context.DeadlineExceeded)
+	}
+
+	if apiErr.status >= http.StatusInternalServerError {
+		log.Error(msg, sl.Err(err))
+	} else {
+		log.Info(msg, sl.Err(err))
+	}
+
+	render.Status(r, apiErr.status)
+	respond(w, r, ErrResp(r, apiErr.code, apiErr.message, nil))
+}
+
+// respondDecodeError renders an error about a request body that can't be
+// read, a body over the size limit is reported as 413
+func respondDecodeError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		log.Warn("request body too large", slog.Int64("limit", tooLarge.Limit))
+		render.Status(r, http.StatusRequestEntityTooLarge)
+		respond(w, r, ErrResp(r, dto.CodeRequestTooLarge, "request body too large", nil))
+		return
+	}
+
+	log.Error("failed to decode request", sl.Err(err))
+	respondBadRequest(w, r, dto.CodeInvalidRequest, "invalid request", nil)
+}
+
+// respondBadRequest renders an error about invalid client input
+func respondBadRequest(w http.ResponseWriter, r *http.Request, code dto.ErrorCode, message string, details map[string]string) {
+	render.Status(r, http.StatusBadRequest)
+	respond(w, r, ErrResp(r, code, message, details))
+}
+
+func ErrResp(r *http.Request, code dto.ErrorCode, message string, details map[string]string) dto.ErrorResponse {
+	return dto.ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
@@ -0,0 +1,76 @@
+package deliveryHttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+)
+
+// etagTimeLayout keeps only the wall clock with microsecond precision, the
+// same precision Postgres stores, so a song read from the cache and from the
+// database produces the same ETag. The time is taken in UTC, a song decoded
+// in another time zone is the same revision.
+const etagTimeLayout = "2006-01-02T15:04:05.000000"
+
+// SongETag returns a strong entity tag identifying the current revision of a
+// song rendered in contentType. The JSON, XML and YAML representations of a
+// revision differ byte for byte, so each has its own tag. Ratings and
+// favorites change the counters of a song without updating it, so the
+// counters are part of the tag.
+func SongETag(song *domain.Song, contentType string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%g|%d|%d|%s",
+		song.ID, song.UpdatedAt.UTC().Format(etagTimeLayout),
+		song.RatingAverage, song.RatingsCount, song.FavoritesCount, contentType)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-Match / If-None-Match header value matches the etag.
+// With weak comparison (If-None-Match) the W/ prefix of a validator is ignored,
+// If-Match requires strong comparison as RFC 9110 defines.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified reports whether the client already holds the current revision of a resource
+func notModified(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	return header != "" && etagMatches(header, etag, true)
+}
+
+// preconditionFailed reports whether the If-Match header of a request doesn't match the etag
+func preconditionFailed(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-Match")
+	return header != "" && !etagMatches(header, etag, false)
+}
+
+// notModifiedSince sets the Last-Modified header of a resource last modified
+// at modified and reports whether the client holds it by If-Modified-Since.
+// HTTP dates have whole seconds, so the header is left out while the second
+// of the modification lasts, a later modification in the same second would
+// have the same Last-Modified.
+func notModifiedSince(w http.ResponseWriter, r *http.Request, modified time.Time) bool {
+	modified = modified.UTC().Truncate(time.Second)
+	if !modified.Before(time.Now().UTC().Truncate(time.Second)) {
+		return false
+	}
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !modified.After(since)
+}
@@ -0,0 +1,114 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// heartbeatInterval keeps idle event streams from being closed by proxies
+const heartbeatInterval = 15 * time.Second
+
+type EventSubscriber interface {
+	Subscribe() (<-chan domain.SongEvent, func())
+}
+
+type EventsHandler struct {
+	Events EventSubscriber
+	log    *slog.Logger
+}
+
+func NewEventsHandler(events EventSubscriber, log *slog.Logger) *EventsHandler {
+	return &EventsHandler{
+		Events: events,
+		log:    log,
+	}
+}
+
+func (h *EventsHandler) Routes(r chi.Router) {
+	r.Get("/songs/events", h.Stream)
+}
+
+// @Summary Stream song changes
+// @Description Server-sent events for created, updated and deleted songs of the library of the request. The event name is the change type (song.created, song.updated, song.deleted), the data is the song.
+// @Tags songs
+// @Produce  text/event-stream
+// @Success 200 {object} dto.SongResponse "stream of song events"
+// @Failure 500 {object} dto.ErrorResponse "streaming unsupported"
+// @Router /songs/events [get]
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	const op = "EventsHandler.Stream"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error("response writer does not support flushing")
+		render.Status(r, http.StatusInternalServerError)
+		respond(w, r, ErrResp(r, dto.CodeInternal, "streaming unsupported", nil))
+		return
+	}
+
+	library := domain.LibraryIDFromContext(r.Context())
+	events, unsubscribe := h.Events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	log.Info("event stream opened")
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Info("event stream closed by client")
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			// subscribers only see the songs of their library
+			if event.Song == nil || event.Song.LibraryID != library {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				log.Error("failed to write event", sl.Err(err))
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				log.Info("event stream closed", sl.Err(err))
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event domain.SongEvent) error {
+	data, err := json.Marshal(songToResponse(event.Song))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,104 @@
+package deliveryHttp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/internal/events"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsHandler_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	bus := events.NewBus(8, mockLog)
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewEventsHandler(bus, mockLog))
+
+	srv := httptest.NewServer(h.InitRoutes())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/songs/events")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Песня без текста тоже должна попасть в поток событий
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	go func() {
+		// Подписка оформляется до отправки заголовков, поэтому событие не потеряется
+		time.Sleep(10 * time.Millisecond)
+		bus.Publish(domain.SongEvent{Type: domain.SongDeleted, Song: song})
+	}()
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "event: song.deleted\n", line)
+
+	line, err = reader.ReadString('\n')
+	assert.NoError(t, err)
+
+	var payload dto.SongResponse
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &payload))
+	assert.Equal(t, song.ID.String(), payload.ID)
+}
+
+func TestEventsHandler_Stream_OtherLibrary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	bus := events.NewBus(8, mockLog)
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewEventsHandler(bus, mockLog))
+
+	srv := httptest.NewServer(h.InitRoutes())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/songs/events")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Событие другой библиотеки не попадает в поток библиотеки по умолчанию
+	other := &domain.Song{ID: uuid.New(), LibraryID: uuid.New(), Name: "Uprising", Group: "Muse"}
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		bus.Publish(domain.SongEvent{Type: domain.SongCreated, Song: other})
+		bus.Publish(domain.SongEvent{Type: domain.SongCreated, Song: song})
+	}()
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "event: song.created\n", line)
+
+	line, err = reader.ReadString('\n')
+	assert.NoError(t, err)
+
+	var payload dto.SongResponse
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &payload))
+	assert.Equal(t, song.ID.String(), payload.ID)
+}
@@ -0,0 +1,68 @@
+package deliveryHttp
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/internal/exporter"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// @Summary Export a song
+// @Description Render the name, group, release date and text of the song by ID as a printable document. PDF documents use the standard PDF fonts, characters outside the Windows Latin character set are printed as question marks.
+// @Tags songs
+// @Produce  plain,text/markdown,application/pdf
+// @Param id path string true "Song ID"
+// @Param format query string false "Document format: txt (default), md or pdf"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or unsupported format"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/export [get]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.Export"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	format, err := exporter.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		log.Warn("unsupported export format", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "format must be txt, md or pdf", nil)
+		return
+	}
+
+	document, err := h.Service.Export(r.Context(), &domain.SongInfo{ID: id}, format)
+	if err != nil {
+		respondError(w, r, log, "failed to export song", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", document.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document.Body)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(document.Body); err != nil {
+		log.Error("failed to send exported song", sl.Err(err))
+		return
+	}
+
+	log.Info("song successfully exported", slog.String("song_id", id.String()), slog.String("format", string(format)))
+}
@@ -0,0 +1,89 @@
+package deliveryHttp_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/exporter"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Export_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+
+	mockService.EXPECT().
+		Export(gomock.Any(), &domain.SongInfo{ID: songID}, exporter.FormatMarkdown).
+		Return(&exporter.Document{
+			FileName:    "Би-2 - Полковник.md",
+			ContentType: "text/markdown; charset=utf-8",
+			Body:        []byte("# Полковник\n"),
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/export?format=md", nil)
+	rec := httptest.NewRecorder()
+
+	h.InitRoutes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "# Полковник\n", rec.Body.String())
+
+	// Имя файла не в ASCII передаётся по RFC 2231
+	assert.Equal(t, "attachment; filename*=utf-8''%D0%91%D0%B8-2%20-%20%D0%9F%D0%BE%D0%BB%D0%BA%D0%BE%D0%B2%D0%BD%D0%B8%D0%BA.md",
+		rec.Header().Get("Content-Disposition"))
+}
+
+func TestHandler_Export_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	// Сервис не должен вызываться для неизвестного формата
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.New().String()+"/export?format=docx", nil)
+	rec := httptest.NewRecorder()
+
+	h.InitRoutes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_Export_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+
+	mockService.EXPECT().
+		Export(gomock.Any(), &domain.SongInfo{ID: songID}, exporter.FormatText).
+		Return(nil, domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/export", nil)
+	rec := httptest.NewRecorder()
+
+	h.InitRoutes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
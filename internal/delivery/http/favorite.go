@@ -0,0 +1,188 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/delivery/http/middleware/user"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type FavoriteService interface {
+	Add(ctx context.Context, userID, songID uuid.UUID) error
+	Remove(ctx context.Context, userID, songID uuid.UUID) error
+	GetAll(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*domain.Song, error)
+}
+
+type FavoriteHandler struct {
+	Service FavoriteService
+	log     *slog.Logger
+}
+
+func NewFavoriteHandler(service FavoriteService, log *slog.Logger) *FavoriteHandler {
+	return &FavoriteHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *FavoriteHandler) Routes(r chi.Router) {
+	r.Post("/songs/{id}/favorite", h.Add)
+	r.Delete("/songs/{id}/favorite", h.Remove)
+	r.Get("/users/me/favorites", h.GetAll)
+}
+
+// @Summary Add a song to favorites
+// @Description Mark the song as a favorite of the current user
+// @Tags favorites
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} dto.MessageResponse "song added to favorites"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/favorite [post]
+func (h *FavoriteHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "FavoriteHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	if err := h.Service.Add(r.Context(), userID, songID); err != nil {
+		respondError(w, r, log, "failed to add favorite", err)
+		return
+	}
+
+	log.Info("favorite successfully added", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("song added to favorites"))
+}
+
+// @Summary Remove a song from favorites
+// @Description Unmark the song as a favorite of the current user
+// @Tags favorites
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} dto.MessageResponse "song removed from favorites"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/favorite [delete]
+func (h *FavoriteHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	const op = "FavoriteHandler.Remove"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	if err := h.Service.Remove(r.Context(), userID, songID); err != nil {
+		respondError(w, r, log, "failed to remove favorite", err)
+		return
+	}
+
+	log.Info("favorite successfully removed", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("song removed from favorites"))
+}
+
+// @Summary Get favorite songs
+// @Description Get the favorite songs of the current user, most recently added first
+// @Tags favorites
+// @Produce  json,xml,application/yaml
+// @Param X-User-ID header string true "User ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of songs per page"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {array} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /users/me/favorites [get]
+func (h *FavoriteHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "FavoriteHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	songs, err := h.Service.GetAll(r.Context(), userID, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch favorites", err)
+		return
+	}
+
+	songsResponse := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songsResponse = append(songsResponse, *MustConvertSongToResponse(song))
+	}
+
+	log.Info("favorites successfully fetched", slog.Int("count", len(songsResponse)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, songsResponse, fields)
+}
+
+// requireUser returns the ID of the user making the request and rejects anonymous requests
+func requireUser(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	userID, ok := user.FromContext(r.Context())
+	if !ok {
+		log.Info("request without user id")
+		render.Status(r, http.StatusUnauthorized)
+		respond(w, r, ErrResp(r, dto.CodeUnauthorized, "user is not identified", nil))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,90 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/middleware/user"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFavoriteRouter(t *testing.T) (http.Handler, *mocks.MockFavoriteService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockFavorites := mocks.NewMockFavoriteService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewFavoriteHandler(mockFavorites, mockLog))
+	h.Use(user.New(mockLog))
+
+	return h.InitRoutes(), mockFavorites
+}
+
+func TestFavoriteHandler_Add(t *testing.T) {
+	router, mockFavorites := newFavoriteRouter(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockFavorites.EXPECT().Add(gomock.Any(), userID, songID).Return(nil)
+
+	// Маршрут /songs/{id}/favorite не должен перекрываться маршрутами /songs
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/favorite", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestFavoriteHandler_Add_SongNotFound(t *testing.T) {
+	router, mockFavorites := newFavoriteRouter(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockFavorites.EXPECT().Add(gomock.Any(), userID, songID).Return(domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/favorite", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestFavoriteHandler_GetAll_Anonymous(t *testing.T) {
+	router, _ := newFavoriteRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/favorites", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeUnauthorized, resp.Code)
+}
+
+func TestHandler_GetAllWithFilter_InvalidSort(t *testing.T) {
+	router, _ := newFavoriteRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?sort=name", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
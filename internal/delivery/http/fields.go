@@ -0,0 +1,116 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"songLibrary/internal/dto"
+)
+
+// songFields are the names of the song fields ?fields can select
+var songFields = jsonFieldNames(reflect.TypeOf(dto.SongResponse{}))
+
+// jsonFieldNames returns the json names of the fields of the struct type t
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// fieldsParam parses the comma separated ?fields parameter selecting the
+// song fields of a response, nil if it is not set. Unknown field names are
+// answered with 400.
+func fieldsParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) ([]string, bool) {
+	if !r.URL.Query().Has("fields") {
+		return nil, true
+	}
+
+	var fields []string
+	for _, field := range strings.Split(r.URL.Query().Get("fields"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !songFields[field] {
+			log.Warn("invalid fields parameter", slog.String("field", field))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid fields parameter",
+				map[string]string{"fields": "unknown field " + field})
+			return nil, false
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		log.Warn("empty fields parameter")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid fields parameter", nil)
+		return nil, false
+	}
+	return fields, true
+}
+
+// respondFields renders v like respond keeping only the fields of its songs.
+// path is the chain of keys leading from v to the songs, lists met on the
+// way are projected item by item. With no fields v is rendered whole.
+func respondFields(w http.ResponseWriter, r *http.Request, log *slog.Logger, v any, fields []string, path ...string) {
+	if fields == nil {
+		respond(w, r, v)
+		return
+	}
+
+	projected, err := project(v, fields, path)
+	if err != nil {
+		respondError(w, r, log, "failed to project response", err)
+		return
+	}
+	respond(w, r, projected)
+}
+
+// project returns the JSON form of v with only fields left in the objects
+// found at path
+func project(v any, fields []string, path []string) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return projectValue(generic, fields, path), nil
+}
+
+func projectValue(v any, fields []string, path []string) any {
+	switch v := v.(type) {
+	case []any:
+		for i, item := range v {
+			v[i] = projectValue(item, fields, path)
+		}
+		return v
+	case map[string]any:
+		if len(path) > 0 {
+			if child, ok := v[path[0]]; ok {
+				v[path[0]] = projectValue(child, fields, path[1:])
+			}
+			return v
+		}
+
+		projected := make(map[string]any, len(fields))
+		for _, field := range fields {
+			if value, ok := v[field]; ok {
+				projected[field] = value
+			}
+		}
+		return projected
+	default:
+		return v
+	}
+}
@@ -0,0 +1,153 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Get_Fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", UpdatedAt: time.Now()}
+	mockService.EXPECT().Get(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+song.ID.String()+"?fields=id,name", nil)
+	req = withURLParam(req, "id", song.ID.String())
+	w := httptest.NewRecorder()
+	h.Get(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, map[string]any{"id": song.ID.String(), "name": "Hysteria"}, body)
+}
+
+func TestHandler_GetAllWithFilter_Fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."}
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		Return([]*domain.Song{song}, nil)
+	mockService.EXPECT().
+		GetAllAfter(gomock.Any(), gomock.Any(), nil, 1).
+		Return([]*domain.Song{song}, &domain.SongCursor{CreatedAt: time.Now(), ID: song.ID}, nil)
+
+	// Список песен
+	req := httptest.NewRequest(http.MethodGet, "/songs?fields=name,group", nil)
+	w := httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var songs []map[string]any
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&songs))
+	assert.Equal(t, []map[string]any{{"name": "Hysteria", "group": "Muse"}}, songs)
+
+	// Страница по курсору: поля выбираются у песен, next_cursor остается
+	req = httptest.NewRequest(http.MethodGet, "/songs?cursor=&page_size=1&fields=id", nil)
+	w = httptest.NewRecorder()
+	h.GetAllWithFilter(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var page map[string]any
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Equal(t, []any{map[string]any{"id": song.ID.String()}}, page["songs"])
+	assert.NotEmpty(t, page["next_cursor"])
+}
+
+func TestHandler_GetAllWithFilter_InvalidFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{"fields=id,lyrics", "fields=", "fields=,"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+			w := httptest.NewRecorder()
+			h.GetAllWithFilter(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestHandler_GetAllWithFilter_IncludeText(t *testing.T) {
+	tests := []struct {
+		query       string
+		withoutText bool
+	}{
+		{query: "", withoutText: true},
+		{query: "include_text=true", withoutText: false},
+		{query: "include_text=false", withoutText: true},
+		{query: "fields=id,text", withoutText: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockService(ctrl)
+			mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+			mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+			h := handler.NewHandler(mockService, mockLog)
+
+			mockService.EXPECT().
+				GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+				DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+					assert.Equal(t, tt.withoutText, filter.WithoutText)
+					return nil, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, "/songs?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.GetAllWithFilter(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+
+	t.Run("некорректное значение", func(t *testing.T) {
+		h := handler.NewHandler(mocks.NewMockService(gomock.NewController(t)), slog.New(slogdiscard.NewDiscardHandler()))
+
+		req := httptest.NewRequest(http.MethodGet, "/songs?include_text=maybe", nil)
+		w := httptest.NewRecorder()
+		h.GetAllWithFilter(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
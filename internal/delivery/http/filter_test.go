@@ -0,0 +1,70 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetAllWithFilter_TextLinkAndReleaseRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			// Все параметры запроса попадают в один фильтр вместе с сортировкой
+			assert.Equal(t, "bugging", filter.Text)
+			assert.Equal(t, "youtube", filter.Link)
+			assert.Equal(t, time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC), filter.ReleasedFrom)
+			assert.Equal(t, time.Date(2003, 12, 31, 0, 0, 0, 0, time.UTC), filter.ReleasedTo)
+			assert.Equal(t, domain.SortByPopularity, filter.Sort)
+			return nil, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?text=bugging&link=youtube&released_from=2003-01-01&released_to=2003-12-31&sort=popularity", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_GetAllWithFilter_InvalidReleaseRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{
+		"released_from=2003",
+		"released_to=01.12.2003",
+		"released_from=2004-01-01&released_to=2003-12-31",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		h.GetAllWithFilter(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -0,0 +1,91 @@
+package deliveryHttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	gql "songLibrary/internal/delivery/graphql"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// graphQL builds the handler of the /graphql endpoint over the Service. GET
+// runs queries only, so viewers can query while mutations need a POST.
+func (h *Handler) graphQL() http.Handler {
+	srv := handler.New(gql.NewExecutableSchema(gql.Config{Resolvers: gql.NewResolver(h.Service)}))
+
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+
+	srv.SetErrorPresenter(h.presentGraphQLError)
+	srv.SetRecoverFunc(h.recoverGraphQL)
+
+	return srv
+}
+
+// presentGraphQLError renders the error a resolver got from the Service
+// like respondError renders it, with the API error code in the extensions.
+// Errors about the query and its arguments are passed on as they are.
+func (h *Handler) presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	const op = "Handler.GraphQL"
+
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]any{}
+	}
+
+	var serviceErr *gql.ServiceError
+	if !errors.As(err, &serviceErr) {
+		if _, ok := presented.Extensions["code"]; !ok {
+			presented.Extensions["code"] = dto.CodeValidationFailed
+		}
+		return presented
+	}
+
+	apiErr := mapError(serviceErr.Err)
+	// a statement cancelled by the server at the deadline of the request
+	// fails with an error of its own instead of the context one
+	if apiErr == internalError && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		apiErr = mapError(context.DeadlineExceeded)
+	}
+
+	log := h.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+	)
+	if apiErr.status >= http.StatusInternalServerError {
+		log.Error("graphql resolver failed", sl.Err(err))
+	} else {
+		log.Info("graphql resolver failed", sl.Err(err))
+	}
+
+	presented.Message = apiErr.message
+	presented.Extensions["code"] = apiErr.code
+	return presented
+}
+
+// recoverGraphQL logs a resolver panic with its stack, the field it
+// happened in fails with an internal error and the rest of the response is
+// still served
+func (h *Handler) recoverGraphQL(ctx context.Context, rvr any) error {
+	h.log.Error("graphql resolver panicked",
+		slog.String("panic", fmt.Sprint(rvr)),
+		sl.RequestID(ctx),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	return &gqlerror.Error{
+		Message:    internalError.message,
+		Extensions: map[string]any{"code": internalError.code},
+	}
+}
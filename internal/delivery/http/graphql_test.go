@@ -0,0 +1,189 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func serveGraphQL(t *testing.T, h *handler.Handler, req *http.Request) graphQLResponse {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.InitRoutes().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func postGraphQL(query string, variables map[string]any) *http.Request {
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestHandler_GraphQL_Songs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	h := handler.NewHandler(mockService, mockLog)
+
+	song := &domain.Song{
+		ID:          uuid.New(),
+		Name:        "Hysteria",
+		Group:       "Muse",
+		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+		Duration:    227 * time.Second,
+		Status:      domain.SongStatusEnriched,
+	}
+
+	// Текст песни не выбран в запросе, поэтому песни читаются без него
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 1, 10).
+		DoAndReturn(func(_ any, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			assert.Equal(t, "Muse", filter.Group)
+			assert.Equal(t, domain.SortByRating, filter.Sort)
+			assert.True(t, filter.ExcludeArchived)
+			assert.True(t, filter.WithoutText)
+			return []*domain.Song{song}, nil
+		})
+
+	query := `{ songs(filter: {group: "Muse"}, sort: RATING, pageSize: 10) { id name releaseDate durationMs status } }`
+	req := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil)
+
+	resp := serveGraphQL(t, h, req)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"songs": [{
+		"id": "`+song.ID.String()+`",
+		"name": "Hysteria",
+		"releaseDate": "2003-12-01T00:00:00Z",
+		"durationMs": 227000,
+		"status": "ENRICHED"
+	}]}`, string(resp.Data))
+}
+
+func TestHandler_GraphQL_SongNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	h := handler.NewHandler(mockService, mockLog)
+
+	id := uuid.New()
+	mockService.EXPECT().
+		Get(gomock.Any(), &domain.SongInfo{ID: id}).
+		Return(nil, domain.ErrSongNotFound)
+
+	resp := serveGraphQL(t, h, postGraphQL(`query($id: UUID!) { song(id: $id) { name } }`, map[string]any{"id": id}))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"song": null}`, string(resp.Data))
+}
+
+func TestHandler_GraphQL_AddSong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	h := handler.NewHandler(mockService, mockLog)
+
+	id := uuid.New()
+	mockService.EXPECT().
+		Add(gomock.Any(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"}).
+		Return(&domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Status: domain.SongStatusPending}, nil)
+
+	mutation := `mutation($input: AddSongInput!) { addSong(input: $input) { id status } }`
+	resp := serveGraphQL(t, h, postGraphQL(mutation, map[string]any{"input": map[string]any{"name": "Hysteria", "group": "Muse"}}))
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"addSong": {"id": "`+id.String()+`", "status": "PENDING"}}`, string(resp.Data))
+}
+
+func TestHandler_GraphQL_UpdateSongErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]any
+		mockFunc func(mockService *mocks.MockService, id uuid.UUID)
+		wantCode string
+	}{
+		{
+			name:     "Отрицательная длительность",
+			input:    map[string]any{"durationMs": -1},
+			mockFunc: func(mockService *mocks.MockService, id uuid.UUID) {},
+			wantCode: "VALIDATION_FAILED",
+		},
+		{
+			name:  "Конфликт версий",
+			input: map[string]any{"name": "Hysteria", "version": 1},
+			mockFunc: func(mockService *mocks.MockService, id uuid.UUID) {
+				mockService.EXPECT().
+					Update(gomock.Any(), &domain.SongInfo{ID: id}, &domain.Song{Name: "Hysteria", Version: 1}).
+					Return(domain.ErrVersionConflict)
+			},
+			wantCode: "VERSION_CONFLICT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockService(ctrl)
+			mockLog := slog.New(slogdiscard.NewDiscardHandler())
+			h := handler.NewHandler(mockService, mockLog)
+
+			id := uuid.New()
+			tt.mockFunc(mockService, id)
+
+			mutation := `mutation($id: UUID!, $input: UpdateSongInput!) { updateSong(id: $id, input: $input) { version } }`
+			resp := serveGraphQL(t, h, postGraphQL(mutation, map[string]any{"id": id, "input": tt.input}))
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.wantCode, resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestHandler_GraphQL_MutationOverGET(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	h := handler.NewHandler(mockService, mockLog)
+
+	// Изменения через GET не выполняются, сервис не вызывается
+	mutation := `mutation { deleteSong(id: "` + uuid.NewString() + `") }`
+	req := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(mutation), nil)
+
+	rec := httptest.NewRecorder()
+	h.InitRoutes().ServeHTTP(rec, req)
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,75 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type GroupService interface {
+	GetAll(ctx context.Context, name string, page, pageSize int) ([]*domain.Group, error)
+}
+
+type GroupHandler struct {
+	Service GroupService
+	log     *slog.Logger
+}
+
+func NewGroupHandler(service GroupService, log *slog.Logger) *GroupHandler {
+	return &GroupHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *GroupHandler) Routes(r chi.Router) {
+	r.Get("/groups", h.GetAll)
+}
+
+// @Summary Get all groups
+// @Description Get the groups that have songs with their song count, earliest and latest release date and last song update, with optional name filter and pagination
+// @Tags groups
+// @Produce  json,xml,application/yaml
+// @Param name query string false "Filter by name"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of groups per page"
+// @Success 200 {array} dto.GroupResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /groups [get]
+func (h *GroupHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "GroupHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+
+	groups, err := h.Service.GetAll(r.Context(), name, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch groups", err)
+		return
+	}
+
+	groupsResponse := make([]*dto.GroupResponse, 0, len(groups))
+	for _, group := range groups {
+		groupsResponse = append(groupsResponse, dto.GroupToResponse(group))
+	}
+
+	log.Info("groups successfully fetched", slog.Int("count", len(groupsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, groupsResponse)
+}
@@ -0,0 +1,78 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupHandler_GetAll_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGroupService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewGroupHandler(mockService, mockLog).Routes(r)
+
+	group := &domain.Group{
+		ArtistID:      uuid.New(),
+		Name:          "Muse",
+		Songs:         2,
+		FirstRelease:  time.Date(2006, 6, 19, 0, 0, 0, 0, time.UTC),
+		LatestRelease: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	mockService.EXPECT().GetAll(gomock.Any(), "mu", 2, 10).Return([]*domain.Group{group}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/groups?name=mu&page=2&page_size=10", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.GroupResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, group.ArtistID.String(), resp[0].ArtistID)
+		assert.Equal(t, "Muse", resp[0].Name)
+		assert.Equal(t, 2, resp[0].Songs)
+		assert.True(t, group.FirstRelease.Equal(resp[0].FirstRelease))
+		assert.True(t, group.LatestRelease.Equal(resp[0].LatestRelease))
+		assert.True(t, group.UpdatedAt.Equal(resp[0].UpdatedAt))
+	}
+}
+
+func TestGroupHandler_GetAll_InvalidPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGroupService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewGroupHandler(mockService, mockLog).Routes(r)
+
+	// Сервис не должен вызываться при неверной пагинации
+	req := httptest.NewRequest(http.MethodGet, "/groups?page=0", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,80 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// healthCheckTimeout limits a single dependency check
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck checks a dependency of the service, e.g. pings a database
+type HealthCheck func(ctx context.Context) error
+
+// HealthHandler reports whether the service and its dependencies are up
+type HealthHandler struct {
+	checks map[string]HealthCheck
+	log    *slog.Logger
+}
+
+func NewHealthHandler(checks map[string]HealthCheck, log *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+		log:    log,
+	}
+}
+
+func (h *HealthHandler) Routes(r chi.Router) {
+	r.Get("/healthz", h.Get)
+}
+
+// @Summary Check health
+// @Description Check the service and its dependencies, 503 is returned if any of them is down
+// @Tags metrics
+// @Produce  json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "HealthHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	response := dto.HealthResponse{Status: "ok", Checks: make(map[string]string, len(names))}
+	status := http.StatusOK
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		err := h.checks[name](ctx)
+		cancel()
+
+		if err != nil {
+			log.Warn("health check failed", slog.String("check", name), sl.Err(err))
+			response.Status = "unavailable"
+			response.Checks[name] = err.Error()
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[name] = "ok"
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, response)
+}
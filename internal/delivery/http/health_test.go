@@ -0,0 +1,71 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandler_Get(t *testing.T) {
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	tests := []struct {
+		name       string
+		checks     map[string]handler.HealthCheck
+		wantStatus int
+		want       dto.HealthResponse
+	}{
+		{
+			name:       "без проверок",
+			wantStatus: http.StatusOK,
+			want:       dto.HealthResponse{Status: "ok"},
+		},
+		{
+			name: "все зависимости доступны",
+			checks: map[string]handler.HealthCheck{
+				"postgres": func(context.Context) error { return nil },
+			},
+			wantStatus: http.StatusOK,
+			want:       dto.HealthResponse{Status: "ok", Checks: map[string]string{"postgres": "ok"}},
+		},
+		{
+			name: "зависимость недоступна",
+			checks: map[string]handler.HealthCheck{
+				"postgres": func(context.Context) error { return nil },
+				"redis":    func(context.Context) error { return errors.New("connection refused") },
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			want: dto.HealthResponse{Status: "unavailable", Checks: map[string]string{
+				"postgres": "ok",
+				"redis":    "connection refused",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			handler.NewHealthHandler(tt.checks, mockLog).Routes(r)
+
+			req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var resp dto.HealthResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package deliveryHttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// maxImportFileSize limits the size of an uploaded CSV file
+const maxImportFileSize = 10 << 20
+
+// @Summary Import songs from CSV
+// @Description Import songs from a CSV file with a header row. Columns name and group are required, text, link and release_date (YYYY-MM-DD) are optional. Invalid rows are reported and skipped.
+// @Tags songs
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "CSV file"
+// @Param dry_run query bool false "Validate the file without saving songs"
+// @Success 200 {object} dto.ImportResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 413 {object} dto.ErrorResponse "file is larger than 10 MB"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	const op = "Handler.Import"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			log.Warn("invalid dry_run parameter", slog.String("dry_run", dryRunStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid dry_run parameter", nil)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondDecodeError(w, r, log, err)
+			return
+		}
+		log.Error("failed to read uploaded file", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeInvalidRequest, "file is required", nil)
+		return
+	}
+	defer file.Close()
+
+	report, err := h.Service.Import(r.Context(), file, dryRun)
+	if err != nil {
+		respondError(w, r, log, "failed to import songs", err)
+		return
+	}
+
+	log.Info("songs import finished",
+		slog.Int("imported", report.Imported),
+		slog.Int("failed", len(report.Errors)),
+		slog.Bool("dry_run", report.DryRun),
+	)
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.ImportReportToResponse(report))
+}
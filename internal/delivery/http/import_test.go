@@ -0,0 +1,49 @@
+package deliveryHttp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Import_FileTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	h := handler.NewHandler(mockService, mockLog)
+
+	// Файл больше 10 МБ не доходит до сервиса и даёт 413, а не «file is required»
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "songs.csv")
+	assert.NoError(t, err)
+	_, err = file.Write([]byte("name,group\n" + strings.Repeat("Hysteria,Muse\n", 1<<20)))
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	h.Import(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeRequestTooLarge, resp.Code)
+}
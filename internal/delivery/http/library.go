@@ -0,0 +1,83 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// @Summary Get all libraries
+// @Description Get all libraries songs can be scoped to with the X-Library-ID header or the library claim of the JWT
+// @Tags admin
+// @Produce  json,xml,application/yaml
+// @Security AdminToken
+// @Success 200 {array} dto.LibraryResponse
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/libraries [get]
+func (h *AdminHandler) GetLibraries(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.GetLibraries"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	libraries, err := h.LibraryService.GetAll(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch libraries", err)
+		return
+	}
+
+	librariesResponse := make([]*dto.LibraryResponse, 0, len(libraries))
+	for _, library := range libraries {
+		librariesResponse = append(librariesResponse, dto.LibraryToResponse(library))
+	}
+
+	log.Info("libraries successfully fetched", slog.Int("count", len(librariesResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, librariesResponse)
+}
+
+// @Summary Add a library
+// @Description Create a library, its ID scopes the songs of a team. Artists and albums are shared by all libraries.
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Security AdminToken
+// @Param library body dto.LibraryRequest true "Add library request"
+// @Success 201 {object} dto.LibraryResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request or name is missing"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 409 {object} dto.ErrorResponse "library already exists"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/libraries [post]
+func (h *AdminHandler) AddLibrary(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.AddLibrary"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req dto.LibraryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	library := &domain.Library{Name: req.Name}
+	if err := h.LibraryService.Add(r.Context(), library); err != nil {
+		respondError(w, r, log, "failed to add library", err)
+		return
+	}
+
+	log.Info("library successfully added", slog.String("library_id", library.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.LibraryToResponse(library))
+}
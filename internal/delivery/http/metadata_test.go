@@ -0,0 +1,210 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetAllWithFilter_Metadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	explicit := false
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			// Длительность задаётся в секундах, флаг explicit разбирается как bool
+			assert.Equal(t, "rock", filter.Genre)
+			assert.Equal(t, "Abbey", filter.Album)
+			assert.Equal(t, &explicit, filter.Explicit)
+			assert.Equal(t, time.Minute, filter.MinDuration)
+			assert.Equal(t, 5*time.Minute, filter.MaxDuration)
+			return []*domain.Song{{
+				ID:          uuid.New(),
+				Name:        "Come Together",
+				Group:       "The Beatles",
+				Text:        "Here come old flat top",
+				Duration:    259 * time.Second,
+				Genre:       "rock",
+				TrackNumber: 1,
+				Album:       "Abbey Road",
+				Explicit:    &explicit,
+			}}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?genre=rock&album=Abbey&explicit=false&min_duration=60&max_duration=300", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, int64(259000), resp[0].DurationMs)
+		assert.Equal(t, "rock", resp[0].Genre)
+		assert.Equal(t, 1, resp[0].TrackNumber)
+		assert.Equal(t, "Abbey Road", resp[0].Album)
+		assert.Equal(t, &explicit, resp[0].Explicit)
+	}
+}
+
+func TestHandler_GetAllWithFilter_InvalidMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{
+		"explicit=maybe",
+		"exclude_explicit=maybe",
+		"explicit=true&exclude_explicit=true",
+		"min_duration=-1",
+		"max_duration=long",
+		"min_duration=300&max_duration=60",
+		"status=deleted",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		h.GetAllWithFilter(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestHandler_GetAllWithFilter_ExcludeExplicit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			assert.True(t, filter.ExcludeExplicit)
+			assert.Nil(t, filter.Explicit)
+			return nil, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?exclude_explicit=true", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_Update_Metadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+	reqBody := `{"duration_ms": 259000, "genre": "rock", "track_number": 1, "album": "Abbey Road", "explicit": true}`
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String(), strings.NewReader(reqBody))
+	req = withURLParam(req, "id", songID.String())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().
+		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+			assert.Equal(t, 259*time.Second, song.Duration)
+			assert.Equal(t, "rock", song.Genre)
+			assert.Equal(t, 1, song.TrackNumber)
+			assert.Equal(t, "Abbey Road", song.Album)
+			if assert.NotNil(t, song.Explicit) {
+				assert.True(t, *song.Explicit)
+			}
+			return nil
+		})
+
+	h.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_Update_InvalidMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+	songID := uuid.New()
+
+	for _, body := range []string{`{"duration_ms": -1}`, `{"track_number": -3}`} {
+		req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String(), strings.NewReader(body))
+		req = withURLParam(req, "id", songID.String())
+		w := httptest.NewRecorder()
+
+		h.Update(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestHandler_GetAllWithFilter_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			assert.Equal(t, domain.SongStatusPending, filter.Status)
+			return []*domain.Song{{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Status: domain.SongStatusPending}}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?status=pending", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Статус возвращается в ответе, чтобы отличать неполные песни
+	var resp []dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, "pending", resp[0].Status)
+	}
+}
@@ -0,0 +1,33 @@
+package deliveryHttp
+
+import (
+	"net/http"
+	"songLibrary/internal/metrics"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MetricsHandler exposes the published metrics
+type MetricsHandler struct {
+	metrics http.Handler
+}
+
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{
+		metrics: metrics.Handler(),
+	}
+}
+
+func (h *MetricsHandler) Routes(r chi.Router) {
+	r.Get("/metrics", h.Get)
+}
+
+// @Summary Get metrics
+// @Description Get runtime metrics of the service as JSON, the PostgreSQL pool gauges are under postgres_pool
+// @Tags metrics
+// @Produce  json,xml,application/yaml
+// @Success 200 {object} map[string]interface{}
+// @Router /metrics [get]
+func (h *MetricsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.metrics.ServeHTTP(w, r)
+}
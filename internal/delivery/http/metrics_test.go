@@ -0,0 +1,31 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler_Get(t *testing.T) {
+	r := chi.NewRouter()
+	handler.NewMetricsHandler().Routes(r)
+
+	expvar.Publish("test_gauge", expvar.Func(func() any { return 7 }))
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Опубликованные метрики отдаются одним JSON-объектом
+	var body map[string]json.RawMessage
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.JSONEq(t, "7", string(body["test_gauge"]))
+}
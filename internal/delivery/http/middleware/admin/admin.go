@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// New lets through requests carrying the admin token as
+// "Authorization: Bearer <token>" and requests of users with the admin role.
+// With an empty token only admins get through, so admin routes are closed
+// unless a token or roles are configured.
+func New(log *slog.Logger, token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/admin"),
+		)
+
+		if token == "" {
+			log.Warn("admin token is not set, admin routes are only open to the admin role")
+		} else {
+			log.Info("admin middleware enabled")
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if role, ok := domain.RoleFromContext(r.Context()); ok && role == domain.RoleAdmin {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				log.Warn("unauthorized admin request",
+					slog.String("path", r.URL.Path),
+					slog.String("remote_addr", r.RemoteAddr),
+				)
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeUnauthorized,
+					Message:   "admin token is missing or invalid",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
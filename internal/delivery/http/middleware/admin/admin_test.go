@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(token, header string) *httptest.ResponseRecorder {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cache", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	w := httptest.NewRecorder()
+
+	New(log, token)(next).ServeHTTP(w, req)
+	return w
+}
+
+func TestAdmin_ValidToken(t *testing.T) {
+	w := serve("secret", "Bearer secret")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAdmin_InvalidToken(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", "Bearer wrong").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", "secret").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", "").Code)
+}
+
+func TestAdmin_NoTokenConfigured(t *testing.T) {
+	// Без настроенного токена админские маршруты закрыты
+	assert.Equal(t, http.StatusUnauthorized, serve("", "Bearer ").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("", "").Code)
+}
+
+func TestAdmin_AdminRole(t *testing.T) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name string
+		role domain.Role
+		want int
+	}{
+		{name: "администратор проходит без токена", role: domain.RoleAdmin, want: http.StatusOK},
+		{name: "редактор без токена отклоняется", role: domain.RoleEditor, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/cache", nil)
+			req = req.WithContext(domain.WithRole(req.Context(), tt.role))
+			w := httptest.NewRecorder()
+
+			New(log, "secret")(next).ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
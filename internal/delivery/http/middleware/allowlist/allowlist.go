@@ -0,0 +1,89 @@
+package allowlist
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"songLibrary/internal/dto"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// Parse parses the allowed networks, given in CIDR notation or as single
+// addresses
+func Parse(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %w", value, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// New lets through only requests coming from the allowed networks. The client
+// address is the remote address of the connection, forwarding headers aren't
+// trusted as the middleware guards listeners not exposed through a proxy.
+func New(log *slog.Logger, allowed []netip.Prefix) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/allowlist"),
+		)
+
+		log.Info("allowlist middleware enabled", slog.Int("networks", len(allowed)))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if !isAllowed(r.RemoteAddr, allowed) {
+				log.Warn("request from a disallowed address",
+					slog.String("path", r.URL.Path),
+					slog.String("remote_addr", r.RemoteAddr),
+				)
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeForbidden,
+					Message:   "address is not allowed",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func isAllowed(remoteAddr string, allowed []netip.Prefix) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
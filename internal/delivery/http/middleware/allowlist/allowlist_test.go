@@ -0,0 +1,66 @@
+package allowlist
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, allowed []string, remoteAddr string) int {
+	prefixes, err := Parse(allowed)
+	require.NoError(t, err)
+
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cache", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+
+	New(log, prefixes)(next).ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAllowlist(t *testing.T) {
+	allowed := []string{"127.0.0.1", "::1", "10.0.0.0/8"}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       int
+	}{
+		{name: "loopback", remoteAddr: "127.0.0.1:51234", want: http.StatusOK},
+		{name: "loopback IPv6", remoteAddr: "[::1]:51234", want: http.StatusOK},
+		{name: "внутренняя сеть", remoteAddr: "10.1.2.3:51234", want: http.StatusOK},
+		{name: "IPv4 в IPv6", remoteAddr: "[::ffff:10.1.2.3]:51234", want: http.StatusOK},
+		{name: "внешний адрес", remoteAddr: "203.0.113.7:51234", want: http.StatusForbidden},
+		{name: "адрес не разобран", remoteAddr: "@", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, serve(t, allowed, tt.remoteAddr))
+		})
+	}
+}
+
+func TestAllowlist_Empty(t *testing.T) {
+	// Без разрешённых сетей закрыты все адреса
+	assert.Equal(t, http.StatusForbidden, serve(t, nil, "127.0.0.1:51234"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = Parse([]string{"localhost"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// Header carries the API key of the client
+const Header = "X-API-Key"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, raw string) (*domain.APIKey, error)
+}
+
+// New authenticates requests carrying an API key and scopes them to the role
+// and library of the key, the ID of the key is put in the context. Requests without a key are let through, an
+// unknown, revoked or expired key is rejected. A key of one library can't be
+// used with another library named by the request.
+func New(log *slog.Logger, keys Authenticator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/apikey"),
+		)
+
+		log.Info("api key middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get(Header)
+			if raw == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			key, err := keys.Authenticate(ctx, raw)
+			if err != nil {
+				if errors.Is(err, domain.ErrAPIKeyInvalid) {
+					log.Warn("invalid api key",
+						slog.String("path", r.URL.Path),
+						slog.String("remote_addr", r.RemoteAddr),
+					)
+					reject(w, r, http.StatusUnauthorized, dto.CodeUnauthorized, "api key is invalid, revoked or expired")
+					return
+				}
+				log.Error("failed to authenticate api key", sl.Err(err))
+				reject(w, r, http.StatusInternalServerError, dto.CodeInternal, "internal error")
+				return
+			}
+
+			if key.LibraryID != nil {
+				requested := domain.LibraryIDFromContext(ctx)
+				if requested != domain.DefaultLibraryID && requested != *key.LibraryID {
+					log.Warn("api key is scoped to another library",
+						slog.String("api_key_id", key.ID.String()),
+						slog.String("library_id", requested.String()),
+					)
+					reject(w, r, http.StatusForbidden, dto.CodeForbidden, "api key doesn't grant access to the library")
+					return
+				}
+				ctx = domain.WithLibraryID(ctx, *key.LibraryID)
+			}
+
+			ctx = domain.WithRole(ctx, key.Role)
+			ctx = domain.WithAPIKeyID(ctx, key.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func reject(w http.ResponseWriter, r *http.Request, status int, code dto.ErrorCode, message string) {
+	render.Status(r, status)
+	render.JSON(w, r, dto.ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+}
@@ -0,0 +1,101 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type authenticator map[string]*domain.APIKey
+
+func (a authenticator) Authenticate(_ context.Context, raw string) (*domain.APIKey, error) {
+	if raw == "broken" {
+		return nil, errors.New("connection refused")
+	}
+	key, ok := a[raw]
+	if !ok {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	return key, nil
+}
+
+type result struct {
+	role    domain.Role
+	hasRole bool
+	library uuid.UUID
+	keyID   uuid.UUID
+}
+
+func serve(keys authenticator, ctx context.Context, raw string) (*httptest.ResponseRecorder, result) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	var res result
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res.role, res.hasRole = domain.RoleFromContext(r.Context())
+		res.library = domain.LibraryIDFromContext(r.Context())
+		res.keyID, _ = domain.APIKeyIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil).WithContext(ctx)
+	if raw != "" {
+		req.Header.Set(Header, raw)
+	}
+	w := httptest.NewRecorder()
+
+	New(log, keys)(next).ServeHTTP(w, req)
+	return w, res
+}
+
+func TestAPIKey_NoKey(t *testing.T) {
+	w, res := serve(authenticator{}, context.Background(), "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, res.hasRole)
+}
+
+func TestAPIKey_Valid(t *testing.T) {
+	libraryID := uuid.New()
+	keyID := uuid.New()
+	keys := authenticator{"sl_key": {ID: keyID, Role: domain.RoleEditor, LibraryID: &libraryID}}
+
+	// Ключ задаёт роль и библиотеку запроса
+	w, res := serve(keys, context.Background(), "sl_key")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.RoleEditor, res.role)
+	assert.Equal(t, libraryID, res.library)
+	assert.Equal(t, keyID, res.keyID)
+}
+
+func TestAPIKey_Invalid(t *testing.T) {
+	w, _ := serve(authenticator{}, context.Background(), "sl_unknown")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKey_AuthenticatorError(t *testing.T) {
+	w, _ := serve(authenticator{}, context.Background(), "broken")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAPIKey_OtherLibrary(t *testing.T) {
+	libraryID := uuid.New()
+	keys := authenticator{"sl_key": {ID: uuid.New(), Role: domain.RoleViewer, LibraryID: &libraryID}}
+
+	// Ключ одной библиотеки не открывает другую
+	ctx := domain.WithLibraryID(context.Background(), uuid.New())
+	w, _ := serve(keys, ctx, "sl_key")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
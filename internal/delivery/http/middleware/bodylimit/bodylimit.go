@@ -0,0 +1,60 @@
+package bodylimit
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// New limits request bodies to maxBytes. A request declaring a longer body
+// is rejected with 413 right away, a body turning out longer fails to be
+// read by the handler. Requests to paths matching the skipped patterns, as
+// path.Match sees them, keep their own limits.
+func New(log *slog.Logger, maxBytes int64, skip ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/bodylimit"),
+		)
+
+		log.Info("body limit middleware enabled", slog.Int64("max_bytes", maxBytes))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if skipped(r.URL.Path, skip) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.ContentLength > maxBytes {
+				log.Warn("request body too large",
+					slog.Int64("content_length", r.ContentLength),
+					slog.String("path", r.URL.Path),
+				)
+				render.Status(r, http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeRequestTooLarge,
+					Message:   "request body too large",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func skipped(urlPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, urlPath); ok {
+			return true
+		}
+	}
+	return false
+}
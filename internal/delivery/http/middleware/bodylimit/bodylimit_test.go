@@ -0,0 +1,74 @@
+package bodylimit
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echo отвечает 413, если тело не удалось прочитать из-за лимита
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	w.Write(body)
+})
+
+func serve(path, body string, contentLength int64) *httptest.ResponseRecorder {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.ContentLength = contentLength
+	w := httptest.NewRecorder()
+
+	New(log, 10, "/songs/import", "/songs/*/cover")(echo).ServeHTTP(w, req)
+	return w
+}
+
+func TestBodyLimit_Allowed(t *testing.T) {
+	w := serve("/songs", "short", 5)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "short", w.Body.String())
+}
+
+func TestBodyLimit_ContentLengthTooLarge(t *testing.T) {
+	w := serve("/songs", strings.Repeat("a", 20), 20)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeRequestTooLarge, resp.Code)
+}
+
+func TestBodyLimit_UnknownLengthTooLarge(t *testing.T) {
+	// Без Content-Length лимит срабатывает при чтении тела
+	w := serve("/songs", strings.Repeat("a", 20), -1)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBodyLimit_SkippedPath(t *testing.T) {
+	body := strings.Repeat("a", 20)
+
+	for _, path := range []string{"/songs/import", "/songs/42/cover"} {
+		w := serve(path, body, 20)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, body, w.Body.String())
+	}
+}
@@ -0,0 +1,237 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"strings"
+)
+
+// Encodings a response can be compressed with, gzip is preferred
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// compressibleTypes are the content types worth compressing. Streams such as
+// text/event-stream are written as they are, so events are not held back.
+var compressibleTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/yaml":       true,
+	"application/javascript": true,
+	"text/xml":               true,
+	"text/plain":             true,
+	"text/html":              true,
+	"text/css":               true,
+	"text/javascript":        true,
+}
+
+// New compresses responses with gzip or deflate depending on the
+// Accept-Encoding header. Responses shorter than minSize bytes are sent
+// uncompressed, level is the compression level from 1 to 9.
+func New(log *slog.Logger, minSize, level int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/compress"),
+		)
+
+		log.Info("compress middleware enabled",
+			slog.Int("min_size", minSize),
+			slog.Int("level", level),
+		)
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			cw := &writer{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        minSize,
+				level:          level,
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(cw, r)
+
+			if err := cw.Close(); err != nil {
+				log.Error("failed to finish response", sl.Err(err))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// negotiateEncoding returns the supported encoding accepted by the client,
+// an empty string if none is accepted
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = q > 0
+	}
+
+	switch {
+	case accepted[encodingGzip]:
+		return encodingGzip
+	case accepted[encodingDeflate]:
+		return encodingDeflate
+	default:
+		return ""
+	}
+}
+
+// writer buffers the beginning of a response until it is known whether the
+// response is long enough to be compressed
+type writer struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+	level    int
+
+	status  int
+	buf     []byte
+	decided bool
+	enc     io.WriteCloser
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	if !w.compressible() {
+		if err := w.passThrough(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.startCompression(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends what was written so far. A response flushed before it reached
+// the minimum size is sent uncompressed.
+func (w *writer) Flush() {
+	if !w.decided {
+		if err := w.passThrough(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := w.enc.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close writes the buffered part of a short response or finishes the
+// compressed stream
+func (w *writer) Close() error {
+	if !w.decided {
+		return w.passThrough()
+	}
+	if w.enc != nil {
+		return w.enc.Close()
+	}
+	return nil
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible reports whether the response is worth compressing by its
+// headers
+func (w *writer) compressible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return compressibleTypes[mediaType]
+}
+
+func (w *writer) passThrough() error {
+	w.decided = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *writer) startCompression() error {
+	w.decided = true
+
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	enc, err := newEncoder(w.encoding, w.ResponseWriter, w.level)
+	if err != nil {
+		return err
+	}
+	w.enc = enc
+
+	_, err = w.enc.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+func newEncoder(encoding string, w io.Writer, level int) (io.WriteCloser, error) {
+	if encoding == encodingGzip {
+		return gzip.NewWriterLevel(w, level)
+	}
+	return zlib.NewWriterLevel(w, level)
+}
@@ -0,0 +1,100 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(acceptEncoding, contentType, body string, status int) *httptest.ResponseRecorder {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+
+	New(log, 100, gzip.DefaultCompression)(next).ServeHTTP(w, req)
+	return w
+}
+
+func TestCompress_Gzip(t *testing.T) {
+	body := strings.Repeat(`{"text":"It's bugging me"}`, 20)
+
+	w := serve("gzip, deflate", "application/json; charset=utf-8", body, http.StatusCreated)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+	r, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	decoded, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
+
+func TestCompress_Deflate(t *testing.T) {
+	body := strings.Repeat("It's bugging me\n", 20)
+
+	// gzip с q=0 запрещён, поэтому выбирается deflate
+	w := serve("gzip;q=0, deflate", "text/plain", body, http.StatusOK)
+
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+	r, err := zlib.NewReader(w.Body)
+	assert.NoError(t, err)
+	decoded, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
+
+func TestCompress_Skipped(t *testing.T) {
+	long := strings.Repeat("a", 200)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+	}{
+		{name: "клиент не поддерживает сжатие", acceptEncoding: "", contentType: "application/json", body: long},
+		{name: "неизвестная кодировка", acceptEncoding: "br", contentType: "application/json", body: long},
+		{name: "короткий ответ", acceptEncoding: "gzip", contentType: "application/json", body: `{"status":"ok"}`},
+		{name: "поток событий", acceptEncoding: "gzip", contentType: "text/event-stream", body: long},
+		{name: "изображение", acceptEncoding: "gzip", contentType: "image/png", body: long},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.acceptEncoding, tt.contentType, tt.body, http.StatusOK)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestCompress_NoBody(t *testing.T) {
+	w := serve("gzip", "application/json", "", http.StatusNoContent)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Body.String())
+}
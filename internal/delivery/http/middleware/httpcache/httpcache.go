@@ -0,0 +1,266 @@
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// Entry is a cached response
+type Entry struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"`
+}
+
+// Store keeps cached responses. Entries are stored under the generation the
+// lookup saw, Invalidate starts a new one, so a response rendered while a
+// mutation was made is never served after it.
+type Store interface {
+	// Get returns the entry of key and the current generation, or
+	// domain.ErrCacheMiss with the generation if there is none
+	Get(ctx context.Context, key string) (*Entry, int64, error)
+	Set(ctx context.Context, key string, generation int64, entry *Entry, ttl time.Duration) error
+	Invalidate(ctx context.Context) error
+}
+
+// Route sets how long responses to paths matching Pattern, as path.Match
+// sees it, are cached. Zero TTL leaves them uncached.
+type Route struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// headers set by the middleware itself, they are not stored with the entry
+var ownHeaders = []string{"Age", "Cache-Control", "X-Cache"}
+
+// New caches successful responses to GET requests of the routes for their
+// TTL, the first route matching the path wins. Responses are cached per
+// library, user and Accept header, larger than maxSize bytes are not cached.
+// Conditional requests and requests with Cache-Control no-cache skip the
+// cache, a successful mutation invalidates it. Cached responses carry
+// Cache-Control max-age and Age headers, X-Cache tells a HIT from a MISS.
+func New(log *slog.Logger, store Store, maxSize int, routes ...Route) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/httpcache"),
+		)
+
+		log.Info("response cache middleware enabled", slog.Int("routes", len(routes)))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				if !mutation(r.Method) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+				next.ServeHTTP(ww, r)
+
+				if ww.Status() < http.StatusBadRequest {
+					if err := store.Invalidate(r.Context()); err != nil {
+						log.Error("failed to invalidate response cache", slog.String("path", r.URL.Path), sl.Err(err))
+					}
+				}
+				return
+			}
+
+			ttl := routeTTL(r.URL.Path, routes)
+			if ttl <= 0 || conditional(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := requestKey(r)
+			entry, generation, err := store.Get(r.Context(), key)
+			switch {
+			case err == nil && !noCache(r):
+				serveEntry(w, entry, ttl)
+				return
+			case err != nil && !errors.Is(err, domain.ErrCacheMiss):
+				// The cache must not take the API down with it, so the request is served uncached
+				log.Error("failed to read cached response", slog.String("path", r.URL.Path), sl.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, ttl: ttl, body: limitedBuffer{limit: maxSize}}
+			next.ServeHTTP(rec, r)
+
+			if !rec.cacheable || rec.body.overflow || w.Header().Get("Set-Cookie") != "" {
+				return
+			}
+
+			entry = &Entry{
+				Status:   http.StatusOK,
+				Header:   storedHeader(w.Header()),
+				Body:     rec.body.Bytes(),
+				StoredAt: time.Now(),
+			}
+			if err := store.Set(r.Context(), key, generation, entry, ttl); err != nil {
+				log.Error("failed to cache response", slog.String("path", r.URL.Path), sl.Err(err))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func mutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func routeTTL(urlPath string, routes []Route) time.Duration {
+	for _, route := range routes {
+		if ok, _ := path.Match(route.Pattern, urlPath); ok {
+			return route.TTL
+		}
+	}
+	return 0
+}
+
+// conditional reports whether the client revalidates a response it holds,
+// handlers answer such requests themselves
+func conditional(r *http.Request) bool {
+	return r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != ""
+}
+
+// noCache reports whether the client asks for a fresh response, it is
+// cached for the next requests all the same
+func noCache(r *http.Request) bool {
+	directives := strings.ToLower(r.Header.Get("Cache-Control") + "," + r.Header.Get("Pragma"))
+	return strings.Contains(directives, "no-cache") || strings.Contains(directives, "no-store")
+}
+
+// requestKey identifies the response to a request, responses differ by
+// library, user and format
+func requestKey(r *http.Request) string {
+	user := ""
+	if id, ok := domain.UserIDFromContext(r.Context()); ok {
+		user = id.String()
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		domain.LibraryIDFromContext(r.Context()).String(),
+		user,
+		r.Header.Get("Accept"),
+		r.URL.RequestURI(),
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+func serveEntry(w http.ResponseWriter, entry *Entry, ttl time.Duration) {
+	for name, values := range entry.Header {
+		w.Header()[name] = values
+	}
+
+	age := max(time.Since(entry.StoredAt), 0)
+	w.Header().Set("Age", strconv.Itoa(int(age.Seconds())))
+	w.Header().Set("Cache-Control", maxAge(ttl))
+	w.Header().Set("X-Cache", "HIT")
+	w.WriteHeader(entry.Status)
+	_, _ = w.Write(entry.Body)
+}
+
+func maxAge(ttl time.Duration) string {
+	return "private, max-age=" + strconv.Itoa(int(ttl.Seconds()))
+}
+
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range ownHeaders {
+		stored.Del(name)
+	}
+	return stored
+}
+
+// recorder keeps the status and body of a response as it is written. A
+// successful response is cacheable for ttl unless the handler set
+// Cache-Control itself.
+type recorder struct {
+	http.ResponseWriter
+	ttl       time.Duration
+	status    int
+	cacheable bool
+	body      limitedBuffer
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+		if code == http.StatusOK && rec.Header().Get("Cache-Control") == "" {
+			rec.cacheable = true
+			rec.Header().Set("Cache-Control", maxAge(rec.ttl))
+			rec.Header().Set("X-Cache", "MISS")
+		}
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *recorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+	_, _ = rec.body.Write(p)
+	return rec.ResponseWriter.Write(p)
+}
+
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// limitedBuffer keeps up to limit bytes written to it and reports whether
+// more were written
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.overflow || b.Len()+len(p) > b.limit {
+		b.overflow = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// InvalidateOn invalidates the store on every song event until ctx is done
+// or events is closed, so songs changed outside of requests, e.g. by
+// enrichment, aren't served stale until their entries expire
+func InvalidateOn(ctx context.Context, log *slog.Logger, store Store, events <-chan domain.SongEvent) {
+	log = log.With(slog.String("component", "middleware/httpcache"))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := store.Invalidate(ctx); err != nil {
+				log.Error("failed to invalidate response cache", slog.String("event", string(event.Type)), sl.Err(err))
+			}
+		}
+	}
+}
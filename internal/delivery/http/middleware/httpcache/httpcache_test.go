@@ -0,0 +1,218 @@
+package httpcache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps entries by generation like RedisStore
+type memoryStore struct {
+	generation int64
+	entries    map[string]*Entry
+	err        error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: map[string]*Entry{}}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*Entry, int64, error) {
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	entry, ok := s.entries[entryKey(s.generation, key)]
+	if !ok {
+		return nil, s.generation, domain.ErrCacheMiss
+	}
+	return entry, s.generation, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, generation int64, entry *Entry, _ time.Duration) error {
+	s.entries[entryKey(generation, key)] = entry
+	return nil
+}
+
+func (s *memoryStore) Invalidate(_ context.Context) error {
+	s.generation++
+	return nil
+}
+
+// songsHandler counts the requests reaching it and answers them with status
+// if it is set
+type songsHandler struct {
+	calls  int
+	status int
+}
+
+func (h *songsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.calls++
+	}
+	if h.status != 0 {
+		w.WriteHeader(h.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"songs":[]}`))
+}
+
+func newCache(store Store, next http.Handler) http.Handler {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	return New(log, store, 1024,
+		Route{Pattern: "/songs/random", TTL: 0},
+		Route{Pattern: "/songs", TTL: time.Minute},
+		Route{Pattern: "/songs/*", TTL: 30 * time.Second},
+	)(next)
+}
+
+func serve(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestCache_HitAndMiss(t *testing.T) {
+	next := &songsHandler{}
+	h := newCache(newMemoryStore(), next)
+
+	// Первый запрос доходит до обработчика и кэшируется
+	w := serve(h, http.MethodGet, "/songs", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+
+	// Повторный запрос отдаётся из кэша вместе с заголовками ответа
+	w = serve(h, http.MethodGet, "/songs", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Equal(t, "0", w.Header().Get("Age"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"songs":[]}`, w.Body.String())
+	assert.Equal(t, 1, next.calls)
+
+	// Другой формат ответа кэшируется отдельно
+	serve(h, http.MethodGet, "/songs", http.Header{"Accept": {"application/xml"}})
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCache_Routes(t *testing.T) {
+	next := &songsHandler{}
+	h := newCache(newMemoryStore(), next)
+
+	// TTL маршрута определяется первым подходящим шаблоном
+	w := serve(h, http.MethodGet, "/songs/8f14e45f-ceea-467f-a8e7-1d5c6c7e5a2b", nil)
+	assert.Equal(t, "private, max-age=30", w.Header().Get("Cache-Control"))
+
+	// Маршруты с нулевым TTL и без шаблона не кэшируются
+	for _, target := range []string{"/songs/random", "/songs/random", "/albums", "/albums"} {
+		w = serve(h, http.MethodGet, target, nil)
+		assert.Empty(t, w.Header().Get("X-Cache"), target)
+	}
+	assert.Equal(t, 5, next.calls)
+}
+
+func TestCache_Invalidation(t *testing.T) {
+	next := &songsHandler{}
+	h := newCache(newMemoryStore(), next)
+
+	serve(h, http.MethodGet, "/songs", nil)
+
+	// Неуспешное изменение не сбрасывает кэш
+	next.status = http.StatusBadRequest
+	serve(h, http.MethodPost, "/songs", nil)
+	next.status = 0
+	w := serve(h, http.MethodGet, "/songs", nil)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+
+	// Успешное изменение сбрасывает кэш
+	serve(h, http.MethodPut, "/songs/8f14e45f-ceea-467f-a8e7-1d5c6c7e5a2b", nil)
+	w = serve(h, http.MethodGet, "/songs", nil)
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCache_Skipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{name: "условный запрос", header: http.Header{"If-None-Match": {`"etag"`}}},
+		{name: "no-cache", header: http.Header{"Cache-Control": {"no-cache"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &songsHandler{}
+			h := newCache(newMemoryStore(), next)
+
+			serve(h, http.MethodGet, "/songs", nil)
+			w := serve(h, http.MethodGet, "/songs", tt.header)
+
+			assert.NotEqual(t, "HIT", w.Header().Get("X-Cache"))
+			assert.Equal(t, 2, next.calls)
+		})
+	}
+}
+
+func TestCache_Uncacheable(t *testing.T) {
+	// Ошибки не кэшируются и не помечаются кэшируемыми
+	next := &songsHandler{status: http.StatusNotFound}
+	h := newCache(newMemoryStore(), next)
+
+	w := serve(h, http.MethodGet, "/songs", nil)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+	serve(h, http.MethodGet, "/songs", nil)
+	assert.Equal(t, 2, next.calls)
+
+	// Ответы больше лимита не кэшируются
+	calls := 0
+	large := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write(make([]byte, 2048))
+	})
+	h = newCache(newMemoryStore(), large)
+	serve(h, http.MethodGet, "/songs", nil)
+	w = serve(h, http.MethodGet, "/songs", nil)
+	assert.Len(t, w.Body.Bytes(), 2048)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_StoreError(t *testing.T) {
+	next := &songsHandler{}
+	store := newMemoryStore()
+	store.err = errors.New("redis is down")
+	h := newCache(store, next)
+
+	// Недоступный кэш не мешает обработке запросов
+	w := serve(h, http.MethodGet, "/songs", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"songs":[]}`, w.Body.String())
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestInvalidateOn(t *testing.T) {
+	store := newMemoryStore()
+	events := make(chan domain.SongEvent, 2)
+	events <- domain.SongEvent{Type: domain.SongUpdated}
+	events <- domain.SongEvent{Type: domain.SongDeleted}
+	close(events)
+
+	// Каждое событие о песне начинает новое поколение кэша
+	InvalidateOn(context.Background(), slog.New(slogdiscard.NewDiscardHandler()), store, events)
+	assert.Equal(t, int64(2), store.generation)
+}
@@ -0,0 +1,90 @@
+package httpcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix     = "httpcache:"
+	generationKey = keyPrefix + "generation"
+)
+
+// lookupScript reads the current generation and the entry stored under it
+// in a single round trip
+var lookupScript = redis.NewScript(`
+local generation = redis.call("GET", KEYS[1]) or "0"
+local entry = redis.call("GET", ARGV[1] .. generation .. ":" .. ARGV[2]) or ""
+return {generation, entry}
+`)
+
+// RedisStore keeps cached responses shared by all application instances.
+// Entries of older generations are left to expire.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (*Entry, int64, error) {
+	const op = "httpcache.RedisStore.Get"
+
+	values, err := lookupScript.Run(ctx, s.client, []string{generationKey}, keyPrefix, key).StringSlice()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: could not run lookup script: %w", op, err)
+	}
+	if len(values) != 2 {
+		return nil, 0, fmt.Errorf("%s: unexpected lookup script result: %v", op, values)
+	}
+
+	generation, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: invalid generation: %w", op, err)
+	}
+	if values[1] == "" {
+		return nil, generation, fmt.Errorf("%s: %w", op, domain.ErrCacheMiss)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(values[1]), &entry); err != nil {
+		return nil, generation, fmt.Errorf("%s: cached response can't be read: %w: %w", op, domain.ErrCacheMiss, err)
+	}
+	return &entry, generation, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, generation int64, entry *Entry, ttl time.Duration) error {
+	const op = "httpcache.RedisStore.Set"
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("%s: could not marshal response: %w", op, err)
+	}
+
+	if err := s.client.Set(ctx, entryKey(generation, key), entryJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: could not set response in Redis: %w", op, err)
+	}
+	return nil
+}
+
+// Invalidate starts a new generation, the entries of the previous ones are
+// never read again
+func (s *RedisStore) Invalidate(ctx context.Context) error {
+	const op = "httpcache.RedisStore.Invalidate"
+
+	if err := s.client.Incr(ctx, generationKey).Err(); err != nil {
+		return fmt.Errorf("%s: could not start a new generation: %w", op, err)
+	}
+	return nil
+}
+
+func entryKey(generation int64, key string) string {
+	return keyPrefix + strconv.FormatInt(generation, 10) + ":" + key
+}
@@ -0,0 +1,182 @@
+package library
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Header carries the ID of the library the request is scoped to
+const Header = "X-Library-ID"
+
+// DefaultClaim is the JWT claim holding the library ID
+const DefaultClaim = "library_id"
+
+var (
+	errMalformedToken = errors.New("malformed token")
+	errInvalidToken   = errors.New("invalid token signature")
+	errExpiredToken   = errors.New("token is expired")
+)
+
+type Resolver interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Library, error)
+}
+
+// New scopes the request context to a library. With a JWT secret the library
+// is taken from the claim of the HS256 token sent as "Authorization: Bearer
+// <token>", the header can't name another library then and is rejected
+// unless such a token grants the library it names. Without a secret the
+// header is trusted, it is expected to be set by the gateway in front of the
+// service. Requests naming no library use the default one.
+func New(log *slog.Logger, resolver Resolver, jwtSecret, claim string) func(next http.Handler) http.Handler {
+	if claim == "" {
+		claim = DefaultClaim
+	}
+
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/library"),
+		)
+
+		if jwtSecret != "" {
+			log.Info("library middleware enabled", slog.String("source", "jwt"), slog.String("claim", claim))
+		} else {
+			log.Info("library middleware enabled", slog.String("source", "header"))
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(Header)
+
+			if jwtSecret != "" {
+				header := value
+				value = ""
+
+				token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+				// Bearer tokens that aren't JWTs, like the admin token, don't
+				// name a library
+				if ok && strings.Count(token, ".") == 2 {
+					fromToken, err := tokenClaim(token, jwtSecret, claim)
+					if err != nil {
+						log.Warn("invalid library token", sl.Err(err))
+						reject(w, r, http.StatusUnauthorized, dto.CodeUnauthorized, "token is invalid or expired")
+						return
+					}
+					value = fromToken
+				}
+
+				// Only a verified token grants a library, the header can at
+				// most repeat it
+				if header != "" && header != value {
+					log.Warn("library header isn't granted by the token",
+						slog.String("library_id", header),
+						slog.String("token_library_id", value),
+					)
+					reject(w, r, http.StatusForbidden, dto.CodeForbidden, "token doesn't grant access to the library")
+					return
+				}
+			}
+
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id, err := uuid.Parse(value)
+			if err != nil {
+				log.Warn("invalid library id", slog.String("library_id", value))
+				reject(w, r, http.StatusBadRequest, dto.CodeValidationFailed, "invalid library id")
+				return
+			}
+
+			if _, err := resolver.Get(r.Context(), id); err != nil {
+				if errors.Is(err, domain.ErrLibraryNotFound) {
+					reject(w, r, http.StatusNotFound, dto.CodeLibraryNotFound, "library not found")
+					return
+				}
+				log.Error("failed to resolve library", slog.String("library_id", value), sl.Err(err))
+				reject(w, r, http.StatusInternalServerError, dto.CodeInternal, "internal error")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.WithLibraryID(r.Context(), id)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// FromContext returns the library the request is scoped to
+func FromContext(ctx context.Context) uuid.UUID {
+	return domain.LibraryIDFromContext(ctx)
+}
+
+func reject(w http.ResponseWriter, r *http.Request, status int, code dto.ErrorCode, message string) {
+	render.Status(r, status)
+	render.JSON(w, r, dto.ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+}
+
+// tokenClaim verifies an HS256 JWT and returns its string claim, empty if the
+// token doesn't have it
+func tokenClaim(token, secret, claim string) (string, error) {
+	parts := strings.Split(token, ".")
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", err
+	}
+	if header.Alg != "HS256" {
+		return "", errMalformedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errMalformedToken
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", errInvalidToken
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", err
+	}
+	if exp, ok := claims["exp"].(float64); ok && time.Now().Unix() >= int64(exp) {
+		return "", errExpiredToken
+	}
+
+	value, _ := claims[claim].(string)
+	return value, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errMalformedToken
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package library
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "test-secret"
+
+type resolver map[uuid.UUID]bool
+
+func (r resolver) Get(_ context.Context, id uuid.UUID) (*domain.Library, error) {
+	if !r[id] {
+		return nil, domain.ErrLibraryNotFound
+	}
+	return &domain.Library{ID: id}, nil
+}
+
+func sign(t *testing.T, claims map[string]interface{}, key string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+	body := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(body))
+	return body + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func serve(known resolver, jwtSecret string, headers map[string]string) (*httptest.ResponseRecorder, uuid.UUID) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	var id uuid.UUID
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+
+	New(log, known, jwtSecret, "")(next).ServeHTTP(w, req)
+	return w, id
+}
+
+func TestLibrary_Default(t *testing.T) {
+	// Без заголовка запрос относится к библиотеке по умолчанию
+	w, id := serve(resolver{}, "", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.DefaultLibraryID, id)
+}
+
+func TestLibrary_Header(t *testing.T) {
+	libraryID := uuid.New()
+
+	w, id := serve(resolver{libraryID: true}, "", map[string]string{Header: libraryID.String()})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, libraryID, id)
+}
+
+func TestLibrary_InvalidID(t *testing.T) {
+	w, _ := serve(resolver{}, "", map[string]string{Header: "not-a-uuid"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLibrary_Unknown(t *testing.T) {
+	w, _ := serve(resolver{}, "", map[string]string{Header: uuid.NewString()})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestLibrary_JWTClaim(t *testing.T) {
+	libraryID := uuid.New()
+	token := sign(t, map[string]interface{}{
+		"library_id": libraryID.String(),
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}, secret)
+
+	w, id := serve(resolver{libraryID: true}, secret, map[string]string{"Authorization": "Bearer " + token})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, libraryID, id)
+}
+
+func TestLibrary_JWTInvalidSignature(t *testing.T) {
+	token := sign(t, map[string]interface{}{"library_id": uuid.NewString()}, "other-secret")
+
+	w, _ := serve(resolver{}, secret, map[string]string{"Authorization": "Bearer " + token})
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestLibrary_JWTExpired(t *testing.T) {
+	libraryID := uuid.New()
+	token := sign(t, map[string]interface{}{
+		"library_id": libraryID.String(),
+		"exp":        time.Now().Add(-time.Minute).Unix(),
+	}, secret)
+
+	w, _ := serve(resolver{libraryID: true}, secret, map[string]string{"Authorization": "Bearer " + token})
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestLibrary_HeaderDoesNotMatchToken(t *testing.T) {
+	// Заголовок не может переопределить библиотеку из токена
+	libraryID := uuid.New()
+	token := sign(t, map[string]interface{}{"library_id": libraryID.String()}, secret)
+
+	w, _ := serve(resolver{libraryID: true}, secret, map[string]string{
+		"Authorization": "Bearer " + token,
+		Header:          uuid.NewString(),
+	})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestLibrary_NonJWTBearerIgnored(t *testing.T) {
+	// Токен администратора не является JWT и не задаёт библиотеку
+	w, id := serve(resolver{}, secret, map[string]string{"Authorization": "Bearer admin-token"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.DefaultLibraryID, id)
+}
+
+func TestLibrary_JWTModeHeaderWithoutToken(t *testing.T) {
+	library := uuid.New()
+
+	// Без проверенного токена заголовок не даёт доступа к чужой библиотеке
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{name: "без Authorization", headers: map[string]string{Header: library.String()}},
+		{name: "токен администратора", headers: map[string]string{
+			"Authorization": "Bearer admin-token",
+			Header:          library.String(),
+		}},
+		{name: "токен без библиотеки", headers: map[string]string{
+			"Authorization": "Bearer " + sign(t, map[string]interface{}{"sub": "user"}, secret),
+			Header:          library.String(),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, id := serve(resolver{library: true}, secret, tt.headers)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Equal(t, uuid.Nil, id)
+		})
+	}
+}
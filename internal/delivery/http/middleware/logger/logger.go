@@ -3,6 +3,7 @@ package logger
 import (
 	"log/slog"
 	"net/http"
+	"songLibrary/pkg/logger/sl"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,17 +18,12 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 		log.Info("logger middleware enabled")
 
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			// reqID := middleware.GetReqID(r.Context())
-			// if reqID == "" {
-			// 	reqID = "unknown" // Just for testing purposes
-			// }
-
 			entry := log.With(
 				slog.String("method", r.Method),
 				slog.String("path", r.URL.Path),
 				slog.String("remote_addr", r.RemoteAddr),
 				slog.String("user_agent", r.UserAgent()),
-				// slog.String("request_id", reqID),
+				sl.RequestID(r.Context()),
 			)
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
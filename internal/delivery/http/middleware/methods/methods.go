@@ -0,0 +1,156 @@
+package methods
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routedMethods are the methods the routes are registered with, HEAD and
+// OPTIONS are answered by the middleware
+var routedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// New answers HEAD and OPTIONS requests for the routes of routes. HEAD is
+// served by the GET route with the body dropped and Content-Length set to
+// its length, OPTIONS lists the methods of the route in the Allow header.
+func New(log *slog.Logger, routes chi.Routes) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/methods"),
+		)
+
+		log.Info("methods middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodHead:
+				routes, path := resolve(routes, r)
+				if routes.Match(chi.NewRouteContext(), http.MethodHead, path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				if rctx := chi.RouteContext(r.Context()); rctx != nil {
+					rctx.RouteMethod = http.MethodGet
+				}
+
+				hw := &headWriter{ResponseWriter: w, status: http.StatusOK}
+				next.ServeHTTP(hw, r)
+				hw.finish()
+			case http.MethodOptions:
+				allowed := allowedMethods(resolve(routes, r))
+				if len(allowed) == 0 {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				log.Debug("options answered", slog.String("path", r.URL.Path))
+				w.Header().Set("Allow", strings.Join(allowed, ", "))
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// resolve returns the router and path matching the request. chi routes the
+// root of a mounted router for every method, so the mounted router is asked
+// about its root instead.
+func resolve(routes chi.Routes, r *http.Request) (chi.Routes, string) {
+	path := r.URL.Path
+	if r.URL.RawPath != "" {
+		path = r.URL.RawPath
+	}
+
+	pattern := strings.TrimSuffix(path, "/") + "/*"
+	for _, route := range routes.Routes() {
+		if route.SubRoutes != nil && route.Pattern == pattern {
+			return route.SubRoutes, "/"
+		}
+	}
+	return routes, path
+}
+
+// allowedMethods returns the methods routed for path, nil if path is not
+// routed at all
+func allowedMethods(routes chi.Routes, path string) []string {
+	var allowed []string
+	for _, method := range routedMethods {
+		if !routes.Match(chi.NewRouteContext(), method, path) {
+			continue
+		}
+		allowed = append(allowed, method)
+		if method == http.MethodGet {
+			allowed = append(allowed, http.MethodHead)
+		}
+	}
+	if allowed == nil {
+		return nil
+	}
+	return append(allowed, http.MethodOptions)
+}
+
+// headWriter drops the body of a response to a HEAD request and holds the
+// status back until the length of the body is known
+type headWriter struct {
+	http.ResponseWriter
+
+	status      int
+	length      int
+	wroteHeader bool
+}
+
+func (w *headWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+}
+
+func (w *headWriter) Write(p []byte) (int, error) {
+	w.length += len(p)
+	return len(p), nil
+}
+
+// Flush sends the headers right away, streamed responses have no length
+func (w *headWriter) Flush() {
+	w.sendHeader()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *headWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish sends the headers with the Content-Length the GET response would
+// have
+func (w *headWriter) finish() {
+	if !w.wroteHeader && w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.length))
+	}
+	w.sendHeader()
+}
+
+func (w *headWriter) sendHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
@@ -0,0 +1,117 @@
+package methods
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const songBody = `{"name":"Hysteria","group":"Muse"}`
+
+func newRouter() *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(New(slog.New(slogdiscard.NewDiscardHandler()), r))
+
+	r.Route("/songs", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, "[]")
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"1"`)
+			if r.Header.Get("If-None-Match") == `"1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			io.WriteString(w, songBody)
+		})
+		r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	})
+	r.Post("/songs/batch-get", func(w http.ResponseWriter, r *http.Request) {})
+
+	return r
+}
+
+func TestMethods_Head(t *testing.T) {
+	req := httptest.NewRequest(http.MethodHead, "/songs/42", nil)
+	w := httptest.NewRecorder()
+
+	newRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Equal(t, "34", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestMethods_Head_NotModified(t *testing.T) {
+	req := httptest.NewRequest(http.MethodHead, "/songs/42", nil)
+	req.Header.Set("If-None-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	newRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Length"))
+}
+
+func TestMethods_Head_NoGetRoute(t *testing.T) {
+	req := httptest.NewRequest(http.MethodHead, "/songs/batch-get", nil)
+	w := httptest.NewRecorder()
+
+	newRouter().ServeHTTP(w, req)
+
+	// batch-get совпадает с /songs/{id}, поэтому отвечает GET /songs/{id}
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodHead, "/albums", nil)
+	w = httptest.NewRecorder()
+
+	newRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestMethods_Options(t *testing.T) {
+	tests := []struct {
+		path  string
+		allow string
+	}{
+		{path: "/songs", allow: "GET, HEAD, POST, OPTIONS"},
+		{path: "/songs/42", allow: "GET, HEAD, DELETE, OPTIONS"},
+		{path: "/songs/batch-get", allow: "GET, HEAD, POST, DELETE, OPTIONS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			newRouter().ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, tt.allow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestMethods_Options_NotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodOptions, "/albums", nil)
+	w := httptest.NewRecorder()
+
+	newRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Allow"))
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// Result is the state of a client's token bucket after a request was counted
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string) (*Result, error)
+}
+
+func New(log *slog.Logger, limiter Limiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/ratelimit"),
+		)
+
+		log.Info("rate limit middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := clientKey(r)
+
+			res, err := limiter.Allow(r.Context(), key)
+			if err != nil {
+				// The limiter must not take the API down with it, so requests are let through
+				log.Error("failed to check rate limit", slog.String("key", key), sl.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(seconds(res.ResetAfter)))
+
+			if !res.Allowed {
+				log.Warn("rate limit exceeded", slog.String("key", key))
+				w.Header().Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+				render.Status(r, http.StatusTooManyRequests)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeTooManyRequests,
+					Message:   "too many requests",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// clientKey identifies the client a request is counted against. Requests
+// authenticated with an API key are limited per key, so clients sharing an
+// address don't share a bucket, anonymous requests per IP address.
+func clientKey(r *http.Request) string {
+	if id, ok := domain.APIKeyIDFromContext(r.Context()); ok {
+		return "key:" + id.String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// seconds rounds a duration up to whole seconds as the rate limit headers expect
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubLimiter struct {
+	result *Result
+	err    error
+	keys   []string
+}
+
+func (s *stubLimiter) Allow(_ context.Context, key string) (*Result, error) {
+	s.keys = append(s.keys, key)
+	return s.result, s.err
+}
+
+func serve(limiter Limiter) *httptest.ResponseRecorder {
+	return serveContext(context.Background(), limiter)
+}
+
+func serveContext(ctx context.Context, limiter Limiter) *httptest.ResponseRecorder {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil).WithContext(ctx)
+	req.RemoteAddr = "10.0.0.1:51234"
+	w := httptest.NewRecorder()
+
+	New(log, limiter)(next).ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_Allowed(t *testing.T) {
+	limiter := &stubLimiter{result: &Result{
+		Allowed:    true,
+		Limit:      20,
+		Remaining:  19,
+		ResetAfter: 100 * time.Millisecond,
+	}}
+
+	w := serve(limiter)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"ip:10.0.0.1"}, limiter.keys)
+	assert.Equal(t, "20", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "19", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestRateLimit_Exceeded(t *testing.T) {
+	limiter := &stubLimiter{result: &Result{
+		Allowed:    false,
+		Limit:      20,
+		Remaining:  0,
+		RetryAfter: 1500 * time.Millisecond,
+		ResetAfter: 2 * time.Second,
+	}}
+
+	w := serve(limiter)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "too many requests")
+}
+
+func TestRateLimit_LimiterError(t *testing.T) {
+	limiter := &stubLimiter{err: errors.New("redis is down")}
+
+	w := serve(limiter)
+
+	// При недоступности Redis запросы пропускаются
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimit_PerAPIKey(t *testing.T) {
+	limiter := &stubLimiter{result: &Result{Allowed: true, Limit: 20, Remaining: 19}}
+
+	// Запросы с API-ключом считаются по ключу, а не по адресу клиента
+	keyID := uuid.New()
+	w := serveContext(domain.WithAPIKeyID(context.Background(), keyID), limiter)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"key:" + keyID.String()}, limiter.keys)
+}
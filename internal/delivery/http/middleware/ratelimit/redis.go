@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// tokenBucketScript refills the bucket for the time passed since the last
+// request and takes one token if available. Everything happens in a single
+// script so concurrent requests of one client can't race each other.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = (1 - tokens) / rate
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000))
+
+return {allowed, tostring(tokens), tostring(retry_after)}
+`)
+
+// RedisLimiter is a token bucket limiter shared by all application instances
+type RedisLimiter struct {
+	client *redis.Client
+	rate   float64
+	burst  int
+}
+
+// NewRedisLimiter creates a limiter refilling rate tokens per second up to burst tokens
+func NewRedisLimiter(client *redis.Client, rate float64, burst int) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+		rate:   rate,
+		burst:  burst,
+	}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (*Result, error) {
+	const op = "ratelimit.RedisLimiter.Allow"
+
+	now := time.Now().UnixMilli()
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{keyPrefix + key}, l.rate, l.burst, now).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not run token bucket script: %w", op, err)
+	}
+
+	if len(values) != 3 {
+		return nil, fmt.Errorf("%s: unexpected token bucket script result: %v", op, values)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokens, err := parseFloat(values[1])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	retryAfter, err := parseFloat(values[2])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Result{
+		Allowed:    allowed == 1,
+		Limit:      l.burst,
+		Remaining:  int(math.Floor(tokens)),
+		RetryAfter: time.Duration(retryAfter * float64(time.Second)),
+		ResetAfter: time.Duration((float64(l.burst) - tokens) / l.rate * float64(time.Second)),
+	}, nil
+}
+
+func parseFloat(value interface{}) (float64, error) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected value type %T", value)
+	}
+	return strconv.ParseFloat(str, 64)
+}
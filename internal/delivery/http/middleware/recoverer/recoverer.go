@@ -0,0 +1,73 @@
+package recoverer
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"sync/atomic"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// panics counts the panics recovered by every recoverer
+var panics atomic.Int64
+
+// Panics returns the number of handler panics recovered since the start
+func Panics() int64 {
+	return panics.Load()
+}
+
+// New recovers from panics of the next handlers. The panic is logged with
+// its stack and the request it happened in, counted in Panics and answered
+// with a 500 in the format of the other errors, its request ID lets clients
+// report the incident. http.ErrAbortHandler is passed on, it aborts the
+// response on purpose.
+func New(log *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/recoverer"),
+		)
+
+		log.Info("recoverer middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				panics.Add(1)
+				log.Error("handler panicked",
+					slog.String("panic", fmt.Sprint(rvr)),
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					sl.RequestID(r.Context()),
+					slog.String("stack", string(debug.Stack())),
+				)
+
+				// the connection of an upgraded request isn't HTTP anymore
+				if r.Header.Get("Connection") == "Upgrade" {
+					return
+				}
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeInternal,
+					Message:   "internal error",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+			}()
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
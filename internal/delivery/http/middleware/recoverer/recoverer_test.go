@@ -0,0 +1,90 @@
+package recoverer
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(log *slog.Logger, next http.HandlerFunc, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/songs/42", nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+
+	middleware.RequestID(New(log)(next)).ServeHTTP(w, req)
+	return w
+}
+
+func TestRecoverer_Panic(t *testing.T) {
+	var logs bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&logs, nil))
+	before := Panics()
+
+	w := serve(log, func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}, http.Header{"X-Request-Id": {"req-42"}})
+
+	// Клиент получает ошибку в общем формате с ID запроса
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var resp dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeInternal, resp.Code)
+	assert.Equal(t, "req-42", resp.RequestID)
+
+	assert.Equal(t, before+1, Panics())
+
+	// В журнал попадают значение паники, запрос и стек
+	var entry map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+		require.NoError(t, json.Unmarshal(line, &entry))
+	}
+	assert.Equal(t, "handler panicked", entry["msg"])
+	assert.Equal(t, "boom", entry["panic"])
+	assert.Equal(t, "/songs/42", entry["path"])
+	assert.Contains(t, entry["stack"], "recoverer_test.go")
+}
+
+func TestRecoverer_NoPanic(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	before := Panics()
+
+	w := serve(log, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}, nil)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, before, Panics())
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	// http.ErrAbortHandler прерывает ответ намеренно и передаётся дальше серверу
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		serve(log, func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}, nil)
+	})
+}
+
+func TestRecoverer_Upgrade(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	// Соединение после Upgrade уже не HTTP, ответ не пишется
+	w := serve(log, func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}, http.Header{"Connection": {"Upgrade"}})
+
+	assert.Empty(t, w.Body.String())
+}
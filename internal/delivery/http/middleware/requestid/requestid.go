@@ -0,0 +1,29 @@
+package requestid
+
+import (
+	"net/http"
+	"songLibrary/pkg/requestid"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// New passes the request ID assigned by middleware.RequestID to the service
+// and repository layers through the request context and returns it to the
+// client in the X-Request-ID header. It must be used after
+// middleware.RequestID.
+func New() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			id := middleware.GetReqID(r.Context())
+			if id == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(requestid.Header, id)
+			next.ServeHTTP(w, r.WithContext(requestid.With(r.Context(), id)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,41 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/pkg/requestid"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(req *http.Request) (*httptest.ResponseRecorder, string) {
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestid.FromContext(r.Context())
+	})
+
+	w := httptest.NewRecorder()
+	middleware.RequestID(New()(next)).ServeHTTP(w, req)
+	return w, got
+}
+
+func TestRequestID_Generated(t *testing.T) {
+	w, got := serve(httptest.NewRequest(http.MethodGet, "/songs", nil))
+
+	assert.NotEmpty(t, got)
+	assert.Equal(t, got, w.Header().Get(requestid.Header))
+}
+
+func TestRequestID_FromClient(t *testing.T) {
+	// ID, присланный клиентом, передаётся дальше без изменений
+	req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+	req.Header.Set(requestid.Header, "gateway-42")
+
+	w, got := serve(req)
+
+	assert.Equal(t, "gateway-42", got)
+	assert.Equal(t, "gateway-42", w.Header().Get(requestid.Header))
+}
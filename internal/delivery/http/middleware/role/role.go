@@ -0,0 +1,125 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Resolver interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+// Rule sets the role needed for requests to paths matching Pattern, as
+// path.Match sees them, made with Method or any method if it is empty. A
+// rule without a role lets every request through, for routes authorized by
+// their own middleware.
+type Rule struct {
+	Method  string
+	Pattern string
+	Role    domain.Role
+}
+
+// New resolves the role of the request and rejects requests the role isn't
+// allowed to make. A role already set in the context, e.g. from an API key,
+// is kept; otherwise the role is read from the record of the user in the
+// X-User-ID header, users without one and anonymous requests get
+// defaultRole. The first rule matching the request decides the role it
+// needs, requests matching none need the viewer role to read and the editor
+// role to write.
+func New(log *slog.Logger, users Resolver, defaultRole domain.Role, rules ...Rule) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/role"),
+		)
+
+		log.Info("role middleware enabled", slog.String("default_role", string(defaultRole)))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			role, ok := domain.RoleFromContext(ctx)
+			if !ok {
+				role = defaultRole
+				if userID, ok := domain.UserIDFromContext(ctx); ok {
+					user, err := users.Get(ctx, userID)
+					switch {
+					case err == nil:
+						role = user.Role
+					case !errors.Is(err, domain.ErrUserNotFound):
+						log.Error("failed to resolve user role", slog.String("user_id", userID.String()), sl.Err(err))
+						render.Status(r, http.StatusInternalServerError)
+						render.JSON(w, r, dto.ErrorResponse{
+							Code:      dto.CodeInternal,
+							Message:   "internal error",
+							RequestID: middleware.GetReqID(ctx),
+						})
+						return
+					}
+				}
+				ctx = domain.WithRole(ctx, role)
+			}
+
+			if required, ok := requiredRole(r, rules); ok && !role.Allows(required) {
+				log.Warn("request is not allowed for role",
+					slog.String("role", string(role)),
+					slog.String("required_role", string(required)),
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+				)
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeForbidden,
+					Message:   "the " + string(required) + " role is required",
+					RequestID: middleware.GetReqID(ctx),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// FromContext returns the role of the request, if it was resolved
+func FromContext(ctx context.Context) (domain.Role, bool) {
+	return domain.RoleFromContext(ctx)
+}
+
+// requiredRole returns the role needed for the request, false if any request
+// is let through
+func requiredRole(r *http.Request, rules []Rule) (domain.Role, bool) {
+	urlPath := r.URL.Path
+	if len(urlPath) > 1 {
+		urlPath = strings.TrimSuffix(urlPath, "/")
+	}
+
+	for _, rule := range rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if ok, _ := path.Match(rule.Pattern, urlPath); !ok {
+			continue
+		}
+		return rule.Role, rule.Role != ""
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.RoleViewer, true
+	default:
+		return domain.RoleEditor, true
+	}
+}
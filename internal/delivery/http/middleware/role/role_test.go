@@ -0,0 +1,108 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type resolver map[uuid.UUID]domain.Role
+
+func (r resolver) Get(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	role, ok := r[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return &domain.User{ID: id, Role: role}, nil
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Get(context.Context, uuid.UUID) (*domain.User, error) {
+	return nil, errors.New("connection refused")
+}
+
+var rules = []Rule{
+	{Pattern: "/admin/*"},
+	{Method: http.MethodPatch, Pattern: "/songs", Role: domain.RoleAdmin},
+	{Pattern: "/songs/*/favorite", Role: domain.RoleViewer},
+}
+
+func serve(users Resolver, ctx context.Context, method, target string) (*httptest.ResponseRecorder, domain.Role) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	var role domain.Role
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, _ = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(method, target, nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	New(log, users, domain.RoleViewer, rules...)(next).ServeHTTP(w, req)
+	return w, role
+}
+
+func TestRole_Rules(t *testing.T) {
+	editorID, adminID, viewerID := uuid.New(), uuid.New(), uuid.New()
+	users := resolver{editorID: domain.RoleEditor, adminID: domain.RoleAdmin}
+
+	tests := []struct {
+		name   string
+		user   uuid.UUID
+		method string
+		target string
+		want   int
+	}{
+		{name: "зритель читает песни", user: viewerID, method: http.MethodGet, target: "/songs", want: http.StatusOK},
+		{name: "зритель не создаёт песни", user: viewerID, method: http.MethodPost, target: "/songs", want: http.StatusForbidden},
+		{name: "зритель добавляет в избранное", user: viewerID, method: http.MethodPost, target: "/songs/1/favorite", want: http.StatusOK},
+		{name: "редактор создаёт песни", user: editorID, method: http.MethodPost, target: "/songs/", want: http.StatusOK},
+		{name: "редактор удаляет песни", user: editorID, method: http.MethodDelete, target: "/songs/1", want: http.StatusOK},
+		{name: "массовое изменение только для администратора", user: editorID, method: http.MethodPatch, target: "/songs", want: http.StatusForbidden},
+		{name: "администратор меняет песни массово", user: adminID, method: http.MethodPatch, target: "/songs/", want: http.StatusOK},
+		{name: "админские маршруты проверяет свой middleware", user: viewerID, method: http.MethodDelete, target: "/admin/cache", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithUserID(context.Background(), tt.user)
+			w, _ := serve(users, ctx, tt.method, tt.target)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestRole_Anonymous(t *testing.T) {
+	// Анонимный запрос получает роль по умолчанию
+	w, role := serve(resolver{}, context.Background(), http.MethodGet, "/songs")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.RoleViewer, role)
+}
+
+func TestRole_KeepsRoleFromContext(t *testing.T) {
+	// Роль, заданная раньше (например, API-ключом), не перечитывается
+	ctx := domain.WithRole(context.Background(), domain.RoleEditor)
+	w, role := serve(failingResolver{}, ctx, http.MethodPost, "/songs")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.RoleEditor, role)
+}
+
+func TestRole_ResolverError(t *testing.T) {
+	ctx := domain.WithUserID(context.Background(), uuid.New())
+	w, _ := serve(failingResolver{}, ctx, http.MethodGet, "/songs")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
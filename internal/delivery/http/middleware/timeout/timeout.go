@@ -0,0 +1,84 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path"
+	"songLibrary/internal/dto"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+)
+
+// Route sets the deadline of requests to paths matching Pattern, as
+// path.Match sees it
+type Route struct {
+	Pattern string
+	Timeout time.Duration
+}
+
+// New sets a deadline on the request context, of the first of routes
+// matching the path or else of timeout. Handlers stop at the deadline
+// through the context, which also cancels their database queries, so the
+// response is never written concurrently; a handler that returned without
+// responding after the deadline gets 503. Requests to paths matching the
+// skipped patterns, as path.Match sees them, such as event streams, have no
+// deadline.
+func New(log *slog.Logger, timeout time.Duration, routes []Route, skip ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/timeout"),
+		)
+
+		log.Info("timeout middleware enabled", slog.Duration("timeout", timeout), slog.Int("routes", len(routes)))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if skipped(r.URL.Path, skip) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timeout := routeTimeout(r.URL.Path, routes, timeout)
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			r = r.WithContext(ctx)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			if ww.Status() == 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				log.Warn("request timed out", slog.String("path", r.URL.Path), slog.Duration("timeout", timeout))
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeRequestTimeout,
+					Message:   "request timed out",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// routeTimeout returns the timeout of the first route matching urlPath
+func routeTimeout(urlPath string, routes []Route, timeout time.Duration) time.Duration {
+	for _, route := range routes {
+		if ok, _ := path.Match(route.Pattern, urlPath); ok {
+			return route.Timeout
+		}
+	}
+	return timeout
+}
+
+func skipped(urlPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, urlPath); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package timeout
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(path string, next http.Handler) *httptest.ResponseRecorder {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+
+	routes := []Route{{Pattern: "/songs/*/export", Timeout: time.Second}}
+	New(log, 20*time.Millisecond, routes, "/songs/events", "/songs/*/audio")(next).ServeHTTP(w, req)
+	return w
+}
+
+// waitForDeadline ждёт отмены контекста запроса и возвращается, не отвечая
+var waitForDeadline = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(time.Second):
+		w.WriteHeader(http.StatusOK)
+	}
+})
+
+func TestTimeout_InTime(t *testing.T) {
+	w := serve("/songs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Deadline()
+		assert.True(t, ok)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestTimeout_Exceeded(t *testing.T) {
+	w := serve("/songs", waitForDeadline)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeRequestTimeout, resp.Code)
+}
+
+func TestTimeout_HandlerResponded(t *testing.T) {
+	// Ответ обработчика после дедлайна не перезаписывается
+	w := serve("/songs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestTimeout_SkippedPath(t *testing.T) {
+	for _, path := range []string{"/songs/events", "/songs/0b4a9d3c-6a5e-4f4b-9d0c-0b7e8f1a2c3d/audio"} {
+		w := serve(path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.False(t, ok)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+}
+
+func TestTimeout_Route(t *testing.T) {
+	// У маршрута свой дедлайн, остальные пути получают общий
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{path: "/songs/0b4a9d3c-6a5e-4f4b-9d0c-0b7e8f1a2c3d/export", want: time.Second},
+		{path: "/songs/0b4a9d3c-6a5e-4f4b-9d0c-0b7e8f1a2c3d", want: 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		w := serve(tt.path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deadline, ok := r.Context().Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(tt.want), deadline, 10*time.Millisecond, tt.path)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		assert.Equal(t, http.StatusNoContent, w.Code, tt.path)
+	}
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Header carries the ID of the user making the request. It is expected to be
+// set by the gateway in front of the service after authenticating the user.
+const Header = "X-User-ID"
+
+// New stores the user ID from the request header in the request context.
+// Anonymous requests are let through, a malformed ID is rejected.
+func New(log *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/user"),
+		)
+
+		log.Info("user middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get(Header)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id, err := uuid.Parse(header)
+			if err != nil {
+				log.Warn("invalid user id", slog.String("user_id", header))
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, dto.ErrorResponse{
+					Code:      dto.CodeUnauthorized,
+					Message:   "invalid user id",
+					RequestID: middleware.GetReqID(r.Context()),
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// WithID returns a copy of ctx carrying the user ID. The ID is shared with
+// the domain, so changes made by the request are attributed to the user.
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return domain.WithUserID(ctx, id)
+}
+
+// FromContext returns the ID of the user making the request, if any
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	return domain.UserIDFromContext(ctx)
+}
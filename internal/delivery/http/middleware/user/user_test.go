@@ -0,0 +1,59 @@
+package user
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(header string) (*httptest.ResponseRecorder, uuid.UUID, bool) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	var (
+		id uuid.UUID
+		ok bool
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/favorites", nil)
+	if header != "" {
+		req.Header.Set(Header, header)
+	}
+	w := httptest.NewRecorder()
+
+	New(log)(next).ServeHTTP(w, req)
+	return w, id, ok
+}
+
+func TestUser_Identified(t *testing.T) {
+	userID := uuid.New()
+
+	w, id, ok := serve(userID.String())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, ok)
+	assert.Equal(t, userID, id)
+}
+
+func TestUser_Anonymous(t *testing.T) {
+	// Анонимные запросы пропускаются без пользователя в контексте
+	w, _, ok := serve("")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, ok)
+}
+
+func TestUser_InvalidID(t *testing.T) {
+	w, _, _ := serve("not-a-uuid")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,15 +1,20 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: songLibrary/internal/delivery/http (interfaces: Service)
+// Source: songLibrary/internal/delivery/http (interfaces: Service,AlbumService,ArtistService,FavoriteService,PlayService,WebhookService,CacheService,AuditService,RevisionService,TagService,CoverService,AudioService,SuggestService,SearchService,SimilarService,RecentService,CalendarService,RandomService,StatsService,GroupService,BackupService,LibraryService,UserService,APIKeyService,NormalizeService,ContentScanService,PlaylistService,ShareService,RatingService)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	domain "songLibrary/internal/domain"
+	exporter "songLibrary/internal/exporter"
+	textdiff "songLibrary/pkg/textdiff"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockService is a mock of Service interface.
@@ -36,11 +41,12 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockService) Add(arg0 context.Context, arg1 *domain.SongInfo) error {
+func (m *MockService) Add(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Add", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Add indicates an expected call of Add.
@@ -49,6 +55,36 @@ func (mr *MockServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockService)(nil).Add), arg0, arg1)
 }
 
+// Archive mocks base method.
+func (m *MockService) Archive(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockServiceMockRecorder) Archive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockService)(nil).Archive), arg0, arg1)
+}
+
+// BulkUpdate mocks base method.
+func (m *MockService) BulkUpdate(arg0 context.Context, arg1 *domain.SongFilter, arg2 *domain.SongChanges) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdate indicates an expected call of BulkUpdate.
+func (mr *MockServiceMockRecorder) BulkUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockService)(nil).BulkUpdate), arg0, arg1, arg2)
+}
+
 // Delete mocks base method.
 func (m *MockService) Delete(arg0 context.Context, arg1 *domain.SongInfo) error {
 	m.ctrl.T.Helper()
@@ -63,6 +99,21 @@ func (mr *MockServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), arg0, arg1)
 }
 
+// Export mocks base method.
+func (m *MockService) Export(arg0 context.Context, arg1 *domain.SongInfo, arg2 exporter.Format) (*exporter.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*exporter.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockService)(nil).Export), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockService) Get(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
 	m.ctrl.T.Helper()
@@ -78,8 +129,24 @@ func (mr *MockServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), arg0, arg1)
 }
 
+// GetAllAfter mocks base method.
+func (m *MockService) GetAllAfter(arg0 context.Context, arg1 *domain.SongFilter, arg2 *domain.SongCursor, arg3 int) ([]*domain.Song, *domain.SongCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(*domain.SongCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllAfter indicates an expected call of GetAllAfter.
+func (mr *MockServiceMockRecorder) GetAllAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAfter", reflect.TypeOf((*MockService)(nil).GetAllAfter), arg0, arg1, arg2, arg3)
+}
+
 // GetAllWithFilter mocks base method.
-func (m *MockService) GetAllWithFilter(arg0 context.Context, arg1 *domain.Song, arg2, arg3 int) ([]*domain.Song, error) {
+func (m *MockService) GetAllWithFilter(arg0 context.Context, arg1 *domain.SongFilter, arg2, arg3 int) ([]*domain.Song, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllWithFilter", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.Song)
@@ -93,19 +160,156 @@ func (mr *MockServiceMockRecorder) GetAllWithFilter(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWithFilter", reflect.TypeOf((*MockService)(nil).GetAllWithFilter), arg0, arg1, arg2, arg3)
 }
 
+// GetByIDs mocks base method.
+func (m *MockService) GetByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.Song, []uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].([]uuid.UUID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockServiceMockRecorder) GetByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockService)(nil).GetByIDs), arg0, arg1)
+}
+
+// GetByNameAndGroup mocks base method.
+func (m *MockService) GetByNameAndGroup(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNameAndGroup", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNameAndGroup indicates an expected call of GetByNameAndGroup.
+func (mr *MockServiceMockRecorder) GetByNameAndGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNameAndGroup", reflect.TypeOf((*MockService)(nil).GetByNameAndGroup), arg0, arg1)
+}
+
+// GetDuplicates mocks base method.
+func (m *MockService) GetDuplicates(arg0 context.Context, arg1 float64, arg2 int) ([]*domain.DuplicateSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.DuplicateSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockServiceMockRecorder) GetDuplicates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockService)(nil).GetDuplicates), arg0, arg1, arg2)
+}
+
 // GetPaginatedText mocks base method.
-func (m *MockService) GetPaginatedText(arg0 context.Context, arg1 *domain.SongInfo) ([]string, error) {
+func (m *MockService) GetPaginatedText(arg0 context.Context, arg1 *domain.SongInfo, arg2 domain.SplitStrategy) (*domain.Lyrics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaginatedText", arg0, arg1)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetPaginatedText", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Lyrics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPaginatedText indicates an expected call of GetPaginatedText.
-func (mr *MockServiceMockRecorder) GetPaginatedText(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetPaginatedText(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedText", reflect.TypeOf((*MockService)(nil).GetPaginatedText), arg0, arg1, arg2)
+}
+
+// GetTextPage mocks base method.
+func (m *MockService) GetTextPage(arg0 context.Context, arg1 *domain.SongInfo, arg2 domain.SplitStrategy, arg3, arg4 int) (*domain.LyricsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTextPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.LyricsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTextPage indicates an expected call of GetTextPage.
+func (mr *MockServiceMockRecorder) GetTextPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextPage", reflect.TypeOf((*MockService)(nil).GetTextPage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Import mocks base method.
+func (m *MockService) Import(arg0 context.Context, arg1 io.Reader, arg2 bool) (*domain.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockServiceMockRecorder) Import(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockService)(nil).Import), arg0, arg1, arg2)
+}
+
+// LastModified mocks base method.
+func (m *MockService) LastModified(arg0 context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastModified", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastModified indicates an expected call of LastModified.
+func (mr *MockServiceMockRecorder) LastModified(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastModified", reflect.TypeOf((*MockService)(nil).LastModified), arg0)
+}
+
+// Refresh mocks base method.
+func (m *MockService) Refresh(arg0 context.Context, arg1 *domain.SongInfo, arg2 bool) (*domain.Song, domain.SongFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(domain.SongFields)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockServiceMockRecorder) Refresh(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockService)(nil).Refresh), arg0, arg1, arg2)
+}
+
+// SearchText mocks base method.
+func (m *MockService) SearchText(arg0 context.Context, arg1 *domain.SongInfo, arg2 string, arg3 domain.SplitStrategy) ([]*domain.VerseMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchText", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.VerseMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchText indicates an expected call of SearchText.
+func (mr *MockServiceMockRecorder) SearchText(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchText", reflect.TypeOf((*MockService)(nil).SearchText), arg0, arg1, arg2, arg3)
+}
+
+// Unarchive mocks base method.
+func (m *MockService) Unarchive(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockServiceMockRecorder) Unarchive(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedText", reflect.TypeOf((*MockService)(nil).GetPaginatedText), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockService)(nil).Unarchive), arg0, arg1)
 }
 
 // Update mocks base method.
@@ -121,3 +325,1768 @@ func (mr *MockServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), arg0, arg1, arg2)
 }
+
+// MockAlbumService is a mock of AlbumService interface.
+type MockAlbumService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumServiceMockRecorder
+}
+
+// MockAlbumServiceMockRecorder is the mock recorder for MockAlbumService.
+type MockAlbumServiceMockRecorder struct {
+	mock *MockAlbumService
+}
+
+// NewMockAlbumService creates a new mock instance.
+func NewMockAlbumService(ctrl *gomock.Controller) *MockAlbumService {
+	mock := &MockAlbumService{ctrl: ctrl}
+	mock.recorder = &MockAlbumServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumService) EXPECT() *MockAlbumServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAlbumService) Add(arg0 context.Context, arg1 *domain.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAlbumServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAlbumService)(nil).Add), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAlbumService) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAlbumServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAlbumService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAlbumService) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAlbumServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAlbumService)(nil).Get), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockAlbumService) GetAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAlbumServiceMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAlbumService)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// GetSongs mocks base method.
+func (m *MockAlbumService) GetSongs(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongs indicates an expected call of GetSongs.
+func (mr *MockAlbumServiceMockRecorder) GetSongs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongs", reflect.TypeOf((*MockAlbumService)(nil).GetSongs), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockAlbumService) Update(arg0 context.Context, arg1 *domain.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAlbumServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlbumService)(nil).Update), arg0, arg1)
+}
+
+// MockArtistService is a mock of ArtistService interface.
+type MockArtistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistServiceMockRecorder
+}
+
+// MockArtistServiceMockRecorder is the mock recorder for MockArtistService.
+type MockArtistServiceMockRecorder struct {
+	mock *MockArtistService
+}
+
+// NewMockArtistService creates a new mock instance.
+func NewMockArtistService(ctrl *gomock.Controller) *MockArtistService {
+	mock := &MockArtistService{ctrl: ctrl}
+	mock.recorder = &MockArtistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistService) EXPECT() *MockArtistServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockArtistService) Add(arg0 context.Context, arg1 *domain.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockArtistServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockArtistService)(nil).Add), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockArtistService) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArtistServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArtistService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockArtistService) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArtistServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArtistService)(nil).Get), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockArtistService) GetAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockArtistServiceMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockArtistService)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// GetSongs mocks base method.
+func (m *MockArtistService) GetSongs(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongs indicates an expected call of GetSongs.
+func (mr *MockArtistServiceMockRecorder) GetSongs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongs", reflect.TypeOf((*MockArtistService)(nil).GetSongs), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockArtistService) Update(arg0 context.Context, arg1 *domain.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArtistServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArtistService)(nil).Update), arg0, arg1)
+}
+
+// MockFavoriteService is a mock of FavoriteService interface.
+type MockFavoriteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteServiceMockRecorder
+}
+
+// MockFavoriteServiceMockRecorder is the mock recorder for MockFavoriteService.
+type MockFavoriteServiceMockRecorder struct {
+	mock *MockFavoriteService
+}
+
+// NewMockFavoriteService creates a new mock instance.
+func NewMockFavoriteService(ctrl *gomock.Controller) *MockFavoriteService {
+	mock := &MockFavoriteService{ctrl: ctrl}
+	mock.recorder = &MockFavoriteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteService) EXPECT() *MockFavoriteServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockFavoriteService) Add(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockFavoriteServiceMockRecorder) Add(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockFavoriteService)(nil).Add), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockFavoriteService) GetAll(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFavoriteServiceMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFavoriteService)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// Remove mocks base method.
+func (m *MockFavoriteService) Remove(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFavoriteServiceMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFavoriteService)(nil).Remove), arg0, arg1, arg2)
+}
+
+// MockPlayService is a mock of PlayService interface.
+type MockPlayService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayServiceMockRecorder
+}
+
+// MockPlayServiceMockRecorder is the mock recorder for MockPlayService.
+type MockPlayServiceMockRecorder struct {
+	mock *MockPlayService
+}
+
+// NewMockPlayService creates a new mock instance.
+func NewMockPlayService(ctrl *gomock.Controller) *MockPlayService {
+	mock := &MockPlayService{ctrl: ctrl}
+	mock.recorder = &MockPlayServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayService) EXPECT() *MockPlayServiceMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockPlayService) Record(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPlayServiceMockRecorder) Record(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPlayService)(nil).Record), arg0, arg1)
+}
+
+// Trending mocks base method.
+func (m *MockPlayService) Trending(arg0 context.Context, arg1 time.Duration, arg2 int) ([]*domain.TrendingSong, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trending", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.TrendingSong)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trending indicates an expected call of Trending.
+func (mr *MockPlayServiceMockRecorder) Trending(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trending", reflect.TypeOf((*MockPlayService)(nil).Trending), arg0, arg1, arg2)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockWebhookService) Add(arg0 context.Context, arg1 *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockWebhookServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWebhookService)(nil).Add), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookService) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockWebhookService) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhookServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhookService)(nil).Get), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockWebhookService) GetAll(arg0 context.Context) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookServiceMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhookService)(nil).GetAll), arg0)
+}
+
+// Update mocks base method.
+func (m *MockWebhookService) Update(arg0 context.Context, arg1 *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookService)(nil).Update), arg0, arg1)
+}
+
+// MockCacheService is a mock of CacheService interface.
+type MockCacheService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheServiceMockRecorder
+}
+
+// MockCacheServiceMockRecorder is the mock recorder for MockCacheService.
+type MockCacheServiceMockRecorder struct {
+	mock *MockCacheService
+}
+
+// NewMockCacheService creates a new mock instance.
+func NewMockCacheService(ctrl *gomock.Controller) *MockCacheService {
+	mock := &MockCacheService{ctrl: ctrl}
+	mock.recorder = &MockCacheServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheService) EXPECT() *MockCacheServiceMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockCacheService) Flush(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockCacheServiceMockRecorder) Flush(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockCacheService)(nil).Flush), arg0)
+}
+
+// Invalidate mocks base method.
+func (m *MockCacheService) Invalidate(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCacheServiceMockRecorder) Invalidate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCacheService)(nil).Invalidate), arg0, arg1)
+}
+
+// StartRebuild mocks base method.
+func (m *MockCacheService) StartRebuild(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRebuild", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRebuild indicates an expected call of StartRebuild.
+func (mr *MockCacheServiceMockRecorder) StartRebuild(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRebuild", reflect.TypeOf((*MockCacheService)(nil).StartRebuild), arg0)
+}
+
+// Stats mocks base method.
+func (m *MockCacheService) Stats(arg0 context.Context) (*domain.CacheStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", arg0)
+	ret0, _ := ret[0].(*domain.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheServiceMockRecorder) Stats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCacheService)(nil).Stats), arg0)
+}
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// History mocks base method.
+func (m *MockAuditService) History(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockAuditServiceMockRecorder) History(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockAuditService)(nil).History), arg0, arg1, arg2, arg3)
+}
+
+// MockRevisionService is a mock of RevisionService interface.
+type MockRevisionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionServiceMockRecorder
+}
+
+// MockRevisionServiceMockRecorder is the mock recorder for MockRevisionService.
+type MockRevisionServiceMockRecorder struct {
+	mock *MockRevisionService
+}
+
+// NewMockRevisionService creates a new mock instance.
+func NewMockRevisionService(ctrl *gomock.Controller) *MockRevisionService {
+	mock := &MockRevisionService{ctrl: ctrl}
+	mock.recorder = &MockRevisionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionService) EXPECT() *MockRevisionServiceMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockRevisionService) Diff(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]textdiff.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]textdiff.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockRevisionServiceMockRecorder) Diff(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockRevisionService)(nil).Diff), arg0, arg1, arg2, arg3)
+}
+
+// GetAll mocks base method.
+func (m *MockRevisionService) GetAll(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.SongRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.SongRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRevisionServiceMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRevisionService)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// Restore mocks base method.
+func (m *MockRevisionService) Restore(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRevisionServiceMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRevisionService)(nil).Restore), arg0, arg1, arg2)
+}
+
+// MockTagService is a mock of TagService interface.
+type MockTagService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagServiceMockRecorder
+}
+
+// MockTagServiceMockRecorder is the mock recorder for MockTagService.
+type MockTagServiceMockRecorder struct {
+	mock *MockTagService
+}
+
+// NewMockTagService creates a new mock instance.
+func NewMockTagService(ctrl *gomock.Controller) *MockTagService {
+	mock := &MockTagService{ctrl: ctrl}
+	mock.recorder = &MockTagServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagService) EXPECT() *MockTagServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTagService) Add(arg0 context.Context, arg1 uuid.UUID, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTagServiceMockRecorder) Add(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTagService)(nil).Add), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockTagService) GetAll(arg0 context.Context, arg1, arg2 int) ([]*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagServiceMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTagService)(nil).GetAll), arg0, arg1, arg2)
+}
+
+// GetSongTags mocks base method.
+func (m *MockTagService) GetSongTags(arg0 context.Context, arg1 uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongTags indicates an expected call of GetSongTags.
+func (mr *MockTagServiceMockRecorder) GetSongTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongTags", reflect.TypeOf((*MockTagService)(nil).GetSongTags), arg0, arg1)
+}
+
+// Remove mocks base method.
+func (m *MockTagService) Remove(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTagServiceMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTagService)(nil).Remove), arg0, arg1, arg2)
+}
+
+// MockCoverService is a mock of CoverService interface.
+type MockCoverService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverServiceMockRecorder
+}
+
+// MockCoverServiceMockRecorder is the mock recorder for MockCoverService.
+type MockCoverServiceMockRecorder struct {
+	mock *MockCoverService
+}
+
+// NewMockCoverService creates a new mock instance.
+func NewMockCoverService(ctrl *gomock.Controller) *MockCoverService {
+	mock := &MockCoverService{ctrl: ctrl}
+	mock.recorder = &MockCoverServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverService) EXPECT() *MockCoverServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCoverService) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCoverServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCoverService)(nil).Get), arg0, arg1)
+}
+
+// Upload mocks base method.
+func (m *MockCoverService) Upload(arg0 context.Context, arg1 uuid.UUID, arg2 io.Reader) (*domain.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockCoverServiceMockRecorder) Upload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockCoverService)(nil).Upload), arg0, arg1, arg2)
+}
+
+// MockAudioService is a mock of AudioService interface.
+type MockAudioService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAudioServiceMockRecorder
+}
+
+// MockAudioServiceMockRecorder is the mock recorder for MockAudioService.
+type MockAudioServiceMockRecorder struct {
+	mock *MockAudioService
+}
+
+// NewMockAudioService creates a new mock instance.
+func NewMockAudioService(ctrl *gomock.Controller) *MockAudioService {
+	mock := &MockAudioService{ctrl: ctrl}
+	mock.recorder = &MockAudioServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudioService) EXPECT() *MockAudioServiceMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockAudioService) Open(arg0 context.Context, arg1 uuid.UUID) (*domain.Audio, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Audio)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAudioServiceMockRecorder) Open(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAudioService)(nil).Open), arg0, arg1)
+}
+
+// Upload mocks base method.
+func (m *MockAudioService) Upload(arg0 context.Context, arg1 uuid.UUID, arg2 io.Reader) (*domain.Audio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Audio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAudioServiceMockRecorder) Upload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAudioService)(nil).Upload), arg0, arg1, arg2)
+}
+
+// MockSuggestService is a mock of SuggestService interface.
+type MockSuggestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestServiceMockRecorder
+}
+
+// MockSuggestServiceMockRecorder is the mock recorder for MockSuggestService.
+type MockSuggestServiceMockRecorder struct {
+	mock *MockSuggestService
+}
+
+// NewMockSuggestService creates a new mock instance.
+func NewMockSuggestService(ctrl *gomock.Controller) *MockSuggestService {
+	mock := &MockSuggestService{ctrl: ctrl}
+	mock.recorder = &MockSuggestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuggestService) EXPECT() *MockSuggestServiceMockRecorder {
+	return m.recorder
+}
+
+// Suggest mocks base method.
+func (m *MockSuggestService) Suggest(arg0 context.Context, arg1 string, arg2 int) ([]*domain.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSuggestServiceMockRecorder) Suggest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSuggestService)(nil).Suggest), arg0, arg1, arg2)
+}
+
+// MockSearchService is a mock of SearchService interface.
+type MockSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchServiceMockRecorder
+}
+
+// MockSearchServiceMockRecorder is the mock recorder for MockSearchService.
+type MockSearchServiceMockRecorder struct {
+	mock *MockSearchService
+}
+
+// NewMockSearchService creates a new mock instance.
+func NewMockSearchService(ctrl *gomock.Controller) *MockSearchService {
+	mock := &MockSearchService{ctrl: ctrl}
+	mock.recorder = &MockSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchService) EXPECT() *MockSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchService) Search(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4, arg5 int) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchServiceMockRecorder) Search(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchService)(nil).Search), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SemanticSearch mocks base method.
+func (m *MockSearchService) SemanticSearch(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4 int) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SemanticSearch", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SemanticSearch indicates an expected call of SemanticSearch.
+func (mr *MockSearchServiceMockRecorder) SemanticSearch(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SemanticSearch", reflect.TypeOf((*MockSearchService)(nil).SemanticSearch), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockSimilarService is a mock of SimilarService interface.
+type MockSimilarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSimilarServiceMockRecorder
+}
+
+// MockSimilarServiceMockRecorder is the mock recorder for MockSimilarService.
+type MockSimilarServiceMockRecorder struct {
+	mock *MockSimilarService
+}
+
+// NewMockSimilarService creates a new mock instance.
+func NewMockSimilarService(ctrl *gomock.Controller) *MockSimilarService {
+	mock := &MockSimilarService{ctrl: ctrl}
+	mock.recorder = &MockSimilarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSimilarService) EXPECT() *MockSimilarServiceMockRecorder {
+	return m.recorder
+}
+
+// Similar mocks base method.
+func (m *MockSimilarService) Similar(arg0 context.Context, arg1 *domain.SongInfo, arg2 bool, arg3 int) ([]*domain.SimilarSong, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.SimilarSong)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSimilarServiceMockRecorder) Similar(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSimilarService)(nil).Similar), arg0, arg1, arg2, arg3)
+}
+
+// MockRecentService is a mock of RecentService interface.
+type MockRecentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecentServiceMockRecorder
+}
+
+// MockRecentServiceMockRecorder is the mock recorder for MockRecentService.
+type MockRecentServiceMockRecorder struct {
+	mock *MockRecentService
+}
+
+// NewMockRecentService creates a new mock instance.
+func NewMockRecentService(ctrl *gomock.Controller) *MockRecentService {
+	mock := &MockRecentService{ctrl: ctrl}
+	mock.recorder = &MockRecentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecentService) EXPECT() *MockRecentServiceMockRecorder {
+	return m.recorder
+}
+
+// Recent mocks base method.
+func (m *MockRecentService) Recent(arg0 context.Context, arg1 domain.SongSort, arg2 bool, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockRecentServiceMockRecorder) Recent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockRecentService)(nil).Recent), arg0, arg1, arg2, arg3)
+}
+
+// MockCalendarService is a mock of CalendarService interface.
+type MockCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServiceMockRecorder
+}
+
+// MockCalendarServiceMockRecorder is the mock recorder for MockCalendarService.
+type MockCalendarServiceMockRecorder struct {
+	mock *MockCalendarService
+}
+
+// NewMockCalendarService creates a new mock instance.
+func NewMockCalendarService(ctrl *gomock.Controller) *MockCalendarService {
+	mock := &MockCalendarService{ctrl: ctrl}
+	mock.recorder = &MockCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarService) EXPECT() *MockCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// Calendar mocks base method.
+func (m *MockCalendarService) Calendar(arg0 context.Context, arg1 int, arg2 bool) ([]*domain.CalendarDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.CalendarDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calendar indicates an expected call of Calendar.
+func (mr *MockCalendarServiceMockRecorder) Calendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calendar", reflect.TypeOf((*MockCalendarService)(nil).Calendar), arg0, arg1, arg2)
+}
+
+// MockRandomService is a mock of RandomService interface.
+type MockRandomService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRandomServiceMockRecorder
+}
+
+// MockRandomServiceMockRecorder is the mock recorder for MockRandomService.
+type MockRandomServiceMockRecorder struct {
+	mock *MockRandomService
+}
+
+// NewMockRandomService creates a new mock instance.
+func NewMockRandomService(ctrl *gomock.Controller) *MockRandomService {
+	mock := &MockRandomService{ctrl: ctrl}
+	mock.recorder = &MockRandomServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRandomService) EXPECT() *MockRandomServiceMockRecorder {
+	return m.recorder
+}
+
+// OfTheDay mocks base method.
+func (m *MockRandomService) OfTheDay(arg0 context.Context) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfTheDay", arg0)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OfTheDay indicates an expected call of OfTheDay.
+func (mr *MockRandomServiceMockRecorder) OfTheDay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfTheDay", reflect.TypeOf((*MockRandomService)(nil).OfTheDay), arg0)
+}
+
+// Random mocks base method.
+func (m *MockRandomService) Random(arg0 context.Context, arg1 *domain.SongFilter) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Random", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Random indicates an expected call of Random.
+func (mr *MockRandomServiceMockRecorder) Random(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Random", reflect.TypeOf((*MockRandomService)(nil).Random), arg0, arg1)
+}
+
+// MockStatsService is a mock of StatsService interface.
+type MockStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsServiceMockRecorder
+}
+
+// MockStatsServiceMockRecorder is the mock recorder for MockStatsService.
+type MockStatsServiceMockRecorder struct {
+	mock *MockStatsService
+}
+
+// NewMockStatsService creates a new mock instance.
+func NewMockStatsService(ctrl *gomock.Controller) *MockStatsService {
+	mock := &MockStatsService{ctrl: ctrl}
+	mock.recorder = &MockStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsService) EXPECT() *MockStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStatsService) Get(arg0 context.Context) (*domain.LibraryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*domain.LibraryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStatsServiceMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStatsService)(nil).Get), arg0)
+}
+
+// MockGroupService is a mock of GroupService interface.
+type MockGroupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupServiceMockRecorder
+}
+
+// MockGroupServiceMockRecorder is the mock recorder for MockGroupService.
+type MockGroupServiceMockRecorder struct {
+	mock *MockGroupService
+}
+
+// NewMockGroupService creates a new mock instance.
+func NewMockGroupService(ctrl *gomock.Controller) *MockGroupService {
+	mock := &MockGroupService{ctrl: ctrl}
+	mock.recorder = &MockGroupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupService) EXPECT() *MockGroupServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockGroupService) GetAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGroupServiceMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGroupService)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// MockBackupService is a mock of BackupService interface.
+type MockBackupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupServiceMockRecorder
+}
+
+// MockBackupServiceMockRecorder is the mock recorder for MockBackupService.
+type MockBackupServiceMockRecorder struct {
+	mock *MockBackupService
+}
+
+// NewMockBackupService creates a new mock instance.
+func NewMockBackupService(ctrl *gomock.Controller) *MockBackupService {
+	mock := &MockBackupService{ctrl: ctrl}
+	mock.recorder = &MockBackupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupService) EXPECT() *MockBackupServiceMockRecorder {
+	return m.recorder
+}
+
+// Restore mocks base method.
+func (m *MockBackupService) Restore(arg0 context.Context, arg1 io.Reader, arg2 bool) (*domain.BackupReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.BackupReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupServiceMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackupService)(nil).Restore), arg0, arg1, arg2)
+}
+
+// Write mocks base method.
+func (m *MockBackupService) Write(arg0 context.Context, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockBackupServiceMockRecorder) Write(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockBackupService)(nil).Write), arg0, arg1)
+}
+
+// MockLibraryService is a mock of LibraryService interface.
+type MockLibraryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLibraryServiceMockRecorder
+}
+
+// MockLibraryServiceMockRecorder is the mock recorder for MockLibraryService.
+type MockLibraryServiceMockRecorder struct {
+	mock *MockLibraryService
+}
+
+// NewMockLibraryService creates a new mock instance.
+func NewMockLibraryService(ctrl *gomock.Controller) *MockLibraryService {
+	mock := &MockLibraryService{ctrl: ctrl}
+	mock.recorder = &MockLibraryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLibraryService) EXPECT() *MockLibraryServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockLibraryService) Add(arg0 context.Context, arg1 *domain.Library) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockLibraryServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockLibraryService)(nil).Add), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockLibraryService) GetAll(arg0 context.Context) ([]*domain.Library, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*domain.Library)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLibraryServiceMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLibraryService)(nil).GetAll), arg0)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserService) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserService)(nil).Delete), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockUserService) GetAll(arg0 context.Context) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUserServiceMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserService)(nil).GetAll), arg0)
+}
+
+// SetRole mocks base method.
+func (m *MockUserService) SetRole(arg0 context.Context, arg1 *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserServiceMockRecorder) SetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserService)(nil).SetRole), arg0, arg1)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyService) Create(arg0 context.Context, arg1 *domain.APIKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyService)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAPIKeyService) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeyServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeyService)(nil).Get), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKeyService) GetAll(arg0 context.Context) ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyServiceMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKeyService)(nil).GetAll), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyService) Revoke(arg0 context.Context, arg1 uuid.UUID) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyServiceMockRecorder) Revoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyService)(nil).Revoke), arg0, arg1)
+}
+
+// MockNormalizeService is a mock of NormalizeService interface.
+type MockNormalizeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNormalizeServiceMockRecorder
+}
+
+// MockNormalizeServiceMockRecorder is the mock recorder for MockNormalizeService.
+type MockNormalizeServiceMockRecorder struct {
+	mock *MockNormalizeService
+}
+
+// NewMockNormalizeService creates a new mock instance.
+func NewMockNormalizeService(ctrl *gomock.Controller) *MockNormalizeService {
+	mock := &MockNormalizeService{ctrl: ctrl}
+	mock.recorder = &MockNormalizeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNormalizeService) EXPECT() *MockNormalizeServiceMockRecorder {
+	return m.recorder
+}
+
+// Normalize mocks base method.
+func (m *MockNormalizeService) Normalize(arg0 context.Context, arg1 bool) (*domain.NormalizeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", arg0, arg1)
+	ret0, _ := ret[0].(*domain.NormalizeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockNormalizeServiceMockRecorder) Normalize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockNormalizeService)(nil).Normalize), arg0, arg1)
+}
+
+// MockContentScanService is a mock of ContentScanService interface.
+type MockContentScanService struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentScanServiceMockRecorder
+}
+
+// MockContentScanServiceMockRecorder is the mock recorder for MockContentScanService.
+type MockContentScanServiceMockRecorder struct {
+	mock *MockContentScanService
+}
+
+// NewMockContentScanService creates a new mock instance.
+func NewMockContentScanService(ctrl *gomock.Controller) *MockContentScanService {
+	mock := &MockContentScanService{ctrl: ctrl}
+	mock.recorder = &MockContentScanServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentScanService) EXPECT() *MockContentScanServiceMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockContentScanService) Scan(arg0 context.Context, arg1 bool) (*domain.ContentScanReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ContentScanReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockContentScanServiceMockRecorder) Scan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockContentScanService)(nil).Scan), arg0, arg1)
+}
+
+// MockPlaylistService is a mock of PlaylistService interface.
+type MockPlaylistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistServiceMockRecorder
+}
+
+// MockPlaylistServiceMockRecorder is the mock recorder for MockPlaylistService.
+type MockPlaylistServiceMockRecorder struct {
+	mock *MockPlaylistService
+}
+
+// NewMockPlaylistService creates a new mock instance.
+func NewMockPlaylistService(ctrl *gomock.Controller) *MockPlaylistService {
+	mock := &MockPlaylistService{ctrl: ctrl}
+	mock.recorder = &MockPlaylistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistService) EXPECT() *MockPlaylistServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockPlaylistService) Add(arg0 context.Context, arg1 *domain.SmartPlaylist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockPlaylistServiceMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockPlaylistService)(nil).Add), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPlaylistService) Delete(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaylistServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaylistService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockPlaylistService) Get(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.SmartPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.SmartPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaylistServiceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaylistService)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockPlaylistService) GetAll(arg0 context.Context, arg1 uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.SmartPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPlaylistServiceMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPlaylistService)(nil).GetAll), arg0, arg1)
+}
+
+// Songs mocks base method.
+func (m *MockPlaylistService) Songs(arg0 context.Context, arg1, arg2 uuid.UUID, arg3, arg4 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Songs", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Songs indicates an expected call of Songs.
+func (mr *MockPlaylistServiceMockRecorder) Songs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Songs", reflect.TypeOf((*MockPlaylistService)(nil).Songs), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Update mocks base method.
+func (m *MockPlaylistService) Update(arg0 context.Context, arg1 *domain.SmartPlaylist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaylistServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaylistService)(nil).Update), arg0, arg1)
+}
+
+// MockShareService is a mock of ShareService interface.
+type MockShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareServiceMockRecorder
+}
+
+// MockShareServiceMockRecorder is the mock recorder for MockShareService.
+type MockShareServiceMockRecorder struct {
+	mock *MockShareService
+}
+
+// NewMockShareService creates a new mock instance.
+func NewMockShareService(ctrl *gomock.Controller) *MockShareService {
+	mock := &MockShareService{ctrl: ctrl}
+	mock.recorder = &MockShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareService) EXPECT() *MockShareServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockShareService) GetAll(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockShareServiceMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockShareService)(nil).GetAll), arg0, arg1)
+}
+
+// Open mocks base method.
+func (m *MockShareService) Open(arg0 context.Context, arg1 string, arg2, arg3 int) (*domain.SharedContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.SharedContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockShareServiceMockRecorder) Open(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockShareService)(nil).Open), arg0, arg1, arg2, arg3)
+}
+
+// Revoke mocks base method.
+func (m *MockShareService) Revoke(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareServiceMockRecorder) Revoke(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareService)(nil).Revoke), arg0, arg1, arg2)
+}
+
+// SharePlaylist mocks base method.
+func (m *MockShareService) SharePlaylist(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Duration) (*domain.Share, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharePlaylist", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SharePlaylist indicates an expected call of SharePlaylist.
+func (mr *MockShareServiceMockRecorder) SharePlaylist(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharePlaylist", reflect.TypeOf((*MockShareService)(nil).SharePlaylist), arg0, arg1, arg2, arg3)
+}
+
+// ShareSong mocks base method.
+func (m *MockShareService) ShareSong(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Duration) (*domain.Share, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSong", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ShareSong indicates an expected call of ShareSong.
+func (mr *MockShareServiceMockRecorder) ShareSong(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSong", reflect.TypeOf((*MockShareService)(nil).ShareSong), arg0, arg1, arg2, arg3)
+}
+
+// Token mocks base method.
+func (m *MockShareService) Token(arg0 uuid.UUID) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockShareServiceMockRecorder) Token(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockShareService)(nil).Token), arg0)
+}
+
+// MockRatingService is a mock of RatingService interface.
+type MockRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceMockRecorder
+}
+
+// MockRatingServiceMockRecorder is the mock recorder for MockRatingService.
+type MockRatingServiceMockRecorder struct {
+	mock *MockRatingService
+}
+
+// NewMockRatingService creates a new mock instance.
+func NewMockRatingService(ctrl *gomock.Controller) *MockRatingService {
+	mock := &MockRatingService{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingService) EXPECT() *MockRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRatingService) Get(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRatingServiceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRatingService)(nil).Get), arg0, arg1, arg2)
+}
+
+// Rate mocks base method.
+func (m *MockRatingService) Rate(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int) (*domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockRatingServiceMockRecorder) Rate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockRatingService)(nil).Rate), arg0, arg1, arg2, arg3)
+}
+
+// Remove mocks base method.
+func (m *MockRatingService) Remove(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRatingServiceMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRatingService)(nil).Remove), arg0, arg1, arg2)
+}
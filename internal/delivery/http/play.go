@@ -0,0 +1,135 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// maxTrendingLimit caps the number of songs a trending request can ask for
+const maxTrendingLimit = 100
+
+type PlayService interface {
+	Record(ctx context.Context, songID uuid.UUID) error
+	Trending(ctx context.Context, window time.Duration, limit int) ([]*domain.TrendingSong, error)
+}
+
+type PlayHandler struct {
+	Service PlayService
+	log     *slog.Logger
+}
+
+func NewPlayHandler(service PlayService, log *slog.Logger) *PlayHandler {
+	return &PlayHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *PlayHandler) Routes(r chi.Router) {
+	r.Post("/songs/{id}/play", h.Record)
+	r.Get("/songs/trending", h.Trending)
+}
+
+// @Summary Record a play
+// @Description Count a play of the song, plays show up in trending after the next flush
+// @Tags plays
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Success 202 {object} dto.MessageResponse "play recorded"
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/play [post]
+func (h *PlayHandler) Record(w http.ResponseWriter, r *http.Request) {
+	const op = "PlayHandler.Record"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	if err := h.Service.Record(r.Context(), songID); err != nil {
+		respondError(w, r, log, "failed to record play", err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	respond(w, r, OkResp("play recorded"))
+}
+
+// @Summary Get trending songs
+// @Description Get the most played songs within a time window
+// @Tags plays
+// @Produce  json,xml,application/yaml
+// @Param window query string false "Time window, e.g. 24h (defaults to the configured window)"
+// @Param limit query int false "Number of songs (defaults to the configured limit, at most 100)"
+// @Success 200 {array} dto.TrendingSongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid window or limit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/trending [get]
+func (h *PlayHandler) Trending(w http.ResponseWriter, r *http.Request) {
+	const op = "PlayHandler.Trending"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var window time.Duration
+	if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+		var err error
+		window, err = time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			log.Warn("invalid window parameter", slog.String("window", windowStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid window parameter", nil)
+			return
+		}
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxTrendingLimit {
+			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid limit parameter", nil)
+			return
+		}
+	}
+
+	trending, err := h.Service.Trending(r.Context(), window, limit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch trending songs", err)
+		return
+	}
+
+	trendingResponse := make([]dto.TrendingSongResponse, 0, len(trending))
+	for _, t := range trending {
+		trendingResponse = append(trendingResponse, dto.TrendingSongResponse{
+			SongResponse: *MustConvertSongToResponse(t.Song),
+			Plays:        t.Plays,
+		})
+	}
+
+	log.Info("trending songs successfully fetched", slog.Int("count", len(trendingResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, trendingResponse)
+}
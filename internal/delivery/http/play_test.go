@@ -0,0 +1,79 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPlayRouter(t *testing.T) (http.Handler, *mocks.MockPlayService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockPlays := mocks.NewMockPlayService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewPlayHandler(mockPlays, mockLog))
+
+	return h.InitRoutes(), mockPlays
+}
+
+func TestPlayHandler_Trending(t *testing.T) {
+	router, mockPlays := newPlayRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."}
+	mockPlays.EXPECT().Trending(gomock.Any(), 24*time.Hour, 5).
+		Return([]*domain.TrendingSong{{Song: song, Plays: 42}}, nil)
+
+	// Маршрут /songs/trending не должен восприниматься как /songs/{id}
+	req := httptest.NewRequest(http.MethodGet, "/songs/trending?window=24h&limit=5", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.TrendingSongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, song.ID.String(), resp[0].ID)
+	assert.Equal(t, 42, resp[0].Plays)
+}
+
+func TestPlayHandler_Trending_InvalidWindow(t *testing.T) {
+	router, _ := newPlayRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/trending?window=week", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPlayHandler_Record(t *testing.T) {
+	router, mockPlays := newPlayRouter(t)
+
+	songID := uuid.New()
+	mockPlays.EXPECT().Record(gomock.Any(), songID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/play", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
@@ -0,0 +1,377 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type PlaylistService interface {
+	Add(ctx context.Context, playlist *domain.SmartPlaylist) error
+	Get(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error)
+	Update(ctx context.Context, playlist *domain.SmartPlaylist) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Songs(ctx context.Context, userID, id uuid.UUID, page, pageSize int) ([]*domain.Song, error)
+}
+
+type PlaylistHandler struct {
+	Service PlaylistService
+	log     *slog.Logger
+}
+
+func NewPlaylistHandler(service PlaylistService, log *slog.Logger) *PlaylistHandler {
+	return &PlaylistHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *PlaylistHandler) Routes(r chi.Router) {
+	r.Route("/smart-playlists", func(r chi.Router) {
+		r.Post("/", h.Add)
+		r.Get("/", h.GetAll)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+		r.Get("/{id}/songs", h.Songs)
+	})
+}
+
+// @Summary Create a smart playlist
+// @Description Save a filter of the current user as a named smart playlist. Its songs are found again every time they are listed.
+// @Tags smart-playlists
+// @Accept  json
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param playlist body dto.SmartPlaylistRequest true "Smart playlist"
+// @Success 201 {object} dto.SmartPlaylistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists [post]
+func (h *PlaylistHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	playlist, ok := decodePlaylistRequest(w, r, log)
+	if !ok {
+		return
+	}
+	playlist.UserID = userID
+
+	if err := h.Service.Add(r.Context(), playlist); err != nil {
+		respondError(w, r, log, "failed to add smart playlist", err)
+		return
+	}
+
+	log.Info("smart playlist successfully added", slog.String("playlist_id", playlist.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.SmartPlaylistToResponse(playlist))
+}
+
+// @Summary Get a smart playlist
+// @Description Get a smart playlist of the current user by ID
+// @Tags smart-playlists
+// @Produce  json,xml,application/yaml
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Smart playlist ID"
+// @Success 200 {object} dto.SmartPlaylistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid smart playlist id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "smart playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists/{id} [get]
+func (h *PlaylistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := playlistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	playlist, err := h.Service.Get(r.Context(), userID, id)
+	if err != nil {
+		respondError(w, r, log, "failed to get smart playlist", err)
+		return
+	}
+
+	log.Info("smart playlist successfully fetched", slog.String("playlist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.SmartPlaylistToResponse(playlist))
+}
+
+// @Summary Get all smart playlists
+// @Description Get the smart playlists of the current user in the library
+// @Tags smart-playlists
+// @Produce  json,xml,application/yaml
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} dto.SmartPlaylistResponse
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists [get]
+func (h *PlaylistHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	playlists, err := h.Service.GetAll(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch smart playlists", err)
+		return
+	}
+
+	playlistsResponse := make([]*dto.SmartPlaylistResponse, 0, len(playlists))
+	for _, playlist := range playlists {
+		playlistsResponse = append(playlistsResponse, dto.SmartPlaylistToResponse(playlist))
+	}
+
+	log.Info("smart playlists successfully fetched", slog.Int("count", len(playlistsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, playlistsResponse)
+}
+
+// @Summary Update a smart playlist
+// @Description Replace the name and the filter of a smart playlist of the current user
+// @Tags smart-playlists
+// @Accept  json
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Smart playlist ID"
+// @Param playlist body dto.SmartPlaylistRequest true "Smart playlist"
+// @Success 200 {object} dto.SmartPlaylistResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request or invalid smart playlist id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "smart playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists/{id} [put]
+func (h *PlaylistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := playlistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	playlist, ok := decodePlaylistRequest(w, r, log)
+	if !ok {
+		return
+	}
+	playlist.ID = id
+	playlist.UserID = userID
+
+	if err := h.Service.Update(r.Context(), playlist); err != nil {
+		respondError(w, r, log, "failed to update smart playlist", err)
+		return
+	}
+
+	log.Info("smart playlist successfully updated", slog.String("playlist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.SmartPlaylistToResponse(playlist))
+}
+
+// @Summary Delete a smart playlist
+// @Description Delete a smart playlist of the current user by ID
+// @Tags smart-playlists
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Smart playlist ID"
+// @Success 200 {object} dto.MessageResponse "smart playlist deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid smart playlist id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "smart playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists/{id} [delete]
+func (h *PlaylistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := playlistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), userID, id); err != nil {
+		respondError(w, r, log, "failed to delete smart playlist", err)
+		return
+	}
+
+	log.Info("smart playlist successfully deleted", slog.String("playlist_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("smart playlist deleted successfully"))
+}
+
+// @Summary Get the songs of a smart playlist
+// @Description Evaluate the filter of a smart playlist of the current user and get the matching songs, newest first
+// @Tags smart-playlists
+// @Produce  json,xml,application/yaml
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Smart playlist ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of songs per page"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {array} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid smart playlist id, page or page_size parameter"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "smart playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists/{id}/songs [get]
+func (h *PlaylistHandler) Songs(w http.ResponseWriter, r *http.Request) {
+	const op = "PlaylistHandler.Songs"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, ok := playlistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	songs, err := h.Service.Songs(r.Context(), userID, id, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch smart playlist songs", err)
+		return
+	}
+
+	songsResponse := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songsResponse = append(songsResponse, *songToResponse(song))
+	}
+
+	log.Info("smart playlist songs successfully fetched", slog.Int("count", len(songsResponse)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, songsResponse, fields)
+}
+
+func playlistIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid smart playlist id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid smart playlist id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// decodePlaylistRequest reads a smart playlist, the tags and the rest of the
+// filter are validated by the service
+func decodePlaylistRequest(w http.ResponseWriter, r *http.Request, log *slog.Logger) (*domain.SmartPlaylist, bool) {
+	var req dto.SmartPlaylistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return nil, false
+	}
+
+	playlist := &domain.SmartPlaylist{
+		Name: req.Name,
+		Filter: domain.PlaylistFilter{
+			Group:   req.Filter.Group,
+			Tags:    req.Filter.Tags,
+			TagMode: domain.TagMode(req.Filter.TagsMode),
+			Query:   req.Filter.Query,
+		},
+	}
+
+	switch playlist.Filter.TagMode {
+	case "", domain.TagModeAll, domain.TagModeAny:
+	default:
+		log.Warn("invalid filter tags_mode", slog.String("tags_mode", req.Filter.TagsMode))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid filter tags_mode", nil)
+		return nil, false
+	}
+
+	for _, date := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"released_from", req.Filter.ReleasedFrom, &playlist.Filter.ReleasedFrom},
+		{"released_to", req.Filter.ReleasedTo, &playlist.Filter.ReleasedTo},
+	} {
+		if date.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, date.value)
+		if err != nil {
+			log.Warn("invalid filter "+date.name, slog.String(date.name, date.value))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid filter "+date.name, nil)
+			return nil, false
+		}
+		*date.dst = parsed
+	}
+
+	return playlist, true
+}
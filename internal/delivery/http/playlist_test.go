@@ -0,0 +1,134 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/middleware/user"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlaylistRouter(t *testing.T) (http.Handler, *mocks.MockPlaylistService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockPlaylists := mocks.NewMockPlaylistService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewPlaylistHandler(mockPlaylists, mockLog))
+	h.Use(user.New(mockLog))
+
+	return h.InitRoutes(), mockPlaylists
+}
+
+func TestPlaylistHandler_Add(t *testing.T) {
+	router, mockPlaylists := newPlaylistRouter(t)
+
+	userID := uuid.New()
+	mockPlaylists.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, playlist *domain.SmartPlaylist) error {
+		assert.Equal(t, userID, playlist.UserID)
+		assert.Equal(t, "Muse 2000s", playlist.Name)
+		assert.Equal(t, domain.TagModeAny, playlist.Filter.TagMode)
+		assert.Equal(t, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), playlist.Filter.ReleasedFrom)
+		assert.True(t, playlist.Filter.ReleasedTo.IsZero())
+		playlist.ID = uuid.New()
+		return nil
+	})
+
+	body := `{"name": "Muse 2000s", "filter": {"group": "Muse", "tags": ["rock"], "tags_mode": "any", "released_from": "2000-01-01", "query": "bugging"}}`
+	req := httptest.NewRequest(http.MethodPost, "/smart-playlists", strings.NewReader(body))
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.SmartPlaylistResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "2000-01-01", resp.Filter.ReleasedFrom)
+	assert.Equal(t, "bugging", resp.Filter.Query)
+}
+
+func TestPlaylistHandler_Add_InvalidFilter(t *testing.T) {
+	router, _ := newPlaylistRouter(t)
+
+	for _, body := range []string{
+		`{"name": "Muse", "filter": {"released_to": "01.01.2000"}}`,
+		`{"name": "Muse", "filter": {"tags": ["rock"], "tags_mode": "some"}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/smart-playlists", strings.NewReader(body))
+		req.Header.Set(user.Header, uuid.NewString())
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestPlaylistHandler_Songs(t *testing.T) {
+	router, mockPlaylists := newPlaylistRouter(t)
+
+	userID, id := uuid.New(), uuid.New()
+	mockPlaylists.EXPECT().Songs(gomock.Any(), userID, id, 2, 5).
+		Return([]*domain.Song{{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/smart-playlists/"+id.String()+"/songs?page=2&page_size=5", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SongResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "Hysteria", resp[0].Name)
+}
+
+func TestPlaylistHandler_Get_NotFound(t *testing.T) {
+	router, mockPlaylists := newPlaylistRouter(t)
+
+	userID, id := uuid.New(), uuid.New()
+	mockPlaylists.EXPECT().Get(gomock.Any(), userID, id).Return(nil, domain.ErrPlaylistNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/smart-playlists/"+id.String(), nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodePlaylistNotFound, resp.Code)
+}
+
+func TestPlaylistHandler_GetAll_Anonymous(t *testing.T) {
+	router, _ := newPlaylistRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/smart-playlists", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
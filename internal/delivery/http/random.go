@@ -0,0 +1,101 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type RandomService interface {
+	Random(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error)
+	OfTheDay(ctx context.Context) (*domain.Song, error)
+}
+
+type RandomHandler struct {
+	Service RandomService
+	log     *slog.Logger
+}
+
+func NewRandomHandler(service RandomService, log *slog.Logger) *RandomHandler {
+	return &RandomHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *RandomHandler) Routes(r chi.Router) {
+	r.Get("/songs/random", h.Random)
+	r.Get("/songs/of-the-day", h.OfTheDay)
+}
+
+// @Summary Get a random song
+// @Description Get a random song, optionally of a group or with a tag
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param group query string false "Filter by group"
+// @Param tag query string false "Filter by tag"
+// @Success 200 {object} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid tag parameter"
+// @Failure 404 {object} dto.ErrorResponse "no song matches the filter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/random [get]
+func (h *RandomHandler) Random(w http.ResponseWriter, r *http.Request) {
+	const op = "RandomHandler.Random"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter := &domain.SongFilter{Group: r.URL.Query().Get("group"), ExcludeArchived: true}
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		tags, err := domain.NormalizeTags([]string{tag})
+		if err != nil {
+			log.Warn("invalid tag parameter", slog.String("tag", tag))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid tag parameter", nil)
+			return
+		}
+		filter.Tags = tags
+	}
+
+	song, err := h.Service.Random(r.Context(), filter)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch random song", err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	respond(w, r, songToResponse(song))
+}
+
+// @Summary Get the song of the day
+// @Description Get the song of the day, the same for every caller until midnight
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Success 200 {object} dto.SongResponse
+// @Failure 404 {object} dto.ErrorResponse "library is empty"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/of-the-day [get]
+func (h *RandomHandler) OfTheDay(w http.ResponseWriter, r *http.Request) {
+	const op = "RandomHandler.OfTheDay"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	song, err := h.Service.OfTheDay(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch song of the day", err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	respond(w, r, songToResponse(song))
+}
@@ -0,0 +1,89 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRandomRouter(t *testing.T) (http.Handler, *mocks.MockRandomService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRandom := mocks.NewMockRandomService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewRandomHandler(mockRandom, mockLog))
+
+	return h.InitRoutes(), mockRandom
+}
+
+func TestRandomHandler_Random(t *testing.T) {
+	router, mockRandom := newRandomRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}
+	mockRandom.EXPECT().
+		Random(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter) (*domain.Song, error) {
+			// Тег нормализуется так же, как в списке песен
+			assert.Equal(t, "Muse", filter.Group)
+			assert.Equal(t, []string{"rock"}, filter.Tags)
+			assert.True(t, filter.ExcludeArchived)
+			return song, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/random?group=Muse&tag=Rock", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, song.ID.String(), resp.ID)
+}
+
+func TestRandomHandler_Random_NotFound(t *testing.T) {
+	router, mockRandom := newRandomRouter(t)
+
+	mockRandom.EXPECT().Random(gomock.Any(), gomock.Any()).Return(nil, domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/random?group=Queen", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRandomHandler_OfTheDay(t *testing.T) {
+	router, mockRandom := newRandomRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Creep", Group: "Radiohead"}
+	mockRandom.EXPECT().OfTheDay(gomock.Any()).Return(song, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/of-the-day", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "Creep", resp.Name)
+}
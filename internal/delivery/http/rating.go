@@ -0,0 +1,177 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type RatingService interface {
+	Rate(ctx context.Context, userID, songID uuid.UUID, value int) (*domain.Rating, error)
+	Get(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+	Remove(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+}
+
+type RatingHandler struct {
+	Service RatingService
+	log     *slog.Logger
+}
+
+func NewRatingHandler(service RatingService, log *slog.Logger) *RatingHandler {
+	return &RatingHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *RatingHandler) Routes(r chi.Router) {
+	r.Put("/songs/{id}/rating", h.Rate)
+	r.Get("/songs/{id}/rating", h.Get)
+	r.Delete("/songs/{id}/rating", h.Remove)
+}
+
+// @Summary Rate a song
+// @Description Rate the song from 1 to 5 for the current user, replacing the previous rating, and get the new average rating of the song
+// @Tags ratings
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param X-User-ID header string true "User ID"
+// @Param rating body dto.RatingRequest true "Rating"
+// @Success 200 {object} dto.RatingResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or rating"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/rating [put]
+func (h *RatingHandler) Rate(w http.ResponseWriter, r *http.Request) {
+	const op = "RatingHandler.Rate"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	var req dto.RatingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	rating, err := h.Service.Rate(r.Context(), userID, songID, req.Rating)
+	if err != nil {
+		respondError(w, r, log, "failed to rate song", err)
+		return
+	}
+
+	log.Info("song successfully rated", slog.String("song_id", songID.String()), slog.Int("rating", rating.Value))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.RatingToResponse(rating))
+}
+
+// @Summary Get the rating of a song
+// @Description Get the rating the current user gave the song, missing if the user didn't rate it, with the average rating of the song
+// @Tags ratings
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} dto.RatingResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/rating [get]
+func (h *RatingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "RatingHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	rating, err := h.Service.Get(r.Context(), userID, songID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch rating", err)
+		return
+	}
+
+	log.Info("rating successfully fetched", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.RatingToResponse(rating))
+}
+
+// @Summary Remove the rating of a song
+// @Description Remove the rating the current user gave the song and get the new average rating of the song
+// @Tags ratings
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} dto.RatingResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/rating [delete]
+func (h *RatingHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	const op = "RatingHandler.Remove"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	rating, err := h.Service.Remove(r.Context(), userID, songID)
+	if err != nil {
+		respondError(w, r, log, "failed to remove rating", err)
+		return
+	}
+
+	log.Info("rating successfully removed", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.RatingToResponse(rating))
+}
@@ -0,0 +1,115 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/middleware/user"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRatingRouter(t *testing.T) (http.Handler, *mocks.MockRatingService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRatings := mocks.NewMockRatingService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewRatingHandler(mockRatings, mockLog))
+	h.Use(user.New(mockLog))
+
+	return h.InitRoutes(), mockRatings
+}
+
+func TestRatingHandler_Rate(t *testing.T) {
+	router, mockRatings := newRatingRouter(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockRatings.EXPECT().Rate(gomock.Any(), userID, songID, 4).Return(&domain.Rating{
+		UserID: userID, SongID: songID, Value: 4, RatedAt: time.Now(), Average: 4.5, Count: 2,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/rating", strings.NewReader(`{"rating": 4}`))
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.RatingResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, songID.String(), resp.SongID)
+	assert.Equal(t, 4, resp.Rating)
+	assert.NotNil(t, resp.RatedAt)
+	assert.Equal(t, 4.5, resp.RatingAverage)
+	assert.Equal(t, 2, resp.RatingsCount)
+}
+
+func TestRatingHandler_Rate_OutOfRange(t *testing.T) {
+	router, mockRatings := newRatingRouter(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockRatings.EXPECT().Rate(gomock.Any(), userID, songID, 6).Return(nil, domain.ErrInvalidRating)
+
+	req := httptest.NewRequest(http.MethodPut, "/songs/"+songID.String()+"/rating", strings.NewReader(`{"rating": 6}`))
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeValidationFailed, resp.Code)
+}
+
+func TestRatingHandler_Get_NotRated(t *testing.T) {
+	router, mockRatings := newRatingRouter(t)
+
+	// Пользователь не оценивал песню: в ответе только средняя оценка
+	userID, songID := uuid.New(), uuid.New()
+	mockRatings.EXPECT().Get(gomock.Any(), userID, songID).Return(&domain.Rating{
+		UserID: userID, SongID: songID, Average: 3, Count: 1,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/rating", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.NotContains(t, resp, "rating")
+	assert.NotContains(t, resp, "rated_at")
+	assert.Equal(t, 3.0, resp["rating_average"])
+	assert.Equal(t, 1.0, resp["ratings_count"])
+}
+
+func TestRatingHandler_Remove_Anonymous(t *testing.T) {
+	router, _ := newRatingRouter(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/songs/"+uuid.NewString()+"/rating", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,242 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/internal/feed"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// feedSummaryLength caps the lyrics excerpt of a feed entry in characters
+const feedSummaryLength = 280
+
+type RecentService interface {
+	Recent(ctx context.Context, sort domain.SongSort, excludeExplicit bool, limit int) ([]*domain.Song, error)
+}
+
+// RecentHandler lists the latest songs and publishes them as a feed. Links
+// of the feed start with BaseURL, the address the request was sent to when
+// it is empty.
+type RecentHandler struct {
+	Service   RecentService
+	FeedTitle string
+	BaseURL   string
+	log       *slog.Logger
+}
+
+func NewRecentHandler(service RecentService, feedTitle, baseURL string, log *slog.Logger) *RecentHandler {
+	return &RecentHandler{
+		Service:   service,
+		FeedTitle: feedTitle,
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		log:       log,
+	}
+}
+
+func (h *RecentHandler) Routes(r chi.Router) {
+	r.Get("/songs/recent", h.Recent)
+	r.Get("/feeds/songs.xml", h.Feed)
+}
+
+// @Summary Get recent songs
+// @Description Get the songs added last, or updated last with sort=updated_at, newest first
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param sort query string false "Order: created_at (default) or updated_at" Enums(created_at, updated_at)
+// @Param limit query int false "Number of songs (default 20, at most 100)"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Param fields query string false "Comma separated song fields to return, e.g. id,name,group"
+// @Success 200 {array} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid sort, limit or exclude_explicit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/recent [get]
+func (h *RecentHandler) Recent(w http.ResponseWriter, r *http.Request) {
+	const op = "RecentHandler.Recent"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	fields, ok := fieldsParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	songs, ok := h.recentSongs(w, r, log)
+	if !ok {
+		return
+	}
+
+	songsResponse := make([]*dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songsResponse = append(songsResponse, songToResponse(song))
+	}
+
+	log.Debug("recent songs successfully fetched", slog.Int("count", len(songsResponse)))
+	render.Status(r, http.StatusOK)
+	respondFields(w, r, log, songsResponse, fields)
+}
+
+// @Summary Get the feed of recent songs
+// @Description Get the songs added last as an RSS 2.0 or Atom feed for feed readers, newest first. With sort=updated_at the feed lists updated songs and an update shows up again in readers. Entries link to the song in the API and summarize the beginning of its lyrics.
+// @Tags songs
+// @Produce  application/rss+xml,application/atom+xml
+// @Param format query string false "Feed format: rss (default) or atom" Enums(rss, atom)
+// @Param sort query string false "Order: created_at (default) or updated_at" Enums(created_at, updated_at)
+// @Param limit query int false "Number of songs (default 20, at most 100)"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse "invalid format, sort, limit or exclude_explicit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /feeds/songs.xml [get]
+func (h *RecentHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	const op = "RecentHandler.Feed"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	format, err := feed.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		log.Warn("unsupported feed format", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "format must be rss or atom", nil)
+		return
+	}
+
+	songs, ok := h.recentSongs(w, r, log)
+	if !ok {
+		return
+	}
+
+	body, err := feed.Render(h.songsFeed(r, songs), format)
+	if err != nil {
+		respondError(w, r, log, "failed to render feed", err)
+		return
+	}
+
+	log.Debug("feed successfully rendered", slog.Int("count", len(songs)))
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// recentSongs fetches the songs selected by the sort, limit and
+// exclude_explicit parameters, it responds with an error when it fails
+func (h *RecentHandler) recentSongs(w http.ResponseWriter, r *http.Request, log *slog.Logger) ([]*domain.Song, bool) {
+	sort := domain.SongSort(r.URL.Query().Get("sort"))
+	switch sort {
+	case "":
+		sort = domain.SortByCreatedAt
+	case domain.SortByCreatedAt, domain.SortByUpdatedAt:
+	default:
+		log.Warn("invalid sort parameter", slog.String("sort", string(sort)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "sort must be created_at or updated_at", nil)
+		return nil, false
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid limit parameter", nil)
+			return nil, false
+		}
+	}
+
+	excludeExplicit, ok := excludeExplicitParam(w, r, log)
+	if !ok {
+		return nil, false
+	}
+
+	songs, err := h.Service.Recent(r.Context(), sort, excludeExplicit, limit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch recent songs", err)
+		return nil, false
+	}
+	return songs, true
+}
+
+// songsFeed builds the feed of the songs. Entries of updated songs get the
+// version in their ID, so readers show every update as a new entry.
+func (h *RecentHandler) songsFeed(r *http.Request, songs []*domain.Song) *feed.Feed {
+	baseURL := baseURL(h.BaseURL, r)
+	updatedFeed := r.URL.Query().Get("sort") == string(domain.SortByUpdatedAt)
+
+	songsFeed := &feed.Feed{
+		Title:       h.FeedTitle,
+		Description: "Songs recently added to " + h.FeedTitle,
+		Link:        baseURL + "/songs/recent",
+		Self:        baseURL + r.URL.RequestURI(),
+		Updated:     time.Now(),
+	}
+	if updatedFeed {
+		songsFeed.Description = "Songs recently updated in " + h.FeedTitle
+		songsFeed.Link += "?sort=updated_at"
+	}
+
+	for i, song := range songs {
+		entry := feed.Entry{
+			ID:        "urn:uuid:" + song.ID.String(),
+			Title:     song.Group + " — " + song.Name,
+			Author:    song.Group,
+			Link:      baseURL + "/songs/" + song.ID.String(),
+			Summary:   lyricsExcerpt(song.Text),
+			Published: song.CreatedAt,
+			Updated:   song.UpdatedAt,
+		}
+		if updatedFeed {
+			entry.ID += "?version=" + strconv.Itoa(song.Version)
+			entry.Published = song.UpdatedAt
+		}
+		// the feed changed when its newest song did
+		if i == 0 {
+			songsFeed.Updated = entry.Published
+		}
+		songsFeed.Entries = append(songsFeed.Entries, entry)
+	}
+
+	return songsFeed
+}
+
+// baseURL is the configured base URL of links or, when it is empty, the
+// scheme and host the request was sent to
+func baseURL(configured string, r *http.Request) string {
+	if configured != "" {
+		return configured
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// lyricsExcerpt is the beginning of the lyrics, cut at a line break or word
+// boundary after at most feedSummaryLength characters
+func lyricsExcerpt(text string) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= feedSummaryLength {
+		return string(runes)
+	}
+
+	excerpt := string(runes[:feedSummaryLength])
+	if i := strings.LastIndexAny(excerpt, " \n"); i > 0 {
+		excerpt = excerpt[:i]
+	}
+	return strings.TrimSpace(excerpt) + "…"
+}
@@ -0,0 +1,148 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecentRouter(t *testing.T, baseURL string) (http.Handler, *mocks.MockRecentService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRecent := mocks.NewMockRecentService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewRecentHandler(mockRecent, "songLibrary", baseURL, mockLog))
+
+	return h.InitRoutes(), mockRecent
+}
+
+func TestRecentHandler_Recent(t *testing.T) {
+	router, mockRecent := newRecentRouter(t, "")
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	mockRecent.EXPECT().Recent(gomock.Any(), domain.SortByUpdatedAt, true, 5).Return([]*domain.Song{song}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/recent?sort=updated_at&limit=5&exclude_explicit=true", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, song.ID.String(), resp[0].ID)
+}
+
+func TestRecentHandler_Feed(t *testing.T) {
+	router, mockRecent := newRecentRouter(t, "https://songs.example.com/")
+
+	added := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	song := &domain.Song{
+		ID: uuid.New(), Name: "Hysteria", Group: "Muse", Version: 3,
+		Text: strings.Repeat("It's bugging me ", 30), CreatedAt: added, UpdatedAt: added.Add(time.Hour),
+	}
+	mockRecent.EXPECT().Recent(gomock.Any(), domain.SortByCreatedAt, false, 0).Return([]*domain.Song{song}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/songs.xml", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	var doc struct {
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				GUID        string `xml:"guid"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "songLibrary", doc.Channel.Title)
+	// Лента обновилась вместе с самой новой песней
+	assert.Equal(t, "Fri, 01 Mar 2024 12:00:00 +0000", doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 1)
+	assert.Equal(t, "Muse — Hysteria", doc.Channel.Items[0].Title)
+	assert.Equal(t, "https://songs.example.com/songs/"+song.ID.String(), doc.Channel.Items[0].Link)
+	assert.Equal(t, "urn:uuid:"+song.ID.String(), doc.Channel.Items[0].GUID)
+	// Длинный текст обрезается по границе слова
+	assert.True(t, strings.HasSuffix(doc.Channel.Items[0].Description, "It's…"))
+	assert.LessOrEqual(t, len([]rune(doc.Channel.Items[0].Description)), 281)
+}
+
+func TestRecentHandler_Feed_AtomUpdated(t *testing.T) {
+	router, mockRecent := newRecentRouter(t, "")
+
+	added := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Version: 3, CreatedAt: added, UpdatedAt: added.Add(time.Hour)}
+	mockRecent.EXPECT().Recent(gomock.Any(), domain.SortByUpdatedAt, false, 0).Return([]*domain.Song{song}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/songs.xml?format=atom&sort=updated_at", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	var doc struct {
+		ID      string `xml:"id"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Link      struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &doc))
+	// Без base_url ссылки ведут на адрес запроса
+	assert.Equal(t, "http://example.com/feeds/songs.xml?format=atom&sort=updated_at", doc.ID)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "http://example.com/songs/"+song.ID.String(), doc.Entries[0].Link.Href)
+	// Каждое изменение песни — новая запись ленты
+	assert.Equal(t, "urn:uuid:"+song.ID.String()+"?version=3", doc.Entries[0].ID)
+	assert.Equal(t, "2024-03-01T13:00:00Z", doc.Entries[0].Published)
+}
+
+func TestRecentHandler_InvalidParams(t *testing.T) {
+	router, _ := newRecentRouter(t, "")
+
+	for _, target := range []string{
+		"/songs/recent?sort=popularity", "/songs/recent?limit=0", "/songs/recent?limit=many",
+		"/songs/recent?exclude_explicit=maybe", "/feeds/songs.xml?format=json", "/feeds/songs.xml?sort=name",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
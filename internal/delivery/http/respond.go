@@ -0,0 +1,177 @@
+package deliveryHttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+	"gopkg.in/yaml.v3"
+)
+
+// Content types a response can be rendered in, JSON is the default
+const (
+	contentTypeJSON = "application/json"
+	contentTypeXML  = "application/xml"
+	contentTypeYAML = "application/yaml"
+)
+
+// acceptedTypes maps the media types of the Accept header to the content type rendered for them
+var acceptedTypes = map[string]string{
+	"application/json":   contentTypeJSON,
+	"application/xml":    contentTypeXML,
+	"text/xml":           contentTypeXML,
+	"application/yaml":   contentTypeYAML,
+	"application/x-yaml": contentTypeYAML,
+	"text/yaml":          contentTypeYAML,
+}
+
+// xmlRoot and xmlItem name the root element of an XML response and the
+// elements of a list
+const (
+	xmlRoot = "response"
+	xmlItem = "item"
+)
+
+// respond renders v in the content type negotiated from the Accept header
+// with the status set by render.Status. XML and YAML responses have the
+// same field names as JSON ones, lists in XML are item elements.
+func respond(w http.ResponseWriter, r *http.Request, v any) {
+	varyAccept(w)
+
+	contentType := negotiateContentType(r)
+	if contentType == contentTypeJSON {
+		render.JSON(w, r, v)
+		return
+	}
+
+	body, err := marshal(contentType, v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	if status, ok := r.Context().Value(render.StatusCtxKey).(int); ok {
+		w.WriteHeader(status)
+	}
+	w.Write(body)
+}
+
+// negotiateContentType returns the supported content type the client
+// prefers by the q values of the Accept header, JSON if none is supported
+func negotiateContentType(r *http.Request) string {
+	best, bestQ := contentTypeJSON, 0.0
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		contentType, ok := acceptedTypes[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qStr, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = contentType, q
+		}
+	}
+	return best
+}
+
+// varyAccept marks the response as negotiated by the Accept header, so
+// caches keep the representations of a resource apart
+func varyAccept(w http.ResponseWriter) {
+	for _, value := range w.Header().Values("Vary") {
+		for _, header := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(header), "Accept") {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", "Accept")
+}
+
+// marshal renders v as XML or YAML through its JSON form, so the json tags
+// of the dto types apply to every format
+func marshal(contentType string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	if contentType == contentTypeYAML {
+		return yaml.Marshal(generic)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	if err := encodeXML(enc, xmlRoot, generic); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeXML writes the decoded JSON value v as the element name, object
+// keys are written in alphabetical order
+func encodeXML(enc *xml.Encoder, name string, v any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if err := encodeXML(enc, key, v[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := encodeXML(enc, xmlItem, item); err != nil {
+				return err
+			}
+		}
+	case float64:
+		if err := enc.EncodeToken(xml.CharData(strconv.FormatFloat(v, 'f', -1, 64))); err != nil {
+			return err
+		}
+	case bool:
+		if err := enc.EncodeToken(xml.CharData(strconv.FormatBool(v))); err != nil {
+			return err
+		}
+	case string:
+		if err := enc.EncodeToken(xml.CharData(v)); err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
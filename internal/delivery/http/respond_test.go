@@ -0,0 +1,122 @@
+package deliveryHttp_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"songLibrary/internal/domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRespond_ContentNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{name: "без Accept", accept: "", contentType: "application/json"},
+		{name: "любой тип", accept: "*/*", contentType: "application/json"},
+		{name: "XML", accept: "application/xml", contentType: "application/xml"},
+		{name: "text/xml", accept: "text/xml", contentType: "application/xml"},
+		{name: "YAML", accept: "application/x-yaml", contentType: "application/yaml"},
+		{name: "предпочтение по q", accept: "application/json;q=0.5, application/yaml;q=0.9", contentType: "application/yaml"},
+		{name: "неподдерживаемый тип", accept: "text/csv", contentType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockTags := newTagRouter(t)
+
+			songID := uuid.New()
+			mockTags.EXPECT().GetSongTags(gomock.Any(), songID).Return([]string{"live", "rock"}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/tags", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Header().Get("Content-Type"), tt.contentType)
+			assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestRespond_XML(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().GetSongTags(gomock.Any(), songID).Return([]string{"live", "rock"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/tags", nil)
+	req.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Поля называются так же, как в JSON, элементы списка — item
+	var resp struct {
+		XMLName xml.Name `xml:"response"`
+		SongID  string   `xml:"song_id"`
+		Tags    []string `xml:"tags>item"`
+	}
+	assert.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, songID.String(), resp.SongID)
+	assert.Equal(t, []string{"live", "rock"}, resp.Tags)
+}
+
+func TestRespond_YAML(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().GetSongTags(gomock.Any(), songID).Return([]string{"live", "rock"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/tags", nil)
+	req.Header.Set("Accept", "application/yaml")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		SongID string   `yaml:"song_id"`
+		Tags   []string `yaml:"tags"`
+	}
+	assert.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, songID.String(), resp.SongID)
+	assert.Equal(t, []string{"live", "rock"}, resp.Tags)
+}
+
+func TestRespond_ErrorInYAML(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().GetSongTags(gomock.Any(), songID).Return(nil, domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/tags", nil)
+	req.Header.Set("Accept", "application/yaml")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	// Ошибки отдаются в том же формате со своим статусом
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/yaml")
+
+	var resp struct {
+		Code string `yaml:"code"`
+	}
+	assert.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Code)
+}
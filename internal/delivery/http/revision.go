@@ -0,0 +1,209 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"songLibrary/pkg/textdiff"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type RevisionService interface {
+	GetAll(ctx context.Context, songID uuid.UUID, page, pageSize int) ([]*domain.SongRevision, error)
+	Restore(ctx context.Context, songID uuid.UUID, revision int) (*domain.Song, error)
+	Diff(ctx context.Context, songID uuid.UUID, from, to int) ([]textdiff.Line, error)
+}
+
+type RevisionHandler struct {
+	Service RevisionService
+	log     *slog.Logger
+}
+
+func NewRevisionHandler(service RevisionService, log *slog.Logger) *RevisionHandler {
+	return &RevisionHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *RevisionHandler) Routes(r chi.Router) {
+	r.Get("/songs/{id}/revisions", h.GetAll)
+	r.Post("/songs/{id}/revisions/{rev}/restore", h.Restore)
+	r.Get("/songs/{id}/revisions/{a}/diff/{b}", h.Diff)
+}
+
+// @Summary Get previous revisions of a song
+// @Description Get the states a song had before its updates, newest first. The revision is the version the song had.
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of revisions per page"
+// @Success 200 {array} dto.SongRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id, page or page_size parameter"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/revisions [get]
+func (h *RevisionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "RevisionHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.Service.GetAll(r.Context(), songID, page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch song revisions", err)
+		return
+	}
+
+	revisionsResponse := make([]dto.SongRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionsResponse = append(revisionsResponse, revisionToResponse(revision))
+	}
+
+	log.Info("song revisions successfully fetched", slog.String("song_id", songID.String()), slog.Int("count", len(revisionsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, revisionsResponse)
+}
+
+// @Summary Restore a previous revision of a song
+// @Description Roll the text, link and release date of the song back to the revision. The current state is kept as a revision.
+// @Tags songs
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param rev path int true "Revision"
+// @Success 200 {object} dto.SongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or revision"
+// @Failure 404 {object} dto.ErrorResponse "song or revision not found"
+// @Failure 409 {object} dto.ErrorResponse "song was modified by another request"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/revisions/{rev}/restore [post]
+func (h *RevisionHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	const op = "RevisionHandler.Restore"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil || revision <= 0 {
+		log.Warn("invalid revision", slog.String("rev", chi.URLParam(r, "rev")))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid revision", nil)
+		return
+	}
+
+	song, err := h.Service.Restore(r.Context(), songID, revision)
+	if err != nil {
+		respondError(w, r, log, "failed to restore song revision", err)
+		return
+	}
+
+	log.Info("song revision successfully restored", slog.String("song_id", songID.String()), slog.Int("revision", revision))
+	render.Status(r, http.StatusOK)
+	respond(w, r, songToResponse(song))
+}
+
+// @Summary Compare the text of two revisions
+// @Description Get a line-level diff of the song text from revision a to revision b. The current version of the song can be used as a revision too.
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param a path int true "Revision to compare from"
+// @Param b path int true "Revision to compare to"
+// @Success 200 {object} dto.TextDiffResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or revision"
+// @Failure 404 {object} dto.ErrorResponse "song or revision not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/revisions/{a}/diff/{b} [get]
+func (h *RevisionHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	const op = "RevisionHandler.Diff"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	from, err := strconv.Atoi(chi.URLParam(r, "a"))
+	if err != nil || from <= 0 {
+		log.Warn("invalid revision", slog.String("a", chi.URLParam(r, "a")))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid revision", nil)
+		return
+	}
+
+	to, err := strconv.Atoi(chi.URLParam(r, "b"))
+	if err != nil || to <= 0 {
+		log.Warn("invalid revision", slog.String("b", chi.URLParam(r, "b")))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid revision", nil)
+		return
+	}
+
+	diff, err := h.Service.Diff(r.Context(), songID, from, to)
+	if err != nil {
+		respondError(w, r, log, "failed to compare song revisions", err)
+		return
+	}
+
+	response := dto.TextDiffResponse{From: from, To: to, Lines: make([]dto.DiffLineResponse, 0, len(diff))}
+	for _, line := range diff {
+		response.Lines = append(response.Lines, dto.DiffLineResponse{Op: string(line.Op), Text: line.Text})
+	}
+
+	log.Info("song revisions successfully compared", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, response)
+}
+
+func revisionToResponse(revision *domain.SongRevision) dto.SongRevisionResponse {
+	response := dto.SongRevisionResponse{
+		Revision:    revision.Revision,
+		Name:        revision.Song.Name,
+		Group:       revision.Song.Group,
+		Text:        revision.Song.Text,
+		Link:        revision.Song.Link,
+		ReleaseDate: revision.Song.ReleaseDate,
+		CreatedAt:   revision.CreatedAt,
+	}
+
+	if revision.Song.AlbumID != nil {
+		response.AlbumID = revision.Song.AlbumID.String()
+	}
+
+	return response
+}
@@ -0,0 +1,134 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"songLibrary/pkg/textdiff"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRevisionRouter(t *testing.T) (http.Handler, *mocks.MockRevisionService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRevisions := mocks.NewMockRevisionService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewRevisionHandler(mockRevisions, mockLog))
+
+	return h.InitRoutes(), mockRevisions
+}
+
+func TestRevisionHandler_GetAll(t *testing.T) {
+	router, mockRevisions := newRevisionRouter(t)
+
+	songID := uuid.New()
+	mockRevisions.EXPECT().GetAll(gomock.Any(), songID, 0, 0).Return([]*domain.SongRevision{
+		{Revision: 2, Song: &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "second"}},
+		{Revision: 1, Song: &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "first"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/revisions", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SongRevisionResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, 2, resp[0].Revision)
+		assert.Equal(t, "second", resp[0].Text)
+	}
+}
+
+func TestRevisionHandler_Restore(t *testing.T) {
+	router, mockRevisions := newRevisionRouter(t)
+
+	songID := uuid.New()
+	mockRevisions.EXPECT().Restore(gomock.Any(), songID, 1).
+		Return(&domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "first", Version: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/revisions/1/restore", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "first", resp.Text)
+	assert.Equal(t, 3, resp.Version)
+}
+
+func TestRevisionHandler_Restore_NotFound(t *testing.T) {
+	router, mockRevisions := newRevisionRouter(t)
+
+	songID := uuid.New()
+	mockRevisions.EXPECT().Restore(gomock.Any(), songID, 5).Return(nil, domain.ErrRevisionNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/revisions/5/restore", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeRevisionNotFound, resp.Code)
+}
+
+func TestRevisionHandler_Restore_InvalidRevision(t *testing.T) {
+	router, _ := newRevisionRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+uuid.NewString()+"/revisions/zero/restore", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRevisionHandler_Diff(t *testing.T) {
+	router, mockRevisions := newRevisionRouter(t)
+
+	songID := uuid.New()
+	mockRevisions.EXPECT().Diff(gomock.Any(), songID, 1, 2).Return([]textdiff.Line{
+		{Op: textdiff.Equal, Text: "It's bugging me"},
+		{Op: textdiff.Insert, Text: "Grating me"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/revisions/1/diff/2", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"from":1,"to":2,"lines":[{"op":"equal","text":"It's bugging me"},{"op":"insert","text":"Grating me"}]}`, rec.Body.String())
+}
+
+func TestRevisionHandler_Diff_InvalidRevision(t *testing.T) {
+	router, _ := newRevisionRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/revisions/1/diff/latest", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
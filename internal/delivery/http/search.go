@@ -0,0 +1,136 @@
+package deliveryHttp
+
+import (
+	"context"
+	"html"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// maxSearchQueryLength caps the length of a search query in characters
+const maxSearchQueryLength = 200
+
+// highlighter turns the highlight markers of escaped snippets into HTML
+var highlighter = strings.NewReplacer(domain.HighlightStart, "<mark>", domain.HighlightEnd, "</mark>")
+
+type SearchService interface {
+	Search(ctx context.Context, query string, excludeExplicit bool, snippets, page, pageSize int) ([]*domain.SearchResult, error)
+	SemanticSearch(ctx context.Context, query string, excludeExplicit bool, page, pageSize int) ([]*domain.SearchResult, error)
+}
+
+type SearchHandler struct {
+	Service SearchService
+	log     *slog.Logger
+}
+
+func NewSearchHandler(service SearchService, log *slog.Logger) *SearchHandler {
+	return &SearchHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *SearchHandler) Routes(r chi.Router) {
+	r.Get("/songs/search", h.Search)
+}
+
+// @Summary Search songs
+// @Description Full-text search in song names, groups and lyrics, most relevant first. Supports quoted phrases, "or" and -word. Every song comes with its relevance between 0 and 1 and HTML snippets of the lyrics the query matched, with the matched words wrapped in <mark>. Semantic mode finds the songs nearest in meaning to the query by their embeddings instead, the relevance is their cosine similarity and no snippets are returned
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param q query string true "Search query, at most 200 characters"
+// @Param mode query string false "Search mode: keyword (default) or semantic" Enums(keyword, semantic)
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Param snippets query int false "Number of snippets per song (defaults to the configured number, larger numbers are cut to the configured maximum, 0 returns none)"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of songs per page"
+// @Success 200 {array} dto.SearchResultResponse
+// @Failure 400 {object} dto.ErrorResponse "missing q or invalid mode, exclude_explicit, snippets, page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Failure 501 {object} dto.ErrorResponse "semantic search is disabled"
+// @Router /songs/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	const op = "SearchHandler.Search"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	query := r.URL.Query().Get("q")
+	if strings.TrimSpace(query) == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		log.Warn("invalid q parameter", slog.String("q", query))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "q is required and must be at most 200 characters", nil)
+		return
+	}
+
+	mode := domain.SearchMode(r.URL.Query().Get("mode"))
+	switch mode {
+	case "":
+		mode = domain.SearchKeyword
+	case domain.SearchKeyword, domain.SearchSemantic:
+	default:
+		log.Warn("invalid mode parameter", slog.String("mode", string(mode)))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "mode must be keyword or semantic", nil)
+		return
+	}
+
+	excludeExplicit, ok := excludeExplicitParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	snippets := -1
+	if snippetsStr := r.URL.Query().Get("snippets"); snippetsStr != "" {
+		var err error
+		snippets, err = strconv.Atoi(snippetsStr)
+		if err != nil || snippets < 0 {
+			log.Warn("invalid snippets parameter", slog.String("snippets", snippetsStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid snippets parameter", nil)
+			return
+		}
+	}
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	var results []*domain.SearchResult
+	var err error
+	if mode == domain.SearchSemantic {
+		results, err = h.Service.SemanticSearch(r.Context(), query, excludeExplicit, page, pageSize)
+	} else {
+		results, err = h.Service.Search(r.Context(), query, excludeExplicit, snippets, page, pageSize)
+	}
+	if err != nil {
+		respondError(w, r, log, "failed to search songs", err)
+		return
+	}
+
+	resultsResponse := make([]dto.SearchResultResponse, 0, len(results))
+	for _, result := range results {
+		snippets := make([]string, 0, len(result.Snippets))
+		for _, snippet := range result.Snippets {
+			snippets = append(snippets, highlighter.Replace(html.EscapeString(snippet)))
+		}
+		resultsResponse = append(resultsResponse, dto.SearchResultResponse{
+			Song:     *songToResponse(result.Song),
+			Rank:     result.Rank,
+			Snippets: snippets,
+		})
+	}
+
+	log.Debug("songs successfully searched", slog.Int("count", len(resultsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, resultsResponse)
+}
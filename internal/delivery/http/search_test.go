@@ -0,0 +1,151 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSearchRouter(t *testing.T) (http.Handler, *mocks.MockSearchService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSearch := mocks.NewMockSearchService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewSearchHandler(mockSearch, mockLog))
+
+	return h.InitRoutes(), mockSearch
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me <3"}
+	mockSearch.EXPECT().Search(gomock.Any(), "bugging", false, 2, 1, 10).Return([]*domain.SearchResult{{
+		Song:     song,
+		Rank:     0.25,
+		Snippets: []string{"It's " + domain.HighlightStart + "bugging" + domain.HighlightEnd + " me <3"},
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=bugging&snippets=2&page=1&page_size=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SearchResultResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, song.ID.String(), resp[0].Song.ID)
+	assert.Equal(t, 0.25, resp[0].Rank)
+	// Текст экранируется, совпадения оборачиваются в <mark>
+	assert.Equal(t, []string{"It&#39;s <mark>bugging</mark> me &lt;3"}, resp[0].Snippets)
+}
+
+func TestSearchHandler_Search_DefaultSnippets(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	// Без параметра snippets сервис берёт значение из конфига
+	mockSearch.EXPECT().Search(gomock.Any(), "love", false, -1, 0, 0).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=love", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+}
+
+func TestSearchHandler_Search_ExcludeExplicit(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	mockSearch.EXPECT().Search(gomock.Any(), "love", true, -1, 0, 0).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=love&exclude_explicit=true", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSearchHandler_Search_Semantic(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	mockSearch.EXPECT().SemanticSearch(gomock.Any(), "songs about jealousy", true, 2, 5).
+		Return([]*domain.SearchResult{{Song: song, Rank: 0.8}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=songs+about+jealousy&mode=semantic&exclude_explicit=true&page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SearchResultResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, song.ID.String(), resp[0].Song.ID)
+	assert.Equal(t, 0.8, resp[0].Rank)
+	assert.Empty(t, resp[0].Snippets)
+}
+
+func TestSearchHandler_Search_SemanticDisabled(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	mockSearch.EXPECT().SemanticSearch(gomock.Any(), "love", false, 0, 0).Return(nil, domain.ErrSemanticSearchDisabled)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=love&mode=semantic", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func TestSearchHandler_Search_InvalidParams(t *testing.T) {
+	router, _ := newSearchRouter(t)
+
+	for _, query := range []string{
+		"", "q=", "q=+++", "q=love&snippets=-1", "q=love&snippets=many",
+		"q=love&page=0", "q=love&exclude_explicit=maybe", "q=love&mode=fuzzy", "q=" + strings.Repeat("a", 201),
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/songs/search?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestSearchHandler_Search_ServiceError(t *testing.T) {
+	router, mockSearch := newSearchRouter(t)
+
+	mockSearch.EXPECT().Search(gomock.Any(), "love", false, -1, 0, 0).Return(nil, errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/search?q=love", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
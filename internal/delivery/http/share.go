@@ -0,0 +1,299 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type ShareService interface {
+	ShareSong(ctx context.Context, userID, songID uuid.UUID, ttl time.Duration) (*domain.Share, string, error)
+	SharePlaylist(ctx context.Context, userID, playlistID uuid.UUID, ttl time.Duration) (*domain.Share, string, error)
+	Open(ctx context.Context, token string, page, pageSize int) (*domain.SharedContent, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) (*domain.Share, error)
+	Token(id uuid.UUID) string
+}
+
+// ShareHandler serves read-only links to songs and smart playlists. Links
+// start with BaseURL, the address the request was sent to when it is empty.
+type ShareHandler struct {
+	Service ShareService
+	BaseURL string
+	log     *slog.Logger
+}
+
+func NewShareHandler(service ShareService, baseURL string, log *slog.Logger) *ShareHandler {
+	return &ShareHandler{
+		Service: service,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		log:     log,
+	}
+}
+
+func (h *ShareHandler) Routes(r chi.Router) {
+	r.Post("/songs/{id}/share", h.ShareSong)
+	r.Post("/smart-playlists/{id}/share", h.SharePlaylist)
+	r.Get("/shares", h.GetAll)
+	r.Delete("/shares/{id}", h.Revoke)
+	r.Get("/shared/{token}", h.Open)
+}
+
+// @Summary Share a song
+// @Description Issue a read-only link to a song of the library. The link is opened without identifying until it expires or is revoked.
+// @Tags shares
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Song ID"
+// @Param expires_in query string false "How long the link stays valid, e.g. 72h, the configured default when empty"
+// @Success 201 {object} dto.ShareResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or expires_in parameter"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/share [post]
+func (h *ShareHandler) ShareSong(w http.ResponseWriter, r *http.Request) {
+	const op = "ShareHandler.ShareSong"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	ttl, ok := expiresInParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	share, token, err := h.Service.ShareSong(r.Context(), userID, songID, ttl)
+	if err != nil {
+		respondError(w, r, log, "failed to share song", err)
+		return
+	}
+
+	log.Info("song successfully shared", slog.String("share_id", share.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.ShareToResponse(share, token, h.url(r, token)))
+}
+
+// @Summary Share a smart playlist
+// @Description Issue a read-only link to a smart playlist of the current user. The link lists the songs matching the playlist when it is opened, without identifying, until it expires or is revoked.
+// @Tags shares
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Smart playlist ID"
+// @Param expires_in query string false "How long the link stays valid, e.g. 72h, the configured default when empty"
+// @Success 201 {object} dto.ShareResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid smart playlist id or expires_in parameter"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "smart playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /smart-playlists/{id}/share [post]
+func (h *ShareHandler) SharePlaylist(w http.ResponseWriter, r *http.Request) {
+	const op = "ShareHandler.SharePlaylist"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	playlistID, ok := playlistIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	ttl, ok := expiresInParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	share, token, err := h.Service.SharePlaylist(r.Context(), userID, playlistID, ttl)
+	if err != nil {
+		respondError(w, r, log, "failed to share smart playlist", err)
+		return
+	}
+
+	log.Info("smart playlist successfully shared", slog.String("share_id", share.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, dto.ShareToResponse(share, token, h.url(r, token)))
+}
+
+// @Summary Get all shares
+// @Description Get the links issued by the current user in the library, newest first, revoked and expired ones included
+// @Tags shares
+// @Produce  json,xml,application/yaml
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} dto.ShareResponse
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /shares [get]
+func (h *ShareHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "ShareHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	shares, err := h.Service.GetAll(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch shares", err)
+		return
+	}
+
+	sharesResponse := make([]*dto.ShareResponse, 0, len(shares))
+	for _, share := range shares {
+		token := h.Service.Token(share.ID)
+		sharesResponse = append(sharesResponse, dto.ShareToResponse(share, token, h.url(r, token)))
+	}
+
+	log.Info("shares successfully fetched", slog.Int("count", len(sharesResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, sharesResponse)
+}
+
+// @Summary Revoke a share
+// @Description Stop a link of the current user from being opened. The share is kept so it can still be listed.
+// @Tags shares
+// @Produce  json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Share ID"
+// @Success 200 {object} dto.ShareResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid share id"
+// @Failure 401 {object} dto.ErrorResponse "user is not identified"
+// @Failure 404 {object} dto.ErrorResponse "share not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /shares/{id} [delete]
+func (h *ShareHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	const op = "ShareHandler.Revoke"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := requireUser(w, r, log)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid share id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid share id", nil)
+		return
+	}
+
+	share, err := h.Service.Revoke(r.Context(), userID, id)
+	if err != nil {
+		respondError(w, r, log, "failed to revoke share", err)
+		return
+	}
+
+	log.Info("share successfully revoked", slog.String("share_id", id.String()))
+	token := h.Service.Token(share.ID)
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.ShareToResponse(share, token, h.url(r, token)))
+}
+
+// @Summary Open a share link
+// @Description Get the song or the smart playlist with a page of its songs a link was issued for. No user or API key is needed, the token grants read access until the link expires or is revoked.
+// @Tags shares
+// @Produce  json,xml,application/yaml
+// @Param token path string true "Share token"
+// @Param page query int false "Page number of the playlist songs"
+// @Param page_size query int false "Number of playlist songs per page"
+// @Success 200 {object} dto.SharedResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 404 {object} dto.ErrorResponse "share not found"
+// @Failure 410 {object} dto.ErrorResponse "share is revoked or expired"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /shared/{token} [get]
+func (h *ShareHandler) Open(w http.ResponseWriter, r *http.Request) {
+	const op = "ShareHandler.Open"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	content, err := h.Service.Open(r.Context(), chi.URLParam(r, "token"), page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to open share", err)
+		return
+	}
+
+	response := &dto.SharedResponse{ExpiresAt: content.Share.ExpiresAt}
+	if content.Song != nil {
+		response.Song = songToResponse(content.Song)
+	}
+	if content.Playlist != nil {
+		songs := make([]dto.SongResponse, 0, len(content.Songs))
+		for _, song := range content.Songs {
+			songs = append(songs, *songToResponse(song))
+		}
+		response.Playlist = &dto.SharedPlaylistResponse{Name: content.Playlist.Name, Songs: songs}
+	}
+
+	log.Info("share successfully opened", slog.String("share_id", content.Share.ID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, response)
+}
+
+// url is the address a share link is opened at
+func (h *ShareHandler) url(r *http.Request, token string) string {
+	return baseURL(h.BaseURL, r) + "/shared/" + token
+}
+
+// expiresInParam reads how long a share link stays valid, zero when the
+// parameter is empty
+func expiresInParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (time.Duration, bool) {
+	raw := r.URL.Query().Get("expires_in")
+	if raw == "" {
+		return 0, true
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		log.Warn("invalid expires_in parameter", slog.String("expires_in", raw))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid expires_in parameter", nil)
+		return 0, false
+	}
+	return ttl, true
+}
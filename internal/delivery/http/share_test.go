@@ -0,0 +1,187 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/middleware/user"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newShareRouter(t *testing.T) (http.Handler, *mocks.MockShareService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockShares := mocks.NewMockShareService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewShareHandler(mockShares, "https://songs.example.com/", mockLog))
+	h.Use(user.New(mockLog))
+
+	return h.InitRoutes(), mockShares
+}
+
+func TestShareHandler_ShareSong(t *testing.T) {
+	router, mockShares := newShareRouter(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	share := &domain.Share{ID: uuid.New(), UserID: userID, SongID: &songID, ExpiresAt: time.Now().Add(72 * time.Hour)}
+	mockShares.EXPECT().ShareSong(gomock.Any(), userID, songID, 72*time.Hour).Return(share, "token", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/share?expires_in=72h", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.ShareResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "token", resp.Token)
+	// Ссылка начинается с адреса из настроек
+	assert.Equal(t, "https://songs.example.com/shared/token", resp.URL)
+	require.NotNil(t, resp.SongID)
+	assert.Equal(t, songID.String(), *resp.SongID)
+	assert.Nil(t, resp.PlaylistID)
+}
+
+func TestShareHandler_ShareSong_Invalid(t *testing.T) {
+	router, _ := newShareRouter(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		userID string
+		status int
+	}{
+		{name: "без пользователя", path: "/songs/" + uuid.NewString() + "/share", status: http.StatusUnauthorized},
+		{name: "неверный id", path: "/songs/bad/share", userID: uuid.NewString(), status: http.StatusBadRequest},
+		{name: "неверный срок", path: "/songs/" + uuid.NewString() + "/share?expires_in=week", userID: uuid.NewString(), status: http.StatusBadRequest},
+		{name: "отрицательный срок", path: "/songs/" + uuid.NewString() + "/share?expires_in=-1h", userID: uuid.NewString(), status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.userID != "" {
+				req.Header.Set(user.Header, tt.userID)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestShareHandler_Open_Playlist(t *testing.T) {
+	router, mockShares := newShareRouter(t)
+
+	playlistID := uuid.New()
+	content := &domain.SharedContent{
+		Share:    &domain.Share{ID: uuid.New(), PlaylistID: &playlistID, ExpiresAt: time.Now().Add(time.Hour)},
+		Playlist: &domain.SmartPlaylist{ID: playlistID, Name: "Muse"},
+		Songs:    []*domain.Song{{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}},
+	}
+	mockShares.EXPECT().Open(gomock.Any(), "token", 2, 1).Return(content, nil)
+
+	// Пользователь не нужен, доступ даёт токен
+	req := httptest.NewRequest(http.MethodGet, "/shared/token?page=2&page_size=1", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SharedResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Nil(t, resp.Song)
+	require.NotNil(t, resp.Playlist)
+	assert.Equal(t, "Muse", resp.Playlist.Name)
+	require.Len(t, resp.Playlist.Songs, 1)
+	assert.Equal(t, "Hysteria", resp.Playlist.Songs[0].Name)
+}
+
+func TestShareHandler_Open_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   dto.ErrorCode
+	}{
+		{name: "неизвестный токен", err: domain.ErrShareNotFound, status: http.StatusNotFound, code: dto.CodeShareNotFound},
+		{name: "отозванная или истёкшая ссылка", err: domain.ErrShareExpired, status: http.StatusGone, code: dto.CodeShareExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockShares := newShareRouter(t)
+			mockShares.EXPECT().Open(gomock.Any(), "token", 0, 0).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/shared/token", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.code, resp.Code)
+		})
+	}
+}
+
+func TestShareHandler_GetAll(t *testing.T) {
+	router, mockShares := newShareRouter(t)
+
+	userID, playlistID := uuid.New(), uuid.New()
+	revokedAt := time.Now()
+	share := &domain.Share{ID: uuid.New(), UserID: userID, PlaylistID: &playlistID, RevokedAt: &revokedAt}
+	mockShares.EXPECT().GetAll(gomock.Any(), userID).Return([]*domain.Share{share}, nil)
+	mockShares.EXPECT().Token(share.ID).Return("token")
+
+	req := httptest.NewRequest(http.MethodGet, "/shares", nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.ShareResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "https://songs.example.com/shared/token", resp[0].URL)
+	assert.NotNil(t, resp[0].RevokedAt)
+}
+
+func TestShareHandler_Revoke_NotFound(t *testing.T) {
+	router, mockShares := newShareRouter(t)
+
+	userID, id := uuid.New(), uuid.New()
+	mockShares.EXPECT().Revoke(gomock.Any(), userID, id).Return(nil, domain.ErrShareNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/shares/"+id.String(), nil)
+	req.Header.Set(user.Header, userID.String())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,97 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+	"strconv"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type SimilarService interface {
+	Similar(ctx context.Context, song *domain.SongInfo, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error)
+}
+
+type SimilarHandler struct {
+	Service SimilarService
+	log     *slog.Logger
+}
+
+func NewSimilarHandler(service SimilarService, log *slog.Logger) *SimilarHandler {
+	return &SimilarHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *SimilarHandler) Routes(r chi.Router) {
+	r.Get("/songs/{id}/similar", h.Similar)
+}
+
+// @Summary Get similar songs
+// @Description Get the songs most like a song for "you may also like" lists, most alike first. Songs are compared by the trigram similarity of their lyrics, the group, the genre and shared tags, every song comes with a score between 0 and 1
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Param limit query int false "Number of songs (default 10, at most 50)"
+// @Param exclude_explicit query bool false "Drop the songs flagged explicit, songs of unknown content are kept"
+// @Success 200 {array} dto.SimilarSongResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id, limit or exclude_explicit parameter"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/similar [get]
+func (h *SimilarHandler) Similar(w http.ResponseWriter, r *http.Request) {
+	const op = "SimilarHandler.Similar"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid limit parameter", nil)
+			return
+		}
+	}
+
+	excludeExplicit, ok := excludeExplicitParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	similar, err := h.Service.Similar(r.Context(), &domain.SongInfo{ID: songID}, excludeExplicit, limit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch similar songs", err)
+		return
+	}
+
+	similarResponse := make([]dto.SimilarSongResponse, 0, len(similar))
+	for _, result := range similar {
+		similarResponse = append(similarResponse, dto.SimilarSongResponse{
+			Song:  *songToResponse(result.Song),
+			Score: result.Score,
+		})
+	}
+
+	log.Debug("similar songs successfully fetched", slog.Int("count", len(similarResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, similarResponse)
+}
@@ -0,0 +1,95 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSimilarRouter(t *testing.T) (http.Handler, *mocks.MockSimilarService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSimilar := mocks.NewMockSimilarService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewSimilarHandler(mockSimilar, mockLog))
+
+	return h.InitRoutes(), mockSimilar
+}
+
+func TestSimilarHandler_Similar(t *testing.T) {
+	router, mockSimilar := newSimilarRouter(t)
+
+	songID := uuid.New()
+	similar := &domain.Song{ID: uuid.New(), Name: "Starlight", Group: "Muse"}
+	mockSimilar.EXPECT().Similar(gomock.Any(), &domain.SongInfo{ID: songID}, true, 5).
+		Return([]*domain.SimilarSong{{Song: similar, Score: 0.42}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/similar?limit=5&exclude_explicit=true", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SimilarSongResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, similar.ID.String(), resp[0].Song.ID)
+		assert.Equal(t, 0.42, resp[0].Score)
+	}
+}
+
+func TestSimilarHandler_Similar_Empty(t *testing.T) {
+	router, mockSimilar := newSimilarRouter(t)
+
+	mockSimilar.EXPECT().Similar(gomock.Any(), gomock.Any(), false, 0).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/similar", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+}
+
+func TestSimilarHandler_Similar_Errors(t *testing.T) {
+	router, mockSimilar := newSimilarRouter(t)
+
+	for _, path := range []string{
+		"/songs/not-a-uuid/similar",
+		"/songs/" + uuid.NewString() + "/similar?limit=0",
+		"/songs/" + uuid.NewString() + "/similar?limit=many",
+		"/songs/" + uuid.NewString() + "/similar?exclude_explicit=maybe",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+
+	mockSimilar.EXPECT().Similar(gomock.Any(), gomock.Any(), false, 0).Return(nil, domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+uuid.NewString()+"/similar", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
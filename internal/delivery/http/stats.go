@@ -0,0 +1,79 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type StatsService interface {
+	Get(ctx context.Context) (*domain.LibraryStats, error)
+}
+
+type StatsHandler struct {
+	Service StatsService
+	log     *slog.Logger
+}
+
+func NewStatsHandler(service StatsService, log *slog.Logger) *StatsHandler {
+	return &StatsHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *StatsHandler) Routes(r chi.Router) {
+	r.Get("/stats", h.Get)
+}
+
+// @Summary Get library statistics
+// @Description Get the number of songs and groups, the average text length, songs missing text or link and songs added per day and week (UTC, weeks start on Monday)
+// @Tags stats
+// @Produce  json,xml,application/yaml
+// @Success 200 {object} dto.LibraryStatsResponse
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /stats [get]
+func (h *StatsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "StatsHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stats, err := h.Service.Get(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch library stats", err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.LibraryStatsResponse{
+		Songs:         stats.Songs,
+		Groups:        stats.Groups,
+		AvgTextLength: math.Round(stats.AvgTextLength*10) / 10,
+		MissingText:   stats.MissingText,
+		MissingLink:   stats.MissingLink,
+		AddedPerDay:   dateCountsToResponse(stats.AddedPerDay),
+		AddedPerWeek:  dateCountsToResponse(stats.AddedPerWeek),
+	})
+}
+
+func dateCountsToResponse(counts []domain.DateCount) []dto.DateCountResponse {
+	response := make([]dto.DateCountResponse, 0, len(counts))
+	for _, count := range counts {
+		response = append(response, dto.DateCountResponse{
+			Date:  count.Date.Format(time.DateOnly),
+			Songs: count.Songs,
+		})
+	}
+	return response
+}
@@ -0,0 +1,80 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStatsRouter(t *testing.T) (http.Handler, *mocks.MockStatsService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockStats := mocks.NewMockStatsService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewStatsHandler(mockStats, mockLog))
+
+	return h.InitRoutes(), mockStats
+}
+
+func TestStatsHandler_Get(t *testing.T) {
+	router, mockStats := newStatsRouter(t)
+
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	mockStats.EXPECT().Get(gomock.Any()).Return(&domain.LibraryStats{
+		Songs:         10,
+		Groups:        4,
+		AvgTextLength: 1234.5678,
+		MissingText:   1,
+		MissingLink:   3,
+		AddedPerDay:   []domain.DateCount{{Date: monday, Songs: 2}},
+		AddedPerWeek:  []domain.DateCount{{Date: monday, Songs: 5}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Даты отдаются без времени, средняя длина округляется до десятых
+	var resp dto.LibraryStatsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.LibraryStatsResponse{
+		Songs:         10,
+		Groups:        4,
+		AvgTextLength: 1234.6,
+		MissingText:   1,
+		MissingLink:   3,
+		AddedPerDay:   []dto.DateCountResponse{{Date: "2024-05-06", Songs: 2}},
+		AddedPerWeek:  []dto.DateCountResponse{{Date: "2024-05-06", Songs: 5}},
+	}, resp)
+}
+
+func TestStatsHandler_Get_ServiceError(t *testing.T) {
+	router, mockStats := newStatsRouter(t)
+
+	mockStats.EXPECT().Get(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,95 @@
+package deliveryHttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// maxSuggestQueryLength caps the length of a suggestion query in characters
+const maxSuggestQueryLength = 100
+
+type SuggestService interface {
+	Suggest(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error)
+}
+
+type SuggestHandler struct {
+	Service SuggestService
+	log     *slog.Logger
+}
+
+func NewSuggestHandler(service SuggestService, log *slog.Logger) *SuggestHandler {
+	return &SuggestHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *SuggestHandler) Routes(r chi.Router) {
+	r.Get("/songs/suggest", h.Suggest)
+}
+
+// @Summary Suggest song and group names
+// @Description Get song and group names starting with the query or similar to it for typeahead, prefix matches first
+// @Tags songs
+// @Produce  json,xml,application/yaml
+// @Param q query string true "Typed text, at most 100 characters"
+// @Param limit query int false "Number of suggestions (defaults to the configured limit, larger limits are cut to the configured maximum)"
+// @Success 200 {array} dto.SuggestionResponse
+// @Failure 400 {object} dto.ErrorResponse "missing q or invalid limit parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/suggest [get]
+func (h *SuggestHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	const op = "SuggestHandler.Suggest"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	query := r.URL.Query().Get("q")
+	if query == "" || utf8.RuneCountInString(query) > maxSuggestQueryLength {
+		log.Warn("invalid q parameter", slog.String("q", query))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "q is required and must be at most 100 characters", nil)
+		return
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			respondBadRequest(w, r, dto.CodeValidationFailed, "invalid limit parameter", nil)
+			return
+		}
+	}
+
+	suggestions, err := h.Service.Suggest(r.Context(), query, limit)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch suggestions", err)
+		return
+	}
+
+	suggestionsResponse := make([]dto.SuggestionResponse, 0, len(suggestions))
+	for _, s := range suggestions {
+		suggestionsResponse = append(suggestionsResponse, dto.SuggestionResponse{
+			Kind:  s.Kind,
+			Text:  s.Text,
+			Group: s.Group,
+			Score: s.Score,
+		})
+	}
+
+	log.Debug("suggestions successfully fetched", slog.Int("count", len(suggestionsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, suggestionsResponse)
+}
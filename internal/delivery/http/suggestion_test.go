@@ -0,0 +1,82 @@
+package deliveryHttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSuggestRouter(t *testing.T) (http.Handler, *mocks.MockSuggestService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSuggest := mocks.NewMockSuggestService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewSuggestHandler(mockSuggest, mockLog))
+
+	return h.InitRoutes(), mockSuggest
+}
+
+func TestSuggestHandler_Suggest(t *testing.T) {
+	router, mockSuggest := newSuggestRouter(t)
+
+	// Маршрут не перехватывается /songs/{id}
+	mockSuggest.EXPECT().Suggest(gomock.Any(), "hy", 5).Return([]*domain.Suggestion{
+		{Kind: domain.SuggestionSong, Text: "Hysteria", Group: "Muse", Score: 1},
+		{Kind: domain.SuggestionGroup, Text: "Hypocrisy", Score: 0.4},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/suggest?q=hy&limit=5", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.SuggestionResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, []dto.SuggestionResponse{
+		{Kind: "song", Text: "Hysteria", Group: "Muse", Score: 1},
+		{Kind: "group", Text: "Hypocrisy", Score: 0.4},
+	}, resp)
+}
+
+func TestSuggestHandler_Suggest_InvalidParams(t *testing.T) {
+	router, _ := newSuggestRouter(t)
+
+	for _, query := range []string{"", "q=", "q=hy&limit=0", "q=hy&limit=many", "q=" + strings.Repeat("a", 101)} {
+		req := httptest.NewRequest(http.MethodGet, "/songs/suggest?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestSuggestHandler_Suggest_ServiceError(t *testing.T) {
+	router, mockSuggest := newSuggestRouter(t)
+
+	mockSuggest.EXPECT().Suggest(gomock.Any(), "hy", 0).Return(nil, errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/suggest?q=hy", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,211 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type TagService interface {
+	Add(ctx context.Context, songID uuid.UUID, tags []string) ([]string, error)
+	Remove(ctx context.Context, songID uuid.UUID, tag string) ([]string, error)
+	GetSongTags(ctx context.Context, songID uuid.UUID) ([]string, error)
+	GetAll(ctx context.Context, page, pageSize int) ([]*domain.Tag, error)
+}
+
+type TagHandler struct {
+	Service TagService
+	log     *slog.Logger
+}
+
+func NewTagHandler(service TagService, log *slog.Logger) *TagHandler {
+	return &TagHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *TagHandler) Routes(r chi.Router) {
+	r.Get("/songs/{id}/tags", h.GetSongTags)
+	r.Post("/songs/{id}/tags", h.Add)
+	r.Delete("/songs/{id}/tags/{tag}", h.Remove)
+	r.Get("/tags", h.GetAll)
+}
+
+// @Summary Get the tags of a song
+// @Description Get the tags of the song in alphabetical order
+// @Tags tags
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Song ID"
+// @Success 200 {object} dto.SongTagsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/tags [get]
+func (h *TagHandler) GetSongTags(w http.ResponseWriter, r *http.Request) {
+	const op = "TagHandler.GetSongTags"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	tags, err := h.Service.GetSongTags(r.Context(), songID)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch song tags", err)
+		return
+	}
+
+	log.Info("song tags successfully fetched", slog.Int("count", len(tags)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, songTagsToResponse(songID, tags))
+}
+
+// @Summary Add tags to a song
+// @Description Attach tags to the song. Tags are lower-cased, tags the song already has are skipped.
+// @Tags tags
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param tags body dto.TagsRequest true "Tags to add"
+// @Success 200 {object} dto.SongTagsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or tags"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/tags [post]
+func (h *TagHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "TagHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	var req dto.TagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	if len(req.Tags) == 0 {
+		log.Info("tags are missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "tags are required", nil)
+		return
+	}
+
+	tags, err := h.Service.Add(r.Context(), songID, req.Tags)
+	if err != nil {
+		respondError(w, r, log, "failed to add tags", err)
+		return
+	}
+
+	log.Info("tags successfully added", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, songTagsToResponse(songID, tags))
+}
+
+// @Summary Remove a tag from a song
+// @Description Detach the tag from the song, removing a tag the song doesn't have is a no-op
+// @Tags tags
+// @Produce  json
+// @Param id path string true "Song ID"
+// @Param tag path string true "Tag"
+// @Success 200 {object} dto.SongTagsResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid song id or tag"
+// @Failure 404 {object} dto.ErrorResponse "song not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /songs/{id}/tags/{tag} [delete]
+func (h *TagHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	const op = "TagHandler.Remove"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	songID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid song id", nil)
+		return
+	}
+
+	tags, err := h.Service.Remove(r.Context(), songID, chi.URLParam(r, "tag"))
+	if err != nil {
+		respondError(w, r, log, "failed to remove tag", err)
+		return
+	}
+
+	log.Info("tag successfully removed", slog.String("song_id", songID.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, songTagsToResponse(songID, tags))
+}
+
+// @Summary Get tags
+// @Description Get the tags with the number of songs they are attached to, most used first
+// @Tags tags
+// @Produce  json,xml,application/yaml
+// @Param page query int false "Page number"
+// @Param page_size query int false "Number of tags per page"
+// @Success 200 {array} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid page or page_size parameter"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /tags [get]
+func (h *TagHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "TagHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, pageSize, ok := paginationParams(w, r, log)
+	if !ok {
+		return
+	}
+
+	tags, err := h.Service.GetAll(r.Context(), page, pageSize)
+	if err != nil {
+		respondError(w, r, log, "failed to fetch tags", err)
+		return
+	}
+
+	tagsResponse := make([]dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagsResponse = append(tagsResponse, dto.TagResponse{Name: tag.Name, Songs: tag.Songs})
+	}
+
+	log.Info("tags successfully fetched", slog.Int("count", len(tagsResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, tagsResponse)
+}
+
+func songTagsToResponse(songID uuid.UUID, tags []string) dto.SongTagsResponse {
+	if tags == nil {
+		tags = []string{}
+	}
+	return dto.SongTagsResponse{SongID: songID.String(), Tags: tags}
+}
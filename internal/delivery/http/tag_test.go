@@ -0,0 +1,177 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTagRouter(t *testing.T) (http.Handler, *mocks.MockTagService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockTags := mocks.NewMockTagService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mocks.NewMockService(ctrl), mockLog)
+	h.Register(handler.NewTagHandler(mockTags, mockLog))
+
+	return h.InitRoutes(), mockTags
+}
+
+func TestTagHandler_Add(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().Add(gomock.Any(), songID, []string{"rock", "live"}).Return([]string{"live", "rock"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/tags", strings.NewReader(`{"tags":["rock","live"]}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SongTagsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, songID.String(), resp.SongID)
+	assert.Equal(t, []string{"live", "rock"}, resp.Tags)
+}
+
+func TestTagHandler_Add_InvalidTag(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().Add(gomock.Any(), songID, []string{"a,b"}).Return(nil, domain.ErrInvalidTag)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+songID.String()+"/tags", strings.NewReader(`{"tags":["a,b"]}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeValidationFailed, resp.Code)
+}
+
+func TestTagHandler_Add_MissingTags(t *testing.T) {
+	router, _ := newTagRouter(t)
+
+	// Пустой список тегов отклоняется без обращения к сервису
+	req := httptest.NewRequest(http.MethodPost, "/songs/"+uuid.NewString()+"/tags", strings.NewReader(`{"tags":[]}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTagHandler_Remove(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().Remove(gomock.Any(), songID, "live").Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/songs/"+songID.String()+"/tags/live", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Пустой список тегов отдаётся массивом, а не null
+	assert.JSONEq(t, `{"song_id":"`+songID.String()+`","tags":[]}`, rec.Body.String())
+}
+
+func TestTagHandler_GetSongTags_NotFound(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	songID := uuid.New()
+	mockTags.EXPECT().GetSongTags(gomock.Any(), songID).Return(nil, domain.ErrSongNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/"+songID.String()+"/tags", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestTagHandler_GetAll(t *testing.T) {
+	router, mockTags := newTagRouter(t)
+
+	mockTags.EXPECT().GetAll(gomock.Any(), 2, 5).Return([]*domain.Tag{{Name: "rock", Songs: 4}, {Name: "live", Songs: 1}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tags?page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.TagResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, []dto.TagResponse{{Name: "rock", Songs: 4}, {Name: "live", Songs: 1}}, resp)
+}
+
+func TestHandler_GetAllWithFilter_Tags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockService.EXPECT().LastModified(gomock.Any()).Return(time.Now(), nil).AnyTimes()
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	mockService.EXPECT().
+		GetAllWithFilter(gomock.Any(), gomock.Any(), 0, 0).
+		DoAndReturn(func(_ context.Context, filter *domain.SongFilter, _, _ int) ([]*domain.Song, error) {
+			// Теги из запроса нормализуются и передаются в фильтр вместе с режимом
+			assert.Equal(t, []string{"rock", "live"}, filter.Tags)
+			assert.Equal(t, domain.TagModeAny, filter.TagMode)
+			return nil, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/songs?tags=Rock,%20live&tags_mode=any", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAllWithFilter(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_GetAllWithFilter_InvalidTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewHandler(mockService, mockLog)
+
+	for _, query := range []string{"tags=rock,,live", "tags=rock&tags_mode=some"} {
+		req := httptest.NewRequest(http.MethodGet, "/songs?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		h.GetAllWithFilter(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -0,0 +1,136 @@
+package deliveryHttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// @Summary Get users with a role
+// @Description Get the users with a role of their own, the others get the default role
+// @Tags admin
+// @Produce  json,xml,application/yaml
+// @Security AdminToken
+// @Success 200 {array} dto.UserResponse
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/users [get]
+func (h *AdminHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.GetUsers"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	users, err := h.UserService.GetAll(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch users", err)
+		return
+	}
+
+	usersResponse := make([]*dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		usersResponse = append(usersResponse, dto.UserToResponse(user))
+	}
+
+	log.Info("users successfully fetched", slog.Int("count", len(usersResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, usersResponse)
+}
+
+// @Summary Set the role of a user
+// @Description Assign the viewer, editor or admin role to the user with the ID sent in X-User-ID
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Param user body dto.UserRequest true "Set role request"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request, invalid user id or unknown role"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/users/{id} [put]
+func (h *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.SetUserRole"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := userIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	var req dto.UserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return
+	}
+
+	user := &domain.User{ID: id, Role: domain.Role(req.Role)}
+	if err := h.UserService.SetRole(r.Context(), user); err != nil {
+		respondError(w, r, log, "failed to set user role", err)
+		return
+	}
+
+	log.Info("user role successfully set", slog.String("user_id", id.String()), slog.String("role", req.Role))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.UserToResponse(user))
+}
+
+// @Summary Delete the role of a user
+// @Description Delete the role of a user, the user gets the default role
+// @Tags admin
+// @Produce  json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.MessageResponse "user deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid user id"
+// @Failure 401 {object} dto.ErrorResponse "admin token is missing or invalid"
+// @Failure 404 {object} dto.ErrorResponse "user not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	const op = "AdminHandler.DeleteUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := userIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.UserService.Delete(r.Context(), id); err != nil {
+		respondError(w, r, log, "failed to delete user", err)
+		return
+	}
+
+	log.Info("user successfully deleted", slog.String("user_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("user deleted successfully"))
+}
+
+func userIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Info("invalid user id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid user id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,260 @@
+package deliveryHttp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	Add(ctx context.Context, hook *domain.Webhook) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	GetAll(ctx context.Context) ([]*domain.Webhook, error)
+	Update(ctx context.Context, hook *domain.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookHandler struct {
+	Service WebhookService
+	log     *slog.Logger
+}
+
+func NewWebhookHandler(service WebhookService, log *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		Service: service,
+		log:     log,
+	}
+}
+
+func (h *WebhookHandler) Routes(r chi.Router) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", h.Add)
+		r.Get("/", h.GetAll)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+	})
+}
+
+// @Summary Register a webhook
+// @Description Register a URL notified about song changes. Payloads are signed with HMAC-SHA256 of the secret in the X-Webhook-Signature header, the secret is generated when omitted and only returned here. Empty events subscribe to all of song.created, song.updated and song.deleted.
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Param webhook body dto.WebhookRequest true "Add webhook request"
+// @Success 201 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /webhooks [post]
+func (h *WebhookHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.Add"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	hook, ok := decodeWebhookRequest(w, r, log)
+	if !ok {
+		return
+	}
+
+	if hook.URL == "" {
+		log.Info("url is missing in request")
+		respondBadRequest(w, r, dto.CodeValidationFailed, "url is required", nil)
+		return
+	}
+
+	if err := h.Service.Add(r.Context(), hook); err != nil {
+		respondError(w, r, log, "failed to add webhook", err)
+		return
+	}
+
+	response := dto.WebhookToResponse(hook)
+	response.Secret = hook.Secret
+
+	log.Info("webhook successfully added", slog.String("webhook_id", hook.ID.String()))
+	render.Status(r, http.StatusCreated)
+	respond(w, r, response)
+}
+
+// @Summary Get a webhook
+// @Description Get webhook by ID
+// @Tags webhooks
+// @Produce  json,xml,application/yaml
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid webhook id"
+// @Failure 404 {object} dto.ErrorResponse "webhook not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := webhookIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	hook, err := h.Service.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, r, log, "failed to get webhook", err)
+		return
+	}
+
+	log.Info("webhook successfully fetched", slog.String("webhook_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.WebhookToResponse(hook))
+}
+
+// @Summary Get all webhooks
+// @Description Get all registered webhooks
+// @Tags webhooks
+// @Produce  json,xml,application/yaml
+// @Success 200 {array} dto.WebhookResponse
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.GetAll"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	hooks, err := h.Service.GetAll(r.Context())
+	if err != nil {
+		respondError(w, r, log, "failed to fetch webhooks", err)
+		return
+	}
+
+	hooksResponse := make([]*dto.WebhookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		hooksResponse = append(hooksResponse, dto.WebhookToResponse(hook))
+	}
+
+	log.Info("webhooks successfully fetched", slog.Int("count", len(hooksResponse)))
+	render.Status(r, http.StatusOK)
+	respond(w, r, hooksResponse)
+}
+
+// @Summary Update a webhook
+// @Description Update a webhook by ID, omitted fields keep their values
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Param webhook body dto.WebhookRequest true "Update webhook request"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ErrorResponse "invalid request or invalid webhook id"
+// @Failure 404 {object} dto.ErrorResponse "webhook not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := webhookIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	hook, ok := decodeWebhookRequest(w, r, log)
+	if !ok {
+		return
+	}
+	hook.ID = id
+
+	if err := h.Service.Update(r.Context(), hook); err != nil {
+		respondError(w, r, log, "failed to update webhook", err)
+		return
+	}
+
+	log.Info("webhook successfully updated", slog.String("webhook_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, dto.WebhookToResponse(hook))
+}
+
+// @Summary Delete a webhook
+// @Description Delete a webhook by ID
+// @Tags webhooks
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.MessageResponse "webhook deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "invalid webhook id"
+// @Failure 404 {object} dto.ErrorResponse "webhook not found"
+// @Failure 500 {object} dto.ErrorResponse "internal error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, ok := webhookIDParam(w, r, log)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), id); err != nil {
+		respondError(w, r, log, "failed to delete webhook", err)
+		return
+	}
+
+	log.Info("webhook successfully deleted", slog.String("webhook_id", id.String()))
+	render.Status(r, http.StatusOK)
+	respond(w, r, OkResp("webhook deleted successfully"))
+}
+
+func webhookIDParam(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Error("invalid webhook id", sl.Err(err))
+		respondBadRequest(w, r, dto.CodeValidationFailed, "invalid webhook id", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func decodeWebhookRequest(w http.ResponseWriter, r *http.Request, log *slog.Logger) (*domain.Webhook, bool) {
+	var req dto.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondDecodeError(w, r, log, err)
+		return nil, false
+	}
+
+	hook := &domain.Webhook{
+		URL:    req.URL,
+		Secret: req.Secret,
+	}
+
+	// A missing events list keeps the current subscription on update
+	if req.Events != nil {
+		hook.Events = make([]domain.SongEventType, 0, len(req.Events))
+		for _, event := range req.Events {
+			hook.Events = append(hook.Events, domain.SongEventType(event))
+		}
+	}
+
+	return hook, true
+}
@@ -0,0 +1,88 @@
+package deliveryHttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	handler "songLibrary/internal/delivery/http"
+	"songLibrary/internal/delivery/http/mocks"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookHandler_Add_ReturnsSecretOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	r := chi.NewRouter()
+	handler.NewWebhookHandler(mockService, mockLog).Routes(r)
+
+	hookID := uuid.New()
+	mockService.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, hook *domain.Webhook) error {
+			assert.Equal(t, []domain.SongEventType{domain.SongCreated}, hook.Events)
+			hook.ID = hookID
+			hook.Secret = "generated"
+			return nil
+		},
+	)
+	mockService.EXPECT().Get(gomock.Any(), hookID).Return(&domain.Webhook{ID: hookID, Secret: "generated"}, nil)
+
+	body := `{"url": "https://example.com/hooks", "events": ["song.created"]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dto.WebhookResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+	assert.Equal(t, "generated", created.Secret)
+
+	// Секрет не возвращается при последующих запросах
+	req = httptest.NewRequest(http.MethodGet, "/webhooks/"+hookID.String(), nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var fetched dto.WebhookResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&fetched))
+	assert.Empty(t, fetched.Secret)
+}
+
+func TestWebhookHandler_Add_InvalidURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookService(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	h := handler.NewWebhookHandler(mockService, mockLog)
+
+	mockService.EXPECT().Add(gomock.Any(), gomock.Any()).Return(domain.ErrWebhookURLIsInvalid)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url": "example.com"}`))
+	rec := httptest.NewRecorder()
+
+	h.Add(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp dto.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, dto.CodeValidationFailed, resp.Code)
+}
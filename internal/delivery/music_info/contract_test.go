@@ -0,0 +1,155 @@
+package musicapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/musicinfomock"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Контрактные тесты: ответы внешнего API по схеме SongResponse версии 1
+func TestMusicInfo_Contract(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *domain.Song
+		err  error
+	}{
+		{
+			name: "full response",
+			body: `{"name": "Hysteria", "group": "Muse", "text": "It's bugging me...", "link": "https://link-to-song.com",
+				"releaseDate": "01.12.2003", "duration_ms": 227440, "genre": "Alternative Rock", "track_number": 8,
+				"album": "Absolution"}`,
+			want: &domain.Song{
+				Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", Link: "https://link-to-song.com",
+				ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC), Duration: 227440 * time.Millisecond,
+				Genre: "Alternative Rock", TrackNumber: 8, Album: "Absolution",
+			},
+		},
+		{
+			name: "explicit version and unknown fields",
+			body: `{"version": 1, "name": "Hysteria", "group": "Muse", "text": "It's bugging me...", "lyricist": "Matt Bellamy"}`,
+			want: &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."},
+		},
+		{
+			name: "invalid release date",
+			body: `{"name": "Hysteria", "group": "Muse", "text": "It's bugging me...", "releaseDate": "December 1, 2003"}`,
+			err:  domain.ErrInvalidReleaseDate,
+		},
+		{
+			name: "missing text",
+			body: `{"name": "Hysteria", "group": "Muse", "releaseDate": "01.12.2003"}`,
+			err:  domain.ErrInvalidSongText,
+		},
+		{
+			name: "missing group",
+			body: `{"name": "Hysteria", "text": "It's bugging me..."}`,
+			err:  domain.ErrInvalidSongGroup,
+		},
+		{
+			name: "negative duration",
+			body: `{"name": "Hysteria", "group": "Muse", "text": "It's bugging me...", "duration_ms": -1}`,
+			err:  domain.ErrMusicInfoMalformed,
+		},
+		{
+			name: "unsupported version",
+			body: `{"version": 2, "name": "Hysteria", "group": "Muse", "text": "It's bugging me..."}`,
+			err:  domain.ErrMusicInfoMalformed,
+		},
+		{
+			name: "wrong field type",
+			body: `{"name": "Hysteria", "group": "Muse", "text": "It's bugging me...", "track_number": "8"}`,
+			err:  domain.ErrMusicInfoMalformed,
+		},
+		{
+			name: "not json",
+			body: `<html>oops</html>`,
+			err:  domain.ErrMusicInfoMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+			song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+			if tt.err != nil {
+				// Любое нарушение схемы — это ErrMusicInfoMalformed
+				assert.ErrorIs(t, err, tt.err)
+				assert.ErrorIs(t, err, domain.ErrMusicInfoMalformed)
+				assert.Nil(t, song)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, song)
+		})
+	}
+}
+
+func TestMusicInfo_Contract_BadRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	_, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.ErrorIs(t, err, domain.ErrMusicInfoBadRequest)
+
+	var httpErr *domain.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.Equal(t, "Bad request", httpErr.Message)
+}
+
+func TestMusicInfo_Contract_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Ошибка сервера не означает, что песня неизвестна
+	_, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrMusicInfoBadRequest)
+	assert.NotErrorIs(t, err, domain.ErrMusicInfoMalformed)
+}
+
+func TestMusicInfo_Contract_MockServer(t *testing.T) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	// Мок стороннего API соблюдает ту же схему, дата релиза не теряется
+	mock := musicinfomock.New(musicinfomock.Config{Seed: 1}, log)
+	mock.Now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, log)
+
+	for range 5 {
+		song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+		require.NoError(t, err)
+		assert.False(t, song.ReleaseDate.IsZero())
+		assert.True(t, song.ReleaseDate.Before(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)))
+	}
+}
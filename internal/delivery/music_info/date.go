@@ -0,0 +1,43 @@
+package musicapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultReleaseDateLayouts are the release date formats tried when none are
+// configured. The format of the MusicInfo API comes first, "2.1.2006" also
+// accepts "02.01.2006".
+var DefaultReleaseDateLayouts = []string{"2.1.2006", "2006-01-02", time.RFC3339, "2006"}
+
+// DateParser parses release dates in the first of Layouts that matches
+type DateParser struct {
+	Layouts []string
+}
+
+// NewDateParser creates a DateParser, no layouts selects
+// DefaultReleaseDateLayouts
+func NewDateParser(layouts ...string) *DateParser {
+	if len(layouts) == 0 {
+		layouts = DefaultReleaseDateLayouts
+	}
+	return &DateParser{Layouts: layouts}
+}
+
+// Parse returns the day of value at midnight UTC, the time of day and zone of
+// layouts with a time are dropped
+func (p *DateParser) Parse(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range p.Layouts {
+		parsed, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		year, month, day := parsed.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Time{}, fmt.Errorf("date %q matches none of the layouts %q", value, p.Layouts)
+}
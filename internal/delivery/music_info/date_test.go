@@ -0,0 +1,95 @@
+package musicapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/musicinfomock"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateParser_Parse(t *testing.T) {
+	parser := NewDateParser()
+	want := time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"01.12.2003",
+		"1.12.2003",
+		" 01.12.2003 ",
+		"2003-12-01",
+		"2003-12-01T00:00:00Z",
+		// Время и часовой пояс отбрасываются, остаётся день релиза
+		"2003-12-01T23:30:00-05:00",
+	} {
+		got, err := parser.Parse(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	// Только год — первое января
+	got, err := parser.Parse("2003")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC), got)
+
+	for _, value := range []string{"", "32.12.2003", "12/01/2003", "December 1, 2003"} {
+		_, err := parser.Parse(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDateParser_Layouts(t *testing.T) {
+	// Заданные форматы заменяют форматы по умолчанию
+	parser := NewDateParser("01/02/2006")
+
+	got, err := parser.Parse("12/01/2003")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = parser.Parse("01.12.2003")
+	assert.Error(t, err)
+}
+
+func TestMusicInfo_FetchMusicInfo_ReleaseDateLayouts(t *testing.T) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	fetch := func(layout string, opts ClientOptions) (time.Time, error) {
+		// С одним seed мок генерирует одну и ту же дату в любом формате
+		mock := musicinfomock.New(musicinfomock.Config{Seed: 7, ReleaseDateLayout: layout}, log)
+		mock.Now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+		server := httptest.NewServer(mock)
+		defer server.Close()
+
+		api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), opts, log)
+		song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+		if err != nil {
+			return time.Time{}, err
+		}
+		return song.ReleaseDate, nil
+	}
+
+	want, err := fetch(musicinfomock.ReleaseDateLayout, ClientOptions{})
+	require.NoError(t, err)
+	assert.False(t, want.IsZero())
+
+	for _, layout := range []string{"2.1.2006", "2006-01-02", time.RFC3339} {
+		got, err := fetch(layout, ClientOptions{})
+		require.NoError(t, err, layout)
+		assert.Equal(t, want, got, layout)
+	}
+
+	// Формат, которого нет в настройках клиента, не принимается
+	_, err = fetch("01/02/2006", ClientOptions{})
+	assert.ErrorIs(t, err, domain.ErrInvalidReleaseDate)
+
+	got, err := fetch("01/02/2006", ClientOptions{ReleaseDateLayouts: []string{"01/02/2006"}})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
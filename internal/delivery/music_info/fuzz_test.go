@@ -0,0 +1,61 @@
+package musicapi
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+)
+
+func FuzzDateParser_Parse(f *testing.F) {
+	for _, value := range []string{"01.12.2003", "1.12.2003", "2003-12-01", "2003-12-01T23:30:00-05:00", "2003", "", "32.12.2003", "-1.-1.-1"} {
+		f.Add(value)
+	}
+
+	parser := NewDateParser()
+	f.Fuzz(func(t *testing.T, value string) {
+		got, err := parser.Parse(value)
+		if err != nil {
+			return
+		}
+		// Разобранная дата всегда полночь UTC
+		if h, m, s := got.Clock(); got.Location() != time.UTC || h != 0 || m != 0 || s != 0 || got.Nanosecond() != 0 {
+			t.Errorf("Parse(%q) = %v, want midnight UTC", value, got)
+		}
+	})
+}
+
+func FuzzConvertResponseToSong(f *testing.F) {
+	f.Add(`{"name":"Hysteria","group":"Muse","text":"It's bugging me","link":"https://example.com","releaseDate":"01.12.2003"}`)
+	f.Add(`{"version":1,"name":"Hysteria","group":"Muse","text":"x","duration_ms":227000,"track_number":8,"explicit":true}`)
+	f.Add(`{"name":"Hysteria","group":"Muse","text":"x","duration_ms":9223372036854775807}`)
+	f.Add(`{"name":"","group":"Muse"}`)
+	f.Add(`{"releaseDate":"2003-12-01T23:30:00-05:00"}`)
+	f.Add(`[]`)
+
+	dates := NewDateParser()
+	f.Fuzz(func(t *testing.T, body string) {
+		// Ответ разбирается так же, как в FetchMusicInfo
+		var response SongResponse
+		if err := json.NewDecoder(strings.NewReader(body)).Decode(&response); err != nil {
+			return
+		}
+
+		song, err := ConvertResponseToSong(&response, dates)
+		if err != nil {
+			if !errors.Is(err, domain.ErrMusicInfoMalformed) {
+				t.Errorf("error %v doesn't wrap ErrMusicInfoMalformed", err)
+			}
+			return
+		}
+		if song.Name == "" || song.Group == "" || song.Text == "" {
+			t.Errorf("song without name, group or text: %+v", song)
+		}
+		if song.Duration < 0 || song.TrackNumber < 0 {
+			t.Errorf("negative duration or track number: %+v", song)
+		}
+	})
+}
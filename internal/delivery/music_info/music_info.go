@@ -4,15 +4,46 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"songLibrary/internal/domain"
-	"songLibrary/internal/dto"
 	"songLibrary/pkg/logger/sl"
+	"songLibrary/pkg/requestid"
+	"strings"
+	"time"
 )
 
-type SongResponse dto.SongDTO
+// SchemaVersion is the version of the MusicInfo response schema SongResponse
+// implements. A response may state its version in the version field, one
+// without it is taken to be version 1.
+const SchemaVersion = 1
+
+// SongResponse is the response of GET /info. name, group and text are
+// required, releaseDate is optional but must be a valid date when present.
+// The API formats dates as 02.01.2006.
+type SongResponse struct {
+	Version     int    `json:"version,omitempty"`
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+	ReleaseDate string `json:"releaseDate"`
+	DurationMs  int64  `json:"duration_ms,omitempty"`
+	Genre       string `json:"genre,omitempty"`
+	TrackNumber int    `json:"track_number,omitempty"`
+	Album       string `json:"album,omitempty"`
+	Explicit    *bool  `json:"explicit,omitempty"`
+}
+
+// maxErrorMessageSize limits how much of an error response is kept
+const maxErrorMessageSize = 1 << 10
+
+// maxDurationMs is the longest duration time.Duration holds in milliseconds
+const maxDurationMs = math.MaxInt64 / int64(time.Millisecond)
 
 type IMusicInfo interface {
 	FetchMusicInfo(ctx context.Context, name, group string) (*domain.Song, error)
@@ -21,14 +52,46 @@ type IMusicInfo interface {
 type MusicInfo struct {
 	BaseURL string
 	Client  *http.Client
+	Dates   *DateParser
 	log     *slog.Logger
 }
 
-func NewMusicInfo(baseURL string, log *slog.Logger) *MusicInfo {
+// ClientOptions tunes the HTTP client of MusicInfo, zero values keep the
+// net/http defaults
+type ClientOptions struct {
+	// ConnectTimeout limits dialing the external API
+	ConnectTimeout time.Duration
+	// RequestTimeout limits a whole request including reading the body
+	RequestTimeout time.Duration
+	// MaxIdleConns limits the kept-alive connections to the external API
+	MaxIdleConns int
+	// ReleaseDateLayouts are the accepted formats of release dates,
+	// DefaultReleaseDateLayouts when empty
+	ReleaseDateLayouts []string
+}
+
+func NewMusicInfo(baseURL string, opts ClientOptions, log *slog.Logger) *MusicInfo {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.ConnectTimeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   opts.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		transport.TLSHandshakeTimeout = opts.ConnectTimeout
+	}
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	}
+
 	return &MusicInfo{
 		BaseURL: baseURL,
-		Client:  &http.Client{},
-		log:     log,
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.RequestTimeout,
+		},
+		Dates: NewDateParser(opts.ReleaseDateLayouts...),
+		log:   log,
 	}
 }
 
@@ -42,6 +105,7 @@ func (api *MusicInfo) FetchMusicInfo(ctx context.Context, song *domain.SongInfo)
 	// Добавляем логирование начала операции
 	log := api.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("url", url),
 		slog.String("song_name", song.Name),
 		slog.String("group_name", song.Group),
@@ -53,6 +117,9 @@ func (api *MusicInfo) FetchMusicInfo(ctx context.Context, song *domain.SongInfo)
 		log.Error("failed to create request", sl.Err(err))
 		return nil, err
 	}
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 
 	resp, err := api.Client.Do(req)
 	if err != nil {
@@ -62,32 +129,68 @@ func (api *MusicInfo) FetchMusicInfo(ctx context.Context, song *domain.SongInfo)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+		httpErr := &domain.HTTPError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+
+		if resp.StatusCode == http.StatusBadRequest {
+			log.Warn("external API rejected the request", sl.Err(httpErr))
+			return nil, fmt.Errorf("%s: %w: %w", op, domain.ErrMusicInfoBadRequest, httpErr)
+		}
 		log.Error("external API returned non-OK status", slog.Int("status_code", resp.StatusCode))
-		return nil, fmt.Errorf("failed to fetch song details, status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%s: failed to fetch song details: %w", op, httpErr)
 	}
 
 	var songResponse SongResponse
 	if err := json.NewDecoder(resp.Body).Decode(&songResponse); err != nil {
 		log.Error("failed to decode response from external API", sl.Err(err))
-		return nil, err
+		return nil, fmt.Errorf("%s: %w: %w", op, domain.ErrMusicInfoMalformed, err)
+	}
+
+	details, err := ConvertResponseToSong(&songResponse, api.Dates)
+	if err != nil {
+		log.Warn("external API returned invalid song info", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("successfully fetched song info from external API", slog.String("song_name", songResponse.Name), slog.String("group_name", songResponse.Group))
 
-	return MustConvertResponseToSong(&songResponse), nil
+	return details, nil
 }
 
-func ConvertResponseToSong(response *SongResponse) (*domain.Song, error) {
+// ConvertResponseToSong validates a response against the schema and parses
+// its release date with dates, every error wraps domain.ErrMusicInfoMalformed
+func ConvertResponseToSong(response *SongResponse, dates *DateParser) (*domain.Song, error) {
+	if response.Version > SchemaVersion {
+		return nil, fmt.Errorf("%w: unsupported schema version %d", domain.ErrMusicInfoMalformed, response.Version)
+	}
+
 	if response.Name == "" {
-		return nil, domain.ErrInvalidSongName
+		return nil, fmt.Errorf("%w: %w", domain.ErrMusicInfoMalformed, domain.ErrInvalidSongName)
 	}
 
 	if response.Group == "" {
-		return nil, domain.ErrInvalidSongGroup
+		return nil, fmt.Errorf("%w: %w", domain.ErrMusicInfoMalformed, domain.ErrInvalidSongGroup)
 	}
 
 	if response.Text == "" {
-		return nil, domain.ErrInvalidSongText
+		return nil, fmt.Errorf("%w: %w", domain.ErrMusicInfoMalformed, domain.ErrInvalidSongText)
+	}
+
+	if response.DurationMs < 0 || response.TrackNumber < 0 {
+		return nil, fmt.Errorf("%w: negative duration or track number", domain.ErrMusicInfoMalformed)
+	}
+
+	if response.DurationMs > maxDurationMs {
+		return nil, fmt.Errorf("%w: duration %d ms is out of range", domain.ErrMusicInfoMalformed, response.DurationMs)
+	}
+
+	var releaseDate time.Time
+	if response.ReleaseDate != "" {
+		var err error
+		releaseDate, err = dates.Parse(response.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: %w", domain.ErrMusicInfoMalformed, domain.ErrInvalidReleaseDate, err)
+		}
 	}
 
 	song := &domain.Song{
@@ -95,13 +198,13 @@ func ConvertResponseToSong(response *SongResponse) (*domain.Song, error) {
 		Group:       response.Group,
 		Text:        response.Text,
 		Link:        response.Link,
-		ReleaseDate: response.ReleaseDate,
+		ReleaseDate: releaseDate,
+		Duration:    time.Duration(response.DurationMs) * time.Millisecond,
+		Genre:       response.Genre,
+		TrackNumber: response.TrackNumber,
+		Album:       response.Album,
+		Explicit:    response.Explicit,
 	}
 
 	return song, nil
 }
-
-func MustConvertResponseToSong(songResponse *SongResponse) *domain.Song {
-	song, _ := ConvertResponseToSong(songResponse)
-	return song
-}
@@ -0,0 +1,160 @@
+package musicapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/musicinfomock"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"songLibrary/pkg/requestid"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMusicInfo_FetchMusicInfo_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Muse", r.URL.Query().Get("group"))
+		assert.Equal(t, "Hysteria", r.URL.Query().Get("song"))
+		json.NewEncoder(w).Encode(SongResponse{
+			Name:        "Hysteria",
+			Group:       "Muse",
+			Text:        "It's bugging me...",
+			ReleaseDate: "01.12.2003",
+		})
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.NoError(t, err)
+	assert.Equal(t, "It's bugging me...", song.Text)
+	assert.Equal(t, time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC), song.ReleaseDate)
+}
+
+func TestMusicInfo_FetchMusicInfo_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"name": "Hysteria", "group": "Muse", "text": "It's bugging me...",
+			"duration_ms": 227440, "genre": "Alternative Rock", "track_number": 8,
+			"album": "Absolution", "explicit": false}`)
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.NoError(t, err)
+	assert.Equal(t, 227440*time.Millisecond, song.Duration)
+	assert.Equal(t, "Alternative Rock", song.Genre)
+	assert.Equal(t, 8, song.TrackNumber)
+	assert.Equal(t, "Absolution", song.Album)
+	// Явное false отличается от неизвестного значения
+	if assert.NotNil(t, song.Explicit) {
+		assert.False(t, *song.Explicit)
+	}
+}
+
+func TestMusicInfo_FetchMusicInfo_RequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ID запроса клиента передаётся в MusicInfo
+		assert.Equal(t, "req-42", r.Header.Get(requestid.Header))
+		json.NewEncoder(w).Encode(SongResponse{Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."})
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	ctx := requestid.With(context.Background(), "req-42")
+	_, err := api.FetchMusicInfo(ctx, &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.NoError(t, err)
+}
+
+func TestMusicInfo_FetchMusicInfo_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Сервер зависает, пока клиент не отключится
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	api := NewMusicInfo(
+		strings.TrimPrefix(server.URL, "http://"),
+		ClientOptions{RequestTimeout: 20 * time.Millisecond},
+		slog.New(slogdiscard.NewDiscardHandler()),
+	)
+
+	start := time.Now()
+	song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.Error(t, err)
+	assert.Nil(t, song)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestMusicInfo_FetchMusicInfo_ContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Без таймаута клиента запрос ограничен дедлайном контекста
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := api.FetchMusicInfo(ctx, &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestMusicInfo_FetchMusicInfo_IncompleteResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(SongResponse{Name: "Hysteria", Group: "Muse"})
+	}))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, slog.New(slogdiscard.NewDiscardHandler()))
+
+	song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.ErrorIs(t, err, domain.ErrInvalidSongText)
+	assert.Nil(t, song)
+}
+
+func TestMusicInfo_FetchMusicInfo_MockServer(t *testing.T) {
+	log := slog.New(slogdiscard.NewDiscardHandler())
+
+	// Мок стороннего API запускается в том же процессе
+	server := httptest.NewServer(musicinfomock.New(musicinfomock.Config{Seed: 1}, log))
+	defer server.Close()
+
+	api := NewMusicInfo(strings.TrimPrefix(server.URL, "http://"), ClientOptions{}, log)
+
+	song, err := api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hysteria", song.Name)
+	assert.NotEmpty(t, song.Text)
+
+	failing := httptest.NewServer(musicinfomock.New(musicinfomock.Config{ErrorRate: 1}, log))
+	defer failing.Close()
+
+	api = NewMusicInfo(strings.TrimPrefix(failing.URL, "http://"), ClientOptions{}, log)
+
+	_, err = api.FetchMusicInfo(context.Background(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	assert.Error(t, err)
+}
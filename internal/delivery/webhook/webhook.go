@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"time"
+)
+
+const (
+	// EventHeader carries the event type of the payload
+	EventHeader = "X-Webhook-Event"
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body
+	// keyed with the webhook secret
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+type Client struct {
+	Client *http.Client
+	log    *slog.Logger
+}
+
+func NewClient(timeout time.Duration, log *slog.Logger) *Client {
+	return &Client{
+		Client: &http.Client{Timeout: timeout},
+		log:    log,
+	}
+}
+
+// Send posts the signed event to the webhook, any status other than 2xx is an error
+func (c *Client) Send(ctx context.Context, hook *domain.Webhook, event domain.SongEvent) error {
+	const op = "webhook.Client.Send"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.String("url", hook.URL),
+		slog.String("event", string(event.Type)),
+	)
+
+	body, err := json.Marshal(dto.SongEventToPayload(event))
+	if err != nil {
+		return fmt.Errorf("%s: failed to encode payload: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(SignatureHeader, "sha256="+Sign(hook.Secret, body))
+
+	log.Debug("sending webhook")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status code: %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of payload keyed with secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Send_SignsPayload(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+		eventType string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		eventType = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient(time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+
+	hook := &domain.Webhook{URL: srv.URL, Secret: "s3cret"}
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	event := domain.SongEvent{Type: domain.SongUpdated, Song: song, OccurredAt: time.Now()}
+
+	err := client.Send(context.Background(), hook, event)
+	assert.NoError(t, err)
+
+	// Получатель проверяет подпись тем же секретом
+	assert.Equal(t, "sha256="+Sign("s3cret", body), signature)
+	assert.Equal(t, "song.updated", eventType)
+
+	var payload dto.WebhookPayload
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "song.updated", payload.Event)
+	assert.Equal(t, song.ID, payload.Song.ID)
+}
+
+func TestClient_Send_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := NewClient(time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+
+	hook := &domain.Webhook{URL: srv.URL, Secret: "s3cret"}
+	event := domain.SongEvent{Type: domain.SongCreated, Song: &domain.Song{ID: uuid.New()}}
+
+	err := client.Send(context.Background(), hook, event)
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlbumNotFound = errors.New("album not found")
+
+	ErrAlbumTitleIsNull = errors.New("album title is null")
+	ErrAlbumGroupIsNull = errors.New("album group is null")
+)
+
+type Album struct {
+	ID          uuid.UUID
+	Title       string
+	Group       string
+	ReleaseDate time.Time
+	CoverLink   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// LibraryID is set from the context the album is created in
+	LibraryID uuid.UUID
+}
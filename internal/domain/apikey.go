@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("api key is invalid, revoked or expired")
+
+	ErrAPIKeyNameIsNull   = errors.New("api key name is null")
+	ErrAPIKeyExpiryInPast = errors.New("api key expiry is in the past")
+)
+
+// APIKey is a credential of a client sent in the X-API-Key header. It is
+// scoped to Role and, if LibraryID is set, to one library. Only the hash of
+// the key is stored, Prefix identifies the key in listings.
+type APIKey struct {
+	ID        uuid.UUID
+	Name      string
+	Prefix    string
+	Hash      string
+	Role      Role
+	LibraryID *uuid.UUID
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active reports whether the key can be used at now
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+type apiKeyKey struct{}
+
+// WithAPIKeyID returns a copy of ctx carrying the ID of the API key the
+// request was authenticated with
+func WithAPIKeyID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, id)
+}
+
+// APIKeyIDFromContext returns the ID of the API key of the request, if it
+// carried one
+func APIKeyIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(apiKeyKey{}).(uuid.UUID)
+	return id, ok
+}
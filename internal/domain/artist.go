@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrArtistNotFound = errors.New("artist not found")
+	ErrArtistExists   = errors.New("artist already exists")
+	ErrArtistHasSongs = errors.New("artist still has songs")
+
+	ErrArtistNameIsNull = errors.New("artist name is null")
+)
+
+type Artist struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// LibraryID is set from the context the artist is created in
+	LibraryID uuid.UUID
+}
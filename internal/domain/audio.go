@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAudioNotFound      = errors.New("audio not found")
+	ErrAudioTooLarge      = errors.New("audio is too large")
+	ErrAudioInvalidFormat = errors.New("audio is not a supported format")
+)
+
+// Audio describes the audio file attached to a song, the file itself is kept
+// in the blob storage. Bitrate is the average number of bits per second.
+type Audio struct {
+	SongID      uuid.UUID
+	Format      string
+	ContentType string
+	Size        int64
+	Duration    time.Duration
+	Bitrate     int
+	SampleRate  int
+	UploadedAt  time.Time
+}
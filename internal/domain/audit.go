@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is the kind of change recorded in the audit log
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEntry records who changed a song, when and how. Old is nil for a
+// created song and New is nil for a deleted one. UserID is nil for changes
+// made by anonymous requests.
+type AuditEntry struct {
+	ID        int64
+	SongID    uuid.UUID
+	Action    AuditAction
+	UserID    *uuid.UUID
+	LibraryID uuid.UUID
+	Old       *Song
+	New       *Song
+	CreatedAt time.Time
+}
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying the ID of the user making the
+// change, it ends up in the audit log
+func WithUserID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext returns the ID of the user making the change, if any
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+	return id, ok
+}
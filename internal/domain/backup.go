@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrBackupInvalid        = errors.New("invalid backup")
+	ErrBackupSchemaMismatch = errors.New("backup schema version does not match the database")
+	ErrBackupUnsupported    = errors.New("backups are not supported by the storage")
+)
+
+// BackupRow is a row of a table in a backup, Data is the row as a JSON
+// object keyed by column
+type BackupRow struct {
+	Table string
+	Data  json.RawMessage
+}
+
+// BackupReport is the number of rows restored to every table. With DryRun
+// set the rows were validated by the database and nothing was changed.
+type BackupReport struct {
+	SchemaVersion int
+	Tables        map[string]int
+	DryRun        bool
+}
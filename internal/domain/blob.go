@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+
+	ErrCoverNotFound    = errors.New("cover not found")
+	ErrCoverTooLarge    = errors.New("cover is too large")
+	ErrCoverInvalidType = errors.New("cover is not a supported image")
+)
+
+// CoverTypes are the image types a song cover can have
+var CoverTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// Blob is a stored file. Body must be closed by the reader.
+type Blob struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
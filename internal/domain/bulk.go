@@ -0,0 +1,62 @@
+package domain
+
+import "errors"
+
+var (
+	ErrBulkFilterEmpty  = errors.New("bulk update filter is empty")
+	ErrBulkChangesEmpty = errors.New("bulk update changes are empty")
+)
+
+// SongChanges are the fields a bulk update sets on every song matching its
+// filter, nil fields are left as they are
+type SongChanges struct {
+	Group    *string
+	Genre    *string
+	Album    *string
+	Explicit *bool
+}
+
+// IsEmpty reports whether the changes don't set any field
+func (c *SongChanges) IsEmpty() bool {
+	return c.Group == nil && c.Genre == nil && c.Album == nil && c.Explicit == nil
+}
+
+// Fields returns the MusicInfo fields the changes set, a bulk update locks
+// them like an edit of the song
+func (c *SongChanges) Fields() SongFields {
+	var fields SongFields
+	if c.Genre != nil {
+		fields |= FieldGenre
+	}
+	if c.Album != nil {
+		fields |= FieldAlbum
+	}
+	if c.Explicit != nil {
+		fields |= FieldExplicit
+	}
+	return fields
+}
+
+// Apply sets the changed fields on song and locks them
+func (c *SongChanges) Apply(song *Song) {
+	song.LockedFields |= c.Fields()
+	if c.Group != nil {
+		song.Group = *c.Group
+	}
+	if c.Genre != nil {
+		song.Genre = *c.Genre
+	}
+	if c.Album != nil {
+		song.Album = *c.Album
+	}
+	if c.Explicit != nil {
+		explicit := *c.Explicit
+		song.Explicit = &explicit
+	}
+}
+
+// SongUpdate is a song changed by a bulk update with its state before
+type SongUpdate struct {
+	Old *Song
+	New *Song
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "errors"
+
+// ErrCacheMiss is returned by caches for keys they don't hold
+var ErrCacheMiss = errors.New("not found in cache")
+
+// CacheStats describes the state of the cache server
+type CacheStats struct {
+	Keys       int64
+	Hits       int64
+	Misses     int64
+	UsedMemory int64
+}
+
+// HitRate returns the share of lookups that found a key, zero before the
+// first lookup
+func (s *CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
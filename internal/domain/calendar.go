@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// CalendarDay is a day of the release calendar with the songs released on
+// it, oldest first. Songs carry their ID, name, group and release date.
+type CalendarDay struct {
+	Month time.Month
+	Day   int
+	Songs []*Song
+}
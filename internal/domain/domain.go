@@ -9,8 +9,9 @@ import (
 )
 
 var (
-	ErrSongExists   = errors.New("song already exists")
-	ErrSongNotFound = errors.New("song not found")
+	ErrSongExists      = errors.New("song already exists")
+	ErrSongNotFound    = errors.New("song not found")
+	ErrVersionConflict = errors.New("song version conflict")
 
 	ErrSongNameIsNull         = errors.New("song name is null")
 	ErrSongGroupIsNull        = errors.New("song group is null")
@@ -20,6 +21,26 @@ var (
 	ErrInvalidSongName  = errors.New("invalid song name")
 	ErrInvalidSongGroup = errors.New("invalid song group")
 	ErrInvalidSongText  = errors.New("invalid song text")
+
+	ErrInvalidSongStatus = errors.New("invalid song status")
+
+	ErrInvalidReleaseDate = errors.New("invalid release date")
+
+	ErrTooManySongIDs = errors.New("too many song IDs")
+
+	ErrMusicInfoTimeout = errors.New("music info request timed out")
+	// ErrMusicInfoBadRequest is returned when MusicInfo rejects a request,
+	// which it does for songs it doesn't know
+	ErrMusicInfoBadRequest = errors.New("music info rejected the request")
+	// ErrMusicInfoMalformed is returned for a MusicInfo response that doesn't
+	// match its schema
+	ErrMusicInfoMalformed = errors.New("malformed music info response")
+
+	// ErrEnrichmentMismatch means song details delivered for enrichment name
+	// another song than the one they are for
+	ErrEnrichmentMismatch = errors.New("enrichment result does not match the song")
+
+	ErrCacheRebuildRunning = errors.New("cache rebuild is already running")
 )
 
 type SongInfo SongSearch
@@ -39,6 +60,77 @@ type Song struct {
 	ReleaseDate time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Version     int
+	AlbumID     *uuid.UUID
+	ArtistID    uuid.UUID
+	// LibraryID is set from the context the song is created in
+	LibraryID uuid.UUID
+	// Status is where the song is in its lifecycle, songs created without
+	// one are enriched
+	Status SongStatus
+
+	// Duration, Genre, TrackNumber, Album and Explicit are supplied by
+	// MusicInfo. Album is the title it reports, AlbumID links the song to an
+	// album of the library. Explicit is nil while it is unknown.
+	Duration    time.Duration
+	Genre       string
+	TrackNumber int
+	Album       string
+	Explicit    *bool
+
+	FavoritesCount int
+
+	// RatingAverage and RatingsCount summarize the ratings users gave the
+	// song, they are recomputed whenever a rating changes
+	RatingAverage float64
+	RatingsCount  int
+
+	// Source is the MusicInfo provider that supplied the song details
+	Source string
+
+	// LockedFields were edited locally, refreshing the song from MusicInfo
+	// keeps them
+	LockedFields SongFields
+
+	// Lyrics is Text split into sections, nil for songs saved before
+	// lyrics were structured
+	Lyrics *Lyrics
+}
+
+// SongSort is the order songs are listed in
+type SongSort string
+
+const (
+	SortByCreatedAt  SongSort = "created_at"
+	SortByUpdatedAt  SongSort = "updated_at"
+	SortByPopularity SongSort = "popularity"
+	SortByRating     SongSort = "rating"
+)
+
+// SongCursor is the position of a song in the newest first listing,
+// the next page starts right after it
+type SongCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorOf returns the cursor pointing right after song
+func CursorOf(song *Song) *SongCursor {
+	return &SongCursor{CreatedAt: song.CreatedAt, ID: song.ID}
+}
+
+// ImportRowError describes why a single row of an import file was rejected.
+type ImportRowError struct {
+	Line int
+	Err  error
+}
+
+// ImportReport summarizes the outcome of a bulk import.
+type ImportReport struct {
+	Total    int
+	Imported int
+	DryRun   bool
+	Errors   []ImportRowError
 }
 
 type HTTPError struct {
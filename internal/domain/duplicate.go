@@ -0,0 +1,9 @@
+package domain
+
+// DuplicateSongs is a pair of songs whose names and groups look alike.
+// Similarity is the trigram similarity of "name group", from 0 to 1.
+type DuplicateSongs struct {
+	Song       *Song
+	Duplicate  *Song
+	Similarity float64
+}
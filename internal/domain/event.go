@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type SongEventType string
+
+const (
+	SongCreated SongEventType = "song.created"
+	SongUpdated SongEventType = "song.updated"
+	SongDeleted SongEventType = "song.deleted"
+)
+
+// SongEvent describes a change of a song, Song holds the state after the
+// change, or the last state for deleted songs.
+type SongEvent struct {
+	Type       SongEventType
+	Song       *Song
+	OccurredAt time.Time
+}
+
+// Valid reports whether t is one of the known event types
+func (t SongEventType) Valid() bool {
+	switch t {
+	case SongCreated, SongUpdated, SongDeleted:
+		return true
+	}
+	return false
+}
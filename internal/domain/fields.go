@@ -0,0 +1,46 @@
+package domain
+
+// SongFields is a set of the song fields MusicInfo supplies
+type SongFields uint8
+
+const (
+	FieldText SongFields = 1 << iota
+	FieldLink
+	FieldReleaseDate
+	FieldDuration
+	FieldGenre
+	FieldTrackNumber
+	FieldAlbum
+	FieldExplicit
+)
+
+// songFieldNames names the fields in the order they are listed
+var songFieldNames = []struct {
+	field SongFields
+	name  string
+}{
+	{FieldText, "text"},
+	{FieldLink, "link"},
+	{FieldReleaseDate, "release_date"},
+	{FieldDuration, "duration"},
+	{FieldGenre, "genre"},
+	{FieldTrackNumber, "track_number"},
+	{FieldAlbum, "album"},
+	{FieldExplicit, "explicit"},
+}
+
+// Has reports whether all of fields are in the set
+func (f SongFields) Has(fields SongFields) bool {
+	return f&fields == fields
+}
+
+// Names returns the names of the fields in the set
+func (f SongFields) Names() []string {
+	names := make([]string, 0, len(songFieldNames))
+	for _, field := range songFieldNames {
+		if f.Has(field.field) {
+			names = append(names, field.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SongFilter selects the songs of a listing, zero fields don't filter
+type SongFilter struct {
+	// Name, Group, Album, Text and Link match substrings, name and group
+	// ignoring case and accents, the rest ignoring case
+	Name  string
+	Group string
+	Album string
+	Text  string
+	Link  string
+
+	// Query is a web search query matched like SearchSongs matches it
+	Query string
+
+	ArtistID uuid.UUID
+	Genre    string
+	Status   SongStatus
+	Explicit *bool
+
+	// ReleaseDate matches a single day, ReleasedFrom and ReleasedTo an
+	// inclusive range of release dates open on the zero side
+	ReleaseDate  time.Time
+	ReleasedFrom time.Time
+	ReleasedTo   time.Time
+
+	// MinDuration and MaxDuration bound the duration, zero leaves the range
+	// open on that side
+	MinDuration time.Duration
+	MaxDuration time.Duration
+
+	// MinRating keeps the songs rated at least MinRating on average, songs
+	// nobody rated don't match
+	MinRating float64
+
+	// A song matches if it has all of Tags or, with TagModeAny, any of them
+	Tags    []string
+	TagMode TagMode
+
+	// ExcludeExplicit drops the songs flagged explicit and keeps the ones
+	// of unknown content, ExcludeArchived drops the archived songs
+	ExcludeExplicit bool
+	ExcludeArchived bool
+
+	// Sort orders listings, newest first if empty
+	Sort SongSort
+
+	// WithoutText reads the songs without their text and lyrics
+	WithoutText bool
+}
+
+// IsEmpty reports whether the filter matches every song, excluding songs
+// doesn't narrow it down
+func (f *SongFilter) IsEmpty() bool {
+	return f.Name == "" && f.Group == "" && f.Album == "" && f.Text == "" && f.Link == "" &&
+		f.Query == "" && f.ArtistID == uuid.Nil && f.Genre == "" && f.Status == "" && f.Explicit == nil &&
+		f.ReleaseDate.IsZero() && f.ReleasedFrom.IsZero() && f.ReleasedTo.IsZero() &&
+		f.MinDuration == 0 && f.MaxDuration == 0 && f.MinRating == 0 && len(f.Tags) == 0
+}
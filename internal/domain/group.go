@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group is an artist together with the aggregates of its songs
+type Group struct {
+	ArtistID      uuid.UUID
+	Name          string
+	Songs         int
+	FirstRelease  time.Time
+	LatestRelease time.Time
+	// UpdatedAt is the last time one of the songs of the group was updated
+	UpdatedAt time.Time
+}
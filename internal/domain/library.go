@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLibraryNotFound = errors.New("library not found")
+	ErrLibraryExists   = errors.New("library already exists")
+
+	ErrLibraryNameIsNull = errors.New("library name is null")
+	ErrInvalidLibraryID  = errors.New("invalid library ID")
+)
+
+// DefaultLibraryID is the library of requests that don't name one, songs
+// saved before libraries existed belong to it
+var DefaultLibraryID = uuid.Nil
+
+// Library is a catalog of songs kept apart from the others on the same
+// deployment. Artists and albums are shared by all libraries.
+type Library struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+type libraryIDKey struct{}
+
+// WithLibraryID returns a copy of ctx scoped to the library, songs are read
+// from and written to it
+func WithLibraryID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, libraryIDKey{}, id)
+}
+
+// LibraryIDFromContext returns the library ctx is scoped to,
+// DefaultLibraryID if it isn't
+func LibraryIDFromContext(ctx context.Context) uuid.UUID {
+	if id, ok := ctx.Value(libraryIDKey{}).(uuid.UUID); ok {
+		return id
+	}
+	return DefaultLibraryID
+}
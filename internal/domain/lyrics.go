@@ -0,0 +1,125 @@
+package domain
+
+import "errors"
+
+var (
+	ErrVerseNotFound         = errors.New("song verse not found")
+	ErrLyricsPageNotFound    = errors.New("song text page not found")
+	ErrContentFilterDisabled = errors.New("content filter is disabled")
+)
+
+// SectionType is the part of a song a lyrics section is
+type SectionType string
+
+const (
+	SectionIntro  SectionType = "intro"
+	SectionVerse  SectionType = "verse"
+	SectionChorus SectionType = "chorus"
+	SectionBridge SectionType = "bridge"
+	SectionOutro  SectionType = "outro"
+)
+
+// SplitStrategy is how a song text is split into sections
+type SplitStrategy string
+
+const (
+	// SplitBlankLines starts a section at every blank line, a marker line
+	// like "[Chorus]" at the start of a block sets its type
+	SplitBlankLines SplitStrategy = "blank_lines"
+	// SplitMarkers starts a section at every marker line, blank lines don't
+	// split sections
+	SplitMarkers SplitStrategy = "markers"
+	// SplitLines makes a verse of every fixed number of lines
+	SplitLines SplitStrategy = "lines"
+)
+
+// Valid reports whether the strategy is known
+func (s SplitStrategy) Valid() bool {
+	switch s {
+	case SplitBlankLines, SplitMarkers, SplitLines:
+		return true
+	}
+	return false
+}
+
+// LyricsSection is a block of lyrics, Index counts the sections of the
+// same type from 1
+type LyricsSection struct {
+	Type  SectionType
+	Index int
+	Text  string
+}
+
+// Lyrics is the text of a song split into typed sections
+type Lyrics struct {
+	Sections []LyricsSection
+}
+
+// Verse returns the section at number, counting all sections from 1 the way
+// Verses lists them
+func (l *Lyrics) Verse(number int) (*LyricsSection, error) {
+	if number < 1 || number > len(l.Sections) {
+		return nil, ErrVerseNotFound
+	}
+	return &l.Sections[number-1], nil
+}
+
+// LyricsPage is a page of the sections of a song text split into pages of
+// VersesPerPage sections, Number counts the pages from 1
+type LyricsPage struct {
+	Lyrics
+	Number        int
+	VersesPerPage int
+	TotalVerses   int
+	TotalPages    int
+}
+
+// FirstVerse returns the number of the first section of the page, counting
+// all sections from 1 like Verse
+func (p *LyricsPage) FirstVerse() int {
+	return (p.Number-1)*p.VersesPerPage + 1
+}
+
+// Verses returns the text of the sections without their markers, the way
+// the song text was paginated before sections were typed
+func (l *Lyrics) Verses() []string {
+	verses := make([]string, 0, len(l.Sections))
+	for _, section := range l.Sections {
+		verses = append(verses, section.Text)
+	}
+	return verses
+}
+
+// VerseMatch is a section of the lyrics matching a search within the song,
+// Number counts the sections from 1 like Verse and Lines are the matching
+// lines of the section
+type VerseMatch struct {
+	Number  int
+	Section LyricsSection
+	Lines   []LineMatch
+}
+
+// LineMatch is a line of a section, Number counts the lines of the section
+// from 1
+type LineMatch struct {
+	Number int
+	Text   string
+}
+
+// NormalizeReport counts the songs a lyrics normalization run went through
+// and the ones whose text changed, DryRun runs don't save the changes
+type NormalizeReport struct {
+	Scanned int
+	Changed int
+	Failed  int
+	DryRun  bool
+}
+
+// ContentScanReport counts the songs a content filter run went through and
+// the ones it flagged explicit, DryRun runs don't save the flags
+type ContentScanReport struct {
+	Scanned int
+	Flagged int
+	Failed  int
+	DryRun  bool
+}
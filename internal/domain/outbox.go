@@ -0,0 +1,16 @@
+package domain
+
+// OutboxEvent is a song event stored in the transaction of the change it
+// describes and kept until the relay publishes it. ID orders the events.
+type OutboxEvent struct {
+	ID    int64
+	Event SongEvent
+}
+
+// AuditEventTypes maps the changes recorded in the audit log to the events
+// published about them
+var AuditEventTypes = map[AuditAction]SongEventType{
+	AuditCreate: SongCreated,
+	AuditUpdate: SongUpdated,
+	AuditDelete: SongDeleted,
+}
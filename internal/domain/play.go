@@ -0,0 +1,7 @@
+package domain
+
+// TrendingSong is a song with the number of plays it got within the trending window.
+type TrendingSong struct {
+	Song  *Song
+	Plays int
+}
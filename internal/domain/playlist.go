@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPlaylistNotFound = errors.New("smart playlist not found")
+
+	ErrPlaylistNameIsNull       = errors.New("smart playlist name is null")
+	ErrPlaylistFilterEmpty      = errors.New("smart playlist filter is empty")
+	ErrPlaylistDateRangeInvalid = errors.New("smart playlist date range is invalid")
+)
+
+// SmartPlaylist is a named filter saved by a user. Its songs aren't stored,
+// the filter is evaluated against the library every time they are listed.
+type SmartPlaylist struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	LibraryID uuid.UUID
+	Name      string
+	Filter    PlaylistFilter
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PlaylistFilter selects the songs of a smart playlist. Group matches a
+// substring, Query is a web search query and the release dates bound an
+// inclusive range, zero values don't filter.
+type PlaylistFilter struct {
+	Group        string
+	Tags         []string
+	TagMode      TagMode
+	ReleasedFrom time.Time
+	ReleasedTo   time.Time
+	Query        string
+}
+
+// SongFilter returns the filter of the song listing of the playlist
+func (f *PlaylistFilter) SongFilter() *SongFilter {
+	return &SongFilter{
+		Group:        f.Group,
+		Tags:         f.Tags,
+		TagMode:      f.TagMode,
+		ReleasedFrom: f.ReleasedFrom,
+		ReleasedTo:   f.ReleasedTo,
+		Query:        f.Query,
+
+		ExcludeArchived: true,
+	}
+}
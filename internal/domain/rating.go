@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRating = errors.New("rating is out of range")
+
+// MinRating and MaxRating bound the score a user gives a song
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Rating is the score a user gave a song with the summary of the ratings
+// of all users of the song
+type Rating struct {
+	UserID uuid.UUID
+	SongID uuid.UUID
+	// Value is the score of the user, zero if the user didn't rate the song
+	Value   int
+	RatedAt time.Time
+
+	// Average and Count summarize the ratings of the song, like
+	// Song.RatingAverage and Song.RatingsCount
+	Average float64
+	Count   int
+}
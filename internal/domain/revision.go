@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("song revision not found")
+
+// SongRevision is a previous state of a song, kept when the song is updated.
+// Revision is the version the song had in that state.
+type SongRevision struct {
+	Revision  int
+	Song      *Song
+	CreatedAt time.Time
+}
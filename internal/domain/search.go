@@ -0,0 +1,29 @@
+package domain
+
+import "errors"
+
+var ErrSemanticSearchDisabled = errors.New("semantic search is disabled")
+
+// Markers around the words of a snippet the search query matched
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// SearchMode is how a search query is matched: keyword search matches the
+// words of the query, semantic search the meaning of the query and lyrics
+type SearchMode string
+
+const (
+	SearchKeyword  SearchMode = "keyword"
+	SearchSemantic SearchMode = "semantic"
+)
+
+// SearchResult is a song matching a full-text search. Rank is its relevance
+// between 0 and 1 and Snippets are the fragments of the lyrics the query
+// matched, with the matched words between HighlightStart and HighlightEnd.
+type SearchResult struct {
+	Song     *Song
+	Rank     float64
+	Snippets []string
+}
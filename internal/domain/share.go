@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrShareNotFound = errors.New("share not found")
+	ErrShareExpired  = errors.New("share is revoked or expired")
+
+	ErrShareTTLInvalid = errors.New("share expiry is out of range")
+)
+
+// Share is a read-only link to a song or a smart playlist of a user. It is
+// opened without identifying, by a token signed by the service, until it
+// expires or is revoked. Exactly one of SongID and PlaylistID is set.
+type Share struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	LibraryID  uuid.UUID
+	SongID     *uuid.UUID
+	PlaylistID *uuid.UUID
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// Active reports whether the share can be opened at now
+func (s *Share) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SharedContent is what a share link opens: the song, or the playlist with a
+// page of its songs
+type SharedContent struct {
+	Share    *Share
+	Song     *Song
+	Playlist *SmartPlaylist
+	Songs    []*Song
+}
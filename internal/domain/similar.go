@@ -0,0 +1,18 @@
+package domain
+
+// Weights of what songs are compared by, they sum to 1: the trigram
+// similarity of the lyrics, the same group, the same genre and the share of
+// the tags of the song the other song has too
+const (
+	SimilarTextWeight  = 0.6
+	SimilarGroupWeight = 0.2
+	SimilarGenreWeight = 0.1
+	SimilarTagsWeight  = 0.1
+)
+
+// SimilarSong is a song like another one, Score from 0 to 1 is how alike
+// they are
+type SimilarSong struct {
+	Song  *Song
+	Score float64
+}
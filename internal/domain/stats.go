@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// LibraryStats are the totals of the song library. AvgTextLength is the
+// average number of characters of the songs having a text. AddedPerDay and
+// AddedPerWeek count the songs created in every day and week, oldest first.
+type LibraryStats struct {
+	Songs         int
+	Groups        int
+	AvgTextLength float64
+	MissingText   int
+	MissingLink   int
+	AddedPerDay   []DateCount
+	AddedPerWeek  []DateCount
+}
+
+// DateCount is the number of songs created in the day or week starting at
+// Date, a midnight in UTC
+type DateCount struct {
+	Date  time.Time
+	Songs int
+}
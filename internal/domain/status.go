@@ -0,0 +1,29 @@
+package domain
+
+// SongStatus is where a song is in its lifecycle. A song is pending while
+// its details are still to be fetched from MusicInfo, enriched once they
+// were, failed if fetching them was given up on and archived when it was
+// put away by hand.
+type SongStatus string
+
+const (
+	SongStatusPending  SongStatus = "pending"
+	SongStatusEnriched SongStatus = "enriched"
+	SongStatusFailed   SongStatus = "failed"
+	SongStatusArchived SongStatus = "archived"
+)
+
+// Valid reports whether the status is one of the known ones
+func (s SongStatus) Valid() bool {
+	switch s {
+	case SongStatusPending, SongStatusEnriched, SongStatusFailed, SongStatusArchived:
+		return true
+	}
+	return false
+}
+
+// AwaitsEnrichment reports whether the details of a song with the status
+// are still missing, such songs become enriched once MusicInfo supplies them
+func (s SongStatus) AwaitsEnrichment() bool {
+	return s == SongStatusPending || s == SongStatusFailed
+}
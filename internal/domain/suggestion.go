@@ -0,0 +1,17 @@
+package domain
+
+// Kinds of suggestions
+const (
+	SuggestionSong  = "song"
+	SuggestionGroup = "group"
+)
+
+// Suggestion is a song or group name matching a typed query. Group is the
+// group of a suggested song and empty for groups. Score is 1 for names
+// starting with the query and their trigram similarity to it otherwise.
+type Suggestion struct {
+	Kind  string
+	Text  string
+	Group string
+	Score float64
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// maxTagLength is the maximal number of characters in a tag
+const maxTagLength = 50
+
+// TagMode decides whether a song filtered by tags must have all of them or any
+type TagMode string
+
+const (
+	TagModeAll TagMode = "all"
+	TagModeAny TagMode = "any"
+)
+
+// Tag is a label of songs with the number of songs it is attached to
+type Tag struct {
+	Name  string
+	Songs int
+}
+
+// NormalizeTag trims and lower-cases a tag. Tags are up to 50 characters
+// long and can't contain commas, which separate tags in filters.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+	if tag == "" || utf8.RuneCountInString(tag) > maxTagLength || strings.Contains(tag, ",") {
+		return "", ErrInvalidTag
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes tags and drops repeated ones, keeping the order
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidRole  = errors.New("invalid role")
+)
+
+// Role is the level of access of a user, each role includes the rights of
+// the roles below it
+type Role string
+
+const (
+	// RoleViewer reads songs and keeps its own favorites and plays
+	RoleViewer Role = "viewer"
+	// RoleEditor also creates, updates and deletes songs, albums and artists
+	RoleEditor Role = "editor"
+	// RoleAdmin also manages the service: /admin routes, webhooks and bulk
+	// updates
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Valid reports whether r is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether r has the rights of required
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// User is the role assigned to a user ID. Users are authenticated by the
+// gateway in front of the service, only their roles are stored.
+type User struct {
+	ID        uuid.UUID
+	Role      Role
+	UpdatedAt time.Time
+}
+
+type roleKey struct{}
+
+// WithRole returns a copy of ctx carrying the role of the request
+func WithRole(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+// RoleFromContext returns the role of the request, if it was resolved
+func RoleFromContext(ctx context.Context) (Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(Role)
+	return role, ok
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrWebhookURLIsInvalid   = errors.New("webhook url is invalid")
+	ErrWebhookEventIsInvalid = errors.New("webhook event is invalid")
+)
+
+// Webhook is an endpoint notified about song changes. Payloads are signed
+// with Secret, an empty Events list subscribes to every event type.
+type Webhook struct {
+	ID        uuid.UUID
+	URL       string
+	Secret    string
+	Events    []SongEventType
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Accepts reports whether the webhook is subscribed to events of type t
+func (w *Webhook) Accepts(t SongEventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, t)
+}
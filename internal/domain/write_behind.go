@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// PendingWriteOp is the kind of a song write waiting for the database
+type PendingWriteOp string
+
+const (
+	PendingCreate PendingWriteOp = "create"
+	PendingUpdate PendingWriteOp = "update"
+)
+
+// PendingWrite is a song write that is already in the cache and is flushed
+// to the database later. Song of an update carries the version the write
+// expects in the database, Song is nil if the queued write can't be read.
+type PendingWrite struct {
+	// ID is the position of the write in the queue, set by the queue
+	ID     string
+	Op     PendingWriteOp
+	Song   *Song
+	UserID *uuid.UUID
+}
+
+// IsPermanentWriteError reports whether a flushed write failed in a way that
+// retrying can't fix, such writes are dropped from the queue
+func IsPermanentWriteError(err error) bool {
+	return errors.Is(err, ErrSongExists) || errors.Is(err, ErrSongNotFound) ||
+		errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrAlbumNotFound)
+}
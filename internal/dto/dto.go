@@ -1,22 +1,144 @@
 package dto
 
 import (
+	"net/url"
 	"songLibrary/internal/domain"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ErrorCode string
+
+const (
+	CodeInvalidRequest     ErrorCode = "INVALID_REQUEST"
+	CodeValidationFailed   ErrorCode = "VALIDATION_FAILED"
+	CodeSongNotFound       ErrorCode = "SONG_NOT_FOUND"
+	CodeAlbumNotFound      ErrorCode = "ALBUM_NOT_FOUND"
+	CodeArtistNotFound     ErrorCode = "ARTIST_NOT_FOUND"
+	CodeArtistExists       ErrorCode = "ARTIST_ALREADY_EXISTS"
+	CodeArtistHasSongs     ErrorCode = "ARTIST_HAS_SONGS"
+	CodeWebhookNotFound    ErrorCode = "WEBHOOK_NOT_FOUND"
+	CodeLibraryNotFound    ErrorCode = "LIBRARY_NOT_FOUND"
+	CodeLibraryExists      ErrorCode = "LIBRARY_ALREADY_EXISTS"
+	CodeUserNotFound       ErrorCode = "USER_NOT_FOUND"
+	CodeAPIKeyNotFound     ErrorCode = "API_KEY_NOT_FOUND"
+	CodePlaylistNotFound   ErrorCode = "SMART_PLAYLIST_NOT_FOUND"
+	CodeShareNotFound      ErrorCode = "SHARE_NOT_FOUND"
+	CodeShareExpired       ErrorCode = "SHARE_EXPIRED"
+	CodeSongExists         ErrorCode = "SONG_ALREADY_EXISTS"
+	CodeVersionConflict    ErrorCode = "VERSION_CONFLICT"
+	CodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeTooManyRequests    ErrorCode = "TOO_MANY_REQUESTS"
+	CodeMusicInfoTimeout   ErrorCode = "MUSIC_INFO_TIMEOUT"
+	CodeMusicInfoRejected  ErrorCode = "MUSIC_INFO_REJECTED"
+	CodeMusicInfoMalformed ErrorCode = "MUSIC_INFO_MALFORMED"
+	CodeCacheRebuilding    ErrorCode = "CACHE_REBUILD_RUNNING"
+	CodeRevisionNotFound   ErrorCode = "REVISION_NOT_FOUND"
+	CodeVerseNotFound      ErrorCode = "VERSE_NOT_FOUND"
+	CodeTextPageNotFound   ErrorCode = "TEXT_PAGE_NOT_FOUND"
+	CodeRequestTooLarge    ErrorCode = "REQUEST_TOO_LARGE"
+	CodeUnsupportedMedia   ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	CodeCoverNotFound      ErrorCode = "COVER_NOT_FOUND"
+	CodeAudioNotFound      ErrorCode = "AUDIO_NOT_FOUND"
+	CodeRequestTimeout     ErrorCode = "REQUEST_TIMEOUT"
+	CodeSchemaMismatch     ErrorCode = "SCHEMA_VERSION_MISMATCH"
+	CodeNotImplemented     ErrorCode = "NOT_IMPLEMENTED"
+	CodeInternal           ErrorCode = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code      ErrorCode         `json:"code"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// MessageResponse confirms a request that has nothing else to return
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
 type AddSongRequest struct {
 	Name  string `json:"name"`
 	Group string `json:"group"`
 }
 
+// Statuses of an added song
+const (
+	SongStatusAdded             = "added"
+	SongStatusPendingEnrichment = "pending_enrichment"
+)
+
+// AddSongResponse confirms an added song. A song added while the song
+// details provider was unavailable has the pending_enrichment status, its
+// details are filled in later.
+type AddSongResponse struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 type UpdateSongRequest struct {
-	Name  string `json:"name"`
-	Group string `json:"group"`
-	Text  string `json:"text,omitempty"`
-	Link  string `json:"link,omitempty"`
+	Name        string     `json:"name"`
+	Group       string     `json:"group"`
+	Text        string     `json:"text,omitempty"`
+	Link        string     `json:"link,omitempty"`
+	Version     int        `json:"version,omitempty"`
+	AlbumID     *uuid.UUID `json:"album_id,omitempty"`
+	DurationMs  int64      `json:"duration_ms,omitempty"`
+	Genre       string     `json:"genre,omitempty"`
+	TrackNumber int        `json:"track_number,omitempty"`
+	Album       string     `json:"album,omitempty"`
+	Explicit    *bool      `json:"explicit,omitempty"`
+}
+
+// BulkUpdateSongsRequest sets the changes on every song matching the filter
+type BulkUpdateSongsRequest struct {
+	Filter  SongFilterRequest  `json:"filter"`
+	Changes SongChangesRequest `json:"changes"`
+}
+
+// SongFilterRequest selects songs like the query parameters of GET /songs:
+// song, group and album match substrings, tags_mode is "all" or "any"
+type SongFilterRequest struct {
+	Song     string    `json:"song,omitempty"`
+	Group    string    `json:"group,omitempty"`
+	ArtistID uuid.UUID `json:"artist_id,omitempty"`
+	Genre    string    `json:"genre,omitempty"`
+	Album    string    `json:"album,omitempty"`
+	Explicit *bool     `json:"explicit,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
+	TagsMode string    `json:"tags_mode,omitempty"`
+}
+
+// SongChangesRequest are the fields set on the matched songs, omitted fields
+// are kept
+type SongChangesRequest struct {
+	Group    *string `json:"group,omitempty"`
+	Genre    *string `json:"genre,omitempty"`
+	Album    *string `json:"album,omitempty"`
+	Explicit *bool   `json:"explicit,omitempty"`
+}
+
+// BulkUpdateSongsResponse is the number of songs a bulk update changed
+type BulkUpdateSongsResponse struct {
+	Updated int `json:"updated"`
+}
+
+// BatchGetSongsRequest lists the IDs of the songs to fetch at once
+type BatchGetSongsRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// BatchGetSongsResponse has the found songs in the order they were asked
+// for and the IDs of the songs that don't exist
+type BatchGetSongsResponse struct {
+	Songs    []SongResponse `json:"songs"`
+	NotFound []string       `json:"not_found"`
 }
 
 type SongResponse struct {
@@ -28,31 +150,489 @@ type SongResponse struct {
 	ReleaseDate time.Time `json:"release_date,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Version     int       `json:"version"`
+	AlbumID     string    `json:"album_id,omitempty"`
+	ArtistID    string    `json:"artist_id,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	DurationMs  int64     `json:"duration_ms,omitempty"`
+	Genre       string    `json:"genre,omitempty"`
+	TrackNumber int       `json:"track_number,omitempty"`
+	Album       string    `json:"album,omitempty"`
+	Explicit    *bool     `json:"explicit,omitempty"`
+	// Status is pending, enriched, failed or archived
+	Status string `json:"status" enums:"pending,enriched,failed,archived"`
+
+	FavoritesCount int     `json:"favorites_count"`
+	RatingAverage  float64 `json:"rating_average"`
+	RatingsCount   int     `json:"ratings_count"`
 }
 
-type GetAllSongsFilter struct {
-	Name        string `json:"name,omitempty"`
-	Group       string `json:"group,omitempty"`
-	ReleaseDate string `json:"release_date,omitempty"`
-	Page        int    `json:"page,omitempty"`
-	PageSize    int    `json:"page_size,omitempty"`
+// SongPageResponse is a page of songs listed with a cursor, NextCursor is
+// empty on the last page
+type SongPageResponse struct {
+	Songs      []SongResponse `json:"songs"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
-type PaginatedTextResponse struct {
-	Text []string `json:"text"`
+// RefreshSongResponse is a song refreshed from MusicInfo with the fields
+// that changed
+type RefreshSongResponse struct {
+	Song    SongResponse `json:"song"`
+	Changed []string     `json:"changed"`
 }
 
-type SongDTO struct {
-	ID          uuid.UUID `json:"id"`
+type DuplicateSongsResponse struct {
+	Song       SongResponse `json:"song"`
+	Duplicate  SongResponse `json:"duplicate"`
+	Similarity float64      `json:"similarity"`
+}
+
+type TrendingSongResponse struct {
+	SongResponse
+	Plays int `json:"plays"`
+}
+
+// AuditEntryResponse is a recorded change of a song. Old is missing for a
+// created song and New for a deleted one.
+type AuditEntryResponse struct {
+	ID        int64         `json:"id"`
+	SongID    string        `json:"song_id"`
+	Action    string        `json:"action"`
+	UserID    string        `json:"user_id,omitempty"`
+	Old       *SongResponse `json:"old,omitempty"`
+	New       *SongResponse `json:"new,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// SongRevisionResponse is a previous state of a song, Revision is the
+// version the song had in it
+type SongRevisionResponse struct {
+	Revision    int       `json:"revision"`
 	Name        string    `json:"name"`
 	Group       string    `json:"group"`
-	Text        string    `json:"text"`
-	Link        string    `json:"link"`
+	Text        string    `json:"text,omitempty"`
+	Link        string    `json:"link,omitempty"`
 	ReleaseDate time.Time `json:"release_date"`
+	AlbumID     string    `json:"album_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TextDiffResponse is the line-level diff of the song text from revision
+// From to revision To
+type TextDiffResponse struct {
+	From  int                `json:"from"`
+	To    int                `json:"to"`
+	Lines []DiffLineResponse `json:"lines"`
+}
+
+// DiffLineResponse is a line of a diff, Op is equal, insert or delete
+type DiffLineResponse struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// PaginatedTextResponse is the text of a song by sections, Text keeps the
+// plain list of sections for older clients
+type PaginatedTextResponse struct {
+	Text     []string                `json:"text"`
+	Sections []LyricsSectionResponse `json:"sections"`
+	Page     *TextPageResponse       `json:"page,omitempty"`
+}
+
+// TextPageResponse describes a page of the song text when it is split into
+// pages of several sections, Prev and Next link to the neighbouring pages and
+// are empty at the ends
+type TextPageResponse struct {
+	Number        int    `json:"number"`
+	VersesPerPage int    `json:"verses_per_page"`
+	TotalPages    int    `json:"total_pages"`
+	TotalVerses   int    `json:"total_verses"`
+	FirstVerse    int    `json:"first_verse"`
+	Prev          string `json:"prev,omitempty"`
+	Next          string `json:"next,omitempty"`
+}
+
+type LyricsSectionResponse struct {
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+}
+
+// VerseResponse is a single section of the song text, Prev and Next link to
+// the neighbouring sections and are empty at the ends
+type VerseResponse struct {
+	Number int    `json:"number"`
+	Total  int    `json:"total"`
+	Type   string `json:"type"`
+	Index  int    `json:"index"`
+	Text   string `json:"text"`
+	Prev   string `json:"prev,omitempty"`
+	Next   string `json:"next,omitempty"`
+}
+
+// VerseSearchResponse lists the sections of a song matching a search, Link
+// points to the section
+type VerseSearchResponse struct {
+	Matches []VerseMatchResponse `json:"matches"`
+}
+
+type VerseMatchResponse struct {
+	Number int                 `json:"number"`
+	Type   string              `json:"type"`
+	Index  int                 `json:"index"`
+	Link   string              `json:"link"`
+	Lines  []LineMatchResponse `json:"lines"`
+}
+
+type LineMatchResponse struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+type AlbumRequest struct {
+	Title       string `json:"title"`
+	Group       string `json:"group"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	CoverLink   string `json:"cover_link,omitempty"`
+}
+
+type AlbumResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Group       string    `json:"group"`
+	ReleaseDate time.Time `json:"release_date"`
+	CoverLink   string    `json:"cover_link,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+type ArtistRequest struct {
+	Name string `json:"name"`
+}
+
+type ArtistResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GroupResponse struct {
+	ArtistID      string    `json:"artist_id"`
+	Name          string    `json:"name"`
+	Songs         int       `json:"songs"`
+	FirstRelease  time.Time `json:"first_release"`
+	LatestRelease time.Time `json:"latest_release"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// SongTagsResponse lists the tags of a song in alphabetical order
+type SongTagsResponse struct {
+	SongID string   `json:"song_id"`
+	Tags   []string `json:"tags"`
+}
+
+// CoverResponse describes the stored cover of a song
+type CoverResponse struct {
+	SongID      string `json:"song_id"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// AudioResponse describes the stored audio file of a song, Bitrate is the
+// average number of bits per second
+type AudioResponse struct {
+	SongID      string    `json:"song_id"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	DurationMs  int64     `json:"duration_ms"`
+	Bitrate     int       `json:"bitrate"`
+	SampleRate  int       `json:"sample_rate"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// LibraryStatsResponse are the totals of the library. AvgTextLength is in
+// characters and counts only songs having a text.
+type LibraryStatsResponse struct {
+	Songs         int                 `json:"songs"`
+	Groups        int                 `json:"groups"`
+	AvgTextLength float64             `json:"avg_text_length"`
+	MissingText   int                 `json:"missing_text"`
+	MissingLink   int                 `json:"missing_link"`
+	AddedPerDay   []DateCountResponse `json:"added_per_day"`
+	AddedPerWeek  []DateCountResponse `json:"added_per_week"`
+}
+
+// DateCountResponse is the number of songs added in the day or the week
+// starting at Date (YYYY-MM-DD)
+type DateCountResponse struct {
+	Date  string `json:"date"`
+	Songs int    `json:"songs"`
+}
+
+// SuggestionResponse is a song or group name matching a search query, Group
+// is set for songs only
+type SuggestionResponse struct {
+	Kind  string  `json:"kind"`
+	Text  string  `json:"text"`
+	Group string  `json:"group,omitempty"`
+	Score float64 `json:"score"`
+}
+
+// SearchResultResponse is a song matching a full-text search with its
+// relevance between 0 and 1 and the HTML snippets of the lyrics the query
+// matched, the matched words are wrapped in <mark>
+type SearchResultResponse struct {
+	Song     SongResponse `json:"song"`
+	Rank     float64      `json:"rank"`
+	Snippets []string     `json:"snippets"`
+}
+
+// SimilarSongResponse is a song like the requested one, Score from 0 to 1
+// is how alike they are
+type SimilarSongResponse struct {
+	Song  SongResponse `json:"song"`
+	Score float64      `json:"score"`
+}
+
+// CalendarDayResponse is a day of the release calendar with the songs
+// released on it
+type CalendarDayResponse struct {
+	Month int                    `json:"month"`
+	Day   int                    `json:"day"`
+	Songs []CalendarSongResponse `json:"songs"`
+}
+
+// CalendarSongResponse is a song of the release calendar, ReleaseDate is
+// formatted as 2006-01-02
+type CalendarSongResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	ReleaseDate string `json:"release_date"`
+}
+
+// TagResponse is a tag with the number of songs it is attached to
+type TagResponse struct {
+	Name  string `json:"name"`
+	Songs int    `json:"songs"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+type LibraryRequest struct {
+	Name string `json:"name"`
+}
+
+type LibraryResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserRequest struct {
+	Role string `json:"role"`
+}
+
+type UserResponse struct {
+	ID        string    `json:"id"`
+	Role      string    `json:"role"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type APIKeyRequest struct {
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	LibraryID *string    `json:"library_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Key       string     `json:"key,omitempty"`
+	Role      string     `json:"role"`
+	LibraryID *string    `json:"library_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// SmartPlaylistRequest is a smart playlist, PUT replaces the name and the
+// whole filter
+type SmartPlaylistRequest struct {
+	Name   string                `json:"name"`
+	Filter PlaylistFilterRequest `json:"filter"`
+}
+
+// PlaylistFilterRequest selects the songs of a smart playlist: group matches
+// a substring, tags_mode is "all" or "any", released_from and released_to
+// are YYYY-MM-DD and bound an inclusive range and query is a web search query
+type PlaylistFilterRequest struct {
+	Group        string   `json:"group,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	TagsMode     string   `json:"tags_mode,omitempty"`
+	ReleasedFrom string   `json:"released_from,omitempty"`
+	ReleasedTo   string   `json:"released_to,omitempty"`
+	Query        string   `json:"query,omitempty"`
+}
+
+type SmartPlaylistResponse struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Filter    PlaylistFilterRequest `json:"filter"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// RatingRequest is the rating from 1 to 5 the current user gives a song
+type RatingRequest struct {
+	Rating int `json:"rating"`
+}
+
+// RatingResponse is the rating of the current user, missing if the user
+// didn't rate the song, with the average and the number of all its ratings
+type RatingResponse struct {
+	SongID        string     `json:"song_id"`
+	Rating        int        `json:"rating,omitempty"`
+	RatedAt       *time.Time `json:"rated_at,omitempty"`
+	RatingAverage float64    `json:"rating_average"`
+	RatingsCount  int        `json:"ratings_count"`
+}
+
+// ShareResponse is a share link, token and url open it without identifying
+type ShareResponse struct {
+	ID         string     `json:"id"`
+	Token      string     `json:"token"`
+	URL        string     `json:"url"`
+	SongID     *string    `json:"song_id,omitempty"`
+	PlaylistID *string    `json:"playlist_id,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// SharedResponse is what a share link opens, a song or a smart playlist
+type SharedResponse struct {
+	ExpiresAt time.Time               `json:"expires_at"`
+	Song      *SongResponse           `json:"song,omitempty"`
+	Playlist  *SharedPlaylistResponse `json:"playlist,omitempty"`
+}
+
+// SharedPlaylistResponse is a shared smart playlist with a page of its songs,
+// its filter isn't shown
+type SharedPlaylistResponse struct {
+	Name  string         `json:"name"`
+	Songs []SongResponse `json:"songs"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookPayload is the body delivered to webhooks
+type WebhookPayload struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Song       *SongDTO  `json:"song"`
+}
+
+type CacheStatsResponse struct {
+	Keys            int64   `json:"keys"`
+	Hits            int64   `json:"hits"`
+	Misses          int64   `json:"misses"`
+	HitRate         float64 `json:"hit_rate"`
+	UsedMemoryBytes int64   `json:"used_memory_bytes"`
+}
+
+// HealthResponse reports the state of the dependencies, a failed check
+// carries its error
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type CacheFlushResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+type ImportRowErrorResponse struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type BackupReportResponse struct {
+	SchemaVersion int            `json:"schema_version"`
+	Tables        map[string]int `json:"tables"`
+	DryRun        bool           `json:"dry_run"`
+}
+
+type NormalizeReportResponse struct {
+	Scanned int  `json:"scanned"`
+	Changed int  `json:"changed"`
+	Failed  int  `json:"failed"`
+	DryRun  bool `json:"dry_run"`
+}
+
+type ContentScanReportResponse struct {
+	Scanned int  `json:"scanned"`
+	Flagged int  `json:"flagged"`
+	Failed  int  `json:"failed"`
+	DryRun  bool `json:"dry_run"`
+}
+
+type ImportResponse struct {
+	Total    int                      `json:"total"`
+	Imported int                      `json:"imported"`
+	Failed   int                      `json:"failed"`
+	DryRun   bool                     `json:"dry_run"`
+	Errors   []ImportRowErrorResponse `json:"errors,omitempty"`
+}
+
+type SongDTO struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Group       string     `json:"group"`
+	Text        string     `json:"text"`
+	Link        string     `json:"link"`
+	ReleaseDate time.Time  `json:"release_date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Version     int        `json:"version"`
+	AlbumID     *uuid.UUID `json:"album_id,omitempty"`
+	ArtistID    uuid.UUID  `json:"artist_id"`
+	LibraryID   uuid.UUID  `json:"library_id"`
+	Source      string     `json:"source,omitempty"`
+	DurationMs  int64      `json:"duration_ms,omitempty"`
+	Genre       string     `json:"genre,omitempty"`
+	TrackNumber int        `json:"track_number,omitempty"`
+	Album       string     `json:"album,omitempty"`
+	Explicit    *bool      `json:"explicit,omitempty"`
+	Status      string     `json:"status"`
+
+	FavoritesCount int     `json:"favorites_count"`
+	RatingAverage  float64 `json:"rating_average"`
+	RatingsCount   int     `json:"ratings_count"`
+
+	// LockedFields keeps the locally edited fields of cached songs
+	LockedFields domain.SongFields `json:"locked_fields,omitempty"`
+}
+
 func SongToDTO(song *domain.Song) *SongDTO {
 	return &SongDTO{
 		ID:          song.ID,
@@ -63,6 +643,22 @@ func SongToDTO(song *domain.Song) *SongDTO {
 		ReleaseDate: song.ReleaseDate,
 		CreatedAt:   song.CreatedAt,
 		UpdatedAt:   song.UpdatedAt,
+		Version:     song.Version,
+		AlbumID:     song.AlbumID,
+		ArtistID:    song.ArtistID,
+		LibraryID:   song.LibraryID,
+		Source:      song.Source,
+		DurationMs:  song.Duration.Milliseconds(),
+		Genre:       song.Genre,
+		TrackNumber: song.TrackNumber,
+		Album:       song.Album,
+		Explicit:    song.Explicit,
+		Status:      string(song.Status),
+
+		FavoritesCount: song.FavoritesCount,
+		RatingAverage:  song.RatingAverage,
+		RatingsCount:   song.RatingsCount,
+		LockedFields:   song.LockedFields,
 	}
 }
 
@@ -76,5 +672,308 @@ func DTOToSong(dto *SongDTO) *domain.Song {
 		ReleaseDate: dto.ReleaseDate,
 		CreatedAt:   dto.CreatedAt,
 		UpdatedAt:   dto.UpdatedAt,
+		Version:     dto.Version,
+		AlbumID:     dto.AlbumID,
+		ArtistID:    dto.ArtistID,
+		LibraryID:   dto.LibraryID,
+		Source:      dto.Source,
+		Duration:    time.Duration(dto.DurationMs) * time.Millisecond,
+		Genre:       dto.Genre,
+		TrackNumber: dto.TrackNumber,
+		Album:       dto.Album,
+		Explicit:    dto.Explicit,
+		Status:      domain.SongStatus(dto.Status),
+
+		FavoritesCount: dto.FavoritesCount,
+		RatingAverage:  dto.RatingAverage,
+		RatingsCount:   dto.RatingsCount,
+		LockedFields:   dto.LockedFields,
+	}
+}
+
+func ImportReportToResponse(report *domain.ImportReport) *ImportResponse {
+	response := &ImportResponse{
+		Total:    report.Total,
+		Imported: report.Imported,
+		Failed:   len(report.Errors),
+		DryRun:   report.DryRun,
+	}
+
+	for _, rowErr := range report.Errors {
+		response.Errors = append(response.Errors, ImportRowErrorResponse{
+			Line:  rowErr.Line,
+			Error: rowErr.Err.Error(),
+		})
+	}
+
+	return response
+}
+
+func AlbumToResponse(album *domain.Album) *AlbumResponse {
+	return &AlbumResponse{
+		ID:          album.ID.String(),
+		Title:       album.Title,
+		Group:       album.Group,
+		ReleaseDate: album.ReleaseDate,
+		CoverLink:   album.CoverLink,
+		CreatedAt:   album.CreatedAt,
+		UpdatedAt:   album.UpdatedAt,
+	}
+}
+
+func ArtistToResponse(artist *domain.Artist) *ArtistResponse {
+	return &ArtistResponse{
+		ID:        artist.ID.String(),
+		Name:      artist.Name,
+		CreatedAt: artist.CreatedAt,
+		UpdatedAt: artist.UpdatedAt,
+	}
+}
+
+func GroupToResponse(group *domain.Group) *GroupResponse {
+	return &GroupResponse{
+		ArtistID:      group.ArtistID.String(),
+		Name:          group.Name,
+		Songs:         group.Songs,
+		FirstRelease:  group.FirstRelease,
+		LatestRelease: group.LatestRelease,
+		UpdatedAt:     group.UpdatedAt,
+	}
+}
+
+// WebhookToResponse converts a webhook without its secret, which is only
+// shown once when the webhook is created
+func WebhookToResponse(hook *domain.Webhook) *WebhookResponse {
+	events := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		events = append(events, string(event))
+	}
+
+	return &WebhookResponse{
+		ID:        hook.ID.String(),
+		URL:       hook.URL,
+		Events:    events,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+}
+
+func SmartPlaylistToResponse(playlist *domain.SmartPlaylist) *SmartPlaylistResponse {
+	filter := PlaylistFilterRequest{
+		Group:    playlist.Filter.Group,
+		Tags:     playlist.Filter.Tags,
+		TagsMode: string(playlist.Filter.TagMode),
+		Query:    playlist.Filter.Query,
+	}
+	if !playlist.Filter.ReleasedFrom.IsZero() {
+		filter.ReleasedFrom = playlist.Filter.ReleasedFrom.Format(time.DateOnly)
+	}
+	if !playlist.Filter.ReleasedTo.IsZero() {
+		filter.ReleasedTo = playlist.Filter.ReleasedTo.Format(time.DateOnly)
+	}
+
+	return &SmartPlaylistResponse{
+		ID:        playlist.ID.String(),
+		Name:      playlist.Name,
+		Filter:    filter,
+		CreatedAt: playlist.CreatedAt,
+		UpdatedAt: playlist.UpdatedAt,
+	}
+}
+
+func ShareToResponse(share *domain.Share, token, url string) *ShareResponse {
+	response := &ShareResponse{
+		ID:        share.ID.String(),
+		Token:     token,
+		URL:       url,
+		ExpiresAt: share.ExpiresAt,
+		CreatedAt: share.CreatedAt,
+		RevokedAt: share.RevokedAt,
+	}
+	if share.SongID != nil {
+		songID := share.SongID.String()
+		response.SongID = &songID
+	}
+	if share.PlaylistID != nil {
+		playlistID := share.PlaylistID.String()
+		response.PlaylistID = &playlistID
+	}
+	return response
+}
+
+func RatingToResponse(rating *domain.Rating) *RatingResponse {
+	response := &RatingResponse{
+		SongID:        rating.SongID.String(),
+		Rating:        rating.Value,
+		RatingAverage: rating.Average,
+		RatingsCount:  rating.Count,
+	}
+	if rating.Value != 0 {
+		response.RatedAt = &rating.RatedAt
+	}
+	return response
+}
+
+func LibraryToResponse(library *domain.Library) *LibraryResponse {
+	return &LibraryResponse{
+		ID:        library.ID.String(),
+		Name:      library.Name,
+		CreatedAt: library.CreatedAt,
+	}
+}
+
+func UserToResponse(user *domain.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID.String(),
+		Role:      string(user.Role),
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+func APIKeyToResponse(key *domain.APIKey) *APIKeyResponse {
+	response := &APIKeyResponse{
+		ID:        key.ID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Role:      string(key.Role),
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+	if key.LibraryID != nil {
+		libraryID := key.LibraryID.String()
+		response.LibraryID = &libraryID
+	}
+	return response
+}
+
+func BackupReportToResponse(report *domain.BackupReport) *BackupReportResponse {
+	return &BackupReportResponse{
+		SchemaVersion: report.SchemaVersion,
+		Tables:        report.Tables,
+		DryRun:        report.DryRun,
+	}
+}
+
+func NormalizeReportToResponse(report *domain.NormalizeReport) *NormalizeReportResponse {
+	return &NormalizeReportResponse{
+		Scanned: report.Scanned,
+		Changed: report.Changed,
+		Failed:  report.Failed,
+		DryRun:  report.DryRun,
+	}
+}
+
+func ContentScanReportToResponse(report *domain.ContentScanReport) *ContentScanReportResponse {
+	return &ContentScanReportResponse{
+		Scanned: report.Scanned,
+		Flagged: report.Flagged,
+		Failed:  report.Failed,
+		DryRun:  report.DryRun,
+	}
+}
+
+func CacheStatsToResponse(stats *domain.CacheStats) *CacheStatsResponse {
+	return &CacheStatsResponse{
+		Keys:            stats.Keys,
+		Hits:            stats.Hits,
+		Misses:          stats.Misses,
+		HitRate:         stats.HitRate(),
+		UsedMemoryBytes: stats.UsedMemory,
+	}
+}
+
+func SongEventToPayload(event domain.SongEvent) *WebhookPayload {
+	return &WebhookPayload{
+		Event:      string(event.Type),
+		OccurredAt: event.OccurredAt,
+		Song:       SongToDTO(event.Song),
+	}
+}
+
+// LyricsToVerse converts the verse at number of the song lyrics, linking the
+// neighbouring verses under textPath
+func LyricsToVerse(lyrics *domain.Lyrics, section *domain.LyricsSection, number int, textPath string) *VerseResponse {
+	response := &VerseResponse{
+		Number: number,
+		Total:  len(lyrics.Sections),
+		Type:   string(section.Type),
+		Index:  section.Index,
+		Text:   section.Text,
+	}
+	if number > 1 {
+		response.Prev = textPath + "/" + strconv.Itoa(number-1)
+	}
+	if number < response.Total {
+		response.Next = textPath + "/" + strconv.Itoa(number+1)
+	}
+	return response
+}
+
+// VerseMatchesToResponse converts the sections matching a search, linking
+// them under textPath
+func VerseMatchesToResponse(matches []*domain.VerseMatch, textPath string) *VerseSearchResponse {
+	response := &VerseSearchResponse{Matches: make([]VerseMatchResponse, 0, len(matches))}
+
+	for _, match := range matches {
+		lines := make([]LineMatchResponse, 0, len(match.Lines))
+		for _, line := range match.Lines {
+			lines = append(lines, LineMatchResponse{Line: line.Number, Text: line.Text})
+		}
+		response.Matches = append(response.Matches, VerseMatchResponse{
+			Number: match.Number,
+			Type:   string(match.Section.Type),
+			Index:  match.Section.Index,
+			Link:   textPath + "/" + strconv.Itoa(match.Number),
+			Lines:  lines,
+		})
+	}
+
+	return response
+}
+
+func LyricsToPaginatedText(lyrics *domain.Lyrics) *PaginatedTextResponse {
+	response := &PaginatedTextResponse{
+		Text:     lyrics.Verses(),
+		Sections: make([]LyricsSectionResponse, 0, len(lyrics.Sections)),
+	}
+
+	for _, section := range lyrics.Sections {
+		response.Sections = append(response.Sections, LyricsSectionResponse{
+			Type:  string(section.Type),
+			Index: section.Index,
+			Text:  section.Text,
+		})
+	}
+
+	return response
+}
+
+// LyricsPageToPaginatedText converts a page of the song text, linking the
+// neighbouring pages under textPath with the query of the request
+func LyricsPageToPaginatedText(page *domain.LyricsPage, textPath string, query url.Values) *PaginatedTextResponse {
+	response := LyricsToPaginatedText(&page.Lyrics)
+	response.Page = &TextPageResponse{
+		Number:        page.Number,
+		VersesPerPage: page.VersesPerPage,
+		TotalPages:    page.TotalPages,
+		TotalVerses:   page.TotalVerses,
+		FirstVerse:    page.FirstVerse(),
+	}
+
+	link := func(number int) string {
+		linkQuery := url.Values{}
+		for key, values := range query {
+			linkQuery[key] = values
+		}
+		linkQuery.Set("page", strconv.Itoa(number))
+		return textPath + "?" + linkQuery.Encode()
+	}
+	if page.Number > 1 {
+		response.Page.Prev = link(page.Number - 1)
+	}
+	if page.Number < page.TotalPages {
+		response.Page.Next = link(page.Number + 1)
 	}
+	return response
 }
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"log/slog"
+	"songLibrary/internal/domain"
+	"sync"
+)
+
+// Bus is an in-process publish/subscribe bus for song events. Publishing
+// never blocks: a subscriber that doesn't keep up loses events instead of
+// slowing down the mutation that published them.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[chan domain.SongEvent]struct{}
+	buffer      int
+	log         *slog.Logger
+}
+
+// NewBus creates a Bus, every subscriber can lag behind by up to buffer events
+func NewBus(buffer int, log *slog.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[chan domain.SongEvent]struct{}),
+		buffer:      buffer,
+		log:         log.With(slog.String("component", "events/bus")),
+	}
+}
+
+func (b *Bus) Publish(event domain.SongEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.log.Warn("subscriber is too slow, event dropped", slog.String("type", string(event.Type)))
+		}
+	}
+}
+
+// Subscribe returns a channel receiving all events published from now on and
+// a function that cancels the subscription and closes the channel.
+func (b *Bus) Subscribe() (<-chan domain.SongEvent, func()) {
+	ch := make(chan domain.SongEvent, b.buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishToSubscribers(t *testing.T) {
+	bus := NewBus(1, slog.New(slogdiscard.NewDiscardHandler()))
+
+	first, unsubscribeFirst := bus.Subscribe()
+	second, unsubscribeSecond := bus.Subscribe()
+	defer unsubscribeSecond()
+
+	bus.Publish(domain.SongEvent{Type: domain.SongCreated})
+
+	assert.Equal(t, domain.SongCreated, (<-first).Type)
+	assert.Equal(t, domain.SongCreated, (<-second).Type)
+
+	// После отписки канал закрывается и события больше не приходят
+	unsubscribeFirst()
+	unsubscribeFirst()
+	bus.Publish(domain.SongEvent{Type: domain.SongDeleted})
+
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, domain.SongDeleted, (<-second).Type)
+}
+
+func TestBus_SlowSubscriberDoesNotBlock(t *testing.T) {
+	bus := NewBus(1, slog.New(slogdiscard.NewDiscardHandler()))
+
+	ch, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	// Второе событие не помещается в буфер и отбрасывается
+	bus.Publish(domain.SongEvent{Type: domain.SongCreated})
+	bus.Publish(domain.SongEvent{Type: domain.SongUpdated})
+
+	assert.Equal(t, domain.SongCreated, (<-ch).Type)
+	assert.Len(t, ch, 0)
+}
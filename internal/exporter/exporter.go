@@ -0,0 +1,147 @@
+package exporter
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const releaseDateLayout = "2006-01-02"
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format is a document format a song can be exported to
+type Format string
+
+const (
+	FormatText     Format = "txt"
+	FormatMarkdown Format = "md"
+	FormatPDF      Format = "pdf"
+)
+
+// ParseFormat returns the format with the name, the plain text format when
+// the name is empty
+func ParseFormat(name string) (Format, error) {
+	const op = "exporter.ParseFormat"
+
+	switch format := Format(strings.ToLower(name)); format {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatMarkdown, FormatPDF:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%s: %w: %q", op, ErrUnsupportedFormat, name)
+	}
+}
+
+// ContentType is the media type of documents in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Document is a song rendered in an export format
+type Document struct {
+	FileName    string
+	ContentType string
+	Body        []byte
+}
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format(releaseDateLayout)
+	},
+	"markdown": markdownEscaper.Replace,
+	// verse keeps the line breaks of a verse as markdown hard breaks
+	"verse": func(verse string) string {
+		lines := strings.Split(verse, "\n")
+		for i, line := range lines {
+			lines[i] = markdownEscaper.Replace(line)
+		}
+		return strings.Join(lines, "  \n")
+	},
+}).ParseFS(templatesFS, "templates/*.tmpl"))
+
+// markdownEscaper escapes the characters markdown would treat as formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `#`, `\#`,
+	`[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `|`, `\|`,
+)
+
+// song is the data the templates are executed with
+type song struct {
+	*domain.Song
+	// Verses are the paragraphs of the text, lines within a verse are
+	// separated by a newline
+	Verses []string
+}
+
+// Export renders the name, group, release date and text of a song. PDF
+// documents are laid out from the plain text rendering.
+func Export(s *domain.Song, format Format) (*Document, error) {
+	const op = "exporter.Export"
+
+	text := format
+	if format == FormatPDF {
+		text = FormatText
+	}
+
+	var buf bytes.Buffer
+	data := song{Song: s, Verses: verses(s.Text)}
+	if err := templates.ExecuteTemplate(&buf, "song."+string(text)+".tmpl", data); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	body := buf.Bytes()
+	if format == FormatPDF {
+		body = renderPDF(s.Group+" - "+s.Name, buf.String())
+	}
+
+	return &Document{
+		FileName:    fileName(s) + "." + string(format),
+		ContentType: format.ContentType(),
+		Body:        body,
+	}, nil
+}
+
+// verses splits a song text into paragraphs separated by blank lines
+func verses(text string) []string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+
+	var result []string
+	for _, verse := range strings.Split(text, "\n\n") {
+		if verse = strings.Trim(verse, "\n"); verse != "" {
+			result = append(result, verse)
+		}
+	}
+	return result
+}
+
+// fileName is "<group> - <name>" without characters that aren't allowed in
+// file names or would break the Content-Disposition header
+func fileName(s *domain.Song) string {
+	name := strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return -1
+		}
+		return r
+	}, s.Group+" - "+s.Name)
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSong() *domain.Song {
+	return &domain.Song{
+		Name:        "Hysteria",
+		Group:       "Muse",
+		Text:        "It's bugging me\nGrating me\n\nAnd twisting me around",
+		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": FormatText, "txt": FormatText, "MD": FormatMarkdown, "pdf": FormatPDF} {
+		format, err := ParseFormat(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, format, name)
+	}
+
+	_, err := ParseFormat("docx")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestExport_Text(t *testing.T) {
+	document, err := Export(testSong(), FormatText)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Muse - Hysteria.txt", document.FileName)
+	assert.Equal(t, "text/plain; charset=utf-8", document.ContentType)
+	assert.Equal(t, "Hysteria\nMuse\nReleased: 2003-12-01\n\nIt's bugging me\nGrating me\n\nAnd twisting me around\n", string(document.Body))
+}
+
+func TestExport_Markdown(t *testing.T) {
+	song := testSong()
+	song.Name = "Hysteria_*live*"
+	song.ReleaseDate = time.Time{}
+
+	document, err := Export(song, FormatMarkdown)
+	require.NoError(t, err)
+
+	// Разметка в названии экранируется, строки куплета разделяются жёстким переносом
+	assert.Equal(t, "# Hysteria\\_\\*live\\*\n\n**Muse**\n\nIt's bugging me  \nGrating me\n\nAnd twisting me around\n", string(document.Body))
+}
+
+func TestExport_PDF(t *testing.T) {
+	song := testSong()
+	song.Name = "Café (Live)"
+	song.Text = strings.Repeat("word ", 40) + "\nПривет"
+
+	document, err := Export(song, FormatPDF)
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/pdf", document.ContentType)
+	assert.Equal(t, "Muse - Café (Live).pdf", document.FileName)
+
+	body := document.Body
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(body, []byte("%%EOF\n")))
+
+	// Символы Latin-1 кодируются в WinAnsi, скобки экранируются, кириллица заменяется
+	assert.Contains(t, string(body), "(Caf\xe9 \\(Live\\)) Tj")
+	assert.Contains(t, string(body), "(??????) Tj")
+
+	// Длинная строка переносится по словам
+	assert.Contains(t, string(body), "("+strings.Repeat("word ", 16)+"word) Tj")
+
+	// Смещения в таблице xref указывают на начала объектов
+	xref := regexp.MustCompile(`(?m)^(\d{10}) 00000 n $`).FindAllSubmatch(body, -1)
+	require.Len(t, xref, 7)
+	for i, entry := range xref {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(body[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), i+1)
+	}
+
+	start := bytes.LastIndex(body, []byte("startxref\n"))
+	offset, err := strconv.Atoi(strings.Fields(string(body[start+len("startxref\n"):]))[0])
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body[offset:], []byte("xref\n")))
+}
+
+func TestWrapLines_Pages(t *testing.T) {
+	lines := wrapLines([]string{strings.Repeat("a", 100), "short"}, 85)
+	assert.Equal(t, []string{strings.Repeat("a", 85), strings.Repeat("a", 15), "short"}, lines)
+
+	// Текст длиннее страницы раскладывается на несколько страниц
+	body := renderPDF("Muse - Long", strings.Repeat("line\n", pageLines+1))
+	assert.Contains(t, string(body), "/Count 2")
+}
@@ -0,0 +1,152 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// A4 page with the text laid out in a single column
+const (
+	pageWidth   = 595
+	pageHeight  = 842
+	pageMargin  = 56
+	fontSize    = 11
+	titleSize   = 16
+	lineHeight  = 14
+	lineRunes   = 85
+	pageLines   = (pageHeight - 2*pageMargin) / lineHeight
+	replacement = '?'
+)
+
+// renderPDF lays out text in Helvetica, the first line as the title. Long
+// lines are wrapped on words. The standard PDF fonts only cover the Windows
+// Latin character set, other characters are printed as question marks.
+func renderPDF(title, text string) []byte {
+	lines := wrapLines(strings.Split(strings.TrimRight(text, "\n"), "\n"), lineRunes)
+
+	var pages [][]string
+	for len(lines) > pageLines {
+		pages = append(pages, lines[:pageLines])
+		lines = lines[pageLines:]
+	}
+	pages = append(pages, lines)
+
+	var doc pdfWriter
+	doc.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed, every page then takes its page and content objects
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 6+2*i))
+	}
+
+	doc.object("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	doc.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	doc.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	doc.object("<< /Title " + pdfString(title) + " /Producer (songLibrary) >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "%d %d Td\n%d TL\n", pageMargin, pageHeight-pageMargin-lineHeight, lineHeight)
+		for j, line := range page {
+			if i == 0 && j == 0 {
+				fmt.Fprintf(&content, "/F2 %d Tf\n%s Tj T*\n/F1 %d Tf\n", titleSize, pdfString(line), fontSize)
+				continue
+			}
+			if j == 0 {
+				fmt.Fprintf(&content, "/F1 %d Tf\n", fontSize)
+			}
+			fmt.Fprintf(&content, "%s Tj T*\n", pdfString(line))
+		}
+		content.WriteString("ET")
+
+		doc.object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i,
+		))
+		doc.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	return doc.finish(1, 5)
+}
+
+// pdfWriter writes the numbered objects of a PDF file and keeps their offsets
+// for the cross-reference table
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *pdfWriter) finish(root, info int) []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(w.offsets)+1, root, info, xref)
+	return w.buf.Bytes()
+}
+
+// wrapLines splits lines longer than width runes on spaces, words longer
+// than a line are cut
+func wrapLines(lines []string, width int) []string {
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \r\t")
+		for utf8.RuneCountInString(line) > width {
+			runes := []rune(line)
+			cut := strings.LastIndex(string(runes[:width+1]), " ")
+			if cut <= 0 {
+				cut = len(string(runes[:width]))
+			}
+			wrapped = append(wrapped, line[:cut])
+			line = strings.TrimLeft(line[cut:], " ")
+		}
+		wrapped = append(wrapped, line)
+	}
+	return wrapped
+}
+
+// winAnsi maps the characters of the Windows-1252 code page outside of
+// Latin-1 to their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsiEncoding
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		var c byte
+		switch code, ok := winAnsi[r]; {
+		case ok:
+			c = code
+		case r == '\t':
+			c = ' '
+		case r >= ' ' && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			c = byte(r)
+		default:
+			c = replacement
+		}
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -0,0 +1,205 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported feed format")
+
+// Format is a syndication format a feed can be rendered in
+type Format string
+
+const (
+	FormatRSS  Format = "rss"
+	FormatAtom Format = "atom"
+)
+
+// ParseFormat returns the format with the name, RSS when the name is empty
+func ParseFormat(name string) (Format, error) {
+	const op = "feed.ParseFormat"
+
+	switch format := Format(strings.ToLower(name)); format {
+	case "":
+		return FormatRSS, nil
+	case FormatRSS, FormatAtom:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%s: %w: %q", op, ErrUnsupportedFormat, name)
+	}
+}
+
+// ContentType is the media type of feeds in the format
+func (f Format) ContentType() string {
+	if f == FormatAtom {
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "application/rss+xml; charset=utf-8"
+}
+
+// Feed is a list of entries, newest first. Link is the page the feed is
+// about and Self the address of the feed itself.
+type Feed struct {
+	Title       string
+	Description string
+	Link        string
+	Self        string
+	Updated     time.Time
+	Entries     []Entry
+}
+
+// Entry is an item of a feed. ID identifies it for good, readers show an
+// entry again when its ID changes.
+type Entry struct {
+	ID        string
+	Title     string
+	Author    string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// Render returns the feed as an XML document in the format
+func Render(feed *Feed, format Format) ([]byte, error) {
+	const op = "feed.Render"
+
+	var doc any
+	switch format {
+	case FormatRSS:
+		doc = newRSS(feed)
+	case FormatAtom:
+		doc = newAtom(feed)
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnsupportedFormat, format)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// RSS 2.0, https://www.rssboard.org/rss-specification
+type (
+	rssDocument struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		AtomNS  string     `xml:"xmlns:atom,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Self          atomLink  `xml:"atom:link"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string  `xml:"title"`
+		Link        string  `xml:"link"`
+		GUID        rssGUID `xml:"guid"`
+		PubDate     string  `xml:"pubDate"`
+		Description string  `xml:"description,omitempty"`
+	}
+
+	rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+)
+
+func newRSS(feed *Feed) *rssDocument {
+	doc := &rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.Link,
+			Description:   feed.Description,
+			LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+			Self:          atomLink{Href: feed.Self, Rel: "self", Type: FormatRSS.mediaType()},
+		},
+	}
+	for _, entry := range feed.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			GUID:        rssGUID{Value: entry.ID},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+			Description: entry.Summary,
+		})
+	}
+	return doc
+}
+
+// Atom 1.0, RFC 4287
+type (
+	atomDocument struct {
+		XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Title    string      `xml:"title"`
+		Subtitle string      `xml:"subtitle,omitempty"`
+		ID       string      `xml:"id"`
+		Updated  string      `xml:"updated"`
+		Links    []atomLink  `xml:"link"`
+		Entries  []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+
+	atomEntry struct {
+		Title     string     `xml:"title"`
+		ID        string     `xml:"id"`
+		Link      atomLink   `xml:"link"`
+		Author    atomAuthor `xml:"author"`
+		Published string     `xml:"published"`
+		Updated   string     `xml:"updated"`
+		Summary   string     `xml:"summary,omitempty"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+)
+
+func newAtom(feed *Feed) *atomDocument {
+	doc := &atomDocument{
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		ID:       feed.Self,
+		Updated:  feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.Link, Rel: "alternate"},
+			{Href: feed.Self, Rel: "self", Type: FormatAtom.mediaType()},
+		},
+	}
+	for _, entry := range feed.Entries {
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:     entry.Title,
+			ID:        entry.ID,
+			Link:      atomLink{Href: entry.Link, Rel: "alternate"},
+			Author:    atomAuthor{Name: entry.Author},
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Summary:   entry.Summary,
+		})
+	}
+	return doc
+}
+
+// mediaType is ContentType without parameters, as used in links
+func (f Format) mediaType() string {
+	mediaType, _, _ := strings.Cut(f.ContentType(), ";")
+	return mediaType
+}
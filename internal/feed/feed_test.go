@@ -0,0 +1,117 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() *Feed {
+	added := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &Feed{
+		Title:       "songLibrary",
+		Description: "Songs recently added to songLibrary",
+		Link:        "https://songs.example.com/songs/recent",
+		Self:        "https://songs.example.com/feeds/songs.xml",
+		Updated:     added,
+		Entries: []Entry{{
+			ID:        "urn:uuid:1c5d3b8e-0000-0000-0000-000000000000",
+			Title:     "Muse — Hysteria",
+			Author:    "Muse",
+			Link:      "https://songs.example.com/songs/1c5d3b8e-0000-0000-0000-000000000000",
+			Summary:   "It's bugging me <3 & grating me",
+			Published: added,
+			Updated:   added.Add(time.Hour),
+		}},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": FormatRSS, "rss": FormatRSS, "ATOM": FormatAtom} {
+		format, err := ParseFormat(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, format, name)
+	}
+
+	_, err := ParseFormat("json")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestRender_RSS(t *testing.T) {
+	body, err := Render(testFeed(), FormatRSS)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), xml.Header))
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			Link          string `xml:"link"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				GUID        string `xml:"guid"`
+				PubDate     string `xml:"pubDate"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "https://songs.example.com/songs/recent", doc.Channel.Link)
+	assert.Equal(t, "Fri, 01 Mar 2024 12:00:00 +0000", doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 1)
+	assert.Equal(t, "Muse — Hysteria", doc.Channel.Items[0].Title)
+	assert.Equal(t, "urn:uuid:1c5d3b8e-0000-0000-0000-000000000000", doc.Channel.Items[0].GUID)
+	assert.Equal(t, "Fri, 01 Mar 2024 12:00:00 +0000", doc.Channel.Items[0].PubDate)
+	// Текст экранируется
+	assert.Equal(t, "It's bugging me <3 & grating me", doc.Channel.Items[0].Description)
+	assert.Contains(t, string(body), `isPermaLink="false"`)
+	assert.Contains(t, string(body), `<atom:link href="https://songs.example.com/feeds/songs.xml" rel="self" type="application/rss+xml">`)
+}
+
+func TestRender_Atom(t *testing.T) {
+	body, err := Render(testFeed(), FormatAtom)
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Links   []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Author    string `xml:"author>name"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Link      struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "https://songs.example.com/feeds/songs.xml", doc.ID)
+	assert.Equal(t, "2024-03-01T12:00:00Z", doc.Updated)
+	require.Len(t, doc.Links, 2)
+	assert.Equal(t, "self", doc.Links[1].Rel)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "Muse", doc.Entries[0].Author)
+	assert.Equal(t, "2024-03-01T12:00:00Z", doc.Entries[0].Published)
+	assert.Equal(t, "2024-03-01T13:00:00Z", doc.Entries[0].Updated)
+	assert.Equal(t, "https://songs.example.com/songs/1c5d3b8e-0000-0000-0000-000000000000", doc.Entries[0].Link.Href)
+}
+
+func TestRender_UnsupportedFormat(t *testing.T) {
+	_, err := Render(testFeed(), Format("json"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
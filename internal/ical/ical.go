@@ -0,0 +1,94 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of calendars
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineLength is the length content lines are folded at in octets,
+// without the line break
+const maxLineLength = 75
+
+// textEscaper escapes the characters with a meaning in TEXT values
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Calendar is a list of all-day events. Stamp is when the calendar was
+// generated.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Stamp  time.Time
+	Events []Event
+}
+
+// Event is an all-day event on Date. A yearly event recurs on the day of
+// every following year, like an anniversary.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Date        time.Time
+	Yearly      bool
+}
+
+// Render returns the calendar as an iCalendar document (RFC 5545)
+func Render(cal *Calendar) []byte {
+	var b bytes.Buffer
+	stamp := cal.Stamp.UTC().Format("20060102T150405Z")
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+cal.ProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	if cal.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+textEscaper.Replace(cal.Name))
+	}
+
+	for _, event := range cal.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+event.UID)
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, "DTSTART;VALUE=DATE:"+event.Date.Format("20060102"))
+		if event.Yearly {
+			writeLine(&b, "RRULE:FREQ=YEARLY")
+		}
+		writeLine(&b, "SUMMARY:"+textEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+textEscaper.Replace(event.Description))
+		}
+		if event.URL != "" {
+			writeLine(&b, "URL:"+event.URL)
+		}
+		writeLine(&b, "TRANSP:TRANSPARENT")
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+// writeLine writes a content line ending with CRLF, folding it into lines of
+// at most maxLineLength octets continued with a leading space. Characters
+// are never split across lines.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts towards the length of continuation lines
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,66 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	cal := &Calendar{
+		ProdID: "-//songLibrary//Release calendar//EN",
+		Name:   "Song releases",
+		Stamp:  time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				UID:     "1@songLibrary",
+				Summary: "Muse — Hysteria; live, remastered",
+				URL:     "https://songs.example.com/songs/1",
+				Date:    time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+				Yearly:  true,
+			},
+			{
+				UID:         "2@songLibrary",
+				Summary:     "Muse — Starlight",
+				Description: "Line one\nLine two",
+				Date:        time.Date(2006, 9, 4, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	body := string(Render(cal))
+
+	// Строки заканчиваются CRLF
+	assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(body, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"))
+	assert.Contains(t, body, "DTSTAMP:20240301T123000Z\r\n")
+	assert.Contains(t, body, "DTSTART;VALUE=DATE:20031201\r\nRRULE:FREQ=YEARLY\r\n")
+	assert.Equal(t, 1, strings.Count(body, "RRULE"))
+	// Спецсимволы текста экранируются
+	assert.Contains(t, body, `SUMMARY:Muse — Hysteria\; live\, remastered`)
+	assert.Contains(t, body, `DESCRIPTION:Line one\nLine two`)
+}
+
+func TestRender_FoldsLongLines(t *testing.T) {
+	cal := &Calendar{
+		ProdID: "-//songLibrary//Release calendar//EN",
+		Events: []Event{{UID: "1", Summary: strings.Repeat("ё", 100), Date: time.Now()}},
+	}
+
+	body := string(Render(cal))
+
+	var summary []string
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		// Длина строки не больше 75 октетов, символы не разрываются
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, line)
+		if strings.HasPrefix(line, "SUMMARY:") || (len(summary) > 0 && strings.HasPrefix(line, " ")) {
+			summary = append(summary, strings.TrimPrefix(line, " "))
+		}
+	}
+	assert.Greater(t, len(summary), 1)
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ё", 100), strings.Join(summary, ""))
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+)
+
+const releaseDateLayout = "2006-01-02"
+
+const (
+	columnName        = "name"
+	columnGroup       = "group"
+	columnText        = "text"
+	columnLink        = "link"
+	columnReleaseDate = "release_date"
+)
+
+var (
+	ErrInvalidHeader    = errors.New("invalid csv header")
+	ErrEmptyFile        = errors.New("csv file is empty")
+	ErrMissingColumn    = errors.New("required column is missing")
+	ErrDuplicateColumn  = errors.New("column is duplicated")
+	ErrInvalidRowLength = errors.New("row has wrong number of fields")
+)
+
+// Record is a successfully parsed CSV row together with its line number.
+type Record struct {
+	Line int
+	Song *domain.Song
+}
+
+// Parse reads songs from CSV. The first row must be a header containing at
+// least the name and group columns; text, link and release_date are optional
+// and unknown columns are ignored. Rows that cannot be parsed are reported
+// per line instead of failing the whole file.
+func Parse(r io.Reader) ([]Record, []domain.ImportRowError, error) {
+	const op = "importer.Parse"
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidHeader, ErrEmptyFile)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidHeader, err)
+	}
+
+	columns, err := mapColumns(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidHeader, err)
+	}
+
+	var (
+		records   []Record
+		rowErrors []domain.ImportRowError
+	)
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, domain.ImportRowError{Line: parseErr.Line, Err: parseErr.Err})
+				continue
+			}
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(row) != len(header) {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: line, Err: ErrInvalidRowLength})
+			continue
+		}
+
+		song, err := parseRow(row, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		records = append(records, Record{Line: line, Song: song})
+	}
+
+	return records, rowErrors, nil
+}
+
+// mapColumns returns the index of every known column in the header.
+func mapColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateColumn, name)
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{columnName, columnGroup} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, required)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseRow(row []string, columns map[string]int) (*domain.Song, error) {
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	song := &domain.Song{
+		Name:  field(columnName),
+		Group: field(columnGroup),
+		Text:  field(columnText),
+		Link:  field(columnLink),
+	}
+
+	switch {
+	case song.Name == "" && song.Group == "":
+		return nil, domain.ErrSongNameAndGroupIsNull
+	case song.Name == "":
+		return nil, domain.ErrSongNameIsNull
+	case song.Group == "":
+		return nil, domain.ErrSongGroupIsNull
+	}
+
+	if releaseDate := field(columnReleaseDate); releaseDate != "" {
+		parsed, err := time.Parse(releaseDateLayout, releaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidReleaseDate, releaseDate)
+		}
+		song.ReleaseDate = parsed
+	}
+
+	return song, nil
+}
@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Success(t *testing.T) {
+	input := "name,group,text,link,release_date\n" +
+		"Hysteria,Muse,It's bugging me...,https://link-to-song.com,2003-12-01\n" +
+		"Time is Running Out,Muse,,,\n"
+
+	records, rowErrors, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, records, 2)
+
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, "Hysteria", records[0].Song.Name)
+	assert.Equal(t, "Muse", records[0].Song.Group)
+	assert.Equal(t, "It's bugging me...", records[0].Song.Text)
+	assert.Equal(t, "https://link-to-song.com", records[0].Song.Link)
+	assert.Equal(t, time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC), records[0].Song.ReleaseDate)
+
+	assert.Equal(t, 3, records[1].Line)
+	assert.True(t, records[1].Song.ReleaseDate.IsZero())
+}
+
+func TestParse_ColumnOrderAndCase(t *testing.T) {
+	input := "Group,Name\nMuse,Hysteria\n"
+
+	records, rowErrors, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "Hysteria", records[0].Song.Name)
+	assert.Equal(t, "Muse", records[0].Song.Group)
+}
+
+func TestParse_RowErrors(t *testing.T) {
+	input := "name,group,release_date\n" +
+		"Hysteria,Muse,2003-12-01\n" +
+		",Muse,\n" +
+		"Hysteria,,\n" +
+		"Hysteria,Muse,01.12.2003\n" +
+		"Hysteria,Muse\n"
+
+	records, rowErrors, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Len(t, rowErrors, 4)
+
+	assert.Equal(t, 3, rowErrors[0].Line)
+	assert.ErrorIs(t, rowErrors[0].Err, domain.ErrSongNameIsNull)
+	assert.Equal(t, 4, rowErrors[1].Line)
+	assert.ErrorIs(t, rowErrors[1].Err, domain.ErrSongGroupIsNull)
+	assert.Equal(t, 5, rowErrors[2].Line)
+	assert.ErrorIs(t, rowErrors[2].Err, domain.ErrInvalidReleaseDate)
+	assert.Equal(t, 6, rowErrors[3].Line)
+	assert.ErrorIs(t, rowErrors[3].Err, ErrInvalidRowLength)
+}
+
+func TestParse_InvalidHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty file", input: "", err: ErrEmptyFile},
+		{name: "missing group", input: "name,text\nHysteria,text\n", err: ErrMissingColumn},
+		{name: "duplicated column", input: "name,group,name\n", err: ErrDuplicateColumn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Parse(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, ErrInvalidHeader)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
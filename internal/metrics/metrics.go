@@ -0,0 +1,47 @@
+// Package metrics publishes runtime metrics of the service with expvar and
+// serves them as JSON.
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Handler serves every published metric as a JSON object
+func Handler() http.Handler {
+	return expvar.Handler()
+}
+
+// PublishFunc publishes the value returned by fn under name, fn is called on
+// every request
+func PublishFunc(name string, fn func() any) {
+	expvar.Publish(name, expvar.Func(fn))
+}
+
+// PublishPool publishes the statistics of a pgx pool under name. The values
+// are read on every request, so the gauges are always current.
+func PublishPool(name string, pool *pgxpool.Pool) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return PoolStats(pool.Stat())
+	}))
+}
+
+// PoolStats converts pgx pool statistics to a JSON friendly map
+func PoolStats(stat *pgxpool.Stat) map[string]int64 {
+	return map[string]int64{
+		"max_conns":                  int64(stat.MaxConns()),
+		"total_conns":                int64(stat.TotalConns()),
+		"idle_conns":                 int64(stat.IdleConns()),
+		"acquired_conns":             int64(stat.AcquiredConns()),
+		"constructing_conns":         int64(stat.ConstructingConns()),
+		"acquire_count":              stat.AcquireCount(),
+		"acquire_duration_ms":        stat.AcquireDuration().Milliseconds(),
+		"empty_acquire_count":        stat.EmptyAcquireCount(),
+		"canceled_acquire_count":     stat.CanceledAcquireCount(),
+		"new_conns_count":            stat.NewConnsCount(),
+		"max_lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+		"max_idle_destroy_count":     stat.MaxIdleDestroyCount(),
+	}
+}
@@ -0,0 +1,157 @@
+// Package musicinfomock is a test double of the external MusicInfo API. It
+// answers GET /info like the real API, with configurable failures and
+// latency, and can run in-process:
+//
+//	srv := httptest.NewServer(musicinfomock.New(musicinfomock.Config{Seed: 1}, log))
+//	defer srv.Close()
+package musicinfomock
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReleaseDateLayout is the date format of the MusicInfo API
+const ReleaseDateLayout = "02.01.2006"
+
+var (
+	texts = []string{
+		"Ooh baby, don't you know I suffer?",
+		"Ooh baby, can you hear me moan?",
+		"You caught me under false pretenses.",
+		"How long before you let me go?",
+	}
+	links = []string{
+		"https://example.com/song1",
+		"https://example.com/song2",
+		"https://example.com/song3",
+		"https://example.com/song4",
+	}
+)
+
+// SongDetail is the response of GET /info
+type SongDetail struct {
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type Config struct {
+	// ErrorRate is the share of requests from 0 to 1 answered with 400, as
+	// the API does for songs it doesn't know
+	ErrorRate float64
+	// Latency delays every response, Jitter adds a random delay up to it
+	Latency time.Duration
+	Jitter  time.Duration
+	// ReleaseDateLayout formats release dates, so clients can be tested
+	// against APIs that format them differently. Defaults to the package
+	// ReleaseDateLayout.
+	ReleaseDateLayout string
+	// Seed makes failures and generated songs repeat from run to run, 0
+	// seeds from the current time
+	Seed int64
+}
+
+type Server struct {
+	cfg Config
+	// Now is the time release dates are generated back from
+	Now func() time.Time
+	log *slog.Logger
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	requests atomic.Int64
+	mux      *http.ServeMux
+}
+
+func New(cfg Config, log *slog.Logger) *Server {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	if cfg.ReleaseDateLayout == "" {
+		cfg.ReleaseDateLayout = ReleaseDateLayout
+	}
+
+	s := &Server{
+		cfg:  cfg,
+		Now:  time.Now,
+		log:  log,
+		rand: rand.New(rand.NewSource(seed)),
+		mux:  http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /info", s.info)
+	s.mux.HandleFunc("GET /health", s.health)
+	return s
+}
+
+// Requests is the number of /info requests served so far
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+
+	group := r.URL.Query().Get("group")
+	name := r.URL.Query().Get("song")
+
+	log := s.log.With(slog.String("group", group), slog.String("song", name))
+
+	// draw everything up front, so the sequence only depends on the seed and
+	// the order of requests
+	s.mu.Lock()
+	fail := s.rand.Float64() < s.cfg.ErrorRate
+	delay := s.cfg.Latency
+	if s.cfg.Jitter > 0 {
+		delay += time.Duration(s.rand.Int63n(int64(s.cfg.Jitter)))
+	}
+	song := SongDetail{
+		Name:        name,
+		Group:       group,
+		ReleaseDate: s.Now().AddDate(0, 0, -s.rand.Intn(365*10)).Format(s.cfg.ReleaseDateLayout),
+		Text:        texts[s.rand.Intn(len(texts))],
+		Link:        links[s.rand.Intn(len(links))],
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if group == "" || name == "" {
+		log.Info("missing group or name")
+		http.Error(w, "Missing group or name", http.StatusBadRequest)
+		return
+	}
+
+	if fail {
+		log.Info("bad request")
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(song)
+}
+
+func (s *Server) health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
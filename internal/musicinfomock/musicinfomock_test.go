@@ -0,0 +1,116 @@
+package musicinfomock
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(cfg Config) *Server {
+	s := New(cfg, slog.New(slogdiscard.NewDiscardHandler()))
+	s.Now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func fetch(t *testing.T, s *Server, target string) (*httptest.ResponseRecorder, *SongDetail) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		return rec, nil
+	}
+
+	var song SongDetail
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&song))
+	return rec, &song
+}
+
+func TestServer_Info(t *testing.T) {
+	s := newTestServer(Config{Seed: 1})
+
+	rec, song := fetch(t, s, "/info?group=Muse&song=Hysteria")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Hysteria", song.Name)
+	assert.Equal(t, "Muse", song.Group)
+	assert.Contains(t, texts, song.Text)
+	assert.Contains(t, links, song.Link)
+
+	releaseDate, err := time.Parse(ReleaseDateLayout, song.ReleaseDate)
+	require.NoError(t, err)
+	assert.True(t, releaseDate.After(time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert.EqualValues(t, 1, s.Requests())
+}
+
+func TestServer_Info_MissingParams(t *testing.T) {
+	s := newTestServer(Config{Seed: 1})
+
+	rec, _ := fetch(t, s, "/info?group=Muse")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_Info_ErrorRate(t *testing.T) {
+	// При error rate 1 любой запрос завершается ошибкой
+	s := newTestServer(Config{ErrorRate: 1, Seed: 1})
+	for range 10 {
+		rec, _ := fetch(t, s, "/info?group=Muse&song=Hysteria")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+	assert.EqualValues(t, 10, s.Requests())
+}
+
+func TestServer_Info_Deterministic(t *testing.T) {
+	// Один и тот же seed даёт одинаковую последовательность ответов
+	results := func() []int {
+		s := newTestServer(Config{ErrorRate: 0.5, Seed: 42})
+		codes := make([]int, 0, 20)
+		for range 20 {
+			rec, _ := fetch(t, s, "/info?group=Muse&song=Hysteria")
+			codes = append(codes, rec.Code)
+		}
+		return codes
+	}
+
+	first := results()
+	assert.Equal(t, first, results())
+	assert.Contains(t, first, http.StatusOK)
+	assert.Contains(t, first, http.StatusBadRequest)
+}
+
+func TestServer_Info_Latency(t *testing.T) {
+	s := newTestServer(Config{Latency: 50 * time.Millisecond, Seed: 1})
+
+	start := time.Now()
+	rec, _ := fetch(t, s, "/info?group=Muse&song=Hysteria")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// Отменённый запрос не ждёт окончания задержки
+	s = newTestServer(Config{Latency: time.Minute, Seed: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start = time.Now()
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/info?group=Muse&song=Hysteria", nil).WithContext(ctx))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestServer_Health(t *testing.T) {
+	s := newTestServer(Config{ErrorRate: 1})
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, rec.Body.String())
+	assert.Zero(t, s.Requests())
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AlbumDatabase interface {
+	CreateAlbum(ctx context.Context, album *domain.Album) error
+	ReadAlbum(ctx context.Context, id uuid.UUID) (*domain.Album, error)
+	ReadAllAlbums(ctx context.Context, group string, limit, offset int) ([]*domain.Album, error)
+	UpdateAlbum(ctx context.Context, album *domain.Album) error
+	DeleteAlbum(ctx context.Context, id uuid.UUID) error
+
+	ReadAlbumSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+// AlbumRepository records deleted albums in cache as modifications of the
+// library, their songs lose the album
+type AlbumRepository struct {
+	db    AlbumDatabase
+	cache ModifiedCache
+	log   *slog.Logger
+}
+
+func NewAlbumRepository(db AlbumDatabase, cache ModifiedCache, log *slog.Logger) *AlbumRepository {
+	return &AlbumRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (r *AlbumRepository) Create(ctx context.Context, album *domain.Album) error {
+	const op = "AlbumRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("album_title", album.Title), slog.String("group_name", album.Group))
+
+	log.Debug("creating album in database")
+	if err := r.db.CreateAlbum(ctx, album); err != nil {
+		log.Error("failed to create album in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("album successfully created")
+	return nil
+}
+
+func (r *AlbumRepository) Read(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	const op = "AlbumRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("album_id", id.String()))
+
+	log.Debug("fetching album from database")
+	album, err := r.db.ReadAlbum(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch album from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("album successfully fetched")
+	return album, nil
+}
+
+func (r *AlbumRepository) ReadAll(ctx context.Context, group string, limit, offset int) ([]*domain.Album, error) {
+	const op = "AlbumRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("group_name", group))
+
+	log.Debug("fetching albums from database")
+	albums, err := r.db.ReadAllAlbums(ctx, group, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch albums from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("albums successfully fetched")
+	return albums, nil
+}
+
+func (r *AlbumRepository) Update(ctx context.Context, album *domain.Album) error {
+	const op = "AlbumRepository.Update"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("album_id", album.ID.String()))
+
+	log.Debug("updating album in database")
+	if err := r.db.UpdateAlbum(ctx, album); err != nil {
+		log.Error("failed to update album in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("album successfully updated")
+	return nil
+}
+
+func (r *AlbumRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "AlbumRepository.Delete"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("album_id", id.String()))
+
+	log.Debug("deleting album from database")
+	if err := r.db.DeleteAlbum(ctx, id); err != nil {
+		log.Error("failed to delete album from database", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("album successfully deleted")
+	return nil
+}
+
+func (r *AlbumRepository) ReadSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "AlbumRepository.ReadSongs"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("album_id", id.String()))
+
+	log.Debug("fetching album songs from database")
+	songs, err := r.db.ReadAlbumSongs(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch album songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("album songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyDatabase interface {
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) error
+	ReadAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	ReadAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	ReadAllAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error)
+}
+
+type APIKeyRepository struct {
+	db  APIKeyDatabase
+	log *slog.Logger
+}
+
+func NewAPIKeyRepository(db APIKeyDatabase, log *slog.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	const op = "APIKeyRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("name", key.Name))
+
+	log.Debug("creating api key in database")
+	if err := r.db.CreateAPIKey(ctx, key); err != nil {
+		log.Error("failed to create api key in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("api key successfully created")
+	return nil
+}
+
+func (r *APIKeyRepository) Read(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	const op = "APIKeyRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("api_key_id", id.String()))
+
+	log.Debug("fetching api key from database")
+	key, err := r.db.ReadAPIKey(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch api key from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("api key successfully fetched")
+	return key, nil
+}
+
+func (r *APIKeyRepository) ReadByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	const op = "APIKeyRepository.ReadByHash"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching api key by hash from database")
+	key, err := r.db.ReadAPIKeyByHash(ctx, hash)
+	if err != nil {
+		// unknown keys are sent by clients, they aren't errors of the service
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			log.Debug("api key not found")
+			return nil, err
+		}
+		log.Error("failed to fetch api key from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("api key successfully fetched", slog.String("api_key_id", key.ID.String()))
+	return key, nil
+}
+
+func (r *APIKeyRepository) ReadAll(ctx context.Context) ([]*domain.APIKey, error) {
+	const op = "APIKeyRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching api keys from database")
+	keys, err := r.db.ReadAllAPIKeys(ctx)
+	if err != nil {
+		log.Error("failed to fetch api keys from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("api keys successfully fetched", slog.Int("count", len(keys)))
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error) {
+	const op = "APIKeyRepository.Revoke"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("api_key_id", id.String()))
+
+	log.Debug("revoking api key in database")
+	key, err := r.db.RevokeAPIKey(ctx, id, revokedAt)
+	if err != nil {
+		log.Error("failed to revoke api key in database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("api key successfully revoked")
+	return key, nil
+}
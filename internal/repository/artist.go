@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type ArtistDatabase interface {
+	CreateArtist(ctx context.Context, artist *domain.Artist) error
+	ReadArtist(ctx context.Context, id uuid.UUID) (*domain.Artist, error)
+	ReadAllArtists(ctx context.Context, name string, limit, offset int) ([]*domain.Artist, error)
+	UpdateArtist(ctx context.Context, artist *domain.Artist) error
+	DeleteArtist(ctx context.Context, id uuid.UUID) error
+
+	ReadArtistSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+type ArtistRepository struct {
+	db    ArtistDatabase
+	cache Cache
+	log   *slog.Logger
+}
+
+func NewArtistRepository(db ArtistDatabase, cache Cache, log *slog.Logger) *ArtistRepository {
+	return &ArtistRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (r *ArtistRepository) Create(ctx context.Context, artist *domain.Artist) error {
+	const op = "ArtistRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_name", artist.Name))
+
+	log.Debug("creating artist in database")
+	if err := r.db.CreateArtist(ctx, artist); err != nil {
+		log.Error("failed to create artist in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("artist successfully created")
+	return nil
+}
+
+func (r *ArtistRepository) Read(ctx context.Context, id uuid.UUID) (*domain.Artist, error) {
+	const op = "ArtistRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_id", id.String()))
+
+	log.Debug("fetching artist from database")
+	artist, err := r.db.ReadArtist(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch artist from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("artist successfully fetched")
+	return artist, nil
+}
+
+func (r *ArtistRepository) ReadAll(ctx context.Context, name string, limit, offset int) ([]*domain.Artist, error) {
+	const op = "ArtistRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_name", name))
+
+	log.Debug("fetching artists from database")
+	artists, err := r.db.ReadAllArtists(ctx, name, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch artists from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("artists successfully fetched")
+	return artists, nil
+}
+
+// Update renames an artist and refreshes the cached songs of the artist,
+// since they carry the group name.
+func (r *ArtistRepository) Update(ctx context.Context, artist *domain.Artist) error {
+	const op = "ArtistRepository.Update"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_id", artist.ID.String()))
+
+	log.Debug("updating artist in database")
+	if err := r.db.UpdateArtist(ctx, artist); err != nil {
+		log.Error("failed to update artist in database", sl.Err(err))
+		return err
+	}
+
+	songs, err := r.db.ReadArtistSongs(ctx, artist.ID)
+	if err != nil {
+		log.Error("failed to fetch artist songs from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("refreshing artist songs in cache", slog.Int("count", len(songs)))
+	for _, song := range songs {
+		if err := r.cache.Set(ctx, song); err != nil {
+			log.Error("failed to cache song", sl.Err(err))
+			return err
+		}
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("artist successfully updated")
+	return nil
+}
+
+func (r *ArtistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "ArtistRepository.Delete"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_id", id.String()))
+
+	log.Debug("deleting artist from database")
+	if err := r.db.DeleteArtist(ctx, id); err != nil {
+		log.Error("failed to delete artist from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("artist successfully deleted")
+	return nil
+}
+
+func (r *ArtistRepository) ReadSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "ArtistRepository.ReadSongs"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("artist_id", id.String()))
+
+	log.Debug("fetching artist songs from database")
+	songs, err := r.db.ReadArtistSongs(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch artist songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("artist songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AudioDatabase interface {
+	SaveAudio(ctx context.Context, audio *domain.Audio) error
+	ReadAudio(ctx context.Context, songID uuid.UUID) (*domain.Audio, error)
+}
+
+// AudioRepository keeps the descriptions of the audio files of songs
+type AudioRepository struct {
+	db  AudioDatabase
+	log *slog.Logger
+}
+
+func NewAudioRepository(db AudioDatabase, log *slog.Logger) *AudioRepository {
+	return &AudioRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *AudioRepository) Save(ctx context.Context, audio *domain.Audio) error {
+	const op = "AudioRepository.Save"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", audio.SongID.String()))
+
+	log.Debug("saving audio in database")
+	if err := r.db.SaveAudio(ctx, audio); err != nil {
+		log.Error("failed to save audio in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("audio successfully saved")
+	return nil
+}
+
+func (r *AudioRepository) Read(ctx context.Context, songID uuid.UUID) (*domain.Audio, error) {
+	const op = "AudioRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()))
+
+	log.Debug("fetching audio from database")
+	audio, err := r.db.ReadAudio(ctx, songID)
+	if err != nil {
+		log.Error("failed to fetch audio from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("audio successfully fetched")
+	return audio, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AuditDatabase interface {
+	ReadAuditEntries(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error)
+}
+
+// AuditRepository reads the audit log, entries are written by Repository
+// together with the changes they record
+type AuditRepository struct {
+	db  AuditDatabase
+	log *slog.Logger
+}
+
+func NewAuditRepository(db AuditDatabase, log *slog.Logger) *AuditRepository {
+	return &AuditRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *AuditRepository) ReadHistory(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error) {
+	const op = "AuditRepository.ReadHistory"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()))
+
+	log.Debug("attempting to fetch song history from database")
+	entries, err := r.db.ReadAuditEntries(ctx, songID, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch song history from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("song history successfully fetched from database", slog.Int("count", len(entries)))
+	return entries, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type CalendarDatabase interface {
+	ReadReleaseCalendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error)
+}
+
+type CalendarRepository struct {
+	db  CalendarDatabase
+	log *slog.Logger
+}
+
+func NewCalendarRepository(db CalendarDatabase, log *slog.Logger) *CalendarRepository {
+	return &CalendarRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *CalendarRepository) ReadReleaseCalendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error) {
+	const op = "CalendarRepository.ReadReleaseCalendar"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Int("year", year))
+
+	log.Debug("fetching release calendar from database")
+	days, err := r.db.ReadReleaseCalendar(ctx, year, excludeExplicit)
+	if err != nil {
+		log.Error("failed to fetch release calendar from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("release calendar successfully fetched", slog.Int("days", len(days)))
+	return days, nil
+}
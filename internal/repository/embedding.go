@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type EmbeddingDatabase interface {
+	EnsureEmbeddings(ctx context.Context, dimensions int) error
+	SaveEmbedding(ctx context.Context, song *domain.Song, embedding []float32) error
+	ReadUnembedded(ctx context.Context, limit int) ([]*domain.Song, error)
+	SearchEmbeddings(ctx context.Context, embedding []float32, excludeExplicit bool, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+type EmbeddingRepository struct {
+	db  EmbeddingDatabase
+	log *slog.Logger
+}
+
+func NewEmbeddingRepository(db EmbeddingDatabase, log *slog.Logger) *EmbeddingRepository {
+	return &EmbeddingRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *EmbeddingRepository) SaveEmbedding(ctx context.Context, song *domain.Song, embedding []float32) error {
+	const op = "EmbeddingRepository.SaveEmbedding"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", song.ID.String()))
+
+	log.Debug("saving embedding to database")
+	if err := r.db.SaveEmbedding(ctx, song, embedding); err != nil {
+		log.Error("failed to save embedding to database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("embedding successfully saved")
+	return nil
+}
+
+func (r *EmbeddingRepository) ReadUnembedded(ctx context.Context, limit int) ([]*domain.Song, error) {
+	const op = "EmbeddingRepository.ReadUnembedded"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching songs without embeddings from database")
+	songs, err := r.db.ReadUnembedded(ctx, limit)
+	if err != nil {
+		log.Error("failed to fetch songs without embeddings from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("songs without embeddings successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
+
+func (r *EmbeddingRepository) SearchEmbeddings(ctx context.Context, embedding []float32, excludeExplicit bool, limit, offset int) ([]*domain.SearchResult, error) {
+	const op = "EmbeddingRepository.SearchEmbeddings"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("searching embeddings in database")
+	results, err := r.db.SearchEmbeddings(ctx, embedding, excludeExplicit, limit, offset)
+	if err != nil {
+		log.Error("failed to search embeddings in database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("embeddings successfully searched", slog.Int("count", len(results)))
+	return results, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+type EnrichmentDatabase interface {
+	ReadStaleSongs(ctx context.Context, staleBefore time.Time, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+}
+
+// EnrichmentRepository finds songs whose details should be fetched from
+// MusicInfo again
+type EnrichmentRepository struct {
+	db  EnrichmentDatabase
+	log *slog.Logger
+}
+
+func NewEnrichmentRepository(db EnrichmentDatabase, log *slog.Logger) *EnrichmentRepository {
+	return &EnrichmentRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *EnrichmentRepository) ReadStale(ctx context.Context, staleBefore time.Time, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	const op = "EnrichmentRepository.ReadStale"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Time("stale_before", staleBefore))
+
+	log.Debug("attempting to fetch stale songs from database")
+	songs, err := r.db.ReadStaleSongs(ctx, staleBefore, after, limit)
+	if err != nil {
+		log.Error("failed to fetch stale songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("stale songs successfully fetched from database", slog.Int("count", len(songs)))
+	return songs, nil
+}
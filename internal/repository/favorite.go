@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteDatabase interface {
+	AddFavorite(ctx context.Context, userID, songID uuid.UUID) error
+	RemoveFavorite(ctx context.Context, userID, songID uuid.UUID) error
+	ReadFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Song, error)
+}
+
+type FavoriteRepository struct {
+	db    FavoriteDatabase
+	cache Cache
+	log   *slog.Logger
+}
+
+func NewFavoriteRepository(db FavoriteDatabase, cache Cache, log *slog.Logger) *FavoriteRepository {
+	return &FavoriteRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+// Add marks a song as a favorite and drops the cached song, its favorites count changed.
+func (r *FavoriteRepository) Add(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "FavoriteRepository.Add"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()), slog.String("song_id", songID.String()))
+
+	log.Debug("adding favorite in database")
+	if err := r.db.AddFavorite(ctx, userID, songID); err != nil {
+		log.Error("failed to add favorite in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("invalidating song in cache")
+	if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Error("failed to invalidate song in cache", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("favorite successfully added")
+	return nil
+}
+
+// Remove unmarks a favorite song and drops the cached song, its favorites count changed.
+func (r *FavoriteRepository) Remove(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "FavoriteRepository.Remove"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()), slog.String("song_id", songID.String()))
+
+	log.Debug("removing favorite from database")
+	if err := r.db.RemoveFavorite(ctx, userID, songID); err != nil {
+		log.Error("failed to remove favorite from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("invalidating song in cache")
+	if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Error("failed to invalidate song in cache", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("favorite successfully removed")
+	return nil
+}
+
+func (r *FavoriteRepository) ReadAll(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Song, error) {
+	const op = "FavoriteRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()))
+
+	log.Debug("fetching favorites from database")
+	songs, err := r.db.ReadFavorites(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch favorites from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("favorites successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type GroupDatabase interface {
+	ReadGroups(ctx context.Context, name string, limit, offset int) ([]*domain.Group, error)
+}
+
+type GroupRepository struct {
+	db  GroupDatabase
+	log *slog.Logger
+}
+
+func NewGroupRepository(db GroupDatabase, log *slog.Logger) *GroupRepository {
+	return &GroupRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *GroupRepository) ReadAll(ctx context.Context, name string, limit, offset int) ([]*domain.Group, error) {
+	const op = "GroupRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("group_name", name))
+
+	log.Debug("fetching groups from database")
+	groups, err := r.db.ReadGroups(ctx, name, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch groups from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("groups successfully fetched", slog.Int("count", len(groups)))
+	return groups, nil
+}
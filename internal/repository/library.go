@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type LibraryDatabase interface {
+	CreateLibrary(ctx context.Context, library *domain.Library) error
+	ReadLibrary(ctx context.Context, id uuid.UUID) (*domain.Library, error)
+	ReadAllLibraries(ctx context.Context) ([]*domain.Library, error)
+}
+
+type LibraryRepository struct {
+	db  LibraryDatabase
+	log *slog.Logger
+}
+
+func NewLibraryRepository(db LibraryDatabase, log *slog.Logger) *LibraryRepository {
+	return &LibraryRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *LibraryRepository) Create(ctx context.Context, library *domain.Library) error {
+	const op = "LibraryRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("name", library.Name))
+
+	log.Debug("creating library in database")
+	if err := r.db.CreateLibrary(ctx, library); err != nil {
+		log.Error("failed to create library in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("library successfully created")
+	return nil
+}
+
+func (r *LibraryRepository) Read(ctx context.Context, id uuid.UUID) (*domain.Library, error) {
+	const op = "LibraryRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("library_id", id.String()))
+
+	log.Debug("fetching library from database")
+	library, err := r.db.ReadLibrary(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch library from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("library successfully fetched")
+	return library, nil
+}
+
+func (r *LibraryRepository) ReadAll(ctx context.Context) ([]*domain.Library, error) {
+	const op = "LibraryRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching libraries from database")
+	libraries, err := r.db.ReadAllLibraries(ctx)
+	if err != nil {
+		log.Error("failed to fetch libraries from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("libraries successfully fetched", slog.Int("count", len(libraries)))
+	return libraries, nil
+}
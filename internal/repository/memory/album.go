@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateAlbum(ctx context.Context, album *domain.Album) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	album.ID = uuid.New()
+	album.CreatedAt = time.Now()
+	album.UpdatedAt = time.Now()
+	album.LibraryID = domain.LibraryIDFromContext(ctx)
+
+	stored := *album
+	s.albums[album.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadAlbum(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	const op = "repository.MemoryDB.ReadAlbum"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := s.libraryAlbum(ctx, id)
+	if stored == nil {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	album := *stored
+	return &album, nil
+}
+
+func (s *Store) ReadAllAlbums(ctx context.Context, group string, limit, offset int) ([]*domain.Album, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	var albums []*domain.Album
+	for _, stored := range s.albums {
+		if stored.LibraryID != library || (group != "" && !containsFold(stored.Group, group)) {
+			continue
+		}
+		album := *stored
+		albums = append(albums, &album)
+	}
+
+	// ORDER BY release_date DESC, title
+	slices.SortFunc(albums, func(a, b *domain.Album) int {
+		if c := b.ReleaseDate.Compare(a.ReleaseDate); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+
+	return page(albums, limit, offset), nil
+}
+
+func (s *Store) UpdateAlbum(ctx context.Context, album *domain.Album) error {
+	const op = "repository.MemoryDB.UpdateAlbum"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.libraryAlbum(ctx, album.ID)
+	if stored == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	album.UpdatedAt = time.Now()
+	album.CreatedAt = stored.CreatedAt
+	album.LibraryID = stored.LibraryID
+
+	updated := *album
+	s.albums[album.ID] = &updated
+
+	return nil
+}
+
+// DeleteAlbum removes an album. Its songs are kept and lose the album reference.
+func (s *Store) DeleteAlbum(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.MemoryDB.DeleteAlbum"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.libraryAlbum(ctx, id) == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	delete(s.albums, id)
+	for _, song := range s.songs {
+		if song.AlbumID != nil && *song.AlbumID == id {
+			song.AlbumID = nil
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) ReadAlbumSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	return s.songsWhere(func(song *domain.Song) bool {
+		return song.LibraryID == library && song.AlbumID != nil && *song.AlbumID == id
+	}), nil
+}
+
+// libraryAlbum returns the stored album if it belongs to the library ctx is
+// scoped to
+func (s *Store) libraryAlbum(ctx context.Context, id uuid.UUID) *domain.Album {
+	album, ok := s.albums[id]
+	if !ok || album.LibraryID != domain.LibraryIDFromContext(ctx) {
+		return nil
+	}
+	return album
+}
+
+// songsWhere returns copies of the songs matching keep ordered by release
+// date and name
+func (s *Store) songsWhere(keep func(song *domain.Song) bool) []*domain.Song {
+	var songs []*domain.Song
+	for _, song := range s.songs {
+		if keep(song) {
+			found := *song
+			songs = append(songs, &found)
+		}
+	}
+
+	slices.SortFunc(songs, func(a, b *domain.Song) int {
+		if c := a.ReleaseDate.Compare(b.ReleaseDate); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return songs
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateAPIKey(_ context.Context, key *domain.APIKey) error {
+	const op = "repository.MemoryDB.CreateAPIKey"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key.LibraryID != nil && s.libraries[*key.LibraryID] == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+	}
+
+	key.ID = uuid.New()
+	key.CreatedAt = time.Now()
+
+	stored := *key
+	s.apiKeys[key.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadAPIKey(_ context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	const op = "repository.MemoryDB.ReadAPIKey"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.apiKeys[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+func (s *Store) ReadAPIKeyByHash(_ context.Context, hash string) (*domain.APIKey, error) {
+	const op = "repository.MemoryDB.ReadAPIKeyByHash"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, stored := range s.apiKeys {
+		if stored.Hash == hash {
+			found := *stored
+			return &found, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyNotFound)
+}
+
+// ReadAllAPIKeys returns the keys including revoked ones, newest first
+func (s *Store) ReadAllAPIKeys(_ context.Context) ([]*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]*domain.APIKey, 0, len(s.apiKeys))
+	for _, stored := range s.apiKeys {
+		found := *stored
+		keys = append(keys, &found)
+	}
+	slices.SortFunc(keys, func(a, b *domain.APIKey) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks the key revoked at revokedAt, a key revoked before
+// keeps its time
+func (s *Store) RevokeAPIKey(_ context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error) {
+	const op = "repository.MemoryDB.RevokeAPIKey"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.apiKeys[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyNotFound)
+	}
+	if stored.RevokedAt == nil {
+		stored.RevokedAt = &revokedAt
+	}
+
+	found := *stored
+	return &found, nil
+}
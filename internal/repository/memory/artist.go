@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateArtist(ctx context.Context, artist *domain.Artist) error {
+	const op = "repository.MemoryDB.CreateArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	if s.findArtist(library, artist.Name) != nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+	}
+
+	artist.ID = uuid.New()
+	artist.CreatedAt = time.Now()
+	artist.UpdatedAt = time.Now()
+	artist.LibraryID = library
+
+	stored := *artist
+	s.artists[artist.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadArtist(ctx context.Context, id uuid.UUID) (*domain.Artist, error) {
+	const op = "repository.MemoryDB.ReadArtist"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := s.libraryArtist(ctx, id)
+	if stored == nil {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+	}
+
+	artist := *stored
+	return &artist, nil
+}
+
+func (s *Store) ReadAllArtists(ctx context.Context, name string, limit, offset int) ([]*domain.Artist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	var artists []*domain.Artist
+	for _, stored := range s.artists {
+		if stored.LibraryID != library || (name != "" && !containsFold(stored.Name, name)) {
+			continue
+		}
+		artist := *stored
+		artists = append(artists, &artist)
+	}
+
+	slices.SortFunc(artists, func(a, b *domain.Artist) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return page(artists, limit, offset), nil
+}
+
+// UpdateArtist renames an artist together with the group name of its songs,
+// which get a new version
+func (s *Store) UpdateArtist(ctx context.Context, artist *domain.Artist) error {
+	const op = "repository.MemoryDB.UpdateArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.libraryArtist(ctx, artist.ID)
+	if stored == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+	}
+	if other := s.findArtist(stored.LibraryID, artist.Name); other != nil && other.ID != artist.ID {
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+	}
+
+	// Renaming can make a song collide with a song of another artist
+	var songs []*domain.Song
+	for _, song := range s.songs {
+		if song.ArtistID != artist.ID {
+			continue
+		}
+		if other := s.findSong(song.LibraryID, song.Name, artist.Name, song.ID); other != nil && other.ArtistID != artist.ID {
+			return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		songs = append(songs, song)
+	}
+
+	artist.UpdatedAt = time.Now()
+	artist.CreatedAt = stored.CreatedAt
+	artist.LibraryID = stored.LibraryID
+	stored.Name = artist.Name
+	stored.UpdatedAt = artist.UpdatedAt
+
+	for _, song := range songs {
+		song.Group = artist.Name
+		song.UpdatedAt = artist.UpdatedAt
+		song.Version++
+	}
+
+	return nil
+}
+
+// DeleteArtist removes an artist. Artists that still have songs can't be deleted.
+func (s *Store) DeleteArtist(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.MemoryDB.DeleteArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.libraryArtist(ctx, id) == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+	}
+	for _, song := range s.songs {
+		if song.ArtistID == id {
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistHasSongs)
+		}
+	}
+
+	delete(s.artists, id)
+
+	return nil
+}
+
+func (s *Store) ReadArtistSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	return s.songsWhere(func(song *domain.Song) bool {
+		return song.LibraryID == library && song.ArtistID == id
+	}), nil
+}
+
+// libraryArtist returns the stored artist if it belongs to the library ctx
+// is scoped to
+func (s *Store) libraryArtist(ctx context.Context, id uuid.UUID) *domain.Artist {
+	artist, ok := s.artists[id]
+	if !ok || artist.LibraryID != domain.LibraryIDFromContext(ctx) {
+		return nil
+	}
+	return artist
+}
+
+// findArtist returns the artist of the library with the name
+func (s *Store) findArtist(library uuid.UUID, name string) *domain.Artist {
+	for _, artist := range s.artists {
+		if artist.LibraryID == library && artist.Name == name {
+			return artist
+		}
+	}
+	return nil
+}
+
+// upsertArtist returns the ID of the artist of the library with the name,
+// creating it when the group is new to the library
+func (s *Store) upsertArtist(library uuid.UUID, name string) uuid.UUID {
+	if artist := s.findArtist(library, name); artist != nil {
+		return artist.ID
+	}
+
+	artist := &domain.Artist{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		LibraryID: library,
+	}
+	s.artists[artist.ID] = artist
+
+	return artist.ID
+}
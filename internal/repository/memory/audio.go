@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveAudio stores the description of the audio file of a song, replacing
+// the previous one
+func (s *Store) SaveAudio(_ context.Context, audio *domain.Audio) error {
+	const op = "repository.MemoryDB.SaveAudio"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.songs[audio.SongID]; !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	audio.UploadedAt = time.Now()
+	stored := *audio
+	s.audio[audio.SongID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadAudio(ctx context.Context, songID uuid.UUID) (*domain.Audio, error) {
+	const op = "repository.MemoryDB.ReadAudio"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// the audio of songs of other libraries is not found
+	audio, ok := s.audio[songID]
+	if _, found := s.librarySong(ctx, songID); !ok || !found {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAudioNotFound)
+	}
+
+	result := *audio
+	return &result, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAuditEntry records a change of a song. The store has no rollback, an
+// entry is kept even if the change it belongs to fails later.
+func (s *Store) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = int64(len(s.audit) + 1)
+	entry.LibraryID = domain.LibraryIDFromContext(ctx)
+	entry.CreatedAt = time.Now()
+
+	stored := *entry
+	stored.Old = copySong(entry.Old)
+	stored.New = copySong(entry.New)
+	s.audit = append(s.audit, &stored)
+
+	return nil
+}
+
+// ReadAuditEntries returns the changes of a song of the library, oldest first
+func (s *Store) ReadAuditEntries(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	var entries []*domain.AuditEntry
+	for _, stored := range s.audit {
+		if stored.SongID != songID || stored.LibraryID != library {
+			continue
+		}
+		entry := *stored
+		entry.Old = copySong(stored.Old)
+		entry.New = copySong(stored.New)
+		entries = append(entries, &entry)
+	}
+
+	return page(entries, limit, offset), nil
+}
+
+func copySong(song *domain.Song) *domain.Song {
+	if song == nil {
+		return nil
+	}
+	copied := *song
+	return &copied
+}
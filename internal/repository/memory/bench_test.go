@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/repository"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"testing"
+	"time"
+)
+
+// benchSongs is the size of the library the benchmarks read from
+const benchSongs = 2_000
+
+// seedStore fills a store with benchSongs songs by 100 groups
+func seedStore(b *testing.B) (*Store, []*domain.Song) {
+	b.Helper()
+
+	ctx := context.Background()
+	s := NewStore()
+	songs := make([]*domain.Song, 0, benchSongs)
+	for i := 0; i < benchSongs; i++ {
+		song := &domain.Song{
+			Name:        fmt.Sprintf("Song %d", i),
+			Group:       fmt.Sprintf("Group %d", i%100),
+			Text:        "Verse line one\nVerse line two\n\nChorus line one\nChorus line two",
+			ReleaseDate: time.Date(2000+i%25, time.Month(i%12+1), i%28+1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := s.Create(ctx, song); err != nil {
+			b.Fatal(err)
+		}
+		songs = append(songs, song)
+	}
+	return s, songs
+}
+
+func newBenchRepository(b *testing.B) (*repository.Repository, *Cache, []*domain.Song) {
+	b.Helper()
+
+	s, songs := seedStore(b)
+	cache := NewCache()
+	return repository.NewRepository(s, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler())), cache, songs
+}
+
+func BenchmarkStore_ReadAllWithFilter(b *testing.B) {
+	ctx := context.Background()
+	s, _ := seedStore(b)
+
+	benchmarks := []struct {
+		name   string
+		filter *domain.SongFilter
+	}{
+		{name: "all", filter: &domain.SongFilter{WithoutText: true}},
+		{name: "group", filter: &domain.SongFilter{Group: "group 42", WithoutText: true}},
+		{name: "song_substring", filter: &domain.SongFilter{Name: "99", WithoutText: true}},
+		{name: "popularity", filter: &domain.SongFilter{Sort: domain.SortByPopularity, WithoutText: true}},
+		{name: "with_text", filter: &domain.SongFilter{Group: "group 42"}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.ReadAllWithFilter(ctx, bm.filter, 20, 0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepository_ReadAllWithFilter(b *testing.B) {
+	ctx := context.Background()
+	repo, _, _ := newBenchRepository(b)
+	filter := &domain.SongFilter{Group: "group 42", WithoutText: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ReadAllWithFilter(ctx, filter, 20, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRepository_Read(b *testing.B) {
+	ctx := context.Background()
+
+	b.Run("cache_hit", func(b *testing.B) {
+		repo, _, songs := newBenchRepository(b)
+		info := &domain.SongInfo{ID: songs[0].ID}
+		if _, err := repo.Read(ctx, info); err != nil {
+			b.Fatal(err)
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.Read(ctx, info); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// каждая итерация вытесняет песню, чтобы чтение шло в базу и
+	// заполняло кеш заново
+	b.Run("cache_miss", func(b *testing.B) {
+		repo, cache, songs := newBenchRepository(b)
+		info := &domain.SongInfo{ID: songs[0].ID}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := cache.Invalidate(ctx, info); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := repo.Read(ctx, info); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
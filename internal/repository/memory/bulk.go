@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateAllWithFilter sets the changes on every song of the library matching
+// the filter and returns the songs before and after, nothing is changed if a renamed song
+// would clash with another one
+func (s *Store) UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error) {
+	const op = "repository.MemoryDB.UpdateAllWithFilter"
+
+	if filter.IsEmpty() {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrBulkFilterEmpty)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	matched := make(map[uuid.UUID]*domain.Song)
+	for _, song := range s.songs {
+		if song.LibraryID == library && s.matches(song, filter) {
+			matched[song.ID] = song
+		}
+	}
+
+	if changes.Group != nil {
+		// The unique name and group index is checked against the songs as
+		// they will be after the update
+		keys := make(map[string]bool, len(s.songs))
+		for _, song := range s.songs {
+			group := song.Group
+			if matched[song.ID] != nil {
+				group = *changes.Group
+			}
+			key := song.LibraryID.String() + "\x00" + strings.ToLower(song.Name) + "\x00" + strings.ToLower(group)
+			if keys[key] {
+				return nil, fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+			}
+			keys[key] = true
+		}
+	}
+
+	var artistID uuid.UUID
+	if changes.Group != nil {
+		artistID = s.upsertArtist(domain.LibraryIDFromContext(ctx), *changes.Group)
+	}
+
+	updates := make([]*domain.SongUpdate, 0, len(matched))
+	now := time.Now()
+	for _, stored := range matched {
+		old := *stored
+		changes.Apply(stored)
+		if changes.Group != nil {
+			stored.ArtistID = artistID
+		}
+		stored.UpdatedAt = now
+		stored.Version++
+
+		updated := *stored
+		updates = append(updates, &domain.SongUpdate{Old: &old, New: &updated})
+	}
+
+	return updates, nil
+}
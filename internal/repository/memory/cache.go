@@ -0,0 +1,344 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// musicInfoEntry is a cached MusicInfo response that expires at expiresAt
+type musicInfoEntry struct {
+	song      domain.Song
+	expiresAt time.Time
+}
+
+// suggestionsEntry is a cached list of suggestions that expires at expiresAt
+type suggestionsEntry struct {
+	suggestions []domain.Suggestion
+	expiresAt   time.Time
+}
+
+// dailyPickEntry is a cached song of the day that expires at expiresAt
+type dailyPickEntry struct {
+	songID    uuid.UUID
+	expiresAt time.Time
+}
+
+// libraryStatsEntry are cached library statistics that expire at expiresAt
+type libraryStatsEntry struct {
+	stats     domain.LibraryStats
+	expiresAt time.Time
+}
+
+// libraryStatsKey matches library statistics by library and start date
+type libraryStatsKey struct {
+	library uuid.UUID
+	since   time.Time
+}
+
+// Cache is an in-memory replacement of the Redis cache: cached songs,
+// MusicInfo responses, suggestions, songs of the day, library statistics
+// the buffer of plays and the times libraries were last modified. Everything
+// but MusicInfo responses and plays is cached per library.
+type Cache struct {
+	mu           sync.RWMutex
+	songs        map[uuid.UUID]domain.Song
+	musicInfo    map[string]musicInfoEntry
+	suggestions  map[string]suggestionsEntry
+	dailyPicks   map[string]dailyPickEntry
+	libraryStats map[libraryStatsKey]libraryStatsEntry
+	modified     map[uuid.UUID]time.Time
+	plays        map[uuid.UUID]int
+	hits         int64
+	misses       int64
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		songs:        make(map[uuid.UUID]domain.Song),
+		musicInfo:    make(map[string]musicInfoEntry),
+		suggestions:  make(map[string]suggestionsEntry),
+		dailyPicks:   make(map[string]dailyPickEntry),
+		libraryStats: make(map[libraryStatsKey]libraryStatsEntry),
+		modified:     make(map[uuid.UUID]time.Time),
+		plays:        make(map[uuid.UUID]int),
+	}
+}
+
+func (c *Cache) Set(_ context.Context, song *domain.Song) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.songs[song.ID] = *song
+
+	return nil
+}
+
+// Get returns the cached song of the library, songs in memory don't expire
+func (c *Cache) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, time.Duration, error) {
+	const op = "repository.MemoryCache.Get"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.songs[song.ID]
+	if !ok || cached.LibraryID != domain.LibraryIDFromContext(ctx) {
+		c.misses++
+		return nil, 0, fmt.Errorf("%s: song not found in cache: %w", op, domain.ErrSongNotFound)
+	}
+
+	c.hits++
+	return &cached, 0, nil
+}
+
+func (c *Cache) Invalidate(_ context.Context, song *domain.SongInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.songs, song.ID)
+
+	return nil
+}
+
+// Stats returns the number of cached songs, MusicInfo responses,
+// suggestions, songs of the day and library statistics and the hit and miss
+// counters of song lookups. Memory usage is not tracked.
+func (c *Cache) Stats(_ context.Context) (*domain.CacheStats, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &domain.CacheStats{
+		Keys:   int64(len(c.songs) + len(c.musicInfo) + len(c.suggestions) + len(c.dailyPicks) + len(c.libraryStats)),
+		Hits:   c.hits,
+		Misses: c.misses,
+	}, nil
+}
+
+// Flush deletes every cached song, MusicInfo response, suggestion, song of
+// the day, library statistics and library modification time, buffered plays
+// are kept
+func (c *Cache) Flush(_ context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := int64(len(c.songs) + len(c.musicInfo) + len(c.suggestions) + len(c.dailyPicks) + len(c.libraryStats) + len(c.modified))
+	clear(c.songs)
+	clear(c.musicInfo)
+	clear(c.suggestions)
+	clear(c.dailyPicks)
+	clear(c.libraryStats)
+	clear(c.modified)
+
+	return deleted, nil
+}
+
+func (c *Cache) IncrPlays(_ context.Context, songID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.plays[songID]++
+
+	return nil
+}
+
+// DrainPlays returns the buffered plays and empties the buffer
+func (c *Cache) DrainPlays(_ context.Context) (map[uuid.UUID]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	plays := c.plays
+	c.plays = make(map[uuid.UUID]int)
+
+	return plays, nil
+}
+
+// RestorePlays puts drained plays back into the buffer
+func (c *Cache) RestorePlays(_ context.Context, plays map[uuid.UUID]int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for songID, count := range plays {
+		c.plays[songID] += count
+	}
+
+	return nil
+}
+
+// musicInfoKey matches songs by their normalized group and name
+func musicInfoKey(song *domain.SongInfo) string {
+	return normalize(song.Group) + "\x00" + normalize(song.Name)
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// GetMusicInfo returns the cached MusicInfo response for the song
+func (c *Cache) GetMusicInfo(_ context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "repository.MemoryCache.GetMusicInfo"
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.musicInfo[musicInfoKey(song)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, fmt.Errorf("%s: music info not found in cache: %w", op, domain.ErrSongNotFound)
+	}
+
+	return &entry.song, nil
+}
+
+// SetMusicInfo caches the MusicInfo response for the song for ttl
+func (c *Cache) SetMusicInfo(_ context.Context, song *domain.SongInfo, details *domain.Song, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.musicInfo[musicInfoKey(song)] = musicInfoEntry{song: *details, expiresAt: time.Now().Add(ttl)}
+
+	return nil
+}
+
+// libraryKey prefixes key with the library ctx is scoped to
+func libraryKey(ctx context.Context, key string) string {
+	return domain.LibraryIDFromContext(ctx).String() + "\x00" + key
+}
+
+// suggestionsKey matches suggestions by library, query and limit
+func suggestionsKey(ctx context.Context, query string, limit int) string {
+	return libraryKey(ctx, strconv.Itoa(limit)+"\x00"+query)
+}
+
+// GetSuggestions returns the cached suggestions for the query
+func (c *Cache) GetSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	const op = "repository.MemoryCache.GetSuggestions"
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.suggestions[suggestionsKey(ctx, query, limit)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, fmt.Errorf("%s: suggestions not found in cache: %w", op, domain.ErrCacheMiss)
+	}
+
+	suggestions := make([]*domain.Suggestion, len(entry.suggestions))
+	for i := range entry.suggestions {
+		suggestion := entry.suggestions[i]
+		suggestions[i] = &suggestion
+	}
+	return suggestions, nil
+}
+
+// SetSuggestions caches the suggestions for the query for ttl
+func (c *Cache) SetSuggestions(ctx context.Context, query string, limit int, suggestions []*domain.Suggestion, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := suggestionsEntry{
+		suggestions: make([]domain.Suggestion, len(suggestions)),
+		expiresAt:   time.Now().Add(ttl),
+	}
+	for i, suggestion := range suggestions {
+		entry.suggestions[i] = *suggestion
+	}
+	c.suggestions[suggestionsKey(ctx, query, limit)] = entry
+
+	return nil
+}
+
+// GetSongOfTheDay returns the ID of the cached song of the day
+func (c *Cache) GetSongOfTheDay(ctx context.Context, day string) (uuid.UUID, error) {
+	const op = "repository.MemoryCache.GetSongOfTheDay"
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.dailyPicks[libraryKey(ctx, day)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return uuid.Nil, fmt.Errorf("%s: song of the day not found in cache: %w", op, domain.ErrCacheMiss)
+	}
+
+	return entry.songID, nil
+}
+
+// SetSongOfTheDay caches the ID of the song of the day for ttl
+func (c *Cache) SetSongOfTheDay(ctx context.Context, day string, songID uuid.UUID, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dailyPicks[libraryKey(ctx, day)] = dailyPickEntry{songID: songID, expiresAt: time.Now().Add(ttl)}
+
+	return nil
+}
+
+// DeleteSongOfTheDay drops the cached song of the day
+func (c *Cache) DeleteSongOfTheDay(ctx context.Context, day string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.dailyPicks, libraryKey(ctx, day))
+
+	return nil
+}
+
+// GetLibraryStats returns the cached library statistics
+func (c *Cache) GetLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error) {
+	const op = "repository.MemoryCache.GetLibraryStats"
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.libraryStats[libraryStatsKey{library: domain.LibraryIDFromContext(ctx), since: since.UTC()}]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, fmt.Errorf("%s: library stats not found in cache: %w", op, domain.ErrCacheMiss)
+	}
+
+	stats := entry.stats
+	stats.AddedPerDay = slices.Clone(stats.AddedPerDay)
+	stats.AddedPerWeek = slices.Clone(stats.AddedPerWeek)
+	return &stats, nil
+}
+
+// SetLibraryStats caches the library statistics for ttl
+func (c *Cache) SetLibraryStats(ctx context.Context, since time.Time, stats *domain.LibraryStats, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := libraryStatsEntry{stats: *stats, expiresAt: time.Now().Add(ttl)}
+	entry.stats.AddedPerDay = slices.Clone(stats.AddedPerDay)
+	entry.stats.AddedPerWeek = slices.Clone(stats.AddedPerWeek)
+	c.libraryStats[libraryStatsKey{library: domain.LibraryIDFromContext(ctx), since: since.UTC()}] = entry
+
+	return nil
+}
+
+// GetLibraryModified returns the time the songs of the library were last
+// modified
+func (c *Cache) GetLibraryModified(ctx context.Context) (time.Time, error) {
+	const op = "repository.MemoryCache.GetLibraryModified"
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	modified, ok := c.modified[domain.LibraryIDFromContext(ctx)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s: library modification time not found in cache: %w", op, domain.ErrCacheMiss)
+	}
+	return modified, nil
+}
+
+// SetLibraryModified records the time the songs of the library were last
+// modified
+func (c *Cache) SetLibraryModified(ctx context.Context, at time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.modified[domain.LibraryIDFromContext(ctx)] = at
+	return nil
+}
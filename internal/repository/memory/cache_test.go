@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"songLibrary/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetSetInvalidate(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+
+	_, _, err := c.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	require.NoError(t, c.Set(ctx, song))
+	cached, _, err := c.Get(ctx, &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Equal(t, song, cached)
+
+	// Промахи и попадания попадают в статистику
+	stats, err := c.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.CacheStats{Keys: 1, Hits: 1, Misses: 1}, stats)
+
+	require.NoError(t, c.Invalidate(ctx, &domain.SongInfo{ID: song.ID}))
+	_, _, err = c.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestCache_Flush_KeepsPlays(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	songID := uuid.New()
+
+	require.NoError(t, c.Set(ctx, &domain.Song{ID: songID}))
+	require.NoError(t, c.SetMusicInfo(ctx, &domain.SongInfo{Name: "Hysteria", Group: "Muse"}, &domain.Song{Text: "..."}, time.Hour))
+	require.NoError(t, c.IncrPlays(ctx, songID))
+
+	deleted, err := c.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// Буфер прослушиваний не является кэшем и сохраняется
+	plays, err := c.DrainPlays(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{songID: 1}, plays)
+}
+
+func TestCache_Flush_LibraryModified(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+
+	require.NoError(t, c.SetLibraryModified(ctx, time.Now().Add(-time.Hour)))
+
+	deleted, err := c.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	// После сброса время изменения неизвестно и записывается заново, так что
+	// данные, заменённые восстановлением, не отдаются как не изменившиеся
+	_, err = c.GetLibraryModified(ctx)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestCache_Plays(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	songID := uuid.New()
+
+	require.NoError(t, c.IncrPlays(ctx, songID))
+	require.NoError(t, c.IncrPlays(ctx, songID))
+
+	plays, err := c.DrainPlays(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{songID: 2}, plays)
+
+	// После выгрузки буфер пуст, а возвращённые прослушивания учитываются снова
+	plays, err = c.DrainPlays(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plays)
+
+	require.NoError(t, c.RestorePlays(ctx, map[uuid.UUID]int{songID: 2}))
+	plays, err = c.DrainPlays(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{songID: 2}, plays)
+}
+
+func TestCache_MusicInfo(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	details := &domain.Song{Text: "It's bugging me", Link: "https://example.com"}
+
+	require.NoError(t, c.SetMusicInfo(ctx, &domain.SongInfo{Name: "Hysteria", Group: "Muse"}, details, time.Hour))
+
+	// Ключ не зависит от регистра и лишних пробелов
+	cached, err := c.GetMusicInfo(ctx, &domain.SongInfo{Name: " hysteria ", Group: "MUSE"})
+	require.NoError(t, err)
+	assert.Equal(t, details, cached)
+
+	// Истёкшие ответы не возвращаются
+	require.NoError(t, c.SetMusicInfo(ctx, &domain.SongInfo{Name: "Creep", Group: "Radiohead"}, details, -time.Second))
+	_, err = c.GetMusicInfo(ctx, &domain.SongInfo{Name: "Creep", Group: "Radiohead"})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestCache_Suggestions(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	suggestions := []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: "Muse", Score: 1}}
+
+	require.NoError(t, c.SetSuggestions(ctx, "mu", 10, suggestions, time.Hour))
+
+	cached, err := c.GetSuggestions(ctx, "mu", 10)
+	require.NoError(t, err)
+	assert.Equal(t, suggestions, cached)
+
+	// Ключ учитывает лимит, истёкшие подсказки не возвращаются
+	_, err = c.GetSuggestions(ctx, "mu", 5)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+	require.NoError(t, c.SetSuggestions(ctx, "hy", 10, suggestions, -time.Second))
+	_, err = c.GetSuggestions(ctx, "hy", 10)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestCache_SongOfTheDay(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	songID := uuid.New()
+
+	require.NoError(t, c.SetSongOfTheDay(ctx, "2024-05-01", songID, time.Hour))
+
+	cached, err := c.GetSongOfTheDay(ctx, "2024-05-01")
+	require.NoError(t, err)
+	assert.Equal(t, songID, cached)
+
+	// После удаления и для других дней песни дня в кэше нет
+	_, err = c.GetSongOfTheDay(ctx, "2024-05-02")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+	require.NoError(t, c.DeleteSongOfTheDay(ctx, "2024-05-01"))
+	_, err = c.GetSongOfTheDay(ctx, "2024-05-01")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestCache_Library(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache()
+	library := uuid.New()
+	song := &domain.Song{ID: uuid.New(), LibraryID: library, Name: "Hysteria", Group: "Muse"}
+
+	require.NoError(t, c.Set(ctx, song))
+
+	// Песня из кэша отдаётся только запросам своей библиотеки
+	_, _, err := c.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	cached, _, err := c.Get(domain.WithLibraryID(ctx, library), &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Equal(t, song, cached)
+}
+
+func TestCache_LibraryModified(t *testing.T) {
+	c := NewCache()
+	ctx := context.Background()
+	other := domain.WithLibraryID(ctx, uuid.New())
+
+	_, err := c.GetLibraryModified(ctx)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+	at := time.Now()
+	assert.NoError(t, c.SetLibraryModified(ctx, at))
+
+	modified, err := c.GetLibraryModified(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, at, modified)
+
+	// Время хранится отдельно для каждой библиотеки
+	_, err = c.GetLibraryModified(other)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
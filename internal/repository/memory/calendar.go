@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+)
+
+// ReadReleaseCalendar returns the days of the year songs of the library were
+// released on with the songs, in calendar order, like the query of PostgreSQL
+func (s *Store) ReadReleaseCalendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type monthDay struct {
+		month time.Month
+		day   int
+	}
+
+	byDay := make(map[monthDay]*domain.CalendarDay)
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{ExcludeExplicit: excludeExplicit, ExcludeArchived: true}) {
+		released := song.ReleaseDate
+		if released.IsZero() || (year != 0 && released.Year() != year) {
+			continue
+		}
+
+		key := monthDay{released.Month(), released.Day()}
+		day, ok := byDay[key]
+		if !ok {
+			day = &domain.CalendarDay{Month: key.month, Day: key.day}
+			byDay[key] = day
+		}
+		day.Songs = append(day.Songs, &domain.Song{
+			ID:          song.ID,
+			Name:        song.Name,
+			Group:       song.Group,
+			ReleaseDate: time.Date(released.Year(), released.Month(), released.Day(), 0, 0, 0, 0, time.UTC),
+		})
+	}
+
+	days := make([]*domain.CalendarDay, 0, len(byDay))
+	for _, day := range byDay {
+		slices.SortFunc(day.Songs, func(a, b *domain.Song) int {
+			return cmp.Or(a.ReleaseDate.Compare(b.ReleaseDate), strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)))
+		})
+		days = append(days, day)
+	}
+	slices.SortFunc(days, func(a, b *domain.CalendarDay) int {
+		return cmp.Or(cmp.Compare(a.Month, b.Month), cmp.Compare(a.Day, b.Day))
+	})
+
+	return days, nil
+}
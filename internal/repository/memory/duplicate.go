@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"unicode"
+)
+
+// ReadDuplicates returns up to limit pairs of songs of the library with a
+// trigram similarity
+// of name and group of at least threshold, most similar first, like the
+// pg_trgm query of PostgreSQL. Case and accents are ignored.
+func (s *Store) ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := s.filterLibrarySongs(ctx, &domain.SongFilter{})
+	slices.SortFunc(songs, func(a, b *domain.Song) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	grams := make([]map[string]struct{}, len(songs))
+	for i, song := range songs {
+		grams[i] = trigrams(normalizeName(song.Name) + " " + normalizeName(song.Group))
+	}
+
+	var duplicates []*domain.DuplicateSongs
+	for i := range songs {
+		for j := i + 1; j < len(songs); j++ {
+			score := similarity(grams[i], grams[j])
+			if score < threshold {
+				continue
+			}
+			duplicates = append(duplicates, &domain.DuplicateSongs{
+				Song:       songs[i],
+				Duplicate:  songs[j],
+				Similarity: score,
+			})
+		}
+	}
+
+	// ORDER BY score DESC, a.id, b.id, pairs are already ordered by IDs
+	slices.SortStableFunc(duplicates, func(a, b *domain.DuplicateSongs) int {
+		switch {
+		case a.Similarity > b.Similarity:
+			return -1
+		case a.Similarity < b.Similarity:
+			return 1
+		}
+		return 0
+	})
+
+	return page(duplicates, limit, 0), nil
+}
+
+// trigrams splits s into the trigrams of pg_trgm: every lower-cased word is
+// padded with two spaces in front and one behind
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// similarity is the share of trigrams the two sets have in common
+func similarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for gram := range a {
+		if _, ok := b[gram]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// songEmbedding is the embedding of a version of a song
+type songEmbedding struct {
+	version   int
+	embedding []float32
+}
+
+// EnsureEmbeddings does nothing, the store keeps embeddings without setup
+func (s *Store) EnsureEmbeddings(ctx context.Context, dimensions int) error {
+	return nil
+}
+
+// SaveEmbedding stores the embedding of the version of song, embeddings of
+// missing songs are dropped
+func (s *Store) SaveEmbedding(ctx context.Context, song *domain.Song, embedding []float32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.songs[song.ID]; ok {
+		s.embeddings[song.ID] = songEmbedding{version: song.Version, embedding: slices.Clone(embedding)}
+	}
+	return nil
+}
+
+// ReadUnembedded returns up to limit songs of all libraries without an
+// embedding of their current version, newest first
+func (s *Store) ReadUnembedded(ctx context.Context, limit int) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := slices.DeleteFunc(s.filterSongs(&domain.SongFilter{}), func(song *domain.Song) bool {
+		stored, ok := s.embeddings[song.ID]
+		return ok && stored.version == song.Version
+	})
+	return page(songs, limit, 0), nil
+}
+
+// SearchEmbeddings returns the songs of the library whose embeddings are
+// nearest to embedding, ranked by cosine similarity like pgvector.
+// excludeExplicit drops the songs flagged explicit.
+func (s *Store) SearchEmbeddings(ctx context.Context, embedding []float32, excludeExplicit bool, limit, offset int) ([]*domain.SearchResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []*domain.SearchResult
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{ExcludeExplicit: excludeExplicit, ExcludeArchived: true}) {
+		stored, ok := s.embeddings[song.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &domain.SearchResult{Song: song, Rank: cosineSimilarity(embedding, stored.embedding)})
+	}
+
+	// ORDER BY embedding <=> $1, created_at DESC, id
+	slices.SortFunc(results, func(a, b *domain.SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Rank, a.Rank),
+			b.Song.CreatedAt.Compare(a.Song.CreatedAt),
+			strings.Compare(a.Song.ID.String(), b.Song.ID.String()),
+		)
+	})
+
+	return page(results, limit, offset), nil
+}
+
+// cosineSimilarity is the cosine of the angle between a and b, 0 when
+// either has no direction
+func cosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range min(len(a), len(b)) {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
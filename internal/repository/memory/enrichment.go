@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"time"
+)
+
+// ReadStaleSongs returns up to limit songs of any library without text or
+// not updated since staleBefore, newest first, that come after the cursor
+func (s *Store) ReadStaleSongs(_ context.Context, staleBefore time.Time, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := slices.DeleteFunc(s.filterSongs(&domain.SongFilter{}), func(song *domain.Song) bool {
+		if song.Text != "" && !song.UpdatedAt.Before(staleBefore) {
+			return true
+		}
+		return after != nil && newestFirst(song, &domain.Song{CreatedAt: after.CreatedAt, ID: after.ID}) <= 0
+	})
+
+	return page(songs, limit, 0), nil
+}
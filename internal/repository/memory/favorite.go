@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddFavorite marks a song as a favorite of the user. Adding a song twice is
+// a no-op, the favorites counter of the song only grows for new favorites.
+func (s *Store) AddFavorite(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "repository.MemoryDB.AddFavorite"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	song, ok := s.librarySong(ctx, songID)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	favorites, ok := s.favorites[userID]
+	if !ok {
+		favorites = make(map[uuid.UUID]time.Time)
+		s.favorites[userID] = favorites
+	}
+	if _, ok := favorites[songID]; ok {
+		return nil
+	}
+
+	favorites[songID] = time.Now()
+	song.FavoritesCount++
+
+	return nil
+}
+
+// RemoveFavorite unmarks a favorite song of the user. Removing a song that
+// isn't a favorite is a no-op.
+func (s *Store) RemoveFavorite(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "repository.MemoryDB.RemoveFavorite"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	song, ok := s.librarySong(ctx, songID)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	if _, ok := s.favorites[userID][songID]; !ok {
+		return nil
+	}
+
+	delete(s.favorites[userID], songID)
+	song.FavoritesCount--
+
+	return nil
+}
+
+// ReadFavorites returns the favorite songs of the user, most recently added first.
+func (s *Store) ReadFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	favorites := s.favorites[userID]
+
+	var songs []*domain.Song
+	for songID := range favorites {
+		if stored, ok := s.librarySong(ctx, songID); ok {
+			song := *stored
+			songs = append(songs, &song)
+		}
+	}
+
+	slices.SortFunc(songs, func(a, b *domain.Song) int {
+		return favorites[b.ID].Compare(favorites[a.ID])
+	})
+
+	return page(songs, limit, offset), nil
+}
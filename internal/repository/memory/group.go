@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+func (s *Store) ReadGroups(ctx context.Context, name string, limit, offset int) ([]*domain.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	groups := make(map[string]*domain.Group)
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{}) {
+		artist, ok := s.artists[song.ArtistID]
+		if !ok || (name != "" && !containsFold(artist.Name, name)) {
+			continue
+		}
+
+		group, ok := groups[artist.Name]
+		if !ok {
+			group = &domain.Group{
+				ArtistID:      artist.ID,
+				Name:          artist.Name,
+				FirstRelease:  song.ReleaseDate,
+				LatestRelease: song.ReleaseDate,
+				UpdatedAt:     song.UpdatedAt,
+			}
+			groups[artist.Name] = group
+		}
+
+		group.Songs++
+		if song.ReleaseDate.Before(group.FirstRelease) {
+			group.FirstRelease = song.ReleaseDate
+		}
+		if song.ReleaseDate.After(group.LatestRelease) {
+			group.LatestRelease = song.ReleaseDate
+		}
+		if song.UpdatedAt.After(group.UpdatedAt) {
+			group.UpdatedAt = song.UpdatedAt
+		}
+	}
+
+	result := make([]*domain.Group, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	slices.SortFunc(result, func(a, b *domain.Group) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return page(result, limit, offset), nil
+}
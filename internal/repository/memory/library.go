@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateLibrary(_ context.Context, library *domain.Library) error {
+	const op = "repository.MemoryDB.CreateLibrary"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.libraries {
+		if strings.EqualFold(stored.Name, library.Name) {
+			return fmt.Errorf("%s: %w", op, domain.ErrLibraryExists)
+		}
+	}
+
+	library.ID = uuid.New()
+	library.CreatedAt = time.Now()
+
+	stored := *library
+	s.libraries[library.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadLibrary(_ context.Context, id uuid.UUID) (*domain.Library, error) {
+	const op = "repository.MemoryDB.ReadLibrary"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.libraries[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+// ReadAllLibraries returns the libraries ordered by name
+func (s *Store) ReadAllLibraries(_ context.Context) ([]*domain.Library, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	libraries := make([]*domain.Library, 0, len(s.libraries))
+	for _, stored := range s.libraries {
+		found := *stored
+		libraries = append(libraries, &found)
+	}
+	slices.SortFunc(libraries, func(a, b *domain.Library) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return libraries, nil
+}
@@ -0,0 +1,468 @@
+// Package memory keeps the song library in process memory. It implements the
+// database and cache interfaces of the repository package for tests and the
+// --dev mode, where no PostgreSQL or Redis is available.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store is an in-memory database. It follows the constraints of the
+// PostgreSQL schema: song name and group unique within a library, unique
+// artist names and the references between songs, libraries, albums, artists,
+// favorites, ratings and plays.
+type Store struct {
+	mu         sync.RWMutex
+	libraries  map[uuid.UUID]*domain.Library
+	songs      map[uuid.UUID]*domain.Song
+	albums     map[uuid.UUID]*domain.Album
+	artists    map[uuid.UUID]*domain.Artist
+	favorites  map[uuid.UUID]map[uuid.UUID]time.Time  // user ID -> song ID -> favorited at
+	ratings    map[uuid.UUID]map[uuid.UUID]songRating // song ID -> user ID -> rating
+	plays      map[playKey]int
+	webhooks   map[uuid.UUID]*domain.Webhook
+	users      map[uuid.UUID]*domain.User
+	apiKeys    map[uuid.UUID]*domain.APIKey
+	audit      []*domain.AuditEntry
+	revisions  map[uuid.UUID]map[int]*domain.SongRevision // song ID -> revision
+	tags       map[uuid.UUID]map[string]struct{}          // song ID -> tags
+	audio      map[uuid.UUID]*domain.Audio                // song ID -> audio
+	embeddings map[uuid.UUID]songEmbedding                // song ID -> embedding
+	playlists  map[uuid.UUID]*domain.SmartPlaylist
+	shares     map[uuid.UUID]*domain.Share
+	outbox     []*domain.OutboxEvent
+	outboxID   int64
+}
+
+func NewStore() *Store {
+	return &Store{
+		libraries: map[uuid.UUID]*domain.Library{
+			domain.DefaultLibraryID: {ID: domain.DefaultLibraryID, Name: "default", CreatedAt: time.Now()},
+		},
+		songs:      make(map[uuid.UUID]*domain.Song),
+		albums:     make(map[uuid.UUID]*domain.Album),
+		artists:    make(map[uuid.UUID]*domain.Artist),
+		favorites:  make(map[uuid.UUID]map[uuid.UUID]time.Time),
+		ratings:    make(map[uuid.UUID]map[uuid.UUID]songRating),
+		plays:      make(map[playKey]int),
+		webhooks:   make(map[uuid.UUID]*domain.Webhook),
+		users:      make(map[uuid.UUID]*domain.User),
+		apiKeys:    make(map[uuid.UUID]*domain.APIKey),
+		revisions:  make(map[uuid.UUID]map[int]*domain.SongRevision),
+		tags:       make(map[uuid.UUID]map[string]struct{}),
+		embeddings: make(map[uuid.UUID]songEmbedding),
+		playlists:  make(map[uuid.UUID]*domain.SmartPlaylist),
+		shares:     make(map[uuid.UUID]*domain.Share),
+		audio:      make(map[uuid.UUID]*domain.Audio),
+	}
+}
+
+// WithinTransaction runs fn. The store has no rollback, writes made by fn
+// before it fails are kept.
+func (s *Store) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *Store) Create(ctx context.Context, song *domain.Song) error {
+	const op = "repository.MemoryDB.Create"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	if _, ok := s.libraries[library]; !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+	}
+	if s.findSong(library, song.Name, song.Group, uuid.Nil) != nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+	}
+	if song.AlbumID != nil && s.libraryAlbum(ctx, *song.AlbumID) == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	// a song queued by the write-behind cache already has its ID and
+	// timestamps
+	if song.ID == uuid.Nil {
+		song.ID = uuid.New()
+		song.CreatedAt = time.Now()
+		song.UpdatedAt = song.CreatedAt
+	}
+	song.Version = 1
+	song.LibraryID = library
+	song.ArtistID = s.upsertArtist(library, song.Group)
+	if song.Status == "" {
+		song.Status = domain.SongStatusEnriched
+	}
+
+	stored := *song
+	s.songs[song.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "repository.MemoryDB.Read"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.librarySong(ctx, song.ID)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+// ReadByIDs returns the songs of the library with the given IDs, IDs of
+// missing songs are skipped
+func (s *Store) ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var songs []*domain.Song
+	for _, id := range ids {
+		if stored, ok := s.librarySong(ctx, id); ok {
+			found := *stored
+			songs = append(songs, &found)
+		}
+	}
+
+	return songs, nil
+}
+
+// ReadByNameAndGroup finds a song by its name and group ignoring case and
+// accents, a song that matches with accents is preferred
+func (s *Store) ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "repository.MemoryDB.ReadByNameAndGroup"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	stored := s.findSong(library, song.Name, song.Group, uuid.Nil)
+	if stored == nil {
+		stored = s.findNormalizedSong(library, song.Name, song.Group)
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+func (s *Store) ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := s.filterLibrarySongs(ctx, filter)
+	switch filter.Sort {
+	case domain.SortByPopularity:
+		slices.SortStableFunc(songs, func(a, b *domain.Song) int {
+			if a.FavoritesCount != b.FavoritesCount {
+				return b.FavoritesCount - a.FavoritesCount
+			}
+			return newestFirst(a, b)
+		})
+	case domain.SortByRating:
+		slices.SortStableFunc(songs, func(a, b *domain.Song) int {
+			if c := cmp.Compare(b.RatingAverage, a.RatingAverage); c != 0 {
+				return c
+			}
+			if a.RatingsCount != b.RatingsCount {
+				return b.RatingsCount - a.RatingsCount
+			}
+			return newestFirst(a, b)
+		})
+	case domain.SortByUpdatedAt:
+		slices.SortStableFunc(songs, func(a, b *domain.Song) int {
+			if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+				return c
+			}
+			return newestFirst(a, b)
+		})
+	}
+
+	return listed(filter, page(songs, limit, offset)), nil
+}
+
+// ReadAllAfter returns up to limit songs matching the filter, newest first,
+// that come after the cursor. A nil cursor starts from the newest song.
+func (s *Store) ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := s.filterLibrarySongs(ctx, filter)
+	if after != nil {
+		songs = slices.DeleteFunc(songs, func(song *domain.Song) bool {
+			return newestFirst(song, &domain.Song{CreatedAt: after.CreatedAt, ID: after.ID}) <= 0
+		})
+	}
+
+	return listed(filter, page(songs, limit, 0)), nil
+}
+
+// listed drops the text and lyrics of the listed copies of songs if the
+// filter asks for them to be left out
+func listed(filter *domain.SongFilter, songs []*domain.Song) []*domain.Song {
+	if filter.WithoutText {
+		for _, song := range songs {
+			song.Text, song.Lyrics = "", nil
+		}
+	}
+	return songs
+}
+
+func (s *Store) Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error {
+	const op = "repository.MemoryDB.Update"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.librarySong(ctx, song.ID)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+	if stored.Version != updatedSong.Version {
+		return fmt.Errorf("%s: %w", op, domain.ErrVersionConflict)
+	}
+	if s.findSong(stored.LibraryID, updatedSong.Name, updatedSong.Group, song.ID) != nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+	}
+	if updatedSong.AlbumID != nil && s.libraryAlbum(ctx, *updatedSong.AlbumID) == nil {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	updatedSong.UpdatedAt = time.Now()
+	updatedSong.Version = stored.Version + 1
+	updatedSong.ArtistID = s.upsertArtist(stored.LibraryID, updatedSong.Group)
+	updatedSong.LibraryID = stored.LibraryID
+	// an empty status keeps the stored one
+	if updatedSong.Status == "" {
+		updatedSong.Status = stored.Status
+	}
+
+	stored.Name = updatedSong.Name
+	stored.Group = updatedSong.Group
+	stored.Text = updatedSong.Text
+	stored.Lyrics = updatedSong.Lyrics
+	stored.LockedFields = updatedSong.LockedFields
+	stored.Source = updatedSong.Source
+	stored.Link = updatedSong.Link
+	stored.ReleaseDate = updatedSong.ReleaseDate
+	stored.Duration = updatedSong.Duration
+	stored.Genre = updatedSong.Genre
+	stored.TrackNumber = updatedSong.TrackNumber
+	stored.Album = updatedSong.Album
+	stored.Explicit = updatedSong.Explicit
+	stored.Status = updatedSong.Status
+	stored.UpdatedAt = updatedSong.UpdatedAt
+	stored.AlbumID = updatedSong.AlbumID
+	stored.ArtistID = updatedSong.ArtistID
+	stored.Version = updatedSong.Version
+
+	return nil
+}
+
+// Delete removes a song together with its favorites, ratings, plays, revisions and tags
+func (s *Store) Delete(ctx context.Context, song *domain.SongInfo) error {
+	const op = "repository.MemoryDB.Delete"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.librarySong(ctx, song.ID); !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	delete(s.songs, song.ID)
+	delete(s.revisions, song.ID)
+	delete(s.tags, song.ID)
+	delete(s.audio, song.ID)
+	delete(s.embeddings, song.ID)
+	delete(s.ratings, song.ID)
+	for _, favorites := range s.favorites {
+		delete(favorites, song.ID)
+	}
+	for key := range s.plays {
+		if key.songID == song.ID {
+			delete(s.plays, key)
+		}
+	}
+	s.deleteShares(song.ID)
+
+	return nil
+}
+
+// librarySong returns the stored song if it belongs to the library ctx is
+// scoped to
+func (s *Store) librarySong(ctx context.Context, id uuid.UUID) (*domain.Song, bool) {
+	song, ok := s.songs[id]
+	if !ok || song.LibraryID != domain.LibraryIDFromContext(ctx) {
+		return nil, false
+	}
+	return song, true
+}
+
+// findSong returns the song of the library with the case-insensitive name
+// and group, other than the song with the except ID
+func (s *Store) findSong(library uuid.UUID, name, group string, except uuid.UUID) *domain.Song {
+	for _, song := range s.songs {
+		if song.LibraryID != library || song.ID == except {
+			continue
+		}
+		if strings.EqualFold(song.Name, name) && strings.EqualFold(song.Group, group) {
+			return song
+		}
+	}
+	return nil
+}
+
+// findNormalizedSong returns the oldest song of the library with the name
+// and group ignoring case and accents
+func (s *Store) findNormalizedSong(library uuid.UUID, name, group string) *domain.Song {
+	name, group = normalizeName(name), normalizeName(group)
+
+	var found *domain.Song
+	for _, song := range s.songs {
+		if song.LibraryID != library || normalizeName(song.Name) != name || normalizeName(song.Group) != group {
+			continue
+		}
+		if found == nil || song.CreatedAt.Before(found.CreatedAt) {
+			found = song
+		}
+	}
+	return found
+}
+
+// filterLibrarySongs is filterSongs for the songs of the library ctx is
+// scoped to
+func (s *Store) filterLibrarySongs(ctx context.Context, filter *domain.SongFilter) []*domain.Song {
+	library := domain.LibraryIDFromContext(ctx)
+	return slices.DeleteFunc(s.filterSongs(filter), func(song *domain.Song) bool {
+		return song.LibraryID != library
+	})
+}
+
+// filterSongs returns copies of the songs of any library matching the
+// non-empty fields of filter like the songFilter of PostgreSQL, newest first
+func (s *Store) filterSongs(filter *domain.SongFilter) []*domain.Song {
+	var songs []*domain.Song
+	for _, song := range s.songs {
+		if !s.matches(song, filter) {
+			continue
+		}
+		found := *song
+		songs = append(songs, &found)
+	}
+
+	slices.SortFunc(songs, newestFirst)
+	return songs
+}
+
+func (s *Store) matches(song *domain.Song, filter *domain.SongFilter) bool {
+	if filter.Name != "" && !containsNormalized(song.Name, filter.Name) {
+		return false
+	}
+	if filter.Group != "" && !containsNormalized(song.Group, filter.Group) {
+		return false
+	}
+	if filter.Text != "" && !containsFold(song.Text, filter.Text) {
+		return false
+	}
+	if filter.Link != "" && !containsFold(song.Link, filter.Link) {
+		return false
+	}
+	if filter.ArtistID != uuid.Nil && song.ArtistID != filter.ArtistID {
+		return false
+	}
+	if !filter.ReleaseDate.IsZero() && !song.ReleaseDate.Equal(filter.ReleaseDate) {
+		return false
+	}
+	if filter.Status != "" && song.Status != filter.Status {
+		return false
+	}
+	if filter.Genre != "" && !strings.EqualFold(song.Genre, filter.Genre) {
+		return false
+	}
+	if filter.Album != "" && !containsFold(song.Album, filter.Album) {
+		return false
+	}
+	if filter.Explicit != nil && (song.Explicit == nil || *song.Explicit != *filter.Explicit) {
+		return false
+	}
+	if filter.ExcludeExplicit && song.Explicit != nil && *song.Explicit {
+		return false
+	}
+	if filter.ExcludeArchived && song.Status == domain.SongStatusArchived {
+		return false
+	}
+	if filter.MinRating > 0 && song.RatingAverage < filter.MinRating {
+		return false
+	}
+	// songs of unknown duration match no duration range
+	if filter.MinDuration > 0 && song.Duration < filter.MinDuration {
+		return false
+	}
+	if filter.MaxDuration > 0 && (song.Duration == 0 || song.Duration > filter.MaxDuration) {
+		return false
+	}
+	// songs of unknown release date match no date range
+	if !filter.ReleasedFrom.IsZero() && (song.ReleaseDate.IsZero() || song.ReleaseDate.Before(filter.ReleasedFrom)) {
+		return false
+	}
+	if !filter.ReleasedTo.IsZero() && (song.ReleaseDate.IsZero() || song.ReleaseDate.After(filter.ReleasedTo)) {
+		return false
+	}
+	if filter.Query != "" && !matchesQuery(song, filter.Query) {
+		return false
+	}
+	if len(filter.Tags) > 0 {
+		tagged := 0
+		for _, tag := range filter.Tags {
+			if _, ok := s.tags[song.ID][tag]; ok {
+				tagged++
+			}
+		}
+		if tagged == 0 || (filter.TagMode != domain.TagModeAny && tagged < len(filter.Tags)) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold is the ILIKE '%substr%' of PostgreSQL
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// newestFirst orders songs by created_at DESC, id DESC
+func newestFirst(a, b *domain.Song) int {
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(b.ID.String(), a.ID.String())
+}
+
+// page applies LIMIT and OFFSET, a limit of zero returns everything
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit != 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,1309 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Store и Cache должны подходить репозиториям вместо PostgreSQL и Redis
+var (
+	_ repository.Database           = (*Store)(nil)
+	_ repository.AlbumDatabase      = (*Store)(nil)
+	_ repository.ArtistDatabase     = (*Store)(nil)
+	_ repository.FavoriteDatabase   = (*Store)(nil)
+	_ repository.RatingDatabase     = (*Store)(nil)
+	_ repository.PlayDatabase       = (*Store)(nil)
+	_ repository.WebhookDatabase    = (*Store)(nil)
+	_ repository.AuditDatabase      = (*Store)(nil)
+	_ repository.RevisionDatabase   = (*Store)(nil)
+	_ repository.TagDatabase        = (*Store)(nil)
+	_ repository.EnrichmentDatabase = (*Store)(nil)
+	_ repository.AudioDatabase      = (*Store)(nil)
+	_ repository.SuggestionDatabase = (*Store)(nil)
+	_ repository.SearchDatabase     = (*Store)(nil)
+	_ repository.EmbeddingDatabase  = (*Store)(nil)
+	_ repository.CalendarDatabase   = (*Store)(nil)
+	_ repository.RandomDatabase     = (*Store)(nil)
+	_ repository.StatsDatabase      = (*Store)(nil)
+	_ repository.GroupDatabase      = (*Store)(nil)
+	_ repository.OutboxDatabase     = (*Store)(nil)
+	_ repository.LibraryDatabase    = (*Store)(nil)
+	_ repository.UserDatabase       = (*Store)(nil)
+	_ repository.APIKeyDatabase     = (*Store)(nil)
+	_ repository.PlaylistDatabase   = (*Store)(nil)
+	_ repository.Cache              = (*Cache)(nil)
+	_ repository.PlayBuffer         = (*Cache)(nil)
+	_ repository.SuggestionCache    = (*Cache)(nil)
+	_ repository.DailyPickCache     = (*Cache)(nil)
+	_ repository.StatsCache         = (*Cache)(nil)
+)
+
+func createSong(t *testing.T, s *Store, name, group string) *domain.Song {
+	t.Helper()
+
+	song := &domain.Song{Name: name, Group: group, ReleaseDate: time.Date(2006, 6, 19, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, s.Create(context.Background(), song))
+	return song
+}
+
+func TestStore_CreateAndRead(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	song := createSong(t, s, "Hysteria", "Muse")
+
+	// Песня получает ID, версию и исполнителя
+	assert.NotEqual(t, uuid.Nil, song.ID)
+	assert.Equal(t, 1, song.Version)
+	assert.NotEqual(t, uuid.Nil, song.ArtistID)
+
+	found, err := s.Read(ctx, &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Equal(t, song, found)
+
+	found, err = s.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "hysteria", Group: "MUSE"})
+	require.NoError(t, err)
+	assert.Equal(t, song.ID, found.ID)
+
+	// Изменение прочитанной копии не меняет хранилище
+	found.Name = "Changed"
+	stored, err := s.Read(ctx, &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Hysteria", stored.Name)
+
+	_, err = s.Read(ctx, &domain.SongInfo{ID: uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestStore_Create_Duplicate(t *testing.T) {
+	s := NewStore()
+	createSong(t, s, "Hysteria", "Muse")
+
+	// Название и группа уникальны без учёта регистра
+	err := s.Create(context.Background(), &domain.Song{Name: "HYSTERIA", Group: "muse"})
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+}
+
+func TestStore_Create_KeepsAssignedID(t *testing.T) {
+	s := NewStore()
+	id := uuid.New()
+	createdAt := time.Date(2024, 10, 14, 23, 36, 29, 0, time.UTC)
+
+	// Песня из очереди write-behind сохраняет выданные кэшем ID и время создания
+	song := &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", CreatedAt: createdAt, UpdatedAt: createdAt}
+	require.NoError(t, s.Create(context.Background(), song))
+
+	stored, err := s.Read(context.Background(), &domain.SongInfo{ID: id})
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(stored.CreatedAt))
+}
+
+func TestStore_Create_UnknownAlbum(t *testing.T) {
+	s := NewStore()
+	albumID := uuid.New()
+
+	err := s.Create(context.Background(), &domain.Song{Name: "Hysteria", Group: "Muse", AlbumID: &albumID})
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+}
+
+func TestStore_Update(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Starlight", "Muse")
+
+	updated := *song
+	updated.Text = "It's bugging me"
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: song.ID}, &updated))
+	assert.Equal(t, 2, updated.Version)
+
+	// Устаревшая версия отклоняется
+	stale := *song
+	err := s.Update(ctx, &domain.SongInfo{ID: song.ID}, &stale)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	// Нельзя переименовать песню в уже существующую
+	updated.Name = "Starlight"
+	err = s.Update(ctx, &domain.SongInfo{ID: song.ID}, &updated)
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+
+	err = s.Update(ctx, &domain.SongInfo{ID: uuid.New()}, &updated)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestStore_ReadAllWithFilter(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Starlight", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	// Фильтр по подстроке без учёта регистра
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Group: "mus"}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 2)
+	assert.Equal(t, "Starlight", songs[0].Name)
+	assert.Equal(t, "Hysteria", songs[1].Name)
+
+	// Пагинация
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{}, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, "Hysteria", songs[0].Name)
+
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{}, 2, 5)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+}
+
+func TestStore_ReadAllWithFilter_Popularity(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Starlight", "Muse")
+
+	require.NoError(t, s.AddFavorite(ctx, uuid.New(), hysteria.ID))
+
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Sort: domain.SortByPopularity}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 2)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+	assert.Equal(t, 1, songs[0].FavoritesCount)
+}
+
+func TestStore_ReadAllWithFilter_UpdatedAt(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	starlight := createSong(t, s, "Starlight", "Muse")
+
+	// Изменённая раньше добавленной песня идёт первой
+	updated := *hysteria
+	updated.Text = "It's bugging me"
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: hysteria.ID}, &updated))
+
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Sort: domain.SortByUpdatedAt}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 2)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+	assert.Equal(t, starlight.ID, songs[1].ID)
+}
+
+func TestStore_ReadAllAfter(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	for i := 0; i < 5; i++ {
+		createSong(t, s, fmt.Sprintf("Song %d", i), "Muse")
+	}
+
+	all, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{}, 0, 0)
+	require.NoError(t, err)
+
+	// Страницы по курсору идут подряд без пропусков и повторов
+	var pages []*domain.Song
+	var cursor *domain.SongCursor
+	for {
+		songs, err := s.ReadAllAfter(ctx, &domain.SongFilter{}, cursor, 2)
+		require.NoError(t, err)
+		if len(songs) == 0 {
+			break
+		}
+		pages = append(pages, songs...)
+		cursor = domain.CursorOf(songs[len(songs)-1])
+	}
+	assert.Equal(t, all, pages)
+}
+
+func TestStore_Delete_Cascades(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := createSong(t, s, "Hysteria", "Muse")
+	userID := uuid.New()
+
+	require.NoError(t, s.AddFavorite(ctx, userID, song.ID))
+	require.NoError(t, s.AddPlays(ctx, map[uuid.UUID]int{song.ID: 3}, time.Now()))
+
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+
+	// Вместе с песней удаляются избранное и прослушивания
+	favorites, err := s.ReadFavorites(ctx, userID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, favorites)
+
+	trending, err := s.ReadTrending(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, trending)
+
+	err = s.Delete(ctx, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestStore_Artists(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := createSong(t, s, "Hysteria", "Muse")
+
+	// Исполнитель с песнями не удаляется
+	err := s.DeleteArtist(ctx, song.ArtistID)
+	assert.ErrorIs(t, err, domain.ErrArtistHasSongs)
+
+	err = s.CreateArtist(ctx, &domain.Artist{Name: "Muse"})
+	assert.ErrorIs(t, err, domain.ErrArtistExists)
+
+	// Переименование исполнителя меняет группу его песен
+	require.NoError(t, s.UpdateArtist(ctx, &domain.Artist{ID: song.ArtistID, Name: "MUSE"}))
+	renamed, err := s.Read(ctx, &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "MUSE", renamed.Group)
+	assert.Equal(t, 2, renamed.Version)
+}
+
+func TestStore_DeleteAlbum_KeepsSongs(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	album := &domain.Album{Title: "Absolution", Group: "Muse"}
+	require.NoError(t, s.CreateAlbum(ctx, album))
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", AlbumID: &album.ID}
+	require.NoError(t, s.Create(ctx, song))
+
+	require.NoError(t, s.DeleteAlbum(ctx, album.ID))
+
+	// Песня остаётся, но без альбома
+	found, err := s.Read(ctx, &domain.SongInfo{ID: song.ID})
+	require.NoError(t, err)
+	assert.Nil(t, found.AlbumID)
+}
+
+func TestStore_ReadDuplicates(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Hysteria (Live)", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	duplicates, err := s.ReadDuplicates(ctx, 0.5, 10)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 1)
+
+	names := []string{duplicates[0].Song.Name, duplicates[0].Duplicate.Name}
+	assert.ElementsMatch(t, []string{"Hysteria", "Hysteria (Live)"}, names)
+	assert.Less(t, duplicates[0].Song.ID.String(), duplicates[0].Duplicate.ID.String())
+}
+
+func TestStore_NormalizedNames(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Müse")
+	cafe := createSong(t, s, "Café Society", "Muse")
+
+	// Регистр и диакритика не учитываются при поиске по названию и группе
+	found, err := s.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "HYSTERIA", Group: "muse"})
+	require.NoError(t, err)
+	assert.Equal(t, hysteria.ID, found.ID)
+
+	// Точное совпадение с диакритикой предпочтительнее
+	exact := createSong(t, s, "Hysteria", "Muse")
+	found, err = s.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "hysteria", Group: "MUSE"})
+	require.NoError(t, err)
+	assert.Equal(t, exact.ID, found.ID)
+
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Name: "cafe"}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, cafe.ID, songs[0].ID)
+
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{Group: "müse"}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 3)
+
+	// Песни, отличающиеся только диакритикой, — полные дубликаты
+	duplicates, err := s.ReadDuplicates(ctx, 0.9, 10)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, float64(1), duplicates[0].Similarity)
+}
+
+func TestNormalizeName(t *testing.T) {
+	for value, want := range map[string]string{
+		"Müse":            "muse",
+		"Beyoncé":         "beyonce",
+		"Motörhead":       "motorhead",
+		"Sigur Rós":       "sigur ros",
+		"Røyksopp":        "royksopp",
+		"Die Ärzte":       "die arzte",
+		"Straße":          "strasse",
+		"Мумий Тролль":    "мумии тролль", // как и unaccent, й сводится к и
+		"Hysteria (Live)": "hysteria (live)",
+	} {
+		assert.Equal(t, want, normalizeName(value), value)
+	}
+}
+
+func TestStore_ReadSuggestions(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Hyper Music", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	// Совпадения по префиксу идут первыми, группа предлагается один раз
+	suggestions, err := s.ReadSuggestions(ctx, "hy", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Suggestion{
+		{Kind: domain.SuggestionSong, Text: "Hyper Music", Group: "Muse", Score: 1},
+		{Kind: domain.SuggestionSong, Text: "Hysteria", Group: "Muse", Score: 1},
+	}, suggestions)
+
+	suggestions, err = s.ReadSuggestions(ctx, "mu", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: "Muse", Score: 1}}, suggestions)
+
+	// Опечатка находится по триграммам
+	suggestions, err = s.ReadSuggestions(ctx, "radiohed", 10)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "Radiohead", suggestions[0].Text)
+	assert.Less(t, suggestions[0].Score, 1.0)
+
+	suggestions, err = s.ReadSuggestions(ctx, "hy", 1)
+	require.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+}
+
+func TestStore_UpdateAllWithFilter(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	bliss := createSong(t, s, "Bliss", "Muse")
+	creep := createSong(t, s, "Creep", "Radiohead")
+
+	group, genre := "MUSE", "rock"
+	updates, err := s.UpdateAllWithFilter(ctx, &domain.SongFilter{Group: "muse"}, &domain.SongChanges{Group: &group, Genre: &genre})
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+
+	// Песни переезжают к новому исполнителю, жанр блокируется от обновления из MusicInfo
+	for _, update := range updates {
+		assert.Equal(t, "Muse", update.Old.Group)
+		assert.Equal(t, "MUSE", update.New.Group)
+		assert.Equal(t, update.Old.Version+1, update.New.Version)
+		assert.NotEqual(t, update.Old.ArtistID, update.New.ArtistID)
+		assert.True(t, update.New.LockedFields.Has(domain.FieldGenre))
+	}
+	for _, song := range []*domain.Song{hysteria, bliss} {
+		stored, err := s.Read(ctx, &domain.SongInfo{ID: song.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "MUSE", stored.Group)
+		assert.Equal(t, "rock", stored.Genre)
+	}
+	stored, err := s.Read(ctx, &domain.SongInfo{ID: creep.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Radiohead", stored.Group)
+
+	// Пустой фильтр не меняет всю библиотеку
+	_, err = s.UpdateAllWithFilter(ctx, &domain.SongFilter{}, &domain.SongChanges{Genre: &genre})
+	assert.ErrorIs(t, err, domain.ErrBulkFilterEmpty)
+}
+
+func TestStore_UpdateAllWithFilter_Duplicate(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	createSong(t, s, "Creep", "Radiohead")
+	stp := createSong(t, s, "Creep", "Stone Temple Pilots")
+
+	// Переименование, которое даёт дубликат, ничего не меняет
+	group := "radiohead"
+	_, err := s.UpdateAllWithFilter(ctx, &domain.SongFilter{Group: "Stone"}, &domain.SongChanges{Group: &group})
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+
+	stored, err := s.Read(ctx, &domain.SongInfo{ID: stp.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Stone Temple Pilots", stored.Group)
+	assert.Equal(t, stp.Version, stored.Version)
+}
+
+func TestStore_SearchSongs(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me\nGrating me\nAnd twisting me around"}
+	require.NoError(t, s.Create(ctx, hysteria))
+	love := &domain.Song{Name: "Love", Group: "Lana Del Rey", Text: "Look at you kids\nYou know you're cool"}
+	require.NoError(t, s.Create(ctx, love))
+	youLove := &domain.Song{Name: "Bliss", Group: "Muse", Text: "Everything about you is so easy to love\nYou love"}
+	require.NoError(t, s.Create(ctx, youLove))
+
+	// Совпадение в названии весит больше, чем в тексте
+	results, err := s.SearchSongs(ctx, "Love", false, 3, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, love.ID, results[0].Song.ID)
+	assert.Equal(t, youLove.ID, results[1].Song.ID)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+	assert.Empty(t, results[0].Snippets)
+	assert.Equal(t, []string{
+		"Everything about you is so easy to " + domain.HighlightStart + "love" + domain.HighlightEnd,
+		"You " + domain.HighlightStart + "love" + domain.HighlightEnd,
+	}, results[1].Snippets)
+
+	// Число фрагментов ограничивается
+	results, err = s.SearchSongs(ctx, "love", false, 1, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, results[1].Snippets, 1)
+
+	// Все слова должны найтись, слова с минусом исключают песню
+	results, err = s.SearchSongs(ctx, "muse me", false, 3, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, hysteria.ID, results[0].Song.ID)
+	assert.Len(t, results[0].Snippets, 3)
+
+	results, err = s.SearchSongs(ctx, "muse -bliss", false, 0, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, hysteria.ID, results[0].Song.ID)
+	assert.Empty(t, results[0].Snippets)
+
+	results, err = s.SearchSongs(ctx, "love", false, 0, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, youLove.ID, results[0].Song.ID)
+
+	// Песни с пометкой explicit исключаются по запросу
+	explicit := true
+	require.NoError(t, s.Create(ctx, &domain.Song{Name: "Love Me", Group: "Muse", Explicit: &explicit}))
+	results, err = s.SearchSongs(ctx, "love", false, 0, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	results, err = s.SearchSongs(ctx, "love", true, 0, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, love.ID, results[0].Song.ID)
+	assert.Equal(t, youLove.ID, results[1].Song.ID)
+}
+
+func TestStore_ReadRandom(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	createSong(t, s, "Hysteria", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	// Случайная песня выбирается только среди подходящих под фильтр
+	for range 10 {
+		song, err := s.ReadRandom(ctx, &domain.SongFilter{Group: "muse"})
+		require.NoError(t, err)
+		assert.Equal(t, "Hysteria", song.Name)
+	}
+
+	_, err := s.ReadRandom(ctx, &domain.SongFilter{Group: "Queen"})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestStore_ReadSeeded(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	_, err := s.ReadSeeded(ctx, "2024-05-01")
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	for i := range 20 {
+		createSong(t, s, fmt.Sprintf("Song %d", i), "Muse")
+	}
+
+	// Одно и то же зерно всегда выбирает одну песню, разные дни — обычно разные
+	first, err := s.ReadSeeded(ctx, "2024-05-01")
+	require.NoError(t, err)
+	again, err := s.ReadSeeded(ctx, "2024-05-01")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+
+	picked := make(map[string]bool)
+	for day := 1; day <= 10; day++ {
+		song, err := s.ReadSeeded(ctx, fmt.Sprintf("2024-05-%02d", day))
+		require.NoError(t, err)
+		picked[song.ID.String()] = true
+	}
+	assert.Greater(t, len(picked), 1)
+}
+
+func TestStore_ReadLibraryStats(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	uprising := createSong(t, s, "Uprising", "MUSE")
+	createSong(t, s, "Creep", "Radiohead")
+
+	// Тексты и ссылки меняем напрямую, минуя Update
+	s.songs[hysteria.ID].Text = "Привет"
+	s.songs[hysteria.ID].Link = "https://example.com"
+	s.songs[uprising.ID].Text = "Paranoia"
+
+	stats, err := s.ReadLibraryStats(ctx, time.Now().AddDate(0, 0, -1))
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Songs)
+	assert.Equal(t, 2, stats.Groups)
+	assert.Equal(t, 1, stats.MissingText)
+	assert.Equal(t, 2, stats.MissingLink)
+
+	// Средняя длина считается в символах и только по песням с текстом
+	assert.Equal(t, 7.0, stats.AvgTextLength)
+
+	require.Len(t, stats.AddedPerDay, 1)
+	assert.Equal(t, 3, stats.AddedPerDay[0].Songs)
+
+	stats, err = s.ReadLibraryStats(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, stats.AddedPerDay)
+}
+
+func TestStore_ReadGroups(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	uprising := createSong(t, s, "Uprising", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	// Даты выхода меняем напрямую, минуя Update
+	s.songs[uprising.ID].ReleaseDate = time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC)
+
+	groups, err := s.ReadGroups(ctx, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	muse := groups[0]
+	assert.Equal(t, hysteria.ArtistID, muse.ArtistID)
+	assert.Equal(t, "Muse", muse.Name)
+	assert.Equal(t, 2, muse.Songs)
+	assert.Equal(t, hysteria.ReleaseDate, muse.FirstRelease)
+	assert.Equal(t, time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC), muse.LatestRelease)
+	assert.Equal(t, uprising.UpdatedAt, muse.UpdatedAt)
+	assert.Equal(t, "Radiohead", groups[1].Name)
+
+	// Фильтр по подстроке без учёта регистра и пагинация
+	groups, err = s.ReadGroups(ctx, "radio", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, 1, groups[0].Songs)
+
+	groups, err = s.ReadGroups(ctx, "", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "Radiohead", groups[0].Name)
+}
+
+func TestStore_Revisions(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := createSong(t, s, "Hysteria", "Muse")
+
+	require.NoError(t, s.CreateRevision(ctx, song))
+	updated := *song
+	updated.Text = "It's bugging me"
+	updated.Version = 2
+	require.NoError(t, s.CreateRevision(ctx, &updated))
+
+	// Ревизии идут от новых к старым
+	revisions, err := s.ReadRevisions(ctx, song.ID, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, "It's bugging me", revisions[0].Song.Text)
+
+	_, err = s.ReadRevision(ctx, song.ID, 3)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+
+	// Ревизии удаляются вместе с песней
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+	_, err = s.ReadRevision(ctx, song.ID, 1)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+}
+
+func TestStore_Tags(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	starlight := createSong(t, s, "Starlight", "Muse")
+	createSong(t, s, "Creep", "Radiohead")
+
+	require.NoError(t, s.AddTags(ctx, hysteria.ID, []string{"rock", "live"}))
+	require.NoError(t, s.AddTags(ctx, starlight.ID, []string{"rock"}))
+	assert.ErrorIs(t, s.AddTags(ctx, uuid.New(), []string{"rock"}), domain.ErrSongNotFound)
+
+	tags, err := s.ReadSongTags(ctx, hysteria.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"live", "rock"}, tags)
+
+	// По умолчанию песня должна иметь все теги фильтра
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Tags: []string{"rock", "live"}}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+
+	// В режиме any достаточно одного тега
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{Tags: []string{"rock", "live"}, TagMode: domain.TagModeAny}, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	// Теги отсортированы по числу песен
+	all, err := s.ReadTags(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Tag{{Name: "rock", Songs: 2}, {Name: "live", Songs: 1}}, all)
+
+	// Теги удаляются вместе с песней
+	require.NoError(t, s.RemoveTag(ctx, starlight.ID, "rock"))
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: hysteria.ID}))
+	all, err = s.ReadTags(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestStore_FilterByMetadata(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	explicit, notExplicit := true, false
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Genre: "Alternative Rock", Album: "Absolution", Duration: 227 * time.Second, Explicit: &notExplicit}
+	require.NoError(t, s.Create(ctx, hysteria))
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", Genre: "alternative rock", Album: "Pablo Honey", Duration: 238 * time.Second, Explicit: &explicit}
+	require.NoError(t, s.Create(ctx, creep))
+	// Длительность и признак неизвестны
+	starlight := createSong(t, s, "Starlight", "Muse")
+
+	tests := []struct {
+		name   string
+		filter *domain.SongFilter
+		want   []uuid.UUID
+	}{
+		{name: "жанр без учёта регистра", filter: &domain.SongFilter{Genre: "ALTERNATIVE ROCK"}, want: []uuid.UUID{creep.ID, hysteria.ID}},
+		{name: "подстрока альбома", filter: &domain.SongFilter{Album: "absol"}, want: []uuid.UUID{hysteria.ID}},
+		{name: "explicit", filter: &domain.SongFilter{Explicit: &explicit}, want: []uuid.UUID{creep.ID}},
+		{name: "не explicit", filter: &domain.SongFilter{Explicit: &notExplicit}, want: []uuid.UUID{hysteria.ID}},
+		{name: "исключить explicit", filter: &domain.SongFilter{ExcludeExplicit: true}, want: []uuid.UUID{starlight.ID, hysteria.ID}},
+		{name: "минимальная длительность", filter: &domain.SongFilter{MinDuration: 230 * time.Second}, want: []uuid.UUID{creep.ID}},
+		{name: "максимальная длительность", filter: &domain.SongFilter{MaxDuration: 230 * time.Second}, want: []uuid.UUID{hysteria.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, err := s.ReadAllWithFilter(ctx, tt.filter, 0, 0)
+			require.NoError(t, err)
+
+			var ids []uuid.UUID
+			for _, song := range songs {
+				ids = append(ids, song.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestStore_Status(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	// Песня без статуса считается обогащённой
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	assert.Equal(t, domain.SongStatusEnriched, hysteria.Status)
+	pending := &domain.Song{Name: "Starlight", Group: "Muse", Status: domain.SongStatusPending}
+	require.NoError(t, s.Create(ctx, pending))
+
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Status: domain.SongStatusPending}, 0, 0)
+	require.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, pending.ID, songs[0].ID)
+	}
+
+	// Обновление без статуса сохраняет прежний
+	update := *pending
+	update.Status = ""
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: pending.ID}, &update))
+	assert.Equal(t, domain.SongStatusPending, update.Status)
+
+	update.Status = domain.SongStatusEnriched
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: pending.ID}, &update))
+	stored, err := s.Read(ctx, &domain.SongInfo{ID: pending.ID})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SongStatusEnriched, stored.Status)
+
+	// Архивные песни скрываются из списков и поиска, но читаются по ID
+	update.Status = domain.SongStatusArchived
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: pending.ID}, &update))
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{ExcludeArchived: true}, 0, 0)
+	require.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{}, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+	results, err := s.SearchSongs(ctx, "starlight", false, 0, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	_, err = s.Read(ctx, &domain.SongInfo{ID: pending.ID})
+	require.NoError(t, err)
+}
+
+func TestStore_Audio(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := createSong(t, s, "Hysteria", "Muse")
+
+	_, err := s.ReadAudio(ctx, song.ID)
+	assert.ErrorIs(t, err, domain.ErrAudioNotFound)
+
+	audio := &domain.Audio{SongID: song.ID, Format: "mp3", Size: 10, Duration: time.Minute}
+	require.NoError(t, s.SaveAudio(ctx, audio))
+	assert.False(t, audio.UploadedAt.IsZero())
+	assert.ErrorIs(t, s.SaveAudio(ctx, &domain.Audio{SongID: uuid.New()}), domain.ErrSongNotFound)
+
+	// Повторная загрузка заменяет описание
+	require.NoError(t, s.SaveAudio(ctx, &domain.Audio{SongID: song.ID, Format: "flac", Size: 20}))
+	stored, err := s.ReadAudio(ctx, song.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "flac", stored.Format)
+
+	// Описание удаляется вместе с песней
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+	_, err = s.ReadAudio(ctx, song.ID)
+	assert.ErrorIs(t, err, domain.ErrAudioNotFound)
+}
+
+func TestStore_ReadStaleSongs(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	fresh := createSong(t, s, "Hysteria", "Muse")
+	fresh.Text = "It's bugging me"
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: fresh.ID}, fresh))
+	withoutText := createSong(t, s, "Starlight", "Muse")
+
+	// Песня без текста устарела в любом случае
+	songs, err := s.ReadStaleSongs(ctx, time.Now().Add(-time.Hour), nil, 10)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, withoutText.ID, songs[0].ID)
+
+	songs, err = s.ReadStaleSongs(ctx, time.Now().Add(time.Hour), nil, 10)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	songs, err = s.ReadStaleSongs(ctx, time.Now().Add(time.Hour), domain.CursorOf(songs[0]), 10)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, fresh.ID, songs[0].ID)
+}
+
+func TestStore_Outbox(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	song := &domain.Song{ID: uuid.New(), Group: "Muse", Name: "Hysteria"}
+	for _, eventType := range []domain.SongEventType{domain.SongCreated, domain.SongUpdated, domain.SongDeleted} {
+		require.NoError(t, s.CreateOutboxEvent(ctx, &domain.SongEvent{Type: eventType, Song: song}))
+	}
+
+	// События читаются в порядке записи
+	events, err := s.ReadOutboxEvents(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.SongCreated, events[0].Event.Type)
+	assert.Equal(t, domain.SongUpdated, events[1].Event.Type)
+	assert.Equal(t, song.ID, events[0].Event.Song.ID)
+
+	require.NoError(t, s.DeleteOutboxEvents(ctx, []int64{events[0].ID, events[1].ID}))
+
+	events, err = s.ReadOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.SongDeleted, events[0].Event.Type)
+}
+
+func TestStore_Libraries(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	library := &domain.Library{Name: "Team A"}
+	require.NoError(t, s.CreateLibrary(ctx, library))
+	assert.ErrorIs(t, s.CreateLibrary(ctx, &domain.Library{Name: "team a"}), domain.ErrLibraryExists)
+
+	libraries, err := s.ReadAllLibraries(ctx)
+	require.NoError(t, err)
+	require.Len(t, libraries, 2)
+
+	// Одинаковые песни могут быть в разных библиотеках
+	song := createSong(t, s, "Hysteria", "Muse")
+	libraryCtx := domain.WithLibraryID(ctx, library.ID)
+	other := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	require.NoError(t, s.Create(libraryCtx, other))
+	assert.Equal(t, library.ID, other.LibraryID)
+
+	// Песня другой библиотеки не видна
+	_, err = s.Read(libraryCtx, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	songs, err := s.ReadAllWithFilter(libraryCtx, &domain.SongFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, other.ID, songs[0].ID)
+
+	// Песню нельзя создать в несуществующей библиотеке
+	err = s.Create(domain.WithLibraryID(ctx, uuid.New()), &domain.Song{Name: "Uprising", Group: "Muse"})
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+}
+
+func TestStore_Libraries_AlbumsArtistsTags(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	library := &domain.Library{Name: "Team A"}
+	require.NoError(t, s.CreateLibrary(ctx, library))
+	libraryCtx := domain.WithLibraryID(ctx, library.ID)
+
+	// Альбом другой библиотеки не виден и не может быть указан у песни
+	album := &domain.Album{Title: "Absolution", Group: "Muse"}
+	require.NoError(t, s.CreateAlbum(ctx, album))
+	_, err := s.ReadAlbum(libraryCtx, album.ID)
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+	assert.ErrorIs(t, s.UpdateAlbum(libraryCtx, album), domain.ErrAlbumNotFound)
+	assert.ErrorIs(t, s.DeleteAlbum(libraryCtx, album.ID), domain.ErrAlbumNotFound)
+	albums, err := s.ReadAllAlbums(libraryCtx, "", 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, albums)
+	err = s.Create(libraryCtx, &domain.Song{Name: "Hysteria", Group: "Muse", AlbumID: &album.ID})
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+
+	// У каждой библиотеки свой исполнитель с тем же именем
+	song := createSong(t, s, "Hysteria", "Muse")
+	other := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	require.NoError(t, s.Create(libraryCtx, other))
+	assert.NotEqual(t, song.ArtistID, other.ArtistID)
+	_, err = s.ReadArtist(libraryCtx, song.ArtistID)
+	assert.ErrorIs(t, err, domain.ErrArtistNotFound)
+	artists, err := s.ReadAllArtists(libraryCtx, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, artists, 1)
+	assert.Equal(t, other.ArtistID, artists[0].ID)
+	assert.ErrorIs(t, s.UpdateArtist(libraryCtx, &domain.Artist{ID: song.ArtistID, Name: "Radiohead"}), domain.ErrArtistNotFound)
+	assert.ErrorIs(t, s.DeleteArtist(libraryCtx, song.ArtistID), domain.ErrArtistNotFound)
+	require.NoError(t, s.CreateArtist(libraryCtx, &domain.Artist{Name: "Radiohead"}))
+	require.NoError(t, s.CreateArtist(ctx, &domain.Artist{Name: "Radiohead"}))
+
+	// Теги песни другой библиотеки не читаются и не снимаются
+	require.NoError(t, s.AddTags(ctx, song.ID, []string{"rock"}))
+	require.NoError(t, s.RemoveTag(libraryCtx, song.ID, "rock"))
+	tags, err := s.ReadSongTags(libraryCtx, song.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+	tags, err = s.ReadSongTags(ctx, song.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rock"}, tags)
+}
+
+func TestStore_Users(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	userID := uuid.New()
+
+	_, err := s.ReadUser(ctx, userID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	require.NoError(t, s.UpsertUser(ctx, &domain.User{ID: userID, Role: domain.RoleViewer}))
+	require.NoError(t, s.UpsertUser(ctx, &domain.User{ID: userID, Role: domain.RoleAdmin}))
+
+	user, err := s.ReadUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, user.Role)
+
+	users, err := s.ReadAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	require.NoError(t, s.DeleteUser(ctx, userID))
+	assert.ErrorIs(t, s.DeleteUser(ctx, userID), domain.ErrUserNotFound)
+}
+
+func TestStore_APIKeys(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	key := &domain.APIKey{Name: "ci", Hash: "hash", Role: domain.RoleEditor}
+	require.NoError(t, s.CreateAPIKey(ctx, key))
+
+	found, err := s.ReadAPIKeyByHash(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+
+	libraryID := uuid.New()
+	err = s.CreateAPIKey(ctx, &domain.APIKey{Name: "x", Hash: "other", Role: domain.RoleViewer, LibraryID: &libraryID})
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+
+	// Повторный отзыв сохраняет время первого
+	revoked, err := s.RevokeAPIKey(ctx, key.ID, time.Now())
+	require.NoError(t, err)
+	again, err := s.RevokeAPIKey(ctx, key.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, revoked.RevokedAt, again.RevokedAt)
+
+	_, err = s.ReadAPIKey(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
+
+func TestStore_ReadSimilar(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	explicit := true
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Genre: "rock", Text: "It's bugging me, grating me"}
+	require.NoError(t, s.Create(ctx, hysteria))
+	require.NoError(t, s.AddTags(ctx, hysteria.ID, []string{"live", "90s"}))
+	starlight := &domain.Song{Name: "Starlight", Group: "MUSE", Genre: "Rock", Text: "Far away, this ship is taking me far away"}
+	require.NoError(t, s.Create(ctx, starlight))
+	require.NoError(t, s.AddTags(ctx, starlight.ID, []string{"live"}))
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", Text: "It's bugging me, grating me", Explicit: &explicit}
+	require.NoError(t, s.Create(ctx, creep))
+	// Ничего общего с песней
+	createSong(t, s, "Summertime Sadness", "Lana Del Rey")
+
+	// Тот же текст весит больше, чем та же группа, жанр и половина тегов
+	similar, err := s.ReadSimilar(ctx, hysteria, false, 10)
+	require.NoError(t, err)
+	require.Len(t, similar, 2)
+	assert.Equal(t, creep.ID, similar[0].Song.ID)
+	assert.InDelta(t, domain.SimilarTextWeight, similar[0].Score, 1e-9)
+	assert.Equal(t, starlight.ID, similar[1].Song.ID)
+	assert.Greater(t, similar[1].Score, domain.SimilarGroupWeight+domain.SimilarGenreWeight+domain.SimilarTagsWeight/2-1e-9)
+
+	similar, err = s.ReadSimilar(ctx, hysteria, true, 10)
+	require.NoError(t, err)
+	require.Len(t, similar, 1)
+	assert.Equal(t, starlight.ID, similar[0].Song.ID)
+
+	similar, err = s.ReadSimilar(ctx, hysteria, false, 1)
+	require.NoError(t, err)
+	assert.Len(t, similar, 1)
+}
+
+func TestStore_Embeddings(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	explicit := true
+
+	hysteria := createSong(t, s, "Hysteria", "Muse")
+	starlight := createSong(t, s, "Starlight", "Muse")
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", Explicit: &explicit}
+	require.NoError(t, s.Create(ctx, creep))
+
+	// Сначала ни у одной песни нет эмбеддинга
+	unembedded, err := s.ReadUnembedded(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, unembedded, 3)
+
+	require.NoError(t, s.SaveEmbedding(ctx, hysteria, []float32{1, 0}))
+	require.NoError(t, s.SaveEmbedding(ctx, starlight, []float32{0.6, 0.8}))
+	require.NoError(t, s.SaveEmbedding(ctx, creep, []float32{-1, 0}))
+
+	unembedded, err = s.ReadUnembedded(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, unembedded)
+
+	// Ближайшие по косинусу первыми
+	results, err := s.SearchEmbeddings(ctx, []float32{2, 0}, false, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, hysteria.ID, results[0].Song.ID)
+	assert.InDelta(t, 1, results[0].Rank, 1e-6)
+	assert.Equal(t, starlight.ID, results[1].Song.ID)
+	assert.InDelta(t, 0.6, results[1].Rank, 1e-6)
+	assert.Equal(t, creep.ID, results[2].Song.ID)
+
+	results, err = s.SearchEmbeddings(ctx, []float32{2, 0}, true, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, starlight.ID, results[0].Song.ID)
+
+	// Изменённая песня снова ждёт эмбеддинга
+	updated := *hysteria
+	updated.Text = "It's bugging me"
+	require.NoError(t, s.Update(ctx, &domain.SongInfo{ID: hysteria.ID}, &updated))
+	unembedded, err = s.ReadUnembedded(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unembedded, 1)
+	assert.Equal(t, hysteria.ID, unembedded[0].ID)
+
+	// Эмбеддинг удаляется вместе с песней
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: starlight.ID}))
+	results, err = s.SearchEmbeddings(ctx, []float32{2, 0}, false, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestStore_ReadReleaseCalendar(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	explicit := true
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Date(2006, 12, 1, 0, 0, 0, 0, time.UTC)}
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", ReleaseDate: time.Date(1992, 9, 21, 0, 0, 0, 0, time.UTC), Explicit: &explicit}
+	undated := &domain.Song{Name: "Untitled", Group: "Muse"}
+	for _, song := range []*domain.Song{hysteria, starlight, creep, undated} {
+		require.NoError(t, s.Create(ctx, song))
+	}
+
+	// Песни всех лет по дням года, песни без даты выпуска пропускаются
+	days, err := s.ReadReleaseCalendar(ctx, 0, false)
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Equal(t, time.September, days[0].Month)
+	assert.Equal(t, 21, days[0].Day)
+	assert.Equal(t, time.December, days[1].Month)
+	require.Len(t, days[1].Songs, 2)
+	assert.Equal(t, hysteria.ID, days[1].Songs[0].ID)
+	assert.Equal(t, starlight.ID, days[1].Songs[1].ID)
+	assert.Equal(t, 2006, days[1].Songs[1].ReleaseDate.Year())
+
+	days, err = s.ReadReleaseCalendar(ctx, 2006, false)
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+	require.Len(t, days[0].Songs, 1)
+	assert.Equal(t, starlight.ID, days[0].Songs[0].ID)
+
+	days, err = s.ReadReleaseCalendar(ctx, 0, true)
+	require.NoError(t, err)
+	assert.Len(t, days, 1)
+}
+
+func TestStore_Playlists(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	owner, other := uuid.New(), uuid.New()
+
+	playlist := &domain.SmartPlaylist{UserID: owner, Name: "Muse", Filter: domain.PlaylistFilter{Group: "muse", Tags: []string{"rock"}}}
+	require.NoError(t, s.CreatePlaylist(ctx, playlist))
+	assert.Equal(t, domain.DefaultLibraryID, playlist.LibraryID)
+
+	found, err := s.ReadPlaylist(ctx, owner, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, playlist, found)
+
+	// Плейлисты других пользователей не видны
+	_, err = s.ReadPlaylist(ctx, other, playlist.ID)
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+	playlists, err := s.ReadPlaylists(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, playlists)
+	assert.ErrorIs(t, s.DeletePlaylist(ctx, other, playlist.ID), domain.ErrPlaylistNotFound)
+
+	updated := &domain.SmartPlaylist{ID: playlist.ID, UserID: owner, Name: "Queen", Filter: domain.PlaylistFilter{Group: "queen"}}
+	require.NoError(t, s.UpdatePlaylist(ctx, updated))
+	assert.Equal(t, playlist.CreatedAt, updated.CreatedAt)
+
+	playlists, err = s.ReadPlaylists(ctx, owner)
+	require.NoError(t, err)
+	require.Len(t, playlists, 1)
+	assert.Equal(t, "Queen", playlists[0].Name)
+	assert.Empty(t, playlists[0].Filter.Tags)
+
+	require.NoError(t, s.DeletePlaylist(ctx, owner, playlist.ID))
+	_, err = s.ReadPlaylist(ctx, owner, playlist.ID)
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+}
+
+func TestStore_Shares(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	owner, other := uuid.New(), uuid.New()
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	require.NoError(t, s.Create(ctx, song))
+	share := &domain.Share{UserID: owner, SongID: &song.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, s.CreateShare(ctx, share))
+	assert.Equal(t, domain.DefaultLibraryID, share.LibraryID)
+
+	// Ссылка открывается без пользователя и библиотеки
+	found, err := s.ReadShare(domain.WithLibraryID(ctx, uuid.New()), share.ID)
+	require.NoError(t, err)
+	assert.Equal(t, share, found)
+
+	// Ссылки других пользователей не видны и не отзываются
+	shares, err := s.ReadShares(ctx, other)
+	require.NoError(t, err)
+	assert.Empty(t, shares)
+	_, err = s.RevokeShare(ctx, other, share.ID, time.Now())
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+
+	revokedAt := time.Now()
+	revoked, err := s.RevokeShare(ctx, owner, share.ID, revokedAt)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	// Повторный отзыв сохраняет время первого
+	revoked, err = s.RevokeShare(ctx, owner, share.ID, revokedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, revokedAt, *revoked.RevokedAt)
+
+	shares, err = s.ReadShares(ctx, owner)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+
+	// Ссылки удаляются вместе с песней
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+	_, err = s.ReadShare(ctx, share.ID)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+}
+
+func TestStore_Ratings(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	alice, bob := uuid.New(), uuid.New()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse"}
+	unrated := &domain.Song{Name: "Uprising", Group: "Muse"}
+	for _, song := range []*domain.Song{hysteria, starlight, unrated} {
+		require.NoError(t, s.Create(ctx, song))
+	}
+
+	rating := &domain.Rating{UserID: alice, SongID: hysteria.ID, Value: 5}
+	require.NoError(t, s.SetRating(ctx, rating))
+	rating = &domain.Rating{UserID: bob, SongID: hysteria.ID, Value: 2}
+	require.NoError(t, s.SetRating(ctx, rating))
+	assert.Equal(t, 3.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+
+	// Повторная оценка заменяет прежнюю и пересчитывает среднюю
+	rating = &domain.Rating{UserID: bob, SongID: hysteria.ID, Value: 4}
+	require.NoError(t, s.SetRating(ctx, rating))
+	assert.Equal(t, 4.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+	require.NoError(t, s.SetRating(ctx, &domain.Rating{UserID: alice, SongID: starlight.ID, Value: 3}))
+
+	found, err := s.ReadRating(ctx, bob, hysteria.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, found.Value)
+	assert.False(t, found.RatedAt.IsZero())
+	found, err = s.ReadRating(ctx, bob, starlight.ID)
+	require.NoError(t, err)
+	assert.Zero(t, found.Value)
+	assert.Equal(t, 3.0, found.Average)
+
+	// Неоценённые песни не проходят фильтр и идут в конце сортировки
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Sort: domain.SortByRating}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 3)
+	assert.Equal(t, []uuid.UUID{hysteria.ID, starlight.ID, unrated.ID}, []uuid.UUID{songs[0].ID, songs[1].ID, songs[2].ID})
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{MinRating: 3}, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	removed, err := s.RemoveRating(ctx, alice, hysteria.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, removed.Average)
+	assert.Equal(t, 1, removed.Count)
+
+	// Удаление отсутствующей оценки ничего не меняет
+	removed, err = s.RemoveRating(ctx, alice, hysteria.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.Count)
+
+	err = s.SetRating(domain.WithLibraryID(ctx, uuid.New()), &domain.Rating{UserID: alice, SongID: hysteria.ID, Value: 1})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	// Оценки удаляются вместе с песней
+	require.NoError(t, s.Delete(ctx, &domain.SongInfo{ID: hysteria.ID}))
+	assert.NotContains(t, s.ratings, hysteria.ID)
+}
+
+func TestStore_ReadAllWithFilter_ReleaseRangeAndQuery(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", ReleaseDate: time.Date(2006, 9, 4, 0, 0, 0, 0, time.UTC)}
+	undated := &domain.Song{Name: "Untitled", Group: "Muse", Text: "It's bugging me"}
+	for _, song := range []*domain.Song{hysteria, starlight, undated} {
+		require.NoError(t, s.Create(ctx, song))
+	}
+
+	// Границы диапазона включаются, песни без даты выпуска не подходят
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{ReleasedTo: hysteria.ReleaseDate}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{ReleasedFrom: time.Date(2004, 1, 1, 0, 0, 0, 0, time.UTC)}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, starlight.ID, songs[0].ID)
+
+	// Запрос ищет как полнотекстовый поиск
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{Query: "bugging -untitled"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+}
+
+func TestStore_ReadAllWithFilter_TextAndLink(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Link: "https://youtube.com/watch?v=3dm_5qWWDV8"}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", Link: "https://vimeo.com/starlight"}
+	for _, song := range []*domain.Song{hysteria, starlight} {
+		require.NoError(t, s.Create(ctx, song))
+	}
+
+	// Текст и ссылка ищутся по подстроке без учёта регистра
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{Text: "BUGGING"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{Link: "vimeo.com"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, starlight.ID, songs[0].ID)
+
+	songs, err = s.ReadAllWithFilter(ctx, &domain.SongFilter{Text: "bugging", Link: "vimeo"}, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+}
+
+func TestStore_ReadByIDs(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	require.NoError(t, s.Create(ctx, hysteria))
+
+	// Отсутствующие песни пропускаются
+	songs, err := s.ReadByIDs(ctx, []uuid.UUID{uuid.New(), hysteria.ID})
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, hysteria.ID, songs[0].ID)
+}
+
+func TestStore_ReadAllWithFilter_WithoutText(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}
+	require.NoError(t, s.Create(ctx, hysteria))
+
+	songs, err := s.ReadAllWithFilter(ctx, &domain.SongFilter{WithoutText: true}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Empty(t, songs[0].Text)
+
+	// Текст хранимой песни не затрагивается
+	song, err := s.Read(ctx, &domain.SongInfo{ID: hysteria.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "It's bugging me", song.Text)
+}
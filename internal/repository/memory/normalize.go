@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// letters replaces the letters that don't decompose into a base letter and
+// accents but are folded by unaccent all the same
+var letters = strings.NewReplacer("ø", "o", "ł", "l", "đ", "d", "ß", "ss", "æ", "ae", "œ", "oe")
+
+// normalizeName folds case and accents like normalize_name of PostgreSQL
+func normalizeName(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(s))
+	if err != nil {
+		return strings.ToLower(s)
+	}
+	return letters.Replace(folded)
+}
+
+// containsNormalized is the normalize_name(s) LIKE normalize_name('%substr%')
+// of PostgreSQL
+func containsNormalized(s, substr string) bool {
+	return strings.Contains(normalizeName(s), normalizeName(substr))
+}
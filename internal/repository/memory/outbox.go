@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+)
+
+func (s *Store) CreateOutboxEvent(_ context.Context, event *domain.SongEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outboxID++
+	stored := *event
+	stored.Song = copySong(event.Song)
+	s.outbox = append(s.outbox, &domain.OutboxEvent{ID: s.outboxID, Event: stored})
+
+	return nil
+}
+
+// ReadOutboxEvents returns up to limit oldest events
+func (s *Store) ReadOutboxEvents(_ context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]*domain.OutboxEvent, 0, min(limit, len(s.outbox)))
+	for _, event := range s.outbox[:min(limit, len(s.outbox))] {
+		copied := *event
+		copied.Event.Song = copySong(event.Event.Song)
+		events = append(events, &copied)
+	}
+
+	return events, nil
+}
+
+func (s *Store) DeleteOutboxEvents(_ context.Context, ids []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outbox = slices.DeleteFunc(s.outbox, func(event *domain.OutboxEvent) bool {
+		return slices.Contains(ids, event.ID)
+	})
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// playKey identifies the plays of a song in a time bucket
+type playKey struct {
+	songID uuid.UUID
+	bucket time.Time
+}
+
+// AddPlays adds play counts to the given time bucket. Plays of songs that
+// were deleted in the meantime are dropped.
+func (s *Store) AddPlays(_ context.Context, plays map[uuid.UUID]int, bucket time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for songID, count := range plays {
+		if _, ok := s.songs[songID]; !ok {
+			continue
+		}
+		s.plays[playKey{songID: songID, bucket: bucket}] += count
+	}
+
+	return nil
+}
+
+// ReadTrending returns the most played songs of the library since the given
+// time.
+func (s *Store) ReadTrending(ctx context.Context, since time.Time, limit int) ([]*domain.TrendingSong, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	totals := make(map[uuid.UUID]int)
+	for key, plays := range s.plays {
+		if _, ok := s.librarySong(ctx, key.songID); ok && !key.bucket.Before(since) {
+			totals[key.songID] += plays
+		}
+	}
+
+	trending := make([]*domain.TrendingSong, 0, len(totals))
+	for songID, plays := range totals {
+		song := *s.songs[songID]
+		trending = append(trending, &domain.TrendingSong{Song: &song, Plays: plays})
+	}
+
+	slices.SortFunc(trending, func(a, b *domain.TrendingSong) int {
+		if a.Plays != b.Plays {
+			return b.Plays - a.Plays
+		}
+		return newestFirst(a.Song, b.Song)
+	})
+
+	return page(trending, limit, 0), nil
+}
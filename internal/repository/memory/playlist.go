@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePlaylist saves a smart playlist of the user in the library of the context
+func (s *Store) CreatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "repository.MemoryDB.CreatePlaylist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	library := domain.LibraryIDFromContext(ctx)
+	if _, ok := s.libraries[library]; !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+	}
+
+	playlist.ID = uuid.New()
+	playlist.LibraryID = library
+	playlist.CreatedAt = time.Now()
+	playlist.UpdatedAt = playlist.CreatedAt
+
+	s.playlists[playlist.ID] = copyPlaylist(playlist)
+
+	return nil
+}
+
+// ReadPlaylist returns a smart playlist of the user in the library of the
+// context, the playlists of other users aren't found
+func (s *Store) ReadPlaylist(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error) {
+	const op = "repository.MemoryDB.ReadPlaylist"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.userPlaylist(ctx, userID, id)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+	}
+
+	return copyPlaylist(stored), nil
+}
+
+// ReadPlaylists returns the smart playlists of the user in the library of the
+// context, oldest first
+func (s *Store) ReadPlaylists(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var playlists []*domain.SmartPlaylist
+	for _, playlist := range s.playlists {
+		if _, ok := s.userPlaylist(ctx, userID, playlist.ID); ok {
+			playlists = append(playlists, copyPlaylist(playlist))
+		}
+	}
+
+	// ORDER BY created_at, id
+	slices.SortFunc(playlists, func(a, b *domain.SmartPlaylist) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+
+	return playlists, nil
+}
+
+// UpdatePlaylist changes the name and filter of a smart playlist of the user
+func (s *Store) UpdatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "repository.MemoryDB.UpdatePlaylist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.userPlaylist(ctx, playlist.UserID, playlist.ID)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+	}
+
+	playlist.LibraryID = stored.LibraryID
+	playlist.CreatedAt = stored.CreatedAt
+	playlist.UpdatedAt = time.Now()
+	s.playlists[playlist.ID] = copyPlaylist(playlist)
+
+	return nil
+}
+
+// DeletePlaylist deletes a smart playlist of the user
+func (s *Store) DeletePlaylist(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repository.MemoryDB.DeletePlaylist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.userPlaylist(ctx, userID, id); !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+	}
+
+	delete(s.playlists, id)
+	s.deleteShares(id)
+
+	return nil
+}
+
+// userPlaylist returns the stored playlist if the user owns it in the
+// library of the context
+func (s *Store) userPlaylist(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, bool) {
+	playlist, ok := s.playlists[id]
+	if !ok || playlist.UserID != userID || playlist.LibraryID != domain.LibraryIDFromContext(ctx) {
+		return nil, false
+	}
+	return playlist, true
+}
+
+func copyPlaylist(playlist *domain.SmartPlaylist) *domain.SmartPlaylist {
+	copied := *playlist
+	copied.Filter.Tags = slices.Clone(playlist.Filter.Tags)
+	return &copied
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"songLibrary/internal/domain"
+)
+
+// ReadRandom returns a random song matching the non-empty fields of filter
+func (s *Store) ReadRandom(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error) {
+	const op = "repository.MemoryDB.ReadRandom"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	songs := s.filterLibrarySongs(ctx, filter)
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	return songs[rand.IntN(len(songs))], nil
+}
+
+// ReadSeeded returns the first song ordered by the MD5 hash of its ID and
+// seed like the query of PostgreSQL
+func (s *Store) ReadSeeded(ctx context.Context, seed string) (*domain.Song, error) {
+	const op = "repository.MemoryDB.ReadSeeded"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		picked *domain.Song
+		best   string
+	)
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{ExcludeArchived: true}) {
+		sum := md5.Sum([]byte(song.ID.String() + seed))
+		hash := hex.EncodeToString(sum[:])
+		if picked == nil || hash < best || (hash == best && song.ID.String() < picked.ID.String()) {
+			picked, best = song, hash
+		}
+	}
+	if picked == nil {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	found := *picked
+	return &found, nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// songRating is the score a user gave a song
+type songRating struct {
+	value   int
+	ratedAt time.Time
+}
+
+// SetRating saves the rating the user gives a song, replacing the previous
+// one, and recomputes the rating summary of the song into rating.
+func (s *Store) SetRating(ctx context.Context, rating *domain.Rating) error {
+	const op = "repository.MemoryDB.SetRating"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	song, ok := s.librarySong(ctx, rating.SongID)
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	ratings, ok := s.ratings[rating.SongID]
+	if !ok {
+		ratings = make(map[uuid.UUID]songRating)
+		s.ratings[rating.SongID] = ratings
+	}
+
+	rating.RatedAt = time.Now()
+	ratings[rating.UserID] = songRating{value: rating.Value, ratedAt: rating.RatedAt}
+	s.recomputeRating(song)
+	rating.Average, rating.Count = song.RatingAverage, song.RatingsCount
+
+	return nil
+}
+
+// RemoveRating drops the rating of the user for a song and returns the
+// recomputed rating summary of the song. Removing a rating the user didn't
+// give is a no-op.
+func (s *Store) RemoveRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "repository.MemoryDB.RemoveRating"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	song, ok := s.librarySong(ctx, songID)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	delete(s.ratings[songID], userID)
+	s.recomputeRating(song)
+
+	return &domain.Rating{UserID: userID, SongID: songID, Average: song.RatingAverage, Count: song.RatingsCount}, nil
+}
+
+// ReadRating returns the rating the user gave a song with the rating summary
+// of the song, the value is zero if the user didn't rate it.
+func (s *Store) ReadRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "repository.MemoryDB.ReadRating"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	song, ok := s.librarySong(ctx, songID)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	given := s.ratings[songID][userID]
+	return &domain.Rating{
+		UserID:  userID,
+		SongID:  songID,
+		Value:   given.value,
+		RatedAt: given.ratedAt,
+		Average: song.RatingAverage,
+		Count:   song.RatingsCount,
+	}, nil
+}
+
+// recomputeRating sets the rating summary of the stored song from its ratings
+func (s *Store) recomputeRating(song *domain.Song) {
+	sum := 0
+	for _, given := range s.ratings[song.ID] {
+		sum += given.value
+	}
+
+	song.RatingsCount = len(s.ratings[song.ID])
+	song.RatingAverage = 0
+	if song.RatingsCount > 0 {
+		song.RatingAverage = float64(sum) / float64(song.RatingsCount)
+	}
+}
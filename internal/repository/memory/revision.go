@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateRevision keeps the state of a song before it is updated. The
+// revision number is the version of that state.
+func (s *Store) CreateRevision(_ context.Context, song *domain.Song) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions, ok := s.revisions[song.ID]
+	if !ok {
+		revisions = make(map[int]*domain.SongRevision)
+		s.revisions[song.ID] = revisions
+	}
+	if _, ok := revisions[song.Version]; ok {
+		return nil
+	}
+
+	revisions[song.Version] = &domain.SongRevision{
+		Revision: song.Version,
+		Song: &domain.Song{
+			ID:          song.ID,
+			Name:        song.Name,
+			Group:       song.Group,
+			Text:        song.Text,
+			Link:        song.Link,
+			ReleaseDate: song.ReleaseDate,
+			AlbumID:     song.AlbumID,
+			Version:     song.Version,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+// ReadRevisions returns the previous states of a song, newest first
+func (s *Store) ReadRevisions(_ context.Context, songID uuid.UUID, limit, offset int) ([]*domain.SongRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var revisions []*domain.SongRevision
+	for _, stored := range s.revisions[songID] {
+		revisions = append(revisions, copyRevision(stored))
+	}
+
+	slices.SortFunc(revisions, func(a, b *domain.SongRevision) int {
+		return b.Revision - a.Revision
+	})
+
+	return page(revisions, limit, offset), nil
+}
+
+func (s *Store) ReadRevision(_ context.Context, songID uuid.UUID, revision int) (*domain.SongRevision, error) {
+	const op = "repository.MemoryDB.ReadRevision"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.revisions[songID][revision]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrRevisionNotFound)
+	}
+
+	return copyRevision(stored), nil
+}
+
+func copyRevision(revision *domain.SongRevision) *domain.SongRevision {
+	copied := *revision
+	copied.Song = copySong(revision.Song)
+	return &copied
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"unicode"
+)
+
+// Weights of the words of a song like setweight of the search document of
+// PostgreSQL, with the default weights of ts_rank
+const (
+	nameWeight  = 1.0
+	groupWeight = 0.4
+	textWeight  = 0.1
+)
+
+// SearchSongs returns the songs containing every word of the query and none
+// of the words prefixed with -, most relevant first. Ranks are normalized to
+// [0, 1) like ts_rank_cd with normalization 32 and the lyrics lines with a
+// match are returned as up to snippets highlighted snippets.
+func (s *Store) SearchSongs(ctx context.Context, query string, excludeExplicit bool, snippets, limit, offset int) ([]*domain.SearchResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	required, excluded := searchTerms(query)
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	var results []*domain.SearchResult
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{ExcludeExplicit: excludeExplicit, ExcludeArchived: true}) {
+		name, group, text := words(song.Name), words(song.Group), words(song.Text)
+
+		var score float64
+		matched := true
+		for term := range required {
+			count := nameWeight*float64(name[term]) + groupWeight*float64(group[term]) + textWeight*float64(text[term])
+			if count == 0 {
+				matched = false
+				break
+			}
+			score += count
+		}
+		for term := range excluded {
+			if name[term]+group[term]+text[term] > 0 {
+				matched = false
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		found := *song
+		results = append(results, &domain.SearchResult{
+			Song: &found,
+			Rank: score / (score + 1),
+		})
+	}
+
+	// ORDER BY rank DESC, created_at DESC, id
+	slices.SortFunc(results, func(a, b *domain.SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Rank, a.Rank),
+			b.Song.CreatedAt.Compare(a.Song.CreatedAt),
+			strings.Compare(a.Song.ID.String(), b.Song.ID.String()),
+		)
+	})
+
+	results = page(results, limit, offset)
+	for _, result := range results {
+		result.Snippets = highlightLines(result.Song.Text, required, snippets)
+	}
+	return results, nil
+}
+
+// searchTerms splits a web search query into lower-cased words to find and
+// words to exclude, quotes and "or" are ignored
+func searchTerms(query string) (required, excluded map[string]struct{}) {
+	required, excluded = make(map[string]struct{}), make(map[string]struct{})
+	for _, field := range strings.Fields(strings.ToLower(query)) {
+		terms := required
+		if strings.HasPrefix(field, "-") {
+			terms = excluded
+		}
+		for _, word := range splitWords(field) {
+			if word != "or" {
+				terms[word] = struct{}{}
+			}
+		}
+	}
+	return required, excluded
+}
+
+// words counts the lower-cased words of s
+func words(s string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range splitWords(strings.ToLower(s)) {
+		counts[word]++
+	}
+	return counts
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlightLines returns up to limit lines of text containing one of the
+// terms, with the matched words between the highlight markers
+func highlightLines(text string, terms map[string]struct{}, limit int) []string {
+	var snippets []string
+	for _, line := range strings.Split(text, "\n") {
+		if len(snippets) >= limit {
+			break
+		}
+
+		var b strings.Builder
+		matched := false
+		start := -1
+		flush := func(end int) {
+			word := line[start:end]
+			if _, ok := terms[strings.ToLower(word)]; ok {
+				matched = true
+				word = domain.HighlightStart + word + domain.HighlightEnd
+			}
+			b.WriteString(word)
+			start = -1
+		}
+		for i, r := range line {
+			isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+			if isWord && start < 0 {
+				start = i
+			}
+			if !isWord {
+				if start >= 0 {
+					flush(i)
+				}
+				b.WriteRune(r)
+			}
+		}
+		if start >= 0 {
+			flush(len(line))
+		}
+
+		if matched {
+			snippets = append(snippets, strings.TrimSpace(b.String()))
+		}
+	}
+	return snippets
+}
+
+// matchesQuery reports whether the song contains every word of the web search
+// query and none of the excluded ones, like a song found by SearchSongs
+func matchesQuery(song *domain.Song, query string) bool {
+	required, excluded := searchTerms(query)
+	if len(required) == 0 {
+		return false
+	}
+
+	name, group, text := words(song.Name), words(song.Group), words(song.Text)
+	for term := range required {
+		if name[term]+group[term]+text[term] == 0 {
+			return false
+		}
+	}
+	for term := range excluded {
+		if name[term]+group[term]+text[term] > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateShare saves a share of the user in the library of the context
+func (s *Store) CreateShare(ctx context.Context, share *domain.Share) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	share.ID = uuid.New()
+	share.LibraryID = domain.LibraryIDFromContext(ctx)
+	share.CreatedAt = time.Now()
+
+	stored := *share
+	s.shares[share.ID] = &stored
+
+	return nil
+}
+
+// ReadShare returns a share by ID in any library
+func (s *Store) ReadShare(_ context.Context, id uuid.UUID) (*domain.Share, error) {
+	const op = "repository.MemoryDB.ReadShare"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.shares[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrShareNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+// ReadShares returns the shares of the user in the library of the context
+// including revoked and expired ones, newest first
+func (s *Store) ReadShares(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	libraryID := domain.LibraryIDFromContext(ctx)
+	var shares []*domain.Share
+	for _, stored := range s.shares {
+		if stored.UserID == userID && stored.LibraryID == libraryID {
+			found := *stored
+			shares = append(shares, &found)
+		}
+	}
+	slices.SortFunc(shares, func(a, b *domain.Share) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return shares, nil
+}
+
+// RevokeShare marks a share of the user revoked at revokedAt, a share
+// revoked before keeps its time
+func (s *Store) RevokeShare(ctx context.Context, userID, id uuid.UUID, revokedAt time.Time) (*domain.Share, error) {
+	const op = "repository.MemoryDB.RevokeShare"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.shares[id]
+	if !ok || stored.UserID != userID || stored.LibraryID != domain.LibraryIDFromContext(ctx) {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrShareNotFound)
+	}
+	if stored.RevokedAt == nil {
+		stored.RevokedAt = &revokedAt
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+// deleteShares removes the shares of a deleted song or playlist, like the
+// foreign keys of the shares table
+func (s *Store) deleteShares(id uuid.UUID) {
+	for shareID, share := range s.shares {
+		if (share.SongID != nil && *share.SongID == id) || (share.PlaylistID != nil && *share.PlaylistID == id) {
+			delete(s.shares, shareID)
+		}
+	}
+}
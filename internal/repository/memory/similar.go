@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// ReadSimilar returns up to limit songs of the library most like song with
+// the weights of domain, like the query of PostgreSQL. excludeExplicit
+// drops the songs flagged explicit.
+func (s *Store) ReadSimilar(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	text := trigrams(song.Text)
+	group := normalizeName(song.Group)
+	tags := s.tags[song.ID]
+
+	var similar []*domain.SimilarSong
+	for _, other := range s.filterLibrarySongs(ctx, &domain.SongFilter{ExcludeExplicit: excludeExplicit, ExcludeArchived: true}) {
+		if other.ID == song.ID {
+			continue
+		}
+
+		score := domain.SimilarTextWeight * similarity(text, trigrams(other.Text))
+		if normalizeName(other.Group) == group {
+			score += domain.SimilarGroupWeight
+		}
+		if other.Genre != "" && strings.EqualFold(other.Genre, song.Genre) {
+			score += domain.SimilarGenreWeight
+		}
+		if len(tags) > 0 {
+			shared := 0
+			for tag := range tags {
+				if _, ok := s.tags[other.ID][tag]; ok {
+					shared++
+				}
+			}
+			score += domain.SimilarTagsWeight * float64(shared) / float64(len(tags))
+		}
+		if score == 0 {
+			continue
+		}
+
+		found := *other
+		similar = append(similar, &domain.SimilarSong{Song: &found, Score: score})
+	}
+
+	// ORDER BY score DESC, created_at DESC, id
+	slices.SortFunc(similar, func(a, b *domain.SimilarSong) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			b.Song.CreatedAt.Compare(a.Song.CreatedAt),
+			strings.Compare(a.Song.ID.String(), b.Song.ID.String()),
+		)
+	})
+
+	return page(similar, limit, 0), nil
+}
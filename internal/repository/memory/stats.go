@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ReadLibraryStats returns the totals of the library and the number of songs
+// created in every day since since that has any, like the queries of
+// PostgreSQL
+func (s *Store) ReadLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		stats      domain.LibraryStats
+		textLength int
+		groups     = make(map[string]struct{})
+		days       = make(map[time.Time]int)
+	)
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{}) {
+		stats.Songs++
+		groups[strings.ToLower(song.Group)] = struct{}{}
+
+		if song.Text == "" {
+			stats.MissingText++
+		} else {
+			textLength += utf8.RuneCountInString(song.Text)
+		}
+		if song.Link == "" {
+			stats.MissingLink++
+		}
+
+		if !song.CreatedAt.Before(since) {
+			created := song.CreatedAt.UTC()
+			days[time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC)]++
+		}
+	}
+
+	stats.Groups = len(groups)
+	if withText := stats.Songs - stats.MissingText; withText > 0 {
+		stats.AvgTextLength = float64(textLength) / float64(withText)
+	}
+
+	for day, songs := range days {
+		stats.AddedPerDay = append(stats.AddedPerDay, domain.DateCount{Date: day, Songs: songs})
+	}
+	slices.SortFunc(stats.AddedPerDay, func(a, b domain.DateCount) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return &stats, nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// similarityThreshold is the default pg_trgm.similarity_threshold used by
+// the % operator
+const similarityThreshold = 0.3
+
+// ReadSuggestions returns up to limit song and group names starting with the
+// lower-cased query or similar to it by trigrams, best matches first, like
+// the query of PostgreSQL
+func (s *Store) ReadSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	queryGrams := trigrams(query)
+	score := func(text string) (float64, bool) {
+		lower := strings.ToLower(text)
+		if strings.HasPrefix(lower, query) {
+			return 1, true
+		}
+		score := similarity(trigrams(lower), queryGrams)
+		return score, score >= similarityThreshold
+	}
+
+	var suggestions []*domain.Suggestion
+	groups := make(map[string]*domain.Suggestion)
+	for _, song := range s.filterLibrarySongs(ctx, &domain.SongFilter{}) {
+		if score, ok := score(song.Name); ok {
+			suggestions = append(suggestions, &domain.Suggestion{
+				Kind:  domain.SuggestionSong,
+				Text:  song.Name,
+				Group: song.Group,
+				Score: score,
+			})
+		}
+
+		// GROUP BY lower(group_name) with min(group_name) as the text
+		key := strings.ToLower(song.Group)
+		if group, ok := groups[key]; ok {
+			group.Text = min(group.Text, song.Group)
+			continue
+		}
+		if score, ok := score(song.Group); ok {
+			groups[key] = &domain.Suggestion{
+				Kind:  domain.SuggestionGroup,
+				Text:  song.Group,
+				Score: score,
+			}
+		}
+	}
+	for _, group := range groups {
+		suggestions = append(suggestions, group)
+	}
+
+	// ORDER BY score DESC, text, kind, group_name
+	slices.SortFunc(suggestions, func(a, b *domain.Suggestion) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			strings.Compare(a.Text, b.Text),
+			strings.Compare(a.Kind, b.Kind),
+			strings.Compare(a.Group, b.Group),
+		)
+	})
+
+	return page(suggestions, limit, 0), nil
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AddTags attaches tags to a song of the library, tags the song already has
+// are skipped
+func (s *Store) AddTags(ctx context.Context, songID uuid.UUID, tags []string) error {
+	const op = "repository.MemoryDB.AddTags"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.librarySong(ctx, songID); !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+
+	songTags, ok := s.tags[songID]
+	if !ok {
+		songTags = make(map[string]struct{})
+		s.tags[songID] = songTags
+	}
+	for _, tag := range tags {
+		songTags[tag] = struct{}{}
+	}
+
+	return nil
+}
+
+// RemoveTag detaches a tag from a song of the library. Removing a tag the
+// song doesn't have is a no-op.
+func (s *Store) RemoveTag(ctx context.Context, songID uuid.UUID, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.librarySong(ctx, songID); !ok {
+		return nil
+	}
+	delete(s.tags[songID], tag)
+
+	return nil
+}
+
+// ReadSongTags returns the tags of a song of the library in alphabetical order
+func (s *Store) ReadSongTags(ctx context.Context, songID uuid.UUID) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.librarySong(ctx, songID); !ok {
+		return nil, nil
+	}
+
+	var tags []string
+	for tag := range s.tags[songID] {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	return tags, nil
+}
+
+// ReadTags returns the tags with the number of songs of the library they are
+// attached to, most used first
+func (s *Store) ReadTags(ctx context.Context, limit, offset int) ([]*domain.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for songID, songTags := range s.tags {
+		if _, ok := s.librarySong(ctx, songID); !ok {
+			continue
+		}
+		for tag := range songTags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]*domain.Tag, 0, len(counts))
+	for name, songs := range counts {
+		tags = append(tags, &domain.Tag{Name: name, Songs: songs})
+	}
+
+	slices.SortFunc(tags, func(a, b *domain.Tag) int {
+		if a.Songs != b.Songs {
+			return b.Songs - a.Songs
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return page(tags, limit, offset), nil
+}
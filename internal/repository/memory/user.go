@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpsertUser assigns the role to the user, creating the user if needed
+func (s *Store) UpsertUser(_ context.Context, user *domain.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user.UpdatedAt = time.Now()
+
+	stored := *user
+	s.users[user.ID] = &stored
+
+	return nil
+}
+
+func (s *Store) ReadUser(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	const op = "repository.MemoryDB.ReadUser"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+// ReadAllUsers returns the users with a role, most recently changed first
+func (s *Store) ReadAllUsers(_ context.Context) ([]*domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]*domain.User, 0, len(s.users))
+	for _, stored := range s.users {
+		found := *stored
+		users = append(users, &found)
+	}
+	slices.SortFunc(users, func(a, b *domain.User) int {
+		if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return users, nil
+}
+
+func (s *Store) DeleteUser(_ context.Context, id uuid.UUID) error {
+	const op = "repository.MemoryDB.DeleteUser"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+	}
+	delete(s.users, id)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateWebhook(_ context.Context, hook *domain.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook.ID = uuid.New()
+	hook.CreatedAt = time.Now()
+	hook.UpdatedAt = time.Now()
+
+	s.webhooks[hook.ID] = copyWebhook(hook)
+
+	return nil
+}
+
+func (s *Store) ReadWebhook(_ context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "repository.MemoryDB.ReadWebhook"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	return copyWebhook(stored), nil
+}
+
+func (s *Store) ReadAllWebhooks(_ context.Context) ([]*domain.Webhook, error) {
+	return s.webhooksWhere(func(*domain.Webhook) bool { return true }), nil
+}
+
+// ReadWebhooksForEvent returns the webhooks subscribed to events of the given type
+func (s *Store) ReadWebhooksForEvent(_ context.Context, eventType domain.SongEventType) ([]*domain.Webhook, error) {
+	return s.webhooksWhere(func(hook *domain.Webhook) bool {
+		return hook.Accepts(eventType)
+	}), nil
+}
+
+func (s *Store) UpdateWebhook(_ context.Context, hook *domain.Webhook) error {
+	const op = "repository.MemoryDB.UpdateWebhook"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.webhooks[hook.ID]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	hook.UpdatedAt = time.Now()
+	hook.CreatedAt = stored.CreatedAt
+	s.webhooks[hook.ID] = copyWebhook(hook)
+
+	return nil
+}
+
+func (s *Store) DeleteWebhook(_ context.Context, id uuid.UUID) error {
+	const op = "repository.MemoryDB.DeleteWebhook"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	delete(s.webhooks, id)
+
+	return nil
+}
+
+// webhooksWhere returns copies of the webhooks matching keep, oldest first
+func (s *Store) webhooksWhere(keep func(hook *domain.Webhook) bool) []*domain.Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var hooks []*domain.Webhook
+	for _, hook := range s.webhooks {
+		if keep(hook) {
+			hooks = append(hooks, copyWebhook(hook))
+		}
+	}
+
+	slices.SortFunc(hooks, func(a, b *domain.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return hooks
+}
+
+func copyWebhook(hook *domain.Webhook) *domain.Webhook {
+	copied := *hook
+	copied.Events = slices.Clone(hook.Events)
+	return &copied
+}
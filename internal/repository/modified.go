@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// ModifiedCache keeps the time the songs of the library ctx is scoped to
+// were last modified, an unknown time is reported as domain.ErrCacheMiss
+type ModifiedCache interface {
+	GetLibraryModified(ctx context.Context) (time.Time, error)
+	SetLibraryModified(ctx context.Context, at time.Time) error
+}
+
+// touchLibrary records that the songs of the library ctx is scoped to
+// changed. The change is already made, so a failure is only logged.
+func touchLibrary(ctx context.Context, log *slog.Logger, cache ModifiedCache) {
+	if err := cache.SetLibraryModified(ctx, time.Now()); err != nil {
+		log.Error("failed to record library modification", sl.Err(err))
+	}
+}
+
+// LibraryModified returns the time the songs of the library were last
+// modified. An unknown time, e.g. after the cache lost it, is recorded as
+// now, as nothing tells the songs didn't change just before.
+func (r *Repository) LibraryModified(ctx context.Context) (time.Time, error) {
+	const op = "Repository.LibraryModified"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	modified, err := r.cache.GetLibraryModified(ctx)
+	if err == nil {
+		return modified, nil
+	}
+	if !errors.Is(err, domain.ErrCacheMiss) {
+		log.Error("failed to read library modification time from cache", sl.Err(err))
+		return time.Time{}, err
+	}
+
+	modified = time.Now()
+	log.Debug("library modification time is unknown, recording it as now")
+	if err := r.cache.SetLibraryModified(ctx, modified); err != nil {
+		log.Error("failed to record library modification", sl.Err(err))
+		return time.Time{}, err
+	}
+	return modified, nil
+}
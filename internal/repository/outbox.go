@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+// OutboxDatabase reads the events Repository stores in the outbox together
+// with the changes they describe
+type OutboxDatabase interface {
+	ReadOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+	DeleteOutboxEvents(ctx context.Context, ids []int64) error
+
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OutboxRepository struct {
+	db  OutboxDatabase
+	log *slog.Logger
+}
+
+func NewOutboxRepository(db OutboxDatabase, log *slog.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Relay passes up to limit oldest events to publish in order and deletes them
+// from the outbox, it returns how many events were published. The events
+// stay locked while they are published, so relays of several instances don't
+// publish them twice. If publish fails or the delete isn't committed, the
+// events are published again by the next relay.
+func (r *OutboxRepository) Relay(ctx context.Context, limit int, publish func(event *domain.OutboxEvent) error) (int, error) {
+	const op = "OutboxRepository.Relay"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Int("limit", limit))
+
+	published := 0
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		log.Debug("fetching events from outbox")
+		events, err := r.db.ReadOutboxEvents(ctx, limit)
+		if err != nil {
+			log.Error("failed to fetch events from outbox", sl.Err(err))
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(events))
+		for _, event := range events {
+			if err := publish(event); err != nil {
+				log.Error("failed to publish event", slog.Int64("event_id", event.ID), sl.Err(err))
+				return err
+			}
+			ids = append(ids, event.ID)
+		}
+
+		log.Debug("deleting published events from outbox", slog.Int("count", len(ids)))
+		if err := r.db.DeleteOutboxEvents(ctx, ids); err != nil {
+			log.Error("failed to delete published events from outbox", sl.Err(err))
+			return err
+		}
+
+		published = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// outboxDB keeps the outbox in memory, deletes are only applied on commit
+type outboxDB struct {
+	events  []*domain.OutboxEvent
+	deleted []int64
+}
+
+func (db *outboxDB) ReadOutboxEvents(_ context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	return db.events[:min(limit, len(db.events))], nil
+}
+
+func (db *outboxDB) DeleteOutboxEvents(_ context.Context, ids []int64) error {
+	db.deleted = append(db.deleted, ids...)
+	return nil
+}
+
+func (db *outboxDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	deleted := len(db.deleted)
+	if err := fn(ctx); err != nil {
+		db.deleted = db.deleted[:deleted]
+		return err
+	}
+	db.events = db.events[len(db.deleted)-deleted:]
+	return nil
+}
+
+func newOutboxDB(types ...domain.SongEventType) *outboxDB {
+	db := &outboxDB{}
+	for i, eventType := range types {
+		db.events = append(db.events, &domain.OutboxEvent{ID: int64(i + 1), Event: domain.SongEvent{Type: eventType}})
+	}
+	return db
+}
+
+func TestOutboxRepository_Relay(t *testing.T) {
+	db := newOutboxDB(domain.SongCreated, domain.SongUpdated, domain.SongDeleted)
+	repo := NewOutboxRepository(db, slog.New(slogdiscard.NewDiscardHandler()))
+
+	var published []domain.SongEventType
+	publish := func(event *domain.OutboxEvent) error {
+		published = append(published, event.Event.Type)
+		return nil
+	}
+
+	// События публикуются по порядку и удаляются из outbox
+	n, err := repo.Relay(context.Background(), 2, publish)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []domain.SongEventType{domain.SongCreated, domain.SongUpdated}, published)
+	assert.Equal(t, []int64{1, 2}, db.deleted)
+	assert.Len(t, db.events, 1)
+}
+
+func TestOutboxRepository_Relay_PublishFailureKeepsEvents(t *testing.T) {
+	db := newOutboxDB(domain.SongCreated, domain.SongUpdated)
+	repo := NewOutboxRepository(db, slog.New(slogdiscard.NewDiscardHandler()))
+
+	calls := 0
+	n, err := repo.Relay(context.Background(), 10, func(event *domain.OutboxEvent) error {
+		calls++
+		if event.ID == 2 {
+			return errors.New("broker is down")
+		}
+		return nil
+	})
+
+	// Все события партии остаются в outbox и будут опубликованы повторно
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, db.deleted)
+	assert.Len(t, db.events, 2)
+}
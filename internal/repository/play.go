@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PlayDatabase interface {
+	AddPlays(ctx context.Context, plays map[uuid.UUID]int, bucket time.Time) error
+	ReadTrending(ctx context.Context, since time.Time, limit int) ([]*domain.TrendingSong, error)
+}
+
+// PlayBuffer collects plays between flushes to the database
+type PlayBuffer interface {
+	IncrPlays(ctx context.Context, songID uuid.UUID) error
+	DrainPlays(ctx context.Context) (map[uuid.UUID]int, error)
+	RestorePlays(ctx context.Context, plays map[uuid.UUID]int) error
+}
+
+type PlayRepository struct {
+	db     PlayDatabase
+	buffer PlayBuffer
+	log    *slog.Logger
+}
+
+func NewPlayRepository(db PlayDatabase, buffer PlayBuffer, log *slog.Logger) *PlayRepository {
+	return &PlayRepository{
+		db:     db,
+		buffer: buffer,
+		log:    log,
+	}
+}
+
+func (r *PlayRepository) Record(ctx context.Context, songID uuid.UUID) error {
+	const op = "PlayRepository.Record"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()))
+
+	log.Debug("recording play in buffer")
+	if err := r.buffer.IncrPlays(ctx, songID); err != nil {
+		log.Error("failed to record play in buffer", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// Flush moves the buffered plays to the database under the given time bucket
+// and returns the number of flushed plays. If the database write fails the
+// plays are put back into the buffer for the next flush.
+func (r *PlayRepository) Flush(ctx context.Context, bucket time.Time) (int, error) {
+	const op = "PlayRepository.Flush"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("draining plays from buffer")
+	plays, err := r.buffer.DrainPlays(ctx)
+	if err != nil {
+		log.Error("failed to drain plays from buffer", sl.Err(err))
+		return 0, err
+	}
+
+	if len(plays) == 0 {
+		return 0, nil
+	}
+
+	total := 0
+	for _, count := range plays {
+		total += count
+	}
+
+	log.Debug("writing plays to database", slog.Int("songs", len(plays)), slog.Int("plays", total))
+	if err := r.db.AddPlays(ctx, plays, bucket); err != nil {
+		log.Error("failed to write plays to database", sl.Err(err))
+
+		if restoreErr := r.buffer.RestorePlays(ctx, plays); restoreErr != nil {
+			log.Error("failed to restore plays in buffer, plays are lost",
+				slog.Int("plays", total), sl.Err(restoreErr))
+		}
+		return 0, err
+	}
+
+	log.Debug("plays successfully flushed")
+	return total, nil
+}
+
+func (r *PlayRepository) ReadTrending(ctx context.Context, since time.Time, limit int) ([]*domain.TrendingSong, error) {
+	const op = "PlayRepository.ReadTrending"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Time("since", since))
+
+	log.Debug("fetching trending songs from database")
+	trending, err := r.db.ReadTrending(ctx, since, limit)
+	if err != nil {
+		log.Error("failed to fetch trending songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("trending songs successfully fetched", slog.Int("count", len(trending)))
+	return trending, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type PlaylistDatabase interface {
+	CreatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error
+	ReadPlaylist(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error)
+	ReadPlaylists(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error)
+	UpdatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error
+	DeletePlaylist(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type PlaylistRepository struct {
+	db  PlaylistDatabase
+	log *slog.Logger
+}
+
+func NewPlaylistRepository(db PlaylistDatabase, log *slog.Logger) *PlaylistRepository {
+	return &PlaylistRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *PlaylistRepository) Create(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "PlaylistRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", playlist.UserID.String()))
+
+	log.Debug("creating smart playlist in database")
+	if err := r.db.CreatePlaylist(ctx, playlist); err != nil {
+		log.Error("failed to create smart playlist in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("smart playlist successfully created")
+	return nil
+}
+
+func (r *PlaylistRepository) Read(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error) {
+	const op = "PlaylistRepository.Read"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", id.String()),
+	)
+
+	log.Debug("fetching smart playlist from database")
+	playlist, err := r.db.ReadPlaylist(ctx, userID, id)
+	if err != nil {
+		log.Error("failed to fetch smart playlist from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("smart playlist successfully fetched")
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) ReadAll(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	const op = "PlaylistRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()))
+
+	log.Debug("fetching smart playlists from database")
+	playlists, err := r.db.ReadPlaylists(ctx, userID)
+	if err != nil {
+		log.Error("failed to fetch smart playlists from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("smart playlists successfully fetched", slog.Int("count", len(playlists)))
+	return playlists, nil
+}
+
+func (r *PlaylistRepository) Update(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "PlaylistRepository.Update"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", playlist.UserID.String()),
+		slog.String("playlist_id", playlist.ID.String()),
+	)
+
+	log.Debug("updating smart playlist in database")
+	if err := r.db.UpdatePlaylist(ctx, playlist); err != nil {
+		log.Error("failed to update smart playlist in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("smart playlist successfully updated")
+	return nil
+}
+
+func (r *PlaylistRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "PlaylistRepository.Delete"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", id.String()),
+	)
+
+	log.Debug("deleting smart playlist from database")
+	if err := r.db.DeletePlaylist(ctx, userID, id); err != nil {
+		log.Error("failed to delete smart playlist from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("smart playlist successfully deleted")
+	return nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const albumColumns = `id, title, group_name, release_date, cover_link, created_at, updated_at, library_id`
+
+func (p *Postgres) CreateAlbum(ctx context.Context, album *domain.Album) error {
+	const op = "repository.AlbumDB.CreateAlbum"
+
+	album.ID = uuid.New()
+	album.CreatedAt = time.Now()
+	album.UpdatedAt = time.Now()
+	album.LibraryID = domain.LibraryIDFromContext(ctx)
+
+	query, params := newInsert("albums").
+		Value("id", album.ID).
+		Value("title", album.Title).
+		Value("group_name", album.Group).
+		Value("release_date", album.ReleaseDate).
+		Value("cover_link", album.CoverLink).
+		Value("created_at", album.CreatedAt).
+		Value("updated_at", album.UpdatedAt).
+		Value("library_id", album.LibraryID).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadAlbum(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	const op = "repository.AlbumDB.ReadAlbum"
+
+	query, params := newSelect(albumColumns).From("albums").
+		Where("id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	var album domain.Album
+	err := scanAlbum(p.conn(ctx).QueryRow(ctx, query, params...), &album)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &album, nil
+}
+
+func (p *Postgres) ReadAllAlbums(ctx context.Context, group string, limit, offset int) ([]*domain.Album, error) {
+	const op = "repository.AlbumDB.ReadAllAlbums"
+
+	selectAlbums := newSelect(albumColumns).From("albums").Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+	if group != "" {
+		selectAlbums.Where(`group_name ILIKE ? ESCAPE '\'`, likePattern(group))
+	}
+	query, params := selectAlbums.OrderBy("release_date DESC, title").Page(limit, offset).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var albums []*domain.Album
+	for rows.Next() {
+		var album domain.Album
+		if err := scanAlbum(rows, &album); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		albums = append(albums, &album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return albums, nil
+}
+
+func (p *Postgres) UpdateAlbum(ctx context.Context, album *domain.Album) error {
+	const op = "repository.AlbumDB.UpdateAlbum"
+
+	album.UpdatedAt = time.Now()
+
+	query, params := newUpdate("albums").
+		Set("title = ?", album.Title).
+		Set("group_name = ?", album.Group).
+		Set("release_date = ?", album.ReleaseDate).
+		Set("cover_link = ?", album.CoverLink).
+		Set("updated_at = ?", album.UpdatedAt).
+		Where("id = ?", album.ID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	return nil
+}
+
+// DeleteAlbum removes an album. Its songs are kept and lose the album reference.
+func (p *Postgres) DeleteAlbum(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.AlbumDB.DeleteAlbum"
+
+	query, params := newDelete("albums").
+		Where("id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadAlbumSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "repository.AlbumDB.ReadAlbumSongs"
+
+	query, params := newSelect(songColumns).From("songs").
+		Where("album_id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("release_date, name").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+// albumExists fails with ErrAlbumNotFound unless the album belongs to the
+// library ctx is scoped to. The foreign key of songs only checks that the
+// album exists in any library.
+func (p *Postgres) albumExists(ctx context.Context, op string, albumID uuid.UUID) error {
+	query, params := newSelect("1").From("albums").
+		Where("id = ?", albumID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Exists()
+
+	var exists bool
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+	}
+	return nil
+}
+
+func scanAlbum(row pgx.Row, album *domain.Album) error {
+	return row.Scan(
+		&album.ID, &album.Title, &album.Group, &album.ReleaseDate,
+		&album.CoverLink, &album.CreatedAt, &album.UpdatedAt, &album.LibraryID,
+	)
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, role, library_id, expires_at, created_at, revoked_at`
+
+func (p *Postgres) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	const op = "repository.APIKeyDB.CreateAPIKey"
+
+	key.ID = uuid.New()
+	key.CreatedAt = time.Now()
+
+	query, params := newInsert("api_keys").
+		Value("id", key.ID).
+		Value("name", key.Name).
+		Value("prefix", key.Prefix).
+		Value("key_hash", key.Hash).
+		Value("role", key.Role).
+		Value("library_id", key.LibraryID).
+		Value("expires_at", key.ExpiresAt).
+		Value("created_at", key.CreatedAt).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	const op = "repository.APIKeyDB.ReadAPIKey"
+
+	key, err := p.readAPIKey(ctx, newSelect(apiKeyColumns).From("api_keys").Where("id = ?", id))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (p *Postgres) ReadAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	const op = "repository.APIKeyDB.ReadAPIKeyByHash"
+
+	key, err := p.readAPIKey(ctx, newSelect(apiKeyColumns).From("api_keys").Where("key_hash = ?", hash))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (p *Postgres) readAPIKey(ctx context.Context, selectKey *selectBuilder) (*domain.APIKey, error) {
+	query, params := selectKey.SQL()
+
+	var key domain.APIKey
+	if err := scanAPIKey(p.conn(ctx).QueryRow(ctx, query, params...), &key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ReadAllAPIKeys returns the keys including revoked ones, newest first
+func (p *Postgres) ReadAllAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	const op = "repository.APIKeyDB.ReadAllAPIKeys"
+
+	query, params := newSelect(apiKeyColumns).From("api_keys").OrderBy("created_at DESC, id").SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		var key domain.APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks the key revoked at revokedAt, a key revoked before
+// keeps its time
+func (p *Postgres) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error) {
+	const op = "repository.APIKeyDB.RevokeAPIKey"
+
+	query, params := newUpdate("api_keys").
+		Set("revoked_at = COALESCE(revoked_at, ?)", revokedAt).
+		Where("id = ?", id).
+		Returning(apiKeyColumns).
+		SQL()
+
+	var key domain.APIKey
+	if err := scanAPIKey(p.conn(ctx).QueryRow(ctx, query, params...), &key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &key, nil
+}
+
+func scanAPIKey(row pgx.Row, key *domain.APIKey) error {
+	return row.Scan(
+		&key.ID, &key.Name, &key.Prefix, &key.Hash, &key.Role, &key.LibraryID,
+		&key.ExpiresAt, &key.CreatedAt, &key.RevokedAt,
+	)
+}
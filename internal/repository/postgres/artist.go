@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const artistColumns = `id, name, created_at, updated_at, library_id`
+
+func (p *Postgres) CreateArtist(ctx context.Context, artist *domain.Artist) error {
+	const op = "repository.ArtistDB.CreateArtist"
+
+	artist.ID = uuid.New()
+	artist.CreatedAt = time.Now()
+	artist.UpdatedAt = time.Now()
+	artist.LibraryID = domain.LibraryIDFromContext(ctx)
+
+	query, params := newInsert("artists").
+		Value("id", artist.ID).
+		Value("name", artist.Name).
+		Value("created_at", artist.CreatedAt).
+		Value("updated_at", artist.UpdatedAt).
+		Value("library_id", artist.LibraryID).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // Код ошибки для дубликатов
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadArtist(ctx context.Context, id uuid.UUID) (*domain.Artist, error) {
+	const op = "repository.ArtistDB.ReadArtist"
+
+	query, params := newSelect(artistColumns).From("artists").
+		Where("id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	var artist domain.Artist
+	err := scanArtist(p.conn(ctx).QueryRow(ctx, query, params...), &artist)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &artist, nil
+}
+
+func (p *Postgres) ReadAllArtists(ctx context.Context, name string, limit, offset int) ([]*domain.Artist, error) {
+	const op = "repository.ArtistDB.ReadAllArtists"
+
+	selectArtists := newSelect(artistColumns).From("artists").Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+	if name != "" {
+		selectArtists.Where(`name ILIKE ? ESCAPE '\'`, likePattern(name))
+	}
+	query, params := selectArtists.OrderBy("name").Page(limit, offset).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var artists []*domain.Artist
+	for rows.Next() {
+		var artist domain.Artist
+		if err := scanArtist(rows, &artist); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		artists = append(artists, &artist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return artists, nil
+}
+
+// UpdateArtist renames an artist. The group name copied into its songs is
+// updated in the same statement so filtering by group keeps working, the
+// songs get a new version since their representation changes.
+func (p *Postgres) UpdateArtist(ctx context.Context, artist *domain.Artist) error {
+	const op = "repository.ArtistDB.UpdateArtist"
+
+	artist.UpdatedAt = time.Now()
+
+	query, params := newSelect("created_at").From("artist").
+		With(`WITH artist AS (
+				  UPDATE artists SET name = ?, updated_at = ?
+				  WHERE id = ? AND library_id = ?
+				  RETURNING id, name, created_at
+			  ), renamed AS (
+				  UPDATE songs SET group_name = artist.name, updated_at = ?, version = songs.version + 1
+				  FROM artist
+				  WHERE songs.artist_id = artist.id
+			  )`, artist.Name, artist.UpdatedAt, artist.ID, domain.LibraryIDFromContext(ctx), artist.UpdatedAt).
+		SQL()
+
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&artist.CreatedAt)
+	if err != nil {
+		// Renaming can make a song collide with a song of another artist
+		if isSongDuplicate(err) {
+			return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteArtist removes an artist. Artists that still have songs can't be deleted.
+func (p *Postgres) DeleteArtist(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.ArtistDB.DeleteArtist"
+
+	query, params := newDelete("artists").
+		Where("id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // Код ошибки для нарушения внешнего ключа
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistHasSongs)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadArtistSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "repository.ArtistDB.ReadArtistSongs"
+
+	query, params := newSelect(songColumns).From("songs").
+		Where("artist_id = ?", id).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("release_date, name").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+func scanArtist(row pgx.Row, artist *domain.Artist) error {
+	return row.Scan(&artist.ID, &artist.Name, &artist.CreatedAt, &artist.UpdatedAt, &artist.LibraryID)
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SaveAudio stores the description of the audio file of a song, replacing
+// the previous one
+func (p *Postgres) SaveAudio(ctx context.Context, audio *domain.Audio) error {
+	const op = "repository.AudioDB.SaveAudio"
+
+	query, params := newInsert("song_audio").
+		Value("song_id", audio.SongID).
+		Value("format", audio.Format).
+		Value("content_type", audio.ContentType).
+		Value("size", audio.Size).
+		Value("duration_ms", audio.Duration.Milliseconds()).
+		Value("bitrate", audio.Bitrate).
+		Value("sample_rate", audio.SampleRate).
+		ValueExpr("uploaded_at", "CURRENT_TIMESTAMP").
+		OnConflict(`(song_id) DO UPDATE SET
+				  format = EXCLUDED.format,
+				  content_type = EXCLUDED.content_type,
+				  size = EXCLUDED.size,
+				  duration_ms = EXCLUDED.duration_ms,
+				  bitrate = EXCLUDED.bitrate,
+				  sample_rate = EXCLUDED.sample_rate,
+				  uploaded_at = EXCLUDED.uploaded_at`).
+		Returning("uploaded_at").
+		SQL()
+
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&audio.UploadedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // Код ошибки для нарушения внешнего ключа
+			return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadAudio(ctx context.Context, songID uuid.UUID) (*domain.Audio, error) {
+	const op = "repository.AudioDB.ReadAudio"
+
+	// the audio of songs of other libraries is not found
+	query, params := newSelect("song_id, format, content_type, size, duration_ms, bitrate, sample_rate, uploaded_at").
+		From("song_audio").
+		Join("JOIN songs ON songs.id = song_audio.song_id").
+		Where("song_id = ?", songID).
+		Where("songs.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	var (
+		audio      domain.Audio
+		durationMs int64
+	)
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(
+		&audio.SongID, &audio.Format, &audio.ContentType, &audio.Size,
+		&durationMs, &audio.Bitrate, &audio.SampleRate, &audio.UploadedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrAudioNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	audio.Duration = time.Duration(durationMs) * time.Millisecond
+
+	return &audio, nil
+}
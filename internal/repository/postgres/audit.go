@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// CreateAuditEntry records a change of a song. It runs in the transaction of
+// the context, so the entry is only kept if the change is committed.
+func (p *Postgres) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	const op = "repository.AuditDB.CreateAuditEntry"
+
+	oldValue, err := marshalAuditSong(entry.Old)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	newValue, err := marshalAuditSong(entry.New)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry.LibraryID = domain.LibraryIDFromContext(ctx)
+
+	query, params := newInsert("audit_log").
+		Value("song_id", entry.SongID).
+		Value("action", entry.Action).
+		Value("user_id", entry.UserID).
+		Value("old_value", oldValue).
+		Value("new_value", newValue).
+		Value("library_id", entry.LibraryID).
+		Returning("id, created_at").
+		SQL()
+
+	err = p.conn(ctx).QueryRow(ctx, query, params...).
+		Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadAuditEntries returns the changes of a song of the library, oldest
+// first. The history of deleted songs is kept.
+func (p *Postgres) ReadAuditEntries(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error) {
+	const op = "repository.AuditDB.ReadAuditEntries"
+
+	query, params := newSelect("id, song_id, action, user_id, library_id, old_value, new_value, created_at").
+		From("audit_log").
+		Where("song_id = ?", songID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("id").
+		Page(limit, offset).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.AuditEntry
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var oldValue, newValue []byte
+		if err := rows.Scan(&entry.ID, &entry.SongID, &entry.Action, &entry.UserID, &entry.LibraryID, &oldValue, &newValue, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if entry.Old, err = unmarshalAuditSong(oldValue); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if entry.New, err = unmarshalAuditSong(newValue); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// marshalAuditSong encodes a song snapshot as JSON, a missing song is NULL
+func marshalAuditSong(song *domain.Song) (any, error) {
+	if song == nil {
+		return nil, nil
+	}
+
+	songJSON, err := json.Marshal(dto.SongToDTO(song))
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal song to JSON: %w", err)
+	}
+
+	return songJSON, nil
+}
+
+func unmarshalAuditSong(songJSON []byte) (*domain.Song, error) {
+	if songJSON == nil {
+		return nil, nil
+	}
+
+	var songDTO dto.SongDTO
+	if err := json.Unmarshal(songJSON, &songDTO); err != nil {
+		return nil, fmt.Errorf("could not unmarshal JSON into song: %w", err)
+	}
+
+	return dto.DTOToSong(&songDTO), nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"songLibrary/internal/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// backupTables are the tables of a backup in the order they can be restored
+// in without breaking foreign keys
+var backupTables = []string{
+	"libraries", "artists", "albums", "songs", "favorites", "ratings", "song_plays", "webhooks",
+	"audit_log", "song_revisions", "tags", "song_tags", "song_audio", "users",
+	"api_keys", "smart_playlists", "shares",
+}
+
+// serialTables are the tables with a serial id, their sequences continue
+// after the restored rows
+var serialTables = []string{"audit_log", "tags"}
+
+// embeddingsTable holds the song embeddings of semantic search, it exists
+// once semantic search was enabled
+const embeddingsTable = "song_embeddings"
+
+// restoreBatchSize is the number of rows inserted by a single statement
+const restoreBatchSize = 500
+
+// SchemaVersion returns the version of the last applied migration
+func (p *Postgres) SchemaVersion(ctx context.Context) (int, error) {
+	const op = "repository.SongDB.SchemaVersion"
+
+	query, params := newSelect("version").From("schema_migrations").SQL()
+
+	var version int
+	if err := p.db.QueryRow(ctx, query, params...).Scan(&version); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// DumpTables calls row for every row of the backup tables, table by table.
+// All rows are read in one read-only transaction so the dump is consistent
+// while the library keeps changing.
+func (p *Postgres) DumpTables(ctx context.Context, row func(row domain.BackupRow) error) error {
+	const op = "repository.SongDB.DumpTables"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, table := range backupTables {
+		if err := dumpTable(ctx, tx, table, row); err != nil {
+			return fmt.Errorf("%s: %s: %w", op, table, err)
+		}
+	}
+
+	return nil
+}
+
+func dumpTable(ctx context.Context, tx pgx.Tx, table string, row func(row domain.BackupRow) error) error {
+	query, params := newSelect("to_jsonb(t)").From(table + " t").SQL()
+
+	rows, err := tx.Query(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := row(domain.BackupRow{Table: table, Data: data}); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// RestoreTables replaces the content of the backup tables with the rows
+// returned by next until io.EOF, in one transaction. The rows of a table
+// must follow the rows of the tables it references. With dryRun the rows are
+// inserted and rolled back, so the database validates them without changes.
+func (p *Postgres) RestoreTables(ctx context.Context, next func() (*domain.BackupRow, error), dryRun bool) (map[string]int, error) {
+	const op = "repository.SongDB.RestoreTables"
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	truncated := backupTables
+	// Embeddings reference the songs but aren't backed up, they are emptied
+	// with them and the indexer embeds the restored songs again
+	query, params := newSelect("to_regclass(?) IS NOT NULL", embeddingsTable).SQL()
+
+	var embeddings bool
+	if err := tx.QueryRow(ctx, query, params...).Scan(&embeddings); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if embeddings {
+		truncated = append(slices.Clone(backupTables), embeddingsTable)
+	}
+
+	if _, err := tx.Exec(ctx, `TRUNCATE `+strings.Join(truncated, ", ")+` RESTART IDENTITY`); err != nil {
+		return nil, fmt.Errorf("%s: failed to truncate tables: %w", op, err)
+	}
+
+	restored := make(map[string]int, len(backupTables))
+	for _, table := range backupTables {
+		restored[table] = 0
+	}
+	var (
+		table string
+		batch []json.RawMessage
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		query, params := newInsert(table).
+			Select(newSelect("*").From("jsonb_populate_recordset(NULL::"+table+", ?)", data)).
+			SQL()
+		if _, err := tx.Exec(ctx, query, params...); err != nil {
+			return restoreError(table, err)
+		}
+		restored[table] += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !slices.Contains(backupTables, row.Table) {
+			return nil, fmt.Errorf("%s: %w: unknown table %q", op, domain.ErrBackupInvalid, row.Table)
+		}
+
+		if row.Table != table || len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			table = row.Table
+		}
+		batch = append(batch, row.Data)
+	}
+	if err := flush(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, table := range serialTables {
+		query, params := newSelect("setval(pg_get_serial_sequence(?, 'id'), coalesce(max(id), 0) + 1, false)", table).
+			From(table).
+			SQL()
+		if _, err := tx.Exec(ctx, query, params...); err != nil {
+			return nil, fmt.Errorf("%s: failed to reset %s sequence: %w", op, table, err)
+		}
+	}
+
+	if dryRun {
+		return restored, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return restored, nil
+}
+
+// restoreError reports rows the database rejects, such as values of the
+// wrong type, missing required columns or broken references, as an invalid
+// backup
+func restoreError(table string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %s: %s", domain.ErrBackupInvalid, table, pgErr.Message)
+	}
+	return fmt.Errorf("%s: %w", table, err)
+}
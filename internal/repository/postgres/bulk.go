@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// UpdateAllWithFilter sets the changes on every song matching the filter of
+// song listings in a single UPDATE and returns the songs before and after
+// it. Renaming the group moves the songs to the artist with the new name.
+func (p *Postgres) UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error) {
+	const op = "repository.SongDB.UpdateAllWithFilter"
+
+	if filter.IsEmpty() {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrBulkFilterEmpty)
+	}
+	query, params := bulkUpdateQuery(ctx, filter, changes).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		if isSongDuplicate(err) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var updates []*domain.SongUpdate
+	for rows.Next() {
+		update := &domain.SongUpdate{Old: &domain.Song{}, New: &domain.Song{}}
+		if err := rows.Scan(append(songFields(update.Old), songFields(update.New)...)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		updates = append(updates, update)
+	}
+	if err := rows.Err(); err != nil {
+		if isSongDuplicate(err) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updates, nil
+}
+
+// bulkUpdateQuery updates the songs of the library matching the filter and
+// returns each of them before and after the update
+func bulkUpdateQuery(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) *updateBuilder {
+	// previous holds the matched rows as they were, RETURNING only sees
+	// the updated ones
+	previous := filterSongs(ctx, newSelect(songColumns).From("songs"), filter).Suffix("FOR UPDATE")
+	query := newUpdate("songs").
+		Set("updated_at = now()").
+		Set("version = songs.version + 1")
+
+	if changes.Group != nil {
+		query.With(upsertArtist, *changes.Group, domain.LibraryIDFromContext(ctx)).
+			Set("group_name = ?", *changes.Group).
+			Set("artist_id = (SELECT id FROM artist)")
+	}
+	if changes.Genre != nil {
+		query.Set("genre = ?", *changes.Genre)
+	}
+	if changes.Album != nil {
+		query.Set("album = ?", *changes.Album)
+	}
+	if changes.Explicit != nil {
+		query.Set("explicit = ?", *changes.Explicit)
+	}
+	if fields := changes.Fields(); fields != 0 {
+		query.Set("locked_fields = songs.locked_fields | ?", fields)
+	}
+
+	return query.
+		FromSelect(previous, "previous").
+		Where("songs.id = previous.id").
+		Returning(prefixColumns("previous") + ", " + prefixColumns("songs"))
+}
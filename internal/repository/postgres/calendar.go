@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// calendarSong is a song as aggregated by ReadReleaseCalendar
+type calendarSong struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Group string    `json:"group"`
+	Year  int       `json:"year"`
+}
+
+// ReadReleaseCalendar returns the days of the year songs of the library were
+// released on with the songs, in calendar order. A zero year takes the songs
+// of every year, songs without a release date and archived songs are left
+// out. excludeExplicit drops the songs flagged explicit.
+func (p *Postgres) ReadReleaseCalendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error) {
+	const op = "repository.SongDB.ReadReleaseCalendar"
+
+	selectDays := newSelect(`EXTRACT(MONTH FROM release_date)::int AS month,
+			  EXTRACT(DAY FROM release_date)::int AS day,
+			  json_agg(json_build_object(
+				  'id', id, 'name', name, 'group', group_name, 'year', EXTRACT(YEAR FROM release_date)::int
+			  ) ORDER BY release_date, lower(name))`).
+		From("songs").
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Where("release_date > '0001-01-01'")
+	if year != 0 {
+		selectDays.Where("EXTRACT(YEAR FROM release_date) = ?", year)
+	}
+	if excludeExplicit {
+		selectDays.Where("explicit IS NOT TRUE")
+	}
+	query, params := selectDays.Where("status <> 'archived'").
+		GroupBy("month, day").
+		OrderBy("month, day").
+		SQL()
+
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var days []*domain.CalendarDay
+	for rows.Next() {
+		var (
+			day   domain.CalendarDay
+			month int
+			songs []byte
+		)
+		if err := rows.Scan(&month, &day.Day, &songs); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		day.Month = time.Month(month)
+
+		var aggregated []calendarSong
+		if err := json.Unmarshal(songs, &aggregated); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, song := range aggregated {
+			day.Songs = append(day.Songs, &domain.Song{
+				ID:          song.ID,
+				Name:        song.Name,
+				Group:       song.Group,
+				ReleaseDate: time.Date(song.Year, day.Month, day.Day, 0, 0, 0, 0, time.UTC),
+			})
+		}
+		days = append(days, &day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return days, nil
+}
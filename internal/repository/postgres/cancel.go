@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgconn/ctxwatch"
+)
+
+// cancelDeadline is how long a statement may keep running after the server
+// was asked to cancel it before its connection is closed
+const cancelDeadline = 5 * time.Second
+
+// CancelQueriesOnServer makes the connections of config ask the server to
+// cancel the running statement when its context is done, because the client
+// went away or the deadline of the request passed. By default pgx only
+// closes the connection and the server keeps running the statement until it
+// tries to send the result. A connection whose statement doesn't stop
+// within cancelDeadline is closed.
+func CancelQueriesOnServer(config *pgconn.Config) {
+	config.BuildContextWatcherHandler = func(conn *pgconn.PgConn) ctxwatch.Handler {
+		return &pgconn.CancelRequestContextWatcherHandler{
+			Conn:          conn,
+			DeadlineDelay: cancelDeadline,
+		}
+	}
+}
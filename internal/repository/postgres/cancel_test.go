@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// activeStatements returns how many statements starting with prefix the
+// server is running
+func activeStatements(t *testing.T, conn *pgxpool.Pool, prefix string) int {
+	t.Helper()
+
+	var count int
+	err := conn.QueryRow(context.Background(),
+		`SELECT count(*) FROM pg_stat_activity WHERE state = 'active' AND query LIKE $1 || '%'`, prefix,
+	).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+// cancelled reports whether err is the error of a statement stopped by its
+// context, either by pgx itself or by the server on request
+func cancelled(err error) bool {
+	var pgErr *pgconn.PgError
+	return pgconn.Timeout(err) || errors.Is(err, context.Canceled) ||
+		errors.As(err, &pgErr) && pgErr.Code == "57014"
+}
+
+func TestCancelQueriesOnServer_Deadline(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := conn.Exec(ctx, `SELECT pg_sleep(30)`)
+	require.Error(t, err)
+	assert.True(t, cancelled(err), err)
+	assert.Less(t, time.Since(start), cancelDeadline)
+
+	// Сервер прекращает выполнение, а не досыпает 30 секунд
+	assert.Eventually(t, func() bool {
+		return activeStatements(t, conn, "SELECT pg_sleep(30)") == 0
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestCancelQueriesOnServer_ClientGone(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	seedSongs(t, context.Background(), conn, song)
+
+	// Другая транзакция держит блокировку строки, удаление её ждёт
+	lock, err := conn.Begin(context.Background())
+	require.NoError(t, err)
+	defer lock.Rollback(context.Background())
+	_, err = lock.Exec(context.Background(), `SELECT id FROM songs WHERE id = $1 FOR UPDATE`, song.ID)
+	require.NoError(t, err)
+
+	// Клиент отключается, пока запрос ждёт блокировку
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- songDB.Delete(ctx, &domain.SongInfo{ID: song.ID})
+	}()
+	require.Eventually(t, func() bool {
+		return activeStatements(t, conn, "DELETE FROM songs") == 1
+	}, 2*time.Second, 20*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.True(t, cancelled(err), err)
+	case <-time.After(cancelDeadline):
+		t.Fatal("Delete didn't return after its context was cancelled")
+	}
+	assert.Equal(t, 0, activeStatements(t, conn, "DELETE FROM songs"))
+
+	// После снятия блокировки отменённое удаление не выполняется
+	require.NoError(t, lock.Rollback(context.Background()))
+	_, err = songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+}
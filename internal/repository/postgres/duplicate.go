@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// ReadDuplicates returns up to limit pairs of songs of the library with a trigram similarity
+// of name and group of at least threshold, most similar first. Case and
+// accents are ignored, so "Muse" and "Müse" are the same. The % operator
+// lets the trigram index prune the self-join, so thresholds below
+// pg_trgm.similarity_threshold (0.3 by default) behave like it.
+func (p *Postgres) ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error) {
+	const op = "repository.SongDB.ReadDuplicates"
+
+	similarity := `similarity(` + normalizedNameGroup("a") + `, ` + normalizedNameGroup("b") + `)`
+	query, params := newSelect(prefixColumns("a")+`, `+prefixColumns("b")+`, `+similarity+` AS score`).
+		From("songs a").
+		Join(`JOIN songs b ON a.id < b.id AND b.library_id = a.library_id
+			  AND `+normalizedNameGroup("a")+` % `+normalizedNameGroup("b")).
+		Where("a.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Where(similarity+" >= ?", threshold).
+		OrderBy("score DESC, a.id, b.id").
+		Limit(limit).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var duplicates []*domain.DuplicateSongs
+	for rows.Next() {
+		duplicate := domain.DuplicateSongs{Song: &domain.Song{}, Duplicate: &domain.Song{}}
+
+		fields := append(songFields(duplicate.Song), songFields(duplicate.Duplicate)...)
+		fields = append(fields, &duplicate.Similarity)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		duplicates = append(duplicates, &duplicate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return duplicates, nil
+}
+
+// prefixColumns qualifies songColumns with a table alias
+func prefixColumns(alias string) string {
+	columns := strings.Split(songColumns, ",")
+	for i, column := range columns {
+		columns[i] = alias + "." + strings.TrimSpace(column)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// normalizedNameGroup is the expression of the trigram index duplicates are
+// searched with, for the songs of a table alias
+func normalizedNameGroup(alias string) string {
+	return "(normalize_name(" + alias + ".name) || ' ' || normalize_name(" + alias + ".group_name))"
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strconv"
+	"strings"
+)
+
+// EnsureEmbeddings creates the pgvector extension and the table of song
+// embeddings of the given dimensions. They are created on start of semantic
+// search instead of by a migration, so databases without pgvector keep
+// working while it is disabled. Embeddings are derived data: they are left
+// out of backups and a table of other dimensions must be dropped to switch
+// to another model.
+func (p *Postgres) EnsureEmbeddings(ctx context.Context, dimensions int) error {
+	const op = "repository.SongDB.EnsureEmbeddings"
+
+	query := fmt.Sprintf(`CREATE EXTENSION IF NOT EXISTS vector;
+			CREATE TABLE IF NOT EXISTS song_embeddings (
+				song_id UUID PRIMARY KEY REFERENCES songs (id) ON DELETE CASCADE,
+				song_version INTEGER NOT NULL,
+				embedding vector(%d) NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS idx_song_embeddings_embedding
+				ON song_embeddings USING hnsw (embedding vector_cosine_ops);`, dimensions)
+
+	if _, err := p.conn(ctx).Exec(ctx, query); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SaveEmbedding stores the embedding of the version of song
+func (p *Postgres) SaveEmbedding(ctx context.Context, song *domain.Song, embedding []float32) error {
+	const op = "repository.SongDB.SaveEmbedding"
+
+	query, params := newInsert("song_embeddings").
+		Select(
+			newSelect("id, ?, ?::vector", song.Version, vectorLiteral(embedding)).From("songs").Where("id = ?", song.ID),
+			"song_id", "song_version", "embedding",
+		).
+		OnConflict("(song_id) DO UPDATE SET song_version = EXCLUDED.song_version, embedding = EXCLUDED.embedding").
+		SQL()
+
+	if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadUnembedded returns up to limit songs of all libraries without an
+// embedding of their current version, newest first
+func (p *Postgres) ReadUnembedded(ctx context.Context, limit int) ([]*domain.Song, error) {
+	const op = "repository.SongDB.ReadUnembedded"
+
+	query, params := newSelect(songColumns).From("songs").
+		Where(`NOT EXISTS (
+				  SELECT 1 FROM song_embeddings
+				  WHERE song_embeddings.song_id = songs.id AND song_embeddings.song_version = songs.version
+			  )`).
+		OrderBy("created_at DESC, id").
+		Limit(limit).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+// SearchEmbeddings returns the songs of the library whose embeddings are
+// nearest to embedding by cosine distance, ranked by cosine similarity.
+// excludeExplicit drops the songs flagged explicit, archived songs are
+// left out.
+func (p *Postgres) SearchEmbeddings(ctx context.Context, embedding []float32, excludeExplicit bool, limit, offset int) ([]*domain.SearchResult, error) {
+	const op = "repository.SongDB.SearchEmbeddings"
+
+	vector := vectorLiteral(embedding)
+	selectNearest := newSelect(songColumns+", 1 - (embedding <=> ?::vector)", vector).
+		From("songs").
+		Join("JOIN song_embeddings ON song_embeddings.song_id = songs.id").
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+	if excludeExplicit {
+		selectNearest.Where("explicit IS NOT TRUE")
+	}
+	query, params := selectNearest.Where("status <> 'archived'").
+		OrderBy("embedding <=> ?::vector, created_at DESC, id", vector).
+		Page(limit, offset).
+		SQL()
+
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var results []*domain.SearchResult
+	for rows.Next() {
+		result := domain.SearchResult{Song: &domain.Song{}}
+		if err := rows.Scan(append(songFields(result.Song), &result.Rank)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// vectorLiteral formats an embedding as pgvector input, like "[0.1,-2]"
+func vectorLiteral(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
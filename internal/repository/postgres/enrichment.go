@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+)
+
+// ReadStaleSongs returns up to limit songs of any library without text or
+// not updated since staleBefore, newest first, that come after the cursor
+func (p *Postgres) ReadStaleSongs(ctx context.Context, staleBefore time.Time, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	const op = "repository.EnrichmentDB.ReadStaleSongs"
+
+	selectSongs := newSelect(songColumns).
+		From("songs").
+		Where("(text IS NULL OR text = '' OR updated_at < ?)", staleBefore)
+	if after != nil {
+		selectSongs.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+	query, params := selectSongs.OrderBy("created_at DESC, id DESC").Limit(limit).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AddFavorite marks a song of the library as a favorite of the user. Adding
+// a song twice is a no-op, the favorites counter of the song only grows for
+// new favorites.
+func (p *Postgres) AddFavorite(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "repository.FavoriteDB.AddFavorite"
+
+	query, params := newUpdate("songs").
+		With(`WITH inserted AS (
+				  INSERT INTO favorites (user_id, song_id)
+				  SELECT ?, id FROM songs WHERE id = ? AND library_id = ?
+				  ON CONFLICT DO NOTHING
+				  RETURNING song_id
+			  )`, userID, songID, domain.LibraryIDFromContext(ctx)).
+		Set("favorites_count = favorites_count + 1").
+		From("inserted").
+		Where("songs.id = inserted.song_id").
+		SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // Код ошибки для нарушения внешнего ключа
+			return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return p.songExists(ctx, op, songID)
+	}
+
+	return nil
+}
+
+// RemoveFavorite unmarks a favorite song of the user. Removing a song that
+// isn't a favorite is a no-op.
+func (p *Postgres) RemoveFavorite(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "repository.FavoriteDB.RemoveFavorite"
+
+	query, params := newUpdate("songs").
+		With(`WITH deleted AS (
+				  DELETE FROM favorites WHERE user_id = ? AND song_id = ?
+				  RETURNING song_id
+			  )`, userID, songID).
+		Set("favorites_count = favorites_count - 1").
+		From("deleted").
+		Where("songs.id = deleted.song_id").
+		SQL()
+
+	// the song is looked up in the library first, songs of other libraries
+	// are not found
+	if err := p.songExists(ctx, op, songID); err != nil {
+		return err
+	}
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// songExists returns ErrSongNotFound, wrapped with op, unless the song
+// belongs to the library ctx is scoped to
+func (p *Postgres) songExists(ctx context.Context, op string, songID uuid.UUID) error {
+	query, params := newSelect("1").From("songs").
+		Where("id = ?", songID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Exists()
+
+	var exists bool
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+	}
+	return nil
+}
+
+// ReadFavorites returns the favorite songs of the user, most recently added first.
+func (p *Postgres) ReadFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Song, error) {
+	const op = "repository.FavoriteDB.ReadFavorites"
+
+	query, params := newSelect(songColumns).
+		From("songs").
+		Join("JOIN favorites ON favorites.song_id = songs.id").
+		Where("favorites.user_id = ?", userID).
+		Where("songs.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("favorites.favorited_at DESC").
+		Page(limit, offset).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
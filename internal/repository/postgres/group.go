@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// ReadGroups aggregates the songs of every artist that has any in a single
+// query, ordered by name
+func (p *Postgres) ReadGroups(ctx context.Context, name string, limit, offset int) ([]*domain.Group, error) {
+	const op = "repository.SongDB.ReadGroups"
+
+	selectGroups := newSelect("artists.id, artists.name, count(*), min(songs.release_date), max(songs.release_date), max(songs.updated_at)").
+		From("artists").
+		Join("JOIN songs ON songs.artist_id = artists.id").
+		Where("artists.library_id = ?", domain.LibraryIDFromContext(ctx))
+	if name != "" {
+		selectGroups.Where(`artists.name ILIKE ? ESCAPE '\'`, likePattern(name))
+	}
+	query, params := selectGroups.GroupBy("artists.id").OrderBy("artists.name").Page(limit, offset).SQL()
+
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var groups []*domain.Group
+	for rows.Next() {
+		var group domain.Group
+		err := rows.Scan(
+			&group.ArtistID, &group.Name, &group.Songs,
+			&group.FirstRelease, &group.LatestRelease, &group.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		groups = append(groups, &group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return groups, nil
+}
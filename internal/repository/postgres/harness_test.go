@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"songLibrary/internal/app/migrations"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/migrator"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// templateDatabase holds the schema of the migrations, every test gets a
+// copy of it
+const templateDatabase = "songlibrary_template"
+
+// testPostgres is the container shared by the tests of the package. It is
+// started by the first test that needs a database, so the tests that don't
+// run without Docker, and stopped by TestMain.
+var testPostgres struct {
+	once      sync.Once
+	container testcontainers.Container
+	address   string
+	// schemaVersion is the version of the last migration
+	schemaVersion uint
+	err           error
+
+	databases atomic.Int64
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testPostgres.container != nil {
+		_ = testPostgres.container.Terminate(context.Background())
+	}
+	os.Exit(code)
+}
+
+// databaseURL returns the address of a database of the shared container
+func databaseURL(name string) string {
+	return "postgres://user:password@" + testPostgres.address + "/" + name + "?sslmode=disable"
+}
+
+// startPostgres starts the shared container and loads the schema from the
+// migrations the application applies into the template database
+func startPostgres(ctx context.Context) error {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:13",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_PASSWORD": "password",
+				"POSTGRES_USER":     "user",
+				"POSTGRES_DB":       "testdb",
+			},
+			// The server restarts once after initializing the database
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return fmt.Errorf("create container: %w", err)
+	}
+	testPostgres.container = container
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return fmt.Errorf("container host: %w", err)
+	}
+	port, err := container.MappedPort(ctx, "5432")
+	if err != nil {
+		return fmt.Errorf("container port: %w", err)
+	}
+	testPostgres.address = host + ":" + port.Port()
+
+	if err := execIn(ctx, "testdb", `CREATE DATABASE `+templateDatabase); err != nil {
+		return err
+	}
+	// The first migration generates IDs with uuid-ossp, the application
+	// creates it along with the database
+	if err := execIn(ctx, templateDatabase, `CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`); err != nil {
+		return err
+	}
+
+	migr := migrator.MustGetNewMigrator(migrations.FS, migrations.Dir)
+	db, err := sql.Open("postgres", databaseURL(templateDatabase))
+	if err != nil {
+		return fmt.Errorf("open template database: %w", err)
+	}
+	if err := migr.ApplyMigrations(db); err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	db, err = sql.Open("postgres", databaseURL(templateDatabase))
+	if err != nil {
+		return fmt.Errorf("open template database: %w", err)
+	}
+	status, err := migr.Status(db)
+	if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	testPostgres.schemaVersion = status.Version
+
+	return nil
+}
+
+// execIn runs a statement in a database of the shared container
+func execIn(ctx context.Context, database, statement string) error {
+	pool, err := pgxpool.New(ctx, databaseURL(database))
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", database, err)
+	}
+	defer pool.Close()
+
+	if _, err := pool.Exec(ctx, statement); err != nil {
+		return fmt.Errorf("%s: %w", statement, err)
+	}
+	return nil
+}
+
+// setupPostgresForSongs returns a pool connected to a new database with the
+// schema of the migrations, teardown drops it
+func setupPostgresForSongs(t *testing.T) (*pgxpool.Pool, func()) {
+	t.Helper()
+	ctx := context.Background()
+
+	testPostgres.once.Do(func() {
+		testPostgres.err = startPostgres(ctx)
+	})
+	require.NoError(t, testPostgres.err)
+
+	// Copying the template is much faster than migrating every database
+	name := fmt.Sprintf("test_%d", testPostgres.databases.Add(1))
+	require.NoError(t, execIn(ctx, "testdb", `CREATE DATABASE `+name+` TEMPLATE `+templateDatabase))
+
+	// The pools are configured like the one of the application
+	config, err := pgxpool.ParseConfig(databaseURL(name))
+	require.NoError(t, err)
+	CancelQueriesOnServer(&config.ConnConfig.Config)
+	conn, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+
+	teardown := func() {
+		conn.Close()
+		_ = execIn(ctx, "testdb", `DROP DATABASE IF EXISTS `+name)
+	}
+
+	return conn, teardown
+}
+
+// seedSongs saves the songs in the library of ctx, filling their IDs and
+// timestamps
+func seedSongs(t *testing.T, ctx context.Context, conn *pgxpool.Pool, songs ...*domain.Song) {
+	t.Helper()
+
+	songDB := NewPostgres(conn)
+	for _, song := range songs {
+		require.NoError(t, songDB.Create(ctx, song), song.Name)
+	}
+}
+
+// seedTags attaches the tags to the song
+func seedTags(t *testing.T, ctx context.Context, conn *pgxpool.Pool, song *domain.Song, tags ...string) {
+	t.Helper()
+
+	require.NoError(t, NewPostgres(conn).AddTags(ctx, song.ID, tags))
+}
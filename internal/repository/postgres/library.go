@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const libraryColumns = `id, name, created_at`
+
+func (p *Postgres) CreateLibrary(ctx context.Context, library *domain.Library) error {
+	const op = "repository.LibraryDB.CreateLibrary"
+
+	library.ID = uuid.New()
+	library.CreatedAt = time.Now()
+
+	query, params := newInsert("libraries").
+		Value("id", library.ID).
+		Value("name", library.Name).
+		Value("created_at", library.CreatedAt).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // Код ошибки для дубликатов
+			return fmt.Errorf("%s: %w", op, domain.ErrLibraryExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadLibrary(ctx context.Context, id uuid.UUID) (*domain.Library, error) {
+	const op = "repository.LibraryDB.ReadLibrary"
+
+	query, params := newSelect(libraryColumns).From("libraries").Where("id = ?", id).SQL()
+
+	var library domain.Library
+	err := scanLibrary(p.conn(ctx).QueryRow(ctx, query, params...), &library)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &library, nil
+}
+
+// ReadAllLibraries returns the libraries ordered by name
+func (p *Postgres) ReadAllLibraries(ctx context.Context) ([]*domain.Library, error) {
+	const op = "repository.LibraryDB.ReadAllLibraries"
+
+	query, params := newSelect(libraryColumns).From("libraries").OrderBy("name").SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var libraries []*domain.Library
+	for rows.Next() {
+		var library domain.Library
+		if err := scanLibrary(rows, &library); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		libraries = append(libraries, &library)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return libraries, nil
+}
+
+func scanLibrary(row pgx.Row, library *domain.Library) error {
+	return row.Scan(&library.ID, &library.Name, &library.CreatedAt)
+}
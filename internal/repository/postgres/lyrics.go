@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// lyricsSectionJSON is a lyrics section as stored in the lyrics column
+type lyricsSectionJSON struct {
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+}
+
+// lyricsJSON encodes lyrics for the lyrics column, nil lyrics are stored as NULL
+func lyricsJSON(lyrics *domain.Lyrics) ([]byte, error) {
+	if lyrics == nil {
+		return nil, nil
+	}
+
+	sections := make([]lyricsSectionJSON, 0, len(lyrics.Sections))
+	for _, section := range lyrics.Sections {
+		sections = append(sections, lyricsSectionJSON{
+			Type:  string(section.Type),
+			Index: section.Index,
+			Text:  section.Text,
+		})
+	}
+
+	return json.Marshal(sections)
+}
+
+// lyricsColumn scans the lyrics column into a song
+type lyricsColumn struct {
+	song *domain.Song
+}
+
+func (c lyricsColumn) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		c.song.Lyrics = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("unsupported lyrics type %T", src)
+	}
+
+	var sections []lyricsSectionJSON
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return fmt.Errorf("decode lyrics: %w", err)
+	}
+
+	lyrics := &domain.Lyrics{}
+	for _, section := range sections {
+		lyrics.Sections = append(lyrics.Sections, domain.LyricsSection{
+			Type:  domain.SectionType(section.Type),
+			Index: section.Index,
+			Text:  section.Text,
+		})
+	}
+	c.song.Lyrics = lyrics
+
+	return nil
+}
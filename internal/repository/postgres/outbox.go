@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// CreateOutboxEvent stores an event in the outbox. It runs in the transaction
+// of the context, so the event is only kept if the change is committed.
+func (p *Postgres) CreateOutboxEvent(ctx context.Context, event *domain.SongEvent) error {
+	const op = "repository.OutboxDB.CreateOutboxEvent"
+
+	payload, err := marshalAuditSong(event.Song)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, params := newInsert("outbox").
+		Value("event_type", event.Type).
+		Value("song_id", event.Song.ID).
+		Value("payload", payload).
+		Value("occurred_at", event.OccurredAt).
+		SQL()
+
+	if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadOutboxEvents returns up to limit oldest events. Inside a transaction the
+// events are locked until it ends and events locked by other transactions
+// are skipped, so concurrent relays don't read the same events.
+func (p *Postgres) ReadOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	const op = "repository.OutboxDB.ReadOutboxEvents"
+
+	query, params := newSelect("id, event_type, payload, occurred_at").From("outbox").
+		OrderBy("id").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		var event domain.OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&event.ID, &event.Event.Type, &payload, &event.Event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if event.Event.Song, err = unmarshalAuditSong(payload); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// DeleteOutboxEvents removes published events from the outbox
+func (p *Postgres) DeleteOutboxEvents(ctx context.Context, ids []int64) error {
+	const op = "repository.OutboxDB.DeleteOutboxEvents"
+
+	query, params := newDelete("outbox").Where("id = ANY(?)", ids).SQL()
+
+	if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddPlays adds play counts to the given time bucket. Plays of songs that
+// were deleted in the meantime are dropped.
+func (p *Postgres) AddPlays(ctx context.Context, plays map[uuid.UUID]int, bucket time.Time) error {
+	const op = "repository.PlayDB.AddPlays"
+
+	songIDs := make([]string, 0, len(plays))
+	counts := make([]int32, 0, len(plays))
+	for songID, count := range plays {
+		songIDs = append(songIDs, songID.String())
+		counts = append(counts, int32(count))
+	}
+
+	buffered := newSelect("songs.id, ?, buffered.plays", bucket).
+		From("unnest(?::uuid[], ?::int[]) AS buffered (song_id, plays)", songIDs, counts).
+		Join("JOIN songs ON songs.id = buffered.song_id")
+	query, params := newInsert("song_plays").
+		Select(buffered, "song_id", "bucket", "plays").
+		OnConflict("(song_id, bucket) DO UPDATE SET plays = song_plays.plays + EXCLUDED.plays").
+		SQL()
+
+	if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadTrending returns the most played songs of the library since the given
+// time.
+func (p *Postgres) ReadTrending(ctx context.Context, since time.Time, limit int) ([]*domain.TrendingSong, error) {
+	const op = "repository.PlayDB.ReadTrending"
+
+	query, params := newSelect(songColumns+", SUM(song_plays.plays) AS total_plays").
+		From("songs").
+		Join("JOIN song_plays ON song_plays.song_id = songs.id").
+		Where("song_plays.bucket >= ?", since).
+		Where("songs.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		GroupBy("songs.id").
+		OrderBy("total_plays DESC, created_at DESC").
+		Limit(limit).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var trending []*domain.TrendingSong
+	for rows.Next() {
+		var (
+			song  domain.Song
+			plays int
+		)
+		if err := rows.Scan(append(songFields(&song), &plays)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		trending = append(trending, &domain.TrendingSong{Song: &song, Plays: plays})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return trending, nil
+}
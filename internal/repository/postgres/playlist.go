@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const playlistColumns = `id, user_id, library_id, name, filter, created_at, updated_at`
+
+// playlistFilterJSON is the filter column of smart_playlists
+type playlistFilterJSON struct {
+	Group        string   `json:"group,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	TagMode      string   `json:"tag_mode,omitempty"`
+	ReleasedFrom string   `json:"released_from,omitempty"`
+	ReleasedTo   string   `json:"released_to,omitempty"`
+	Query        string   `json:"query,omitempty"`
+}
+
+// CreatePlaylist saves a smart playlist of the user in the library of the context
+func (p *Postgres) CreatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "repository.PlaylistDB.CreatePlaylist"
+
+	filter, err := playlistFilter(&playlist.Filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	playlist.ID = uuid.New()
+	playlist.LibraryID = domain.LibraryIDFromContext(ctx)
+	playlist.CreatedAt = time.Now()
+	playlist.UpdatedAt = playlist.CreatedAt
+
+	query, params := newInsert("smart_playlists").
+		Value("id", playlist.ID).
+		Value("user_id", playlist.UserID).
+		Value("library_id", playlist.LibraryID).
+		Value("name", playlist.Name).
+		Value("filter", filter).
+		Value("created_at", playlist.CreatedAt).
+		Value("updated_at", playlist.UpdatedAt).
+		SQL()
+
+	_, err = p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadPlaylist returns a smart playlist of the user in the library of the
+// context, the playlists of other users aren't found
+func (p *Postgres) ReadPlaylist(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error) {
+	const op = "repository.PlaylistDB.ReadPlaylist"
+
+	query, params := newSelect(playlistColumns).From("smart_playlists").
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	var playlist domain.SmartPlaylist
+	err := scanPlaylist(p.conn(ctx).QueryRow(ctx, query, params...), &playlist)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &playlist, nil
+}
+
+// ReadPlaylists returns the smart playlists of the user in the library of the
+// context, oldest first
+func (p *Postgres) ReadPlaylists(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	const op = "repository.PlaylistDB.ReadPlaylists"
+
+	query, params := newSelect(playlistColumns).From("smart_playlists").
+		Where("user_id = ?", userID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("created_at, id").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var playlists []*domain.SmartPlaylist
+	for rows.Next() {
+		var playlist domain.SmartPlaylist
+		if err := scanPlaylist(rows, &playlist); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		playlists = append(playlists, &playlist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return playlists, nil
+}
+
+// UpdatePlaylist changes the name and filter of a smart playlist of the user
+func (p *Postgres) UpdatePlaylist(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "repository.PlaylistDB.UpdatePlaylist"
+
+	filter, err := playlistFilter(&playlist.Filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	playlist.UpdatedAt = time.Now()
+
+	query, params := newUpdate("smart_playlists").
+		Set("name = ?", playlist.Name).
+		Set("filter = ?", filter).
+		Set("updated_at = ?", playlist.UpdatedAt).
+		Where("id = ?", playlist.ID).
+		Where("user_id = ?", playlist.UserID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Returning("library_id, created_at").
+		SQL()
+
+	err = p.conn(ctx).QueryRow(ctx, query, params...).Scan(&playlist.LibraryID, &playlist.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeletePlaylist deletes a smart playlist of the user
+func (p *Postgres) DeletePlaylist(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repository.PlaylistDB.DeletePlaylist"
+
+	query, params := newDelete("smart_playlists").
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrPlaylistNotFound)
+	}
+
+	return nil
+}
+
+func scanPlaylist(row pgx.Row, playlist *domain.SmartPlaylist) error {
+	var filter []byte
+	err := row.Scan(&playlist.ID, &playlist.UserID, &playlist.LibraryID, &playlist.Name, &filter,
+		&playlist.CreatedAt, &playlist.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	var stored playlistFilterJSON
+	if err := json.Unmarshal(filter, &stored); err != nil {
+		return fmt.Errorf("invalid playlist filter: %w", err)
+	}
+
+	playlist.Filter = domain.PlaylistFilter{
+		Group:   stored.Group,
+		Tags:    stored.Tags,
+		TagMode: domain.TagMode(stored.TagMode),
+		Query:   stored.Query,
+	}
+	if playlist.Filter.ReleasedFrom, err = parsePlaylistDate(stored.ReleasedFrom); err != nil {
+		return err
+	}
+	if playlist.Filter.ReleasedTo, err = parsePlaylistDate(stored.ReleasedTo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// playlistFilter encodes a filter for the filter column
+func playlistFilter(filter *domain.PlaylistFilter) ([]byte, error) {
+	return json.Marshal(playlistFilterJSON{
+		Group:        filter.Group,
+		Tags:         filter.Tags,
+		TagMode:      string(filter.TagMode),
+		ReleasedFrom: formatPlaylistDate(filter.ReleasedFrom),
+		ReleasedTo:   formatPlaylistDate(filter.ReleasedTo),
+		Query:        filter.Query,
+	})
+}
+
+func formatPlaylistDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+func parsePlaylistDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid playlist filter date: %w", err)
+	}
+	return parsed, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"songLibrary/internal/domain"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,36 +15,115 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// songColumns lists the columns scanned by scanSong, in order
+const songColumns = `id, name, group_name, text,
+			  link, release_date, created_at, updated_at, version, album_id, artist_id, favorites_count, source, lyrics, locked_fields,
+			  duration_ms, genre, track_number, album, explicit, library_id, status, rating_average, ratings_count`
+
+// songSummaryColumns are songColumns with the text and lyrics left empty,
+// for listings that don't need them
+const songSummaryColumns = `id, name, group_name, '' AS text,
+			  link, release_date, created_at, updated_at, version, album_id, artist_id, favorites_count, source, NULL AS lyrics, locked_fields,
+			  duration_ms, genre, track_number, album, explicit, library_id, status, rating_average, ratings_count`
+
+// upsertArtist resolves the artist named by its first parameter in the
+// library of the second, creating it when the group is new to the library.
+// The no-op update makes RETURNING yield the id for artists that already
+// exist.
+const upsertArtist = `WITH artist AS (
+				  INSERT INTO artists (name, library_id) VALUES (?, ?)
+				  ON CONFLICT (library_id, name) DO UPDATE SET name = EXCLUDED.name
+				  RETURNING id
+			  )`
+
+// songNameGroupIndex is the unique index on the case-insensitive name and group of a song
+const songNameGroupIndex = "idx_songs_name_group_unique"
+
+// songLibraryForeignKey references the library of a song
+const songLibraryForeignKey = "songs_library_id_fkey"
+
+// artistLibraryForeignKey references the library of an artist, the artist
+// of a song is created in the library before the song
+const artistLibraryForeignKey = "artists_library_id_fkey"
+
 type Postgres struct {
 	db *pgxpool.Pool
+	// replicas serve reads that tolerate replication lag, in turn
+	replicas    []*replica
+	nextReplica atomic.Uint32
 }
 
-func NewPostgres(conn *pgxpool.Pool) *Postgres {
-	return &Postgres{
+// NewPostgres uses conn for writes and reads that must see them. Song reads
+// and listings go to the replicas, if any, and fall back to conn while they
+// are down.
+func NewPostgres(conn *pgxpool.Pool, replicas ...*pgxpool.Pool) *Postgres {
+	p := &Postgres{
 		db: conn,
 	}
+	for _, pool := range replicas {
+		p.replicas = append(p.replicas, &replica{pool: pool})
+	}
+	return p
+}
+
+// Ping checks the connection to the primary
+func (p *Postgres) Ping(ctx context.Context) error {
+	return p.db.Ping(ctx)
 }
 
 func (p *Postgres) Create(ctx context.Context, song *domain.Song) error {
 	const op = "repository.SongDB.Create"
 
-	song.ID = uuid.New()
-	song.CreatedAt = time.Now()
-	song.UpdatedAt = time.Now()
+	// a song queued by the write-behind cache already has its ID and
+	// timestamps
+	if song.ID == uuid.Nil {
+		song.ID = uuid.New()
+		song.CreatedAt = time.Now()
+		song.UpdatedAt = song.CreatedAt
+	}
+	song.Version = 1
+	song.LibraryID = domain.LibraryIDFromContext(ctx)
+	if song.Status == "" {
+		song.Status = domain.SongStatusEnriched
+	}
+
+	if song.AlbumID != nil {
+		if err := p.albumExists(ctx, op, *song.AlbumID); err != nil {
+			return err
+		}
+	}
+
+	lyrics, err := lyricsJSON(song.Lyrics)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-	query := `INSERT INTO songs (id, name, group_name, text, link, release_date, created_at, updated_at)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	values := newSelect(
+		"?, ?, ?, ?, ?, ?, ?, ?, ?, ?, artist.id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?",
+		song.ID, song.Name, song.Group, song.Text,
+		song.Link, song.ReleaseDate, song.CreatedAt, song.UpdatedAt, song.Version, song.AlbumID, song.Source, lyrics, song.LockedFields,
+		song.Duration.Milliseconds(), song.Genre, song.TrackNumber, song.Album, song.Explicit, song.LibraryID, song.Status,
+	).From("artist")
+	query, params := newInsert("songs").
+		With(upsertArtist, song.Group, song.LibraryID).
+		Select(values, "id", "name", "group_name", "text", "link", "release_date", "created_at", "updated_at", "version", "album_id", "artist_id", "source", "lyrics", "locked_fields",
+			"duration_ms", "genre", "track_number", "album", "explicit", "library_id", "status").
+		Returning("artist_id").
+		SQL()
 
-	_, err := p.db.Exec(
-		ctx, query, song.ID, song.Name, song.Group, song.Text,
-		song.Link, song.ReleaseDate, song.CreatedAt, song.UpdatedAt,
-	)
+	err = p.conn(ctx).QueryRow(ctx, query, params...).Scan(&song.ArtistID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" { // Код ошибки для дубликатов
+			if pgErr.Code == "23505" { // Код ошибки для дубликатов, в том числе по названию и группе
 				return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
 			}
+			if pgErr.Code == "23503" && (pgErr.ConstraintName == songLibraryForeignKey || pgErr.ConstraintName == artistLibraryForeignKey) {
+				return fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+			}
+			if pgErr.Code == "23503" { // Код ошибки для нарушения внешнего ключа
+				return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+			}
 		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -54,16 +134,14 @@ func (p *Postgres) Create(ctx context.Context, song *domain.Song) error {
 func (p *Postgres) Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
 	const op = "repository.SongDB.Read"
 
-	query := `SELECT id, name, group_name, text,
-			  link, release_date, created_at, updated_at
-              FROM songs WHERE id = $1`
-	row := p.db.QueryRow(ctx, query, song.ID)
+	query, params := newSelect(songColumns).From("songs").
+		Where("id = ?", song.ID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+	row := p.readConn(ctx).QueryRow(ctx, query, params...)
 
 	var targetSong domain.Song
-	err := row.Scan(
-		&targetSong.ID, &targetSong.Name, &targetSong.Group, &targetSong.Text,
-		&targetSong.Link, &targetSong.ReleaseDate, &targetSong.CreatedAt, &targetSong.UpdatedAt,
-	)
+	err := scanSong(row, &targetSong)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
@@ -74,88 +152,300 @@ func (p *Postgres) Read(ctx context.Context, song *domain.SongInfo) (*domain.Son
 	return &targetSong, nil
 }
 
-func (p *Postgres) ReadAllWithFilter(ctx context.Context, song *domain.Song, limit, offset int) ([]*domain.Song, error) {
-	const op = "repository.SongDB.ReadAllWithFilter"
+// ReadByIDs returns the songs of the library with the given IDs in a single
+// query, IDs of missing songs are skipped
+func (p *Postgres) ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error) {
+	const op = "repository.SongDB.ReadByIDs"
 
-	// Базовый запрос
-	query := `SELECT id, name, group_name, text,
-			  link, release_date, created_at, updated_at
-			  FROM songs`
-	var conditions []string
-	var params []interface{}
-	var paramIndex = 1
+	query, params := newSelect(songColumns).From("songs").
+		Where("id = ANY(?)", ids).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
 
-	// Проверяем поля фильтра и добавляем условия в запрос
-	if song.Name != "" {
-		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", paramIndex))
-		params = append(params, "%"+song.Name+"%")
-		paramIndex++
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if song.Group != "" {
-		conditions = append(conditions, fmt.Sprintf("group_name ILIKE $%d", paramIndex))
-		params = append(params, "%"+song.Group+"%")
-		paramIndex++
+
+	return songs, nil
+}
+
+// ReadByNameAndGroup finds a song by its name and group ignoring case and
+// accents. Name and group are only unique ignoring case, so a song that
+// matches with accents is preferred.
+func (p *Postgres) ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "repository.SongDB.ReadByNameAndGroup"
+
+	query, params := newSelect(songColumns).From("songs").
+		Where("normalize_name(name) = normalize_name(?)", song.Name).
+		Where("normalize_name(group_name) = normalize_name(?)", song.Group).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("lower(name) = lower(?) AND lower(group_name) = lower(?) DESC, created_at", song.Name, song.Group).
+		Limit(1).
+		SQL()
+	row := p.conn(ctx).QueryRow(ctx, query, params...)
+
+	var targetSong domain.Song
+	err := scanSong(row, &targetSong)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if !song.ReleaseDate.IsZero() {
-		conditions = append(conditions, fmt.Sprintf("release_date = $%d", paramIndex))
-		params = append(params, song.ReleaseDate)
-		paramIndex++
+
+	return &targetSong, nil
+}
+
+func (p *Postgres) ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error) {
+	const op = "repository.SongDB.ReadAllWithFilter"
+
+	query, params := listSongsQuery(ctx, filter, limit, offset).SQL()
+
+	// Выполняем запрос
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	// Добавляем условия к запросу, если они есть
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	// Обрабатываем результаты
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if limit != 0 {
-		query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-		params = append(params, limit, offset)
+	return songs, nil
+}
+
+// listSongsQuery selects a page of the songs of the library matching the
+// filter in the order it asks for
+func listSongsQuery(ctx context.Context, filter *domain.SongFilter, limit, offset int) *selectBuilder {
+	query := filterSongs(ctx, newSelect(listColumns(filter)).From("songs"), filter)
+
+	switch {
+	case filter.Sort == domain.SortByPopularity:
+		query.OrderBy("favorites_count DESC, created_at DESC")
+	case filter.Sort == domain.SortByRating:
+		query.OrderBy("rating_average DESC, ratings_count DESC, created_at DESC")
+	case filter.Sort == domain.SortByUpdatedAt:
+		query.OrderBy("updated_at DESC, created_at DESC")
+	case limit != 0:
+		query.OrderBy("created_at DESC")
 	}
 
-	// Выполняем запрос
-	rows, err := p.db.Query(ctx, query, params...)
+	return query.Page(limit, offset)
+}
+
+// ReadAllAfter returns up to limit songs matching the filter, newest first,
+// that come after the cursor. A nil cursor starts from the newest song.
+func (p *Postgres) ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	const op = "repository.SongDB.ReadAllAfter"
+
+	query, params := songsAfterQuery(ctx, filter, after, limit).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	// Обрабатываем результаты
-	var songs []*domain.Song
-	for rows.Next() {
-		var song domain.Song
-		err := rows.Scan(
-			&song.ID, &song.Name, &song.Group, &song.Text,
-			&song.Link, &song.ReleaseDate, &song.CreatedAt, &song.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		songs = append(songs, &song)
+	songs, err := scanSongs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return songs, nil
 }
 
+// songsAfterQuery selects the songs of the library matching the filter that
+// come after the cursor, newest first
+func songsAfterQuery(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) *selectBuilder {
+	query := filterSongs(ctx, newSelect(listColumns(filter)).From("songs"), filter)
+
+	// The row comparison matches the index on (created_at, id)
+	if after != nil {
+		query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	return query.OrderBy("created_at DESC, id DESC").Limit(limit)
+}
+
+// listColumns returns the columns a listing with the filter selects
+func listColumns(filter *domain.SongFilter) string {
+	if filter.WithoutText {
+		return songSummaryColumns
+	}
+	return songColumns
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePattern returns the pattern matching s anywhere in a value, the
+// wildcards of s match literally with ESCAPE '\'
+func likePattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// filterSongs adds the conditions for the non-empty fields of filter and
+// the one matching the songs of the library ctx is scoped to
+func filterSongs(ctx context.Context, query *selectBuilder, filter *domain.SongFilter) *selectBuilder {
+	// Проверяем поля фильтра и добавляем условия в запрос
+	// name and group match ignoring case and accents
+	if filter.Name != "" {
+		query.Where(`normalize_name(name) LIKE normalize_name(?) ESCAPE '\'`, likePattern(filter.Name))
+	}
+	if filter.Group != "" {
+		query.Where(`normalize_name(group_name) LIKE normalize_name(?) ESCAPE '\'`, likePattern(filter.Group))
+	}
+	if filter.Text != "" {
+		query.Where(`text ILIKE ? ESCAPE '\'`, likePattern(filter.Text))
+	}
+	if filter.Link != "" {
+		query.Where(`link ILIKE ? ESCAPE '\'`, likePattern(filter.Link))
+	}
+	if filter.ArtistID != uuid.Nil {
+		query.Where("artist_id = ?", filter.ArtistID)
+	}
+	if !filter.ReleaseDate.IsZero() {
+		query.Where("release_date = ?", filter.ReleaseDate)
+	}
+	if filter.Status != "" {
+		query.Where("status = ?", filter.Status)
+	}
+	if filter.Genre != "" {
+		query.Where("lower(genre) = lower(?)", filter.Genre)
+	}
+	if filter.Album != "" {
+		query.Where(`album ILIKE ? ESCAPE '\'`, likePattern(filter.Album))
+	}
+	if filter.Explicit != nil {
+		query.Where("explicit = ?", *filter.Explicit)
+	}
+	if filter.ExcludeExplicit {
+		query.Where("explicit IS NOT TRUE")
+	}
+	if filter.ExcludeArchived {
+		query.Where("status <> 'archived'")
+	}
+	if filter.MinRating > 0 {
+		query.Where("rating_average >= ?", filter.MinRating)
+	}
+	if filter.MinDuration > 0 {
+		query.Where("duration_ms >= ?", filter.MinDuration.Milliseconds())
+	}
+	if filter.MaxDuration > 0 {
+		query.Where("duration_ms BETWEEN 1 AND ?", filter.MaxDuration.Milliseconds())
+	}
+	if !filter.ReleasedFrom.IsZero() {
+		query.Where("release_date >= ?", filter.ReleasedFrom)
+	}
+	if !filter.ReleasedTo.IsZero() {
+		// songs without a release date are saved with the zero date
+		query.Where("release_date > '0001-01-01' AND release_date <= ?", filter.ReleasedTo)
+	}
+	if filter.Query != "" {
+		query.Where("("+searchDocument+") @@ websearch_to_tsquery('simple', ?)", filter.Query)
+	}
+	if len(filter.Tags) > 0 {
+		// With all tags required a song must match as many tags as were asked,
+		// the tags of a filter are distinct
+		condition := `id IN (SELECT song_tags.song_id
+				  FROM song_tags JOIN tags ON tags.id = song_tags.tag_id
+				  WHERE tags.name = ANY(?) AND tags.library_id = ?`
+		args := []any{filter.Tags, domain.LibraryIDFromContext(ctx)}
+		if filter.TagMode != domain.TagModeAny {
+			condition += " GROUP BY song_tags.song_id HAVING count(*) = ?"
+			args = append(args, len(filter.Tags))
+		}
+		query.Where(condition+")", args...)
+	}
+
+	return query.Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+}
+
 func (p *Postgres) Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error {
 	const op = "repository.SongDB.Update"
 
 	updatedSong.UpdatedAt = time.Now()
 
-	query := `UPDATE songs
-			  SET name = $1, group_name = $2, text = $3,
-			  link = $4, release_date = $5, updated_at = $6 
-              WHERE id = $7`
+	if updatedSong.AlbumID != nil {
+		if err := p.albumExists(ctx, op, *updatedSong.AlbumID); err != nil {
+			return err
+		}
+	}
 
-	result, err := p.db.Exec(
-		ctx, query, updatedSong.Name, updatedSong.Group, updatedSong.Text, updatedSong.Link,
-		updatedSong.ReleaseDate, updatedSong.UpdatedAt, song.ID,
-	)
+	lyrics, err := lyricsJSON(updatedSong.Lyrics)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
+	// The row is only updated if it still has the version the caller read,
+	// otherwise a concurrent update happened in between. An empty status
+	// keeps the stored one.
+	library := domain.LibraryIDFromContext(ctx)
+	query, params := newUpdate("songs").
+		With(upsertArtist, updatedSong.Group, library).
+		Set("name = ?", updatedSong.Name).
+		Set("group_name = ?", updatedSong.Group).
+		Set("text = ?", updatedSong.Text).
+		Set("link = ?", updatedSong.Link).
+		Set("release_date = ?", updatedSong.ReleaseDate).
+		Set("updated_at = ?", updatedSong.UpdatedAt).
+		Set("album_id = ?", updatedSong.AlbumID).
+		Set("artist_id = artist.id").
+		Set("lyrics = ?", lyrics).
+		Set("locked_fields = ?", updatedSong.LockedFields).
+		Set("source = ?", updatedSong.Source).
+		Set("duration_ms = ?", updatedSong.Duration.Milliseconds()).
+		Set("genre = ?", updatedSong.Genre).
+		Set("track_number = ?", updatedSong.TrackNumber).
+		Set("album = ?", updatedSong.Album).
+		Set("explicit = ?", updatedSong.Explicit).
+		Set("status = COALESCE(NULLIF(?, ''), songs.status)", updatedSong.Status).
+		Set("version = version + 1").
+		From("artist").
+		Where("songs.id = ?", song.ID).
+		Where("songs.version = ?", updatedSong.Version).
+		Where("songs.library_id = ?", library).
+		Returning("songs.version, songs.artist_id, songs.library_id, songs.status").
+		SQL()
+
+	err = p.conn(ctx).QueryRow(ctx, query, params...).Scan(&updatedSong.Version, &updatedSong.ArtistID, &updatedSong.LibraryID, &updatedSong.Status)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			// no song is stored in a library that doesn't exist
+			if pgErr.ConstraintName == artistLibraryForeignKey {
+				return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+			}
+			return fmt.Errorf("%s: %w", op, domain.ErrAlbumNotFound)
+		}
+		if isSongDuplicate(err) {
+			return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		query, params := newSelect("1").From("songs").
+			Where("id = ?", song.ID).
+			Where("library_id = ?", library).
+			Exists()
+		var exists bool
+		err = p.conn(ctx).QueryRow(ctx, query, params...).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if exists {
+			return fmt.Errorf("%s: %w", op, domain.ErrVersionConflict)
+		}
 		return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
 	}
 
@@ -165,8 +455,11 @@ func (p *Postgres) Update(ctx context.Context, song *domain.SongInfo, updatedSon
 func (p *Postgres) Delete(ctx context.Context, song *domain.SongInfo) error {
 	const op = "repository.SongDB.Delete"
 
-	query := `DELETE FROM songs WHERE id = $1`
-	result, err := p.db.Exec(ctx, query, song.ID)
+	query, params := newDelete("songs").
+		Where("id = ?", song.ID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -178,3 +471,52 @@ func (p *Postgres) Delete(ctx context.Context, song *domain.SongInfo) error {
 
 	return nil
 }
+
+// isSongDuplicate reports whether err is a violation of songNameGroupIndex
+func isSongDuplicate(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == songNameGroupIndex
+}
+
+func scanSong(row pgx.Row, song *domain.Song) error {
+	return row.Scan(songFields(song)...)
+}
+
+// songFields returns the scan destinations of songColumns
+func songFields(song *domain.Song) []any {
+	return []any{
+		&song.ID, &song.Name, &song.Group, &song.Text,
+		&song.Link, &song.ReleaseDate, &song.CreatedAt, &song.UpdatedAt,
+		&song.Version, &song.AlbumID, &song.ArtistID, &song.FavoritesCount,
+		&song.Source, lyricsColumn{song}, &song.LockedFields,
+		durationColumn{&song.Duration}, &song.Genre, &song.TrackNumber, &song.Album, &song.Explicit, &song.LibraryID,
+		&song.Status, &song.RatingAverage, &song.RatingsCount,
+	}
+}
+
+// durationColumn scans a duration stored in milliseconds
+type durationColumn struct {
+	duration *time.Duration
+}
+
+func (c durationColumn) Scan(src any) error {
+	ms, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("unsupported duration type %T", src)
+	}
+	*c.duration = time.Duration(ms) * time.Millisecond
+	return nil
+}
+
+func scanSongs(rows pgx.Rows) ([]*domain.Song, error) {
+	var songs []*domain.Song
+	for rows.Next() {
+		var song domain.Song
+		if err := scanSong(rows, &song); err != nil {
+			return nil, err
+		}
+		songs = append(songs, &song)
+	}
+
+	return songs, rows.Err()
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -10,66 +11,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/wait"
 )
 
-// Helper function to setup PostgreSQL container for songs
-func setupPostgresForSongs(t *testing.T) (*pgxpool.Pool, func()) {
-	ctx := context.Background()
-
-	req := testcontainers.ContainerRequest{
-		Image:        "postgres:13",
-		ExposedPorts: []string{"5432/tcp"},
-		Env: map[string]string{
-			"POSTGRES_PASSWORD": "password",
-			"POSTGRES_USER":     "user",
-			"POSTGRES_DB":       "testdb",
-		},
-		WaitingFor: wait.ForListeningPort("5432/tcp"),
-	}
-	postgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: req,
-		Started:          true,
-	})
-	assert.NoError(t, err)
-
-	host, err := postgresContainer.Host(ctx)
-	assert.NoError(t, err)
-
-	port, err := postgresContainer.MappedPort(ctx, "5432")
-	assert.NoError(t, err)
-
-	dsn := "postgres://user:password@" + host + ":" + port.Port() + "/testdb?sslmode=disable"
-	poolConfig, err := pgxpool.ParseConfig(dsn)
-	assert.NoError(t, err)
-	conn, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
-	assert.NoError(t, err)
-
-	_, err = conn.Exec(ctx, `
-		CREATE TABLE songs (
-			id UUID PRIMARY KEY,
-			name VARCHAR(100),
-			group_name VARCHAR(100),
-			text TEXT,
-			link TEXT,
-			release_date TIMESTAMP,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		);
-	`)
-	assert.NoError(t, err)
-
-	teardown := func() {
-		conn.Close()
-		postgresContainer.Terminate(ctx)
-	}
-
-	return conn, teardown
-}
-
 func TestSongDB_Create(t *testing.T) {
 	conn, teardown := setupPostgresForSongs(t)
 	defer teardown()
@@ -145,21 +89,21 @@ func TestSongDB_ReadAllWithFilter(t *testing.T) {
 
 	songDB := NewPostgres(conn)
 
-	song := &domain.Song{
+	filter := &domain.SongFilter{
 		Group: "Muse",
 	}
-	songs, err := songDB.ReadAllWithFilter(context.Background(), song, 10, 0)
+	songs, err := songDB.ReadAllWithFilter(context.Background(), filter, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, songs, 2)
 
-	song = &domain.Song{
+	filter = &domain.SongFilter{
 		Name: "Time is Running Out",
 	}
-	songs, err = songDB.ReadAllWithFilter(context.Background(), song, 10, 0)
+	songs, err = songDB.ReadAllWithFilter(context.Background(), filter, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, songs, 1)
 
-	songs, err = songDB.ReadAllWithFilter(context.Background(), &domain.Song{}, 0, 0)
+	songs, err = songDB.ReadAllWithFilter(context.Background(), &domain.SongFilter{}, 0, 0)
 	assert.NoError(t, err)
 	assert.Len(t, songs, 2)
 }
@@ -189,10 +133,12 @@ func TestSongDB_Update(t *testing.T) {
 		Link:        "https://link-to-song-updated.com",
 		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
 		UpdatedAt:   time.Now(),
+		Version:     1,
 	}
 
 	err = songDB.Update(context.Background(), songSearch, updatedSong)
 	assert.NoError(t, err)
+	assert.Equal(t, 2, updatedSong.Version)
 
 	// Verify the song was updated
 	var song domain.Song
@@ -212,6 +158,32 @@ func TestSongDB_Update(t *testing.T) {
 	assert.Equal(t, updatedSong.Link, song.Link)
 }
 
+func TestSongDB_Update_VersionConflict(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	// Insert a song that was already updated once
+	songID := uuid.New()
+	_, err := conn.Exec(context.Background(), `INSERT INTO songs (id, name, group_name, text, link, release_date, created_at, updated_at, version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		songID, "Hysteria", "Muse", "It's bugging me...", "https://link-to-song.com", time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC), time.Now(), time.Now(), 2)
+	assert.NoError(t, err)
+
+	songDB := NewPostgres(conn)
+
+	// Update based on a stale version
+	staleSong := &domain.Song{
+		Name:    "Hysteria (Stale)",
+		Group:   "Muse",
+		Version: 1,
+	}
+	err = songDB.Update(context.Background(), &domain.SongInfo{ID: songID}, staleSong)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	// Update of a missing song is still reported as not found
+	err = songDB.Update(context.Background(), &domain.SongInfo{ID: uuid.New()}, &domain.Song{Version: 1})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
 func TestSongDB_Delete(t *testing.T) {
 	conn, teardown := setupPostgresForSongs(t)
 	defer teardown()
@@ -246,3 +218,1402 @@ func TestSongDB_Delete(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, pgx.ErrNoRows))
 }
+
+func TestArtistDB_UpdateArtist_RenamesSongs(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	song := &domain.Song{
+		Name:        "Hysteria",
+		Group:       "Muse",
+		Text:        "It's bugging me...",
+		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err := songDB.Create(context.Background(), song)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, song.ArtistID)
+
+	// Группа песни переименовывается вместе с исполнителем
+	err = songDB.UpdateArtist(context.Background(), &domain.Artist{ID: song.ArtistID, Name: "MUSE"})
+	assert.NoError(t, err)
+
+	renamed, err := songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "MUSE", renamed.Group)
+	assert.Equal(t, song.ArtistID, renamed.ArtistID)
+	assert.Equal(t, 2, renamed.Version)
+
+	// Исполнителя с песнями удалить нельзя
+	err = songDB.DeleteArtist(context.Background(), song.ArtistID)
+	assert.ErrorIs(t, err, domain.ErrArtistHasSongs)
+}
+
+func TestFavoriteDB_AddFavorite_CountsOnce(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	song := &domain.Song{
+		Name:        "Hysteria",
+		Group:       "Muse",
+		Text:        "It's bugging me...",
+		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err := songDB.Create(context.Background(), song)
+	assert.NoError(t, err)
+
+	// Повторное добавление в избранное не увеличивает счётчик
+	userID := uuid.New()
+	assert.NoError(t, songDB.AddFavorite(context.Background(), userID, song.ID))
+	assert.NoError(t, songDB.AddFavorite(context.Background(), userID, song.ID))
+	assert.NoError(t, songDB.AddFavorite(context.Background(), uuid.New(), song.ID))
+
+	favorited, err := songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, favorited.FavoritesCount)
+
+	favorites, err := songDB.ReadFavorites(context.Background(), userID, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, favorites, 1)
+
+	assert.NoError(t, songDB.RemoveFavorite(context.Background(), userID, song.ID))
+	favorited, err = songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, favorited.FavoritesCount)
+
+	err = songDB.AddFavorite(context.Background(), userID, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestRatingDB_SetRating_Recomputes(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Now()}
+	seedSongs(t, ctx, conn, hysteria, starlight)
+
+	assert.NoError(t, songDB.SetRating(ctx, &domain.Rating{UserID: alice, SongID: hysteria.ID, Value: 5}))
+	assert.NoError(t, songDB.SetRating(ctx, &domain.Rating{UserID: bob, SongID: hysteria.ID, Value: 2}))
+
+	// Повторная оценка заменяет прежнюю и пересчитывает среднюю
+	rating := &domain.Rating{UserID: bob, SongID: hysteria.ID, Value: 4}
+	assert.NoError(t, songDB.SetRating(ctx, rating))
+	assert.Equal(t, 4.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+	assert.NoError(t, songDB.SetRating(ctx, &domain.Rating{UserID: alice, SongID: starlight.ID, Value: 3}))
+
+	rated, err := songDB.Read(ctx, &domain.SongInfo{ID: hysteria.ID})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4.5, rated.RatingAverage)
+		assert.Equal(t, 2, rated.RatingsCount)
+	}
+
+	found, err := songDB.ReadRating(ctx, bob, starlight.ID)
+	if assert.NoError(t, err) {
+		assert.Zero(t, found.Value)
+		assert.Equal(t, 3.0, found.Average)
+	}
+
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{MinRating: 4, Sort: domain.SortByRating}, 10, 0)
+	if assert.NoError(t, err) && assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+
+	removed, err := songDB.RemoveRating(ctx, alice, hysteria.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4.0, removed.Average)
+		assert.Equal(t, 1, removed.Count)
+	}
+
+	// Оценки вне диапазона отклоняет ограничение таблицы
+	assert.Error(t, songDB.SetRating(ctx, &domain.Rating{UserID: alice, SongID: hysteria.ID, Value: 6}))
+
+	err = songDB.SetRating(ctx, &domain.Rating{UserID: alice, SongID: uuid.New(), Value: 1})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestPlayDB_AddPlays_ReadTrending(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(context.Background(), hysteria))
+	uprising := &domain.Song{Name: "Uprising", Group: "Muse", Text: "Paranoia is in bloom...", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(context.Background(), uprising))
+
+	bucket := time.Now().Truncate(time.Hour)
+
+	// Прослушивания удалённых песен пропускаются
+	err := songDB.AddPlays(context.Background(), map[uuid.UUID]int{hysteria.ID: 2, uprising.ID: 5, uuid.New(): 7}, bucket)
+	assert.NoError(t, err)
+	err = songDB.AddPlays(context.Background(), map[uuid.UUID]int{hysteria.ID: 4}, bucket)
+	assert.NoError(t, err)
+
+	trending, err := songDB.ReadTrending(context.Background(), bucket.Add(-time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, trending, 2)
+	assert.Equal(t, hysteria.ID, trending[0].Song.ID)
+	assert.Equal(t, 6, trending[0].Plays)
+	assert.Equal(t, 5, trending[1].Plays)
+
+	trending, err = songDB.ReadTrending(context.Background(), bucket.Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, trending)
+}
+
+func TestWebhookDB_ReadWebhooksForEvent(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	webhookDB := NewPostgres(conn)
+
+	all := &domain.Webhook{URL: "https://example.com/all", Secret: "secret"}
+	assert.NoError(t, webhookDB.CreateWebhook(context.Background(), all))
+	deletions := &domain.Webhook{URL: "https://example.com/deletions", Secret: "secret", Events: []domain.SongEventType{domain.SongDeleted}}
+	assert.NoError(t, webhookDB.CreateWebhook(context.Background(), deletions))
+
+	// Вебхук без списка событий получает все события
+	hooks, err := webhookDB.ReadWebhooksForEvent(context.Background(), domain.SongCreated)
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 1)
+	assert.Equal(t, all.ID, hooks[0].ID)
+
+	hooks, err = webhookDB.ReadWebhooksForEvent(context.Background(), domain.SongDeleted)
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 2)
+
+	err = webhookDB.DeleteWebhook(context.Background(), uuid.New())
+	assert.True(t, errors.Is(err, domain.ErrWebhookNotFound))
+}
+
+func TestSongDB_WithinTransaction_Rollback(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", ReleaseDate: time.Now()}
+	errAbort := errors.New("abort")
+
+	// Ошибка внутри транзакции отменяет все изменения
+	err := songDB.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := songDB.Create(ctx, song); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	_, err = songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	err = songDB.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return songDB.Create(ctx, song)
+	})
+	assert.NoError(t, err)
+
+	_, err = songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+}
+
+func TestSongDB_ReadAllAfter(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	var created []*domain.Song
+	for _, name := range []string{"Hysteria", "Uprising", "Madness"} {
+		song := &domain.Song{Name: name, Group: "Muse", Text: "...", ReleaseDate: time.Now()}
+		assert.NoError(t, songDB.Create(context.Background(), song))
+		created = append(created, song)
+	}
+
+	page, err := songDB.ReadAllAfter(context.Background(), &domain.SongFilter{Group: "muse"}, nil, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, created[2].ID, page[0].ID)
+
+	// Следующая страница начинается после последней песни предыдущей
+	page, err = songDB.ReadAllAfter(context.Background(), &domain.SongFilter{Group: "muse"}, domain.CursorOf(page[1]), 2)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, created[0].ID, page[0].ID)
+}
+
+func TestSongDB_Create_DuplicateNameAndGroup(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(context.Background(), song))
+
+	// Название и группа сравниваются без учёта регистра
+	duplicate := &domain.Song{Name: "HYSTERIA", Group: "muse", Text: "...", ReleaseDate: time.Now()}
+	err := songDB.Create(context.Background(), duplicate)
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+}
+
+func TestSongDB_ReadDuplicates(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	for _, song := range []*domain.Song{
+		{Name: "Hysteria", Group: "Muse"},
+		{Name: "Hysteria", Group: "Muse."},
+		{Name: "Mr. Blue Sky", Group: "ELO"},
+	} {
+		song.Text = "..."
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	duplicates, err := songDB.ReadDuplicates(context.Background(), 0.6, 10)
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, "Hysteria", duplicates[0].Song.Name)
+	assert.Equal(t, "Hysteria", duplicates[0].Duplicate.Name)
+	assert.Greater(t, duplicates[0].Similarity, 0.6)
+}
+
+func TestSongDB_ReadSuggestions(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	for _, song := range []*domain.Song{
+		{Name: "Hysteria", Group: "Muse"},
+		{Name: "Hyper Music", Group: "Muse"},
+		{Name: "Mr. Blue Sky", Group: "ELO"},
+	} {
+		song.Text = "..."
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	// Группа предлагается один раз, сколько бы у неё ни было песен
+	suggestions, err := songDB.ReadSuggestions(context.Background(), "hy", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Suggestion{
+		{Kind: domain.SuggestionSong, Text: "Hyper Music", Group: "Muse", Score: 1},
+		{Kind: domain.SuggestionSong, Text: "Hysteria", Group: "Muse", Score: 1},
+	}, suggestions)
+
+	suggestions, err = songDB.ReadSuggestions(context.Background(), "mu", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: "Muse", Score: 1}}, suggestions)
+}
+
+func TestSongDB_UpdateAllWithFilter(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	bliss := &domain.Song{Name: "Bliss", Group: "Muse"}
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead"}
+	for _, song := range []*domain.Song{hysteria, bliss, creep} {
+		song.Text = "..."
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	// Одним запросом меняются группа и жанр всех песен группы
+	group, genre := "MUSE", "rock"
+	updates, err := songDB.UpdateAllWithFilter(context.Background(), &domain.SongFilter{Group: "muse"}, &domain.SongChanges{Group: &group, Genre: &genre})
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+	for _, update := range updates {
+		assert.Equal(t, "Muse", update.Old.Group)
+		assert.Equal(t, "MUSE", update.New.Group)
+		assert.Equal(t, "rock", update.New.Genre)
+		assert.Equal(t, update.Old.Version+1, update.New.Version)
+		assert.NotEqual(t, update.Old.ArtistID, update.New.ArtistID)
+		assert.True(t, update.New.LockedFields.Has(domain.FieldGenre))
+	}
+
+	stored, err := songDB.Read(context.Background(), &domain.SongInfo{ID: creep.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "Radiohead", stored.Group)
+
+	// Переименование в существующую песню нарушает уникальность
+	radiohead := "Radiohead"
+	stp := &domain.Song{Name: "Creep", Group: "Stone Temple Pilots", Text: "...", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(context.Background(), stp))
+	_, err = songDB.UpdateAllWithFilter(context.Background(), &domain.SongFilter{Group: "Stone"}, &domain.SongChanges{Group: &radiohead})
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+
+	_, err = songDB.UpdateAllWithFilter(context.Background(), &domain.SongFilter{}, &domain.SongChanges{Genre: &genre})
+	assert.ErrorIs(t, err, domain.ErrBulkFilterEmpty)
+}
+
+func TestSongDB_SearchSongs(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	love := &domain.Song{Name: "Love", Group: "Lana Del Rey", Text: "Look at you kids, you know you're cool"}
+	bliss := &domain.Song{Name: "Bliss", Group: "Muse", Text: "Everything about you is so easy to love"}
+	for _, song := range []*domain.Song{love, bliss, {Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}} {
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	// Совпадение в названии ранжируется выше совпадения в тексте
+	results, err := songDB.SearchSongs(context.Background(), "love", false, 3, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, love.ID, results[0].Song.ID)
+	assert.Equal(t, bliss.ID, results[1].Song.ID)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+	assert.Less(t, results[0].Rank, 1.0)
+
+	// Фрагменты без совпадений в тексте отбрасываются
+	assert.Empty(t, results[0].Snippets)
+	assert.Len(t, results[1].Snippets, 1)
+	assert.Contains(t, results[1].Snippets[0], domain.HighlightStart+"love"+domain.HighlightEnd)
+
+	results, err = songDB.SearchSongs(context.Background(), "muse -bliss", false, 0, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Hysteria", results[0].Song.Name)
+	assert.Empty(t, results[0].Snippets)
+
+	// Песни с пометкой explicit исключаются по запросу, неизвестные остаются
+	explicit := true
+	bliss.Explicit = &explicit
+	assert.NoError(t, songDB.Update(context.Background(), &domain.SongInfo{ID: bliss.ID}, bliss))
+	results, err = songDB.SearchSongs(context.Background(), "love", true, 0, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, love.ID, results[0].Song.ID)
+}
+
+func TestSongDB_ReadSimilar(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Genre: "rock", Text: "It's bugging me, grating me"}
+	starlight := &domain.Song{Name: "Starlight", Group: "MUSE", Genre: "Rock", Text: "Far away, this ship is taking me far away"}
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", Text: "It's bugging me, grating me"}
+	for _, song := range []*domain.Song{hysteria, starlight, creep} {
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	// Тот же текст весит больше, чем та же группа и жанр
+	similar, err := songDB.ReadSimilar(context.Background(), hysteria, false, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, similar, 2) {
+		assert.Equal(t, creep.ID, similar[0].Song.ID)
+		assert.InDelta(t, domain.SimilarTextWeight, similar[0].Score, 1e-6)
+		assert.Equal(t, starlight.ID, similar[1].Song.ID)
+	}
+
+	similar, err = songDB.ReadSimilar(context.Background(), hysteria, false, 1)
+	assert.NoError(t, err)
+	assert.Len(t, similar, 1)
+}
+
+func TestSongDB_ReadRandom_ReadSeeded(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	for _, song := range []*domain.Song{
+		{Name: "Hysteria", Group: "Muse"},
+		{Name: "Creep", Group: "Radiohead"},
+	} {
+		song.Text = "..."
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	song, err := songDB.ReadRandom(context.Background(), &domain.SongFilter{Group: "radio"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Creep", song.Name)
+
+	_, err = songDB.ReadRandom(context.Background(), &domain.SongFilter{Group: "Queen"})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+
+	// Одно и то же зерно выбирает одну и ту же песню
+	first, err := songDB.ReadSeeded(context.Background(), "2024-05-01")
+	assert.NoError(t, err)
+	again, err := songDB.ReadSeeded(context.Background(), "2024-05-01")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+}
+
+func TestSongDB_ReadLibraryStats(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	for _, song := range []*domain.Song{
+		{Name: "Hysteria", Group: "Muse", Text: "Привет", Link: "https://example.com"},
+		{Name: "Uprising", Group: "MUSE", Text: "Paranoia"},
+		{Name: "Creep", Group: "Radiohead"},
+	} {
+		song.ReleaseDate = time.Now()
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	stats, err := songDB.ReadLibraryStats(context.Background(), time.Now().AddDate(0, 0, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Songs)
+	assert.Equal(t, 2, stats.Groups)
+	assert.Equal(t, 1, stats.MissingText)
+	assert.Equal(t, 2, stats.MissingLink)
+	assert.InDelta(t, 7.0, stats.AvgTextLength, 0.001)
+	if assert.Len(t, stats.AddedPerDay, 1) {
+		assert.Equal(t, 3, stats.AddedPerDay[0].Songs)
+	}
+}
+
+func TestSongDB_ReadGroups(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	first := time.Date(2006, 6, 19, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC)
+	for _, song := range []*domain.Song{
+		{Name: "Hysteria", Group: "Muse", ReleaseDate: latest},
+		{Name: "Starlight", Group: "Muse", ReleaseDate: first},
+		{Name: "Creep", Group: "Radiohead", ReleaseDate: first},
+	} {
+		assert.NoError(t, songDB.Create(context.Background(), song))
+	}
+
+	groups, err := songDB.ReadGroups(context.Background(), "mus", 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, "Muse", groups[0].Name)
+		assert.Equal(t, 2, groups[0].Songs)
+		assert.True(t, first.Equal(groups[0].FirstRelease))
+		assert.True(t, latest.Equal(groups[0].LatestRelease))
+	}
+
+	groups, err = songDB.ReadGroups(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, "Radiohead", groups[0].Name)
+	}
+}
+
+func TestSongDB_ReadByNameAndGroup(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+
+	song := &domain.Song{Name: "Mr. Blue Sky", Group: "ELO", Text: "Sun is shinin' in the sky", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(context.Background(), song))
+
+	// Регистр названия и группы не учитывается
+	found, err := songDB.ReadByNameAndGroup(context.Background(), &domain.SongInfo{Name: "mr. blue sky", Group: "elo"})
+	assert.NoError(t, err)
+	assert.Equal(t, song.ID, found.ID)
+
+	_, err = songDB.ReadByNameAndGroup(context.Background(), &domain.SongInfo{Name: "Evil Woman", Group: "ELO"})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestUserDB_Roles(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	_, err := songDB.ReadUser(ctx, userID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	// Повторное назначение заменяет роль
+	assert.NoError(t, songDB.UpsertUser(ctx, &domain.User{ID: userID, Role: domain.RoleViewer}))
+	assert.NoError(t, songDB.UpsertUser(ctx, &domain.User{ID: userID, Role: domain.RoleEditor}))
+
+	user, err := songDB.ReadUser(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleEditor, user.Role)
+
+	users, err := songDB.ReadAllUsers(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, songDB.DeleteUser(ctx, userID))
+	assert.ErrorIs(t, songDB.DeleteUser(ctx, userID), domain.ErrUserNotFound)
+}
+
+func TestAPIKeyDB_CreateReadRevoke(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	key := &domain.APIKey{Name: "ci", Prefix: "sl_01234567", Hash: "hash", Role: domain.RoleEditor}
+	assert.NoError(t, songDB.CreateAPIKey(ctx, key))
+
+	found, err := songDB.ReadAPIKeyByHash(ctx, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+	assert.Nil(t, found.LibraryID)
+
+	_, err = songDB.ReadAPIKeyByHash(ctx, "other")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	// Ключ несуществующей библиотеки не создаётся
+	libraryID := uuid.New()
+	err = songDB.CreateAPIKey(ctx, &domain.APIKey{Name: "x", Hash: "hash2", Role: domain.RoleViewer, LibraryID: &libraryID})
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+
+	revoked, err := songDB.RevokeAPIKey(ctx, key.ID, time.Now())
+	assert.NoError(t, err)
+	assert.NotNil(t, revoked.RevokedAt)
+
+	keys, err := songDB.ReadAllAPIKeys(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	_, err = songDB.RevokeAPIKey(ctx, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
+
+func TestLibraryDB_SongsAreScoped(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	library := &domain.Library{Name: "Team B"}
+	assert.NoError(t, songDB.CreateLibrary(ctx, library))
+	assert.ErrorIs(t, songDB.CreateLibrary(ctx, &domain.Library{Name: "team b"}), domain.ErrLibraryExists)
+
+	libraries, err := songDB.ReadAllLibraries(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, libraries, 2)
+
+	_, err = songDB.ReadLibrary(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+
+	// Одна и та же песня может быть в разных библиотеках
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, song))
+	scoped := domain.WithLibraryID(ctx, library.ID)
+	teamSong := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(scoped, teamSong))
+	assert.Equal(t, library.ID, teamSong.LibraryID)
+
+	// Песни другой библиотеки не видны
+	_, err = songDB.Read(scoped, &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+	assert.ErrorIs(t, songDB.Delete(scoped, &domain.SongInfo{ID: song.ID}), domain.ErrSongNotFound)
+
+	songs, err := songDB.ReadAllWithFilter(scoped, &domain.SongFilter{}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, teamSong.ID, songs[0].ID)
+	}
+
+	// Песню нельзя создать в несуществующей библиотеке
+	err = songDB.Create(domain.WithLibraryID(ctx, uuid.New()), &domain.Song{Name: "Creep", Group: "Radiohead", ReleaseDate: time.Now()})
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+}
+
+func TestLibraryDB_AlbumsArtistsTagsAreScoped(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	library := &domain.Library{Name: "Team B"}
+	assert.NoError(t, songDB.CreateLibrary(ctx, library))
+	scoped := domain.WithLibraryID(ctx, library.ID)
+
+	// Альбом другой библиотеки не виден и не может быть указан у песни
+	album := &domain.Album{Title: "Absolution", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.CreateAlbum(ctx, album))
+	_, err := songDB.ReadAlbum(scoped, album.ID)
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+	assert.ErrorIs(t, songDB.UpdateAlbum(scoped, album), domain.ErrAlbumNotFound)
+	assert.ErrorIs(t, songDB.DeleteAlbum(scoped, album.ID), domain.ErrAlbumNotFound)
+	albums, err := songDB.ReadAllAlbums(scoped, "", 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, albums)
+	err = songDB.Create(scoped, &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now(), AlbumID: &album.ID})
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+
+	// У каждой библиотеки свой исполнитель с тем же именем
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, song))
+	teamSong := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(scoped, teamSong))
+	assert.NotEqual(t, song.ArtistID, teamSong.ArtistID)
+	_, err = songDB.ReadArtist(scoped, song.ArtistID)
+	assert.ErrorIs(t, err, domain.ErrArtistNotFound)
+	artists, err := songDB.ReadAllArtists(scoped, "", 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, artists, 1) {
+		assert.Equal(t, teamSong.ArtistID, artists[0].ID)
+	}
+	assert.ErrorIs(t, songDB.UpdateArtist(scoped, &domain.Artist{ID: song.ArtistID, Name: "Radiohead"}), domain.ErrArtistNotFound)
+	assert.ErrorIs(t, songDB.DeleteArtist(scoped, song.ArtistID), domain.ErrArtistNotFound)
+	assert.NoError(t, songDB.CreateArtist(scoped, &domain.Artist{Name: "Radiohead"}))
+	assert.NoError(t, songDB.CreateArtist(ctx, &domain.Artist{Name: "Radiohead"}))
+
+	// Одинаковые теги разных библиотек не пересекаются
+	assert.NoError(t, songDB.AddTags(ctx, song.ID, []string{"rock"}))
+	assert.NoError(t, songDB.AddTags(scoped, teamSong.ID, []string{"rock"}))
+	assert.NoError(t, songDB.RemoveTag(scoped, song.ID, "rock"))
+	tags, err := songDB.ReadSongTags(scoped, song.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+	tags, err = songDB.ReadSongTags(ctx, song.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rock"}, tags)
+
+	assert.NoError(t, songDB.RemoveTag(scoped, teamSong.ID, "rock"))
+	all, err := songDB.ReadTags(ctx, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, all, 1) {
+		assert.Equal(t, &domain.Tag{Name: "rock", Songs: 1}, all[0])
+	}
+}
+
+func TestSongDB_NormalizedNames(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Müse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+	cafe := &domain.Song{Name: "Café Society", Group: "Muse", Text: "Café", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, cafe))
+
+	// Регистр и диакритика не учитываются при поиске по названию и группе
+	found, err := songDB.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "HYSTERIA", Group: "muse"})
+	assert.NoError(t, err)
+	assert.Equal(t, hysteria.ID, found.ID)
+
+	// Точное совпадение с диакритикой предпочтительнее
+	exact := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, exact))
+	found, err = songDB.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "hysteria", Group: "MUSE"})
+	assert.NoError(t, err)
+	assert.Equal(t, exact.ID, found.ID)
+
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Name: "cafe"}, 10, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, cafe.ID, songs[0].ID)
+	}
+
+	songs, err = songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Group: "müse"}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, songs, 3)
+
+	// Песни, отличающиеся только диакритикой, — полные дубликаты
+	duplicates, err := songDB.ReadDuplicates(ctx, 0.9, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, duplicates, 1) {
+		assert.Equal(t, float64(1), duplicates[0].Similarity)
+	}
+}
+
+func TestSongDB_Read_ReplicaFallback(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn, unreachablePool(t))
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me..."}
+	assert.NoError(t, songDB.Create(context.Background(), song))
+
+	// Реплика недоступна, чтение и список песен выполняются на основной базе
+	found, err := songDB.Read(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, song.Name, found.Name)
+
+	songs, err := songDB.ReadAllWithFilter(context.Background(), &domain.SongFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, songs, 1)
+}
+
+func TestAuditDB_CreateAuditEntry_ReadAuditEntries(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	auditDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	songID, userID := uuid.New(), uuid.New()
+	song := &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Version: 1}
+
+	// Запись в откаченной транзакции не сохраняется
+	errAbort := errors.New("abort")
+	err := auditDB.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := auditDB.CreateAuditEntry(ctx, &domain.AuditEntry{SongID: songID, Action: domain.AuditCreate, New: song}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	assert.NoError(t, auditDB.CreateAuditEntry(ctx, &domain.AuditEntry{SongID: songID, Action: domain.AuditCreate, New: song}))
+	assert.NoError(t, auditDB.CreateAuditEntry(ctx, &domain.AuditEntry{SongID: songID, Action: domain.AuditDelete, UserID: &userID, Old: song}))
+
+	entries, err := auditDB.ReadAuditEntries(ctx, songID, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, domain.AuditCreate, entries[0].Action)
+		assert.Nil(t, entries[0].UserID)
+		assert.Nil(t, entries[0].Old)
+		assert.Equal(t, "Hysteria", entries[0].New.Name)
+
+		assert.Equal(t, domain.AuditDelete, entries[1].Action)
+		assert.Equal(t, &userID, entries[1].UserID)
+		assert.Nil(t, entries[1].New)
+	}
+
+	entries, err = auditDB.ReadAuditEntries(ctx, songID, 1, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, domain.AuditDelete, entries[0].Action)
+	}
+}
+
+func TestOutboxDB_CreateOutboxEvent_ReadOutboxEvents(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	outboxDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Version: 1}
+	assert.NoError(t, outboxDB.CreateOutboxEvent(ctx, &domain.SongEvent{Type: domain.SongCreated, Song: song, OccurredAt: time.Now()}))
+	assert.NoError(t, outboxDB.CreateOutboxEvent(ctx, &domain.SongEvent{Type: domain.SongDeleted, Song: song, OccurredAt: time.Now()}))
+
+	// События читаются в порядке записи
+	events, err := outboxDB.ReadOutboxEvents(ctx, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.SongCreated, events[0].Event.Type)
+		assert.Equal(t, "Hysteria", events[0].Event.Song.Name)
+
+		assert.NoError(t, outboxDB.DeleteOutboxEvents(ctx, []int64{events[0].ID}))
+	}
+
+	events, err = outboxDB.ReadOutboxEvents(ctx, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.SongDeleted, events[0].Event.Type)
+	}
+}
+
+func TestRevisionDB_CreateRevision_ReadRevisions(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "first", ReleaseDate: time.Now().UTC().Truncate(time.Second)}
+	assert.NoError(t, songDB.Create(ctx, song))
+	assert.NoError(t, songDB.CreateRevision(ctx, song))
+
+	// Повторное сохранение той же версии не создаёт дубликат
+	assert.NoError(t, songDB.CreateRevision(ctx, song))
+
+	second := *song
+	second.Text = "second"
+	second.Version = 2
+	assert.NoError(t, songDB.CreateRevision(ctx, &second))
+
+	revisions, err := songDB.ReadRevisions(ctx, song.ID, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, 2, revisions[0].Revision)
+		assert.Equal(t, "second", revisions[0].Song.Text)
+		assert.Equal(t, song.ReleaseDate, revisions[1].Song.ReleaseDate)
+	}
+
+	revision, err := songDB.ReadRevision(ctx, song.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", revision.Song.Text)
+
+	_, err = songDB.ReadRevision(ctx, song.ID, 3)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+}
+
+func TestTagDB_AddTags_ReadAllWithFilter(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+	assert.NoError(t, songDB.Create(ctx, starlight))
+
+	assert.NoError(t, songDB.AddTags(ctx, hysteria.ID, []string{"rock", "live"}))
+	assert.NoError(t, songDB.AddTags(ctx, hysteria.ID, []string{"rock"}))
+	assert.NoError(t, songDB.AddTags(ctx, starlight.ID, []string{"rock"}))
+	assert.ErrorIs(t, songDB.AddTags(ctx, uuid.New(), []string{"rock"}), domain.ErrSongNotFound)
+
+	tags, err := songDB.ReadSongTags(ctx, hysteria.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"live", "rock"}, tags)
+
+	// По умолчанию песня должна иметь все теги фильтра, в режиме any хватает одного
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Tags: []string{"rock", "live"}}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+
+	songs, err = songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Tags: []string{"rock", "live"}, TagMode: domain.TagModeAny}, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	all, err := songDB.ReadTags(ctx, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Tag{{Name: "rock", Songs: 2}, {Name: "live", Songs: 1}}, all)
+
+	// Тег без песен удаляется
+	assert.NoError(t, songDB.RemoveTag(ctx, hysteria.ID, "live"))
+	all, err = songDB.ReadTags(ctx, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Tag{{Name: "rock", Songs: 2}}, all)
+}
+
+func TestSongDB_Create_Lyrics(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	lyrics := &domain.Lyrics{Sections: []domain.LyricsSection{
+		{Type: domain.SectionVerse, Index: 1, Text: "It's bugging me"},
+		{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+	}}
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me\n\n'Cause I want it now", ReleaseDate: time.Now(), Lyrics: lyrics}
+	assert.NoError(t, songDB.Create(ctx, song))
+
+	found, err := songDB.Read(ctx, &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, lyrics, found.Lyrics)
+
+	// Песни без разметки хранят NULL, блокировки полей сохраняются
+	song.Lyrics = nil
+	song.LockedFields = domain.FieldText | domain.FieldLink
+	assert.NoError(t, songDB.Update(ctx, &domain.SongInfo{ID: song.ID}, song))
+
+	found, err = songDB.Read(ctx, &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Nil(t, found.Lyrics)
+	assert.Equal(t, domain.FieldText|domain.FieldLink, found.LockedFields)
+}
+
+func TestSongDB_ReadReleaseCalendar(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Date(2006, 12, 1, 0, 0, 0, 0, time.UTC)}
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", ReleaseDate: time.Date(1992, 9, 21, 0, 0, 0, 0, time.UTC)}
+	for _, song := range []*domain.Song{hysteria, starlight, creep} {
+		assert.NoError(t, songDB.Create(ctx, song))
+	}
+
+	days, err := songDB.ReadReleaseCalendar(ctx, 0, false)
+	assert.NoError(t, err)
+	if assert.Len(t, days, 2) {
+		assert.Equal(t, time.September, days[0].Month)
+		assert.Equal(t, 21, days[0].Day)
+		if assert.Len(t, days[1].Songs, 2) {
+			assert.Equal(t, hysteria.ID, days[1].Songs[0].ID)
+			assert.Equal(t, "Muse", days[1].Songs[0].Group)
+			assert.Equal(t, 2006, days[1].Songs[1].ReleaseDate.Year())
+		}
+	}
+
+	days, err = songDB.ReadReleaseCalendar(ctx, 2006, false)
+	assert.NoError(t, err)
+	if assert.Len(t, days, 1) && assert.Len(t, days[0].Songs, 1) {
+		assert.Equal(t, starlight.ID, days[0].Songs[0].ID)
+	}
+}
+
+func TestEnrichmentDB_ReadStaleSongs(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	withText := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	withoutText := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, withText))
+	assert.NoError(t, songDB.Create(ctx, withoutText))
+
+	// Песня без текста устарела в любом случае
+	songs, err := songDB.ReadStaleSongs(ctx, time.Now().Add(-time.Hour), nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, withoutText.ID, songs[0].ID)
+	}
+
+	songs, err = songDB.ReadStaleSongs(ctx, time.Now().Add(time.Hour), nil, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		songs, err = songDB.ReadStaleSongs(ctx, time.Now().Add(time.Hour), domain.CursorOf(songs[0]), 10)
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+	}
+}
+
+func TestSongDB_DumpAndRestoreTables(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	ctx := context.Background()
+	songDB := NewPostgres(conn)
+
+	// Схема загружена из миграций приложения
+	version, err := songDB.SchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int(testPostgres.schemaVersion), version)
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+	assert.NoError(t, songDB.Create(ctx, &domain.Song{Name: "Creep", Group: "Radiohead", ReleaseDate: time.Now()}))
+
+	var rows []domain.BackupRow
+	err = songDB.DumpTables(ctx, func(row domain.BackupRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	next := func(rows []domain.BackupRow) func() (*domain.BackupRow, error) {
+		return func() (*domain.BackupRow, error) {
+			if len(rows) == 0 {
+				return nil, io.EOF
+			}
+			row := rows[0]
+			rows = rows[1:]
+			return &row, nil
+		}
+	}
+
+	// После бэкапа добавляется песня, восстановление её удаляет
+	assert.NoError(t, songDB.Create(ctx, &domain.Song{Name: "Uprising", Group: "Muse", ReleaseDate: time.Now()}))
+
+	tables, err := songDB.RestoreTables(ctx, next(rows), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tables["songs"])
+	assert.Equal(t, 2, tables["artists"])
+
+	var count int
+	assert.NoError(t, conn.QueryRow(ctx, `SELECT count(*) FROM songs`).Scan(&count))
+	assert.Equal(t, 3, count, "dry run must not change the database")
+
+	_, err = songDB.RestoreTables(ctx, next(rows), false)
+	assert.NoError(t, err)
+	assert.NoError(t, conn.QueryRow(ctx, `SELECT count(*) FROM songs`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	restored, err := songDB.Read(ctx, &domain.SongInfo{ID: hysteria.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "It's bugging me", restored.Text)
+	assert.Equal(t, hysteria.ArtistID, restored.ArtistID)
+
+	// Строка без обязательных колонок отклоняется базой
+	invalid := []domain.BackupRow{{Table: "songs", Data: []byte(`{"id": "` + uuid.New().String() + `"}`)}}
+	_, err = songDB.RestoreTables(ctx, next(invalid), false)
+	assert.ErrorIs(t, err, domain.ErrBackupInvalid)
+
+	_, err = songDB.RestoreTables(ctx, next([]domain.BackupRow{{Table: "users", Data: []byte(`{}`)}}), false)
+	assert.ErrorIs(t, err, domain.ErrBackupInvalid)
+}
+
+func TestSongDB_RestoreTables_WithEmbeddings(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	ctx := context.Background()
+	songDB := NewPostgres(conn)
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+
+	var rows []domain.BackupRow
+	assert.NoError(t, songDB.DumpTables(ctx, func(row domain.BackupRow) error {
+		rows = append(rows, row)
+		return nil
+	}))
+
+	// Таблица эмбеддингов создаётся при включении семантического поиска и
+	// ссылается на песни, образ Postgres тестов не содержит pgvector
+	_, err := conn.Exec(ctx, `CREATE TABLE song_embeddings (
+		song_id UUID PRIMARY KEY REFERENCES songs (id) ON DELETE CASCADE,
+		song_version INTEGER NOT NULL
+	)`)
+	assert.NoError(t, err)
+	_, err = conn.Exec(ctx, `INSERT INTO song_embeddings VALUES ($1, 1)`, hysteria.ID)
+	assert.NoError(t, err)
+
+	next := func() (*domain.BackupRow, error) {
+		if len(rows) == 0 {
+			return nil, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return &row, nil
+	}
+
+	tables, err := songDB.RestoreTables(ctx, next, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tables["songs"])
+
+	// Эмбеддинги очищаются и строятся заново индексатором
+	var count int
+	assert.NoError(t, conn.QueryRow(ctx, `SELECT count(*) FROM song_embeddings`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestPlaylistDB_CRUD(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	playlistDB := NewPostgres(conn)
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	playlist := &domain.SmartPlaylist{
+		UserID: owner,
+		Name:   "Muse",
+		Filter: domain.PlaylistFilter{
+			Group:        "muse",
+			Tags:         []string{"rock"},
+			TagMode:      domain.TagModeAny,
+			ReleasedFrom: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			Query:        "bugging",
+		},
+	}
+	assert.NoError(t, playlistDB.CreatePlaylist(ctx, playlist))
+
+	found, err := playlistDB.ReadPlaylist(ctx, owner, playlist.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, playlist.Filter, found.Filter)
+
+	// Плейлисты других пользователей не видны
+	_, err = playlistDB.ReadPlaylist(ctx, other, playlist.ID)
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+	assert.ErrorIs(t, playlistDB.UpdatePlaylist(ctx, &domain.SmartPlaylist{ID: playlist.ID, UserID: other, Name: "Queen"}), domain.ErrPlaylistNotFound)
+
+	playlist.Name = "Muse 2000s"
+	assert.NoError(t, playlistDB.UpdatePlaylist(ctx, playlist))
+
+	playlists, err := playlistDB.ReadPlaylists(ctx, owner)
+	assert.NoError(t, err)
+	if assert.Len(t, playlists, 1) {
+		assert.Equal(t, "Muse 2000s", playlists[0].Name)
+	}
+
+	assert.NoError(t, playlistDB.DeletePlaylist(ctx, owner, playlist.ID))
+	assert.ErrorIs(t, playlistDB.DeletePlaylist(ctx, owner, playlist.ID), domain.ErrPlaylistNotFound)
+}
+
+func TestShareDB_CreateReadRevoke(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	shareDB := NewPostgres(conn)
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	seedSongs(t, ctx, conn, song)
+	playlist := &domain.SmartPlaylist{UserID: owner, Name: "Muse", Filter: domain.PlaylistFilter{Group: "muse"}}
+	assert.NoError(t, shareDB.CreatePlaylist(ctx, playlist))
+
+	songShare := &domain.Share{UserID: owner, SongID: &song.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, shareDB.CreateShare(ctx, songShare))
+	playlistShare := &domain.Share{UserID: owner, PlaylistID: &playlist.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, shareDB.CreateShare(ctx, playlistShare))
+
+	// Ссылка ссылается ровно на песню или плейлист
+	assert.Error(t, shareDB.CreateShare(ctx, &domain.Share{UserID: owner, ExpiresAt: time.Now()}))
+
+	found, err := shareDB.ReadShare(ctx, songShare.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &song.ID, found.SongID)
+		assert.Nil(t, found.PlaylistID)
+		assert.Nil(t, found.RevokedAt)
+	}
+
+	shares, err := shareDB.ReadShares(ctx, owner)
+	assert.NoError(t, err)
+	assert.Len(t, shares, 2)
+	shares, err = shareDB.ReadShares(ctx, other)
+	assert.NoError(t, err)
+	assert.Empty(t, shares)
+
+	_, err = shareDB.RevokeShare(ctx, other, songShare.ID, time.Now())
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+	revoked, err := shareDB.RevokeShare(ctx, owner, songShare.ID, time.Now())
+	if assert.NoError(t, err) {
+		assert.NotNil(t, revoked.RevokedAt)
+	}
+
+	// Ссылки удаляются вместе с песней и плейлистом
+	assert.NoError(t, shareDB.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+	_, err = shareDB.ReadShare(ctx, songShare.ID)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+	assert.NoError(t, shareDB.DeletePlaylist(ctx, owner, playlist.ID))
+	_, err = shareDB.ReadShare(ctx, playlistShare.ID)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+}
+
+func TestSongDB_ReadAllWithFilter_ReleaseRangeAndQuery(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", ReleaseDate: time.Date(2006, 9, 4, 0, 0, 0, 0, time.UTC)}
+	undated := &domain.Song{Name: "Untitled", Group: "Muse", Text: "It's bugging me"}
+	seedSongs(t, ctx, conn, hysteria, starlight, undated)
+
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{ReleasedTo: hysteria.ReleaseDate}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+
+	songs, err = songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Query: "bugging -untitled"}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+}
+
+func TestSongDB_ReadAllWithFilter_TextAndLink(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Link: "https://youtube.com/watch?v=3dm_5qWWDV8", ReleaseDate: time.Now()}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", Link: "https://vimeo.com/starlight", ReleaseDate: time.Now()}
+	seedSongs(t, ctx, conn, hysteria, starlight)
+
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Text: "BUGGING"}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, hysteria.ID, songs[0].ID)
+	}
+
+	songs, err = songDB.ReadAllWithFilter(ctx, &domain.SongFilter{Link: "vimeo.com"}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, starlight.ID, songs[0].ID)
+	}
+}
+
+func TestSongDB_ReadByIDs(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+	assert.NoError(t, songDB.Create(ctx, starlight))
+
+	songs, err := songDB.ReadByIDs(ctx, []uuid.UUID{hysteria.ID, uuid.New(), starlight.ID})
+	assert.NoError(t, err)
+	assert.Len(t, songs, 2)
+}
+
+func TestSongDB_ReadAllWithFilter_WithoutText(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	assert.NoError(t, songDB.Create(ctx, hysteria))
+
+	songs, err := songDB.ReadAllWithFilter(ctx, &domain.SongFilter{WithoutText: true}, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, "Hysteria", songs[0].Name)
+		assert.Empty(t, songs[0].Text)
+		assert.Nil(t, songs[0].Lyrics)
+	}
+
+	songs, err = songDB.ReadAllAfter(ctx, &domain.SongFilter{WithoutText: true}, nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Empty(t, songs[0].Text)
+	}
+}
+
+func TestSongDB_Update_ErrorPaths(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", ReleaseDate: time.Now()}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", ReleaseDate: time.Now()}
+	seedSongs(t, ctx, conn, hysteria, starlight)
+
+	// Переименование в существующую песню нарушает уникальный индекс
+	renamed := *starlight
+	renamed.Name = "HYSTERIA"
+	err := songDB.Update(ctx, &domain.SongInfo{ID: starlight.ID}, &renamed)
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+
+	// Ссылка на несуществующий альбом нарушает внешний ключ
+	withAlbum := *starlight
+	albumID := uuid.New()
+	withAlbum.AlbumID = &albumID
+	err = songDB.Update(ctx, &domain.SongInfo{ID: starlight.ID}, &withAlbum)
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+
+	// Неудачные изменения не меняют песню
+	song, err := songDB.Read(ctx, &domain.SongInfo{ID: starlight.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "Starlight", song.Name)
+	assert.Equal(t, starlight.Version, song.Version)
+
+	// Песня из другой библиотеки не находится
+	other := domain.WithLibraryID(ctx, uuid.New())
+	err = songDB.Update(other, &domain.SongInfo{ID: hysteria.ID}, hysteria)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestSongDB_Delete_NotFound(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", ReleaseDate: time.Now()}
+	seedSongs(t, ctx, conn, hysteria)
+
+	assert.ErrorIs(t, songDB.Delete(ctx, &domain.SongInfo{ID: uuid.New()}), domain.ErrSongNotFound)
+
+	// Повторное удаление тоже не находит песню
+	assert.NoError(t, songDB.Delete(ctx, &domain.SongInfo{ID: hysteria.ID}))
+	assert.ErrorIs(t, songDB.Delete(ctx, &domain.SongInfo{ID: hysteria.ID}), domain.ErrSongNotFound)
+}
+
+func TestSongDB_ReadAllWithFilter_Combinations(t *testing.T) {
+	conn, teardown := setupPostgresForSongs(t)
+	defer teardown()
+
+	songDB := NewPostgres(conn)
+	ctx := context.Background()
+
+	explicit, clean := true, false
+	hysteria := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Genre: "Alternative Rock", Duration: 227 * time.Second,
+		Explicit: &clean, ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)}
+	starlight := &domain.Song{Name: "Starlight", Group: "Muse", Text: "Far away", Genre: "Alternative Rock", Duration: 240 * time.Second,
+		ReleaseDate: time.Date(2006, 9, 4, 0, 0, 0, 0, time.UTC)}
+	creep := &domain.Song{Name: "Creep", Group: "Radiohead", Text: "You're so very special", Genre: "Alternative Rock", Duration: 238 * time.Second,
+		Explicit: &explicit, ReleaseDate: time.Date(1992, 9, 21, 0, 0, 0, 0, time.UTC)}
+	seedSongs(t, ctx, conn, hysteria, starlight, creep)
+	seedTags(t, ctx, conn, hysteria, "rock", "live")
+	seedTags(t, ctx, conn, creep, "rock")
+
+	tests := []struct {
+		name   string
+		filter *domain.SongFilter
+		want   []uuid.UUID
+	}{
+		{
+			name:   "группа и диапазон дат",
+			filter: &domain.SongFilter{Group: "muse", ReleasedFrom: time.Date(2004, 1, 1, 0, 0, 0, 0, time.UTC)},
+			want:   []uuid.UUID{starlight.ID},
+		},
+		{
+			name:   "жанр без explicit и длительность",
+			filter: &domain.SongFilter{Genre: "alternative rock", ExcludeExplicit: true, MinDuration: 230 * time.Second},
+			want:   []uuid.UUID{starlight.ID},
+		},
+		{
+			name:   "все теги и текст",
+			filter: &domain.SongFilter{Tags: []string{"rock", "live"}, Text: "bugging"},
+			want:   []uuid.UUID{hysteria.ID},
+		},
+		{
+			name:   "любой тег и explicit",
+			filter: &domain.SongFilter{Tags: []string{"rock", "live"}, TagMode: domain.TagModeAny, Explicit: &explicit},
+			want:   []uuid.UUID{creep.ID},
+		},
+		{
+			name:   "запрос и группа без совпадений",
+			filter: &domain.SongFilter{Query: "special", Group: "muse"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, err := songDB.ReadAllWithFilter(ctx, tt.filter, 0, 0)
+			assert.NoError(t, err)
+
+			var ids []uuid.UUID
+			for _, song := range songs {
+				ids = append(ids, song.ID)
+			}
+			assert.ElementsMatch(t, tt.want, ids)
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sqlPart is a fragment of a statement with its parameters marked by ?
+type sqlPart struct {
+	sql  string
+	args []any
+}
+
+// selectBuilder assembles a SELECT statement. Parts mark their parameters
+// with ?, which SQL numbers $1, $2, ... in the order they appear in the
+// statement, so conditions can be added in any order without tracking
+// parameter indexes. A literal question mark is written as ??.
+type selectBuilder struct {
+	with    *sqlPart
+	columns sqlPart
+	from    []sqlPart
+	where   []sqlPart
+	groupBy string
+	orderBy *sqlPart
+	limit   *sqlPart
+	suffix  string
+}
+
+// newSelect starts a SELECT of the columns, which can have parameters of
+// their own like "ts_rank(document, query, ?)"
+func newSelect(columns string, args ...any) *selectBuilder {
+	return &selectBuilder{columns: sqlPart{sql: columns, args: args}}
+}
+
+// With prefixes the statement with a WITH clause, written out including
+// its WITH keyword
+func (b *selectBuilder) With(cte string, args ...any) *selectBuilder {
+	b.with = &sqlPart{sql: cte, args: args}
+	return b
+}
+
+// From sets the table the rows are selected from, it may be written with
+// parameters like a function call "websearch_to_tsquery('simple', ?)"
+func (b *selectBuilder) From(table string, args ...any) *selectBuilder {
+	b.from = []sqlPart{{sql: table, args: args}}
+	return b
+}
+
+// FromSelect selects from the rows of a subquery under alias
+func (b *selectBuilder) FromSelect(query *selectBuilder, alias string) *selectBuilder {
+	part := query.part()
+	b.from = []sqlPart{{sql: "(" + part.sql + ") AS " + alias, args: part.args}}
+	return b
+}
+
+// Join adds a join clause, written out including its JOIN keyword
+func (b *selectBuilder) Join(join string, args ...any) *selectBuilder {
+	b.from = append(b.from, sqlPart{sql: join, args: args})
+	return b
+}
+
+// Where adds a condition, the conditions are joined with AND
+func (b *selectBuilder) Where(condition string, args ...any) *selectBuilder {
+	b.where = append(b.where, sqlPart{sql: condition, args: args})
+	return b
+}
+
+func (b *selectBuilder) GroupBy(columns string) *selectBuilder {
+	b.groupBy = columns
+	return b
+}
+
+// OrderBy sets the order of the rows, which can have parameters like
+// "lower(name) = lower(?) DESC"
+func (b *selectBuilder) OrderBy(order string, args ...any) *selectBuilder {
+	b.orderBy = &sqlPart{sql: order, args: args}
+	return b
+}
+
+// Limit returns at most limit rows
+func (b *selectBuilder) Limit(limit int) *selectBuilder {
+	b.limit = &sqlPart{sql: "LIMIT ?", args: []any{limit}}
+	return b
+}
+
+// Page returns limit rows starting at offset, a zero limit returns all rows
+func (b *selectBuilder) Page(limit, offset int) *selectBuilder {
+	if limit == 0 {
+		b.limit = nil
+		return b
+	}
+	b.limit = &sqlPart{sql: "LIMIT ? OFFSET ?", args: []any{limit, offset}}
+	return b
+}
+
+// Suffix adds a clause at the end of the statement, like FOR UPDATE
+func (b *selectBuilder) Suffix(suffix string) *selectBuilder {
+	b.suffix = suffix
+	return b
+}
+
+// SQL returns the statement with numbered parameters and their values
+func (b *selectBuilder) SQL() (string, []any) {
+	return b.part().SQL()
+}
+
+// Exists returns a statement selecting whether the query has any rows
+func (b *selectBuilder) Exists() (string, []any) {
+	part := b.part()
+	return newQuery("SELECT EXISTS ("+part.sql+")", part.args...).SQL()
+}
+
+func (b *selectBuilder) part() sqlPart {
+	var w partWriter
+	if b.with != nil {
+		w.write(sqlPart{sql: b.with.sql + " ", args: b.with.args})
+	}
+	w.write(sqlPart{sql: "SELECT " + b.columns.sql, args: b.columns.args})
+	for i, from := range b.from {
+		if i == 0 {
+			w.write(sqlPart{sql: " FROM " + from.sql, args: from.args})
+			continue
+		}
+		w.write(sqlPart{sql: " " + from.sql, args: from.args})
+	}
+	w.join(" WHERE ", b.where, " AND ")
+	if b.groupBy != "" {
+		w.write(sqlPart{sql: " GROUP BY " + b.groupBy})
+	}
+	if b.orderBy != nil {
+		w.write(sqlPart{sql: " ORDER BY " + b.orderBy.sql, args: b.orderBy.args})
+	}
+	if b.limit != nil {
+		w.write(sqlPart{sql: " " + b.limit.sql, args: b.limit.args})
+	}
+	if b.suffix != "" {
+		w.write(sqlPart{sql: " " + b.suffix})
+	}
+	return w.part()
+}
+
+// updateBuilder assembles an UPDATE statement, its parts mark parameters
+// like the ones of selectBuilder
+type updateBuilder struct {
+	with      *sqlPart
+	table     string
+	sets      []sqlPart
+	from      *sqlPart
+	where     []sqlPart
+	returning string
+}
+
+func newUpdate(table string) *updateBuilder {
+	return &updateBuilder{table: table}
+}
+
+// With prefixes the statement with a WITH clause, written out including
+// its WITH keyword
+func (b *updateBuilder) With(cte string, args ...any) *updateBuilder {
+	b.with = &sqlPart{sql: cte, args: args}
+	return b
+}
+
+// Set adds an assignment like "name = ?"
+func (b *updateBuilder) Set(assignment string, args ...any) *updateBuilder {
+	b.sets = append(b.sets, sqlPart{sql: assignment, args: args})
+	return b
+}
+
+// From joins the rows of a table, like a CTE of the statement
+func (b *updateBuilder) From(table string) *updateBuilder {
+	b.from = &sqlPart{sql: table}
+	return b
+}
+
+// FromSelect joins the rows of a subquery under alias
+func (b *updateBuilder) FromSelect(query *selectBuilder, alias string) *updateBuilder {
+	part := query.part()
+	b.from = &sqlPart{sql: "(" + part.sql + ") AS " + alias, args: part.args}
+	return b
+}
+
+// Where adds a condition, the conditions are joined with AND
+func (b *updateBuilder) Where(condition string, args ...any) *updateBuilder {
+	b.where = append(b.where, sqlPart{sql: condition, args: args})
+	return b
+}
+
+func (b *updateBuilder) Returning(columns string) *updateBuilder {
+	b.returning = columns
+	return b
+}
+
+// SQL returns the statement with numbered parameters and their values
+func (b *updateBuilder) SQL() (string, []any) {
+	var w partWriter
+	if b.with != nil {
+		w.write(sqlPart{sql: b.with.sql + " ", args: b.with.args})
+	}
+	w.write(sqlPart{sql: "UPDATE " + b.table})
+	w.join(" SET ", b.sets, ", ")
+	if b.from != nil {
+		w.write(sqlPart{sql: " FROM " + b.from.sql, args: b.from.args})
+	}
+	w.join(" WHERE ", b.where, " AND ")
+	if b.returning != "" {
+		w.write(sqlPart{sql: " RETURNING " + b.returning})
+	}
+	return w.part().SQL()
+}
+
+// insertBuilder assembles an INSERT statement, its parts mark parameters
+// like the ones of selectBuilder
+type insertBuilder struct {
+	with       *sqlPart
+	table      string
+	columns    []string
+	values     []sqlPart
+	query      *sqlPart
+	onConflict *sqlPart
+	returning  string
+}
+
+func newInsert(table string) *insertBuilder {
+	return &insertBuilder{table: table}
+}
+
+// With prefixes the statement with a WITH clause, written out including
+// its WITH keyword
+func (b *insertBuilder) With(cte string, args ...any) *insertBuilder {
+	b.with = &sqlPart{sql: cte, args: args}
+	return b
+}
+
+// Value inserts the value into the column
+func (b *insertBuilder) Value(column string, value any) *insertBuilder {
+	return b.ValueExpr(column, "?", value)
+}
+
+// ValueExpr inserts the result of an expression like "?::vector" into the
+// column
+func (b *insertBuilder) ValueExpr(column, expr string, args ...any) *insertBuilder {
+	b.columns = append(b.columns, column)
+	b.values = append(b.values, sqlPart{sql: expr, args: args})
+	return b
+}
+
+// Select inserts the rows of a query into the columns instead of values,
+// without columns the rows fill all columns of the table
+func (b *insertBuilder) Select(query *selectBuilder, columns ...string) *insertBuilder {
+	part := query.part()
+	b.columns = columns
+	b.query = &part
+	return b
+}
+
+// OnConflict sets the action taken on a conflict, written out after ON
+// CONFLICT like "(id) DO NOTHING"
+func (b *insertBuilder) OnConflict(action string, args ...any) *insertBuilder {
+	b.onConflict = &sqlPart{sql: action, args: args}
+	return b
+}
+
+func (b *insertBuilder) Returning(columns string) *insertBuilder {
+	b.returning = columns
+	return b
+}
+
+// SQL returns the statement with numbered parameters and their values
+func (b *insertBuilder) SQL() (string, []any) {
+	var w partWriter
+	if b.with != nil {
+		w.write(sqlPart{sql: b.with.sql + " ", args: b.with.args})
+	}
+	w.write(sqlPart{sql: "INSERT INTO " + b.table})
+	if len(b.columns) > 0 {
+		w.write(sqlPart{sql: " (" + strings.Join(b.columns, ", ") + ")"})
+	}
+	if b.query != nil {
+		w.write(sqlPart{sql: " " + b.query.sql, args: b.query.args})
+	} else {
+		w.write(sqlPart{sql: " VALUES ("})
+		w.join("", b.values, ", ")
+		w.write(sqlPart{sql: ")"})
+	}
+	if b.onConflict != nil {
+		w.write(sqlPart{sql: " ON CONFLICT " + b.onConflict.sql, args: b.onConflict.args})
+	}
+	if b.returning != "" {
+		w.write(sqlPart{sql: " RETURNING " + b.returning})
+	}
+	return w.part().SQL()
+}
+
+// deleteBuilder assembles a DELETE statement, its parts mark parameters
+// like the ones of selectBuilder
+type deleteBuilder struct {
+	with      *sqlPart
+	table     string
+	where     []sqlPart
+	returning string
+}
+
+func newDelete(table string) *deleteBuilder {
+	return &deleteBuilder{table: table}
+}
+
+// With prefixes the statement with a WITH clause, written out including
+// its WITH keyword
+func (b *deleteBuilder) With(cte string, args ...any) *deleteBuilder {
+	b.with = &sqlPart{sql: cte, args: args}
+	return b
+}
+
+// Where adds a condition, the conditions are joined with AND
+func (b *deleteBuilder) Where(condition string, args ...any) *deleteBuilder {
+	b.where = append(b.where, sqlPart{sql: condition, args: args})
+	return b
+}
+
+func (b *deleteBuilder) Returning(columns string) *deleteBuilder {
+	b.returning = columns
+	return b
+}
+
+// SQL returns the statement with numbered parameters and their values
+func (b *deleteBuilder) SQL() (string, []any) {
+	var w partWriter
+	if b.with != nil {
+		w.write(sqlPart{sql: b.with.sql + " ", args: b.with.args})
+	}
+	w.write(sqlPart{sql: "DELETE FROM " + b.table})
+	w.join(" WHERE ", b.where, " AND ")
+	if b.returning != "" {
+		w.write(sqlPart{sql: " RETURNING " + b.returning})
+	}
+	return w.part().SQL()
+}
+
+// newQuery returns a statement written out in full, for the ones the
+// builders don't cover like unions. Its parameters are marked like the ones
+// of selectBuilder.
+func newQuery(sql string, args ...any) sqlPart {
+	return sqlPart{sql: sql, args: args}
+}
+
+// SQL returns the statement with numbered parameters and their values
+func (p sqlPart) SQL() (string, []any) {
+	return numberParams(p.sql), p.args
+}
+
+// partWriter concatenates parts keeping their parameters in the order of
+// their placeholders
+type partWriter struct {
+	sql  strings.Builder
+	args []any
+}
+
+func (w *partWriter) write(part sqlPart) {
+	w.sql.WriteString(part.sql)
+	w.args = append(w.args, part.args...)
+}
+
+func (w *partWriter) join(keyword string, parts []sqlPart, separator string) {
+	for i, part := range parts {
+		if i == 0 {
+			w.sql.WriteString(keyword)
+		} else {
+			w.sql.WriteString(separator)
+		}
+		w.write(part)
+	}
+}
+
+func (w *partWriter) part() sqlPart {
+	return sqlPart{sql: w.sql.String(), args: w.args}
+}
+
+// numberParams replaces the ? placeholders with $1, $2, ... and ?? with a
+// literal question mark
+func numberParams(sql string) string {
+	var numbered strings.Builder
+	numbered.Grow(len(sql) + 8)
+
+	param := 0
+	for i := 0; i < len(sql); i++ {
+		if sql[i] != '?' {
+			numbered.WriteByte(sql[i])
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			numbered.WriteByte('?')
+			i++
+			continue
+		}
+		param++
+		numbered.WriteByte('$')
+		numbered.WriteString(strconv.Itoa(param))
+	}
+	return numbered.String()
+}
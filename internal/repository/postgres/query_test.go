@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *selectBuilder
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:    "без условий",
+			query:   newSelect("id").From("songs"),
+			wantSQL: "SELECT id FROM songs",
+		},
+		{
+			name: "условия нумеруются по порядку",
+			query: newSelect("id").From("songs").
+				Where("name = ?", "Hysteria").
+				Where("explicit IS NOT TRUE").
+				Where("(created_at, id) < (?, ?)", "2024-10-14", "id"),
+			wantSQL:  "SELECT id FROM songs WHERE name = $1 AND explicit IS NOT TRUE AND (created_at, id) < ($2, $3)",
+			wantArgs: []any{"Hysteria", "2024-10-14", "id"},
+		},
+		{
+			name: "параметры соединения идут раньше условий",
+			query: newSelect("artists.name, count(*)").From("artists").
+				Where("artists.name ILIKE ?", "%muse%").
+				Join("JOIN songs ON songs.artist_id = artists.id AND songs.library_id = ?", "library").
+				GroupBy("artists.name").
+				OrderBy("artists.name").
+				Page(10, 20),
+			wantSQL:  "SELECT artists.name, count(*) FROM artists JOIN songs ON songs.artist_id = artists.id AND songs.library_id = $1 WHERE artists.name ILIKE $2 GROUP BY artists.name ORDER BY artists.name LIMIT $3 OFFSET $4",
+			wantArgs: []any{"library", "%muse%", 10, 20},
+		},
+		{
+			name:    "нулевой лимит возвращает все строки",
+			query:   newSelect("id").From("songs").Page(0, 20),
+			wantSQL: "SELECT id FROM songs",
+		},
+		{
+			name:     "экранированный вопросительный знак",
+			query:    newSelect("id").From("songs").Where("metadata ?? 'genre' AND genre = ?", "rock").Limit(1).Suffix("FOR UPDATE"),
+			wantSQL:  "SELECT id FROM songs WHERE metadata ? 'genre' AND genre = $1 LIMIT $2 FOR UPDATE",
+			wantArgs: []any{"rock", 1},
+		},
+		{
+			name: "параметры WITH, столбцов и порядка",
+			query: newSelect("id, similarity(name, ?) AS score", "hysteria").
+				With("WITH target AS (SELECT id FROM songs WHERE id = ?)", "target").
+				From("songs").
+				Where("id <> ?", "target").
+				OrderBy("score DESC, md5(id::text || ?)", "seed"),
+			wantSQL:  "WITH target AS (SELECT id FROM songs WHERE id = $1) SELECT id, similarity(name, $2) AS score FROM songs WHERE id <> $3 ORDER BY score DESC, md5(id::text || $4)",
+			wantArgs: []any{"target", "hysteria", "target", "seed"},
+		},
+		{
+			name: "выборка из подзапроса",
+			query: newSelect("id, rank").
+				FromSelect(newSelect("id, ts_rank(document, query) AS rank").From("songs, websearch_to_tsquery('simple', ?) AS query", "muse").Limit(10), "matches").
+				Where("rank > ?", 0.1),
+			wantSQL:  "SELECT id, rank FROM (SELECT id, ts_rank(document, query) AS rank FROM songs, websearch_to_tsquery('simple', $1) AS query LIMIT $2) AS matches WHERE rank > $3",
+			wantArgs: []any{"muse", 10, 0.1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query.SQL()
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSelectBuilder_Exists(t *testing.T) {
+	sql, args := newSelect("1").From("songs").Where("id = ?", "id").Where("library_id = ?", "library").Exists()
+
+	assert.Equal(t, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1 AND library_id = $2)", sql)
+	assert.Equal(t, []any{"id", "library"}, args)
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	previous := newSelect("id").From("songs").Where("genre = ?", "rock").Suffix("FOR UPDATE")
+
+	// Параметры подзапроса нумеруются после параметров SET и WITH
+	sql, args := newUpdate("songs").
+		With("WITH artist AS (SELECT id FROM artists WHERE name = ?)", "Muse").
+		Set("genre = ?", "alternative").
+		Set("version = songs.version + 1").
+		FromSelect(previous, "previous").
+		Where("songs.id = previous.id").
+		Returning("songs.id").
+		SQL()
+
+	assert.Equal(t, "WITH artist AS (SELECT id FROM artists WHERE name = $1) UPDATE songs SET genre = $2, version = songs.version + 1 "+
+		"FROM (SELECT id FROM songs WHERE genre = $3 FOR UPDATE) AS previous WHERE songs.id = previous.id RETURNING songs.id", sql)
+	assert.Equal(t, []any{"Muse", "alternative", "rock"}, args)
+}
+
+func TestInsertBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *insertBuilder
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "значения",
+			query: newInsert("song_audio").
+				Value("song_id", "id").
+				Value("format", "mp3").
+				ValueExpr("uploaded_at", "CURRENT_TIMESTAMP").
+				OnConflict("(song_id) DO UPDATE SET format = EXCLUDED.format").
+				Returning("uploaded_at"),
+			wantSQL:  "INSERT INTO song_audio (song_id, format, uploaded_at) VALUES ($1, $2, CURRENT_TIMESTAMP) ON CONFLICT (song_id) DO UPDATE SET format = EXCLUDED.format RETURNING uploaded_at",
+			wantArgs: []any{"id", "mp3"},
+		},
+		{
+			name: "строки запроса",
+			query: newInsert("songs").
+				With("WITH artist AS (INSERT INTO artists (name) VALUES (?) RETURNING id)", "Muse").
+				Select(newSelect("?, artist.id", "Hysteria").From("artist"), "name", "artist_id").
+				OnConflict("DO NOTHING"),
+			wantSQL:  "WITH artist AS (INSERT INTO artists (name) VALUES ($1) RETURNING id) INSERT INTO songs (name, artist_id) SELECT $2, artist.id FROM artist ON CONFLICT DO NOTHING",
+			wantArgs: []any{"Muse", "Hysteria"},
+		},
+		{
+			name:     "строки во все столбцы",
+			query:    newInsert("songs").Select(newSelect("*").From("jsonb_populate_recordset(NULL::songs, ?)", "[]")),
+			wantSQL:  "INSERT INTO songs SELECT * FROM jsonb_populate_recordset(NULL::songs, $1)",
+			wantArgs: []any{"[]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query.SQL()
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestDeleteBuilder(t *testing.T) {
+	sql, args := newDelete("tags").
+		With("WITH removed AS (DELETE FROM song_tags WHERE song_id = ? RETURNING tag_id)", "id").
+		Where("id IN (SELECT tag_id FROM removed)").
+		Where("NOT EXISTS (SELECT 1 FROM song_tags WHERE tag_id = tags.id AND song_id <> ?)", "id").
+		Returning("id").
+		SQL()
+
+	assert.Equal(t, "WITH removed AS (DELETE FROM song_tags WHERE song_id = $1 RETURNING tag_id) DELETE FROM tags "+
+		"WHERE id IN (SELECT tag_id FROM removed) AND NOT EXISTS (SELECT 1 FROM song_tags WHERE tag_id = tags.id AND song_id <> $2) RETURNING id", sql)
+	assert.Equal(t, []any{"id", "id"}, args)
+}
+
+func TestQuery(t *testing.T) {
+	sql, args := newQuery("SELECT name FROM songs WHERE name LIKE ? UNION ALL SELECT name FROM albums WHERE title LIKE ? LIMIT ?", "a%", "a%", 5).SQL()
+
+	assert.Equal(t, "SELECT name FROM songs WHERE name LIKE $1 UNION ALL SELECT name FROM albums WHERE title LIKE $2 LIMIT $3", sql)
+	assert.Equal(t, []any{"a%", "a%", 5}, args)
+}
+
+func TestListSongsQuery(t *testing.T) {
+	library := uuid.MustParse("8f14e45f-ceea-467f-a8e7-1d5c6c7e5a2b")
+	ctx := domain.WithLibraryID(context.Background(), library)
+	released := time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        *domain.SongFilter
+		limit, offset int
+		wantSQL       string
+		wantArgs      []any
+	}{
+		{
+			name:     "пустой фильтр без пагинации",
+			filter:   &domain.SongFilter{},
+			wantSQL:  "SELECT " + songColumns + " FROM songs WHERE library_id = $1",
+			wantArgs: []any{library},
+		},
+		{
+			name:     "подстроки и пагинация",
+			filter:   &domain.SongFilter{Name: "hyst", Group: "muse", Text: "bugging", WithoutText: true},
+			limit:    10,
+			offset:   20,
+			wantSQL:  "SELECT " + songSummaryColumns + " FROM songs WHERE normalize_name(name) LIKE normalize_name($1) ESCAPE '\\' AND normalize_name(group_name) LIKE normalize_name($2) ESCAPE '\\' AND text ILIKE $3 ESCAPE '\\' AND library_id = $4 ORDER BY created_at DESC LIMIT $5 OFFSET $6",
+			wantArgs: []any{"%hyst%", "%muse%", "%bugging%", library, 10, 20},
+		},
+		{
+			name:     "подстановочные знаки в подстроках ищутся буквально",
+			filter:   &domain.SongFilter{Text: `100%_\`, Link: "my_song", Album: "50%"},
+			wantSQL:  "SELECT " + songColumns + " FROM songs WHERE text ILIKE $1 ESCAPE '\\' AND link ILIKE $2 ESCAPE '\\' AND album ILIKE $3 ESCAPE '\\' AND library_id = $4",
+			wantArgs: []any{`%100\%\_\\%`, `%my\_song%`, `%50\%%`, library},
+		},
+		{
+			name:     "диапазон дат и популярность",
+			filter:   &domain.SongFilter{ReleasedFrom: released, ReleasedTo: released, ExcludeArchived: true, Sort: domain.SortByPopularity},
+			wantSQL:  "SELECT " + songColumns + " FROM songs WHERE status <> 'archived' AND release_date >= $1 AND release_date > '0001-01-01' AND release_date <= $2 AND library_id = $3 ORDER BY favorites_count DESC, created_at DESC",
+			wantArgs: []any{released, released, library},
+		},
+		{
+			name:     "минимальная оценка и сортировка по оценке",
+			filter:   &domain.SongFilter{MinRating: 4, Sort: domain.SortByRating},
+			limit:    5,
+			wantSQL:  "SELECT " + songColumns + " FROM songs WHERE rating_average >= $1 AND library_id = $2 ORDER BY rating_average DESC, ratings_count DESC, created_at DESC LIMIT $3 OFFSET $4",
+			wantArgs: []any{4.0, library, 5, 0},
+		},
+		{
+			name:   "все теги",
+			filter: &domain.SongFilter{Tags: []string{"rock", "live"}, MaxDuration: time.Minute},
+			wantSQL: "SELECT " + songColumns + ` FROM songs WHERE duration_ms BETWEEN 1 AND $1 AND id IN (SELECT song_tags.song_id
+				  FROM song_tags JOIN tags ON tags.id = song_tags.tag_id
+				  WHERE tags.name = ANY($2) AND tags.library_id = $3 GROUP BY song_tags.song_id HAVING count(*) = $4) AND library_id = $5`,
+			wantArgs: []any{int64(60000), []string{"rock", "live"}, library, 2, library},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := listSongsQuery(ctx, tt.filter, tt.limit, tt.offset).SQL()
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSongsAfterQuery(t *testing.T) {
+	library := uuid.New()
+	ctx := domain.WithLibraryID(context.Background(), library)
+	cursor := &domain.SongCursor{CreatedAt: time.Date(2024, 10, 14, 23, 36, 29, 0, time.UTC), ID: uuid.New()}
+
+	sql, args := songsAfterQuery(ctx, &domain.SongFilter{Genre: "rock"}, cursor, 21).SQL()
+	assert.Equal(t, "SELECT "+songColumns+" FROM songs WHERE lower(genre) = lower($1) AND library_id = $2 AND (created_at, id) < ($3, $4) ORDER BY created_at DESC, id DESC LIMIT $5", sql)
+	assert.Equal(t, []any{"rock", library, cursor.CreatedAt, cursor.ID, 21}, args)
+}
+
+func TestBulkUpdateQuery(t *testing.T) {
+	library := uuid.New()
+	ctx := domain.WithLibraryID(context.Background(), library)
+	group, explicit := "Radiohead", true
+
+	sql, args := bulkUpdateQuery(ctx, &domain.SongFilter{Group: "radio"}, &domain.SongChanges{Group: &group, Explicit: &explicit}).SQL()
+
+	// Новая группа передаётся и в CTE исполнителя библиотеки, и в SET
+	assert.Contains(t, sql, "INSERT INTO artists (name, library_id) VALUES ($1, $2)")
+	assert.Contains(t, sql, "UPDATE songs SET updated_at = now(), version = songs.version + 1, group_name = $3, artist_id = (SELECT id FROM artist), explicit = $4, locked_fields = songs.locked_fields | $5")
+	assert.Contains(t, sql, "WHERE normalize_name(group_name) LIKE normalize_name($6) ESCAPE '\\' AND library_id = $7 FOR UPDATE) AS previous WHERE songs.id = previous.id RETURNING ")
+	assert.Equal(t, []any{group, library, group, explicit, (&domain.SongChanges{Group: &group, Explicit: &explicit}).Fields(), "%radio%", library}, args)
+}
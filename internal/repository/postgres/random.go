@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReadRandom returns a random song matching the non-empty fields of filter
+func (p *Postgres) ReadRandom(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error) {
+	const op = "repository.SongDB.ReadRandom"
+
+	query, params := filterSongs(ctx, newSelect(songColumns).From("songs"), filter).
+		OrderBy("random()").
+		Limit(1).
+		SQL()
+
+	var song domain.Song
+	if err := scanSong(p.readConn(ctx).QueryRow(ctx, query, params...), &song); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &song, nil
+}
+
+// ReadSeeded returns the first song ordered by the MD5 hash of its ID and
+// seed. The same seed picks the same song as long as it exists, deleting or
+// archiving it moves the pick to the next one.
+func (p *Postgres) ReadSeeded(ctx context.Context, seed string) (*domain.Song, error) {
+	const op = "repository.SongDB.ReadSeeded"
+
+	query, params := newSelect(songColumns).From("songs").
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Where("status <> 'archived'").
+		OrderBy("md5(id::text || ?), id", seed).
+		Limit(1).
+		SQL()
+
+	var song domain.Song
+	if err := scanSong(p.readConn(ctx).QueryRow(ctx, query, params...), &song); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &song, nil
+}
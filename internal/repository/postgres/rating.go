@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// recomputeRating sets the rating summary of the song from its ratings and
+// returns it
+func recomputeRating(songID uuid.UUID) (string, []any) {
+	summary := newSelect("count(*) AS count, COALESCE(avg(rating), 0)::DOUBLE PRECISION AS average").
+		From("ratings").
+		Where("song_id = ?", songID)
+
+	return newUpdate("songs").
+		Set("ratings_count = summary.count").
+		Set("rating_average = summary.average").
+		FromSelect(summary, "summary").
+		Where("songs.id = ?", songID).
+		Returning("songs.rating_average, songs.ratings_count").
+		SQL()
+}
+
+// SetRating saves the rating the user gives a song of the library, replacing
+// the previous one, and recomputes the rating summary of the song into rating.
+func (p *Postgres) SetRating(ctx context.Context, rating *domain.Rating) error {
+	const op = "repository.RatingDB.SetRating"
+
+	rating.RatedAt = time.Now()
+	return p.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.lockRatedSong(ctx, op, rating.SongID); err != nil {
+			return err
+		}
+
+		query, params := newInsert("ratings").
+			Value("user_id", rating.UserID).
+			Value("song_id", rating.SongID).
+			Value("rating", rating.Value).
+			Value("rated_at", rating.RatedAt).
+			OnConflict("(user_id, song_id) DO UPDATE SET rating = EXCLUDED.rating, rated_at = EXCLUDED.rated_at").
+			SQL()
+		if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		query, params = recomputeRating(rating.SongID)
+		err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&rating.Average, &rating.Count)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	})
+}
+
+// RemoveRating drops the rating of the user for a song and returns the
+// recomputed rating summary of the song. Removing a rating the user didn't
+// give is a no-op.
+func (p *Postgres) RemoveRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "repository.RatingDB.RemoveRating"
+
+	rating := &domain.Rating{UserID: userID, SongID: songID}
+	err := p.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.lockRatedSong(ctx, op, songID); err != nil {
+			return err
+		}
+
+		query, params := newDelete("ratings").
+			Where("user_id = ?", userID).
+			Where("song_id = ?", songID).
+			SQL()
+		if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		query, params = recomputeRating(songID)
+		err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&rating.Average, &rating.Count)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rating, nil
+}
+
+// ReadRating returns the rating the user gave a song of the library with the
+// rating summary of the song, the value is zero if the user didn't rate it.
+func (p *Postgres) ReadRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "repository.RatingDB.ReadRating"
+
+	query, params := newSelect("COALESCE(ratings.rating, 0), ratings.rated_at, songs.rating_average, songs.ratings_count").
+		From("songs").
+		Join("LEFT JOIN ratings ON ratings.song_id = songs.id AND ratings.user_id = ?", userID).
+		Where("songs.id = ?", songID).
+		Where("songs.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+
+	rating := &domain.Rating{UserID: userID, SongID: songID}
+	var ratedAt *time.Time
+	err := p.conn(ctx).QueryRow(ctx, query, params...).
+		Scan(&rating.Value, &ratedAt, &rating.Average, &rating.Count)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if ratedAt != nil {
+		rating.RatedAt = *ratedAt
+	}
+
+	return rating, nil
+}
+
+// lockRatedSong locks the song of the library ctx is scoped to until the
+// transaction ends, concurrent rating changes of the song recompute its
+// summary one after another. It returns ErrSongNotFound, wrapped with op,
+// for songs of other libraries.
+func (p *Postgres) lockRatedSong(ctx context.Context, op string, songID uuid.UUID) error {
+	query, params := newSelect("id").From("songs").
+		Where("id = ?", songID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Suffix("FOR UPDATE").
+		SQL()
+
+	var id uuid.UUID
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaCooldown is how long a replica that failed to connect is skipped
+const replicaCooldown = 10 * time.Second
+
+type replica struct {
+	pool *pgxpool.Pool
+	// downUntil is the unix nano time until which the replica is skipped
+	downUntil atomic.Int64
+}
+
+func (r *replica) available(now time.Time) bool {
+	return now.UnixNano() >= r.downUntil.Load()
+}
+
+func (r *replica) markDown(now time.Time) {
+	r.downUntil.Store(now.Add(replicaCooldown).UnixNano())
+}
+
+// readConn returns the connection for queries that tolerate replication lag.
+// Inside a transaction it is the transaction, otherwise the next available
+// replica falling back to the primary. Without replicas it is the primary.
+func (p *Postgres) readConn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	now := time.Now()
+	for range p.replicas {
+		r := p.replicas[p.nextReplica.Add(1)%uint32(len(p.replicas))]
+		if r.available(now) {
+			return &fallbackQuerier{replica: r, primary: p.db}
+		}
+	}
+
+	return p.db
+}
+
+// fallbackQuerier runs a query on a replica and repeats it on the primary
+// when the replica can't be reached. The replica is then skipped for a while.
+type fallbackQuerier struct {
+	replica *replica
+	primary querier
+}
+
+func (q *fallbackQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tag, err := q.replica.pool.Exec(ctx, sql, args...)
+	if q.unavailable(ctx, err) {
+		return q.primary.Exec(ctx, sql, args...)
+	}
+	return tag, err
+}
+
+func (q *fallbackQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := q.replica.pool.Query(ctx, sql, args...)
+	if q.unavailable(ctx, err) {
+		return q.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+func (q *fallbackQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &fallbackRow{q: q, ctx: ctx, sql: sql, args: args}
+}
+
+// unavailable reports whether err means the replica is down and marks it so
+func (q *fallbackQuerier) unavailable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+		return false
+	}
+	q.replica.markDown(time.Now())
+	return true
+}
+
+// fallbackRow defers the query to Scan, where pgx reports the errors of QueryRow
+type fallbackRow struct {
+	q    *fallbackQuerier
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (r *fallbackRow) Scan(dest ...any) error {
+	err := r.q.replica.pool.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	if r.q.unavailable(r.ctx, err) {
+		return r.q.primary.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	}
+	return err
+}
+
+// isConnectionError reports whether err happened before the server could
+// answer, so running the query elsewhere is safe
+func isConnectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) || pgconn.SafeToRetry(err)
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// revisionColumns lists the columns scanned by scanRevision, in order
+const revisionColumns = `song_id, revision, name, group_name, text, link, release_date, album_id, created_at`
+
+// CreateRevision keeps the state of a song before it is updated. The
+// revision number is the version of that state.
+func (p *Postgres) CreateRevision(ctx context.Context, song *domain.Song) error {
+	const op = "repository.RevisionDB.CreateRevision"
+
+	query, params := newInsert("song_revisions").
+		Value("song_id", song.ID).
+		Value("revision", song.Version).
+		Value("name", song.Name).
+		Value("group_name", song.Group).
+		Value("text", song.Text).
+		Value("link", song.Link).
+		Value("release_date", song.ReleaseDate).
+		Value("album_id", song.AlbumID).
+		OnConflict("(song_id, revision) DO NOTHING").
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadRevisions returns the previous states of a song, newest first
+func (p *Postgres) ReadRevisions(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.SongRevision, error) {
+	const op = "repository.RevisionDB.ReadRevisions"
+
+	query, params := newSelect(revisionColumns).
+		From("song_revisions").
+		Where("song_id = ?", songID).
+		OrderBy("revision DESC").
+		Page(limit, offset).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.SongRevision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+func (p *Postgres) ReadRevision(ctx context.Context, songID uuid.UUID, revision int) (*domain.SongRevision, error) {
+	const op = "repository.RevisionDB.ReadRevision"
+
+	query, params := newSelect(revisionColumns).From("song_revisions").
+		Where("song_id = ?", songID).
+		Where("revision = ?", revision).
+		SQL()
+
+	found, err := scanRevision(p.conn(ctx).QueryRow(ctx, query, params...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrRevisionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return found, nil
+}
+
+func scanRevision(row pgx.Row) (*domain.SongRevision, error) {
+	revision := domain.SongRevision{Song: &domain.Song{}}
+	song := revision.Song
+
+	err := row.Scan(
+		&song.ID, &revision.Revision, &song.Name, &song.Group, &song.Text,
+		&song.Link, &song.ReleaseDate, &song.AlbumID, &revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	song.Version = revision.Revision
+	return &revision, nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strings"
+)
+
+// searchDocument is the text searched by SearchSongs, it must stay the same
+// as the expression of idx_songs_search
+const searchDocument = `setweight(to_tsvector('simple', name), 'A') ||
+				setweight(to_tsvector('simple', group_name), 'B') ||
+				setweight(to_tsvector('simple', coalesce(text, '')), 'D')`
+
+// fragmentDelimiter separates the snippets returned by ts_headline
+const fragmentDelimiter = "\x1f"
+
+// SearchSongs returns the songs matching the web search query (quoted
+// phrases, or, -word), most relevant first. Ranks are normalized to [0, 1)
+// and up to snippets fragments of the lyrics are highlighted for the songs
+// of the page only. excludeExplicit drops the songs flagged explicit,
+// archived songs are left out.
+func (p *Postgres) SearchSongs(ctx context.Context, query string, excludeExplicit bool, snippets, limit, offset int) ([]*domain.SearchResult, error) {
+	const op = "repository.SongDB.SearchSongs"
+
+	matches := newSelect("songs.*, query, ts_rank_cd("+searchDocument+", query, 32) AS rank").
+		From("songs, websearch_to_tsquery('simple', ?) AS query", query).
+		Where("("+searchDocument+") @@ query").
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+	if excludeExplicit {
+		matches.Where("explicit IS NOT TRUE")
+	}
+	matches.Where("status <> 'archived'").
+		OrderBy("rank DESC, created_at DESC, id").
+		Page(limit, offset)
+
+	// the lyrics are only highlighted for the songs of the page
+	headline := newSelect(songColumns + ", rank, ''")
+	if snippets > 0 {
+		options := fmt.Sprintf(
+			"StartSel=%s, StopSel=%s, MaxFragments=%d, MaxWords=20, MinWords=5, FragmentDelimiter=%s",
+			domain.HighlightStart, domain.HighlightEnd, snippets, fragmentDelimiter,
+		)
+		headline = newSelect(songColumns+", rank, ts_headline('simple', coalesce(text, ''), query, ?)", options)
+	}
+	sql, params := headline.FromSelect(matches, "matches").
+		OrderBy("rank DESC, created_at DESC, id").
+		SQL()
+
+	rows, err := p.readConn(ctx).Query(ctx, sql, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var results []*domain.SearchResult
+	for rows.Next() {
+		result := domain.SearchResult{Song: &domain.Song{}}
+		var headline string
+		if err := rows.Scan(append(songFields(result.Song), &result.Rank, &headline)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result.Snippets = splitHeadline(headline, snippets)
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// splitHeadline splits the fragments of ts_headline, dropping the ones
+// without a match: when only the name or the group matched, ts_headline
+// returns the beginning of the lyrics
+func splitHeadline(headline string, snippets int) []string {
+	var fragments []string
+	for _, fragment := range strings.Split(headline, fragmentDelimiter) {
+		fragment = strings.TrimSpace(fragment)
+		if strings.Contains(fragment, domain.HighlightStart) && len(fragments) < snippets {
+			fragments = append(fragments, fragment)
+		}
+	}
+	return fragments
+}
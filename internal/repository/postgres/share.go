@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const shareColumns = `id, user_id, library_id, song_id, playlist_id, expires_at, created_at, revoked_at`
+
+// CreateShare saves a share of the user in the library of the context
+func (p *Postgres) CreateShare(ctx context.Context, share *domain.Share) error {
+	const op = "repository.ShareDB.CreateShare"
+
+	share.ID = uuid.New()
+	share.LibraryID = domain.LibraryIDFromContext(ctx)
+	share.CreatedAt = time.Now()
+
+	query, params := newInsert("shares").
+		Value("id", share.ID).
+		Value("user_id", share.UserID).
+		Value("library_id", share.LibraryID).
+		Value("song_id", share.SongID).
+		Value("playlist_id", share.PlaylistID).
+		Value("expires_at", share.ExpiresAt).
+		Value("created_at", share.CreatedAt).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadShare returns a share by ID in any library, shares are opened without
+// a library or user
+func (p *Postgres) ReadShare(ctx context.Context, id uuid.UUID) (*domain.Share, error) {
+	const op = "repository.ShareDB.ReadShare"
+
+	query, params := newSelect(shareColumns).From("shares").Where("id = ?", id).SQL()
+
+	var share domain.Share
+	if err := scanShare(p.conn(ctx).QueryRow(ctx, query, params...), &share); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrShareNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &share, nil
+}
+
+// ReadShares returns the shares of the user in the library of the context
+// including revoked and expired ones, newest first
+func (p *Postgres) ReadShares(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error) {
+	const op = "repository.ShareDB.ReadShares"
+
+	query, params := newSelect(shareColumns).From("shares").
+		Where("user_id = ?", userID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("created_at DESC, id").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var shares []*domain.Share
+	for rows.Next() {
+		var share domain.Share
+		if err := scanShare(rows, &share); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		shares = append(shares, &share)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shares, nil
+}
+
+// RevokeShare marks a share of the user revoked at revokedAt, a share
+// revoked before keeps its time
+func (p *Postgres) RevokeShare(ctx context.Context, userID, id uuid.UUID, revokedAt time.Time) (*domain.Share, error) {
+	const op = "repository.ShareDB.RevokeShare"
+
+	query, params := newUpdate("shares").
+		Set("revoked_at = COALESCE(revoked_at, ?)", revokedAt).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		Returning(shareColumns).
+		SQL()
+
+	var share domain.Share
+	err := scanShare(p.conn(ctx).QueryRow(ctx, query, params...), &share)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrShareNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &share, nil
+}
+
+func scanShare(row pgx.Row, share *domain.Share) error {
+	return row.Scan(
+		&share.ID, &share.UserID, &share.LibraryID, &share.SongID, &share.PlaylistID,
+		&share.ExpiresAt, &share.CreatedAt, &share.RevokedAt,
+	)
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// similarScore scores a song against the lyrics, group and genre, its
+// parameters in this order, and the tags of a song with the weights of
+// domain. The tags are taken from the target_tags CTE.
+var similarScore = fmt.Sprintf(`%g * similarity(coalesce(text, ''), ?) +
+					%g * (normalize_name(group_name) = normalize_name(?))::int +
+					%g * (genre <> '' AND lower(genre) = lower(?))::int +
+					%g * coalesce((SELECT count(*) FROM song_tags
+						WHERE song_tags.song_id = songs.id AND song_tags.tag_id IN (SELECT tag_id FROM target_tags))::float
+						/ NULLIF((SELECT count(*) FROM target_tags), 0), 0)`,
+	domain.SimilarTextWeight, domain.SimilarGroupWeight, domain.SimilarGenreWeight, domain.SimilarTagsWeight,
+)
+
+// similarQuery scores every other song of the library against song
+func similarQuery(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) *selectBuilder {
+	candidates := newSelect("songs.*, "+similarScore+" AS score", song.Text, song.Group, song.Genre).
+		From("songs").
+		Where("id <> ?", song.ID).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx))
+	if excludeExplicit {
+		candidates.Where("explicit IS NOT TRUE")
+	}
+	candidates.Where("status <> 'archived'")
+
+	return newSelect(songColumns+", score").
+		With("WITH target_tags AS (SELECT tag_id FROM song_tags WHERE song_id = ?)", song.ID).
+		FromSelect(candidates, "candidates").
+		Where("score > 0").
+		OrderBy("score DESC, created_at DESC, id").
+		Limit(limit)
+}
+
+// ReadSimilar returns up to limit songs of the library most like song,
+// excludeExplicit drops the songs flagged explicit, archived songs are left
+// out
+func (p *Postgres) ReadSimilar(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error) {
+	const op = "repository.SongDB.ReadSimilar"
+
+	query, params := similarQuery(ctx, song, excludeExplicit, limit).SQL()
+
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var similar []*domain.SimilarSong
+	for rows.Next() {
+		result := domain.SimilarSong{Song: &domain.Song{}}
+		if err := rows.Scan(append(songFields(result.Song), &result.Score)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		similar = append(similar, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return similar, nil
+}
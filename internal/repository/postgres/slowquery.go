@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// explainable are the statements EXPLAIN accepts, it plans them without
+// running them
+var explainable = []string{"SELECT", "WITH", "INSERT", "UPDATE", "DELETE"}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql  string
+	args []any
+	at   time.Time
+}
+
+// SlowQueryTracer logs queries taking at least Threshold with their SQL,
+// redacted parameters and duration, and counts them. With Explain set the
+// plan of a slow query is fetched from DB in the background and logged
+// too, one plan at a time so a burst of slow queries doesn't pile more on
+// the database.
+type SlowQueryTracer struct {
+	Threshold time.Duration
+	Explain   bool
+	// ExplainTimeout limits fetching a plan
+	ExplainTimeout time.Duration
+	// DB runs EXPLAIN, it is set once the pool is created
+	DB *pgxpool.Pool
+
+	slow       atomic.Int64
+	explained  atomic.Int64
+	explaining atomic.Bool
+
+	log *slog.Logger
+}
+
+func NewSlowQueryTracer(threshold time.Duration, explain bool, explainTimeout time.Duration, log *slog.Logger) *SlowQueryTracer {
+	return &SlowQueryTracer{
+		Threshold:      threshold,
+		Explain:        explain,
+		ExplainTimeout: explainTimeout,
+		log:            log.With(slog.String("component", "repository/slow_query")),
+	}
+}
+
+func (t *SlowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, &queryStart{sql: data.SQL, args: data.Args, at: time.Now()})
+}
+
+func (t *SlowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(*queryStart)
+	if !ok {
+		return
+	}
+	duration := time.Since(start.at)
+	if duration < t.Threshold || strings.HasPrefix(strings.TrimSpace(start.sql), "EXPLAIN") {
+		return
+	}
+
+	t.slow.Add(1)
+	log := t.log.With(
+		sl.RequestID(ctx),
+		slog.String("sql", compactSQL(start.sql)),
+		slog.Any("args", redactArgs(start.args)),
+		slog.Duration("duration", duration),
+	)
+	if data.Err != nil {
+		log = log.With(sl.Err(data.Err))
+	}
+	log.Warn("slow query")
+
+	if t.Explain && t.DB != nil && explainableSQL(start.sql) && t.explaining.CompareAndSwap(false, true) {
+		go func() {
+			defer t.explaining.Store(false)
+			t.explain(log, start)
+		}()
+	}
+}
+
+// explain logs the plan of a slow query
+func (t *SlowQueryTracer) explain(log *slog.Logger, start *queryStart) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.ExplainTimeout)
+	defer cancel()
+
+	rows, err := t.DB.Query(ctx, "EXPLAIN "+start.sql, start.args...)
+	if err != nil {
+		log.Warn("failed to explain slow query", sl.Err(err))
+		return
+	}
+	lines, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		log.Warn("failed to explain slow query", sl.Err(err))
+		return
+	}
+
+	t.explained.Add(1)
+	log.Info("slow query plan", slog.String("plan", strings.Join(lines, "\n")))
+}
+
+// Stats returns the number of slow queries and plans fetched since the start
+func (t *SlowQueryTracer) Stats() map[string]int64 {
+	return map[string]int64{
+		"slow":      t.slow.Load(),
+		"explained": t.explained.Load(),
+	}
+}
+
+func explainableSQL(sql string) bool {
+	sql = strings.ToUpper(strings.TrimSpace(sql))
+	for _, statement := range explainable {
+		if strings.HasPrefix(sql, statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactSQL collapses the indentation of a query to fit a log line
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// redactArgs describes query parameters without the values that may hold
+// user data, like names and texts, keeping the ones that help to reproduce
+// a query plan, like numbers, IDs and dates
+func redactArgs(args []any) []string {
+	redacted := make([]string, 0, len(args))
+	for i, arg := range args {
+		var value string
+		switch arg := arg.(type) {
+		case nil:
+			value = "NULL"
+		case bool, int, int16, int32, int64, float32, float64, uuid.UUID, time.Time, time.Duration:
+			value = fmt.Sprint(arg)
+		case string:
+			value = fmt.Sprintf("<string, %d bytes>", len(arg))
+		case []byte:
+			value = fmt.Sprintf("<bytes, %d bytes>", len(arg))
+		default:
+			value = fmt.Sprintf("<%T>", arg)
+		}
+		redacted = append(redacted, fmt.Sprintf("$%d=%s", i+1, value))
+	}
+	return redacted
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowQueryTracer(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		sql       string
+		wantSlow  int64
+	}{
+		{name: "медленный запрос", threshold: 0, sql: "SELECT * FROM songs", wantSlow: 1},
+		{name: "быстрый запрос", threshold: time.Hour, sql: "SELECT * FROM songs", wantSlow: 0},
+		{name: "план запроса не считается", threshold: 0, sql: "EXPLAIN SELECT * FROM songs", wantSlow: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := NewSlowQueryTracer(tt.threshold, true, time.Second, slog.New(slogdiscard.NewDiscardHandler()))
+
+			// Без пула план не запрашивается
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: tt.sql})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+			assert.Equal(t, map[string]int64{"slow": tt.wantSlow, "explained": 0}, tracer.Stats())
+		})
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	id := uuid.MustParse("8f14e45f-ceea-467f-a8e7-1d5c6c7e5a2b")
+
+	// Строки скрываются, числа, идентификаторы и NULL сохраняются
+	assert.Equal(t, []string{
+		"$1=<string, 8 bytes>",
+		"$2=20",
+		"$3=8f14e45f-ceea-467f-a8e7-1d5c6c7e5a2b",
+		"$4=NULL",
+		"$5=<[]string>",
+	}, redactArgs([]any{"Hysteria", 20, id, nil, []string{"rock"}}))
+}
+
+func TestExplainableSQL(t *testing.T) {
+	assert.True(t, explainableSQL("\n\t\t\tselect id FROM songs"))
+	assert.True(t, explainableSQL(numberParams(upsertArtist)+" INSERT INTO songs"))
+	assert.False(t, explainableSQL("BEGIN"))
+	assert.False(t, explainableSQL("COPY songs TO STDOUT"))
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+)
+
+// ReadLibraryStats returns the totals of the library ctx is scoped to and the number of songs
+// created in every day since since that has any. The weekly counts are left
+// to the caller.
+func (p *Postgres) ReadLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error) {
+	const op = "repository.SongDB.ReadLibraryStats"
+
+	var stats domain.LibraryStats
+
+	query, params := newSelect(`count(*),
+			  count(DISTINCT lower(group_name)),
+			  coalesce(avg(char_length(text)) FILTER (WHERE text <> ''), 0),
+			  count(*) FILTER (WHERE text = ''),
+			  count(*) FILTER (WHERE coalesce(link, '') = '')`).
+		From("songs").
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		SQL()
+	err := p.readConn(ctx).QueryRow(ctx, query, params...).Scan(
+		&stats.Songs, &stats.Groups, &stats.AvgTextLength, &stats.MissingText, &stats.MissingLink,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, params = newSelect("date_trunc('day', created_at) AS day, count(*)").
+		From("songs").
+		Where("created_at >= ?", since).
+		Where("library_id = ?", domain.LibraryIDFromContext(ctx)).
+		GroupBy("day").
+		OrderBy("day").
+		SQL()
+	rows, err := p.readConn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day domain.DateCount
+		if err := rows.Scan(&day.Date, &day.Songs); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		stats.AddedPerDay = append(stats.AddedPerDay, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &stats, nil
+}
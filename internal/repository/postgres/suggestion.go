@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+)
+
+// ReadSuggestions returns up to limit song and group names starting with the
+// lower-cased query or similar to it by trigrams, best matches first. Groups
+// are suggested once however many songs they have.
+func (p *Postgres) ReadSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	const op = "repository.SongDB.ReadSuggestions"
+
+	// both CTEs take the prefix pattern, the query and the library as
+	// parameters, in the order they appear
+	prefix := likeEscaper.Replace(query) + "%"
+	library := domain.LibraryIDFromContext(ctx)
+	matches := []any{prefix, query, library, prefix, query}
+	sql, params := newQuery(`WITH song_matches AS (
+				SELECT 'song' AS kind, name AS text, group_name,
+				CASE WHEN lower(name) LIKE ? ESCAPE '\' THEN 1 ELSE similarity(lower(name), ?) END AS score
+				FROM songs
+				WHERE library_id = ? AND (lower(name) LIKE ? ESCAPE '\' OR lower(name) % ?)
+			), group_matches AS (
+				SELECT 'group' AS kind, min(group_name) AS text, '' AS group_name,
+				CASE WHEN lower(group_name) LIKE ? ESCAPE '\' THEN 1 ELSE similarity(lower(group_name), ?) END AS score
+				FROM songs
+				WHERE library_id = ? AND (lower(group_name) LIKE ? ESCAPE '\' OR lower(group_name) % ?)
+				GROUP BY lower(group_name)
+			)
+			SELECT kind, text, group_name, score FROM song_matches
+			UNION ALL
+			SELECT kind, text, group_name, score FROM group_matches
+			ORDER BY score DESC, text, kind, group_name
+			LIMIT ?`, append(append(matches, matches...), limit)...).SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, sql, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var suggestions []*domain.Suggestion
+	for rows.Next() {
+		var suggestion domain.Suggestion
+		if err := rows.Scan(&suggestion.Kind, &suggestion.Text, &suggestion.Group, &suggestion.Score); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AddTags attaches tags to a song of the library, creating the tags that are
+// new. Tags the song already has are skipped. The tags must be normalized and
+// distinct.
+func (p *Postgres) AddTags(ctx context.Context, songID uuid.UUID, tags []string) error {
+	const op = "repository.TagDB.AddTags"
+
+	// The no-op update makes RETURNING yield the id for tags that already exist
+	query, params := newInsert("song_tags").
+		With(`WITH tag AS (
+				  INSERT INTO tags (name, library_id) SELECT unnest(?::text[]), ?
+				  ON CONFLICT (library_id, name) DO UPDATE SET name = EXCLUDED.name
+				  RETURNING id
+			  )`, tags, domain.LibraryIDFromContext(ctx)).
+		Select(newSelect("?, tag.id", songID).From("tag"), "song_id", "tag_id").
+		OnConflict("DO NOTHING").
+		SQL()
+
+	if err := p.songExists(ctx, op, songID); err != nil {
+		return err
+	}
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // Код ошибки для нарушения внешнего ключа
+			return fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveTag detaches a tag of the library from a song and drops the tag once
+// no song has it. Removing a tag the song doesn't have is a no-op.
+func (p *Postgres) RemoveTag(ctx context.Context, songID uuid.UUID, tag string) error {
+	const op = "repository.TagDB.RemoveTag"
+
+	// The outer statement sees song_tags as it was before the removal
+	query, params := newDelete("tags").
+		With(`WITH removed AS (
+				  DELETE FROM song_tags
+				  WHERE song_id = ? AND tag_id = (SELECT id FROM tags WHERE name = ? AND library_id = ?)
+				  RETURNING tag_id
+			  )`, songID, tag, domain.LibraryIDFromContext(ctx)).
+		Where("id IN (SELECT tag_id FROM removed)").
+		Where("NOT EXISTS (SELECT 1 FROM song_tags WHERE tag_id = tags.id AND song_id <> ?)", songID).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReadSongTags returns the tags of a song of the library in alphabetical order
+func (p *Postgres) ReadSongTags(ctx context.Context, songID uuid.UUID) ([]string, error) {
+	const op = "repository.TagDB.ReadSongTags"
+
+	query, params := newSelect("tags.name").From("tags").
+		Join("JOIN song_tags ON song_tags.tag_id = tags.id").
+		Where("song_tags.song_id = ?", songID).
+		Where("tags.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		OrderBy("tags.name").
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// ReadTags returns the tags with the number of songs of the library they are
+// attached to, most used first
+func (p *Postgres) ReadTags(ctx context.Context, limit, offset int) ([]*domain.Tag, error) {
+	const op = "repository.TagDB.ReadTags"
+
+	query, params := newSelect("tags.name, count(*)").
+		From("tags").
+		Join("JOIN song_tags ON song_tags.tag_id = tags.id").
+		Where("tags.library_id = ?", domain.LibraryIDFromContext(ctx)).
+		GroupBy("tags.name").
+		OrderBy("count(*) DESC, tags.name").
+		Page(limit, offset).
+		SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		var tag domain.Tag
+		if err := rows.Scan(&tag.Name, &tag.Songs); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, &tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
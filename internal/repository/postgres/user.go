@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const userColumns = `id, role, updated_at`
+
+// UpsertUser assigns the role to the user, creating the user if needed
+func (p *Postgres) UpsertUser(ctx context.Context, user *domain.User) error {
+	const op = "repository.UserDB.UpsertUser"
+
+	user.UpdatedAt = time.Now()
+
+	query, params := newInsert("users").
+		Value("id", user.ID).
+		Value("role", user.Role).
+		Value("updated_at", user.UpdatedAt).
+		OnConflict("(id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at").
+		SQL()
+
+	if _, err := p.conn(ctx).Exec(ctx, query, params...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	const op = "repository.UserDB.ReadUser"
+
+	query, params := newSelect(userColumns).From("users").Where("id = ?", id).SQL()
+
+	var user domain.User
+	err := scanUser(p.conn(ctx).QueryRow(ctx, query, params...), &user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// ReadAllUsers returns the users with a role, most recently changed first
+func (p *Postgres) ReadAllUsers(ctx context.Context) ([]*domain.User, error) {
+	const op = "repository.UserDB.ReadAllUsers"
+
+	query, params := newSelect(userColumns).From("users").OrderBy("updated_at DESC, id").SQL()
+
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (p *Postgres) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.UserDB.DeleteUser"
+
+	query, params := newDelete("users").Where("id = ?", id).SQL()
+
+	tag, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func scanUser(row pgx.Row, user *domain.User) error {
+	return row.Scan(&user.ID, &user.Role, &user.UpdatedAt)
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const webhookColumns = `id, url, secret, events, created_at, updated_at`
+
+func (p *Postgres) CreateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	const op = "repository.WebhookDB.CreateWebhook"
+
+	hook.ID = uuid.New()
+	hook.CreatedAt = time.Now()
+	hook.UpdatedAt = time.Now()
+
+	query, params := newInsert("webhooks").
+		Value("id", hook.ID).
+		Value("url", hook.URL).
+		Value("secret", hook.Secret).
+		Value("events", eventNames(hook.Events)).
+		Value("created_at", hook.CreatedAt).
+		Value("updated_at", hook.UpdatedAt).
+		SQL()
+
+	_, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) ReadWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "repository.WebhookDB.ReadWebhook"
+
+	query, params := newSelect(webhookColumns).From("webhooks").Where("id = ?", id).SQL()
+
+	var hook domain.Webhook
+	err := scanWebhook(p.conn(ctx).QueryRow(ctx, query, params...), &hook)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &hook, nil
+}
+
+func (p *Postgres) ReadAllWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	const op = "repository.WebhookDB.ReadAllWebhooks"
+
+	hooks, err := p.queryWebhooks(ctx, newSelect(webhookColumns).From("webhooks").OrderBy("created_at"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hooks, nil
+}
+
+// ReadWebhooksForEvent returns the webhooks subscribed to events of the given type
+func (p *Postgres) ReadWebhooksForEvent(ctx context.Context, eventType domain.SongEventType) ([]*domain.Webhook, error) {
+	const op = "repository.WebhookDB.ReadWebhooksForEvent"
+
+	hooks, err := p.queryWebhooks(ctx, newSelect(webhookColumns).From("webhooks").
+		Where("(cardinality(events) = 0 OR ? = ANY (events))", string(eventType)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hooks, nil
+}
+
+func (p *Postgres) UpdateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	const op = "repository.WebhookDB.UpdateWebhook"
+
+	hook.UpdatedAt = time.Now()
+
+	query, params := newUpdate("webhooks").
+		Set("url = ?", hook.URL).
+		Set("secret = ?", hook.Secret).
+		Set("events = ?", eventNames(hook.Events)).
+		Set("updated_at = ?", hook.UpdatedAt).
+		Where("id = ?", hook.ID).
+		Returning("created_at").
+		SQL()
+
+	err := p.conn(ctx).QueryRow(ctx, query, params...).Scan(&hook.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.WebhookDB.DeleteWebhook"
+
+	query, params := newDelete("webhooks").Where("id = ?", id).SQL()
+
+	result, err := p.conn(ctx).Exec(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) queryWebhooks(ctx context.Context, selectHooks *selectBuilder) ([]*domain.Webhook, error) {
+	query, params := selectHooks.SQL()
+	rows, err := p.conn(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hooks []*domain.Webhook
+	for rows.Next() {
+		var hook domain.Webhook
+		if err := scanWebhook(rows, &hook); err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, &hook)
+	}
+
+	return hooks, rows.Err()
+}
+
+func scanWebhook(row pgx.Row, hook *domain.Webhook) error {
+	var events []string
+	if err := row.Scan(&hook.ID, &hook.URL, &hook.Secret, &events, &hook.CreatedAt, &hook.UpdatedAt); err != nil {
+		return err
+	}
+
+	hook.Events = make([]domain.SongEventType, 0, len(events))
+	for _, event := range events {
+		hook.Events = append(hook.Events, domain.SongEventType(event))
+	}
+
+	return nil
+}
+
+func eventNames(events []domain.SongEventType) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+	return names
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RandomDatabase interface {
+	ReadRandom(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error)
+	ReadSeeded(ctx context.Context, seed string) (*domain.Song, error)
+}
+
+// DailyPickCache keeps the ID of the song of the day by date, a miss is
+// reported as domain.ErrCacheMiss
+type DailyPickCache interface {
+	GetSongOfTheDay(ctx context.Context, day string) (uuid.UUID, error)
+	SetSongOfTheDay(ctx context.Context, day string, songID uuid.UUID, ttl time.Duration) error
+	DeleteSongOfTheDay(ctx context.Context, day string) error
+}
+
+// RandomRepository picks random songs and the song of the day, which is
+// kept in the cache until the day ends. Cache failures are logged and never
+// fail the request.
+type RandomRepository struct {
+	db    RandomDatabase
+	cache DailyPickCache
+	log   *slog.Logger
+}
+
+func NewRandomRepository(db RandomDatabase, cache DailyPickCache, log *slog.Logger) *RandomRepository {
+	return &RandomRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (r *RandomRepository) ReadRandom(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error) {
+	const op = "RandomRepository.ReadRandom"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching random song from database")
+	song, err := r.db.ReadRandom(ctx, filter)
+	if err != nil {
+		log.Error("failed to fetch random song from database", sl.Err(err))
+		return nil, err
+	}
+
+	return song, nil
+}
+
+// ReadOfTheDay returns the ID of the song of the day, picked from the
+// database by the day as seed and cached for ttl
+func (r *RandomRepository) ReadOfTheDay(ctx context.Context, day string, ttl time.Duration) (uuid.UUID, error) {
+	const op = "RandomRepository.ReadOfTheDay"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("day", day))
+
+	songID, err := r.cache.GetSongOfTheDay(ctx, day)
+	if err == nil {
+		log.Debug("song of the day found in cache")
+		return songID, nil
+	}
+	if !errors.Is(err, domain.ErrCacheMiss) {
+		log.Warn("failed to read song of the day from cache", sl.Err(err))
+	}
+
+	log.Debug("picking song of the day from database")
+	song, err := r.db.ReadSeeded(ctx, day)
+	if err != nil {
+		log.Error("failed to pick song of the day from database", sl.Err(err))
+		return uuid.Nil, err
+	}
+
+	if err := r.cache.SetSongOfTheDay(ctx, day, song.ID, ttl); err != nil {
+		log.Warn("failed to cache song of the day", sl.Err(err))
+	}
+
+	return song.ID, nil
+}
+
+// ForgetOfTheDay drops the cached song of the day, e.g. after it was deleted
+func (r *RandomRepository) ForgetOfTheDay(ctx context.Context, day string) error {
+	const op = "RandomRepository.ForgetOfTheDay"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("day", day))
+
+	if err := r.cache.DeleteSongOfTheDay(ctx, day); err != nil {
+		log.Error("failed to delete song of the day from cache", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type RatingDatabase interface {
+	SetRating(ctx context.Context, rating *domain.Rating) error
+	RemoveRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+	ReadRating(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+}
+
+type RatingRepository struct {
+	db    RatingDatabase
+	cache Cache
+	log   *slog.Logger
+}
+
+func NewRatingRepository(db RatingDatabase, cache Cache, log *slog.Logger) *RatingRepository {
+	return &RatingRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+// Set saves the rating of the user and drops the cached song, its rating summary changed.
+func (r *RatingRepository) Set(ctx context.Context, rating *domain.Rating) error {
+	const op = "RatingRepository.Set"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", rating.UserID.String()), slog.String("song_id", rating.SongID.String()))
+
+	log.Debug("saving rating in database")
+	if err := r.db.SetRating(ctx, rating); err != nil {
+		log.Error("failed to save rating in database", sl.Err(err))
+		return err
+	}
+
+	if err := r.invalidateSong(ctx, log, rating.SongID); err != nil {
+		return err
+	}
+
+	log.Debug("rating successfully saved", slog.Float64("average", rating.Average), slog.Int("count", rating.Count))
+	return nil
+}
+
+// Remove drops the rating of the user and the cached song, its rating summary changed.
+func (r *RatingRepository) Remove(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "RatingRepository.Remove"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()), slog.String("song_id", songID.String()))
+
+	log.Debug("removing rating from database")
+	rating, err := r.db.RemoveRating(ctx, userID, songID)
+	if err != nil {
+		log.Error("failed to remove rating from database", sl.Err(err))
+		return nil, err
+	}
+
+	if err := r.invalidateSong(ctx, log, songID); err != nil {
+		return nil, err
+	}
+
+	log.Debug("rating successfully removed", slog.Float64("average", rating.Average), slog.Int("count", rating.Count))
+	return rating, nil
+}
+
+func (r *RatingRepository) Read(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "RatingRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()), slog.String("song_id", songID.String()))
+
+	log.Debug("fetching rating from database")
+	rating, err := r.db.ReadRating(ctx, userID, songID)
+	if err != nil {
+		log.Error("failed to fetch rating from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("rating successfully fetched")
+	return rating, nil
+}
+
+// invalidateSong drops the cached song and bumps the library version, the
+// listings sorted or filtered by rating changed
+func (r *RatingRepository) invalidateSong(ctx context.Context, log *slog.Logger, songID uuid.UUID) error {
+	log.Debug("invalidating song in cache")
+	if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Error("failed to invalidate song in cache", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+	return nil
+}
@@ -0,0 +1,146 @@
+package redi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/dto"
+	"sync/atomic"
+)
+
+// songSchemaVersion is the version of the dto.SongDTO layout written to the
+// cache. Bump it when a field is added, renamed or changes meaning and add a
+// migration from the previous version to songMigrations if the old values
+// can be upgraded, otherwise they are invalidated on read.
+const songSchemaVersion = 2
+
+// songMigrations upgrade the fields of a cached song from the version of the
+// key to the next one
+var songMigrations = map[int]func(fields map[string]json.RawMessage) error{
+	// values cached before the envelope have the layout of version 1
+	0: func(map[string]json.RawMessage) error { return nil },
+	// version 2 adds the status, songs cached without text are still pending
+	1: func(fields map[string]json.RawMessage) error {
+		var text string
+		if raw, ok := fields["text"]; ok {
+			if err := json.Unmarshal(raw, &text); err != nil {
+				return err
+			}
+		}
+
+		status := domain.SongStatusEnriched
+		if text == "" {
+			status = domain.SongStatusPending
+		}
+		raw, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+		fields["status"] = raw
+		return nil
+	},
+}
+
+// envelope wraps a cached value with the version of its layout
+type envelope struct {
+	Version int             `json:"v"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// errUndecodable is returned for cached values that can't be read, they are
+// treated as a cache miss
+var errUndecodable = errors.New("undecodable cached value")
+
+// decodeStats counts the cached values that needed more than decoding
+type decodeStats struct {
+	migrated    atomic.Int64
+	invalidated atomic.Int64
+	malformed   atomic.Int64
+	newer       atomic.Int64
+}
+
+// DecodeStats returns the number of cached songs upgraded from an older
+// layout, invalidated because they couldn't be upgraded, deleted because
+// they were malformed and skipped because a newer build wrote them
+func (r *Redis) DecodeStats() map[string]int64 {
+	return map[string]int64{
+		"migrated":    r.decode.migrated.Load(),
+		"invalidated": r.decode.invalidated.Load(),
+		"malformed":   r.decode.malformed.Load(),
+		"newer":       r.decode.newer.Load(),
+	}
+}
+
+// encodeSong wraps the song in an envelope of the current version
+func encodeSong(song *domain.Song) ([]byte, error) {
+	data, err := json.Marshal(dto.SongToDTO(song))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Version: songSchemaVersion, Data: data})
+}
+
+// decodeSong reads a song cached under key, upgrading older layouts. Values
+// that are malformed or can't be upgraded are deleted, values of a newer
+// layout are left for the build that wrote them; both are reported as
+// errUndecodable.
+func (r *Redis) decodeSong(ctx context.Context, key, value string) (*domain.Song, error) {
+	const op = "repository.Redis.decodeSong"
+
+	var env envelope
+	if err := json.Unmarshal([]byte(value), &env); err != nil {
+		return nil, r.discard(ctx, key, &r.decode.malformed, fmt.Errorf("%s: %w: %w", op, errUndecodable, err))
+	}
+	if env.Data == nil {
+		env = envelope{Version: 0, Data: json.RawMessage(value)}
+	}
+
+	if env.Version > songSchemaVersion {
+		r.decode.newer.Add(1)
+		return nil, fmt.Errorf("%s: %w: version %d is newer than %d", op, errUndecodable, env.Version, songSchemaVersion)
+	}
+
+	data := env.Data
+	if env.Version < songSchemaVersion {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(env.Data, &fields); err != nil {
+			return nil, r.discard(ctx, key, &r.decode.malformed, fmt.Errorf("%s: %w: %w", op, errUndecodable, err))
+		}
+
+		for version := env.Version; version < songSchemaVersion; version++ {
+			migrate, ok := songMigrations[version]
+			if !ok {
+				return nil, r.discard(ctx, key, &r.decode.invalidated,
+					fmt.Errorf("%s: %w: no migration from version %d", op, errUndecodable, version))
+			}
+			if err := migrate(fields); err != nil {
+				return nil, r.discard(ctx, key, &r.decode.malformed,
+					fmt.Errorf("%s: %w: migrating version %d: %w", op, errUndecodable, version, err))
+			}
+		}
+
+		var err error
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		r.decode.migrated.Add(1)
+	}
+
+	var songDTO dto.SongDTO
+	if err := json.Unmarshal(data, &songDTO); err != nil {
+		return nil, r.discard(ctx, key, &r.decode.malformed, fmt.Errorf("%s: %w: %w", op, errUndecodable, err))
+	}
+
+	return dto.DTOToSong(&songDTO), nil
+}
+
+// discard deletes a cached value that can't be read and counts it
+func (r *Redis) discard(ctx context.Context, key string, counter *atomic.Int64, err error) error {
+	counter.Add(1)
+	if delErr := r.cache.Del(ctx, key).Err(); delErr != nil {
+		return fmt.Errorf("%w (could not delete it: %v)", err, delErr)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package redi
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// songOfTheDayKeyPrefix namespaces the songs of the day, keyed by date
+const songOfTheDayKeyPrefix = "song_of_the_day:"
+
+// songOfTheDayKey is built from the library and the date
+func songOfTheDayKey(ctx context.Context, day string) string {
+	return libraryKey(domain.LibraryIDFromContext(ctx), songOfTheDayKeyPrefix+day)
+}
+
+// GetSongOfTheDay returns the ID of the cached song of the day
+func (r *Redis) GetSongOfTheDay(ctx context.Context, day string) (uuid.UUID, error) {
+	const op = "repository.Redis.GetSongOfTheDay"
+
+	value, err := r.cache.Get(ctx, songOfTheDayKey(ctx, day)).Result()
+	if err == redis.Nil {
+		return uuid.Nil, fmt.Errorf("%s: song of the day not found in Redis cache: %w", op, domain.ErrCacheMiss)
+	} else if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: could not get song of the day from Redis: %w", op, err)
+	}
+
+	songID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: could not parse song ID: %w", op, err)
+	}
+
+	return songID, nil
+}
+
+// SetSongOfTheDay caches the ID of the song of the day for ttl
+func (r *Redis) SetSongOfTheDay(ctx context.Context, day string, songID uuid.UUID, ttl time.Duration) error {
+	const op = "repository.Redis.SetSongOfTheDay"
+
+	if err := r.cache.Set(ctx, songOfTheDayKey(ctx, day), songID.String(), ttl).Err(); err != nil {
+		return fmt.Errorf("%s: could not set song of the day in Redis: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteSongOfTheDay drops the cached song of the day
+func (r *Redis) DeleteSongOfTheDay(ctx context.Context, day string) error {
+	const op = "repository.Redis.DeleteSongOfTheDay"
+
+	if err := r.cache.Del(ctx, songOfTheDayKey(ctx, day)).Err(); err != nil {
+		return fmt.Errorf("%s: could not delete song of the day from Redis: %w", op, err)
+	}
+
+	return nil
+}
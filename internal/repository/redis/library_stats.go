@@ -0,0 +1,54 @@
+package redi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// libraryStatsKeyPrefix namespaces the library statistics, keyed by the
+// start of their daily counts
+const libraryStatsKeyPrefix = "library_stats:"
+
+func libraryStatsKey(ctx context.Context, since time.Time) string {
+	return libraryKey(domain.LibraryIDFromContext(ctx), libraryStatsKeyPrefix+since.UTC().Format(time.DateOnly))
+}
+
+// GetLibraryStats returns the cached library statistics
+func (r *Redis) GetLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error) {
+	const op = "repository.Redis.GetLibraryStats"
+
+	statsJSON, err := r.cache.Get(ctx, libraryStatsKey(ctx, since)).Result()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%s: library stats not found in Redis cache: %w", op, domain.ErrCacheMiss)
+	} else if err != nil {
+		return nil, fmt.Errorf("%s: could not get library stats from Redis: %w", op, err)
+	}
+
+	var stats domain.LibraryStats
+	if err := json.Unmarshal([]byte(statsJSON), &stats); err != nil {
+		return nil, fmt.Errorf("%s: could not unmarshal JSON into library stats: %w", op, err)
+	}
+
+	return &stats, nil
+}
+
+// SetLibraryStats caches the library statistics for ttl
+func (r *Redis) SetLibraryStats(ctx context.Context, since time.Time, stats *domain.LibraryStats, ttl time.Duration) error {
+	const op = "repository.Redis.SetLibraryStats"
+
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("%s: could not marshal library stats to JSON: %w", op, err)
+	}
+
+	if err := r.cache.Set(ctx, libraryStatsKey(ctx, since), statsJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: could not set library stats in Redis: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package redi
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// libraryModifiedKey keeps the time the songs of a library were last
+// modified in Unix nanoseconds
+const libraryModifiedKey = "library_modified"
+
+// GetLibraryModified returns the time the songs of the library were last
+// modified
+func (r *Redis) GetLibraryModified(ctx context.Context) (time.Time, error) {
+	const op = "repository.Redis.GetLibraryModified"
+
+	value, err := r.cache.Get(ctx, libraryKey(domain.LibraryIDFromContext(ctx), libraryModifiedKey)).Result()
+	if err == redis.Nil {
+		return time.Time{}, fmt.Errorf("%s: library modification time not found in Redis: %w", op, domain.ErrCacheMiss)
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("%s: could not get library modification time from Redis: %w", op, err)
+	}
+
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: could not parse library modification time: %w", op, err)
+	}
+
+	return time.Unix(0, nanos), nil
+}
+
+// SetLibraryModified records the time the songs of the library were last
+// modified, it never expires
+func (r *Redis) SetLibraryModified(ctx context.Context, at time.Time) error {
+	const op = "repository.Redis.SetLibraryModified"
+
+	key := libraryKey(domain.LibraryIDFromContext(ctx), libraryModifiedKey)
+	if err := r.cache.Set(ctx, key, at.UnixNano(), 0).Err(); err != nil {
+		return fmt.Errorf("%s: could not set library modification time in Redis: %w", op, err)
+	}
+
+	return nil
+}
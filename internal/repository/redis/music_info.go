@@ -0,0 +1,61 @@
+package redi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"songLibrary/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// musicInfoKeyPrefix namespaces cached MusicInfo responses away from songs
+const musicInfoKeyPrefix = "music_info:"
+
+// musicInfoKey is built from the normalized group and name, both escaped so
+// a colon in a name can't make two songs share a key
+func musicInfoKey(song *domain.SongInfo) string {
+	return musicInfoKeyPrefix + url.QueryEscape(normalize(song.Group)) + ":" + url.QueryEscape(normalize(song.Name))
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// GetMusicInfo returns the cached MusicInfo response for the song
+func (r *Redis) GetMusicInfo(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "repository.Redis.GetMusicInfo"
+
+	key := musicInfoKey(song)
+	songJSON, err := r.cache.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%s: music info not found in Redis cache: %w", op, domain.ErrSongNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("%s: could not get music info from Redis: %w", op, err)
+	}
+
+	details, err := r.decodeSong(ctx, key, songJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%s: music info in Redis cache can't be read: %w: %w", op, domain.ErrSongNotFound, err)
+	}
+
+	return details, nil
+}
+
+// SetMusicInfo caches the MusicInfo response for the song for ttl
+func (r *Redis) SetMusicInfo(ctx context.Context, song *domain.SongInfo, details *domain.Song, ttl time.Duration) error {
+	const op = "repository.Redis.SetMusicInfo"
+
+	songJSON, err := encodeSong(details)
+	if err != nil {
+		return fmt.Errorf("%s: could not marshal song to JSON: %w", op, err)
+	}
+
+	if err := r.cache.Set(ctx, musicInfoKey(song), songJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: could not set music info in Redis: %w", op, err)
+	}
+
+	return nil
+}
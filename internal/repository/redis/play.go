@@ -0,0 +1,72 @@
+package redi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// playsKey holds the plays recorded since the last flush as song ID -> count
+const playsKey = "plays:pending"
+
+// drainPlaysScript reads and deletes the buffered plays in one step, so plays
+// recorded during a flush end up in the next one
+var drainPlaysScript = redis.NewScript(`
+local plays = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return plays
+`)
+
+func (r *Redis) IncrPlays(ctx context.Context, songID uuid.UUID) error {
+	const op = "repository.Redis.IncrPlays"
+
+	if err := r.cache.HIncrBy(ctx, playsKey, songID.String(), 1).Err(); err != nil {
+		return fmt.Errorf("%s: could not record play in Redis: %w", op, err)
+	}
+
+	return nil
+}
+
+// DrainPlays returns the buffered plays and empties the buffer
+func (r *Redis) DrainPlays(ctx context.Context) (map[uuid.UUID]int, error) {
+	const op = "repository.Redis.DrainPlays"
+
+	values, err := drainPlaysScript.Run(ctx, r.cache, []string{playsKey}).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not drain plays from Redis: %w", op, err)
+	}
+
+	plays := make(map[uuid.UUID]int, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		songID, err := uuid.Parse(values[i])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(values[i+1])
+		if err != nil {
+			continue
+		}
+		plays[songID] += count
+	}
+
+	return plays, nil
+}
+
+// RestorePlays puts drained plays back into the buffer, e.g. after a failed flush
+func (r *Redis) RestorePlays(ctx context.Context, plays map[uuid.UUID]int) error {
+	const op = "repository.Redis.RestorePlays"
+
+	pipe := r.cache.Pipeline()
+	for songID, count := range plays {
+		pipe.HIncrBy(ctx, playsKey, songID.String(), int64(count))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: could not restore plays in Redis: %w", op, err)
+	}
+
+	return nil
+}
@@ -2,35 +2,51 @@ package redi
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"songLibrary/internal/domain"
-	"songLibrary/internal/dto"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// libraryKeyPrefix namespaces the keys of every library but the default one,
+// whose keys keep the layout from before libraries existed
+const libraryKeyPrefix = "library:"
+
+// libraryKey prefixes key with the library it is cached for
+func libraryKey(library uuid.UUID, key string) string {
+	if library == domain.DefaultLibraryID {
+		return key
+	}
+	return libraryKeyPrefix + library.String() + ":" + key
+}
+
 type Redis struct {
-	cache *redis.Client
+	cache   *redis.Client
+	songTTL time.Duration
+	decode  decodeStats
 }
 
-func NewRedis(cache *redis.Client) *Redis {
+// NewRedis keeps cached songs for songTTL, zero keeps them until they are
+// invalidated
+func NewRedis(cache *redis.Client, songTTL time.Duration) *Redis {
 	return &Redis{
-		cache: cache,
+		cache:   cache,
+		songTTL: songTTL,
 	}
 }
 
 func (r *Redis) Set(ctx context.Context, song *domain.Song) error {
 	const op = "repository.Redis.Set"
 
-	songDTO := dto.SongToDTO(song)
-	songJSON, err := json.Marshal(songDTO)
+	songJSON, err := encodeSong(song)
 	if err != nil {
 		return fmt.Errorf("%s: could not marshal song to JSON: %w", op, err)
 	}
 
-	key := songDTO.ID.String()
-	err = r.cache.Set(ctx, key, songJSON, 0).Err()
+	key := libraryKey(song.LibraryID, song.ID.String())
+	err = r.cache.Set(ctx, key, songJSON, r.songTTL).Err()
 	if err != nil {
 		return fmt.Errorf("%s: could not set song JSON in Redis: %w", op, err)
 	}
@@ -38,30 +54,39 @@ func (r *Redis) Set(ctx context.Context, song *domain.Song) error {
 	return nil
 }
 
-func (r *Redis) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+// Get returns the cached song and the time it has left to live, zero if the
+// song doesn't expire
+func (r *Redis) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, time.Duration, error) {
 	const op = "repository.Redis.Get"
 
-	key := song.ID.String()
-	songJSON, err := r.cache.Get(ctx, key).Result()
+	key := libraryKey(domain.LibraryIDFromContext(ctx), song.ID.String())
+	pipe := r.cache.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	_, _ = pipe.Exec(ctx)
+
+	songJSON, err := get.Result()
 	if err == redis.Nil {
-		return nil, fmt.Errorf("%s: song not found in Redis cache: %w", op, domain.ErrSongNotFound)
+		return nil, 0, fmt.Errorf("%s: song not found in Redis cache: %w", op, domain.ErrSongNotFound)
 	} else if err != nil {
-		return nil, fmt.Errorf("%s: could not get song from Redis: %w", op, err)
+		return nil, 0, fmt.Errorf("%s: could not get song from Redis: %w", op, err)
 	}
 
-	var targetSong *domain.Song
-	err = json.Unmarshal([]byte(songJSON), &targetSong)
+	cached, err := r.decodeSong(ctx, key, songJSON)
 	if err != nil {
-		return nil, fmt.Errorf("%s: could not unmarshal JSON into song: %w", op, err)
+		return nil, 0, fmt.Errorf("%s: song in Redis cache can't be read: %w: %w", op, domain.ErrSongNotFound, err)
 	}
 
-	return targetSong, nil
+	// PTTL reports keys without expiry and failures as negative durations
+	ttl := max(pttl.Val(), 0)
+
+	return cached, ttl, nil
 }
 
 func (r *Redis) Invalidate(ctx context.Context, song *domain.SongInfo) error {
 	const op = "repository.Redis.Invalidate"
 
-	key := song.ID.String()
+	key := libraryKey(domain.LibraryIDFromContext(ctx), song.ID.String())
 	err := r.cache.Del(ctx, key).Err()
 	if err != nil {
 		return fmt.Errorf("%s: could not delete song from Redis: %w", op, err)
@@ -14,11 +14,22 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// cachedSong is the value Set writes for the song
+func cachedSong(t *testing.T, song *domain.Song) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(dto.SongToDTO(song))
+	assert.NoError(t, err)
+	value, err := json.Marshal(envelope{Version: songSchemaVersion, Data: data})
+	assert.NoError(t, err)
+	return value
+}
+
 func TestRedis_Set_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	// Создаем тестовые данные
 	song := &domain.Song{
@@ -30,15 +41,11 @@ func TestRedis_Set_Success(t *testing.T) {
 		ReleaseDate: time.Now(),
 	}
 
-	songDTO := dto.SongToDTO(song)
-	songJSON, err := json.Marshal(songDTO)
-	assert.NoError(t, err)
-
-	// Ожидаем успешный Set запрос в Redis
-	mock.ExpectSet(songDTO.ID.String(), songJSON, 0).SetVal("OK")
+	// Песня хранится в конверте с версией схемы
+	mock.ExpectSet(song.ID.String(), cachedSong(t, song), 0).SetVal("OK")
 
 	// Вызов метода Set
-	err = r.Set(ctx, song)
+	err := r.Set(ctx, song)
 	assert.NoError(t, err)
 
 	// Проверяем все ожидания
@@ -49,7 +56,7 @@ func TestRedis_Set_Overwrite(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	// Создаем первоначальные тестовые данные
 	songOriginal := &domain.Song{
@@ -61,15 +68,11 @@ func TestRedis_Set_Overwrite(t *testing.T) {
 		ReleaseDate: time.Now(),
 	}
 
-	songDTOOriginal := dto.SongToDTO(songOriginal)
-	songJSONOriginal, err := json.Marshal(songDTOOriginal)
-	assert.NoError(t, err)
-
 	// Ожидаем успешный Set запрос для первоначальных данных в Redis
-	mock.ExpectSet(songDTOOriginal.ID.String(), songJSONOriginal, 0).SetVal("OK")
+	mock.ExpectSet(songOriginal.ID.String(), cachedSong(t, songOriginal), 0).SetVal("OK")
 
 	// Вызов метода Set для первоначальных данных
-	err = r.Set(ctx, songOriginal)
+	err := r.Set(ctx, songOriginal)
 	assert.NoError(t, err)
 
 	// Создаем новые тестовые данные для перезаписи
@@ -82,12 +85,8 @@ func TestRedis_Set_Overwrite(t *testing.T) {
 		ReleaseDate: time.Now(),
 	}
 
-	songDTOUpdated := dto.SongToDTO(songUpdated)
-	songJSONUpdated, err := json.Marshal(songDTOUpdated)
-	assert.NoError(t, err)
-
 	// Ожидаем успешный Set запрос для обновленных данных в Redis
-	mock.ExpectSet(songDTOUpdated.ID.String(), songJSONUpdated, 0).SetVal("OK")
+	mock.ExpectSet(songUpdated.ID.String(), cachedSong(t, songUpdated), 0).SetVal("OK")
 
 	// Вызов метода Set для обновленных данных (перезапись)
 	err = r.Set(ctx, songUpdated)
@@ -101,7 +100,7 @@ func TestRedis_Get_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	songID := uuid.New()
 
@@ -119,10 +118,11 @@ func TestRedis_Get_Success(t *testing.T) {
 
 	// Ожидаем успешный Get запрос в Redis
 	mock.ExpectGet(songID.String()).SetVal(string(songJSON))
+	mock.ExpectPTTL(songID.String()).SetVal(-1)
 
 	// Вызов метода Get
 	songInfo := &domain.SongInfo{ID: songID}
-	song, err := r.Get(ctx, songInfo)
+	song, _, err := r.Get(ctx, songInfo)
 	assert.NoError(t, err)
 
 	// Проверяем полученные данные
@@ -134,11 +134,45 @@ func TestRedis_Get_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestRedis_Get_KeepsTimestampsAndVersion(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songDTO := &dto.SongDTO{
+		ID:          uuid.New(),
+		Name:        "Hysteria",
+		Group:       "Muse",
+		ReleaseDate: time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Date(2024, 10, 14, 23, 36, 29, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 10, 15, 10, 0, 0, 0, time.UTC),
+		Version:     3,
+	}
+
+	songJSON, err := json.Marshal(songDTO)
+	assert.NoError(t, err)
+
+	mock.ExpectGet(songDTO.ID.String()).SetVal(string(songJSON))
+	mock.ExpectPTTL(songDTO.ID.String()).SetVal(-1)
+
+	song, _, err := r.Get(ctx, &domain.SongInfo{ID: songDTO.ID})
+	assert.NoError(t, err)
+
+	// Поля с snake_case ключами должны восстанавливаться из кэша
+	assert.True(t, songDTO.ReleaseDate.Equal(song.ReleaseDate))
+	assert.True(t, songDTO.CreatedAt.Equal(song.CreatedAt))
+	assert.True(t, songDTO.UpdatedAt.Equal(song.UpdatedAt))
+	assert.Equal(t, 3, song.Version)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRedis_Get_NotFound(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	songID := uuid.New()
 
@@ -147,7 +181,7 @@ func TestRedis_Get_NotFound(t *testing.T) {
 
 	// Вызов метода Get
 	songInfo := &domain.SongInfo{ID: songID}
-	song, err := r.Get(ctx, songInfo)
+	song, _, err := r.Get(ctx, songInfo)
 
 	// Проверяем результат
 	assert.Error(t, err)
@@ -162,31 +196,157 @@ func TestRedis_Get_UnmarshalError(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	songID := uuid.New()
 
-	// Ожидаем, что Redis вернет некорректные данные
+	// Ожидаем, что Redis вернет некорректные данные, они удаляются из кэша
 	mock.ExpectGet(songID.String()).SetVal("invalid JSON")
+	mock.ExpectPTTL(songID.String()).SetVal(-1)
+	mock.ExpectDel(songID.String()).SetVal(1)
 
 	// Вызов метода Get
 	songInfo := &domain.SongInfo{ID: songID}
-	song, err := r.Get(ctx, songInfo)
+	song, _, err := r.Get(ctx, songInfo)
 
-	// Проверяем результат
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "could not unmarshal JSON into song")
+	// Ошибка разбора считается промахом кэша и попадает в метрики
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
 	assert.Nil(t, song)
+	assert.Equal(t, int64(1), r.DecodeStats()["malformed"])
 
 	// Проверяем все ожидания
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestRedis_Get_Envelope(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Genre: "rock"}
+	mock.ExpectGet(song.ID.String()).SetVal(string(cachedSong(t, song)))
+	mock.ExpectPTTL(song.ID.String()).SetVal(-1)
+
+	cached, _, err := r.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "rock", cached.Genre)
+
+	// Значение текущей версии читается без миграций
+	assert.Equal(t, map[string]int64{"migrated": 0, "invalidated": 0, "malformed": 0, "newer": 0}, r.DecodeStats())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_SongTTL(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, time.Hour)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	mock.ExpectSet(song.ID.String(), cachedSong(t, song), time.Hour).SetVal("OK")
+	mock.ExpectGet(song.ID.String()).SetVal(string(cachedSong(t, song)))
+	mock.ExpectPTTL(song.ID.String()).SetVal(10 * time.Minute)
+
+	assert.NoError(t, r.Set(ctx, song))
+
+	// Вместе с песней возвращается оставшееся время жизни ключа
+	_, ttl, err := r.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Get_MigratesLegacyValue(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	// Значения, записанные до появления конверта, читаются как версия 1
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	legacy, err := json.Marshal(dto.SongToDTO(song))
+	assert.NoError(t, err)
+	mock.ExpectGet(song.ID.String()).SetVal(string(legacy))
+	mock.ExpectPTTL(song.ID.String()).SetVal(-1)
+
+	cached, _, err := r.Get(ctx, &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hysteria", cached.Name)
+	assert.Equal(t, int64(1), r.DecodeStats()["migrated"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Get_MigratesStatus(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	// Песни версии 1 без текста ещё ждут обогащения, с текстом считаются обогащёнными
+	pendingID, enrichedID := uuid.New(), uuid.New()
+	mock.ExpectGet(pendingID.String()).SetVal(`{"v": 1, "data": {"id": "` + pendingID.String() + `", "name": "Starlight", "text": ""}}`)
+	mock.ExpectPTTL(pendingID.String()).SetVal(-1)
+	mock.ExpectGet(enrichedID.String()).SetVal(`{"v": 1, "data": {"id": "` + enrichedID.String() + `", "name": "Hysteria", "text": "It's bugging me"}}`)
+	mock.ExpectPTTL(enrichedID.String()).SetVal(-1)
+
+	pending, _, err := r.Get(ctx, &domain.SongInfo{ID: pendingID})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SongStatusPending, pending.Status)
+
+	enriched, _, err := r.Get(ctx, &domain.SongInfo{ID: enrichedID})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SongStatusEnriched, enriched.Status)
+	assert.Equal(t, int64(2), r.DecodeStats()["migrated"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Get_InvalidatesUnmigratableValue(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	migration := songMigrations[0]
+	delete(songMigrations, 0)
+	t.Cleanup(func() { songMigrations[0] = migration })
+
+	// Без миграции устаревшее значение удаляется и считается промахом
+	songID := uuid.New()
+	mock.ExpectGet(songID.String()).SetVal(`{"id": "` + songID.String() + `", "name": "Hysteria"}`)
+	mock.ExpectPTTL(songID.String()).SetVal(-1)
+	mock.ExpectDel(songID.String()).SetVal(1)
+
+	song, _, err := r.Get(ctx, &domain.SongInfo{ID: songID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+	assert.Nil(t, song)
+	assert.Equal(t, int64(1), r.DecodeStats()["invalidated"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Get_SkipsNewerValue(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	// Значение более новой версии не удаляется: его записала новая версия сервиса
+	songID := uuid.New()
+	mock.ExpectGet(songID.String()).SetVal(`{"v": 99, "data": {"name": "Hysteria"}}`)
+	mock.ExpectPTTL(songID.String()).SetVal(-1)
+
+	song, _, err := r.Get(ctx, &domain.SongInfo{ID: songID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+	assert.Nil(t, song)
+	assert.Equal(t, int64(1), r.DecodeStats()["newer"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRedis_Invalidate_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	songID := uuid.New()
 
@@ -206,7 +366,7 @@ func TestRedis_Invalidate_Failure(t *testing.T) {
 	ctx := context.Background()
 	mockRedis, mock := redismock.NewClientMock()
 
-	r := NewRedis(mockRedis)
+	r := NewRedis(mockRedis, 0)
 
 	songID := uuid.New()
 
@@ -224,3 +384,266 @@ func TestRedis_Invalidate_Failure(t *testing.T) {
 	// Проверяем все ожидания
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRedis_IncrPlays(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songID := uuid.New()
+	mock.ExpectHIncrBy(playsKey, songID.String(), 1).SetVal(1)
+
+	err := r.IncrPlays(ctx, songID)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_MusicInfo_KeyIsNormalized(t *testing.T) {
+	// Регистр и лишние пробелы не влияют на ключ
+	a := musicInfoKey(&domain.SongInfo{Name: "Supermassive  Black Hole", Group: " Muse"})
+	b := musicInfoKey(&domain.SongInfo{Name: "supermassive black hole", Group: "MUSE"})
+	assert.Equal(t, a, b)
+
+	// Двоеточие в названии не смешивает группу и название
+	c := musicInfoKey(&domain.SongInfo{Name: "b", Group: "a:"})
+	d := musicInfoKey(&domain.SongInfo{Name: ":b", Group: "a"})
+	assert.NotEqual(t, c, d)
+}
+
+func TestRedis_SetMusicInfo(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songInfo := &domain.SongInfo{Name: "Hysteria", Group: "Muse"}
+	details := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", Source: "music_info"}
+
+	mock.ExpectSet(musicInfoKey(songInfo), cachedSong(t, details), time.Hour).SetVal("OK")
+
+	err := r.SetMusicInfo(ctx, songInfo, details, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_GetMusicInfo(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songInfo := &domain.SongInfo{Name: "Hysteria", Group: "Muse"}
+	details := &domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me...", Source: "music_info"}
+
+	mock.ExpectGet(musicInfoKey(songInfo)).SetVal(string(cachedSong(t, details)))
+
+	song, err := r.GetMusicInfo(ctx, songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, details.Text, song.Text)
+	assert.Equal(t, details.Source, song.Source)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_GetMusicInfo_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songInfo := &domain.SongInfo{Name: "Hysteria", Group: "Muse"}
+	mock.ExpectGet(musicInfoKey(songInfo)).RedisNil()
+
+	song, err := r.GetMusicInfo(ctx, songInfo)
+	assert.Nil(t, song)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Stats(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	mock.ExpectDBSize().SetVal(42)
+	mock.ExpectInfo("stats", "memory").SetVal("# Stats\r\nkeyspace_hits:30\r\nkeyspace_misses:10\r\n\r\n# Memory\r\nused_memory:1048576\r\nused_memory_human:1.00M\r\n")
+
+	stats, err := r.Stats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.CacheStats{Keys: 42, Hits: 30, Misses: 10, UsedMemory: 1048576}, stats)
+	assert.Equal(t, 0.75, stats.HitRate())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_Flush_KeepsPlays(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songID := uuid.New().String()
+
+	// Удаляются только песни, ответы провайдеров и подсказки, playsKey не затрагивается
+	mock.ExpectScan(0, cacheKeyPatterns[0], flushBatchSize).SetVal([]string{songID}, 0)
+	mock.ExpectDel(songID).SetVal(1)
+	mock.ExpectScan(0, cacheKeyPatterns[1], flushBatchSize).SetVal([]string{"music_info:muse:hysteria"}, 0)
+	mock.ExpectDel("music_info:muse:hysteria").SetVal(1)
+	mock.ExpectScan(0, cacheKeyPatterns[2], flushBatchSize).SetVal([]string{"suggest:10:hy"}, 0)
+	mock.ExpectDel("suggest:10:hy").SetVal(1)
+	mock.ExpectScan(0, cacheKeyPatterns[3], flushBatchSize).SetVal(nil, 0)
+	mock.ExpectScan(0, cacheKeyPatterns[4], flushBatchSize).SetVal(nil, 0)
+	// Время изменения библиотеки по умолчанию сбрасывается, чтобы после
+	// восстановления бэкапа If-Modified-Since не давал 304
+	mock.ExpectScan(0, cacheKeyPatterns[5], flushBatchSize).SetVal([]string{libraryModifiedKey}, 0)
+	mock.ExpectDel(libraryModifiedKey).SetVal(1)
+	mock.ExpectScan(0, cacheKeyPatterns[6], flushBatchSize).SetVal([]string{"library:" + songID + ":" + songID}, 0)
+	mock.ExpectDel("library:" + songID + ":" + songID).SetVal(1)
+
+	deleted, err := r.Flush(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_LibraryKeys(t *testing.T) {
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	library := uuid.New()
+	ctx := domain.WithLibraryID(context.Background(), library)
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", LibraryID: library}
+	prefix := "library:" + library.String() + ":"
+
+	// Ключи библиотек, кроме библиотеки по умолчанию, получают префикс
+	mock.ExpectSet(prefix+song.ID.String(), cachedSong(t, song), 0).SetVal("OK")
+	mock.ExpectDel(prefix + song.ID.String()).SetVal(1)
+	mock.ExpectGet(prefix + "suggest:10:hy").RedisNil()
+	mock.ExpectGet(prefix + "song_of_the_day:2024-05-01").RedisNil()
+
+	assert.NoError(t, r.Set(ctx, song))
+	assert.NoError(t, r.Invalidate(ctx, &domain.SongInfo{ID: song.ID}))
+	_, err := r.GetSuggestions(ctx, "hy", 10)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	_, err = r.GetSongOfTheDay(ctx, "2024-05-01")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_SetSuggestions(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	suggestions := []*domain.Suggestion{{Kind: domain.SuggestionSong, Text: "Hysteria", Group: "Muse", Score: 1}}
+	suggestionsJSON, err := json.Marshal(suggestions)
+	assert.NoError(t, err)
+
+	mock.ExpectSet("suggest:10:hy", suggestionsJSON, time.Minute).SetVal("OK")
+
+	err = r.SetSuggestions(ctx, "hy", 10, suggestions, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_GetSuggestions(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	suggestions := []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: "Muse", Score: 1}}
+	suggestionsJSON, err := json.Marshal(suggestions)
+	assert.NoError(t, err)
+
+	// Пробелы в запросе экранируются в ключе
+	mock.ExpectGet("suggest:5:muse+h").SetVal(string(suggestionsJSON))
+	mock.ExpectGet("suggest:5:mu").RedisNil()
+
+	cached, err := r.GetSuggestions(ctx, "muse h", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, suggestions, cached)
+
+	_, err = r.GetSuggestions(ctx, "mu", 5)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_SongOfTheDay(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	songID := uuid.New()
+	mock.ExpectSet("song_of_the_day:2024-05-01", songID.String(), time.Hour).SetVal("OK")
+	mock.ExpectGet("song_of_the_day:2024-05-01").SetVal(songID.String())
+	mock.ExpectDel("song_of_the_day:2024-05-01").SetVal(1)
+	mock.ExpectGet("song_of_the_day:2024-05-01").RedisNil()
+
+	assert.NoError(t, r.SetSongOfTheDay(ctx, "2024-05-01", songID, time.Hour))
+
+	cached, err := r.GetSongOfTheDay(ctx, "2024-05-01")
+	assert.NoError(t, err)
+	assert.Equal(t, songID, cached)
+
+	assert.NoError(t, r.DeleteSongOfTheDay(ctx, "2024-05-01"))
+
+	_, err = r.GetSongOfTheDay(ctx, "2024-05-01")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_LibraryStats(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	since := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	stats := &domain.LibraryStats{
+		Songs:       3,
+		Groups:      2,
+		AddedPerDay: []domain.DateCount{{Date: since, Songs: 3}},
+	}
+	statsJSON, err := json.Marshal(stats)
+	assert.NoError(t, err)
+
+	mock.ExpectSet("library_stats:2024-04-01", statsJSON, time.Minute).SetVal("OK")
+	mock.ExpectGet("library_stats:2024-04-01").SetVal(string(statsJSON))
+	mock.ExpectGet("library_stats:2024-04-02").RedisNil()
+
+	assert.NoError(t, r.SetLibraryStats(ctx, since, stats, time.Minute))
+
+	cached, err := r.GetLibraryStats(ctx, since)
+	assert.NoError(t, err)
+	assert.Equal(t, stats, cached)
+
+	_, err = r.GetLibraryStats(ctx, since.AddDate(0, 0, 1))
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_LibraryModified(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	at := time.Unix(0, 1714557600123456789)
+	mock.ExpectGet("library_modified").RedisNil()
+	mock.ExpectSet("library_modified", at.UnixNano(), 0).SetVal("OK")
+	mock.ExpectGet("library_modified").SetVal("1714557600123456789")
+
+	_, err := r.GetLibraryModified(ctx)
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+	assert.NoError(t, r.SetLibraryModified(ctx, at))
+
+	modified, err := r.GetLibraryModified(ctx)
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(modified))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,111 @@
+package redi
+
+import (
+	"context"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strconv"
+	"strings"
+)
+
+// cacheKeyPatterns match the keys holding cached data. The library
+// modification time is flushed too, so it is recorded anew once the cached
+// data may have changed underneath, e.g. by a restore. Buffered plays, song
+// writes queued for the database and rate limiter state are not a cache and
+// survive a flush.
+var cacheKeyPatterns = []string{
+	"????????-????-????-????-????????????", // songs are stored by ID
+	musicInfoKeyPrefix + "*",
+	suggestionsKeyPrefix + "*",
+	songOfTheDayKeyPrefix + "*",
+	libraryStatsKeyPrefix + "*",
+	libraryModifiedKey,
+	libraryKeyPrefix + "*", // all of the above for libraries but the default one
+}
+
+// flushBatchSize is the number of keys scanned and deleted per round trip
+const flushBatchSize = 500
+
+// Stats returns the number of keys, the hit and miss counters of the Redis
+// server and the memory it uses
+func (r *Redis) Stats(ctx context.Context) (*domain.CacheStats, error) {
+	const op = "repository.Redis.Stats"
+
+	keys, err := r.cache.DBSize(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not count keys in Redis: %w", op, err)
+	}
+
+	info, err := r.cache.Info(ctx, "stats", "memory").Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not get info from Redis: %w", op, err)
+	}
+
+	fields := parseInfo(info)
+	stats := &domain.CacheStats{Keys: keys}
+	for name, dst := range map[string]*int64{
+		"keyspace_hits":   &stats.Hits,
+		"keyspace_misses": &stats.Misses,
+		"used_memory":     &stats.UsedMemory,
+	} {
+		if *dst, err = strconv.ParseInt(fields[name], 10, 64); err != nil {
+			return nil, fmt.Errorf("%s: could not parse %s from Redis info: %w", op, name, err)
+		}
+	}
+
+	return stats, nil
+}
+
+// parseInfo splits the "name:value" lines of the INFO reply, section
+// headers and blank lines are skipped
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+// Flush deletes every cached song, MusicInfo response, suggestion, song of
+// the day, library statistics and library modification time and returns how
+// many keys were deleted
+func (r *Redis) Flush(ctx context.Context) (int64, error) {
+	const op = "repository.Redis.Flush"
+
+	var deleted int64
+	for _, pattern := range cacheKeyPatterns {
+		iter := r.cache.Scan(ctx, 0, pattern, flushBatchSize).Iterator()
+
+		batch := make([]string, 0, flushBatchSize)
+		for iter.Next(ctx) {
+			batch = append(batch, iter.Val())
+			if len(batch) == flushBatchSize {
+				n, err := r.cache.Del(ctx, batch...).Result()
+				if err != nil {
+					return deleted, fmt.Errorf("%s: could not delete keys from Redis: %w", op, err)
+				}
+				deleted += n
+				batch = batch[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, fmt.Errorf("%s: could not scan keys in Redis: %w", op, err)
+		}
+
+		if len(batch) > 0 {
+			n, err := r.cache.Del(ctx, batch...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("%s: could not delete keys from Redis: %w", op, err)
+			}
+			deleted += n
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,56 @@
+package redi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"songLibrary/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// suggestionsKeyPrefix namespaces cached suggestions away from songs
+const suggestionsKeyPrefix = "suggest:"
+
+// suggestionsKey is built from the library, the limit and the escaped query
+func suggestionsKey(ctx context.Context, query string, limit int) string {
+	return libraryKey(domain.LibraryIDFromContext(ctx), suggestionsKeyPrefix+strconv.Itoa(limit)+":"+url.QueryEscape(query))
+}
+
+// GetSuggestions returns the cached suggestions for the query
+func (r *Redis) GetSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	const op = "repository.Redis.GetSuggestions"
+
+	suggestionsJSON, err := r.cache.Get(ctx, suggestionsKey(ctx, query, limit)).Result()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%s: suggestions not found in Redis cache: %w", op, domain.ErrCacheMiss)
+	} else if err != nil {
+		return nil, fmt.Errorf("%s: could not get suggestions from Redis: %w", op, err)
+	}
+
+	var suggestions []*domain.Suggestion
+	if err := json.Unmarshal([]byte(suggestionsJSON), &suggestions); err != nil {
+		return nil, fmt.Errorf("%s: could not unmarshal JSON into suggestions: %w", op, err)
+	}
+
+	return suggestions, nil
+}
+
+// SetSuggestions caches the suggestions for the query for ttl
+func (r *Redis) SetSuggestions(ctx context.Context, query string, limit int, suggestions []*domain.Suggestion, ttl time.Duration) error {
+	const op = "repository.Redis.SetSuggestions"
+
+	suggestionsJSON, err := json.Marshal(suggestions)
+	if err != nil {
+		return fmt.Errorf("%s: could not marshal suggestions to JSON: %w", op, err)
+	}
+
+	if err := r.cache.Set(ctx, suggestionsKey(ctx, query, limit), suggestionsJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: could not set suggestions in Redis: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package redi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"songLibrary/internal/domain"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// writeBehindStream keeps the song writes that aren't in the database yet
+	writeBehindStream = "write_behind"
+	// writeBehindGroup is the consumer group of the flusher, writes it read
+	// stay pending in the group until they are acknowledged
+	writeBehindGroup    = "flusher"
+	writeBehindConsumer = "flusher"
+	// writeBehindVersion is the layout of queued writes
+	writeBehindVersion = 1
+)
+
+// queuedWrite is the layout of a write in the stream
+type queuedWrite struct {
+	Version int                   `json:"v"`
+	Op      domain.PendingWriteOp `json:"op"`
+	Song    *domain.Song          `json:"song"`
+	UserID  *uuid.UUID            `json:"user_id,omitempty"`
+}
+
+// PushWrite appends the write to the queue
+func (r *Redis) PushWrite(ctx context.Context, write *domain.PendingWrite) error {
+	const op = "repository.Redis.PushWrite"
+
+	value, err := json.Marshal(queuedWrite{Version: writeBehindVersion, Op: write.Op, Song: write.Song, UserID: write.UserID})
+	if err != nil {
+		return fmt.Errorf("%s: could not marshal write to JSON: %w", op, err)
+	}
+
+	id, err := r.cache.XAdd(ctx, &redis.XAddArgs{
+		Stream: writeBehindStream,
+		Values: map[string]any{"write": value},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("%s: could not add write to Redis stream: %w", op, err)
+	}
+	write.ID = id
+
+	return nil
+}
+
+// ClaimWrites returns up to count oldest writes that aren't acknowledged.
+// Writes claimed earlier and not acknowledged, e.g. before a restart, come
+// first, so the queue is replayed in order. Writes that can't be read are
+// returned without a song.
+func (r *Redis) ClaimWrites(ctx context.Context, count int) ([]*domain.PendingWrite, error) {
+	const op = "repository.Redis.ClaimWrites"
+
+	err := r.cache.XGroupCreateMkStream(ctx, writeBehindStream, writeBehindGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("%s: could not create consumer group: %w", op, err)
+	}
+
+	// "0" reads the claimed writes, ">" the ones never claimed
+	for _, start := range []string{"0", ">"} {
+		streams, err := r.cache.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    writeBehindGroup,
+			Consumer: writeBehindConsumer,
+			Streams:  []string{writeBehindStream, start},
+			Count:    int64(count),
+			Block:    -1,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: could not read writes from Redis stream: %w", op, err)
+		}
+
+		var writes []*domain.PendingWrite
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				writes = append(writes, decodeWrite(message))
+			}
+		}
+		if len(writes) > 0 {
+			return writes, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// AckWrite removes a flushed write from the queue
+func (r *Redis) AckWrite(ctx context.Context, write *domain.PendingWrite) error {
+	const op = "repository.Redis.AckWrite"
+
+	pipe := r.cache.Pipeline()
+	pipe.XAck(ctx, writeBehindStream, writeBehindGroup, write.ID)
+	pipe.XDel(ctx, writeBehindStream, write.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: could not acknowledge write in Redis stream: %w", op, err)
+	}
+
+	return nil
+}
+
+// decodeWrite reads a write from a stream message, the song of a write that
+// can't be read is nil
+func decodeWrite(message redis.XMessage) *domain.PendingWrite {
+	write := &domain.PendingWrite{ID: message.ID}
+
+	value, ok := message.Values["write"].(string)
+	if !ok {
+		return write
+	}
+
+	var queued queuedWrite
+	if err := json.Unmarshal([]byte(value), &queued); err != nil || queued.Version != writeBehindVersion {
+		return write
+	}
+	if queued.Op != domain.PendingCreate && queued.Op != domain.PendingUpdate {
+		return write
+	}
+
+	write.Op = queued.Op
+	write.Song = queued.Song
+	write.UserID = queued.UserID
+	return write
+}
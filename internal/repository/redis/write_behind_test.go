@@ -0,0 +1,97 @@
+package redi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"songLibrary/internal/domain"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedis_PushWrite(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	write := &domain.PendingWrite{Op: domain.PendingCreate, Song: &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}}
+	value, err := json.Marshal(queuedWrite{Version: writeBehindVersion, Op: write.Op, Song: write.Song})
+	assert.NoError(t, err)
+
+	mock.ExpectXAdd(&redis.XAddArgs{Stream: writeBehindStream, Values: map[string]any{"write": value}}).SetVal("1-0")
+
+	// Запись получает позицию в очереди
+	assert.NoError(t, r.PushWrite(ctx, write))
+	assert.Equal(t, "1-0", write.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_ClaimWrites_ReplaysPendingFirst(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Version: 1}
+	value, err := json.Marshal(queuedWrite{Version: writeBehindVersion, Op: domain.PendingUpdate, Song: song})
+	assert.NoError(t, err)
+
+	mock.ExpectXGroupCreateMkStream(writeBehindStream, writeBehindGroup, "0").SetErr(errors.New("BUSYGROUP Consumer Group name already exists"))
+	mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+		Group: writeBehindGroup, Consumer: writeBehindConsumer,
+		Streams: []string{writeBehindStream, "0"}, Count: 10, Block: -1,
+	}).SetVal([]redis.XStream{{Stream: writeBehindStream, Messages: []redis.XMessage{
+		{ID: "1-0", Values: map[string]any{"write": string(value)}},
+		{ID: "2-0", Values: map[string]any{"write": "invalid JSON"}},
+	}}})
+
+	// Неподтверждённые записи прошлого запуска читаются первыми, нечитаемые - без песни
+	writes, err := r.ClaimWrites(ctx, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, writes, 2) {
+		assert.Equal(t, "1-0", writes[0].ID)
+		assert.Equal(t, domain.PendingUpdate, writes[0].Op)
+		assert.Equal(t, song.ID, writes[0].Song.ID)
+		assert.Equal(t, "2-0", writes[1].ID)
+		assert.Nil(t, writes[1].Song)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_ClaimWrites_Empty(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	mock.ExpectXGroupCreateMkStream(writeBehindStream, writeBehindGroup, "0").SetVal("OK")
+	for _, start := range []string{"0", ">"} {
+		mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+			Group: writeBehindGroup, Consumer: writeBehindConsumer,
+			Streams: []string{writeBehindStream, start}, Count: 10, Block: -1,
+		}).SetVal([]redis.XStream{{Stream: writeBehindStream}})
+	}
+
+	writes, err := r.ClaimWrites(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, writes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedis_AckWrite(t *testing.T) {
+	ctx := context.Background()
+	mockRedis, mock := redismock.NewClientMock()
+
+	r := NewRedis(mockRedis, 0)
+
+	mock.ExpectXAck(writeBehindStream, writeBehindGroup, "1-0").SetVal(1)
+	mock.ExpectXDel(writeBehindStream, "1-0").SetVal(1)
+
+	assert.NoError(t, r.AckWrite(ctx, &domain.PendingWrite{ID: "1-0"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,110 +2,375 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"math"
+	"math/rand/v2"
 	"songLibrary/internal/domain"
 	"songLibrary/pkg/logger/sl"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 )
 
 type Database interface {
 	Create(ctx context.Context, song *domain.Song) error
 	Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error)
+	ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
 	Delete(ctx context.Context, song *domain.SongInfo) error
+	UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error)
+
+	ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error)
+	ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+	ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error)
+
+	CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error
+	CreateRevision(ctx context.Context, song *domain.Song) error
+	CreateOutboxEvent(ctx context.Context, event *domain.SongEvent) error
 
-	ReadAllWithFilter(ctx context.Context, song *domain.Song, limit, offset int) ([]*domain.Song, error)
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Cache interface {
 	Set(ctx context.Context, song *domain.Song) error
-	Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	// Get returns the cached song and the time it has left to live, zero if
+	// the song doesn't expire
+	Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, time.Duration, error)
 	Invalidate(ctx context.Context, song *domain.SongInfo) error
+
+	Stats(ctx context.Context) (*domain.CacheStats, error)
+	Flush(ctx context.Context) (int64, error)
+
+	ModifiedCache
 }
 
 type IRepository interface {
 	Create(ctx context.Context, song *domain.Song) error
 	Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error)
+	ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
 	Delete(ctx context.Context, song *domain.SongInfo) error
-
-	ReadAllWithFilter(ctx context.Context, song *domain.Song, limit, offset int) ([]*domain.Song, error)
-	CacheRecovery(ctx context.Context) error
+	UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error)
+
+	ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error)
+	ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+	ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error)
+	CacheRecovery(ctx context.Context, batchSize, limit int) (int, error)
+	CacheStats(ctx context.Context) (*domain.CacheStats, error)
+	InvalidateCache(ctx context.Context, id uuid.UUID) error
+	FlushCache(ctx context.Context) (int64, error)
+	LibraryModified(ctx context.Context) (time.Time, error)
+
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
+// minLoadTime is the assumed duration of a database read until one is measured
+const minLoadTime = 10 * time.Millisecond
+
 type Repository struct {
 	db    Database
 	cache Cache
 	log   *slog.Logger
+
+	// queue keeps the writes of the write-behind mode until they are
+	// flushed to the database, nil writes through to the database
+	queue WriteQueue
+	// flushMu keeps flushes from replaying the same claimed writes twice
+	flushMu sync.Mutex
+	flushed atomic.Int64
+	dropped atomic.Int64
+	// deletedWrites are the songs deleted while writes of them may still be
+	// queued, flushes drop these writes. Guarded by flushMu.
+	deletedWrites map[uuid.UUID]struct{}
+
+	// loads coalesces concurrent database reads of the same song, so a song
+	// missing in the cache is read and cached once
+	loads singleflight.Group
+	// misses are the loads of songs missing in the cache, each counts the
+	// readers waiting for it and is canceled when the last one is gone
+	missesMu sync.Mutex
+	misses   map[string]*missLoad
+	// earlyRefresh is the beta of the probabilistic early refresh of songs
+	// about to expire from the cache, zero disables it
+	earlyRefresh float64
+	// loadTime is the duration of the last database read in nanoseconds
+	loadTime atomic.Int64
+	random   func() float64
 }
 
-func NewRepository(db Database, cache Cache, log *slog.Logger) *Repository {
+// NewRepository writes songs behind the cache through queue, nil writes them
+// through to the database. Cached songs about to expire are refreshed with
+// the probability scaled by earlyRefresh, zero disables the early refresh.
+func NewRepository(db Database, cache Cache, queue WriteQueue, earlyRefresh float64, log *slog.Logger) *Repository {
 	return &Repository{
-		db:    db,
-		cache: cache,
-		log:   log,
+		db:           db,
+		cache:        cache,
+		queue:        queue,
+		log:          log,
+		earlyRefresh: earlyRefresh,
+		random:       rand.Float64,
+		misses:       make(map[string]*missLoad),
+
+		deletedWrites: make(map[uuid.UUID]struct{}),
 	}
 }
 
 func (r *Repository) Create(ctx context.Context, song *domain.Song) error {
 	const op = "Repository.Create"
 
-	log := r.log.With(slog.String("op", op), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
 
-	log.Debug("creating song in database")
-	err := r.db.Create(ctx, song)
-	if err != nil {
-		log.Error("failed to create song in database", sl.Err(err))
-		return err
+	if r.queue != nil {
+		return r.createBehind(ctx, log, song)
 	}
 
-	log.Debug("storing song in cache")
-	err = r.cache.Set(ctx, song)
+	err := r.writeThrough(ctx, log, &song.ID,
+		func(ctx context.Context) error {
+			return r.createInDB(ctx, log, song)
+		},
+		func(ctx context.Context) error {
+			log.Debug("storing song in cache")
+			if err := r.cache.Set(ctx, song); err != nil {
+				log.Error("failed to store song in cache", sl.Err(err))
+				return err
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		log.Error("failed to store song in cache", sl.Err(err))
 		return err
 	}
+	touchLibrary(ctx, log, r.cache)
 
 	log.Debug("song successfully created and cached")
 	return nil
 }
 
+// createInDB creates the song and records it in the audit log
+func (r *Repository) createInDB(ctx context.Context, log *slog.Logger, song *domain.Song) error {
+	log.Debug("creating song in database")
+	if err := r.db.Create(ctx, song); err != nil {
+		log.Error("failed to create song in database", sl.Err(err))
+		return err
+	}
+	return r.audit(ctx, log, domain.AuditCreate, song.ID, nil, song)
+}
+
 func (r *Repository) Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
 	const op = "Repository.Read"
 
-	log := r.log.With(slog.String("op", op), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
 
 	log.Debug("attempting to fetch song from cache")
-	targetSong, err := r.cache.Get(ctx, song)
+	targetSong, ttl, err := r.cache.Get(ctx, song)
 	if err != nil {
 		log.Warn("song not found in cache, fetching from database", sl.Err(err))
 
-		targetSong, err = r.db.Read(ctx, song)
+		loaded, err := r.loadMissing(ctx, log, song)
 		if err != nil {
-			log.Error("failed to fetch song from database", sl.Err(err))
 			return nil, err
 		}
 
-		log.Debug("storing song in cache after fetching from database")
-		err = r.cache.Set(ctx, targetSong)
+		// callers of a shared load get their own copy of the song
+		targetSong := *loaded
+		return &targetSong, nil
+	}
+
+	if r.refreshEarly(ttl) {
+		log.Debug("refreshing song in cache before it expires", slog.Duration("ttl", ttl))
+		// the refresh outlives the request, concurrent refreshes and reads of
+		// the song wait for it instead of reading the database again
+		r.loads.DoChan(loadKey(ctx, song.ID), func() (any, error) {
+			return r.load(context.WithoutCancel(ctx), log, song)
+		})
+	}
+
+	log.Debug("song successfully fetched from cache")
+	return targetSong, nil
+}
+
+// loadKey identifies the load of a song, loads of songs with the same ID in
+// other libraries aren't shared
+func loadKey(ctx context.Context, id uuid.UUID) string {
+	return domain.LibraryIDFromContext(ctx).String() + ":" + id.String()
+}
+
+// missLoad is a shared load of a song missing in the cache
+type missLoad struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	readers int
+}
+
+// loadMissing loads a song missing in the cache, sharing the load with the
+// concurrent readers of the song. The load isn't canceled with the reader
+// that started it, only when every reader waiting for it is gone, so the
+// database statement of an abandoned read doesn't run to the end.
+func (r *Repository) loadMissing(ctx context.Context, log *slog.Logger, song *domain.SongInfo) (*domain.Song, error) {
+	key := loadKey(ctx, song.ID)
+
+	for {
+		miss := r.joinMiss(ctx, key)
+		loads := r.loads.DoChan(key, func() (any, error) {
+			return r.load(miss.ctx, log, song)
+		})
+
+		select {
+		case res := <-loads:
+			r.leaveMiss(key, miss)
+			// the load was canceled by the readers that left just before
+			// this one joined it, the next attempt starts a load of its own
+			if res.Err != nil && errors.Is(res.Err, context.Canceled) && ctx.Err() == nil {
+				continue
+			}
+			if res.Err != nil {
+				return nil, res.Err
+			}
+			if res.Shared {
+				log.Debug("song fetched by a concurrent read")
+			}
+			return res.Val.(*domain.Song), nil
+		case <-ctx.Done():
+			r.leaveMiss(key, miss)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// joinMiss counts the reader in the load of the song missing in the cache,
+// starting a new one if no reader waits for it
+func (r *Repository) joinMiss(ctx context.Context, key string) *missLoad {
+	r.missesMu.Lock()
+	defer r.missesMu.Unlock()
+
+	miss, ok := r.misses[key]
+	if !ok {
+		loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		miss = &missLoad{ctx: loadCtx, cancel: cancel}
+		r.misses[key] = miss
+	}
+	miss.readers++
+	return miss
+}
+
+// leaveMiss stops counting the reader in the load, the last reader cancels it
+func (r *Repository) leaveMiss(key string, miss *missLoad) {
+	r.missesMu.Lock()
+	defer r.missesMu.Unlock()
+
+	miss.readers--
+	if miss.readers > 0 {
+		return
+	}
+	miss.cancel()
+	delete(r.misses, key)
+}
+
+// load reads the song from the database and stores it in the cache
+func (r *Repository) load(ctx context.Context, log *slog.Logger, song *domain.SongInfo) (*domain.Song, error) {
+	start := time.Now()
+	targetSong, err := r.db.Read(ctx, song)
+	if err != nil {
+		log.Error("failed to fetch song from database", sl.Err(err))
+		return nil, err
+	}
+	r.loadTime.Store(int64(time.Since(start)))
+
+	log.Debug("storing song in cache after fetching from database")
+	err = r.cache.Set(ctx, targetSong)
+	if err != nil {
+		log.Error("failed to store song in cache", sl.Err(err))
+		return nil, err
+	}
+
+	return targetSong, nil
+}
+
+// refreshEarly decides whether a cached song that expires in ttl is reloaded
+// now (XFetch): the chance grows as the expiry nears and with the time a
+// database read takes, so one of many readers refreshes a popular song
+// instead of all of them at once after it has expired
+func (r *Repository) refreshEarly(ttl time.Duration) bool {
+	if r.earlyRefresh <= 0 || ttl <= 0 {
+		return false
+	}
+
+	loadTime := max(time.Duration(r.loadTime.Load()), minLoadTime)
+	return float64(loadTime)*r.earlyRefresh*-math.Log(r.random()) >= float64(ttl)
+}
+
+// ReadByIDs returns the songs with the given IDs that exist. Cached songs
+// are taken from the cache, the rest are read in a single query and cached.
+// The songs are in no particular order.
+func (r *Repository) ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error) {
+	const op = "Repository.ReadByIDs"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Int("count", len(ids)))
+
+	songs := make([]*domain.Song, 0, len(ids))
+	var missed []uuid.UUID
+	for _, id := range ids {
+		song, _, err := r.cache.Get(ctx, &domain.SongInfo{ID: id})
 		if err != nil {
-			log.Error("failed to store song in cache", sl.Err(err))
-			return nil, err
+			missed = append(missed, id)
+			continue
 		}
+		songs = append(songs, song)
+	}
 
-		return targetSong, nil
+	if len(missed) == 0 {
+		log.Debug("songs successfully fetched from cache")
+		return songs, nil
 	}
 
-	log.Debug("song successfully fetched from cache")
+	log.Debug("fetching songs missing in cache from database", slog.Int("missed", len(missed)))
+	loaded, err := r.db.ReadByIDs(ctx, missed)
+	if err != nil {
+		log.Error("failed to fetch songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	for _, song := range loaded {
+		// the song is read, failing to cache it only costs the next read
+		if err := r.cache.Set(ctx, song); err != nil {
+			log.Warn("failed to store song in cache", slog.String("song_id", song.ID.String()), sl.Err(err))
+		}
+	}
+
+	log.Debug("songs successfully fetched", slog.Int("cached", len(songs)), slog.Int("loaded", len(loaded)))
+	return append(songs, loaded...), nil
+}
+
+// ReadByNameAndGroup bypasses the cache, which is keyed by song ID
+func (r *Repository) ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "Repository.ReadByNameAndGroup"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
+
+	log.Debug("attempting to fetch song by name and group from database")
+	targetSong, err := r.db.ReadByNameAndGroup(ctx, song)
+	if err != nil {
+		log.Error("failed to fetch song by name and group from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("song successfully fetched from database")
 	return targetSong, nil
 }
 
-func (r *Repository) ReadAllWithFilter(ctx context.Context, song *domain.Song, limit, offset int) ([]*domain.Song, error) {
+func (r *Repository) ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error) {
 	const op = "Repository.ReadAllWithFilter"
 
-	log := r.log.With(slog.String("op", op), slog.String("song_name", song.Name), slog.String("group_name", song.Group))
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", filter.Name), slog.String("group_name", filter.Group))
 
 	log.Debug("attempting to fetch songs from database with filter")
-	songs, err := r.db.ReadAllWithFilter(ctx, song, limit, offset)
+	songs, err := r.db.ReadAllWithFilter(ctx, filter, limit, offset)
 	if err != nil {
 		log.Error("failed to fetch songs from database with filter", sl.Err(err))
 		return nil, err
@@ -115,45 +380,102 @@ func (r *Repository) ReadAllWithFilter(ctx context.Context, song *domain.Song, l
 	return songs, nil
 }
 
+func (r *Repository) ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	const op = "Repository.ReadAllAfter"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", filter.Name), slog.String("group_name", filter.Group))
+
+	log.Debug("attempting to fetch songs after cursor from database")
+	songs, err := r.db.ReadAllAfter(ctx, filter, after, limit)
+	if err != nil {
+		log.Error("failed to fetch songs after cursor from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("songs successfully fetched from database")
+	return songs, nil
+}
+
+func (r *Repository) ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error) {
+	const op = "Repository.ReadDuplicates"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Float64("threshold", threshold))
+
+	log.Debug("attempting to fetch duplicate songs from database")
+	duplicates, err := r.db.ReadDuplicates(ctx, threshold, limit)
+	if err != nil {
+		log.Error("failed to fetch duplicate songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("duplicate songs successfully fetched from database", slog.Int("count", len(duplicates)))
+	return duplicates, nil
+}
+
 func (r *Repository) Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error {
 	const op = "Repository.Update"
 
-	log := r.log.With(slog.String("op", op), slog.String("song_name", updatedSong.Name), slog.String("group_name", updatedSong.Group))
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", updatedSong.Name), slog.String("group_name", updatedSong.Group))
 
-	log.Debug("updating song in database")
-	err := r.db.Update(ctx, song, updatedSong)
-	if err != nil {
-		log.Error("failed to update song in database", sl.Err(err))
-		return err
+	if r.queue != nil {
+		return r.updateBehind(ctx, log, song, updatedSong)
 	}
 
-	log.Debug("updating song in cache")
-	err = r.cache.Set(ctx, updatedSong)
+	err := r.writeThrough(ctx, log, &song.ID,
+		func(ctx context.Context) error {
+			return r.updateInDB(ctx, log, song, updatedSong)
+		},
+		func(ctx context.Context) error {
+			log.Debug("updating song in cache")
+			if err := r.cache.Set(ctx, updatedSong); err != nil {
+				log.Error("failed to update song in cache", sl.Err(err))
+				return err
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		log.Error("failed to update song in cache", sl.Err(err))
 		return err
 	}
+	touchLibrary(ctx, log, r.cache)
 
 	log.Debug("song successfully updated in database and cache")
 	return nil
 }
 
+// updateInDB updates the song, keeps its previous revision and records the
+// change in the audit log
+func (r *Repository) updateInDB(ctx context.Context, log *slog.Logger, song *domain.SongInfo, updatedSong *domain.Song) error {
+	oldSong, err := r.db.Read(ctx, song)
+	if err != nil {
+		log.Error("failed to fetch song before update", sl.Err(err))
+		return err
+	}
+
+	log.Debug("updating song in database")
+	if err := r.db.Update(ctx, song, updatedSong); err != nil {
+		log.Error("failed to update song in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("keeping previous revision of song", slog.Int("revision", oldSong.Version))
+	if err := r.db.CreateRevision(ctx, oldSong); err != nil {
+		log.Error("failed to keep previous revision of song", sl.Err(err))
+		return err
+	}
+	return r.audit(ctx, log, domain.AuditUpdate, song.ID, oldSong, updatedSong)
+}
+
 func (r *Repository) Delete(ctx context.Context, song *domain.SongInfo) error {
 	const op = "Repository.Delete"
 
-	log := r.log.With(slog.String("op", op), slog.String("song_id", song.ID.String()))
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", song.ID.String()))
 
-	log.Debug("deleting song from database")
-	err := r.db.Delete(ctx, song)
-	if err != nil {
-		log.Error("failed to delete song from database", sl.Err(err))
-		return err
+	if r.queue != nil {
+		return r.deleteBehind(ctx, log, song)
 	}
 
-	log.Debug("invalidating song in cache")
-	err = r.cache.Invalidate(ctx, song)
-	if err != nil {
-		log.Error("failed to invalidate song in cache", sl.Err(err))
+	if err := r.deleteThrough(ctx, log, song); err != nil {
 		return err
 	}
 
@@ -161,27 +483,215 @@ func (r *Repository) Delete(ctx context.Context, song *domain.SongInfo) error {
 	return nil
 }
 
-func (r *Repository) CacheRecovery(ctx context.Context) error {
-	const op = "Repository.CacheRecovery"
-
-	log := r.log.With(slog.String("op", op))
-
-	log.Debug("attempting to recover cache from database")
-	songs, err := r.db.ReadAllWithFilter(ctx, &domain.Song{}, 0, 0)
+// deleteThrough deletes the song from the database and evicts it from the
+// cache
+func (r *Repository) deleteThrough(ctx context.Context, log *slog.Logger, song *domain.SongInfo) error {
+	err := r.writeThrough(ctx, log, &song.ID,
+		func(ctx context.Context) error {
+			oldSong, err := r.db.Read(ctx, song)
+			if err != nil {
+				log.Error("failed to fetch song before delete", sl.Err(err))
+				return err
+			}
+
+			log.Debug("deleting song from database")
+			if err := r.db.Delete(ctx, song); err != nil {
+				log.Error("failed to delete song from database", sl.Err(err))
+				return err
+			}
+			return r.audit(ctx, log, domain.AuditDelete, song.ID, oldSong, nil)
+		},
+		func(ctx context.Context) error {
+			log.Debug("invalidating song in cache")
+			if err := r.cache.Invalidate(ctx, song); err != nil {
+				log.Error("failed to invalidate song in cache", sl.Err(err))
+				return err
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		log.Error("failed to fetch songs from database for cache recovery", sl.Err(err))
 		return err
 	}
+	touchLibrary(ctx, log, r.cache)
+	return nil
+}
+
+// UpdateAllWithFilter sets the changes on every song matching the filter.
+// The previous revision and an audit entry are recorded for every song and
+// the songs are evicted from the cache in the same transaction, so a failure
+// rolls the whole update back.
+func (r *Repository) UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error) {
+	const op = "Repository.UpdateAllWithFilter"
 
-	for _, song := range songs {
-		log.Debug("caching song", slog.String("song_name", song.Name), slog.String("group_name", song.Group))
-		err = r.cache.Set(ctx, song)
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_name", filter.Name), slog.String("group_name", filter.Group))
+
+	var updates []*domain.SongUpdate
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		log.Debug("updating songs in database with filter")
+		updates, err = r.db.UpdateAllWithFilter(ctx, filter, changes)
 		if err != nil {
-			log.Error("failed to cache song", sl.Err(err))
+			log.Error("failed to update songs in database with filter", sl.Err(err))
 			return err
 		}
+
+		for _, update := range updates {
+			if err := r.db.CreateRevision(ctx, update.Old); err != nil {
+				log.Error("failed to keep previous revision of song", slog.String("song_id", update.Old.ID.String()), sl.Err(err))
+				return err
+			}
+			if err := r.audit(ctx, log, domain.AuditUpdate, update.New.ID, update.Old, update.New); err != nil {
+				return err
+			}
+			if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: update.New.ID}); err != nil {
+				log.Error("failed to invalidate song in cache", slog.String("song_id", update.New.ID.String()), sl.Err(err))
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(updates) > 0 {
+		touchLibrary(ctx, log, r.cache)
+	}
+
+	log.Debug("songs successfully updated in database and invalidated in cache", slog.Int("count", len(updates)))
+	return updates, nil
+}
+
+// CacheRecovery copies up to limit newest songs of the library from the
+// database to the cache in batches of batchSize and returns how many were
+// cached. A limit of zero caches every song.
+func (r *Repository) CacheRecovery(ctx context.Context, batchSize, limit int) (int, error) {
+	const op = "Repository.CacheRecovery"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("batch_size", batchSize),
+		slog.Int("limit", limit),
+	)
+
+	log.Info("attempting to recover cache from database")
+
+	// Songs are read newest first with a cursor, so only batchSize songs are
+	// held in memory and the most recent ones are cached before the limit hits
+	cached := 0
+	var after *domain.SongCursor
+	for limit <= 0 || cached < limit {
+		size := batchSize
+		if limit > 0 && limit-cached < size {
+			size = limit - cached
+		}
+
+		songs, err := r.db.ReadAllAfter(ctx, &domain.SongFilter{}, after, size)
+		if err != nil {
+			log.Error("failed to fetch songs from database for cache recovery", sl.Err(err), slog.Int("cached", cached))
+			return cached, err
+		}
+
+		for _, song := range songs {
+			if err := r.cache.Set(ctx, song); err != nil {
+				log.Error("failed to cache song", sl.Err(err), slog.Int("cached", cached))
+				return cached, err
+			}
+			cached++
+		}
+
+		log.Info("cache recovery in progress", slog.Int("cached", cached))
+
+		if len(songs) < size {
+			break
+		}
+		after = domain.CursorOf(songs[len(songs)-1])
 	}
 
-	log.Debug("cache recovery completed successfully")
+	log.Info("cache recovery completed successfully", slog.Int("cached", cached))
+	return cached, nil
+}
+
+func (r *Repository) CacheStats(ctx context.Context) (*domain.CacheStats, error) {
+	return r.cache.Stats(ctx)
+}
+
+// InvalidateCache evicts a song from the cache, the next read loads it from
+// the database
+func (r *Repository) InvalidateCache(ctx context.Context, id uuid.UUID) error {
+	return r.cache.Invalidate(ctx, &domain.SongInfo{ID: id})
+}
+
+// FlushCache evicts every cached entry and returns how many were evicted
+func (r *Repository) FlushCache(ctx context.Context) (int64, error) {
+	return r.cache.Flush(ctx)
+}
+
+// WithinTransaction runs fn in a database transaction, the repository methods
+// called with the context passed to fn take part in it
+func (r *Repository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithinTransaction(ctx, fn)
+}
+
+// audit records a change of a song made by the user of the context and
+// stores the event about it in the outbox. It is called inside the
+// transaction of the change, so a failed entry rolls the change back and the
+// event is only published for committed changes.
+func (r *Repository) audit(ctx context.Context, log *slog.Logger, action domain.AuditAction, id uuid.UUID, oldSong, newSong *domain.Song) error {
+	entry := &domain.AuditEntry{
+		SongID: id,
+		Action: action,
+		Old:    oldSong,
+		New:    newSong,
+	}
+	if userID, ok := domain.UserIDFromContext(ctx); ok {
+		entry.UserID = &userID
+	}
+
+	log.Debug("recording change in audit log", slog.String("action", string(action)))
+	if err := r.db.CreateAuditEntry(ctx, entry); err != nil {
+		log.Error("failed to record change in audit log", sl.Err(err))
+		return err
+	}
+
+	// the event carries the state after the change, or the last state of a
+	// deleted song
+	event := &domain.SongEvent{Type: domain.AuditEventTypes[action], Song: newSong, OccurredAt: time.Now()}
+	if newSong == nil {
+		event.Song = oldSong
+	}
+
+	log.Debug("storing event in outbox", slog.String("type", string(event.Type)))
+	if err := r.db.CreateOutboxEvent(ctx, event); err != nil {
+		log.Error("failed to store event in outbox", sl.Err(err))
+		return err
+	}
 	return nil
 }
+
+// writeThrough runs a database write and the matching cache update in one
+// transaction, so a failed cache update rolls the write back. If the commit
+// fails after the cache was updated, the song is evicted from the cache. id
+// is read after the write, which assigns it for new songs.
+func (r *Repository) writeThrough(ctx context.Context, log *slog.Logger, id *uuid.UUID, write, cache func(ctx context.Context) error) error {
+	cached := false
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		if err := cache(ctx); err != nil {
+			return err
+		}
+		cached = true
+		return nil
+	})
+	if err != nil && cached {
+		log.Error("failed to commit transaction", sl.Err(err))
+		if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: *id}); err != nil {
+			log.Error("failed to invalidate song in cache", sl.Err(err))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,781 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDB records whether the transaction was committed, the embedded
+// interface panics on methods the tests don't expect
+type stubDB struct {
+	Database
+	createErr error
+	commitErr error
+	committed bool
+	auditErr  error
+	audited   []*domain.AuditEntry
+	revisions []*domain.Song
+	events    []*domain.SongEvent
+	stored    *domain.Song
+	updates   []*domain.SongUpdate
+}
+
+func (db *stubDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	if db.commitErr != nil {
+		return db.commitErr
+	}
+	db.committed = true
+	return nil
+}
+
+func (db *stubDB) Create(_ context.Context, song *domain.Song) error {
+	if db.createErr != nil {
+		return db.createErr
+	}
+	if song.ID == uuid.Nil {
+		song.ID = uuid.New()
+	}
+	return nil
+}
+
+func (db *stubDB) Read(_ context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+	if db.stored == nil {
+		return nil, domain.ErrSongNotFound
+	}
+	song := *db.stored
+	return &song, nil
+}
+
+func (db *stubDB) Update(_ context.Context, _ *domain.SongInfo, updatedSong *domain.Song) error {
+	updatedSong.Version++
+	return nil
+}
+
+func (db *stubDB) Delete(_ context.Context, _ *domain.SongInfo) error {
+	return nil
+}
+
+func (db *stubDB) UpdateAllWithFilter(_ context.Context, _ *domain.SongFilter, _ *domain.SongChanges) ([]*domain.SongUpdate, error) {
+	return db.updates, nil
+}
+
+func (db *stubDB) CreateAuditEntry(_ context.Context, entry *domain.AuditEntry) error {
+	if db.auditErr != nil {
+		return db.auditErr
+	}
+	db.audited = append(db.audited, entry)
+	return nil
+}
+
+func (db *stubDB) CreateOutboxEvent(_ context.Context, event *domain.SongEvent) error {
+	db.events = append(db.events, event)
+	return nil
+}
+
+func (db *stubDB) CreateRevision(_ context.Context, song *domain.Song) error {
+	db.revisions = append(db.revisions, song)
+	return nil
+}
+
+// pagedDB serves songs newest first like the cursor listing of Postgres
+type pagedDB struct {
+	Database
+	songs []*domain.Song
+	calls int
+}
+
+func (db *pagedDB) ReadAllAfter(_ context.Context, _ *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error) {
+	db.calls++
+	start := 0
+	if after != nil {
+		for i, song := range db.songs {
+			if song.ID == after.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+limit, len(db.songs))
+	return db.songs[start:end], nil
+}
+
+type stubCache struct {
+	Cache
+	setErr      error
+	set         int
+	invalidated []uuid.UUID
+	modified    time.Time
+}
+
+func (c *stubCache) Set(_ context.Context, _ *domain.Song) error {
+	if c.setErr != nil {
+		return c.setErr
+	}
+	c.set++
+	return nil
+}
+
+func (c *stubCache) Invalidate(_ context.Context, song *domain.SongInfo) error {
+	c.invalidated = append(c.invalidated, song.ID)
+	return nil
+}
+
+func (c *stubCache) GetLibraryModified(_ context.Context) (time.Time, error) {
+	if c.modified.IsZero() {
+		return time.Time{}, domain.ErrCacheMiss
+	}
+	return c.modified, nil
+}
+
+func (c *stubCache) SetLibraryModified(_ context.Context, at time.Time) error {
+	c.modified = at
+	return nil
+}
+
+func TestRepository_Create_CacheFailureRollsBack(t *testing.T) {
+	db := &stubDB{}
+	cache := &stubCache{setErr: errors.New("redis is down")}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := repo.Create(context.Background(), &domain.Song{Name: "Hysteria", Group: "Muse"})
+
+	// Песня не сохраняется в базе, если её не удалось закэшировать
+	assert.Error(t, err)
+	assert.False(t, db.committed)
+	assert.Empty(t, cache.invalidated)
+}
+
+func TestRepository_Create_CommitFailureEvictsSong(t *testing.T) {
+	db := &stubDB{commitErr: errors.New("connection reset")}
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	err := repo.Create(context.Background(), song)
+
+	// Закэшированная песня удаляется из кэша, если транзакция не зафиксирована
+	assert.Error(t, err)
+	assert.Equal(t, []uuid.UUID{song.ID}, cache.invalidated)
+}
+
+func newPagedDB(n int) *pagedDB {
+	db := &pagedDB{}
+	for i := 0; i < n; i++ {
+		db.songs = append(db.songs, &domain.Song{ID: uuid.New()})
+	}
+	return db
+}
+
+func TestRepository_CacheRecovery_Batches(t *testing.T) {
+	db := newPagedDB(25)
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	cached, err := repo.CacheRecovery(context.Background(), 10, 0)
+
+	// Без лимита кэшируются все песни, по 10 за запрос
+	assert.NoError(t, err)
+	assert.Equal(t, 25, cached)
+	assert.Equal(t, 25, cache.set)
+	assert.Equal(t, 3, db.calls)
+}
+
+func TestRepository_CacheRecovery_Limit(t *testing.T) {
+	db := newPagedDB(25)
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	cached, err := repo.CacheRecovery(context.Background(), 10, 15)
+
+	// Последняя порция урезается до лимита
+	assert.NoError(t, err)
+	assert.Equal(t, 15, cached)
+	assert.Equal(t, 15, cache.set)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRepository_CacheRecovery_CacheFailure(t *testing.T) {
+	db := newPagedDB(5)
+	cache := &stubCache{setErr: errors.New("redis is down")}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	cached, err := repo.CacheRecovery(context.Background(), 10, 0)
+	assert.Error(t, err)
+	assert.Equal(t, 0, cached)
+}
+
+func TestRepository_Create_Audited(t *testing.T) {
+	db := &stubDB{}
+	repo := NewRepository(db, &stubCache{}, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	userID := uuid.New()
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	err := repo.Create(domain.WithUserID(context.Background(), userID), song)
+
+	// Создание записывается в журнал вместе с пользователем
+	assert.NoError(t, err)
+	if assert.Len(t, db.audited, 1) {
+		entry := db.audited[0]
+		assert.Equal(t, domain.AuditCreate, entry.Action)
+		assert.Equal(t, song.ID, entry.SongID)
+		assert.Equal(t, &userID, entry.UserID)
+		assert.Nil(t, entry.Old)
+		assert.Equal(t, song, entry.New)
+	}
+	// Событие о создании сохраняется в outbox в той же транзакции
+	if assert.Len(t, db.events, 1) {
+		assert.Equal(t, domain.SongCreated, db.events[0].Type)
+		assert.Equal(t, song, db.events[0].Song)
+	}
+}
+
+func TestRepository_Update_AuditedWithOldSong(t *testing.T) {
+	id := uuid.New()
+	db := &stubDB{stored: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 1}}
+	repo := NewRepository(db, &stubCache{}, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	updated := &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Version: 1}
+	err := repo.Update(context.Background(), &domain.SongInfo{ID: id}, updated)
+
+	// В журнале есть песня до и после изменения, анонимный пользователь не указан
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Song{db.stored}, db.revisions)
+	if assert.Len(t, db.audited, 1) {
+		entry := db.audited[0]
+		assert.Equal(t, domain.AuditUpdate, entry.Action)
+		assert.Nil(t, entry.UserID)
+		assert.Equal(t, db.stored, entry.Old)
+		assert.Equal(t, updated, entry.New)
+	}
+}
+
+func TestRepository_Delete_EventCarriesLastState(t *testing.T) {
+	id := uuid.New()
+	db := &stubDB{stored: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 3}}
+	repo := NewRepository(db, &stubCache{}, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := repo.Delete(context.Background(), &domain.SongInfo{ID: id})
+
+	// Событие об удалении несёт последнее состояние песни
+	assert.NoError(t, err)
+	assert.True(t, db.committed)
+	if assert.Len(t, db.events, 1) {
+		assert.Equal(t, domain.SongDeleted, db.events[0].Type)
+		assert.Equal(t, db.stored, db.events[0].Song)
+	}
+}
+
+func TestRepository_Create_AuditFailureRollsBack(t *testing.T) {
+	db := &stubDB{auditErr: errors.New("audit_log is missing")}
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := repo.Create(context.Background(), &domain.Song{Name: "Hysteria", Group: "Muse"})
+
+	// Изменение без записи в журнале не сохраняется
+	assert.Error(t, err)
+	assert.False(t, db.committed)
+	assert.Equal(t, 0, cache.set)
+}
+
+func TestRepository_UpdateAllWithFilter_AuditedAndEvicted(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	db := &stubDB{updates: []*domain.SongUpdate{
+		{Old: &domain.Song{ID: first, Group: "Muse", Version: 1}, New: &domain.Song{ID: first, Group: "MUSE", Version: 2}},
+		{Old: &domain.Song{ID: second, Group: "Muse", Version: 3}, New: &domain.Song{ID: second, Group: "MUSE", Version: 4}},
+	}}
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	group := "MUSE"
+	updates, err := repo.UpdateAllWithFilter(context.Background(), &domain.SongFilter{Group: "Muse"}, &domain.SongChanges{Group: &group})
+
+	// Каждая песня получает ревизию и запись в журнале и удаляется из кэша
+	assert.NoError(t, err)
+	assert.Equal(t, db.updates, updates)
+	assert.True(t, db.committed)
+	assert.Equal(t, []*domain.Song{db.updates[0].Old, db.updates[1].Old}, db.revisions)
+	assert.Len(t, db.audited, 2)
+	assert.Equal(t, []uuid.UUID{first, second}, cache.invalidated)
+}
+
+func TestRepository_UpdateAllWithFilter_AuditFailureRollsBack(t *testing.T) {
+	id := uuid.New()
+	db := &stubDB{
+		auditErr: errors.New("audit_log is missing"),
+		updates:  []*domain.SongUpdate{{Old: &domain.Song{ID: id}, New: &domain.Song{ID: id}}},
+	}
+	cache := &stubCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	genre := "rock"
+	_, err := repo.UpdateAllWithFilter(context.Background(), &domain.SongFilter{Group: "Muse"}, &domain.SongChanges{Genre: &genre})
+
+	assert.Error(t, err)
+	assert.False(t, db.committed)
+	assert.Empty(t, cache.invalidated)
+}
+
+// slowDB serves one song, each read waits until release is closed
+type slowDB struct {
+	Database
+	song    domain.Song
+	release chan struct{}
+	reads   atomic.Int32
+}
+
+func (db *slowDB) Read(_ context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+	db.reads.Add(1)
+	<-db.release
+	song := db.song
+	return &song, nil
+}
+
+// expiringCache keeps one song that expires in ttl, lookups are counted in
+// gets
+type expiringCache struct {
+	Cache
+	mu     sync.Mutex
+	cached *domain.Song
+	ttl    time.Duration
+	gets   sync.WaitGroup
+	set    int
+}
+
+func (c *expiringCache) Get(_ context.Context, _ *domain.SongInfo) (*domain.Song, time.Duration, error) {
+	defer c.gets.Done()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached == nil {
+		return nil, 0, domain.ErrSongNotFound
+	}
+	song := *c.cached
+	return &song, c.ttl, nil
+}
+
+func (c *expiringCache) Set(_ context.Context, song *domain.Song) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cached = song
+	c.set++
+	return nil
+}
+
+func (c *expiringCache) stored() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set
+}
+
+func TestRepository_Read_CoalescesMisses(t *testing.T) {
+	id := uuid.New()
+	db := &slowDB{song: domain.Song{ID: id, Name: "Hysteria", Group: "Muse"}, release: make(chan struct{})}
+	cache := &expiringCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	const readers = 10
+	cache.gets.Add(readers)
+	songs := make(chan *domain.Song, readers)
+	for range readers {
+		go func() {
+			song, err := repo.Read(context.Background(), &domain.SongInfo{ID: id})
+			assert.NoError(t, err)
+			songs <- song
+		}()
+	}
+
+	// Все читатели промахнулись мимо кэша до того, как база ответила
+	cache.gets.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(db.release)
+
+	// Песню из базы читает и кэширует только один из них, каждый получает свою копию
+	seen := make(map[*domain.Song]bool)
+	for range readers {
+		song := <-songs
+		assert.Equal(t, "Hysteria", song.Name)
+		seen[song] = true
+	}
+	assert.Equal(t, int32(1), db.reads.Load())
+	assert.Equal(t, 1, cache.stored())
+	assert.Len(t, seen, readers)
+}
+
+// cancelableDB serves one song after release is closed, a read whose
+// context is canceled first reports the cancellation in canceled
+type cancelableDB struct {
+	Database
+	song     domain.Song
+	release  chan struct{}
+	started  chan struct{}
+	canceled chan error
+}
+
+func (db *cancelableDB) Read(ctx context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+	close(db.started)
+	select {
+	case <-db.release:
+		song := db.song
+		return &song, nil
+	case <-ctx.Done():
+		db.canceled <- ctx.Err()
+		return nil, ctx.Err()
+	}
+}
+
+func newCancelableDB(id uuid.UUID) *cancelableDB {
+	return &cancelableDB{
+		song:     domain.Song{ID: id, Name: "Hysteria", Group: "Muse"},
+		release:  make(chan struct{}),
+		started:  make(chan struct{}),
+		canceled: make(chan error, 1),
+	}
+}
+
+func TestRepository_Read_CancelsAbandonedLoad(t *testing.T) {
+	id := uuid.New()
+	db := newCancelableDB(id)
+	cache := &expiringCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cache.gets.Add(1)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := repo.Read(ctx, &domain.SongInfo{ID: id})
+		errs <- err
+	}()
+
+	// Единственный читатель ушел, запрос к базе отменяется вместе с ним
+	<-db.started
+	cancel()
+
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case err := <-db.canceled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("database read was not canceled")
+	}
+	assert.Equal(t, 0, cache.stored())
+}
+
+func TestRepository_Read_SharedLoadOutlivesReader(t *testing.T) {
+	id := uuid.New()
+	db := newCancelableDB(id)
+	cache := &expiringCache{}
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	cache.gets.Add(2)
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.Read(first, &domain.SongInfo{ID: id})
+		firstErr <- err
+	}()
+	<-db.started
+
+	songs := make(chan *domain.Song, 1)
+	go func() {
+		song, err := repo.Read(context.Background(), &domain.SongInfo{ID: id})
+		assert.NoError(t, err)
+		songs <- song
+	}()
+	cache.gets.Wait()
+	time.Sleep(20 * time.Millisecond)
+
+	// Читатель, начавший загрузку, ушел, но второй еще ждет: запрос не отменяется
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(db.release)
+
+	assert.Equal(t, "Hysteria", (<-songs).Name)
+	assert.Empty(t, db.canceled)
+	assert.Equal(t, 1, cache.stored())
+}
+
+func TestRepository_Read_RefreshesEarly(t *testing.T) {
+	id := uuid.New()
+	db := &slowDB{song: domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 2}, release: make(chan struct{})}
+	close(db.release)
+	cache := &expiringCache{cached: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 1}, ttl: time.Millisecond}
+	repo := NewRepository(db, cache, nil, 1, slog.New(slogdiscard.NewDiscardHandler()))
+	repo.random = func() float64 { return 0.5 }
+
+	cache.gets.Add(1)
+	song, err := repo.Read(context.Background(), &domain.SongInfo{ID: id})
+
+	// Читатель сразу получает песню из кэша, а обновление идет в фоне
+	assert.NoError(t, err)
+	assert.Equal(t, 1, song.Version)
+	assert.Eventually(t, func() bool { return cache.stored() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), db.reads.Load())
+}
+
+func TestRepository_Read_KeepsFreshSong(t *testing.T) {
+	id := uuid.New()
+	db := &slowDB{release: make(chan struct{})}
+	cache := &expiringCache{cached: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse"}, ttl: time.Hour}
+	repo := NewRepository(db, cache, nil, 1, slog.New(slogdiscard.NewDiscardHandler()))
+	repo.random = func() float64 { return 0.5 }
+
+	cache.gets.Add(1)
+	_, err := repo.Read(context.Background(), &domain.SongInfo{ID: id})
+
+	// До истечения срока далеко, база не читается
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), db.reads.Load())
+}
+
+// batchDB serves songs by ID and records the IDs asked in every query
+type batchDB struct {
+	Database
+	songs   map[uuid.UUID]*domain.Song
+	queries [][]uuid.UUID
+}
+
+func (db *batchDB) ReadByIDs(_ context.Context, ids []uuid.UUID) ([]*domain.Song, error) {
+	db.queries = append(db.queries, ids)
+	var songs []*domain.Song
+	for _, id := range ids {
+		if song, ok := db.songs[id]; ok {
+			songs = append(songs, song)
+		}
+	}
+	return songs, nil
+}
+
+func TestRepository_ReadByIDs_CacheFirst(t *testing.T) {
+	cached := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	stored := &domain.Song{ID: uuid.New(), Name: "Starlight", Group: "Muse"}
+	missing := uuid.New()
+
+	db := &batchDB{songs: map[uuid.UUID]*domain.Song{stored.ID: stored}}
+	cache := newMapCache(cached)
+	repo := NewRepository(db, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Промахи кэша читаются из базы одним запросом и кэшируются
+	songs, err := repo.ReadByIDs(context.Background(), []uuid.UUID{cached.ID, stored.ID, missing})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*domain.Song{cached, stored}, songs)
+	assert.Equal(t, [][]uuid.UUID{{stored.ID, missing}}, db.queries)
+	assert.Contains(t, cache.songs, stored.ID)
+
+	// Песни из кэша не требуют запроса к базе
+	songs, err = repo.ReadByIDs(context.Background(), []uuid.UUID{cached.ID, stored.ID})
+	assert.NoError(t, err)
+	assert.Len(t, songs, 2)
+	assert.Len(t, db.queries, 1)
+}
+
+// suggestionDB counts the queries reaching the database
+type suggestionDB struct {
+	calls int
+}
+
+func (db *suggestionDB) ReadSuggestions(_ context.Context, query string, _ int) ([]*domain.Suggestion, error) {
+	db.calls++
+	return []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: query, Score: 1}}, nil
+}
+
+// suggestionCache fails every lookup with getErr and counts the stored lists
+type suggestionCache struct {
+	getErr error
+	cached map[string][]*domain.Suggestion
+	set    int
+}
+
+func (c *suggestionCache) GetSuggestions(_ context.Context, query string, _ int) ([]*domain.Suggestion, error) {
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	suggestions, ok := c.cached[query]
+	if !ok {
+		return nil, domain.ErrCacheMiss
+	}
+	return suggestions, nil
+}
+
+func (c *suggestionCache) SetSuggestions(_ context.Context, query string, _ int, suggestions []*domain.Suggestion, _ time.Duration) error {
+	c.cached[query] = suggestions
+	c.set++
+	return nil
+}
+
+func TestSuggestionRepository_Read_Cached(t *testing.T) {
+	db := &suggestionDB{}
+	cache := &suggestionCache{cached: make(map[string][]*domain.Suggestion)}
+	repo := NewSuggestionRepository(db, cache, time.Minute, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Второй запрос обслуживается из кэша
+	for range 2 {
+		suggestions, err := repo.Read(context.Background(), "muse", 10)
+		assert.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+	}
+	assert.Equal(t, 1, db.calls)
+	assert.Equal(t, 1, cache.set)
+}
+
+func TestSuggestionRepository_Read_CacheFailure(t *testing.T) {
+	db := &suggestionDB{}
+	cache := &suggestionCache{getErr: errors.New("redis is down"), cached: make(map[string][]*domain.Suggestion)}
+	repo := NewSuggestionRepository(db, cache, time.Minute, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Ошибка кэша не мешает ответу из базы
+	suggestions, err := repo.Read(context.Background(), "muse", 10)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestSuggestionRepository_Read_CacheDisabled(t *testing.T) {
+	db := &suggestionDB{}
+	cache := &suggestionCache{cached: make(map[string][]*domain.Suggestion)}
+	repo := NewSuggestionRepository(db, cache, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	for range 2 {
+		_, err := repo.Read(context.Background(), "muse", 10)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, db.calls)
+	assert.Zero(t, cache.set)
+}
+
+// seededDB picks the same song for every seed and counts the picks
+type seededDB struct {
+	RandomDatabase
+	song  *domain.Song
+	calls int
+}
+
+func (db *seededDB) ReadSeeded(_ context.Context, _ string) (*domain.Song, error) {
+	db.calls++
+	return db.song, nil
+}
+
+// dailyCache keeps the songs of the day in a map
+type dailyCache struct {
+	picks map[string]uuid.UUID
+	ttl   time.Duration
+}
+
+func (c *dailyCache) GetSongOfTheDay(_ context.Context, day string) (uuid.UUID, error) {
+	songID, ok := c.picks[day]
+	if !ok {
+		return uuid.Nil, domain.ErrCacheMiss
+	}
+	return songID, nil
+}
+
+func (c *dailyCache) SetSongOfTheDay(_ context.Context, day string, songID uuid.UUID, ttl time.Duration) error {
+	c.picks[day] = songID
+	c.ttl = ttl
+	return nil
+}
+
+func (c *dailyCache) DeleteSongOfTheDay(_ context.Context, day string) error {
+	delete(c.picks, day)
+	return nil
+}
+
+func TestRandomRepository_ReadOfTheDay_Cached(t *testing.T) {
+	db := &seededDB{song: &domain.Song{ID: uuid.New()}}
+	cache := &dailyCache{picks: make(map[string]uuid.UUID)}
+	repo := NewRandomRepository(db, cache, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Песня дня выбирается в базе один раз и хранится в кэше переданное время
+	for range 2 {
+		songID, err := repo.ReadOfTheDay(context.Background(), "2024-05-01", time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, db.song.ID, songID)
+	}
+	assert.Equal(t, 1, db.calls)
+	assert.Equal(t, time.Hour, cache.ttl)
+
+	// После сброса песня выбирается заново
+	assert.NoError(t, repo.ForgetOfTheDay(context.Background(), "2024-05-01"))
+	_, err := repo.ReadOfTheDay(context.Background(), "2024-05-01", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+// statsDB counts the computations of the library statistics
+type statsDB struct {
+	calls int
+}
+
+func (db *statsDB) ReadLibraryStats(_ context.Context, _ time.Time) (*domain.LibraryStats, error) {
+	db.calls++
+	return &domain.LibraryStats{Songs: 3}, nil
+}
+
+// statsCache keeps the statistics by their start date
+type statsCache struct {
+	stats map[time.Time]*domain.LibraryStats
+}
+
+func (c *statsCache) GetLibraryStats(_ context.Context, since time.Time) (*domain.LibraryStats, error) {
+	stats, ok := c.stats[since]
+	if !ok {
+		return nil, domain.ErrCacheMiss
+	}
+	return stats, nil
+}
+
+func (c *statsCache) SetLibraryStats(_ context.Context, since time.Time, stats *domain.LibraryStats, _ time.Duration) error {
+	c.stats[since] = stats
+	return nil
+}
+
+func TestStatsRepository_Read_Cached(t *testing.T) {
+	db := &statsDB{}
+	cache := &statsCache{stats: make(map[time.Time]*domain.LibraryStats)}
+	repo := NewStatsRepository(db, cache, time.Minute, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Статистика считается в базе один раз для одной даты начала
+	since := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	for range 2 {
+		stats, err := repo.Read(context.Background(), since)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, stats.Songs)
+	}
+	assert.Equal(t, 1, db.calls)
+
+	_, err := repo.Read(context.Background(), since.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRepository_LibraryModified(t *testing.T) {
+	cache := &stubCache{}
+	repo := NewRepository(&stubDB{}, cache, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+	ctx := context.Background()
+
+	// Неизвестное время изменения записывается как текущее
+	before := time.Now()
+	modified, err := repo.LibraryModified(ctx)
+	assert.NoError(t, err)
+	assert.False(t, modified.Before(before))
+	assert.Equal(t, modified, cache.modified)
+
+	// Создание песни сдвигает время изменения библиотеки
+	assert.NoError(t, repo.Create(ctx, &domain.Song{Name: "Hysteria", Group: "Muse"}))
+	created, err := repo.LibraryModified(ctx)
+	assert.NoError(t, err)
+	assert.True(t, created.After(modified))
+}
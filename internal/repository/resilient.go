@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ResilientCache keeps the song repository working while the cache is
+// unavailable. A failed cache write is logged and counted instead of
+// failing the request, and the cache is bypassed from then on, as it may
+// hold songs the write would have replaced. Run probes the cache in the
+// background and puts it back in use once it answers, flushed, since it
+// missed the writes made meanwhile.
+type ResilientCache struct {
+	Cache
+
+	ping func(ctx context.Context) error
+	log  *slog.Logger
+
+	down     atomic.Bool
+	failures atomic.Int64
+	// recovered is when the cache was last put back in use, libraries are
+	// reported modified then as their changes weren't recorded while down
+	mu        sync.RWMutex
+	recovered time.Time
+}
+
+// NewResilientCache wraps cache, ping checks whether it is reachable again
+func NewResilientCache(cache Cache, ping func(ctx context.Context) error, log *slog.Logger) *ResilientCache {
+	return &ResilientCache{
+		Cache: cache,
+		ping:  ping,
+		log:   log.With(slog.String("component", "repository/resilient_cache")),
+	}
+}
+
+func (c *ResilientCache) Set(ctx context.Context, song *domain.Song) error {
+	if c.down.Load() {
+		return nil
+	}
+	c.fail(c.Cache.Set(ctx, song), "failed to cache song")
+	return nil
+}
+
+func (c *ResilientCache) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, time.Duration, error) {
+	if c.down.Load() {
+		return nil, 0, domain.ErrCacheMiss
+	}
+	cached, ttl, err := c.Cache.Get(ctx, song)
+	if err != nil && !errors.Is(err, domain.ErrCacheMiss) {
+		c.fail(err, "failed to read song from cache")
+		return nil, 0, domain.ErrCacheMiss
+	}
+	return cached, ttl, err
+}
+
+func (c *ResilientCache) Invalidate(ctx context.Context, song *domain.SongInfo) error {
+	if c.down.Load() {
+		return nil
+	}
+	c.fail(c.Cache.Invalidate(ctx, song), "failed to invalidate song in cache")
+	return nil
+}
+
+func (c *ResilientCache) GetLibraryModified(ctx context.Context) (time.Time, error) {
+	if c.down.Load() {
+		return time.Time{}, domain.ErrCacheMiss
+	}
+	modified, err := c.Cache.GetLibraryModified(ctx)
+	if err != nil && !errors.Is(err, domain.ErrCacheMiss) {
+		c.fail(err, "failed to read library modification time from cache")
+		return time.Time{}, domain.ErrCacheMiss
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if err == nil && modified.Before(c.recovered) {
+		return c.recovered, nil
+	}
+	return modified, err
+}
+
+func (c *ResilientCache) SetLibraryModified(ctx context.Context, at time.Time) error {
+	if c.down.Load() {
+		return nil
+	}
+	c.fail(c.Cache.SetLibraryModified(ctx, at), "failed to record library modification in cache")
+	return nil
+}
+
+// fail takes the cache out of use if err is not nil
+func (c *ResilientCache) fail(err error, msg string) {
+	if err == nil {
+		return
+	}
+	c.failures.Add(1)
+	c.log.Error(msg, sl.Err(err))
+	if c.down.CompareAndSwap(false, true) {
+		c.log.Warn("cache is unavailable, songs are read from the database until it recovers")
+	}
+}
+
+// Run probes the cache every interval while it is out of use until ctx is
+// done
+func (c *ResilientCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.down.Load() {
+				c.reconnect(ctx)
+			}
+		}
+	}
+}
+
+// reconnect puts the cache back in use if it is reachable and could be
+// flushed
+func (c *ResilientCache) reconnect(ctx context.Context) {
+	if err := c.ping(ctx); err != nil {
+		c.log.Debug("cache is still unavailable", sl.Err(err))
+		return
+	}
+
+	deleted, err := c.Cache.Flush(ctx)
+	if err != nil {
+		c.log.Warn("failed to flush cache after it recovered", sl.Err(err))
+		return
+	}
+
+	c.mu.Lock()
+	c.recovered = time.Now()
+	c.mu.Unlock()
+	c.down.Store(false)
+	c.log.Info("cache recovered and flushed", slog.Int64("deleted", deleted))
+}
+
+// ResilienceStats returns whether the cache is out of use and how many cache
+// operations failed since the start
+func (c *ResilientCache) ResilienceStats() map[string]int64 {
+	down := int64(0)
+	if c.down.Load() {
+		down = 1
+	}
+	return map[string]int64{
+		"down":     down,
+		"failures": c.failures.Load(),
+	}
+}
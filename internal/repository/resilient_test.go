@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyCache fails while err is set and counts the reads and flushes
+type flakyCache struct {
+	stubCache
+	err     error
+	gets    int
+	flushes int
+}
+
+func (c *flakyCache) Set(ctx context.Context, song *domain.Song) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.stubCache.Set(ctx, song)
+}
+
+func (c *flakyCache) Get(_ context.Context, _ *domain.SongInfo) (*domain.Song, time.Duration, error) {
+	c.gets++
+	if c.err != nil {
+		return nil, 0, c.err
+	}
+	return nil, 0, domain.ErrCacheMiss
+}
+
+func (c *flakyCache) Flush(_ context.Context) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	c.flushes++
+	return 3, nil
+}
+
+func TestResilientCache_CacheFailureKeepsWrite(t *testing.T) {
+	ctx := context.Background()
+	db := &stubDB{}
+	cache := &flakyCache{err: errors.New("redis is down")}
+	resilient := NewResilientCache(cache, func(context.Context) error { return cache.err }, slog.New(slogdiscard.NewDiscardHandler()))
+	repo := NewRepository(db, resilient, nil, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Песня сохраняется в базе, несмотря на недоступный кэш
+	require.NoError(t, repo.Create(ctx, &domain.Song{Name: "Hysteria", Group: "Muse"}))
+	assert.True(t, db.committed)
+	assert.Equal(t, map[string]int64{"down": 1, "failures": 1}, resilient.ResilienceStats())
+
+	// Пока кэш недоступен, чтения идут мимо него
+	db.stored = &domain.Song{Name: "Hysteria", Group: "Muse"}
+	_, err := repo.Read(ctx, &domain.SongInfo{Name: "Hysteria", Group: "Muse"})
+	require.NoError(t, err)
+	assert.Zero(t, cache.gets)
+
+	// Пока кэш не отвечает, он остаётся выключенным
+	resilient.reconnect(ctx)
+	assert.Equal(t, int64(1), resilient.ResilienceStats()["down"])
+}
+
+func TestResilientCache_Reconnect(t *testing.T) {
+	ctx := context.Background()
+	cache := &flakyCache{err: errors.New("redis is down"), stubCache: stubCache{modified: time.Now().Add(-time.Hour)}}
+	resilient := NewResilientCache(cache, func(context.Context) error { return cache.err }, slog.New(slogdiscard.NewDiscardHandler()))
+
+	require.NoError(t, resilient.Set(ctx, &domain.Song{}))
+	cache.err = nil
+
+	// Восстановленный кэш очищается, библиотеки считаются изменёнными в момент восстановления
+	start := time.Now()
+	resilient.reconnect(ctx)
+	assert.Equal(t, 1, cache.flushes)
+	assert.Equal(t, int64(0), resilient.ResilienceStats()["down"])
+
+	modified, err := resilient.GetLibraryModified(ctx)
+	require.NoError(t, err)
+	assert.False(t, modified.Before(start))
+
+	require.NoError(t, resilient.Set(ctx, &domain.Song{}))
+	assert.Equal(t, 1, cache.set)
+}
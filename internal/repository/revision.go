@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type RevisionDatabase interface {
+	ReadRevisions(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.SongRevision, error)
+	ReadRevision(ctx context.Context, songID uuid.UUID, revision int) (*domain.SongRevision, error)
+}
+
+// RevisionRepository reads previous states of songs, they are kept by
+// Repository when a song is updated
+type RevisionRepository struct {
+	db  RevisionDatabase
+	log *slog.Logger
+}
+
+func NewRevisionRepository(db RevisionDatabase, log *slog.Logger) *RevisionRepository {
+	return &RevisionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *RevisionRepository) ReadAll(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.SongRevision, error) {
+	const op = "RevisionRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()))
+
+	log.Debug("attempting to fetch song revisions from database")
+	revisions, err := r.db.ReadRevisions(ctx, songID, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch song revisions from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("song revisions successfully fetched from database", slog.Int("count", len(revisions)))
+	return revisions, nil
+}
+
+func (r *RevisionRepository) Read(ctx context.Context, songID uuid.UUID, revision int) (*domain.SongRevision, error) {
+	const op = "RevisionRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()), slog.Int("revision", revision))
+
+	log.Debug("attempting to fetch song revision from database")
+	found, err := r.db.ReadRevision(ctx, songID, revision)
+	if err != nil {
+		log.Error("failed to fetch song revision from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("song revision successfully fetched from database")
+	return found, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type SearchDatabase interface {
+	SearchSongs(ctx context.Context, query string, excludeExplicit bool, snippets, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+type SearchRepository struct {
+	db  SearchDatabase
+	log *slog.Logger
+}
+
+func NewSearchRepository(db SearchDatabase, log *slog.Logger) *SearchRepository {
+	return &SearchRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query string, excludeExplicit bool, snippets, limit, offset int) ([]*domain.SearchResult, error) {
+	const op = "SearchRepository.Search"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("query", query))
+
+	log.Debug("searching songs in database")
+	results, err := r.db.SearchSongs(ctx, query, excludeExplicit, snippets, limit, offset)
+	if err != nil {
+		log.Error("failed to search songs in database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("songs successfully searched", slog.Int("count", len(results)))
+	return results, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShareDatabase interface {
+	CreateShare(ctx context.Context, share *domain.Share) error
+	ReadShare(ctx context.Context, id uuid.UUID) (*domain.Share, error)
+	ReadShares(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error)
+	RevokeShare(ctx context.Context, userID, id uuid.UUID, revokedAt time.Time) (*domain.Share, error)
+}
+
+type ShareRepository struct {
+	db  ShareDatabase
+	log *slog.Logger
+}
+
+func NewShareRepository(db ShareDatabase, log *slog.Logger) *ShareRepository {
+	return &ShareRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *ShareRepository) Create(ctx context.Context, share *domain.Share) error {
+	const op = "ShareRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", share.UserID.String()))
+
+	log.Debug("creating share in database")
+	if err := r.db.CreateShare(ctx, share); err != nil {
+		log.Error("failed to create share in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("share successfully created")
+	return nil
+}
+
+func (r *ShareRepository) Read(ctx context.Context, id uuid.UUID) (*domain.Share, error) {
+	const op = "ShareRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("share_id", id.String()))
+
+	log.Debug("fetching share from database")
+	share, err := r.db.ReadShare(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch share from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("share successfully fetched")
+	return share, nil
+}
+
+func (r *ShareRepository) ReadAll(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error) {
+	const op = "ShareRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()))
+
+	log.Debug("fetching shares from database")
+	shares, err := r.db.ReadShares(ctx, userID)
+	if err != nil {
+		log.Error("failed to fetch shares from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("shares successfully fetched", slog.Int("count", len(shares)))
+	return shares, nil
+}
+
+func (r *ShareRepository) Revoke(ctx context.Context, userID, id uuid.UUID, revokedAt time.Time) (*domain.Share, error) {
+	const op = "ShareRepository.Revoke"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("share_id", id.String()),
+	)
+
+	log.Debug("revoking share in database")
+	share, err := r.db.RevokeShare(ctx, userID, id, revokedAt)
+	if err != nil {
+		log.Error("failed to revoke share in database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("share successfully revoked")
+	return share, nil
+}
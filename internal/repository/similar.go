@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type SimilarDatabase interface {
+	ReadSimilar(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error)
+}
+
+type SimilarRepository struct {
+	db  SimilarDatabase
+	log *slog.Logger
+}
+
+func NewSimilarRepository(db SimilarDatabase, log *slog.Logger) *SimilarRepository {
+	return &SimilarRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *SimilarRepository) ReadSimilar(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error) {
+	const op = "SimilarRepository.ReadSimilar"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", song.ID.String()))
+
+	log.Debug("fetching similar songs from database")
+	similar, err := r.db.ReadSimilar(ctx, song, excludeExplicit, limit)
+	if err != nil {
+		log.Error("failed to fetch similar songs from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("similar songs successfully fetched", slog.Int("count", len(similar)))
+	return similar, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+type StatsDatabase interface {
+	ReadLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error)
+}
+
+// StatsCache keeps the library statistics by the start of their daily
+// counts, a miss is reported as domain.ErrCacheMiss
+type StatsCache interface {
+	GetLibraryStats(ctx context.Context, since time.Time) (*domain.LibraryStats, error)
+	SetLibraryStats(ctx context.Context, since time.Time, stats *domain.LibraryStats, ttl time.Duration) error
+}
+
+// StatsRepository computes the library statistics in the database and keeps
+// them in the cache for ttl, zero disables caching. Cache failures are
+// logged and never fail the request.
+type StatsRepository struct {
+	db    StatsDatabase
+	cache StatsCache
+	ttl   time.Duration
+	log   *slog.Logger
+}
+
+func NewStatsRepository(db StatsDatabase, cache StatsCache, ttl time.Duration, log *slog.Logger) *StatsRepository {
+	return &StatsRepository{
+		db:    db,
+		cache: cache,
+		ttl:   ttl,
+		log:   log,
+	}
+}
+
+func (r *StatsRepository) Read(ctx context.Context, since time.Time) (*domain.LibraryStats, error) {
+	const op = "StatsRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Time("since", since))
+
+	if r.ttl > 0 {
+		stats, err := r.cache.GetLibraryStats(ctx, since)
+		if err == nil {
+			log.Debug("library stats found in cache")
+			return stats, nil
+		}
+		if !errors.Is(err, domain.ErrCacheMiss) {
+			log.Warn("failed to read library stats from cache", sl.Err(err))
+		}
+	}
+
+	log.Debug("computing library stats in database")
+	stats, err := r.db.ReadLibraryStats(ctx, since)
+	if err != nil {
+		log.Error("failed to compute library stats in database", sl.Err(err))
+		return nil, err
+	}
+
+	if r.ttl > 0 {
+		if err := r.cache.SetLibraryStats(ctx, since, stats, r.ttl); err != nil {
+			log.Warn("failed to cache library stats", sl.Err(err))
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+type SuggestionDatabase interface {
+	ReadSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error)
+}
+
+// SuggestionCache keeps suggestions by query and limit, a miss is reported
+// as domain.ErrCacheMiss
+type SuggestionCache interface {
+	GetSuggestions(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error)
+	SetSuggestions(ctx context.Context, query string, limit int, suggestions []*domain.Suggestion, ttl time.Duration) error
+}
+
+// SuggestionRepository reads search suggestions from the database and keeps
+// them in the cache for ttl. Suggestions aren't invalidated when songs
+// change, so ttl should be short; zero disables caching. Cache failures are
+// logged and never fail the request.
+type SuggestionRepository struct {
+	db    SuggestionDatabase
+	cache SuggestionCache
+	ttl   time.Duration
+	log   *slog.Logger
+}
+
+func NewSuggestionRepository(db SuggestionDatabase, cache SuggestionCache, ttl time.Duration, log *slog.Logger) *SuggestionRepository {
+	return &SuggestionRepository{
+		db:    db,
+		cache: cache,
+		ttl:   ttl,
+		log:   log,
+	}
+}
+
+func (r *SuggestionRepository) Read(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	const op = "SuggestionRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("query", query), slog.Int("limit", limit))
+
+	if r.ttl > 0 {
+		suggestions, err := r.cache.GetSuggestions(ctx, query, limit)
+		if err == nil {
+			log.Debug("suggestions found in cache")
+			return suggestions, nil
+		}
+		if !errors.Is(err, domain.ErrCacheMiss) {
+			log.Warn("failed to read suggestions from cache", sl.Err(err))
+		}
+	}
+
+	log.Debug("fetching suggestions from database")
+	suggestions, err := r.db.ReadSuggestions(ctx, query, limit)
+	if err != nil {
+		log.Error("failed to fetch suggestions from database", sl.Err(err))
+		return nil, err
+	}
+
+	if r.ttl > 0 {
+		if err := r.cache.SetSuggestions(ctx, query, limit, suggestions, r.ttl); err != nil {
+			log.Warn("failed to cache suggestions", sl.Err(err))
+		}
+	}
+
+	log.Debug("suggestions successfully fetched", slog.Int("count", len(suggestions)))
+	return suggestions, nil
+}
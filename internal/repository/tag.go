@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type TagDatabase interface {
+	AddTags(ctx context.Context, songID uuid.UUID, tags []string) error
+	RemoveTag(ctx context.Context, songID uuid.UUID, tag string) error
+	ReadSongTags(ctx context.Context, songID uuid.UUID) ([]string, error)
+	ReadTags(ctx context.Context, limit, offset int) ([]*domain.Tag, error)
+}
+
+// TagRepository records the tag changes in cache as modifications of the
+// library, songs are listed by their tags
+type TagRepository struct {
+	db    TagDatabase
+	cache ModifiedCache
+	log   *slog.Logger
+}
+
+func NewTagRepository(db TagDatabase, cache ModifiedCache, log *slog.Logger) *TagRepository {
+	return &TagRepository{
+		db:    db,
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (r *TagRepository) Add(ctx context.Context, songID uuid.UUID, tags []string) error {
+	const op = "TagRepository.Add"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()), slog.Any("tags", tags))
+
+	log.Debug("adding tags in database")
+	if err := r.db.AddTags(ctx, songID, tags); err != nil {
+		log.Error("failed to add tags in database", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("tags successfully added")
+	return nil
+}
+
+func (r *TagRepository) Remove(ctx context.Context, songID uuid.UUID, tag string) error {
+	const op = "TagRepository.Remove"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()), slog.String("tag", tag))
+
+	log.Debug("removing tag from database")
+	if err := r.db.RemoveTag(ctx, songID, tag); err != nil {
+		log.Error("failed to remove tag from database", sl.Err(err))
+		return err
+	}
+
+	touchLibrary(ctx, log, r.cache)
+
+	log.Debug("tag successfully removed")
+	return nil
+}
+
+func (r *TagRepository) ReadSongTags(ctx context.Context, songID uuid.UUID) ([]string, error) {
+	const op = "TagRepository.ReadSongTags"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("song_id", songID.String()))
+
+	log.Debug("attempting to fetch song tags from database")
+	tags, err := r.db.ReadSongTags(ctx, songID)
+	if err != nil {
+		log.Error("failed to fetch song tags from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("song tags successfully fetched from database", slog.Int("count", len(tags)))
+	return tags, nil
+}
+
+func (r *TagRepository) ReadAll(ctx context.Context, limit, offset int) ([]*domain.Tag, error) {
+	const op = "TagRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("attempting to fetch tags from database")
+	tags, err := r.db.ReadTags(ctx, limit, offset)
+	if err != nil {
+		log.Error("failed to fetch tags from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("tags successfully fetched from database", slog.Int("count", len(tags)))
+	return tags, nil
+}
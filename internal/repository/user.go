@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type UserDatabase interface {
+	UpsertUser(ctx context.Context, user *domain.User) error
+	ReadUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	ReadAllUsers(ctx context.Context) ([]*domain.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+}
+
+type UserRepository struct {
+	db  UserDatabase
+	log *slog.Logger
+}
+
+func NewUserRepository(db UserDatabase, log *slog.Logger) *UserRepository {
+	return &UserRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *UserRepository) Upsert(ctx context.Context, user *domain.User) error {
+	const op = "UserRepository.Upsert"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", user.ID.String()))
+
+	log.Debug("saving user role in database", slog.String("role", string(user.Role)))
+	if err := r.db.UpsertUser(ctx, user); err != nil {
+		log.Error("failed to save user role in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("user role successfully saved")
+	return nil
+}
+
+func (r *UserRepository) Read(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	const op = "UserRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", id.String()))
+
+	log.Debug("fetching user from database")
+	user, err := r.db.ReadUser(ctx, id)
+	if err != nil {
+		// most users have no role of their own, so this isn't worth an error
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Debug("user has no role")
+			return nil, err
+		}
+		log.Error("failed to fetch user from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("user successfully fetched")
+	return user, nil
+}
+
+func (r *UserRepository) ReadAll(ctx context.Context) ([]*domain.User, error) {
+	const op = "UserRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching users from database")
+	users, err := r.db.ReadAllUsers(ctx)
+	if err != nil {
+		log.Error("failed to fetch users from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("users successfully fetched", slog.Int("count", len(users)))
+	return users, nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "UserRepository.Delete"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", id.String()))
+
+	log.Debug("deleting user from database")
+	if err := r.db.DeleteUser(ctx, id); err != nil {
+		log.Error("failed to delete user from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("user successfully deleted")
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDatabase interface {
+	CreateWebhook(ctx context.Context, hook *domain.Webhook) error
+	ReadWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ReadAllWebhooks(ctx context.Context) ([]*domain.Webhook, error)
+	ReadWebhooksForEvent(ctx context.Context, eventType domain.SongEventType) ([]*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, hook *domain.Webhook) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookRepository struct {
+	db  WebhookDatabase
+	log *slog.Logger
+}
+
+func NewWebhookRepository(db WebhookDatabase, log *slog.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, hook *domain.Webhook) error {
+	const op = "WebhookRepository.Create"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("url", hook.URL))
+
+	log.Debug("creating webhook in database")
+	if err := r.db.CreateWebhook(ctx, hook); err != nil {
+		log.Error("failed to create webhook in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("webhook successfully created")
+	return nil
+}
+
+func (r *WebhookRepository) Read(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "WebhookRepository.Read"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("webhook_id", id.String()))
+
+	log.Debug("fetching webhook from database")
+	hook, err := r.db.ReadWebhook(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch webhook from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("webhook successfully fetched")
+	return hook, nil
+}
+
+func (r *WebhookRepository) ReadAll(ctx context.Context) ([]*domain.Webhook, error) {
+	const op = "WebhookRepository.ReadAll"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Debug("fetching webhooks from database")
+	hooks, err := r.db.ReadAllWebhooks(ctx)
+	if err != nil {
+		log.Error("failed to fetch webhooks from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("webhooks successfully fetched", slog.Int("count", len(hooks)))
+	return hooks, nil
+}
+
+func (r *WebhookRepository) ReadForEvent(ctx context.Context, eventType domain.SongEventType) ([]*domain.Webhook, error) {
+	const op = "WebhookRepository.ReadForEvent"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("event", string(eventType)))
+
+	log.Debug("fetching subscribed webhooks from database")
+	hooks, err := r.db.ReadWebhooksForEvent(ctx, eventType)
+	if err != nil {
+		log.Error("failed to fetch subscribed webhooks from database", sl.Err(err))
+		return nil, err
+	}
+
+	log.Debug("subscribed webhooks successfully fetched", slog.Int("count", len(hooks)))
+	return hooks, nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, hook *domain.Webhook) error {
+	const op = "WebhookRepository.Update"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("webhook_id", hook.ID.String()))
+
+	log.Debug("updating webhook in database")
+	if err := r.db.UpdateWebhook(ctx, hook); err != nil {
+		log.Error("failed to update webhook in database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("webhook successfully updated")
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "WebhookRepository.Delete"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("webhook_id", id.String()))
+
+	log.Debug("deleting webhook from database")
+	if err := r.db.DeleteWebhook(ctx, id); err != nil {
+		log.Error("failed to delete webhook from database", sl.Err(err))
+		return err
+	}
+
+	log.Debug("webhook successfully deleted")
+	return nil
+}
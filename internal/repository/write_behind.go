@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WriteQueue durably keeps the song writes of the write-behind mode until
+// they are flushed to the database
+type WriteQueue interface {
+	PushWrite(ctx context.Context, write *domain.PendingWrite) error
+	ClaimWrites(ctx context.Context, count int) ([]*domain.PendingWrite, error)
+	AckWrite(ctx context.Context, write *domain.PendingWrite) error
+}
+
+// createBehind assigns the ID of the new song the database would, stores the
+// song in the cache and queues it for the database. Duplicates are only
+// detected when the song is flushed.
+func (r *Repository) createBehind(ctx context.Context, log *slog.Logger, song *domain.Song) error {
+	song.ID = uuid.New()
+	song.CreatedAt = time.Now()
+	song.UpdatedAt = song.CreatedAt
+	song.Version = 1
+	song.LibraryID = domain.LibraryIDFromContext(ctx)
+
+	queued := *song
+	if err := r.writeBehind(ctx, log, domain.PendingCreate, &queued, song, nil); err != nil {
+		return err
+	}
+
+	log.Debug("song successfully cached and queued for database")
+	return nil
+}
+
+// updateBehind checks the version of the update against the cached song,
+// stores the updated song in the cache and queues the update for the
+// database
+func (r *Repository) updateBehind(ctx context.Context, log *slog.Logger, song *domain.SongInfo, updatedSong *domain.Song) error {
+	current, err := r.Read(ctx, song)
+	if err != nil {
+		log.Error("failed to fetch song before update", sl.Err(err))
+		return err
+	}
+	if current.Version != updatedSong.Version {
+		log.Warn("song was updated concurrently", slog.Int("version", updatedSong.Version), slog.Int("current_version", current.Version))
+		return domain.ErrVersionConflict
+	}
+
+	updatedSong.LibraryID = current.LibraryID
+
+	// the queued song carries the version the database is expected to have
+	queued := *updatedSong
+	updatedSong.UpdatedAt = time.Now()
+	updatedSong.Version++
+
+	if err := r.writeBehind(ctx, log, domain.PendingUpdate, &queued, updatedSong, current); err != nil {
+		return err
+	}
+
+	log.Debug("song successfully cached and queued for database")
+	return nil
+}
+
+// writeBehind stores cached in the cache and queues the write of queued. If
+// the write can't be queued, the cache is reverted to previous, a new song is
+// evicted.
+func (r *Repository) writeBehind(ctx context.Context, log *slog.Logger, op domain.PendingWriteOp, queued, cached, previous *domain.Song) error {
+	write := &domain.PendingWrite{Op: op, Song: queued}
+	if userID, ok := domain.UserIDFromContext(ctx); ok {
+		write.UserID = &userID
+	}
+
+	log.Debug("storing song in cache")
+	if err := r.cache.Set(ctx, cached); err != nil {
+		log.Error("failed to store song in cache", sl.Err(err))
+		return err
+	}
+
+	log.Debug("queueing song write for database", slog.String("write_op", string(op)))
+	if err := r.queue.PushWrite(ctx, write); err != nil {
+		log.Error("failed to queue song write for database", sl.Err(err))
+
+		var revertErr error
+		if previous != nil {
+			revertErr = r.cache.Set(ctx, previous)
+		} else {
+			revertErr = r.cache.Invalidate(ctx, &domain.SongInfo{ID: cached.ID})
+		}
+		if revertErr != nil {
+			log.Error("failed to revert song in cache", sl.Err(revertErr))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// deleteBehind deletes the song and marks it deleted, so the flushes drop its
+// queued writes instead of writing them after the delete. A song whose
+// creation wasn't flushed yet is only in the cache and is evicted from it.
+// The flushes wait for the delete, none writes the song meanwhile.
+func (r *Repository) deleteBehind(ctx context.Context, log *slog.Logger, song *domain.SongInfo) error {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	r.deletedWrites[song.ID] = struct{}{}
+	err := r.deleteThrough(ctx, log, song)
+	if errors.Is(err, domain.ErrSongNotFound) {
+		if _, _, cacheErr := r.cache.Get(ctx, song); cacheErr == nil {
+			log.Debug("song is only queued, evicting it from cache")
+			if err := r.cache.Invalidate(ctx, song); err != nil {
+				log.Error("failed to invalidate song in cache", sl.Err(err))
+				delete(r.deletedWrites, song.ID)
+				return err
+			}
+			touchLibrary(ctx, log, r.cache)
+			return nil
+		}
+	}
+	if err != nil {
+		delete(r.deletedWrites, song.ID)
+		return err
+	}
+
+	log.Debug("song successfully deleted and its queued writes dropped")
+	return nil
+}
+
+// FlushWrites writes the queued songs to the database in batches of
+// batchSize and returns how many writes were flushed. Writes the database
+// rejects for good, e.g. duplicates, are dropped and their songs evicted from
+// the cache. Other failures stop the flush, the write is retried with the
+// next one, so the writes of a song are never reordered.
+func (r *Repository) FlushWrites(ctx context.Context, batchSize int) (int, error) {
+	const op = "Repository.FlushWrites"
+
+	log := r.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Int("batch_size", batchSize))
+
+	// claimed writes are claimed again until acknowledged, so only one
+	// flush may run at a time
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	flushed := 0
+	for {
+		writes, err := r.queue.ClaimWrites(ctx, batchSize)
+		if err != nil {
+			log.Error("failed to claim queued song writes", sl.Err(err))
+			return flushed, err
+		}
+		if len(writes) == 0 {
+			// the writes queued before the deletes are gone, later ones of a
+			// deleted song fail as it isn't found
+			clear(r.deletedWrites)
+			return flushed, nil
+		}
+
+		for _, write := range writes {
+			if err := r.flushWrite(ctx, log, write); err != nil {
+				return flushed, err
+			}
+			flushed++
+		}
+	}
+}
+
+// flushWrite applies one queued write to the database and acknowledges it
+func (r *Repository) flushWrite(ctx context.Context, log *slog.Logger, write *domain.PendingWrite) error {
+	log = log.With(slog.String("write_id", write.ID))
+
+	if write.Song == nil {
+		log.Error("dropping song write that can't be read")
+		r.dropped.Add(1)
+		return r.ackWrite(ctx, log, write)
+	}
+
+	log = log.With(slog.String("write_op", string(write.Op)), slog.String("song_id", write.Song.ID.String()))
+	if write.UserID != nil {
+		ctx = domain.WithUserID(ctx, *write.UserID)
+	}
+	ctx = domain.WithLibraryID(ctx, write.Song.LibraryID)
+
+	// a write queued before the delete of its song, or racing with it, must
+	// neither recreate the song nor cache it again
+	if _, deleted := r.deletedWrites[write.Song.ID]; deleted {
+		log.Debug("dropping write of deleted song, evicting song from cache")
+		r.dropped.Add(1)
+		if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: write.Song.ID}); err != nil {
+			log.Error("failed to invalidate song in cache", sl.Err(err))
+		}
+		return r.ackWrite(ctx, log, write)
+	}
+
+	song := *write.Song
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if write.Op == domain.PendingCreate {
+			return r.createInDB(ctx, log, &song)
+		}
+		return r.updateInDB(ctx, log, &domain.SongInfo{ID: song.ID}, &song)
+	})
+	if domain.IsPermanentWriteError(err) {
+		log.Error("dropping song write rejected by database, evicting song from cache", sl.Err(err))
+		r.dropped.Add(1)
+		if err := r.cache.Invalidate(ctx, &domain.SongInfo{ID: song.ID}); err != nil {
+			log.Error("failed to invalidate song in cache", sl.Err(err))
+		}
+		return r.ackWrite(ctx, log, write)
+	}
+	if err != nil {
+		log.Error("failed to flush song write, retrying with the next flush", sl.Err(err))
+		return err
+	}
+
+	r.flushed.Add(1)
+	touchLibrary(ctx, log, r.cache)
+	r.refreshCached(ctx, log, &song)
+	return r.ackWrite(ctx, log, write)
+}
+
+// refreshCached stores the flushed song in the cache unless the cache holds a
+// newer version, e.g. of a write queued after it
+func (r *Repository) refreshCached(ctx context.Context, log *slog.Logger, song *domain.Song) {
+	cached, _, err := r.cache.Get(ctx, &domain.SongInfo{ID: song.ID})
+	if err == nil && cached.Version > song.Version {
+		return
+	}
+	if err := r.cache.Set(ctx, song); err != nil {
+		log.Warn("failed to store flushed song in cache", sl.Err(err))
+	}
+}
+
+func (r *Repository) ackWrite(ctx context.Context, log *slog.Logger, write *domain.PendingWrite) error {
+	if err := r.queue.AckWrite(ctx, write); err != nil {
+		log.Error("failed to acknowledge song write", sl.Err(err))
+		return err
+	}
+	return nil
+}
+
+// WriteBehindStats returns the number of song writes flushed to the database
+// and dropped since the start
+func (r *Repository) WriteBehindStats() map[string]int64 {
+	return map[string]int64{
+		"flushed": r.flushed.Load(),
+		"dropped": r.dropped.Load(),
+	}
+}
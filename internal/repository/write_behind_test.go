@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubQueue keeps the writes in memory, acknowledged writes are removed
+type stubQueue struct {
+	writes  []*domain.PendingWrite
+	acked   []string
+	pushErr error
+	pushed  int
+}
+
+func (q *stubQueue) PushWrite(_ context.Context, write *domain.PendingWrite) error {
+	if q.pushErr != nil {
+		return q.pushErr
+	}
+	q.pushed++
+	write.ID = strconv.Itoa(q.pushed)
+	q.writes = append(q.writes, write)
+	return nil
+}
+
+func (q *stubQueue) ClaimWrites(_ context.Context, count int) ([]*domain.PendingWrite, error) {
+	return q.writes[:min(count, len(q.writes))], nil
+}
+
+func (q *stubQueue) AckWrite(_ context.Context, write *domain.PendingWrite) error {
+	q.acked = append(q.acked, write.ID)
+	for i, queued := range q.writes {
+		if queued.ID == write.ID {
+			q.writes = append(q.writes[:i:i], q.writes[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// mapCache keeps songs by ID
+type mapCache struct {
+	Cache
+	songs map[uuid.UUID]domain.Song
+}
+
+func newMapCache(songs ...*domain.Song) *mapCache {
+	c := &mapCache{songs: make(map[uuid.UUID]domain.Song)}
+	for _, song := range songs {
+		c.songs[song.ID] = *song
+	}
+	return c
+}
+
+func (c *mapCache) Get(_ context.Context, song *domain.SongInfo) (*domain.Song, time.Duration, error) {
+	cached, ok := c.songs[song.ID]
+	if !ok {
+		return nil, 0, domain.ErrSongNotFound
+	}
+	return &cached, 0, nil
+}
+
+func (c *mapCache) Set(_ context.Context, song *domain.Song) error {
+	c.songs[song.ID] = *song
+	return nil
+}
+
+func (c *mapCache) Invalidate(_ context.Context, song *domain.SongInfo) error {
+	delete(c.songs, song.ID)
+	return nil
+}
+
+func (c *mapCache) SetLibraryModified(_ context.Context, _ time.Time) error {
+	return nil
+}
+
+func TestRepository_Create_WriteBehind(t *testing.T) {
+	db := &stubDB{}
+	cache := newMapCache()
+	queue := &stubQueue{}
+	repo := NewRepository(db, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	userID := uuid.New()
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	err := repo.Create(domain.WithUserID(context.Background(), userID), song)
+
+	// Песня сразу попадает в кэш и очередь, база не затрагивается
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, song.ID)
+	assert.Equal(t, 1, song.Version)
+	assert.Contains(t, cache.songs, song.ID)
+	assert.False(t, db.committed)
+	if assert.Len(t, queue.writes, 1) {
+		write := queue.writes[0]
+		assert.Equal(t, domain.PendingCreate, write.Op)
+		assert.Equal(t, song.ID, write.Song.ID)
+		assert.Equal(t, &userID, write.UserID)
+	}
+}
+
+func TestRepository_Create_WriteBehindQueueFailure(t *testing.T) {
+	cache := newMapCache()
+	queue := &stubQueue{pushErr: errors.New("redis is down")}
+	repo := NewRepository(&stubDB{}, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	err := repo.Create(context.Background(), &domain.Song{Name: "Hysteria", Group: "Muse"})
+
+	// Песня, которую не удалось поставить в очередь, удаляется из кэша
+	assert.Error(t, err)
+	assert.Empty(t, cache.songs)
+}
+
+func TestRepository_Update_WriteBehind(t *testing.T) {
+	id := uuid.New()
+	cache := newMapCache(&domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 1})
+	queue := &stubQueue{}
+	repo := NewRepository(&stubDB{}, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	updated := &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Version: 1}
+	err := repo.Update(context.Background(), &domain.SongInfo{ID: id}, updated)
+
+	// В кэше новая версия, в очереди - ожидаемая версия в базе
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cache.songs[id].Version)
+	assert.Equal(t, "It's bugging me", cache.songs[id].Text)
+	if assert.Len(t, queue.writes, 1) {
+		assert.Equal(t, domain.PendingUpdate, queue.writes[0].Op)
+		assert.Equal(t, 1, queue.writes[0].Song.Version)
+	}
+
+	// Обновление устаревшей версии отклоняется сразу
+	stale := &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 1}
+	err = repo.Update(context.Background(), &domain.SongInfo{ID: id}, stale)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Len(t, queue.writes, 1)
+}
+
+func TestRepository_FlushWrites(t *testing.T) {
+	id := uuid.New()
+	userID := uuid.New()
+	db := &stubDB{}
+	cache := newMapCache()
+	queue := &stubQueue{writes: []*domain.PendingWrite{
+		{ID: "1", Op: domain.PendingCreate, Song: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse", Version: 1}, UserID: &userID},
+		{ID: "2"},
+	}}
+	repo := NewRepository(db, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	flushed, err := repo.FlushWrites(context.Background(), 1)
+
+	// Песня создаётся в базе от имени пользователя, нечитаемая запись отбрасывается
+	assert.NoError(t, err)
+	assert.Equal(t, 2, flushed)
+	assert.True(t, db.committed)
+	if assert.Len(t, db.audited, 1) {
+		assert.Equal(t, id, db.audited[0].SongID)
+		assert.Equal(t, &userID, db.audited[0].UserID)
+	}
+	assert.Contains(t, cache.songs, id)
+	assert.Equal(t, []string{"1", "2"}, queue.acked)
+	assert.Equal(t, map[string]int64{"flushed": 1, "dropped": 1}, repo.WriteBehindStats())
+}
+
+func TestRepository_FlushWrites_Failures(t *testing.T) {
+	id := uuid.New()
+	write := &domain.PendingWrite{ID: "1", Op: domain.PendingCreate, Song: &domain.Song{ID: id, Name: "Hysteria", Group: "Muse"}}
+
+	t.Run("retried", func(t *testing.T) {
+		db := &stubDB{createErr: errors.New("connection refused")}
+		queue := &stubQueue{writes: []*domain.PendingWrite{write}}
+		repo := NewRepository(db, newMapCache(write.Song), queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+		// Запись остаётся в очереди до следующего переноса
+		flushed, err := repo.FlushWrites(context.Background(), 10)
+		assert.Error(t, err)
+		assert.Equal(t, 0, flushed)
+		assert.Empty(t, queue.acked)
+		assert.Len(t, queue.writes, 1)
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		db := &stubDB{createErr: domain.ErrSongExists}
+		cache := newMapCache(write.Song)
+		queue := &stubQueue{writes: []*domain.PendingWrite{write}}
+		repo := NewRepository(db, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+		// Дубликат не попадёт в базу никогда: запись отбрасывается, песня удаляется из кэша
+		flushed, err := repo.FlushWrites(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, flushed)
+		assert.Equal(t, []string{"1"}, queue.acked)
+		assert.Empty(t, cache.songs)
+		assert.Equal(t, int64(1), repo.WriteBehindStats()["dropped"])
+	})
+}
+
+// storingDB keeps the song created in it until it is deleted
+type storingDB struct {
+	*stubDB
+}
+
+func (db storingDB) Create(ctx context.Context, song *domain.Song) error {
+	if err := db.stubDB.Create(ctx, song); err != nil {
+		return err
+	}
+	stored := *song
+	db.stored = &stored
+	return nil
+}
+
+func (db storingDB) Delete(_ context.Context, _ *domain.SongInfo) error {
+	db.stored = nil
+	return nil
+}
+
+func TestRepository_Delete_WriteBehindPending(t *testing.T) {
+	db := storingDB{&stubDB{}}
+	cache := newMapCache()
+	queue := &stubQueue{}
+	repo := NewRepository(db, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	assert.NoError(t, repo.Create(context.Background(), song))
+
+	// Песня еще в очереди: она удаляется из кэша, а не теряется с 404
+	err := repo.Delete(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.NoError(t, err)
+	assert.NotContains(t, cache.songs, song.ID)
+	assert.Len(t, queue.writes, 1)
+
+	// Следующий перенос отбрасывает создание удаленной песни
+	flushed, err := repo.FlushWrites(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, flushed)
+	assert.Nil(t, db.stored)
+	assert.Empty(t, db.audited)
+	assert.Empty(t, queue.writes)
+	assert.Equal(t, map[string]int64{"flushed": 0, "dropped": 1}, repo.WriteBehindStats())
+
+	// Песни нет ни в базе, ни в кэше
+	err = repo.Delete(context.Background(), &domain.SongInfo{ID: song.ID})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestRepository_Delete_WriteBehindQueued(t *testing.T) {
+	db := storingDB{&stubDB{}}
+	cache := newMapCache()
+	queue := &stubQueue{}
+	repo := NewRepository(db, cache, queue, 0, slog.New(slogdiscard.NewDiscardHandler()))
+	ctx := context.Background()
+
+	song := &domain.Song{Name: "Hysteria", Group: "Muse"}
+	assert.NoError(t, repo.Create(ctx, song))
+	_, err := repo.FlushWrites(ctx, 10)
+	assert.NoError(t, err)
+
+	updated := *song
+	updated.Text = "It's bugging me"
+	assert.NoError(t, repo.Update(ctx, &domain.SongInfo{ID: song.ID}, &updated))
+	other := &domain.Song{Name: "Starlight", Group: "Muse"}
+	assert.NoError(t, repo.Create(ctx, other))
+
+	// Удаление не переносит очередь целиком: записи других песен ждут переноса
+	assert.NoError(t, repo.Delete(ctx, &domain.SongInfo{ID: song.ID}))
+	assert.Nil(t, db.stored)
+	assert.NotContains(t, cache.songs, song.ID)
+	assert.Len(t, queue.writes, 2)
+	if assert.Len(t, db.audited, 2) {
+		assert.Equal(t, domain.AuditDelete, db.audited[1].Action)
+	}
+
+	// Изменение, поставленное в очередь одновременно с удалением, вернуло песню в кэш
+	cache.songs[song.ID] = updated
+	queue.writes = append(queue.writes, &domain.PendingWrite{ID: "late", Op: domain.PendingUpdate, Song: &updated})
+
+	// Перенос отбрасывает изменения удаленной песни и не кэширует ее снова
+	flushed, err := repo.FlushWrites(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flushed)
+	assert.Empty(t, db.revisions)
+	assert.NotContains(t, cache.songs, song.ID)
+	assert.Contains(t, cache.songs, other.ID)
+	if assert.NotNil(t, db.stored) {
+		assert.Equal(t, other.ID, db.stored.ID)
+	}
+	assert.Equal(t, map[string]int64{"flushed": 2, "dropped": 2}, repo.WriteBehindStats())
+}
@@ -0,0 +1,182 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/importer"
+	"songLibrary/internal/service"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"time"
+)
+
+const releaseDateLayout = "2006-01-02"
+
+// ErrUnsupportedFile is returned for a seed file that is neither JSON nor CSV
+var ErrUnsupportedFile = errors.New("seed file must be .json or .csv")
+
+// sample is the seed loaded when no file is given
+//
+//go:embed songs.json
+var sample []byte
+
+// Songs is the storage seed songs are saved to
+type Songs interface {
+	ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error)
+	Create(ctx context.Context, song *domain.Song) error
+}
+
+type Seed struct {
+	Songs Songs
+	log   *slog.Logger
+}
+
+func New(songs Songs, log *slog.Logger) *Seed {
+	return &Seed{
+		Songs: songs,
+		log:   log,
+	}
+}
+
+// record is a song of a JSON seed file, the fields match the CSV import columns
+type record struct {
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+	ReleaseDate string `json:"release_date"`
+}
+
+// Load saves the songs of file, or of the built-in sample when file is empty,
+// if the library has no songs yet. Songs that already exist are skipped, so
+// a seed interrupted halfway can be loaded again. It returns the number of
+// songs saved.
+func (s *Seed) Load(ctx context.Context, file string) (int, error) {
+	const op = "Seed.Load"
+
+	log := s.log.With(slog.String("op", op), slog.String("file", file))
+
+	songs, err := s.read(file)
+	if err != nil {
+		log.Error("failed to read seed", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	existing, err := s.Songs.ReadAllWithFilter(ctx, &domain.SongFilter{}, 1, 0)
+	if err != nil {
+		log.Error("failed to check whether the library is empty", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(existing) > 0 {
+		log.Info("library isn't empty, seed skipped")
+		return 0, nil
+	}
+
+	created := 0
+	for _, song := range songs {
+		song.Text = service.NormalizeLyrics(song.Text)
+		song.Lyrics = service.ParseLyrics(song.Text)
+		err := s.Songs.Create(ctx, song)
+		if errors.Is(err, domain.ErrSongExists) {
+			continue
+		}
+		if err != nil {
+			log.Error("failed to save seed song", slog.String("song_name", song.Name), slog.String("group_name", song.Group), sl.Err(err))
+			return created, fmt.Errorf("%s: %w", op, err)
+		}
+		created++
+	}
+
+	log.Info("seed loaded", slog.Int("songs", created), slog.Int("skipped", len(songs)-created))
+	return created, nil
+}
+
+func (s *Seed) read(file string) ([]*domain.Song, error) {
+	if file == "" {
+		return Parse("songs.json", bytes.NewReader(sample))
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(file, f)
+}
+
+// Parse reads the songs of a seed file, its format is chosen by the
+// extension of name. Unlike an import, a seed with an invalid song is
+// rejected as a whole.
+func Parse(name string, r io.Reader) ([]*domain.Song, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return parseJSON(r)
+	case ".csv":
+		return parseCSV(r)
+	default:
+		return nil, ErrUnsupportedFile
+	}
+}
+
+func parseCSV(r io.Reader) ([]*domain.Song, error) {
+	records, rowErrors, err := importer.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(rowErrors) > 0 {
+		return nil, fmt.Errorf("line %d: %w", rowErrors[0].Line, rowErrors[0].Err)
+	}
+
+	songs := make([]*domain.Song, 0, len(records))
+	for _, record := range records {
+		songs = append(songs, record.Song)
+	}
+	return songs, nil
+}
+
+func parseJSON(r io.Reader) ([]*domain.Song, error) {
+	var raw []record
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	songs := make([]*domain.Song, 0, len(raw))
+	for i, r := range raw {
+		song := &domain.Song{
+			Name:  strings.TrimSpace(r.Name),
+			Group: strings.TrimSpace(r.Group),
+			Text:  r.Text,
+			Link:  r.Link,
+		}
+
+		switch {
+		case song.Name == "" && song.Group == "":
+			return nil, fmt.Errorf("song %d: %w", i+1, domain.ErrSongNameAndGroupIsNull)
+		case song.Name == "":
+			return nil, fmt.Errorf("song %d: %w", i+1, domain.ErrSongNameIsNull)
+		case song.Group == "":
+			return nil, fmt.Errorf("song %d: %w", i+1, domain.ErrSongGroupIsNull)
+		}
+
+		if r.ReleaseDate != "" {
+			releaseDate, err := time.Parse(releaseDateLayout, r.ReleaseDate)
+			if err != nil {
+				return nil, fmt.Errorf("song %d: %w: %q", i+1, domain.ErrInvalidReleaseDate, r.ReleaseDate)
+			}
+			song.ReleaseDate = releaseDate
+		}
+
+		songs = append(songs, song)
+	}
+	return songs, nil
+}
@@ -0,0 +1,116 @@
+package seed
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/repository"
+	"songLibrary/internal/repository/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSeed() (*Seed, *repository.Repository) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := repository.NewRepository(memory.NewStore(), memory.NewCache(), nil, 0, log)
+	return New(repo, log), repo
+}
+
+func TestSeed_Load_Sample(t *testing.T) {
+	s, repo := newTestSeed()
+	ctx := context.Background()
+
+	created, err := s.Load(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, 8, created)
+
+	song, err := repo.ReadByNameAndGroup(ctx, &domain.SongInfo{Name: "Paper Lanterns", Group: "The Harbour Lights"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2014, 5, 16, 0, 0, 0, 0, time.UTC), song.ReleaseDate)
+	assert.NotNil(t, song.Lyrics)
+
+	// Повторная загрузка в непустую библиотеку ничего не добавляет
+	created, err = s.Load(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, created)
+}
+
+func TestSeed_Load_File(t *testing.T) {
+	s, repo := newTestSeed()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "songs.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,group,release_date\nHysteria,Muse,2003-12-01\nCreep,Radiohead,\n"), 0o644))
+
+	created, err := s.Load(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+
+	songs, err := repo.ReadAllWithFilter(ctx, &domain.SongFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+}
+
+func TestSeed_Load_SkipsExisting(t *testing.T) {
+	s, _ := newTestSeed()
+
+	// Песни, которые уже сохранены, пропускаются, а не прерывают загрузку
+	s.Songs = &existingSongs{Songs: s.Songs, existing: "Northbound"}
+
+	created, err := s.Load(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, 7, created)
+}
+
+func TestSeed_Load_InvalidFile(t *testing.T) {
+	s, repo := newTestSeed()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "songs.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "Hysteria", "group": "Muse"}, {"name": "Creep"}]`), 0o644))
+
+	// Сид с ошибкой отвергается целиком
+	_, err := s.Load(ctx, path)
+	assert.ErrorIs(t, err, domain.ErrSongGroupIsNull)
+
+	songs, err := repo.ReadAllWithFilter(ctx, &domain.SongFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+}
+
+func TestParse(t *testing.T) {
+	songs, err := Parse("songs.JSON", strings.NewReader(`[{"name": " Hysteria ", "group": "Muse", "release_date": "2003-12-01"}]`))
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, "Hysteria", songs[0].Name)
+
+	_, err = Parse("songs.json", strings.NewReader(`[{"name": "Hysteria", "group": "Muse", "release_date": "01.12.2003"}]`))
+	assert.ErrorIs(t, err, domain.ErrInvalidReleaseDate)
+
+	_, err = Parse("songs.csv", strings.NewReader("name,group\nHysteria,\n"))
+	assert.ErrorIs(t, err, domain.ErrSongGroupIsNull)
+
+	_, err = Parse("songs.yaml", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnsupportedFile)
+}
+
+// existingSongs reports one song as already saved, like a concurrent seed would
+type existingSongs struct {
+	Songs
+	existing string
+}
+
+func (s *existingSongs) Create(ctx context.Context, song *domain.Song) error {
+	if song.Name == s.existing {
+		return domain.ErrSongExists
+	}
+	return s.Songs.Create(ctx, song)
+}
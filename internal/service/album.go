@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AlbumRepository interface {
+	Create(ctx context.Context, album *domain.Album) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.Album, error)
+	ReadAll(ctx context.Context, group string, limit, offset int) ([]*domain.Album, error)
+	Update(ctx context.Context, album *domain.Album) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	ReadSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+type AlbumService struct {
+	Repo AlbumRepository
+	log  *slog.Logger
+}
+
+func NewAlbumService(r AlbumRepository, log *slog.Logger) *AlbumService {
+	return &AlbumService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Add creates a new album.
+func (s *AlbumService) Add(ctx context.Context, album *domain.Album) error {
+	const op = "AlbumService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("album_title", album.Title),
+		slog.String("group_name", album.Group),
+	)
+
+	log.Info("attempting to add a new album")
+
+	if err := s.Repo.Create(ctx, album); err != nil {
+		log.Error("failed to save album", sl.Err(err))
+		return fmt.Errorf("%s: failed to save album: %w", op, err)
+	}
+
+	log.Info("album successfully added", slog.String("album_id", album.ID.String()))
+	return nil
+}
+
+// Get fetches an album by ID.
+func (s *AlbumService) Get(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	const op = "AlbumService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("album_id", id.String()),
+	)
+
+	log.Info("attempting to fetch album")
+
+	album, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlbumNotFound) {
+			log.Warn("album not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: album not found: %w", op, domain.ErrAlbumNotFound)
+		}
+		log.Error("failed to read album", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read album: %w", op, err)
+	}
+
+	log.Info("album successfully fetched")
+	return album, nil
+}
+
+// GetAll retrieves albums filtered by group with pagination.
+func (s *AlbumService) GetAll(ctx context.Context, group string, page, pageSize int) ([]*domain.Album, error) {
+	const op = "AlbumService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch albums", slog.Int("offset", offset))
+
+	albums, err := s.Repo.ReadAll(ctx, group, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch albums", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch albums: %w", op, err)
+	}
+
+	log.Info("albums successfully fetched", slog.Int("count", len(albums)))
+	return albums, nil
+}
+
+// Update changes an existing album. Empty fields of updatedAlbum keep their current values.
+func (s *AlbumService) Update(ctx context.Context, updatedAlbum *domain.Album) error {
+	const op = "AlbumService.Update"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("album_id", updatedAlbum.ID.String()),
+	)
+
+	log.Info("attempting to update album")
+
+	targetAlbum, err := s.Get(ctx, updatedAlbum.ID)
+	if err != nil {
+		log.Error("failed to fetch album", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	mergedAlbum := mergeAlbums(updatedAlbum, targetAlbum)
+
+	if err := s.Repo.Update(ctx, mergedAlbum); err != nil {
+		if errors.Is(err, domain.ErrAlbumNotFound) {
+			log.Warn("album not found during update", sl.Err(err))
+			return fmt.Errorf("%s: album not found: %w", op, domain.ErrAlbumNotFound)
+		}
+		log.Error("failed to update album", sl.Err(err))
+		return fmt.Errorf("%s: failed to update album: %w", op, err)
+	}
+
+	log.Info("album successfully updated")
+	return nil
+}
+
+// Delete removes an album, its songs stay in the library.
+func (s *AlbumService) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "AlbumService.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("album_id", id.String()),
+	)
+
+	log.Info("attempting to delete album")
+
+	if err := s.Repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrAlbumNotFound) {
+			log.Warn("album not found during deletion", sl.Err(err))
+			return fmt.Errorf("%s: album not found: %w", op, domain.ErrAlbumNotFound)
+		}
+		log.Error("failed to delete album", sl.Err(err))
+		return fmt.Errorf("%s: failed to delete album: %w", op, err)
+	}
+
+	log.Info("album successfully deleted")
+	return nil
+}
+
+// GetSongs retrieves the songs of an album.
+func (s *AlbumService) GetSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "AlbumService.GetSongs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("album_id", id.String()),
+	)
+
+	log.Info("attempting to fetch album songs")
+
+	// Make sure the album exists so an unknown ID isn't reported as an empty album
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	songs, err := s.Repo.ReadSongs(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch album songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch album songs: %w", op, err)
+	}
+
+	log.Info("album songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
+
+func mergeAlbums(updatedAlbum, targetAlbum *domain.Album) *domain.Album {
+	if updatedAlbum.Title == "" {
+		updatedAlbum.Title = targetAlbum.Title
+	}
+	if updatedAlbum.Group == "" {
+		updatedAlbum.Group = targetAlbum.Group
+	}
+	if updatedAlbum.ReleaseDate.IsZero() {
+		updatedAlbum.ReleaseDate = targetAlbum.ReleaseDate
+	}
+	if updatedAlbum.CoverLink == "" {
+		updatedAlbum.CoverLink = targetAlbum.CoverLink
+	}
+	updatedAlbum.CreatedAt = targetAlbum.CreatedAt
+
+	return updatedAlbum
+}
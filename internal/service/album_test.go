@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumService_Update_MergesFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAlbumRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	albumService := service.NewAlbumService(mockRepo, mockLog)
+
+	albumID := uuid.New()
+	releaseDate := time.Date(2006, 7, 3, 0, 0, 0, 0, time.UTC)
+	current := &domain.Album{
+		ID:          albumID,
+		Title:       "Black Holes and Revelations",
+		Group:       "Muse",
+		ReleaseDate: releaseDate,
+		CoverLink:   "https://example.com/cover.jpg",
+	}
+
+	mockRepo.EXPECT().Read(gomock.Any(), albumID).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, album *domain.Album) error {
+			// Незаполненные поля берутся из текущей версии альбома
+			assert.Equal(t, "Black Holes & Revelations", album.Title)
+			assert.Equal(t, "Muse", album.Group)
+			assert.Equal(t, releaseDate, album.ReleaseDate)
+			assert.Equal(t, "https://example.com/cover.jpg", album.CoverLink)
+			return nil
+		},
+	)
+
+	err := albumService.Update(context.Background(), &domain.Album{ID: albumID, Title: "Black Holes & Revelations"})
+	assert.NoError(t, err)
+}
+
+func TestAlbumService_GetSongs_AlbumNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAlbumRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	albumService := service.NewAlbumService(mockRepo, mockLog)
+
+	albumID := uuid.New()
+	mockRepo.EXPECT().Read(gomock.Any(), albumID).Return(nil, domain.ErrAlbumNotFound)
+
+	songs, err := albumService.GetSongs(context.Background(), albumID)
+	assert.Nil(t, songs)
+	assert.ErrorIs(t, err, domain.ErrAlbumNotFound)
+}
+
+func TestAlbumService_GetAll_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAlbumRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	albumService := service.NewAlbumService(mockRepo, mockLog)
+
+	// Третья страница по 10 альбомов начинается со смещения 20
+	mockRepo.EXPECT().ReadAll(gomock.Any(), "Muse", 10, 20).Return([]*domain.Album{}, nil)
+
+	albums, err := albumService.GetAll(context.Background(), "Muse", 3, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, albums)
+}
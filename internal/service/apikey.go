@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyPrefix marks the keys of the service, so leaked keys are easy to
+	// find in code and logs
+	apiKeyPrefix = "sl_"
+	apiKeySize   = 32
+	// apiKeyShownSize is how many characters of a key identify it in listings
+	apiKeyShownSize = len(apiKeyPrefix) + 8
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	ReadByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	ReadAll(ctx context.Context) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error)
+}
+
+type APIKeyService struct {
+	Repo APIKeyRepository
+	log  *slog.Logger
+}
+
+func NewAPIKeyService(r APIKeyRepository, log *slog.Logger) *APIKeyService {
+	return &APIKeyService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Create issues a new API key and returns it. The key is only returned here,
+// the service keeps its hash.
+func (s *APIKeyService) Create(ctx context.Context, key *domain.APIKey) (string, error) {
+	const op = "APIKeyService.Create"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("name", key.Name),
+		slog.String("role", string(key.Role)),
+	)
+
+	log.Info("attempting to create a new api key")
+
+	if err := validateAPIKey(key, time.Now()); err != nil {
+		log.Warn("invalid api key", sl.Err(err))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	secret := make([]byte, apiKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error("failed to generate api key", sl.Err(err))
+		return "", fmt.Errorf("%s: failed to generate api key: %w", op, err)
+	}
+	raw := apiKeyPrefix + hex.EncodeToString(secret)
+	key.Prefix = raw[:apiKeyShownSize]
+	key.Hash = hashAPIKey(raw)
+
+	if err := s.Repo.Create(ctx, key); err != nil {
+		if errors.Is(err, domain.ErrLibraryNotFound) {
+			log.Warn("library of api key not found", sl.Err(err))
+			return "", fmt.Errorf("%s: %w", op, domain.ErrLibraryNotFound)
+		}
+		log.Error("failed to save api key", sl.Err(err))
+		return "", fmt.Errorf("%s: failed to save api key: %w", op, err)
+	}
+
+	log.Info("api key successfully created", slog.String("api_key_id", key.ID.String()))
+	return raw, nil
+}
+
+// Get fetches an API key by ID.
+func (s *APIKeyService) Get(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	const op = "APIKeyService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("api_key_id", id.String()),
+	)
+
+	log.Info("attempting to fetch api key")
+
+	key, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			log.Warn("api key not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: api key not found: %w", op, domain.ErrAPIKeyNotFound)
+		}
+		log.Error("failed to read api key", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read api key: %w", op, err)
+	}
+
+	log.Info("api key successfully fetched")
+	return key, nil
+}
+
+// GetAll retrieves all API keys, revoked ones included.
+func (s *APIKeyService) GetAll(ctx context.Context) ([]*domain.APIKey, error) {
+	const op = "APIKeyService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+	)
+
+	log.Info("attempting to fetch api keys")
+
+	keys, err := s.Repo.ReadAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch api keys", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch api keys: %w", op, err)
+	}
+
+	log.Info("api keys successfully fetched", slog.Int("count", len(keys)))
+	return keys, nil
+}
+
+// Revoke stops an API key from being accepted, revoked keys are kept so
+// they can still be listed.
+func (s *APIKeyService) Revoke(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	const op = "APIKeyService.Revoke"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("api_key_id", id.String()),
+	)
+
+	log.Info("attempting to revoke api key")
+
+	key, err := s.Repo.Revoke(ctx, id, time.Now())
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			log.Warn("api key not found during revocation", sl.Err(err))
+			return nil, fmt.Errorf("%s: api key not found: %w", op, domain.ErrAPIKeyNotFound)
+		}
+		log.Error("failed to revoke api key", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to revoke api key: %w", op, err)
+	}
+
+	log.Info("api key successfully revoked")
+	return key, nil
+}
+
+// Authenticate returns the active API key matching raw, ErrAPIKeyInvalid if
+// there is none.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*domain.APIKey, error) {
+	const op = "APIKeyService.Authenticate"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+	)
+
+	key, err := s.Repo.ReadByHash(ctx, hashAPIKey(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyInvalid)
+		}
+		log.Error("failed to read api key", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read api key: %w", op, err)
+	}
+
+	if !key.Active(time.Now()) {
+		log.Warn("api key is revoked or expired", slog.String("api_key_id", key.ID.String()))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAPIKeyInvalid)
+	}
+
+	return key, nil
+}
+
+func validateAPIKey(key *domain.APIKey, now time.Time) error {
+	if key.Name == "" {
+		return domain.ErrAPIKeyNameIsNull
+	}
+	if !key.Role.Valid() {
+		return domain.ErrInvalidRole
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return domain.ErrAPIKeyExpiryInPast
+	}
+	return nil
+}
+
+// hashAPIKey returns the hash a key is stored and looked up by. Keys are
+// random, so a fast hash is enough to keep them from being read back.
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
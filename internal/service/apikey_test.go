@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIKeyService(t *testing.T) (*service.APIKeyService, *mocks.MockAPIKeyRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewAPIKeyService(mockRepo, mockLog), mockRepo
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	apiKeyService, mockRepo := newAPIKeyService(t)
+
+	var stored *domain.APIKey
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.APIKey) error {
+		stored = key
+		return nil
+	})
+
+	raw, err := apiKeyService.Create(context.Background(), &domain.APIKey{Name: "ci", Role: domain.RoleEditor})
+	require.NoError(t, err)
+
+	// Хранится только хеш ключа и его начало для списка
+	sum := sha256.Sum256([]byte(raw))
+	assert.True(t, strings.HasPrefix(raw, "sl_"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.Hash)
+	assert.True(t, strings.HasPrefix(raw, stored.Prefix))
+	assert.NotEqual(t, raw, stored.Prefix)
+}
+
+func TestAPIKeyService_Create_Invalid(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		key  *domain.APIKey
+		err  error
+	}{
+		{name: "без названия", key: &domain.APIKey{Role: domain.RoleViewer}, err: domain.ErrAPIKeyNameIsNull},
+		{name: "неизвестная роль", key: &domain.APIKey{Name: "ci", Role: "owner"}, err: domain.ErrInvalidRole},
+		{name: "срок в прошлом", key: &domain.APIKey{Name: "ci", Role: domain.RoleViewer, ExpiresAt: &past}, err: domain.ErrAPIKeyExpiryInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyService, _ := newAPIKeyService(t)
+
+			// Репозиторий не должен вызываться
+			_, err := apiKeyService.Create(context.Background(), tt.key)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		key     *domain.APIKey
+		wantErr bool
+	}{
+		{name: "действующий ключ", key: &domain.APIKey{ID: uuid.New(), Role: domain.RoleViewer, ExpiresAt: &future}},
+		{name: "отозванный ключ", key: &domain.APIKey{ID: uuid.New(), Role: domain.RoleViewer, RevokedAt: &past}, wantErr: true},
+		{name: "просроченный ключ", key: &domain.APIKey{ID: uuid.New(), Role: domain.RoleViewer, ExpiresAt: &past}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyService, mockRepo := newAPIKeyService(t)
+
+			sum := sha256.Sum256([]byte("sl_key"))
+			mockRepo.EXPECT().ReadByHash(gomock.Any(), hex.EncodeToString(sum[:])).Return(tt.key, nil)
+
+			key, err := apiKeyService.Authenticate(context.Background(), "sl_key")
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate_Unknown(t *testing.T) {
+	apiKeyService, mockRepo := newAPIKeyService(t)
+
+	mockRepo.EXPECT().ReadByHash(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAPIKeyNotFound)
+
+	_, err := apiKeyService.Authenticate(context.Background(), "sl_unknown")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+}
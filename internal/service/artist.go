@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist *domain.Artist) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.Artist, error)
+	ReadAll(ctx context.Context, name string, limit, offset int) ([]*domain.Artist, error)
+	Update(ctx context.Context, artist *domain.Artist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	ReadSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error)
+}
+
+type ArtistService struct {
+	Repo ArtistRepository
+	log  *slog.Logger
+}
+
+func NewArtistService(r ArtistRepository, log *slog.Logger) *ArtistService {
+	return &ArtistService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Add creates a new artist.
+func (s *ArtistService) Add(ctx context.Context, artist *domain.Artist) error {
+	const op = "ArtistService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("artist_name", artist.Name),
+	)
+
+	log.Info("attempting to add a new artist")
+
+	if artist.Name == "" {
+		log.Warn("artist name is empty")
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistNameIsNull)
+	}
+
+	if err := s.Repo.Create(ctx, artist); err != nil {
+		if errors.Is(err, domain.ErrArtistExists) {
+			log.Warn("artist already exists", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+		}
+		log.Error("failed to save artist", sl.Err(err))
+		return fmt.Errorf("%s: failed to save artist: %w", op, err)
+	}
+
+	log.Info("artist successfully added", slog.String("artist_id", artist.ID.String()))
+	return nil
+}
+
+// Get fetches an artist by ID.
+func (s *ArtistService) Get(ctx context.Context, id uuid.UUID) (*domain.Artist, error) {
+	const op = "ArtistService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("artist_id", id.String()),
+	)
+
+	log.Info("attempting to fetch artist")
+
+	artist, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrArtistNotFound) {
+			log.Warn("artist not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: artist not found: %w", op, domain.ErrArtistNotFound)
+		}
+		log.Error("failed to read artist", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read artist: %w", op, err)
+	}
+
+	log.Info("artist successfully fetched")
+	return artist, nil
+}
+
+// GetAll retrieves artists filtered by name with pagination.
+func (s *ArtistService) GetAll(ctx context.Context, name string, page, pageSize int) ([]*domain.Artist, error) {
+	const op = "ArtistService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch artists", slog.Int("offset", offset))
+
+	artists, err := s.Repo.ReadAll(ctx, name, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch artists", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch artists: %w", op, err)
+	}
+
+	log.Info("artists successfully fetched", slog.Int("count", len(artists)))
+	return artists, nil
+}
+
+// Update renames an artist, the new name is applied to all of its songs.
+func (s *ArtistService) Update(ctx context.Context, artist *domain.Artist) error {
+	const op = "ArtistService.Update"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("artist_id", artist.ID.String()),
+	)
+
+	log.Info("attempting to update artist")
+
+	if artist.Name == "" {
+		log.Warn("artist name is empty")
+		return fmt.Errorf("%s: %w", op, domain.ErrArtistNameIsNull)
+	}
+
+	if err := s.Repo.Update(ctx, artist); err != nil {
+		if errors.Is(err, domain.ErrArtistNotFound) {
+			log.Warn("artist not found during update", sl.Err(err))
+			return fmt.Errorf("%s: artist not found: %w", op, domain.ErrArtistNotFound)
+		}
+		if errors.Is(err, domain.ErrArtistExists) {
+			log.Warn("artist name is already taken", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistExists)
+		}
+		if errors.Is(err, domain.ErrSongExists) {
+			log.Warn("renamed artist has a song that already exists", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, domain.ErrSongExists)
+		}
+		log.Error("failed to update artist", sl.Err(err))
+		return fmt.Errorf("%s: failed to update artist: %w", op, err)
+	}
+
+	log.Info("artist successfully updated")
+	return nil
+}
+
+// Delete removes an artist that has no songs left.
+func (s *ArtistService) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "ArtistService.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("artist_id", id.String()),
+	)
+
+	log.Info("attempting to delete artist")
+
+	if err := s.Repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrArtistNotFound) {
+			log.Warn("artist not found during deletion", sl.Err(err))
+			return fmt.Errorf("%s: artist not found: %w", op, domain.ErrArtistNotFound)
+		}
+		if errors.Is(err, domain.ErrArtistHasSongs) {
+			log.Warn("artist still has songs", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, domain.ErrArtistHasSongs)
+		}
+		log.Error("failed to delete artist", sl.Err(err))
+		return fmt.Errorf("%s: failed to delete artist: %w", op, err)
+	}
+
+	log.Info("artist successfully deleted")
+	return nil
+}
+
+// GetSongs retrieves the songs of an artist.
+func (s *ArtistService) GetSongs(ctx context.Context, id uuid.UUID) ([]*domain.Song, error) {
+	const op = "ArtistService.GetSongs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("artist_id", id.String()),
+	)
+
+	log.Info("attempting to fetch artist songs")
+
+	// Make sure the artist exists so an unknown ID isn't reported as an artist without songs
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	songs, err := s.Repo.ReadSongs(ctx, id)
+	if err != nil {
+		log.Error("failed to fetch artist songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch artist songs: %w", op, err)
+	}
+
+	log.Info("artist songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
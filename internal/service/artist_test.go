@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtistService_Add_EmptyName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockArtistRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	artistService := service.NewArtistService(mockRepo, mockLog)
+
+	// Репозиторий не должен вызываться для исполнителя без имени
+	err := artistService.Add(context.Background(), &domain.Artist{})
+	assert.ErrorIs(t, err, domain.ErrArtistNameIsNull)
+}
+
+func TestArtistService_Update_NameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockArtistRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	artistService := service.NewArtistService(mockRepo, mockLog)
+
+	artist := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	mockRepo.EXPECT().Update(gomock.Any(), artist).Return(domain.ErrArtistExists)
+
+	err := artistService.Update(context.Background(), artist)
+	assert.ErrorIs(t, err, domain.ErrArtistExists)
+}
+
+func TestArtistService_Delete_HasSongs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockArtistRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	artistService := service.NewArtistService(mockRepo, mockLog)
+
+	artistID := uuid.New()
+	mockRepo.EXPECT().Delete(gomock.Any(), artistID).Return(domain.ErrArtistHasSongs)
+
+	err := artistService.Delete(context.Background(), artistID)
+	assert.ErrorIs(t, err, domain.ErrArtistHasSongs)
+}
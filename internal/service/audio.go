@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/audiometa"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AudioRepository interface {
+	Save(ctx context.Context, audio *domain.Audio) error
+	Read(ctx context.Context, songID uuid.UUID) (*domain.Audio, error)
+}
+
+type AudioService struct {
+	Repo    AudioRepository
+	Songs   SongReader
+	Storage BlobStorage
+	MaxSize int64
+	log     *slog.Logger
+}
+
+func NewAudioService(r AudioRepository, songs SongReader, storage BlobStorage, maxSize int64, log *slog.Logger) *AudioService {
+	return &AudioService{
+		Repo:    r,
+		Songs:   songs,
+		Storage: storage,
+		MaxSize: maxSize,
+		log:     log,
+	}
+}
+
+// audioKey is the key the audio file of a song is stored under
+func audioKey(songID uuid.UUID) string {
+	return "audio/" + songID.String()
+}
+
+// Upload stores the audio file read from body for a song, replacing the
+// previous one. The file is buffered in a temporary file, so its format,
+// duration and bitrate are known before it is stored.
+func (s *AudioService) Upload(ctx context.Context, songID uuid.UUID, body io.Reader) (*domain.Audio, error) {
+	const op = "AudioService.Upload"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp("", "audio-*")
+	if err != nil {
+		log.Error("failed to create temporary file", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to create temporary file: %w", op, err)
+	}
+	defer func() {
+		tmp.Close()
+		if err := os.Remove(tmp.Name()); err != nil {
+			log.Warn("failed to remove temporary file", sl.Err(err))
+		}
+	}()
+
+	size, err := io.Copy(tmp, io.LimitReader(body, s.MaxSize+1))
+	if err != nil {
+		log.Error("failed to read audio", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read audio: %w", op, err)
+	}
+	if size > s.MaxSize {
+		log.Warn("audio is too large", slog.Int64("max_size", s.MaxSize))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAudioTooLarge)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		log.Error("failed to rewind temporary file", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	meta, err := audiometa.Parse(tmp, size)
+	if errors.Is(err, audiometa.ErrUnsupportedFormat) {
+		log.Warn("audio is not a supported format", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrAudioInvalidFormat)
+	}
+	if err != nil {
+		log.Error("failed to read audio metadata", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read audio metadata: %w", op, err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		log.Error("failed to rewind temporary file", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to store audio",
+		slog.String("format", meta.Format),
+		slog.Int64("size", size),
+		slog.Duration("duration", meta.Duration),
+	)
+
+	if err := s.Storage.Put(ctx, audioKey(songID), tmp, size, meta.ContentType); err != nil {
+		log.Error("failed to store audio", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to store audio: %w", op, err)
+	}
+
+	audio := &domain.Audio{
+		SongID:      songID,
+		Format:      meta.Format,
+		ContentType: meta.ContentType,
+		Size:        size,
+		Duration:    meta.Duration,
+		Bitrate:     meta.Bitrate,
+		SampleRate:  meta.SampleRate,
+	}
+	if err := s.Repo.Save(ctx, audio); err != nil {
+		log.Error("failed to save audio", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("audio successfully stored")
+	return audio, nil
+}
+
+// Open returns the audio of a song and its content. The content is fetched
+// from the storage only from the position it is read at, so seeking to a
+// range doesn't transfer the file before it. It must be closed by the caller.
+func (s *AudioService) Open(ctx context.Context, songID uuid.UUID) (*domain.Audio, io.ReadSeekCloser, error) {
+	const op = "AudioService.Open"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	audio, err := s.Repo.Read(ctx, songID)
+	if errors.Is(err, domain.ErrAudioNotFound) {
+		log.Info("song has no audio")
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err != nil {
+		log.Error("failed to fetch audio", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return audio, &blobReader{
+		ctx:     ctx,
+		storage: s.Storage,
+		key:     audioKey(songID),
+		size:    audio.Size,
+	}, nil
+}
+
+// blobReader reads a blob of a known size through ranges of the storage.
+// A range from the current offset to the end is requested on the first read
+// after a seek.
+type blobReader struct {
+	ctx     context.Context
+	storage BlobStorage
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (b *blobReader) Read(p []byte) (int, error) {
+	if b.offset >= b.size {
+		return 0, io.EOF
+	}
+
+	if b.body == nil {
+		body, err := b.storage.GetRange(b.ctx, b.key, b.offset, b.size-b.offset)
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			return 0, fmt.Errorf("%w: %w", domain.ErrAudioNotFound, err)
+		}
+		if err != nil {
+			return 0, err
+		}
+		b.body = body
+	}
+
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	if err == io.EOF && b.offset < b.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != b.offset {
+		if err := b.Close(); err != nil {
+			return 0, err
+		}
+		b.offset = offset
+	}
+	return offset, nil
+}
+
+func (b *blobReader) Close() error {
+	if b.body == nil {
+		return nil
+	}
+	err := b.body.Close()
+	b.body = nil
+	return err
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wavSecond возвращает секунду тишины в WAV: 8000 Гц, моно, 8 бит
+func wavSecond() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+8000))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, struct {
+		Size          uint32
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{16, 1, 1, 8000, 8000, 1, 8})
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(8000))
+	buf.Write(bytes.Repeat([]byte{0x80}, 8000))
+	return buf.Bytes()
+}
+
+type audioMocks struct {
+	repo    *mocks.MockAudioRepository
+	songs   *mocks.MockSongReader
+	storage *mocks.MockBlobStorage
+}
+
+func newAudioService(t *testing.T, maxSize int64) (*service.AudioService, audioMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := audioMocks{
+		repo:    mocks.NewMockAudioRepository(ctrl),
+		songs:   mocks.NewMockSongReader(ctrl),
+		storage: mocks.NewMockBlobStorage(ctrl),
+	}
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewAudioService(m.repo, m.songs, m.storage, maxSize, mockLog), m
+}
+
+func TestAudioService_Upload(t *testing.T) {
+	audioService, m := newAudioService(t, 1<<20)
+
+	wav := wavSecond()
+	songID := uuid.New()
+	m.songs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	m.storage.EXPECT().
+		Put(gomock.Any(), "audio/"+songID.String(), gomock.Any(), int64(len(wav)), "audio/wav").
+		DoAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+			// В хранилище попадает весь файл, а не остаток после разбора заголовков
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, wav, data)
+			return nil
+		})
+
+	expected := &domain.Audio{
+		SongID:      songID,
+		Format:      "wav",
+		ContentType: "audio/wav",
+		Size:        int64(len(wav)),
+		Duration:    time.Second,
+		Bitrate:     64000,
+		SampleRate:  8000,
+	}
+	m.repo.EXPECT().Save(gomock.Any(), expected).Return(nil)
+
+	audio, err := audioService.Upload(context.Background(), songID, bytes.NewReader(wav))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, audio)
+}
+
+func TestAudioService_Upload_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    []byte
+		maxSize int64
+		err     error
+	}{
+		{name: "слишком большой файл", body: wavSecond(), maxSize: 1024, err: domain.ErrAudioTooLarge},
+		{name: "не аудио", body: []byte(strings.Repeat("It's bugging me ", 100)), maxSize: 1 << 20, err: domain.ErrAudioInvalidFormat},
+		{name: "пустое тело", body: nil, maxSize: 1 << 20, err: domain.ErrAudioInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audioService, m := newAudioService(t, tt.maxSize)
+
+			songID := uuid.New()
+			m.songs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+
+			// В хранилище и базу ничего не записывается
+			_, err := audioService.Upload(context.Background(), songID, bytes.NewReader(tt.body))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestAudioService_Upload_SongNotFound(t *testing.T) {
+	audioService, m := newAudioService(t, 1<<20)
+
+	songID := uuid.New()
+	m.songs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	_, err := audioService.Upload(context.Background(), songID, bytes.NewReader(wavSecond()))
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestAudioService_Open(t *testing.T) {
+	audioService, m := newAudioService(t, 1<<20)
+
+	songID := uuid.New()
+	key := "audio/" + songID.String()
+	content := "0123456789"
+	m.repo.EXPECT().Read(gomock.Any(), songID).Return(&domain.Audio{SongID: songID, Size: int64(len(content))}, nil)
+
+	audio, body, err := audioService.Open(context.Background(), songID)
+	require.NoError(t, err)
+	defer body.Close()
+	assert.Equal(t, songID, audio.SongID)
+
+	// Размер известен без обращения к хранилищу
+	size, err := body.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+
+	// Чтение после перемотки запрашивает диапазон от текущей позиции до конца
+	_, err = body.Seek(6, io.SeekStart)
+	assert.NoError(t, err)
+	m.storage.EXPECT().GetRange(gomock.Any(), key, int64(6), int64(4)).
+		Return(io.NopCloser(strings.NewReader(content[6:])), nil)
+
+	data, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "6789", string(data))
+}
+
+func TestAudioService_Open_NotFound(t *testing.T) {
+	audioService, m := newAudioService(t, 1<<20)
+
+	songID := uuid.New()
+	m.repo.EXPECT().Read(gomock.Any(), songID).Return(nil, domain.ErrAudioNotFound)
+
+	_, _, err := audioService.Open(context.Background(), songID)
+	assert.ErrorIs(t, err, domain.ErrAudioNotFound)
+}
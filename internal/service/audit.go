@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type AuditRepository interface {
+	ReadHistory(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error)
+}
+
+type AuditService struct {
+	Repo AuditRepository
+	log  *slog.Logger
+}
+
+func NewAuditService(r AuditRepository, log *slog.Logger) *AuditService {
+	return &AuditService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// History returns the recorded changes of a song, oldest first. The history
+// of a deleted song is still available.
+func (s *AuditService) History(ctx context.Context, songID uuid.UUID, page, pageSize int) ([]*domain.AuditEntry, error) {
+	const op = "AuditService.History"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch song history", slog.Int("offset", offset))
+
+	entries, err := s.Repo.ReadHistory(ctx, songID, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch song history", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song history: %w", op, err)
+	}
+
+	log.Info("song history successfully fetched", slog.Int("count", len(entries)))
+	return entries, nil
+}
@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditService_History_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuditRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	auditService := service.NewAuditService(mockRepo, mockLog)
+
+	// Третья страница по 10 записей начинается со смещения 20
+	songID := uuid.New()
+	entries := []*domain.AuditEntry{{ID: 21, SongID: songID, Action: domain.AuditUpdate}}
+	mockRepo.EXPECT().ReadHistory(gomock.Any(), songID, 10, 20).Return(entries, nil)
+
+	history, err := auditService.History(context.Background(), songID, 3, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, entries, history)
+}
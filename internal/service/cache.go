@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+type CacheRepository interface {
+	CacheRecovery(ctx context.Context, batchSize, limit int) (int, error)
+	CacheStats(ctx context.Context) (*domain.CacheStats, error)
+	InvalidateCache(ctx context.Context, id uuid.UUID) error
+	FlushCache(ctx context.Context) (int64, error)
+}
+
+// CacheService rebuilds the song cache from the database. Only one rebuild
+// runs at a time.
+type CacheService struct {
+	Repo      CacheRepository
+	batchSize int
+	limit     int
+	running   atomic.Bool
+	log       *slog.Logger
+}
+
+func NewCacheService(r CacheRepository, batchSize, limit int, log *slog.Logger) *CacheService {
+	return &CacheService{
+		Repo:      r,
+		batchSize: batchSize,
+		limit:     limit,
+		log:       log,
+	}
+}
+
+// Rebuild caches the newest songs and returns how many were cached
+func (s *CacheService) Rebuild(ctx context.Context) (int, error) {
+	const op = "CacheService.Rebuild"
+
+	if !s.running.CompareAndSwap(false, true) {
+		s.log.Warn("cache rebuild is already running", slog.String("op", op))
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrCacheRebuildRunning)
+	}
+	defer s.running.Store(false)
+
+	return s.rebuild(ctx)
+}
+
+// StartRebuild runs a rebuild in the background. The rebuild outlives ctx, so
+// it isn't aborted when the request that started it completes.
+func (s *CacheService) StartRebuild(ctx context.Context) error {
+	const op = "CacheService.StartRebuild"
+
+	if !s.running.CompareAndSwap(false, true) {
+		s.log.Warn("cache rebuild is already running", slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, domain.ErrCacheRebuildRunning)
+	}
+
+	go func() {
+		defer s.running.Store(false)
+		s.rebuild(context.WithoutCancel(ctx))
+	}()
+
+	return nil
+}
+
+// Stats reports the state of the cache
+func (s *CacheService) Stats(ctx context.Context) (*domain.CacheStats, error) {
+	const op = "CacheService.Stats"
+
+	stats, err := s.Repo.CacheStats(ctx)
+	if err != nil {
+		s.log.Error("failed to get cache stats", slog.String("op", op), sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to get cache stats: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+// Invalidate evicts a song from the cache
+func (s *CacheService) Invalidate(ctx context.Context, id uuid.UUID) error {
+	const op = "CacheService.Invalidate"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", id.String()),
+	)
+
+	if err := s.Repo.InvalidateCache(ctx, id); err != nil {
+		log.Error("failed to invalidate cached song", sl.Err(err))
+		return fmt.Errorf("%s: failed to invalidate cached song: %w", op, err)
+	}
+
+	log.Info("cached song invalidated")
+	return nil
+}
+
+// Flush evicts every cached entry and returns how many were evicted
+func (s *CacheService) Flush(ctx context.Context) (int64, error) {
+	const op = "CacheService.Flush"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	deleted, err := s.Repo.FlushCache(ctx)
+	if err != nil {
+		log.Error("failed to flush cache", sl.Err(err), slog.Int64("deleted", deleted))
+		return deleted, fmt.Errorf("%s: failed to flush cache: %w", op, err)
+	}
+
+	log.Info("cache flushed", slog.Int64("deleted", deleted))
+	return deleted, nil
+}
+
+func (s *CacheService) rebuild(ctx context.Context) (int, error) {
+	const op = "CacheService.rebuild"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Info("attempting to rebuild cache")
+
+	cached, err := s.Repo.CacheRecovery(ctx, s.batchSize, s.limit)
+	if err != nil {
+		log.Error("failed to rebuild cache", sl.Err(err), slog.Int("cached", cached))
+		return cached, fmt.Errorf("%s: failed to rebuild cache: %w", op, err)
+	}
+
+	log.Info("cache successfully rebuilt", slog.Int("cached", cached))
+	return cached, nil
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheService_Rebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCacheRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cacheService := service.NewCacheService(mockRepo, 100, 1000, mockLog)
+
+	mockRepo.EXPECT().CacheRecovery(gomock.Any(), 100, 1000).Return(42, nil)
+
+	cached, err := cacheService.Rebuild(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, cached)
+}
+
+func TestCacheService_Rebuild_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCacheRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cacheService := service.NewCacheService(mockRepo, 100, 0, mockLog)
+
+	// Второй запуск во время первого отклоняется
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.EXPECT().CacheRecovery(gomock.Any(), 100, 0).DoAndReturn(
+		func(context.Context, int, int) (int, error) {
+			close(started)
+			<-release
+			return 0, nil
+		})
+
+	assert.NoError(t, cacheService.StartRebuild(context.Background()))
+	<-started
+
+	_, err := cacheService.Rebuild(context.Background())
+	assert.ErrorIs(t, err, domain.ErrCacheRebuildRunning)
+	assert.ErrorIs(t, cacheService.StartRebuild(context.Background()), domain.ErrCacheRebuildRunning)
+
+	close(release)
+}
+
+func TestCacheService_Rebuild_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCacheRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cacheService := service.NewCacheService(mockRepo, 100, 0, mockLog)
+
+	repoErr := errors.New("redis is down")
+	mockRepo.EXPECT().CacheRecovery(gomock.Any(), 100, 0).Return(10, repoErr)
+
+	cached, err := cacheService.Rebuild(context.Background())
+	assert.ErrorIs(t, err, repoErr)
+	assert.Equal(t, 10, cached)
+}
+
+func TestCacheService_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCacheRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cacheService := service.NewCacheService(mockRepo, 100, 0, mockLog)
+
+	mockRepo.EXPECT().FlushCache(gomock.Any()).Return(int64(5), nil)
+
+	deleted, err := cacheService.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
+
+func TestCacheService_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCacheRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cacheService := service.NewCacheService(mockRepo, 100, 0, mockLog)
+
+	songID := uuid.New()
+	repoErr := errors.New("redis is down")
+	mockRepo.EXPECT().InvalidateCache(gomock.Any(), songID).Return(repoErr)
+
+	err := cacheService.Invalidate(context.Background(), songID)
+	assert.ErrorIs(t, err, repoErr)
+}
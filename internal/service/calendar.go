@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type CalendarRepository interface {
+	ReadReleaseCalendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error)
+}
+
+type CalendarService struct {
+	Repo CalendarRepository
+	log  *slog.Logger
+}
+
+func NewCalendarService(r CalendarRepository, log *slog.Logger) *CalendarService {
+	return &CalendarService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Calendar returns the days of the year songs were released on with the
+// songs released on each, in calendar order. A zero year takes the songs of
+// every year, for anniversaries of releases. excludeExplicit drops the songs
+// flagged explicit.
+func (s *CalendarService) Calendar(ctx context.Context, year int, excludeExplicit bool) ([]*domain.CalendarDay, error) {
+	const op = "CalendarService.Calendar"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("year", year),
+		slog.Bool("exclude_explicit", excludeExplicit),
+	)
+
+	days, err := s.Repo.ReadReleaseCalendar(ctx, year, excludeExplicit)
+	if err != nil {
+		log.Error("failed to fetch release calendar", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch release calendar: %w", op, err)
+	}
+
+	log.Debug("release calendar successfully fetched", slog.Int("days", len(days)))
+	return days, nil
+}
@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarService_Calendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalendarRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	calendarService := service.NewCalendarService(mockRepo, mockLog)
+
+	days := []*domain.CalendarDay{{Month: time.December, Day: 1, Songs: []*domain.Song{{Name: "Hysteria"}}}}
+	mockRepo.EXPECT().ReadReleaseCalendar(gomock.Any(), 2003, true).Return(days, nil)
+
+	result, err := calendarService.Calendar(context.Background(), 2003, true)
+	assert.NoError(t, err)
+	assert.Equal(t, days, result)
+
+	mockRepo.EXPECT().ReadReleaseCalendar(gomock.Any(), 0, false).Return(nil, errors.New("connection refused"))
+	_, err = calendarService.Calendar(context.Background(), 0, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"time"
+)
+
+// ContentFilter flags lyrics containing any word or phrase of its list.
+// Matching ignores case and only matches whole words, a trailing * matches
+// any ending of a word. A nil filter matches nothing.
+type ContentFilter struct {
+	pattern *regexp.Regexp
+}
+
+// NewContentFilter compiles the word list, blank entries are skipped
+func NewContentFilter(words []string) (*ContentFilter, error) {
+	var alternatives []string
+	for _, word := range words {
+		fields := strings.Fields(NormalizeLyrics(word))
+		if len(fields) == 0 {
+			continue
+		}
+		for i, field := range fields {
+			prefix, wildcard := strings.CutSuffix(field, "*")
+			if prefix == "" {
+				return nil, fmt.Errorf("invalid word %q", word)
+			}
+			fields[i] = regexp.QuoteMeta(prefix)
+			if wildcard {
+				fields[i] += `[\p{L}\p{N}]*`
+			}
+		}
+		alternatives = append(alternatives, strings.Join(fields, `\s+`))
+	}
+	if len(alternatives) == 0 {
+		return nil, errors.New("word list is empty")
+	}
+
+	pattern, err := regexp.Compile(`(?i)(?:^|[^\p{L}\p{N}])(?:` + strings.Join(alternatives, "|") + `)(?:$|[^\p{L}\p{N}])`)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentFilter{pattern: pattern}, nil
+}
+
+// Match reports whether the text contains a word of the list
+func (f *ContentFilter) Match(text string) bool {
+	if f == nil {
+		return false
+	}
+	return f.pattern.MatchString(NormalizeLyrics(text))
+}
+
+// Flag marks the song explicit when its text matches. The filter only adds
+// the flag: a clean text leaves the flag MusicInfo or an editor set.
+func (f *ContentFilter) Flag(song *domain.Song) bool {
+	if song.Explicit != nil && *song.Explicit || !f.Match(song.Text) {
+		return false
+	}
+	explicit := true
+	song.Explicit = &explicit
+	return true
+}
+
+// ContentScanService runs a ContentFilter over the songs saved before it was
+// enabled or its list changed, library by library and BatchSize songs at a
+// time
+type ContentScanService struct {
+	Repo      NormalizeRepository
+	Libraries LibraryLister
+	// Filter is nil while content filtering is disabled
+	Filter    *ContentFilter
+	BatchSize int
+	log       *slog.Logger
+}
+
+func NewContentScanService(r NormalizeRepository, libraries LibraryLister, filter *ContentFilter, log *slog.Logger) *ContentScanService {
+	return &ContentScanService{
+		Repo:      r,
+		Libraries: libraries,
+		Filter:    filter,
+		BatchSize: defaultNormalizeBatchSize,
+		log:       log,
+	}
+}
+
+// Scan flags the songs whose texts match the filter and saves them, with
+// dryRun they are only counted. Songs whose explicit flag was edited keep
+// it. A song that fails to save is counted and skipped.
+func (s *ContentScanService) Scan(ctx context.Context, dryRun bool) (*domain.ContentScanReport, error) {
+	const op = "ContentScanService.Scan"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	if s.Filter == nil {
+		log.Warn("content filter is disabled")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrContentFilterDisabled)
+	}
+
+	log.Info("content scan started")
+
+	libraries, err := s.Libraries.GetAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch libraries", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch libraries: %w", op, err)
+	}
+
+	report := &domain.ContentScanReport{DryRun: dryRun}
+	for _, library := range libraries {
+		libraryCtx := domain.WithLibraryID(ctx, library.ID)
+		err := walkLibrary(libraryCtx, s.Repo, s.BatchSize, func(song *domain.Song) {
+			report.Scanned++
+
+			flagged := *song
+			if flagged.LockedFields.Has(domain.FieldExplicit) || !s.Filter.Flag(&flagged) {
+				return
+			}
+			report.Flagged++
+			if dryRun {
+				return
+			}
+
+			flagged.UpdatedAt = time.Now()
+			if err := s.Repo.Update(libraryCtx, &domain.SongInfo{ID: song.ID}, &flagged); err != nil {
+				log.Warn("failed to save flagged song", slog.String("song_id", song.ID.String()), sl.Err(err))
+				report.Flagged--
+				report.Failed++
+			}
+		})
+		if err != nil {
+			log.Error("failed to fetch songs", slog.String("library_id", library.ID.String()), sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to fetch songs: %w", op, err)
+		}
+	}
+
+	log.Info("content scan finished",
+		slog.Int("scanned", report.Scanned),
+		slog.Int("flagged", report.Flagged),
+		slog.Int("failed", report.Failed),
+	)
+	return report, nil
+}
@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentFilter_Match(t *testing.T) {
+	filter, err := service.NewContentFilter([]string{"damn", "hell*", "son of a gun", "чёрт", " "})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "слово без учёта регистра", text: "Oh DAMN it", want: true},
+		{name: "только целые слова", text: "The damned and the damnation", want: false},
+		{name: "окончание по звёздочке", text: "What the hellish night", want: true},
+		{name: "фраза через пробелы и строки", text: "You son\nof a  gun", want: true},
+		{name: "часть фразы", text: "son of a", want: false},
+		{name: "кириллица", text: "Ну и Чёрт с ним", want: true},
+		{name: "кириллица внутри слова", text: "чёртова дюжина", want: false},
+		{name: "чистый текст", text: "It's bugging me", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filter.Match(tt.text))
+		})
+	}
+}
+
+func TestNewContentFilter_Invalid(t *testing.T) {
+	_, err := service.NewContentFilter([]string{" ", ""})
+	assert.Error(t, err)
+
+	_, err = service.NewContentFilter([]string{"*"})
+	assert.Error(t, err)
+}
+
+func TestContentFilter_Flag(t *testing.T) {
+	filter, err := service.NewContentFilter([]string{"damn"})
+	require.NoError(t, err)
+	explicit, notExplicit := true, false
+
+	song := &domain.Song{Text: "Damn"}
+	assert.True(t, filter.Flag(song))
+	assert.Equal(t, &explicit, song.Explicit)
+
+	// Фильтр только добавляет пометку
+	song = &domain.Song{Text: "It's bugging me", Explicit: &explicit}
+	assert.False(t, filter.Flag(song))
+	assert.Equal(t, &explicit, song.Explicit)
+
+	song = &domain.Song{Text: "It's bugging me", Explicit: &notExplicit}
+	assert.False(t, filter.Flag(song))
+	assert.Equal(t, &notExplicit, song.Explicit)
+
+	// Отключённый фильтр ничего не помечает
+	var disabled *service.ContentFilter
+	song = &domain.Song{Text: "Damn"}
+	assert.False(t, disabled.Flag(song))
+	assert.Nil(t, song.Explicit)
+}
+
+func TestService_Add_ContentFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	songService := service.NewService(mockRepo, mockMusicInfo, mockLog)
+	filter, err := service.NewContentFilter([]string{"damn"})
+	require.NoError(t, err)
+	songService.ContentFilter = filter
+
+	songInfo := &domain.SongInfo{Name: "Hysteria", Group: "Muse"}
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).
+		Return(&domain.Song{Name: "Hysteria", Group: "Muse", Text: "Damn, it's bugging me"}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, song *domain.Song) error {
+		require.NotNil(t, song.Explicit)
+		assert.True(t, *song.Explicit)
+		return nil
+	})
+
+	_, err = songService.Add(context.Background(), songInfo)
+	assert.NoError(t, err)
+}
+
+func TestService_Update_ContentFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	songService := service.NewService(mockRepo, mocks.NewMockMusicInfo(ctrl), mockLog)
+	filter, err := service.NewContentFilter([]string{"damn"})
+	require.NoError(t, err)
+	songService.ContentFilter = filter
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	notExplicit := false
+
+	// Новый текст помечается, поле explicit при этом не блокируется
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(&domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+		require.NotNil(t, song.Explicit)
+		assert.True(t, *song.Explicit)
+		assert.False(t, song.LockedFields.Has(domain.FieldExplicit))
+		return nil
+	})
+	assert.NoError(t, songService.Update(context.Background(), songInfo, &domain.Song{Text: "Damn"}))
+
+	// Явно переданная пометка важнее фильтра
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(&domain.Song{Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+		assert.Equal(t, &notExplicit, song.Explicit)
+		return nil
+	})
+	assert.NoError(t, songService.Update(context.Background(), songInfo, &domain.Song{Text: "Damn", Explicit: &notExplicit}))
+}
+
+func TestContentScanService_Scan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNormalizeRepository(ctrl)
+	mockLibraries := mocks.NewMockLibraryLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	filter, err := service.NewContentFilter([]string{"damn"})
+	require.NoError(t, err)
+	scanService := service.NewContentScanService(mockRepo, mockLibraries, filter, mockLog)
+
+	explicit, notExplicit := true, false
+	clean := &domain.Song{ID: uuid.New(), Text: "It's bugging me"}
+	matched := &domain.Song{ID: uuid.New(), Text: "Damn"}
+	flagged := &domain.Song{ID: uuid.New(), Text: "Damn", Explicit: &explicit}
+	locked := &domain.Song{ID: uuid.New(), Text: "Damn", Explicit: &notExplicit, LockedFields: domain.FieldExplicit}
+	conflicted := &domain.Song{ID: uuid.New(), Text: "damn"}
+
+	mockLibraries.EXPECT().GetAll(gomock.Any()).Return([]*domain.Library{{ID: domain.DefaultLibraryID}}, nil).Times(2)
+	mockRepo.EXPECT().ReadAllAfter(gomock.Any(), &domain.SongFilter{}, nil, gomock.Any()).
+		Return([]*domain.Song{clean, matched, flagged, locked, conflicted}, nil).Times(2)
+
+	// Без сохранения: Update не вызывается
+	report, err := scanService.Scan(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ContentScanReport{Scanned: 5, Flagged: 2, DryRun: true}, report)
+
+	// Уже помеченные и отредактированные вручную песни не сохраняются
+	mockRepo.EXPECT().Update(gomock.Any(), &domain.SongInfo{ID: matched.ID}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+			assert.Equal(t, &explicit, song.Explicit)
+			return nil
+		})
+	mockRepo.EXPECT().Update(gomock.Any(), &domain.SongInfo{ID: conflicted.ID}, gomock.Any()).Return(domain.ErrVersionConflict)
+
+	report, err = scanService.Scan(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ContentScanReport{Scanned: 5, Flagged: 1, Failed: 1}, report)
+	assert.Nil(t, matched.Explicit)
+}
+
+func TestContentScanService_Scan_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	scanService := service.NewContentScanService(mocks.NewMockNormalizeRepository(ctrl), mocks.NewMockLibraryLister(ctrl), nil, mockLog)
+
+	_, err := scanService.Scan(context.Background(), false)
+	assert.ErrorIs(t, err, domain.ErrContentFilterDisabled)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+// BlobStorage stores files such as song covers and audio by key
+type BlobStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (*domain.Blob, error)
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
+
+type CoverService struct {
+	Songs   SongReader
+	Storage BlobStorage
+	MaxSize int64
+	log     *slog.Logger
+}
+
+func NewCoverService(songs SongReader, storage BlobStorage, maxSize int64, log *slog.Logger) *CoverService {
+	return &CoverService{
+		Songs:   songs,
+		Storage: storage,
+		MaxSize: maxSize,
+		log:     log,
+	}
+}
+
+// coverKey is the key a song cover is stored under
+func coverKey(songID uuid.UUID) string {
+	return "covers/" + songID.String()
+}
+
+// Upload stores the image read from body as the cover of a song, replacing
+// the previous one. The type of the image is detected from its content.
+func (s *CoverService) Upload(ctx context.Context, songID uuid.UUID, body io.Reader) (*domain.Blob, error) {
+	const op = "CoverService.Upload"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// covers are small, so the image is read into memory to check its size
+	// and type before anything is stored
+	image, err := io.ReadAll(io.LimitReader(body, s.MaxSize+1))
+	if err != nil {
+		log.Error("failed to read cover", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read cover: %w", op, err)
+	}
+	if int64(len(image)) > s.MaxSize {
+		log.Warn("cover is too large", slog.Int64("max_size", s.MaxSize))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrCoverTooLarge)
+	}
+
+	contentType := http.DetectContentType(image)
+	if len(image) == 0 || !slices.Contains(domain.CoverTypes, contentType) {
+		log.Warn("cover is not a supported image", slog.String("content_type", contentType))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrCoverInvalidType)
+	}
+
+	log.Info("attempting to store cover", slog.String("content_type", contentType), slog.Int("size", len(image)))
+
+	if err := s.Storage.Put(ctx, coverKey(songID), bytes.NewReader(image), int64(len(image)), contentType); err != nil {
+		log.Error("failed to store cover", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to store cover: %w", op, err)
+	}
+
+	log.Info("cover successfully stored")
+	return &domain.Blob{ContentType: contentType, Size: int64(len(image))}, nil
+}
+
+// Get returns the cover of a song, its body must be closed by the caller.
+func (s *CoverService) Get(ctx context.Context, songID uuid.UUID) (*domain.Blob, error) {
+	const op = "CoverService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	// covers are keyed by song only, the song must be in the library of the
+	// request for its cover to be served
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cover, err := s.Storage.Get(ctx, coverKey(songID))
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		log.Info("song has no cover")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrCoverNotFound)
+	}
+	if err != nil {
+		log.Error("failed to fetch cover", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch cover: %w", op, err)
+	}
+
+	return cover, nil
+}
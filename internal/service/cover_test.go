@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader достаточно, чтобы тип изображения определился как image/png
+var pngHeader = []byte("\x89PNG\r\n\x1a\n0000")
+
+func newCoverService(t *testing.T) (*service.CoverService, *mocks.MockBlobStorage, *mocks.MockSongReader) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockStorage := mocks.NewMockBlobStorage(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewCoverService(mockSongs, mockStorage, 32, mockLog), mockStorage, mockSongs
+}
+
+func TestCoverService_Upload(t *testing.T) {
+	coverService, mockStorage, mockSongs := newCoverService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	mockStorage.EXPECT().
+		Put(gomock.Any(), "covers/"+songID.String(), gomock.Any(), int64(len(pngHeader)), "image/png").
+		DoAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, pngHeader, data)
+			return nil
+		})
+
+	cover, err := coverService.Upload(context.Background(), songID, bytes.NewReader(pngHeader))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", cover.ContentType)
+	assert.Equal(t, int64(len(pngHeader)), cover.Size)
+}
+
+func TestCoverService_Upload_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{name: "слишком большой файл", body: string(pngHeader) + strings.Repeat("0", 32), err: domain.ErrCoverTooLarge},
+		{name: "не изображение", body: "It's bugging me", err: domain.ErrCoverInvalidType},
+		{name: "пустое тело", body: "", err: domain.ErrCoverInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverService, _, mockSongs := newCoverService(t)
+
+			songID := uuid.New()
+			mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+
+			// В хранилище ничего не записывается
+			_, err := coverService.Upload(context.Background(), songID, strings.NewReader(tt.body))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCoverService_Upload_SongNotFound(t *testing.T) {
+	coverService, _, mockSongs := newCoverService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	_, err := coverService.Upload(context.Background(), songID, bytes.NewReader(pngHeader))
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestCoverService_Get(t *testing.T) {
+	coverService, mockStorage, mockSongs := newCoverService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	blob := &domain.Blob{Body: io.NopCloser(bytes.NewReader(pngHeader)), ContentType: "image/png"}
+	mockStorage.EXPECT().Get(gomock.Any(), "covers/"+songID.String()).Return(blob, nil)
+
+	cover, err := coverService.Get(context.Background(), songID)
+	assert.NoError(t, err)
+	assert.Equal(t, blob, cover)
+}
+
+func TestCoverService_Get_NotFound(t *testing.T) {
+	coverService, mockStorage, mockSongs := newCoverService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	mockStorage.EXPECT().Get(gomock.Any(), "covers/"+songID.String()).Return(nil, domain.ErrBlobNotFound)
+
+	_, err := coverService.Get(context.Background(), songID)
+	assert.ErrorIs(t, err, domain.ErrCoverNotFound)
+}
+
+func TestCoverService_Get_StorageError(t *testing.T) {
+	coverService, mockStorage, mockSongs := newCoverService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	mockStorage.EXPECT().Get(gomock.Any(), "covers/"+songID.String()).Return(nil, errors.New("connection refused"))
+
+	_, err := coverService.Get(context.Background(), songID)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrCoverNotFound)
+}
+
+func TestCoverService_Get_OtherLibrary(t *testing.T) {
+	coverService, _, mockSongs := newCoverService(t)
+
+	// Песня другой библиотеки не найдена, обложка не запрашивается
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	_, err := coverService.Get(context.Background(), songID)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
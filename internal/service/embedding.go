@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+)
+
+// defaultEmbeddingBatchSize is how many songs a backfill embeds at a time
+// when no other size is set
+const defaultEmbeddingBatchSize = 50
+
+// Embedder turns a text into a vector, texts of similar meaning get vectors
+// pointing the same way
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+type EmbeddingRepository interface {
+	SaveEmbedding(ctx context.Context, song *domain.Song, embedding []float32) error
+	// ReadUnembedded returns songs of all libraries without an embedding of
+	// their current version
+	ReadUnembedded(ctx context.Context, limit int) ([]*domain.Song, error)
+}
+
+// EmbeddingIndexer keeps the embeddings of semantic search up to date.
+// Songs are embedded as their events arrive, Backfill embeds the songs left
+// behind: saved before semantic search was enabled, whose event was dropped
+// or whose embedding failed.
+type EmbeddingIndexer struct {
+	Repo      EmbeddingRepository
+	Embedder  Embedder
+	BatchSize int
+	log       *slog.Logger
+}
+
+func NewEmbeddingIndexer(r EmbeddingRepository, embedder Embedder, batchSize int, log *slog.Logger) *EmbeddingIndexer {
+	if batchSize <= 0 {
+		batchSize = defaultEmbeddingBatchSize
+	}
+	return &EmbeddingIndexer{
+		Repo:      r,
+		Embedder:  embedder,
+		BatchSize: batchSize,
+		log:       log,
+	}
+}
+
+// Run backfills the embeddings and embeds the songs of created and updated
+// events until ctx is cancelled or events is closed
+func (i *EmbeddingIndexer) Run(ctx context.Context, events <-chan domain.SongEvent) {
+	const op = "EmbeddingIndexer.Run"
+
+	log := i.log.With(slog.String("op", op))
+
+	log.Info("embedding indexer started")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		i.Backfill(ctx)
+	}()
+	defer func() {
+		wg.Wait()
+		log.Info("embedding indexer stopped")
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Type == domain.SongDeleted {
+				// the embedding is deleted together with the song
+				continue
+			}
+			if err := i.index(ctx, event.Song); err != nil {
+				log.Warn("failed to embed song, it is embedded by the next backfill",
+					slog.String("song_id", event.Song.ID.String()), sl.Err(err))
+			}
+		}
+	}
+}
+
+// Backfill embeds the songs without an up to date embedding, BatchSize at a
+// time. It stops at the first failure, the songs left are embedded by the
+// next run.
+func (i *EmbeddingIndexer) Backfill(ctx context.Context) {
+	const op = "EmbeddingIndexer.Backfill"
+
+	log := i.log.With(slog.String("op", op))
+
+	embedded := 0
+	for ctx.Err() == nil {
+		songs, err := i.Repo.ReadUnembedded(ctx, i.BatchSize)
+		if err != nil {
+			log.Error("failed to fetch songs without embeddings", sl.Err(err))
+			return
+		}
+
+		for _, song := range songs {
+			if err := i.index(ctx, song); err != nil {
+				log.Warn("embedding backfill stopped", slog.String("song_id", song.ID.String()),
+					slog.Int("embedded", embedded), sl.Err(err))
+				return
+			}
+			embedded++
+		}
+
+		if len(songs) < i.BatchSize {
+			break
+		}
+	}
+
+	if embedded > 0 {
+		log.Info("embedding backfill finished", slog.Int("embedded", embedded))
+	}
+}
+
+func (i *EmbeddingIndexer) index(ctx context.Context, song *domain.Song) error {
+	embedding, err := i.Embedder.Embed(ctx, embeddingText(song))
+	if err != nil {
+		return fmt.Errorf("failed to embed song: %w", err)
+	}
+	if err := i.Repo.SaveEmbedding(ctx, song, embedding); err != nil {
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+	return nil
+}
+
+// embeddingText is the text a song is embedded by: name, group and lyrics
+func embeddingText(song *domain.Song) string {
+	return song.Name + "\n" + song.Group + "\n\n" + song.Text
+}
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingIndexer_Backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEmbeddingRepository(ctrl)
+	mockEmbedder := mocks.NewMockEmbedder(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	indexer := service.NewEmbeddingIndexer(mockRepo, mockEmbedder, 2, mockLog)
+
+	hysteria := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse", Text: "It's bugging me"}
+	starlight := &domain.Song{ID: uuid.New(), Name: "Starlight", Group: "Muse"}
+	creep := &domain.Song{ID: uuid.New(), Name: "Creep", Group: "Radiohead"}
+
+	// Полная пачка означает, что могут остаться ещё песни
+	gomock.InOrder(
+		mockRepo.EXPECT().ReadUnembedded(gomock.Any(), 2).Return([]*domain.Song{hysteria, starlight}, nil),
+		mockRepo.EXPECT().ReadUnembedded(gomock.Any(), 2).Return([]*domain.Song{creep}, nil),
+	)
+	mockEmbedder.EXPECT().Embed(gomock.Any(), "Hysteria\nMuse\n\nIt's bugging me").Return([]float32{1}, nil)
+	mockEmbedder.EXPECT().Embed(gomock.Any(), gomock.Any()).Return([]float32{2}, nil).Times(2)
+	mockRepo.EXPECT().SaveEmbedding(gomock.Any(), hysteria, []float32{1}).Return(nil)
+	mockRepo.EXPECT().SaveEmbedding(gomock.Any(), gomock.Any(), []float32{2}).Return(nil).Times(2)
+
+	indexer.Backfill(context.Background())
+}
+
+func TestEmbeddingIndexer_Backfill_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEmbeddingRepository(ctrl)
+	mockEmbedder := mocks.NewMockEmbedder(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	indexer := service.NewEmbeddingIndexer(mockRepo, mockEmbedder, 2, mockLog)
+
+	// Недоступный провайдер не перебирается песня за песней
+	mockRepo.EXPECT().ReadUnembedded(gomock.Any(), 2).
+		Return([]*domain.Song{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+	mockEmbedder.EXPECT().Embed(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	indexer.Backfill(context.Background())
+}
+
+func TestEmbeddingIndexer_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEmbeddingRepository(ctrl)
+	mockEmbedder := mocks.NewMockEmbedder(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	indexer := service.NewEmbeddingIndexer(mockRepo, mockEmbedder, 0, mockLog)
+
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	mockRepo.EXPECT().ReadUnembedded(gomock.Any(), 50).Return(nil, nil)
+	mockEmbedder.EXPECT().Embed(gomock.Any(), gomock.Any()).Return([]float32{1}, nil)
+	mockRepo.EXPECT().SaveEmbedding(gomock.Any(), song, []float32{1}).Return(nil)
+
+	events := make(chan domain.SongEvent, 2)
+	events <- domain.SongEvent{Type: domain.SongUpdated, Song: song, OccurredAt: time.Now()}
+	// Эмбеддинг удалённой песни удаляется вместе с ней
+	events <- domain.SongEvent{Type: domain.SongDeleted, Song: song, OccurredAt: time.Now()}
+	close(events)
+
+	indexer.Run(context.Background(), events)
+}
+
+func TestSearchService_SemanticSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmbedder := mocks.NewMockEmbedder(ctrl)
+	mockVectors := mocks.NewMockVectorSearchRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	searchService := service.NewSearchService(mocks.NewMockSearchRepository(ctrl), 3, 10, mockLog)
+	searchService.Embedder = mockEmbedder
+	searchService.Vectors = mockVectors
+
+	// Без размера страницы поиск ограничен, смещение по странице
+	results := []*domain.SearchResult{{Song: &domain.Song{Name: "Hysteria"}, Rank: 0.8}}
+	mockEmbedder.EXPECT().Embed(gomock.Any(), "songs about jealousy").Return([]float32{1, 0}, nil)
+	mockVectors.EXPECT().SearchEmbeddings(gomock.Any(), []float32{1, 0}, true, 20, 20).Return(results, nil)
+
+	result, err := searchService.SemanticSearch(context.Background(), " songs  about jealousy ", true, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, results, result)
+
+	// Пустой запрос не отправляется провайдеру
+	result, err = searchService.SemanticSearch(context.Background(), "   ", false, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	mockEmbedder.EXPECT().Embed(gomock.Any(), "love").Return(nil, errors.New("connection refused"))
+	_, err = searchService.SemanticSearch(context.Background(), "love", false, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestSearchService_SemanticSearch_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+	searchService := service.NewSearchService(mocks.NewMockSearchRepository(ctrl), 3, 10, mockLog)
+
+	_, err := searchService.SemanticSearch(context.Background(), "love", false, 0, 0)
+	assert.ErrorIs(t, err, domain.ErrSemanticSearchDisabled)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync/atomic"
+	"time"
+)
+
+type StaleSongRepository interface {
+	ReadStale(ctx context.Context, staleBefore time.Time, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+}
+
+// SongRefresher refreshes a song from MusicInfo, it is satisfied by Service
+type SongRefresher interface {
+	Refresh(ctx context.Context, song *domain.SongInfo, force bool) (*domain.Song, domain.SongFields, error)
+}
+
+// EnrichmentService fetches the details of songs without text or not
+// updated for StaleAfter from MusicInfo again. Songs are read BatchSize at a
+// time and refreshed at no more than RequestsPerSecond, fields edited
+// locally are kept.
+type EnrichmentService struct {
+	Repo  StaleSongRepository
+	Songs SongRefresher
+
+	StaleAfter        time.Duration
+	BatchSize         int
+	RequestsPerSecond float64
+
+	runs      atomic.Int64
+	refreshed atomic.Int64
+	unchanged atomic.Int64
+	failed    atomic.Int64
+
+	log *slog.Logger
+}
+
+func NewEnrichmentService(r StaleSongRepository, songs SongRefresher, staleAfter time.Duration, batchSize int, requestsPerSecond float64, log *slog.Logger) *EnrichmentService {
+	return &EnrichmentService{
+		Repo:              r,
+		Songs:             songs,
+		StaleAfter:        staleAfter,
+		BatchSize:         batchSize,
+		RequestsPerSecond: requestsPerSecond,
+		log:               log,
+	}
+}
+
+// Run walks the stale songs once and refreshes them. A failed song is
+// counted and skipped, the run stops when ctx is cancelled.
+func (s *EnrichmentService) Run(ctx context.Context) {
+	const op = "EnrichmentService.Run"
+
+	staleBefore := time.Now().Add(-s.StaleAfter)
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Time("stale_before", staleBefore),
+	)
+
+	log.Info("song enrichment started")
+	s.runs.Add(1)
+
+	limiter := time.NewTicker(time.Duration(float64(time.Second) / s.RequestsPerSecond))
+	defer limiter.Stop()
+
+	var refreshed, unchanged, failed int
+	var after *domain.SongCursor
+	for {
+		// Refreshed songs are no longer stale, the cursor skips the ones that
+		// stay stale because MusicInfo had nothing new or failed
+		songs, err := s.Repo.ReadStale(ctx, staleBefore, after, s.BatchSize)
+		if err != nil {
+			log.Error("failed to fetch stale songs", sl.Err(err))
+			break
+		}
+
+		for _, song := range songs {
+			select {
+			case <-limiter.C:
+			case <-ctx.Done():
+				log.Info("song enrichment interrupted", slog.Int("refreshed", refreshed), slog.Int("failed", failed))
+				return
+			}
+
+			// stale songs are read from every library, each is refreshed in its own
+			songCtx := domain.WithLibraryID(ctx, song.LibraryID)
+			_, changed, err := s.Songs.Refresh(songCtx, &domain.SongInfo{ID: song.ID}, false)
+			switch {
+			case err != nil:
+				log.Warn("failed to refresh song", slog.String("song_id", song.ID.String()), sl.Err(err))
+				failed++
+				s.failed.Add(1)
+			case changed != 0:
+				refreshed++
+				s.refreshed.Add(1)
+			default:
+				unchanged++
+				s.unchanged.Add(1)
+			}
+		}
+
+		if len(songs) < s.BatchSize {
+			break
+		}
+		after = domain.CursorOf(songs[len(songs)-1])
+	}
+
+	log.Info("song enrichment finished",
+		slog.Int("refreshed", refreshed),
+		slog.Int("unchanged", unchanged),
+		slog.Int("failed", failed),
+	)
+}
+
+// Stats returns the number of runs and of refreshed, unchanged and failed
+// songs since the start
+func (s *EnrichmentService) Stats() map[string]int64 {
+	return map[string]int64{
+		"runs":      s.runs.Load(),
+		"refreshed": s.refreshed.Load(),
+		"unchanged": s.unchanged.Load(),
+		"failed":    s.failed.Load(),
+	}
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichmentService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStaleSongRepository(ctrl)
+	mockSongs := mocks.NewMockSongRefresher(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	enrichmentService := service.NewEnrichmentService(mockRepo, mockSongs, time.Hour, 2, 1000, mockLog)
+
+	first := &domain.Song{ID: uuid.New(), CreatedAt: time.Now()}
+	second := &domain.Song{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Minute)}
+	third := &domain.Song{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Hour)}
+
+	// Вторая порция читается после курсора последней песни первой
+	gomock.InOrder(
+		mockRepo.EXPECT().ReadStale(gomock.Any(), gomock.Any(), nil, 2).Return([]*domain.Song{first, second}, nil),
+		mockRepo.EXPECT().ReadStale(gomock.Any(), gomock.Any(), domain.CursorOf(second), 2).Return([]*domain.Song{third}, nil),
+	)
+	mockSongs.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: first.ID}, false).Return(first, domain.FieldText, nil)
+	mockSongs.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: second.ID}, false).Return(second, domain.SongFields(0), nil)
+	mockSongs.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: third.ID}, false).Return(nil, domain.SongFields(0), errors.New("music info is down"))
+
+	enrichmentService.Run(context.Background())
+
+	// Ошибка одной песни не прерывает обход
+	assert.Equal(t, map[string]int64{"runs": 1, "refreshed": 1, "unchanged": 1, "failed": 1}, enrichmentService.Stats())
+}
+
+func TestEnrichmentService_Run_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStaleSongRepository(ctrl)
+	mockSongs := mocks.NewMockSongRefresher(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	enrichmentService := service.NewEnrichmentService(mockRepo, mockSongs, time.Hour, 10, 0.001, mockLog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// После отмены контекста песни больше не обновляются
+	mockRepo.EXPECT().ReadStale(gomock.Any(), gomock.Any(), nil, 10).Return([]*domain.Song{{ID: uuid.New()}}, nil)
+
+	enrichmentService.Run(ctx)
+
+	assert.Equal(t, int64(0), enrichmentService.Stats()["failed"])
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/exporter"
+	"songLibrary/pkg/logger/sl"
+)
+
+// Export renders a song as a printable document in the format.
+func (s *Service) Export(ctx context.Context, song *domain.SongInfo, format exporter.Format) (*exporter.Document, error) {
+	const op = "Service.Export"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", song.ID.String()),
+		slog.String("format", string(format)),
+	)
+
+	targetSong, err := s.Get(ctx, song)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	document, err := exporter.Export(targetSong, format)
+	if err != nil {
+		log.Error("failed to export song", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to export song: %w", op, err)
+	}
+
+	log.Info("song successfully exported", slog.Int("size", len(document.Body)))
+	return document, nil
+}
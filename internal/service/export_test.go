@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/exporter"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Export_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	songService := service.NewService(mockRepo, mockMusicInfo, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(&domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse"}, nil)
+
+	document, err := songService.Export(context.Background(), songInfo, exporter.FormatText)
+	assert.NoError(t, err)
+	assert.Equal(t, "Muse - Hysteria.txt", document.FileName)
+	assert.Equal(t, "Hysteria\nMuse\n", string(document.Body))
+}
+
+func TestService_Export_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	songService := service.NewService(mockRepo, mockMusicInfo, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(nil, domain.ErrSongNotFound)
+
+	_, err := songService.Export(context.Background(), songInfo, exporter.FormatPDF)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
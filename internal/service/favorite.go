@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteRepository interface {
+	Add(ctx context.Context, userID, songID uuid.UUID) error
+	Remove(ctx context.Context, userID, songID uuid.UUID) error
+	ReadAll(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Song, error)
+}
+
+type FavoriteService struct {
+	Repo FavoriteRepository
+	log  *slog.Logger
+}
+
+func NewFavoriteService(r FavoriteRepository, log *slog.Logger) *FavoriteService {
+	return &FavoriteService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Add marks a song as a favorite of the user.
+func (s *FavoriteService) Add(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "FavoriteService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+	)
+
+	log.Info("attempting to add favorite")
+
+	if err := s.Repo.Add(ctx, userID, songID); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to add favorite", sl.Err(err))
+		return fmt.Errorf("%s: failed to add favorite: %w", op, err)
+	}
+
+	log.Info("favorite successfully added")
+	return nil
+}
+
+// Remove unmarks a favorite song of the user.
+func (s *FavoriteService) Remove(ctx context.Context, userID, songID uuid.UUID) error {
+	const op = "FavoriteService.Remove"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+	)
+
+	log.Info("attempting to remove favorite")
+
+	if err := s.Repo.Remove(ctx, userID, songID); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to remove favorite", sl.Err(err))
+		return fmt.Errorf("%s: failed to remove favorite: %w", op, err)
+	}
+
+	log.Info("favorite successfully removed")
+	return nil
+}
+
+// GetAll retrieves the favorite songs of the user with pagination.
+func (s *FavoriteService) GetAll(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*domain.Song, error) {
+	const op = "FavoriteService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch favorites", slog.Int("offset", offset))
+
+	songs, err := s.Repo.ReadAll(ctx, userID, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch favorites", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch favorites: %w", op, err)
+	}
+
+	log.Info("favorites successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavoriteService_Add_SongNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFavoriteRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	favoriteService := service.NewFavoriteService(mockRepo, mockLog)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().Add(gomock.Any(), userID, songID).Return(domain.ErrSongNotFound)
+
+	err := favoriteService.Add(context.Background(), userID, songID)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestFavoriteService_GetAll_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFavoriteRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	favoriteService := service.NewFavoriteService(mockRepo, mockLog)
+
+	// Вторая страница по 5 песен начинается со смещения 5
+	userID := uuid.New()
+	mockRepo.EXPECT().ReadAll(gomock.Any(), userID, 5, 5).Return([]*domain.Song{}, nil)
+
+	songs, err := favoriteService.GetAll(context.Background(), userID, 2, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, songs)
+}
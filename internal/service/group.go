@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+type GroupRepository interface {
+	ReadAll(ctx context.Context, name string, limit, offset int) ([]*domain.Group, error)
+}
+
+type GroupService struct {
+	Repo GroupRepository
+	log  *slog.Logger
+}
+
+func NewGroupService(r GroupRepository, log *slog.Logger) *GroupService {
+	return &GroupService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// GetAll retrieves the groups that have songs, filtered by name with pagination.
+func (s *GroupService) GetAll(ctx context.Context, name string, page, pageSize int) ([]*domain.Group, error) {
+	const op = "GroupService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch groups", slog.Int("offset", offset))
+
+	groups, err := s.Repo.ReadAll(ctx, name, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch groups", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch groups: %w", op, err)
+	}
+
+	log.Info("groups successfully fetched", slog.Int("count", len(groups)))
+	return groups, nil
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupService_GetAll_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	groupService := service.NewGroupService(mockRepo, mockLog)
+
+	// Третья страница по 20 групп начинается с 40-й
+	groups := []*domain.Group{{Name: "Muse", Songs: 2}}
+	mockRepo.EXPECT().ReadAll(gomock.Any(), "mu", 20, 40).Return(groups, nil)
+
+	result, err := groupService.GetAll(context.Background(), "mu", 3, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+}
+
+func TestGroupService_GetAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	groupService := service.NewGroupService(mockRepo, mockLog)
+
+	dbErr := errors.New("connection refused")
+	mockRepo.EXPECT().ReadAll(gomock.Any(), "", 0, 0).Return(nil, dbErr)
+
+	_, err := groupService.GetAll(context.Background(), "", 0, 0)
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/internal/importer"
+	"songLibrary/pkg/logger/sl"
+	"sort"
+)
+
+var errSaveSong = errors.New("failed to save song")
+
+// Import loads songs from a CSV file. Every row is validated and saved on its
+// own, so a bad row is reported without aborting the rest of the file. With
+// dryRun set nothing is written and the report only reflects validation.
+func (s *Service) Import(ctx context.Context, file io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	const op = "Service.Import"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	log.Info("attempting to import songs")
+
+	records, rowErrors, err := importer.Parse(file)
+	if err != nil {
+		log.Warn("failed to parse import file", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := &domain.ImportReport{
+		Total:  len(records) + len(rowErrors),
+		DryRun: dryRun,
+		Errors: rowErrors,
+	}
+
+	if dryRun {
+		report.Imported = len(records)
+		log.Info("dry run finished", slog.Int("valid", report.Imported), slog.Int("invalid", len(report.Errors)))
+		return report, nil
+	}
+
+	for _, record := range records {
+		record.Song.Text = NormalizeLyrics(record.Song.Text)
+		record.Song.Lyrics = ParseLyrics(record.Song.Text)
+		s.ContentFilter.Flag(record.Song)
+		if err := s.Repo.Create(ctx, record.Song); err != nil {
+			log.Warn("failed to import row", slog.Int("line", record.Line), sl.Err(err))
+
+			// Storage errors are not exposed to the client as is
+			rowErr := errSaveSong
+			if errors.Is(err, domain.ErrSongExists) {
+				rowErr = domain.ErrSongExists
+			}
+			report.Errors = append(report.Errors, domain.ImportRowError{Line: record.Line, Err: rowErr})
+			continue
+		}
+		report.Imported++
+	}
+
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	log.Info("songs successfully imported", slog.Int("imported", report.Imported), slog.Int("failed", len(report.Errors)))
+	return report, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type LibraryRepository interface {
+	Create(ctx context.Context, library *domain.Library) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.Library, error)
+	ReadAll(ctx context.Context) ([]*domain.Library, error)
+}
+
+type LibraryService struct {
+	Repo LibraryRepository
+	log  *slog.Logger
+
+	// known holds the libraries already read, they are looked up on every
+	// request and can't be deleted
+	known sync.Map
+}
+
+func NewLibraryService(r LibraryRepository, log *slog.Logger) *LibraryService {
+	return &LibraryService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Add creates a new library.
+func (s *LibraryService) Add(ctx context.Context, library *domain.Library) error {
+	const op = "LibraryService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("library_name", library.Name),
+	)
+
+	log.Info("attempting to add a new library")
+
+	if library.Name == "" {
+		log.Warn("library name is empty")
+		return fmt.Errorf("%s: %w", op, domain.ErrLibraryNameIsNull)
+	}
+
+	if err := s.Repo.Create(ctx, library); err != nil {
+		if errors.Is(err, domain.ErrLibraryExists) {
+			log.Warn("library already exists", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, domain.ErrLibraryExists)
+		}
+		log.Error("failed to save library", sl.Err(err))
+		return fmt.Errorf("%s: failed to save library: %w", op, err)
+	}
+
+	s.known.Store(library.ID, library)
+
+	log.Info("library successfully added", slog.String("library_id", library.ID.String()))
+	return nil
+}
+
+// Get fetches a library by ID. Libraries are cached once read, so resolving
+// the library of every request doesn't hit the database.
+func (s *LibraryService) Get(ctx context.Context, id uuid.UUID) (*domain.Library, error) {
+	const op = "LibraryService.Get"
+
+	if library, ok := s.known.Load(id); ok {
+		return library.(*domain.Library), nil
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("library_id", id.String()),
+	)
+
+	log.Debug("attempting to fetch library")
+
+	library, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrLibraryNotFound) {
+			log.Warn("library not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: library not found: %w", op, domain.ErrLibraryNotFound)
+		}
+		log.Error("failed to read library", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read library: %w", op, err)
+	}
+
+	s.known.Store(id, library)
+
+	log.Debug("library successfully fetched")
+	return library, nil
+}
+
+// GetAll retrieves all libraries.
+func (s *LibraryService) GetAll(ctx context.Context) ([]*domain.Library, error) {
+	const op = "LibraryService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+	)
+
+	log.Info("attempting to fetch libraries")
+
+	libraries, err := s.Repo.ReadAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch libraries", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch libraries: %w", op, err)
+	}
+
+	log.Info("libraries successfully fetched", slog.Int("count", len(libraries)))
+	return libraries, nil
+}
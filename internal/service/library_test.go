@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibraryService_Add_EmptyName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLibraryRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	libraryService := service.NewLibraryService(mockRepo, mockLog)
+
+	// Репозиторий не должен вызываться для библиотеки без названия
+	err := libraryService.Add(context.Background(), &domain.Library{})
+	assert.ErrorIs(t, err, domain.ErrLibraryNameIsNull)
+}
+
+func TestLibraryService_Get_Cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLibraryRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	libraryService := service.NewLibraryService(mockRepo, mockLog)
+
+	library := &domain.Library{ID: uuid.New(), Name: "Team A"}
+	// Библиотека читается из базы один раз, дальше берётся из памяти
+	mockRepo.EXPECT().Read(gomock.Any(), library.ID).Return(library, nil).Times(1)
+
+	for i := 0; i < 3; i++ {
+		got, err := libraryService.Get(context.Background(), library.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, library, got)
+	}
+}
+
+func TestLibraryService_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLibraryRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	libraryService := service.NewLibraryService(mockRepo, mockLog)
+
+	libraryID := uuid.New()
+	// Неизвестная библиотека не запоминается и проверяется снова
+	mockRepo.EXPECT().Read(gomock.Any(), libraryID).Return(nil, domain.ErrLibraryNotFound).Times(2)
+
+	_, err := libraryService.Get(context.Background(), libraryID)
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+	_, err = libraryService.Get(context.Background(), libraryID)
+	assert.ErrorIs(t, err, domain.ErrLibraryNotFound)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"songLibrary/internal/domain"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// sectionMarker matches a line like "[Chorus]" or "[Verse 2]" that starts a block
+var sectionMarker = regexp.MustCompile(`(?i)^\[\s*(intro|verse|chorus|bridge|outro)(?:\s*\d+)?\s*\]$`)
+
+// defaultLinesPerVerse is the size of the verses of SplitLines when no other
+// size is set
+const defaultLinesPerVerse = 4
+
+// LyricsSplitter splits a song text into sections. It returns nil for an
+// empty text.
+type LyricsSplitter interface {
+	Split(text string) *domain.Lyrics
+}
+
+// LyricsSplit selects the splitter of a song text: the requested strategy,
+// else the strategy of the library of the request, else Default. Stored
+// lyrics are split by blank lines, other strategies split the text when it
+// is read.
+type LyricsSplit struct {
+	Default       domain.SplitStrategy
+	Libraries     map[uuid.UUID]domain.SplitStrategy
+	LinesPerVerse int
+}
+
+// Strategy returns the strategy to split the texts of the request with
+func (c LyricsSplit) Strategy(ctx context.Context, requested domain.SplitStrategy) domain.SplitStrategy {
+	if requested != "" {
+		return requested
+	}
+	if strategy, ok := c.Libraries[domain.LibraryIDFromContext(ctx)]; ok {
+		return strategy
+	}
+	if c.Default != "" {
+		return c.Default
+	}
+	return domain.SplitBlankLines
+}
+
+// Splitter returns the splitter of the strategy
+func (c LyricsSplit) Splitter(strategy domain.SplitStrategy) LyricsSplitter {
+	switch strategy {
+	case domain.SplitMarkers:
+		return MarkerSplitter{}
+	case domain.SplitLines:
+		lines := c.LinesPerVerse
+		if lines <= 0 {
+			lines = defaultLinesPerVerse
+		}
+		return LineCountSplitter{Lines: lines}
+	default:
+		return BlankLineSplitter{}
+	}
+}
+
+// BlankLineSplitter splits texts the way ParseLyrics does
+type BlankLineSplitter struct{}
+
+func (BlankLineSplitter) Split(text string) *domain.Lyrics {
+	return ParseLyrics(text)
+}
+
+// MarkerSplitter starts a section at every marker line, for lyrics without
+// blank lines between their sections. Lines before the first marker are a
+// verse and blank lines are dropped.
+type MarkerSplitter struct{}
+
+func (MarkerSplitter) Split(text string) *domain.Lyrics {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	lyrics := &domain.Lyrics{}
+	counts := make(map[domain.SectionType]int)
+	sectionType := domain.SectionVerse
+	var lines []string
+
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		counts[sectionType]++
+		lyrics.Sections = append(lyrics.Sections, domain.LyricsSection{
+			Type:  sectionType,
+			Index: counts[sectionType],
+			Text:  strings.Join(lines, "\n"),
+		})
+		lines = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if match := sectionMarker.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			flush()
+			sectionType = domain.SectionType(strings.ToLower(match[1]))
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	return lyrics
+}
+
+// LineCountSplitter makes a verse of every Lines lines of the text, for
+// lyrics without any structure. Blank and marker lines are dropped. Lines
+// below one selects defaultLinesPerVerse.
+type LineCountSplitter struct {
+	Lines int
+}
+
+func (s LineCountSplitter) Split(text string) *domain.Lyrics {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	if s.Lines <= 0 {
+		s.Lines = defaultLinesPerVerse
+	}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" || sectionMarker.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	lyrics := &domain.Lyrics{}
+	for start := 0; start < len(lines); start += s.Lines {
+		end := min(start+s.Lines, len(lines))
+		lyrics.Sections = append(lyrics.Sections, domain.LyricsSection{
+			Type:  domain.SectionVerse,
+			Index: len(lyrics.Sections) + 1,
+			Text:  strings.Join(lines[start:end], "\n"),
+		})
+	}
+
+	return lyrics
+}
+
+// blankLines matches the blank lines beyond the first between two lines
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// NormalizeLyrics cleans up a song text before it is saved: the byte order
+// mark is dropped, line endings become \n, the text is put in Unicode NFC,
+// trailing whitespace is trimmed from lines and runs of blank lines are
+// collapsed into one, blank lines at the ends are dropped.
+func NormalizeLyrics(text string) string {
+	text = strings.ReplaceAll(text, "\ufeff", "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = norm.NFC.String(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.Trim(text, "\n")
+}
+
+// ParseLyrics splits a song text into sections by blank lines. A block
+// starting with a marker line such as "[Chorus]" gets its type, the marker
+// isn't part of the section text; unmarked blocks are verses. Returns nil
+// for an empty text.
+func ParseLyrics(text string) *domain.Lyrics {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	lyrics := &domain.Lyrics{}
+	counts := make(map[domain.SectionType]int)
+	for _, block := range strings.Split(text, "\n\n") {
+		sectionType := domain.SectionVerse
+
+		firstLine, rest, _ := strings.Cut(block, "\n")
+		if match := sectionMarker.FindStringSubmatch(strings.TrimSpace(firstLine)); match != nil {
+			sectionType = domain.SectionType(strings.ToLower(match[1]))
+			block = rest
+		}
+
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		counts[sectionType]++
+		lyrics.Sections = append(lyrics.Sections, domain.LyricsSection{
+			Type:  sectionType,
+			Index: counts[sectionType],
+			Text:  block,
+		})
+	}
+
+	return lyrics
+}
+
+// MatchVerses returns the sections having lines that contain the query,
+// ignoring case and repeated whitespace, in the order of the lyrics
+func MatchVerses(lyrics *domain.Lyrics, query string) []*domain.VerseMatch {
+	query = normalizeLine(query)
+	if query == "" {
+		return nil
+	}
+
+	var matches []*domain.VerseMatch
+	for i, section := range lyrics.Sections {
+		var lines []domain.LineMatch
+		for j, line := range strings.Split(section.Text, "\n") {
+			if strings.Contains(normalizeLine(line), query) {
+				lines = append(lines, domain.LineMatch{Number: j + 1, Text: line})
+			}
+		}
+		if len(lines) > 0 {
+			matches = append(matches, &domain.VerseMatch{Number: i + 1, Section: section, Lines: lines})
+		}
+	}
+
+	return matches
+}
+
+func normalizeLine(line string) string {
+	return strings.ToLower(strings.Join(strings.Fields(line), " "))
+}
@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"songLibrary/internal/service"
+)
+
+// benchLyrics is a long song text with section markers and blank lines
+var benchLyrics = strings.Repeat("[Verse]\nIt's bugging me\nGrating me\nAnd twisting me around\n\n[Chorus]\n'Cause I want it now\nI want it now\n\n", 50)
+
+func BenchmarkLyricsSplitter(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		splitter service.LyricsSplitter
+	}{
+		{name: "blank_lines", splitter: service.BlankLineSplitter{}},
+		{name: "markers", splitter: service.MarkerSplitter{}},
+		{name: "lines", splitter: service.LineCountSplitter{Lines: 4}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if bm.splitter.Split(benchLyrics) == nil {
+					b.Fatal("lyrics not split")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMatchVerses(b *testing.B) {
+	lyrics := service.ParseLyrics(benchLyrics)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service.MatchVerses(lyrics, "want it")
+	}
+}
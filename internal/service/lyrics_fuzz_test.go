@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+)
+
+func FuzzLyricsSplitters(f *testing.F) {
+	f.Add("[Verse 1]\nIt's bugging me\nGrating me\n\n[Chorus]\n'Cause I want it now", 2)
+	f.Add("It's bugging me\n\n\n\nGrating me", 1)
+	f.Add("[Chorus]\n\n[ verse 2 ]\n[Bridge]", 0)
+	f.Add("\r\n\ufeff[Outro]\r\n", -1)
+	f.Add("", 4)
+
+	f.Fuzz(func(t *testing.T, text string, lines int) {
+		splitters := map[string]service.LyricsSplitter{
+			"blank_lines": service.BlankLineSplitter{},
+			"markers":     service.MarkerSplitter{},
+			"lines":       service.LineCountSplitter{Lines: lines},
+		}
+
+		for name, splitter := range splitters {
+			lyrics := splitter.Split(text)
+			if strings.TrimSpace(text) == "" {
+				if lyrics != nil {
+					t.Errorf("%s: empty text split into %+v", name, lyrics)
+				}
+				continue
+			}
+
+			// Индексы секций каждого типа идут подряд с единицы, пустых секций нет
+			counts := make(map[domain.SectionType]int)
+			for _, section := range lyrics.Sections {
+				counts[section.Type]++
+				if section.Index != counts[section.Type] {
+					t.Errorf("%s: section %+v, want index %d", name, section, counts[section.Type])
+				}
+				if strings.TrimSpace(section.Text) == "" {
+					t.Errorf("%s: empty section %+v", name, section)
+				}
+			}
+		}
+
+		// Нормализация идемпотентна
+		normalized := service.NormalizeLyrics(text)
+		if again := service.NormalizeLyrics(normalized); again != normalized {
+			t.Errorf("NormalizeLyrics isn't idempotent: %q -> %q", normalized, again)
+		}
+	})
+}
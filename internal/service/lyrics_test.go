@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLyrics(t *testing.T) {
+	text := "[Verse 1]\nIt's bugging me\nGrating me\n\n[Chorus]\n'Cause I want it now\n\n[verse 2]\nI'm breaking out\n\n[Chorus]\n'Cause I want it now\n\n[Bridge]\nLast chance"
+
+	lyrics := service.ParseLyrics(text)
+
+	// Маркеры задают тип секции и не попадают в её текст, индексы считаются по типу
+	assert.Equal(t, []domain.LyricsSection{
+		{Type: domain.SectionVerse, Index: 1, Text: "It's bugging me\nGrating me"},
+		{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+		{Type: domain.SectionVerse, Index: 2, Text: "I'm breaking out"},
+		{Type: domain.SectionChorus, Index: 2, Text: "'Cause I want it now"},
+		{Type: domain.SectionBridge, Index: 1, Text: "Last chance"},
+	}, lyrics.Sections)
+}
+
+func TestParseLyrics_WithoutMarkers(t *testing.T) {
+	// Без маркеров каждый блок считается куплетом, как при старой разбивке
+	lyrics := service.ParseLyrics("Ooh baby\n\nDon't you hear me moan?")
+
+	assert.Equal(t, []string{"Ooh baby", "Don't you hear me moan?"}, lyrics.Verses())
+	assert.Equal(t, domain.SectionVerse, lyrics.Sections[0].Type)
+	assert.Equal(t, 2, lyrics.Sections[1].Index)
+}
+
+func TestParseLyrics_Empty(t *testing.T) {
+	assert.Nil(t, service.ParseLyrics(""))
+	assert.Nil(t, service.ParseLyrics(" \n\n "))
+}
+
+func TestMatchVerses(t *testing.T) {
+	lyrics := service.ParseLyrics("[Verse 1]\nIt's bugging me\nGrating me\n\n[Chorus]\n'Cause I want it now\nI want it  NOW\n\n[Verse 2]\nI'm breaking out")
+
+	matches := service.MatchVerses(lyrics, "  want it now ")
+
+	// Регистр и лишние пробелы не учитываются, строки нумеруются внутри секции
+	assert.Equal(t, []*domain.VerseMatch{
+		{
+			Number:  2,
+			Section: domain.LyricsSection{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now\nI want it  NOW"},
+			Lines: []domain.LineMatch{
+				{Number: 1, Text: "'Cause I want it now"},
+				{Number: 2, Text: "I want it  NOW"},
+			},
+		},
+	}, matches)
+}
+
+func TestMatchVerses_NoMatch(t *testing.T) {
+	lyrics := service.ParseLyrics("It's bugging me\n\nGrating me")
+
+	assert.Empty(t, service.MatchVerses(lyrics, "hysteria"))
+	assert.Empty(t, service.MatchVerses(lyrics, "   "))
+}
+
+func TestMarkerSplitter(t *testing.T) {
+	// Секции без пустых строк между ними разделяются маркерами
+	text := "Intro line\n[Verse 1]\nIt's bugging me\nGrating me\n[Chorus]\n'Cause I want it now\n\n[Verse 2]\nI'm breaking out"
+
+	lyrics := service.MarkerSplitter{}.Split(text)
+
+	assert.Equal(t, []domain.LyricsSection{
+		{Type: domain.SectionVerse, Index: 1, Text: "Intro line"},
+		{Type: domain.SectionVerse, Index: 2, Text: "It's bugging me\nGrating me"},
+		{Type: domain.SectionChorus, Index: 1, Text: "'Cause I want it now"},
+		{Type: domain.SectionVerse, Index: 3, Text: "I'm breaking out"},
+	}, lyrics.Sections)
+	assert.Nil(t, service.MarkerSplitter{}.Split(" \n "))
+}
+
+func TestLineCountSplitter(t *testing.T) {
+	// Пустые строки и маркеры отбрасываются, остаток попадает в последний куплет
+	text := "[Verse 1]\none\ntwo\n\nthree\nfour\nfive"
+
+	lyrics := service.LineCountSplitter{Lines: 2}.Split(text)
+
+	assert.Equal(t, []string{"one\ntwo", "three\nfour", "five"}, lyrics.Verses())
+	assert.Equal(t, 3, lyrics.Sections[2].Index)
+}
+
+func TestLyricsSplit_Strategy(t *testing.T) {
+	libraryID := uuid.New()
+	split := service.LyricsSplit{
+		Default:   domain.SplitMarkers,
+		Libraries: map[uuid.UUID]domain.SplitStrategy{libraryID: domain.SplitLines},
+	}
+	libraryCtx := domain.WithLibraryID(context.Background(), libraryID)
+
+	// Запрос важнее настройки библиотеки, библиотека — настройки по умолчанию
+	assert.Equal(t, domain.SplitBlankLines, split.Strategy(libraryCtx, domain.SplitBlankLines))
+	assert.Equal(t, domain.SplitLines, split.Strategy(libraryCtx, ""))
+	assert.Equal(t, domain.SplitMarkers, split.Strategy(context.Background(), ""))
+	assert.Equal(t, domain.SplitBlankLines, service.LyricsSplit{}.Strategy(context.Background(), ""))
+}
+
+func TestNormalizeLyrics(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "уже нормализован", text: "It's bugging me\n\nGrating me", want: "It's bugging me\n\nGrating me"},
+		{name: "BOM и CRLF", text: "\uFEFFIt's bugging me\r\n\r\nGrating me\r", want: "It's bugging me\n\nGrating me"},
+		{name: "пробелы в конце строк", text: "It's bugging me  \t\n\nGrating me ", want: "It's bugging me\n\nGrating me"},
+		{name: "лишние пустые строки", text: "\n\nIt's bugging me\n\n\n \n\nGrating me\n\n", want: "It's bugging me\n\nGrating me"},
+		{name: "NFC", text: "Cafe\u0301", want: "Caf\u00e9"},
+		{name: "пустой текст", text: " \r\n ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.NormalizeLyrics(tt.text))
+		})
+	}
+}
@@ -1,15 +1,18 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: songLibrary/internal/service (interfaces: Repository,MusicInfo)
+// Source: songLibrary/internal/service (interfaces: Repository,MusicInfo,AlbumRepository,ArtistRepository,FavoriteRepository,PlayRepository,SongReader,WebhookRepository,AuditRepository,RevisionRepository,TagRepository,StaleSongRepository,SongRefresher,SongWriter,BlobStorage,AudioRepository,SuggestionRepository,SearchRepository,SongLister,CalendarRepository,VectorSearchRepository,Embedder,EmbeddingRepository,SimilarRepository,RandomRepository,StatsRepository,GroupRepository,WebhookSender,MusicInfoCache,CacheRepository,WriteBehindRepository,OutboxRepository,LibraryRepository,UserRepository,APIKeyRepository,NormalizeRepository,LibraryLister,PlaylistRepository,PendingSongs,ShareRepository,RatingRepository)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	domain "songLibrary/internal/domain"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockRepository is a mock of Repository interface.
@@ -63,6 +66,21 @@ func (mr *MockRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), arg0, arg1)
 }
 
+// LibraryModified mocks base method.
+func (m *MockRepository) LibraryModified(arg0 context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LibraryModified", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LibraryModified indicates an expected call of LibraryModified.
+func (mr *MockRepositoryMockRecorder) LibraryModified(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LibraryModified", reflect.TypeOf((*MockRepository)(nil).LibraryModified), arg0)
+}
+
 // Read mocks base method.
 func (m *MockRepository) Read(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
 	m.ctrl.T.Helper()
@@ -78,8 +96,23 @@ func (mr *MockRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockRepository)(nil).Read), arg0, arg1)
 }
 
+// ReadAllAfter mocks base method.
+func (m *MockRepository) ReadAllAfter(arg0 context.Context, arg1 *domain.SongFilter, arg2 *domain.SongCursor, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAllAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAllAfter indicates an expected call of ReadAllAfter.
+func (mr *MockRepositoryMockRecorder) ReadAllAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllAfter", reflect.TypeOf((*MockRepository)(nil).ReadAllAfter), arg0, arg1, arg2, arg3)
+}
+
 // ReadAllWithFilter mocks base method.
-func (m *MockRepository) ReadAllWithFilter(arg0 context.Context, arg1 *domain.Song, arg2, arg3 int) ([]*domain.Song, error) {
+func (m *MockRepository) ReadAllWithFilter(arg0 context.Context, arg1 *domain.SongFilter, arg2, arg3 int) ([]*domain.Song, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReadAllWithFilter", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.Song)
@@ -93,6 +126,51 @@ func (mr *MockRepositoryMockRecorder) ReadAllWithFilter(arg0, arg1, arg2, arg3 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllWithFilter", reflect.TypeOf((*MockRepository)(nil).ReadAllWithFilter), arg0, arg1, arg2, arg3)
 }
 
+// ReadByIDs mocks base method.
+func (m *MockRepository) ReadByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIDs indicates an expected call of ReadByIDs.
+func (mr *MockRepositoryMockRecorder) ReadByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIDs", reflect.TypeOf((*MockRepository)(nil).ReadByIDs), arg0, arg1)
+}
+
+// ReadByNameAndGroup mocks base method.
+func (m *MockRepository) ReadByNameAndGroup(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByNameAndGroup", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByNameAndGroup indicates an expected call of ReadByNameAndGroup.
+func (mr *MockRepositoryMockRecorder) ReadByNameAndGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByNameAndGroup", reflect.TypeOf((*MockRepository)(nil).ReadByNameAndGroup), arg0, arg1)
+}
+
+// ReadDuplicates mocks base method.
+func (m *MockRepository) ReadDuplicates(arg0 context.Context, arg1 float64, arg2 int) ([]*domain.DuplicateSongs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDuplicates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.DuplicateSongs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadDuplicates indicates an expected call of ReadDuplicates.
+func (mr *MockRepositoryMockRecorder) ReadDuplicates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDuplicates", reflect.TypeOf((*MockRepository)(nil).ReadDuplicates), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(arg0 context.Context, arg1 *domain.SongInfo, arg2 *domain.Song) error {
 	m.ctrl.T.Helper()
@@ -107,6 +185,35 @@ func (mr *MockRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), arg0, arg1, arg2)
 }
 
+// UpdateAllWithFilter mocks base method.
+func (m *MockRepository) UpdateAllWithFilter(arg0 context.Context, arg1 *domain.SongFilter, arg2 *domain.SongChanges) ([]*domain.SongUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAllWithFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.SongUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAllWithFilter indicates an expected call of UpdateAllWithFilter.
+func (mr *MockRepositoryMockRecorder) UpdateAllWithFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllWithFilter", reflect.TypeOf((*MockRepository)(nil).UpdateAllWithFilter), arg0, arg1, arg2)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockRepository) WithinTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockRepositoryMockRecorder) WithinTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockRepository)(nil).WithinTransaction), arg0, arg1)
+}
+
 // MockMusicInfo is a mock of MusicInfo interface.
 type MockMusicInfo struct {
 	ctrl     *gomock.Controller
@@ -144,3 +251,2265 @@ func (mr *MockMusicInfoMockRecorder) FetchMusicInfo(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMusicInfo", reflect.TypeOf((*MockMusicInfo)(nil).FetchMusicInfo), arg0, arg1)
 }
+
+// MockAlbumRepository is a mock of AlbumRepository interface.
+type MockAlbumRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumRepositoryMockRecorder
+}
+
+// MockAlbumRepositoryMockRecorder is the mock recorder for MockAlbumRepository.
+type MockAlbumRepositoryMockRecorder struct {
+	mock *MockAlbumRepository
+}
+
+// NewMockAlbumRepository creates a new mock instance.
+func NewMockAlbumRepository(ctrl *gomock.Controller) *MockAlbumRepository {
+	mock := &MockAlbumRepository{ctrl: ctrl}
+	mock.recorder = &MockAlbumRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumRepository) EXPECT() *MockAlbumRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAlbumRepository) Create(arg0 context.Context, arg1 *domain.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAlbumRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAlbumRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAlbumRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAlbumRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAlbumRepository)(nil).Delete), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockAlbumRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockAlbumRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockAlbumRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockAlbumRepository) ReadAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockAlbumRepositoryMockRecorder) ReadAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockAlbumRepository)(nil).ReadAll), arg0, arg1, arg2, arg3)
+}
+
+// ReadSongs mocks base method.
+func (m *MockAlbumRepository) ReadSongs(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSongs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSongs indicates an expected call of ReadSongs.
+func (mr *MockAlbumRepositoryMockRecorder) ReadSongs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSongs", reflect.TypeOf((*MockAlbumRepository)(nil).ReadSongs), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockAlbumRepository) Update(arg0 context.Context, arg1 *domain.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAlbumRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlbumRepository)(nil).Update), arg0, arg1)
+}
+
+// MockArtistRepository is a mock of ArtistRepository interface.
+type MockArtistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistRepositoryMockRecorder
+}
+
+// MockArtistRepositoryMockRecorder is the mock recorder for MockArtistRepository.
+type MockArtistRepositoryMockRecorder struct {
+	mock *MockArtistRepository
+}
+
+// NewMockArtistRepository creates a new mock instance.
+func NewMockArtistRepository(ctrl *gomock.Controller) *MockArtistRepository {
+	mock := &MockArtistRepository{ctrl: ctrl}
+	mock.recorder = &MockArtistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistRepository) EXPECT() *MockArtistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistRepository) Create(arg0 context.Context, arg1 *domain.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockArtistRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArtistRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArtistRepository)(nil).Delete), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockArtistRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockArtistRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockArtistRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockArtistRepository) ReadAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockArtistRepositoryMockRecorder) ReadAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockArtistRepository)(nil).ReadAll), arg0, arg1, arg2, arg3)
+}
+
+// ReadSongs mocks base method.
+func (m *MockArtistRepository) ReadSongs(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSongs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSongs indicates an expected call of ReadSongs.
+func (mr *MockArtistRepositoryMockRecorder) ReadSongs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSongs", reflect.TypeOf((*MockArtistRepository)(nil).ReadSongs), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockArtistRepository) Update(arg0 context.Context, arg1 *domain.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArtistRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArtistRepository)(nil).Update), arg0, arg1)
+}
+
+// MockFavoriteRepository is a mock of FavoriteRepository interface.
+type MockFavoriteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteRepositoryMockRecorder
+}
+
+// MockFavoriteRepositoryMockRecorder is the mock recorder for MockFavoriteRepository.
+type MockFavoriteRepositoryMockRecorder struct {
+	mock *MockFavoriteRepository
+}
+
+// NewMockFavoriteRepository creates a new mock instance.
+func NewMockFavoriteRepository(ctrl *gomock.Controller) *MockFavoriteRepository {
+	mock := &MockFavoriteRepository{ctrl: ctrl}
+	mock.recorder = &MockFavoriteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteRepository) EXPECT() *MockFavoriteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockFavoriteRepository) Add(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockFavoriteRepositoryMockRecorder) Add(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockFavoriteRepository)(nil).Add), arg0, arg1, arg2)
+}
+
+// ReadAll mocks base method.
+func (m *MockFavoriteRepository) ReadAll(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockFavoriteRepositoryMockRecorder) ReadAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockFavoriteRepository)(nil).ReadAll), arg0, arg1, arg2, arg3)
+}
+
+// Remove mocks base method.
+func (m *MockFavoriteRepository) Remove(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFavoriteRepositoryMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFavoriteRepository)(nil).Remove), arg0, arg1, arg2)
+}
+
+// MockPlayRepository is a mock of PlayRepository interface.
+type MockPlayRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayRepositoryMockRecorder
+}
+
+// MockPlayRepositoryMockRecorder is the mock recorder for MockPlayRepository.
+type MockPlayRepositoryMockRecorder struct {
+	mock *MockPlayRepository
+}
+
+// NewMockPlayRepository creates a new mock instance.
+func NewMockPlayRepository(ctrl *gomock.Controller) *MockPlayRepository {
+	mock := &MockPlayRepository{ctrl: ctrl}
+	mock.recorder = &MockPlayRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayRepository) EXPECT() *MockPlayRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockPlayRepository) Flush(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockPlayRepositoryMockRecorder) Flush(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockPlayRepository)(nil).Flush), arg0, arg1)
+}
+
+// ReadTrending mocks base method.
+func (m *MockPlayRepository) ReadTrending(arg0 context.Context, arg1 time.Time, arg2 int) ([]*domain.TrendingSong, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTrending", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.TrendingSong)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadTrending indicates an expected call of ReadTrending.
+func (mr *MockPlayRepositoryMockRecorder) ReadTrending(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTrending", reflect.TypeOf((*MockPlayRepository)(nil).ReadTrending), arg0, arg1, arg2)
+}
+
+// Record mocks base method.
+func (m *MockPlayRepository) Record(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPlayRepositoryMockRecorder) Record(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPlayRepository)(nil).Record), arg0, arg1)
+}
+
+// MockSongReader is a mock of SongReader interface.
+type MockSongReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongReaderMockRecorder
+}
+
+// MockSongReaderMockRecorder is the mock recorder for MockSongReader.
+type MockSongReaderMockRecorder struct {
+	mock *MockSongReader
+}
+
+// NewMockSongReader creates a new mock instance.
+func NewMockSongReader(ctrl *gomock.Controller) *MockSongReader {
+	mock := &MockSongReader{ctrl: ctrl}
+	mock.recorder = &MockSongReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongReader) EXPECT() *MockSongReaderMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockSongReader) Read(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSongReaderMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSongReader)(nil).Read), arg0, arg1)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(arg0 context.Context, arg1 *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockWebhookRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockWebhookRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockWebhookRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockWebhookRepository) ReadAll(arg0 context.Context) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockWebhookRepositoryMockRecorder) ReadAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockWebhookRepository)(nil).ReadAll), arg0)
+}
+
+// ReadForEvent mocks base method.
+func (m *MockWebhookRepository) ReadForEvent(arg0 context.Context, arg1 domain.SongEventType) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadForEvent", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadForEvent indicates an expected call of ReadForEvent.
+func (mr *MockWebhookRepositoryMockRecorder) ReadForEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadForEvent", reflect.TypeOf((*MockWebhookRepository)(nil).ReadForEvent), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockWebhookRepository) Update(arg0 context.Context, arg1 *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookRepository)(nil).Update), arg0, arg1)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadHistory mocks base method.
+func (m *MockAuditRepository) ReadHistory(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadHistory indicates an expected call of ReadHistory.
+func (mr *MockAuditRepositoryMockRecorder) ReadHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadHistory", reflect.TypeOf((*MockAuditRepository)(nil).ReadHistory), arg0, arg1, arg2, arg3)
+}
+
+// MockRevisionRepository is a mock of RevisionRepository interface.
+type MockRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRepositoryMockRecorder
+}
+
+// MockRevisionRepositoryMockRecorder is the mock recorder for MockRevisionRepository.
+type MockRevisionRepositoryMockRecorder struct {
+	mock *MockRevisionRepository
+}
+
+// NewMockRevisionRepository creates a new mock instance.
+func NewMockRevisionRepository(ctrl *gomock.Controller) *MockRevisionRepository {
+	mock := &MockRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRepository) EXPECT() *MockRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockRevisionRepository) Read(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*domain.SongRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.SongRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockRevisionRepositoryMockRecorder) Read(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockRevisionRepository)(nil).Read), arg0, arg1, arg2)
+}
+
+// ReadAll mocks base method.
+func (m *MockRevisionRepository) ReadAll(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int) ([]*domain.SongRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.SongRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockRevisionRepositoryMockRecorder) ReadAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockRevisionRepository)(nil).ReadAll), arg0, arg1, arg2, arg3)
+}
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTagRepository) Add(arg0 context.Context, arg1 uuid.UUID, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTagRepositoryMockRecorder) Add(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTagRepository)(nil).Add), arg0, arg1, arg2)
+}
+
+// ReadAll mocks base method.
+func (m *MockTagRepository) ReadAll(arg0 context.Context, arg1, arg2 int) ([]*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockTagRepositoryMockRecorder) ReadAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockTagRepository)(nil).ReadAll), arg0, arg1, arg2)
+}
+
+// ReadSongTags mocks base method.
+func (m *MockTagRepository) ReadSongTags(arg0 context.Context, arg1 uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSongTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSongTags indicates an expected call of ReadSongTags.
+func (mr *MockTagRepositoryMockRecorder) ReadSongTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSongTags", reflect.TypeOf((*MockTagRepository)(nil).ReadSongTags), arg0, arg1)
+}
+
+// Remove mocks base method.
+func (m *MockTagRepository) Remove(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTagRepositoryMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTagRepository)(nil).Remove), arg0, arg1, arg2)
+}
+
+// MockStaleSongRepository is a mock of StaleSongRepository interface.
+type MockStaleSongRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaleSongRepositoryMockRecorder
+}
+
+// MockStaleSongRepositoryMockRecorder is the mock recorder for MockStaleSongRepository.
+type MockStaleSongRepositoryMockRecorder struct {
+	mock *MockStaleSongRepository
+}
+
+// NewMockStaleSongRepository creates a new mock instance.
+func NewMockStaleSongRepository(ctrl *gomock.Controller) *MockStaleSongRepository {
+	mock := &MockStaleSongRepository{ctrl: ctrl}
+	mock.recorder = &MockStaleSongRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaleSongRepository) EXPECT() *MockStaleSongRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadStale mocks base method.
+func (m *MockStaleSongRepository) ReadStale(arg0 context.Context, arg1 time.Time, arg2 *domain.SongCursor, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadStale", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadStale indicates an expected call of ReadStale.
+func (mr *MockStaleSongRepositoryMockRecorder) ReadStale(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadStale", reflect.TypeOf((*MockStaleSongRepository)(nil).ReadStale), arg0, arg1, arg2, arg3)
+}
+
+// MockSongRefresher is a mock of SongRefresher interface.
+type MockSongRefresher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongRefresherMockRecorder
+}
+
+// MockSongRefresherMockRecorder is the mock recorder for MockSongRefresher.
+type MockSongRefresherMockRecorder struct {
+	mock *MockSongRefresher
+}
+
+// NewMockSongRefresher creates a new mock instance.
+func NewMockSongRefresher(ctrl *gomock.Controller) *MockSongRefresher {
+	mock := &MockSongRefresher{ctrl: ctrl}
+	mock.recorder = &MockSongRefresherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongRefresher) EXPECT() *MockSongRefresherMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockSongRefresher) Refresh(arg0 context.Context, arg1 *domain.SongInfo, arg2 bool) (*domain.Song, domain.SongFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(domain.SongFields)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSongRefresherMockRecorder) Refresh(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSongRefresher)(nil).Refresh), arg0, arg1, arg2)
+}
+
+// MockSongWriter is a mock of SongWriter interface.
+type MockSongWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongWriterMockRecorder
+}
+
+// MockSongWriterMockRecorder is the mock recorder for MockSongWriter.
+type MockSongWriterMockRecorder struct {
+	mock *MockSongWriter
+}
+
+// NewMockSongWriter creates a new mock instance.
+func NewMockSongWriter(ctrl *gomock.Controller) *MockSongWriter {
+	mock := &MockSongWriter{ctrl: ctrl}
+	mock.recorder = &MockSongWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongWriter) EXPECT() *MockSongWriterMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockSongWriter) Read(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSongWriterMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSongWriter)(nil).Read), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockSongWriter) Update(arg0 context.Context, arg1 *domain.SongInfo, arg2 *domain.Song) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSongWriterMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSongWriter)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockBlobStorage is a mock of BlobStorage interface.
+type MockBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStorageMockRecorder
+}
+
+// MockBlobStorageMockRecorder is the mock recorder for MockBlobStorage.
+type MockBlobStorageMockRecorder struct {
+	mock *MockBlobStorage
+}
+
+// NewMockBlobStorage creates a new mock instance.
+func NewMockBlobStorage(ctrl *gomock.Controller) *MockBlobStorage {
+	mock := &MockBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStorage) EXPECT() *MockBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBlobStorage) Get(arg0 context.Context, arg1 string) (*domain.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStorageMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStorage)(nil).Get), arg0, arg1)
+}
+
+// GetRange mocks base method.
+func (m *MockBlobStorage) GetRange(arg0 context.Context, arg1 string, arg2, arg3 int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockBlobStorageMockRecorder) GetRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockBlobStorage)(nil).GetRange), arg0, arg1, arg2, arg3)
+}
+
+// Put mocks base method.
+func (m *MockBlobStorage) Put(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 int64, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStorageMockRecorder) Put(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStorage)(nil).Put), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockAudioRepository is a mock of AudioRepository interface.
+type MockAudioRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAudioRepositoryMockRecorder
+}
+
+// MockAudioRepositoryMockRecorder is the mock recorder for MockAudioRepository.
+type MockAudioRepositoryMockRecorder struct {
+	mock *MockAudioRepository
+}
+
+// NewMockAudioRepository creates a new mock instance.
+func NewMockAudioRepository(ctrl *gomock.Controller) *MockAudioRepository {
+	mock := &MockAudioRepository{ctrl: ctrl}
+	mock.recorder = &MockAudioRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudioRepository) EXPECT() *MockAudioRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockAudioRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Audio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Audio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockAudioRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockAudioRepository)(nil).Read), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockAudioRepository) Save(arg0 context.Context, arg1 *domain.Audio) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAudioRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAudioRepository)(nil).Save), arg0, arg1)
+}
+
+// MockSuggestionRepository is a mock of SuggestionRepository interface.
+type MockSuggestionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestionRepositoryMockRecorder
+}
+
+// MockSuggestionRepositoryMockRecorder is the mock recorder for MockSuggestionRepository.
+type MockSuggestionRepositoryMockRecorder struct {
+	mock *MockSuggestionRepository
+}
+
+// NewMockSuggestionRepository creates a new mock instance.
+func NewMockSuggestionRepository(ctrl *gomock.Controller) *MockSuggestionRepository {
+	mock := &MockSuggestionRepository{ctrl: ctrl}
+	mock.recorder = &MockSuggestionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuggestionRepository) EXPECT() *MockSuggestionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockSuggestionRepository) Read(arg0 context.Context, arg1 string, arg2 int) ([]*domain.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSuggestionRepositoryMockRecorder) Read(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSuggestionRepository)(nil).Read), arg0, arg1, arg2)
+}
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepository) Search(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4, arg5 int) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepositoryMockRecorder) Search(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepository)(nil).Search), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MockSongLister is a mock of SongLister interface.
+type MockSongLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongListerMockRecorder
+}
+
+// MockSongListerMockRecorder is the mock recorder for MockSongLister.
+type MockSongListerMockRecorder struct {
+	mock *MockSongLister
+}
+
+// NewMockSongLister creates a new mock instance.
+func NewMockSongLister(ctrl *gomock.Controller) *MockSongLister {
+	mock := &MockSongLister{ctrl: ctrl}
+	mock.recorder = &MockSongListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongLister) EXPECT() *MockSongListerMockRecorder {
+	return m.recorder
+}
+
+// ReadAllWithFilter mocks base method.
+func (m *MockSongLister) ReadAllWithFilter(arg0 context.Context, arg1 *domain.SongFilter, arg2, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAllWithFilter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAllWithFilter indicates an expected call of ReadAllWithFilter.
+func (mr *MockSongListerMockRecorder) ReadAllWithFilter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllWithFilter", reflect.TypeOf((*MockSongLister)(nil).ReadAllWithFilter), arg0, arg1, arg2, arg3)
+}
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadReleaseCalendar mocks base method.
+func (m *MockCalendarRepository) ReadReleaseCalendar(arg0 context.Context, arg1 int, arg2 bool) ([]*domain.CalendarDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadReleaseCalendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.CalendarDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadReleaseCalendar indicates an expected call of ReadReleaseCalendar.
+func (mr *MockCalendarRepositoryMockRecorder) ReadReleaseCalendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadReleaseCalendar", reflect.TypeOf((*MockCalendarRepository)(nil).ReadReleaseCalendar), arg0, arg1, arg2)
+}
+
+// MockVectorSearchRepository is a mock of VectorSearchRepository interface.
+type MockVectorSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVectorSearchRepositoryMockRecorder
+}
+
+// MockVectorSearchRepositoryMockRecorder is the mock recorder for MockVectorSearchRepository.
+type MockVectorSearchRepositoryMockRecorder struct {
+	mock *MockVectorSearchRepository
+}
+
+// NewMockVectorSearchRepository creates a new mock instance.
+func NewMockVectorSearchRepository(ctrl *gomock.Controller) *MockVectorSearchRepository {
+	mock := &MockVectorSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockVectorSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVectorSearchRepository) EXPECT() *MockVectorSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SearchEmbeddings mocks base method.
+func (m *MockVectorSearchRepository) SearchEmbeddings(arg0 context.Context, arg1 []float32, arg2 bool, arg3, arg4 int) ([]*domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEmbeddings", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEmbeddings indicates an expected call of SearchEmbeddings.
+func (mr *MockVectorSearchRepositoryMockRecorder) SearchEmbeddings(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEmbeddings", reflect.TypeOf((*MockVectorSearchRepository)(nil).SearchEmbeddings), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockEmbedder is a mock of Embedder interface.
+type MockEmbedder struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmbedderMockRecorder
+}
+
+// MockEmbedderMockRecorder is the mock recorder for MockEmbedder.
+type MockEmbedderMockRecorder struct {
+	mock *MockEmbedder
+}
+
+// NewMockEmbedder creates a new mock instance.
+func NewMockEmbedder(ctrl *gomock.Controller) *MockEmbedder {
+	mock := &MockEmbedder{ctrl: ctrl}
+	mock.recorder = &MockEmbedderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmbedder) EXPECT() *MockEmbedderMockRecorder {
+	return m.recorder
+}
+
+// Embed mocks base method.
+func (m *MockEmbedder) Embed(arg0 context.Context, arg1 string) ([]float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Embed", arg0, arg1)
+	ret0, _ := ret[0].([]float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Embed indicates an expected call of Embed.
+func (mr *MockEmbedderMockRecorder) Embed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Embed", reflect.TypeOf((*MockEmbedder)(nil).Embed), arg0, arg1)
+}
+
+// MockEmbeddingRepository is a mock of EmbeddingRepository interface.
+type MockEmbeddingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmbeddingRepositoryMockRecorder
+}
+
+// MockEmbeddingRepositoryMockRecorder is the mock recorder for MockEmbeddingRepository.
+type MockEmbeddingRepositoryMockRecorder struct {
+	mock *MockEmbeddingRepository
+}
+
+// NewMockEmbeddingRepository creates a new mock instance.
+func NewMockEmbeddingRepository(ctrl *gomock.Controller) *MockEmbeddingRepository {
+	mock := &MockEmbeddingRepository{ctrl: ctrl}
+	mock.recorder = &MockEmbeddingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmbeddingRepository) EXPECT() *MockEmbeddingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadUnembedded mocks base method.
+func (m *MockEmbeddingRepository) ReadUnembedded(arg0 context.Context, arg1 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUnembedded", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUnembedded indicates an expected call of ReadUnembedded.
+func (mr *MockEmbeddingRepositoryMockRecorder) ReadUnembedded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUnembedded", reflect.TypeOf((*MockEmbeddingRepository)(nil).ReadUnembedded), arg0, arg1)
+}
+
+// SaveEmbedding mocks base method.
+func (m *MockEmbeddingRepository) SaveEmbedding(arg0 context.Context, arg1 *domain.Song, arg2 []float32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEmbedding", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEmbedding indicates an expected call of SaveEmbedding.
+func (mr *MockEmbeddingRepositoryMockRecorder) SaveEmbedding(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEmbedding", reflect.TypeOf((*MockEmbeddingRepository)(nil).SaveEmbedding), arg0, arg1, arg2)
+}
+
+// MockSimilarRepository is a mock of SimilarRepository interface.
+type MockSimilarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSimilarRepositoryMockRecorder
+}
+
+// MockSimilarRepositoryMockRecorder is the mock recorder for MockSimilarRepository.
+type MockSimilarRepositoryMockRecorder struct {
+	mock *MockSimilarRepository
+}
+
+// NewMockSimilarRepository creates a new mock instance.
+func NewMockSimilarRepository(ctrl *gomock.Controller) *MockSimilarRepository {
+	mock := &MockSimilarRepository{ctrl: ctrl}
+	mock.recorder = &MockSimilarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSimilarRepository) EXPECT() *MockSimilarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadSimilar mocks base method.
+func (m *MockSimilarRepository) ReadSimilar(arg0 context.Context, arg1 *domain.Song, arg2 bool, arg3 int) ([]*domain.SimilarSong, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSimilar", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.SimilarSong)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSimilar indicates an expected call of ReadSimilar.
+func (mr *MockSimilarRepositoryMockRecorder) ReadSimilar(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSimilar", reflect.TypeOf((*MockSimilarRepository)(nil).ReadSimilar), arg0, arg1, arg2, arg3)
+}
+
+// MockRandomRepository is a mock of RandomRepository interface.
+type MockRandomRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRandomRepositoryMockRecorder
+}
+
+// MockRandomRepositoryMockRecorder is the mock recorder for MockRandomRepository.
+type MockRandomRepositoryMockRecorder struct {
+	mock *MockRandomRepository
+}
+
+// NewMockRandomRepository creates a new mock instance.
+func NewMockRandomRepository(ctrl *gomock.Controller) *MockRandomRepository {
+	mock := &MockRandomRepository{ctrl: ctrl}
+	mock.recorder = &MockRandomRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRandomRepository) EXPECT() *MockRandomRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ForgetOfTheDay mocks base method.
+func (m *MockRandomRepository) ForgetOfTheDay(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgetOfTheDay", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgetOfTheDay indicates an expected call of ForgetOfTheDay.
+func (mr *MockRandomRepositoryMockRecorder) ForgetOfTheDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetOfTheDay", reflect.TypeOf((*MockRandomRepository)(nil).ForgetOfTheDay), arg0, arg1)
+}
+
+// ReadOfTheDay mocks base method.
+func (m *MockRandomRepository) ReadOfTheDay(arg0 context.Context, arg1 string, arg2 time.Duration) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOfTheDay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOfTheDay indicates an expected call of ReadOfTheDay.
+func (mr *MockRandomRepositoryMockRecorder) ReadOfTheDay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOfTheDay", reflect.TypeOf((*MockRandomRepository)(nil).ReadOfTheDay), arg0, arg1, arg2)
+}
+
+// ReadRandom mocks base method.
+func (m *MockRandomRepository) ReadRandom(arg0 context.Context, arg1 *domain.SongFilter) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRandom", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRandom indicates an expected call of ReadRandom.
+func (mr *MockRandomRepositoryMockRecorder) ReadRandom(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRandom", reflect.TypeOf((*MockRandomRepository)(nil).ReadRandom), arg0, arg1)
+}
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockStatsRepository) Read(arg0 context.Context, arg1 time.Time) (*domain.LibraryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.LibraryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockStatsRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockStatsRepository)(nil).Read), arg0, arg1)
+}
+
+// MockGroupRepository is a mock of GroupRepository interface.
+type MockGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupRepositoryMockRecorder
+}
+
+// MockGroupRepositoryMockRecorder is the mock recorder for MockGroupRepository.
+type MockGroupRepositoryMockRecorder struct {
+	mock *MockGroupRepository
+}
+
+// NewMockGroupRepository creates a new mock instance.
+func NewMockGroupRepository(ctrl *gomock.Controller) *MockGroupRepository {
+	mock := &MockGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupRepository) EXPECT() *MockGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadAll mocks base method.
+func (m *MockGroupRepository) ReadAll(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockGroupRepositoryMockRecorder) ReadAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockGroupRepository)(nil).ReadAll), arg0, arg1, arg2, arg3)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(arg0 context.Context, arg1 *domain.Webhook, arg2 domain.SongEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), arg0, arg1, arg2)
+}
+
+// MockMusicInfoCache is a mock of MusicInfoCache interface.
+type MockMusicInfoCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockMusicInfoCacheMockRecorder
+}
+
+// MockMusicInfoCacheMockRecorder is the mock recorder for MockMusicInfoCache.
+type MockMusicInfoCacheMockRecorder struct {
+	mock *MockMusicInfoCache
+}
+
+// NewMockMusicInfoCache creates a new mock instance.
+func NewMockMusicInfoCache(ctrl *gomock.Controller) *MockMusicInfoCache {
+	mock := &MockMusicInfoCache{ctrl: ctrl}
+	mock.recorder = &MockMusicInfoCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMusicInfoCache) EXPECT() *MockMusicInfoCacheMockRecorder {
+	return m.recorder
+}
+
+// GetMusicInfo mocks base method.
+func (m *MockMusicInfoCache) GetMusicInfo(arg0 context.Context, arg1 *domain.SongInfo) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusicInfo", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusicInfo indicates an expected call of GetMusicInfo.
+func (mr *MockMusicInfoCacheMockRecorder) GetMusicInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusicInfo", reflect.TypeOf((*MockMusicInfoCache)(nil).GetMusicInfo), arg0, arg1)
+}
+
+// SetMusicInfo mocks base method.
+func (m *MockMusicInfoCache) SetMusicInfo(arg0 context.Context, arg1 *domain.SongInfo, arg2 *domain.Song, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMusicInfo", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMusicInfo indicates an expected call of SetMusicInfo.
+func (mr *MockMusicInfoCacheMockRecorder) SetMusicInfo(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMusicInfo", reflect.TypeOf((*MockMusicInfoCache)(nil).SetMusicInfo), arg0, arg1, arg2, arg3)
+}
+
+// MockCacheRepository is a mock of CacheRepository interface.
+type MockCacheRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheRepositoryMockRecorder
+}
+
+// MockCacheRepositoryMockRecorder is the mock recorder for MockCacheRepository.
+type MockCacheRepositoryMockRecorder struct {
+	mock *MockCacheRepository
+}
+
+// NewMockCacheRepository creates a new mock instance.
+func NewMockCacheRepository(ctrl *gomock.Controller) *MockCacheRepository {
+	mock := &MockCacheRepository{ctrl: ctrl}
+	mock.recorder = &MockCacheRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheRepository) EXPECT() *MockCacheRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CacheRecovery mocks base method.
+func (m *MockCacheRepository) CacheRecovery(arg0 context.Context, arg1, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheRecovery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CacheRecovery indicates an expected call of CacheRecovery.
+func (mr *MockCacheRepositoryMockRecorder) CacheRecovery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheRecovery", reflect.TypeOf((*MockCacheRepository)(nil).CacheRecovery), arg0, arg1, arg2)
+}
+
+// CacheStats mocks base method.
+func (m *MockCacheRepository) CacheStats(arg0 context.Context) (*domain.CacheStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheStats", arg0)
+	ret0, _ := ret[0].(*domain.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CacheStats indicates an expected call of CacheStats.
+func (mr *MockCacheRepositoryMockRecorder) CacheStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheStats", reflect.TypeOf((*MockCacheRepository)(nil).CacheStats), arg0)
+}
+
+// FlushCache mocks base method.
+func (m *MockCacheRepository) FlushCache(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushCache", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushCache indicates an expected call of FlushCache.
+func (mr *MockCacheRepositoryMockRecorder) FlushCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushCache", reflect.TypeOf((*MockCacheRepository)(nil).FlushCache), arg0)
+}
+
+// InvalidateCache mocks base method.
+func (m *MockCacheRepository) InvalidateCache(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateCache indicates an expected call of InvalidateCache.
+func (mr *MockCacheRepositoryMockRecorder) InvalidateCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateCache", reflect.TypeOf((*MockCacheRepository)(nil).InvalidateCache), arg0, arg1)
+}
+
+// MockWriteBehindRepository is a mock of WriteBehindRepository interface.
+type MockWriteBehindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteBehindRepositoryMockRecorder
+}
+
+// MockWriteBehindRepositoryMockRecorder is the mock recorder for MockWriteBehindRepository.
+type MockWriteBehindRepositoryMockRecorder struct {
+	mock *MockWriteBehindRepository
+}
+
+// NewMockWriteBehindRepository creates a new mock instance.
+func NewMockWriteBehindRepository(ctrl *gomock.Controller) *MockWriteBehindRepository {
+	mock := &MockWriteBehindRepository{ctrl: ctrl}
+	mock.recorder = &MockWriteBehindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteBehindRepository) EXPECT() *MockWriteBehindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FlushWrites mocks base method.
+func (m *MockWriteBehindRepository) FlushWrites(arg0 context.Context, arg1 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushWrites", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushWrites indicates an expected call of FlushWrites.
+func (mr *MockWriteBehindRepositoryMockRecorder) FlushWrites(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushWrites", reflect.TypeOf((*MockWriteBehindRepository)(nil).FlushWrites), arg0, arg1)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockOutboxRepository) Relay(arg0 context.Context, arg1 int, arg2 func(*domain.OutboxEvent) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxRepositoryMockRecorder) Relay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutboxRepository)(nil).Relay), arg0, arg1, arg2)
+}
+
+// MockLibraryRepository is a mock of LibraryRepository interface.
+type MockLibraryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLibraryRepositoryMockRecorder
+}
+
+// MockLibraryRepositoryMockRecorder is the mock recorder for MockLibraryRepository.
+type MockLibraryRepositoryMockRecorder struct {
+	mock *MockLibraryRepository
+}
+
+// NewMockLibraryRepository creates a new mock instance.
+func NewMockLibraryRepository(ctrl *gomock.Controller) *MockLibraryRepository {
+	mock := &MockLibraryRepository{ctrl: ctrl}
+	mock.recorder = &MockLibraryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLibraryRepository) EXPECT() *MockLibraryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLibraryRepository) Create(arg0 context.Context, arg1 *domain.Library) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLibraryRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLibraryRepository)(nil).Create), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockLibraryRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Library, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Library)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockLibraryRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockLibraryRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockLibraryRepository) ReadAll(arg0 context.Context) ([]*domain.Library, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0)
+	ret0, _ := ret[0].([]*domain.Library)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockLibraryRepositoryMockRecorder) ReadAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockLibraryRepository)(nil).ReadAll), arg0)
+}
+
+// MockUserRepository is a mock of UserRepository interface.
+type MockUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepositoryMockRecorder
+}
+
+// MockUserRepositoryMockRecorder is the mock recorder for MockUserRepository.
+type MockUserRepositoryMockRecorder struct {
+	mock *MockUserRepository
+}
+
+// NewMockUserRepository creates a new mock instance.
+func NewMockUserRepository(ctrl *gomock.Controller) *MockUserRepository {
+	mock := &MockUserRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockUserRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockUserRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockUserRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockUserRepository) ReadAll(arg0 context.Context) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockUserRepositoryMockRecorder) ReadAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockUserRepository)(nil).ReadAll), arg0)
+}
+
+// Upsert mocks base method.
+func (m *MockUserRepository) Upsert(arg0 context.Context, arg1 *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserRepositoryMockRecorder) Upsert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserRepository)(nil).Upsert), arg0, arg1)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepository) Create(arg0 context.Context, arg1 *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepository)(nil).Create), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockAPIKeyRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockAPIKeyRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockAPIKeyRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockAPIKeyRepository) ReadAll(arg0 context.Context) ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0)
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockAPIKeyRepositoryMockRecorder) ReadAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockAPIKeyRepository)(nil).ReadAll), arg0)
+}
+
+// ReadByHash mocks base method.
+func (m *MockAPIKeyRepository) ReadByHash(arg0 context.Context, arg1 string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByHash", arg0, arg1)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByHash indicates an expected call of ReadByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) ReadByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).ReadByHash), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepository) Revoke(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepositoryMockRecorder) Revoke(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepository)(nil).Revoke), arg0, arg1, arg2)
+}
+
+// MockNormalizeRepository is a mock of NormalizeRepository interface.
+type MockNormalizeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNormalizeRepositoryMockRecorder
+}
+
+// MockNormalizeRepositoryMockRecorder is the mock recorder for MockNormalizeRepository.
+type MockNormalizeRepositoryMockRecorder struct {
+	mock *MockNormalizeRepository
+}
+
+// NewMockNormalizeRepository creates a new mock instance.
+func NewMockNormalizeRepository(ctrl *gomock.Controller) *MockNormalizeRepository {
+	mock := &MockNormalizeRepository{ctrl: ctrl}
+	mock.recorder = &MockNormalizeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNormalizeRepository) EXPECT() *MockNormalizeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReadAllAfter mocks base method.
+func (m *MockNormalizeRepository) ReadAllAfter(arg0 context.Context, arg1 *domain.SongFilter, arg2 *domain.SongCursor, arg3 int) ([]*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAllAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAllAfter indicates an expected call of ReadAllAfter.
+func (mr *MockNormalizeRepositoryMockRecorder) ReadAllAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllAfter", reflect.TypeOf((*MockNormalizeRepository)(nil).ReadAllAfter), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockNormalizeRepository) Update(arg0 context.Context, arg1 *domain.SongInfo, arg2 *domain.Song) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNormalizeRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNormalizeRepository)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockLibraryLister is a mock of LibraryLister interface.
+type MockLibraryLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockLibraryListerMockRecorder
+}
+
+// MockLibraryListerMockRecorder is the mock recorder for MockLibraryLister.
+type MockLibraryListerMockRecorder struct {
+	mock *MockLibraryLister
+}
+
+// NewMockLibraryLister creates a new mock instance.
+func NewMockLibraryLister(ctrl *gomock.Controller) *MockLibraryLister {
+	mock := &MockLibraryLister{ctrl: ctrl}
+	mock.recorder = &MockLibraryListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLibraryLister) EXPECT() *MockLibraryListerMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockLibraryLister) GetAll(arg0 context.Context) ([]*domain.Library, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*domain.Library)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLibraryListerMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLibraryLister)(nil).GetAll), arg0)
+}
+
+// MockPlaylistRepository is a mock of PlaylistRepository interface.
+type MockPlaylistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistRepositoryMockRecorder
+}
+
+// MockPlaylistRepositoryMockRecorder is the mock recorder for MockPlaylistRepository.
+type MockPlaylistRepositoryMockRecorder struct {
+	mock *MockPlaylistRepository
+}
+
+// NewMockPlaylistRepository creates a new mock instance.
+func NewMockPlaylistRepository(ctrl *gomock.Controller) *MockPlaylistRepository {
+	mock := &MockPlaylistRepository{ctrl: ctrl}
+	mock.recorder = &MockPlaylistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistRepository) EXPECT() *MockPlaylistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPlaylistRepository) Create(arg0 context.Context, arg1 *domain.SmartPlaylist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaylistRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaylistRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPlaylistRepository) Delete(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaylistRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaylistRepository)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Read mocks base method.
+func (m *MockPlaylistRepository) Read(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.SmartPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.SmartPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockPlaylistRepositoryMockRecorder) Read(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockPlaylistRepository)(nil).Read), arg0, arg1, arg2)
+}
+
+// ReadAll mocks base method.
+func (m *MockPlaylistRepository) ReadAll(arg0 context.Context, arg1 uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.SmartPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockPlaylistRepositoryMockRecorder) ReadAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockPlaylistRepository)(nil).ReadAll), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockPlaylistRepository) Update(arg0 context.Context, arg1 *domain.SmartPlaylist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaylistRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaylistRepository)(nil).Update), arg0, arg1)
+}
+
+// MockPendingSongs is a mock of PendingSongs interface.
+type MockPendingSongs struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingSongsMockRecorder
+}
+
+// MockPendingSongsMockRecorder is the mock recorder for MockPendingSongs.
+type MockPendingSongsMockRecorder struct {
+	mock *MockPendingSongs
+}
+
+// NewMockPendingSongs creates a new mock instance.
+func NewMockPendingSongs(ctrl *gomock.Controller) *MockPendingSongs {
+	mock := &MockPendingSongs{ctrl: ctrl}
+	mock.recorder = &MockPendingSongsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingSongs) EXPECT() *MockPendingSongsMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockPendingSongs) Refresh(arg0 context.Context, arg1 *domain.SongInfo, arg2 bool) (*domain.Song, domain.SongFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(domain.SongFields)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPendingSongsMockRecorder) Refresh(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPendingSongs)(nil).Refresh), arg0, arg1, arg2)
+}
+
+// SetStatus mocks base method.
+func (m *MockPendingSongs) SetStatus(arg0 context.Context, arg1 *domain.SongInfo, arg2 domain.SongStatus) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPendingSongsMockRecorder) SetStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPendingSongs)(nil).SetStatus), arg0, arg1, arg2)
+}
+
+// MockShareRepository is a mock of ShareRepository interface.
+type MockShareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareRepositoryMockRecorder
+}
+
+// MockShareRepositoryMockRecorder is the mock recorder for MockShareRepository.
+type MockShareRepositoryMockRecorder struct {
+	mock *MockShareRepository
+}
+
+// NewMockShareRepository creates a new mock instance.
+func NewMockShareRepository(ctrl *gomock.Controller) *MockShareRepository {
+	mock := &MockShareRepository{ctrl: ctrl}
+	mock.recorder = &MockShareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareRepository) EXPECT() *MockShareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShareRepository) Create(arg0 context.Context, arg1 *domain.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShareRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShareRepository)(nil).Create), arg0, arg1)
+}
+
+// Read mocks base method.
+func (m *MockShareRepository) Read(arg0 context.Context, arg1 uuid.UUID) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockShareRepositoryMockRecorder) Read(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockShareRepository)(nil).Read), arg0, arg1)
+}
+
+// ReadAll mocks base method.
+func (m *MockShareRepository) ReadAll(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockShareRepositoryMockRecorder) ReadAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockShareRepository)(nil).ReadAll), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockShareRepository) Revoke(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Time) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareRepositoryMockRecorder) Revoke(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareRepository)(nil).Revoke), arg0, arg1, arg2, arg3)
+}
+
+// MockRatingRepository is a mock of RatingRepository interface.
+type MockRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepositoryMockRecorder
+}
+
+// MockRatingRepositoryMockRecorder is the mock recorder for MockRatingRepository.
+type MockRatingRepositoryMockRecorder struct {
+	mock *MockRatingRepository
+}
+
+// NewMockRatingRepository creates a new mock instance.
+func NewMockRatingRepository(ctrl *gomock.Controller) *MockRatingRepository {
+	mock := &MockRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepository) EXPECT() *MockRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockRatingRepository) Read(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockRatingRepositoryMockRecorder) Read(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockRatingRepository)(nil).Read), arg0, arg1, arg2)
+}
+
+// Remove mocks base method.
+func (m *MockRatingRepository) Remove(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRatingRepositoryMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRatingRepository)(nil).Remove), arg0, arg1, arg2)
+}
+
+// Set mocks base method.
+func (m *MockRatingRepository) Set(arg0 context.Context, arg1 *domain.Rating) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRatingRepositoryMockRecorder) Set(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRatingRepository)(nil).Set), arg0, arg1)
+}
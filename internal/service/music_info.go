@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// MusicInfoProvider is a named source of song details
+type MusicInfoProvider struct {
+	Name      string
+	MusicInfo MusicInfo
+}
+
+// MusicInfoChain asks the providers in order and returns the details of the
+// first one that answers, with the song's Source set to the provider name.
+type MusicInfoChain struct {
+	providers []MusicInfoProvider
+	log       *slog.Logger
+}
+
+func NewMusicInfoChain(providers []MusicInfoProvider, log *slog.Logger) *MusicInfoChain {
+	return &MusicInfoChain{
+		providers: providers,
+		log:       log,
+	}
+}
+
+func (c *MusicInfoChain) FetchMusicInfo(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "MusicInfoChain.FetchMusicInfo"
+
+	log := c.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_name", song.Name),
+		slog.String("group_name", song.Group),
+	)
+
+	var errs []error
+	for _, provider := range c.providers {
+		details, err := provider.MusicInfo.FetchMusicInfo(ctx, song)
+		if err == nil {
+			details.Source = provider.Name
+			log.Debug("song info supplied by provider", slog.String("provider", provider.Name))
+			return details, nil
+		}
+
+		log.Warn("provider failed to supply song info", slog.String("provider", provider.Name), sl.Err(err))
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+
+		// The remaining providers would fail the same way
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("%s: no provider supplied song info: %w", op, errors.Join(errs...))
+}
+
+// MusicInfoCache stores MusicInfo responses by group and song name
+type MusicInfoCache interface {
+	GetMusicInfo(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	SetMusicInfo(ctx context.Context, song *domain.SongInfo, details *domain.Song, ttl time.Duration) error
+}
+
+// CachedMusicInfo remembers successful responses of the wrapped MusicInfo for
+// ttl, so retrying the same song doesn't query the external API again. Cache
+// failures are logged and never fail the request.
+type CachedMusicInfo struct {
+	next  MusicInfo
+	cache MusicInfoCache
+	ttl   time.Duration
+	log   *slog.Logger
+}
+
+func NewCachedMusicInfo(next MusicInfo, cache MusicInfoCache, ttl time.Duration, log *slog.Logger) *CachedMusicInfo {
+	return &CachedMusicInfo{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+		log:   log,
+	}
+}
+
+func (c *CachedMusicInfo) FetchMusicInfo(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "CachedMusicInfo.FetchMusicInfo"
+
+	log := c.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_name", song.Name),
+		slog.String("group_name", song.Group),
+	)
+
+	details, err := c.cache.GetMusicInfo(ctx, song)
+	if err == nil {
+		log.Debug("music info found in cache")
+		return details, nil
+	}
+	if !errors.Is(err, domain.ErrSongNotFound) {
+		log.Warn("failed to read music info from cache", sl.Err(err))
+	}
+
+	details, err = c.next.FetchMusicInfo(ctx, song)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.cache.SetMusicInfo(ctx, song, details, c.ttl); err != nil {
+		log.Warn("failed to cache music info", sl.Err(err))
+	}
+
+	return details, nil
+}
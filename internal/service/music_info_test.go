@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMusicInfoChain_FetchMusicInfo_Fallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockMusicInfo(ctrl)
+	secondary := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	chain := service.NewMusicInfoChain([]service.MusicInfoProvider{
+		{Name: "primary", MusicInfo: primary},
+		{Name: "secondary", MusicInfo: secondary},
+	}, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+
+	// Первый провайдер недоступен, песню возвращает второй
+	primary.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(nil, errors.New("unavailable"))
+	secondary.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(&domain.Song{Name: "Song", Group: "Group", Text: "Text"}, nil)
+
+	song, err := chain.FetchMusicInfo(context.Background(), songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "secondary", song.Source)
+}
+
+func TestMusicInfoChain_FetchMusicInfo_FirstWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockMusicInfo(ctrl)
+	secondary := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	chain := service.NewMusicInfoChain([]service.MusicInfoProvider{
+		{Name: "primary", MusicInfo: primary},
+		{Name: "secondary", MusicInfo: secondary},
+	}, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+
+	// Второй провайдер не должен вызываться
+	primary.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(&domain.Song{Name: "Song", Group: "Group", Text: "Text"}, nil)
+
+	song, err := chain.FetchMusicInfo(context.Background(), songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", song.Source)
+}
+
+func TestMusicInfoChain_FetchMusicInfo_AllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockMusicInfo(ctrl)
+	secondary := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	chain := service.NewMusicInfoChain([]service.MusicInfoProvider{
+		{Name: "primary", MusicInfo: primary},
+		{Name: "secondary", MusicInfo: secondary},
+	}, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+
+	primary.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(nil, domain.ErrInvalidSongText)
+	secondary.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(nil, errors.New("unavailable"))
+
+	song, err := chain.FetchMusicInfo(context.Background(), songInfo)
+	assert.Nil(t, song)
+	assert.ErrorIs(t, err, domain.ErrInvalidSongText)
+}
+
+func TestCachedMusicInfo_FetchMusicInfo_Hit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockMusicInfo(ctrl)
+	cache := mocks.NewMockMusicInfoCache(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cached := service.NewCachedMusicInfo(next, cache, time.Hour, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+	details := &domain.Song{Name: "Song", Group: "Group", Text: "Text", Source: "primary"}
+
+	// Ответ берется из кэша, внешнее API не вызывается
+	cache.EXPECT().GetMusicInfo(gomock.Any(), songInfo).Return(details, nil)
+
+	song, err := cached.FetchMusicInfo(context.Background(), songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, details, song)
+}
+
+func TestCachedMusicInfo_FetchMusicInfo_Miss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockMusicInfo(ctrl)
+	cache := mocks.NewMockMusicInfoCache(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cached := service.NewCachedMusicInfo(next, cache, time.Hour, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+	details := &domain.Song{Name: "Song", Group: "Group", Text: "Text"}
+
+	cache.EXPECT().GetMusicInfo(gomock.Any(), songInfo).Return(nil, domain.ErrSongNotFound)
+	next.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(details, nil)
+	cache.EXPECT().SetMusicInfo(gomock.Any(), songInfo, details, time.Hour).Return(nil)
+
+	song, err := cached.FetchMusicInfo(context.Background(), songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, details, song)
+}
+
+func TestCachedMusicInfo_FetchMusicInfo_CacheFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockMusicInfo(ctrl)
+	cache := mocks.NewMockMusicInfoCache(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cached := service.NewCachedMusicInfo(next, cache, time.Hour, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+	details := &domain.Song{Name: "Song", Group: "Group", Text: "Text"}
+
+	// Недоступный кэш не мешает получить ответ
+	cache.EXPECT().GetMusicInfo(gomock.Any(), songInfo).Return(nil, errors.New("connection refused"))
+	next.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(details, nil)
+	cache.EXPECT().SetMusicInfo(gomock.Any(), songInfo, details, time.Hour).Return(errors.New("connection refused"))
+
+	song, err := cached.FetchMusicInfo(context.Background(), songInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, details, song)
+}
+
+func TestCachedMusicInfo_FetchMusicInfo_ErrorNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockMusicInfo(ctrl)
+	cache := mocks.NewMockMusicInfoCache(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	cached := service.NewCachedMusicInfo(next, cache, time.Hour, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Song", Group: "Group"}
+
+	// Ошибки не кэшируются, SetMusicInfo не вызывается
+	cache.EXPECT().GetMusicInfo(gomock.Any(), songInfo).Return(nil, domain.ErrSongNotFound)
+	next.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(nil, errors.New("unavailable"))
+
+	song, err := cached.FetchMusicInfo(context.Background(), songInfo)
+	assert.Error(t, err)
+	assert.Nil(t, song)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// defaultNormalizeBatchSize is how many songs a normalization run reads at a
+// time
+const defaultNormalizeBatchSize = 100
+
+type NormalizeRepository interface {
+	ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
+}
+
+// LibraryLister lists the libraries, it is satisfied by LibraryService
+type LibraryLister interface {
+	GetAll(ctx context.Context) ([]*domain.Library, error)
+}
+
+// NormalizeService runs NormalizeLyrics over the songs saved before texts
+// were normalized on ingest, library by library and BatchSize songs at a time
+type NormalizeService struct {
+	Repo      NormalizeRepository
+	Libraries LibraryLister
+	BatchSize int
+	log       *slog.Logger
+}
+
+func NewNormalizeService(r NormalizeRepository, libraries LibraryLister, log *slog.Logger) *NormalizeService {
+	return &NormalizeService{
+		Repo:      r,
+		Libraries: libraries,
+		BatchSize: defaultNormalizeBatchSize,
+		log:       log,
+	}
+}
+
+// Normalize normalizes the texts of all songs and saves the ones that
+// changed, with dryRun they are only counted. A song that fails to save is
+// counted and skipped, the run stops when ctx is cancelled.
+func (s *NormalizeService) Normalize(ctx context.Context, dryRun bool) (*domain.NormalizeReport, error) {
+	const op = "NormalizeService.Normalize"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	log.Info("lyrics normalization started")
+
+	libraries, err := s.Libraries.GetAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch libraries", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch libraries: %w", op, err)
+	}
+
+	report := &domain.NormalizeReport{DryRun: dryRun}
+	for _, library := range libraries {
+		if err := s.normalizeLibrary(domain.WithLibraryID(ctx, library.ID), log, report); err != nil {
+			log.Error("failed to fetch songs", slog.String("library_id", library.ID.String()), sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to fetch songs: %w", op, err)
+		}
+	}
+
+	log.Info("lyrics normalization finished",
+		slog.Int("scanned", report.Scanned),
+		slog.Int("changed", report.Changed),
+		slog.Int("failed", report.Failed),
+	)
+	return report, nil
+}
+
+func (s *NormalizeService) normalizeLibrary(ctx context.Context, log *slog.Logger, report *domain.NormalizeReport) error {
+	return walkLibrary(ctx, s.Repo, s.BatchSize, func(song *domain.Song) {
+		report.Scanned++
+
+		text := NormalizeLyrics(song.Text)
+		if text == song.Text {
+			return
+		}
+		report.Changed++
+		if report.DryRun {
+			return
+		}
+
+		normalized := *song
+		normalized.Text = text
+		normalized.Lyrics = ParseLyrics(text)
+		normalized.UpdatedAt = time.Now()
+		if err := s.Repo.Update(ctx, &domain.SongInfo{ID: song.ID}, &normalized); err != nil {
+			log.Warn("failed to save normalized song", slog.String("song_id", song.ID.String()), sl.Err(err))
+			report.Changed--
+			report.Failed++
+		}
+	})
+}
+
+// walkLibrary calls fn for every song of the library of ctx, newest first,
+// reading batchSize songs at a time
+func walkLibrary(ctx context.Context, repo NormalizeRepository, batchSize int, fn func(song *domain.Song)) error {
+	var after *domain.SongCursor
+	for {
+		songs, err := repo.ReadAllAfter(ctx, &domain.SongFilter{}, after, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, song := range songs {
+			fn(song)
+		}
+
+		if len(songs) < batchSize {
+			return nil
+		}
+		after = domain.CursorOf(songs[len(songs)-1])
+	}
+}
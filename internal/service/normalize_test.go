@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeService_Normalize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNormalizeRepository(ctrl)
+	mockLibraries := mocks.NewMockLibraryLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	normalizeService := service.NewNormalizeService(mockRepo, mockLibraries, mockLog)
+	normalizeService.BatchSize = 2
+
+	libraryID := uuid.New()
+	mockLibraries.EXPECT().GetAll(gomock.Any()).Return([]*domain.Library{{ID: domain.DefaultLibraryID}, {ID: libraryID}}, nil)
+
+	now := time.Now()
+	clean := &domain.Song{ID: uuid.New(), Text: "It's bugging me", CreatedAt: now}
+	dirty := &domain.Song{ID: uuid.New(), Text: "It's bugging me \r\n", CreatedAt: now.Add(-time.Minute)}
+	conflicted := &domain.Song{ID: uuid.New(), Text: "Grating me\r\n", CreatedAt: now}
+
+	// Песни читаются порциями в контексте своей библиотеки
+	gomock.InOrder(
+		mockRepo.EXPECT().ReadAllAfter(gomock.Any(), &domain.SongFilter{}, nil, 2).
+			DoAndReturn(func(ctx context.Context, _ *domain.SongFilter, _ *domain.SongCursor, _ int) ([]*domain.Song, error) {
+				assert.Equal(t, domain.DefaultLibraryID, domain.LibraryIDFromContext(ctx))
+				return []*domain.Song{clean, dirty}, nil
+			}),
+		mockRepo.EXPECT().ReadAllAfter(gomock.Any(), &domain.SongFilter{}, domain.CursorOf(dirty), 2).Return(nil, nil),
+		mockRepo.EXPECT().ReadAllAfter(gomock.Any(), &domain.SongFilter{}, nil, 2).
+			DoAndReturn(func(ctx context.Context, _ *domain.SongFilter, _ *domain.SongCursor, _ int) ([]*domain.Song, error) {
+				assert.Equal(t, libraryID, domain.LibraryIDFromContext(ctx))
+				return []*domain.Song{conflicted}, nil
+			}),
+	)
+
+	mockRepo.EXPECT().Update(gomock.Any(), &domain.SongInfo{ID: dirty.ID}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+			assert.Equal(t, "It's bugging me", song.Text)
+			assert.Equal(t, []string{"It's bugging me"}, song.Lyrics.Verses())
+			return nil
+		})
+	mockRepo.EXPECT().Update(gomock.Any(), &domain.SongInfo{ID: conflicted.ID}, gomock.Any()).Return(domain.ErrVersionConflict)
+
+	report, err := normalizeService.Normalize(context.Background(), false)
+
+	// Песня, которую не удалось сохранить, считается отдельно
+	require.NoError(t, err)
+	assert.Equal(t, &domain.NormalizeReport{Scanned: 3, Changed: 1, Failed: 1}, report)
+}
+
+func TestNormalizeService_Normalize_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNormalizeRepository(ctrl)
+	mockLibraries := mocks.NewMockLibraryLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	normalizeService := service.NewNormalizeService(mockRepo, mockLibraries, mockLog)
+
+	mockLibraries.EXPECT().GetAll(gomock.Any()).Return([]*domain.Library{{ID: domain.DefaultLibraryID}}, nil)
+	mockRepo.EXPECT().ReadAllAfter(gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return([]*domain.Song{{ID: uuid.New(), Text: "\uFEFFIt's bugging me"}}, nil)
+
+	// Без сохранения: Update не вызывается
+	report, err := normalizeService.Normalize(context.Background(), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.NormalizeReport{Scanned: 1, Changed: 1, DryRun: true}, report)
+}
+
+func TestNormalizeService_Normalize_LibrariesFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLibraries := mocks.NewMockLibraryLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	normalizeService := service.NewNormalizeService(mocks.NewMockNormalizeRepository(ctrl), mockLibraries, mockLog)
+
+	mockLibraries.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	_, err := normalizeService.Normalize(context.Background(), false)
+	assert.Error(t, err)
+}
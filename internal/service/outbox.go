@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync/atomic"
+	"time"
+)
+
+type OutboxRepository interface {
+	Relay(ctx context.Context, limit int, publish func(event *domain.OutboxEvent) error) (int, error)
+}
+
+// Broker publishes song events to a message broker for other services
+type Broker interface {
+	Publish(ctx context.Context, event domain.SongEvent) error
+}
+
+// OutboxRelay publishes the events stored in the outbox with the song changes
+// they describe. An event is published at least once: after a failure or a
+// crash it is published again. Events are sent to the Broker, when it is set,
+// before they are published in the service, so an event the broker rejects
+// stays in the outbox.
+type OutboxRelay struct {
+	Repo      OutboxRepository
+	Events    Publisher
+	Broker    Broker
+	batchSize int
+	published atomic.Int64
+	failures  atomic.Int64
+	log       *slog.Logger
+}
+
+func NewOutboxRelay(r OutboxRepository, events Publisher, batchSize int, log *slog.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		Repo:      r,
+		Events:    events,
+		batchSize: batchSize,
+		log:       log,
+	}
+}
+
+// Run publishes the events in the outbox every interval until ctx is
+// cancelled. The outbox is drained in batches, so a backlog left by a
+// previous run is published right after the start.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	const op = "OutboxRelay.Run"
+
+	log := r.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Duration("interval", interval),
+	)
+
+	log.Info("outbox relay started")
+	r.relay(ctx, log)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.relay(ctx, log)
+		case <-ctx.Done():
+			log.Info("outbox relay stopped")
+			return
+		}
+	}
+}
+
+// relay publishes batches of events until the outbox is empty
+func (r *OutboxRelay) relay(ctx context.Context, log *slog.Logger) {
+	for ctx.Err() == nil {
+		published, err := r.Repo.Relay(ctx, r.batchSize, func(event *domain.OutboxEvent) error {
+			if r.Broker != nil {
+				if err := r.Broker.Publish(ctx, event.Event); err != nil {
+					return err
+				}
+			}
+			r.Events.Publish(event.Event)
+			return nil
+		})
+		if err != nil {
+			r.failures.Add(1)
+			log.Error("failed to relay events from outbox", sl.Err(err))
+			return
+		}
+
+		r.published.Add(int64(published))
+		if published > 0 {
+			log.Debug("events relayed from outbox", slog.Int("events", published))
+		}
+		if published < r.batchSize {
+			return
+		}
+	}
+}
+
+// Stats returns the number of events published and of failed relays since
+// the start
+func (r *OutboxRelay) Stats() map[string]int64 {
+	return map[string]int64{
+		"published": r.published.Load(),
+		"failures":  r.failures.Load(),
+	}
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// relayBatch serves events to the publish function like the outbox repository
+func relayBatch(events ...domain.SongEventType) func(context.Context, int, func(*domain.OutboxEvent) error) (int, error) {
+	return func(_ context.Context, _ int, publish func(*domain.OutboxEvent) error) (int, error) {
+		for i, eventType := range events {
+			if err := publish(&domain.OutboxEvent{ID: int64(i + 1), Event: domain.SongEvent{Type: eventType}}); err != nil {
+				return 0, err
+			}
+		}
+		return len(events), nil
+	}
+}
+
+func TestOutboxRelay_Run_DrainsBacklog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	publisher := &recordingPublisher{}
+	relay := service.NewOutboxRelay(mockRepo, publisher, 2, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Полная партия означает, что в outbox могут остаться события: они читаются сразу
+	drained := make(chan struct{})
+	gomock.InOrder(
+		mockRepo.EXPECT().Relay(gomock.Any(), 2, gomock.Any()).DoAndReturn(relayBatch(domain.SongCreated, domain.SongUpdated)),
+		mockRepo.EXPECT().Relay(gomock.Any(), 2, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, limit int, publish func(*domain.OutboxEvent) error) (int, error) {
+				defer close(drained)
+				return relayBatch(domain.SongDeleted)(ctx, limit, publish)
+			}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx, time.Hour)
+	}()
+
+	<-drained
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop")
+	}
+
+	if assert.Len(t, publisher.events, 3) {
+		assert.Equal(t, domain.SongCreated, publisher.events[0].Type)
+		assert.Equal(t, domain.SongDeleted, publisher.events[2].Type)
+	}
+	assert.Equal(t, map[string]int64{"published": 3, "failures": 0}, relay.Stats())
+}
+
+func TestOutboxRelay_Run_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	relay := service.NewOutboxRelay(mockRepo, &recordingPublisher{}, 10, slog.New(slogdiscard.NewDiscardHandler()))
+
+	failed := make(chan struct{})
+	mockRepo.EXPECT().Relay(gomock.Any(), 10, gomock.Any()).DoAndReturn(
+		func(context.Context, int, func(*domain.OutboxEvent) error) (int, error) {
+			defer close(failed)
+			return 0, errors.New("connection refused")
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx, time.Hour)
+	}()
+
+	// Ошибка учитывается в метриках, события остаются до следующей попытки
+	<-failed
+	cancel()
+	<-done
+
+	assert.Equal(t, map[string]int64{"published": 0, "failures": 1}, relay.Stats())
+}
+
+type failingBroker struct {
+	err       error
+	published []domain.SongEvent
+}
+
+func (b *failingBroker) Publish(_ context.Context, event domain.SongEvent) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, event)
+	return nil
+}
+
+func TestOutboxRelay_Run_Broker(t *testing.T) {
+	tests := []struct {
+		name          string
+		brokerErr     error
+		wantPublished int
+		wantStats     map[string]int64
+	}{
+		{
+			name:          "published to broker and service",
+			wantPublished: 1,
+			wantStats:     map[string]int64{"published": 1, "failures": 0},
+		},
+		{
+			// Событие, не принятое брокером, остаётся в outbox
+			name:      "broker failure keeps event",
+			brokerErr: errors.New("broker unavailable"),
+			wantStats: map[string]int64{"published": 0, "failures": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockOutboxRepository(ctrl)
+			publisher := &recordingPublisher{}
+			broker := &failingBroker{err: tt.brokerErr}
+			relay := service.NewOutboxRelay(mockRepo, publisher, 10, slog.New(slogdiscard.NewDiscardHandler()))
+			relay.Broker = broker
+
+			relayed := make(chan struct{})
+			mockRepo.EXPECT().Relay(gomock.Any(), 10, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, limit int, publish func(*domain.OutboxEvent) error) (int, error) {
+					defer close(relayed)
+					return relayBatch(domain.SongCreated)(ctx, limit, publish)
+				})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				relay.Run(ctx, time.Hour)
+			}()
+
+			<-relayed
+			cancel()
+			<-done
+
+			assert.Len(t, broker.published, tt.wantPublished)
+			assert.Len(t, publisher.events, tt.wantPublished)
+			assert.Equal(t, tt.wantStats, relay.Stats())
+		})
+	}
+}
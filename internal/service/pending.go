@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pendingQueueSize limits the songs waiting for a retry, songs scheduled
+// beyond it are left to the enrichment job, which refreshes songs without
+// text
+const pendingQueueSize = 1000
+
+// musicInfoUnavailable reports whether MusicInfo failed to answer rather
+// than answered about the song: it timed out, returned a 5xx or 429 status
+// or couldn't be reached. A canceled request is not counted.
+func musicInfoUnavailable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, domain.ErrMusicInfoBadRequest) || errors.Is(err, domain.ErrMusicInfoMalformed) {
+		return false
+	}
+	if errors.Is(err, domain.ErrMusicInfoTimeout) {
+		return true
+	}
+
+	var httpErr *domain.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// PendingSongs refreshes pending songs and marks the ones given up on as
+// failed, it is satisfied by Service
+type PendingSongs interface {
+	SongRefresher
+	SetStatus(ctx context.Context, song *domain.SongInfo, status domain.SongStatus) (*domain.Song, error)
+}
+
+type pendingSong struct {
+	id      uuid.UUID
+	library uuid.UUID
+}
+
+// PendingEnrichment retries fetching the details of songs added while
+// MusicInfo was unavailable. Every song is refreshed after Backoff, doubled
+// after each failure, until it succeeds or MaxAttempts were made, then it is
+// marked failed.
+type PendingEnrichment struct {
+	Songs PendingSongs
+
+	Backoff     time.Duration
+	MaxAttempts int
+
+	queue     chan pendingSong
+	enriched  atomic.Int64
+	abandoned atomic.Int64
+
+	log *slog.Logger
+}
+
+func NewPendingEnrichment(songs PendingSongs, backoff time.Duration, maxAttempts int, log *slog.Logger) *PendingEnrichment {
+	return &PendingEnrichment{
+		Songs:       songs,
+		Backoff:     backoff,
+		MaxAttempts: maxAttempts,
+		queue:       make(chan pendingSong, pendingQueueSize),
+		log:         log,
+	}
+}
+
+// Schedule queues the song of the library ctx is scoped to for a retry
+func (p *PendingEnrichment) Schedule(ctx context.Context, id uuid.UUID) {
+	select {
+	case p.queue <- pendingSong{id: id, library: domain.LibraryIDFromContext(ctx)}:
+	default:
+		p.abandoned.Add(1)
+		p.log.Warn("pending enrichment queue is full, song is left to the enrichment job",
+			slog.String("song_id", id.String()), sl.RequestID(ctx))
+	}
+}
+
+// Run retries the scheduled songs until ctx is done
+func (p *PendingEnrichment) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case song := <-p.queue:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.retry(ctx, song)
+			}()
+		}
+	}
+}
+
+// retry refreshes the song until MusicInfo supplies its details
+func (p *PendingEnrichment) retry(ctx context.Context, song pendingSong) {
+	const op = "PendingEnrichment.retry"
+
+	log := p.log.With(slog.String("op", op), slog.String("song_id", song.id.String()))
+	ctx = domain.WithLibraryID(ctx, song.library)
+
+	backoff := p.Backoff
+	for attempt := 1; attempt <= p.MaxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		_, _, err := p.Songs.Refresh(ctx, &domain.SongInfo{ID: song.id}, false)
+		if err == nil {
+			p.enriched.Add(1)
+			log.Info("pending song enriched", slog.Int("attempt", attempt))
+			return
+		}
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Info("pending song was deleted")
+			return
+		}
+		log.Warn("failed to enrich pending song", slog.Int("attempt", attempt), sl.Err(err))
+		backoff *= 2
+	}
+
+	p.abandoned.Add(1)
+	log.Error("giving up enriching pending song, it is left to the enrichment job", slog.Int("attempts", p.MaxAttempts))
+	if _, err := p.Songs.SetStatus(ctx, &domain.SongInfo{ID: song.id}, domain.SongStatusFailed); err != nil {
+		log.Error("failed to mark pending song failed", sl.Err(err))
+	}
+}
+
+// Stats returns the number of pending songs enriched and given up on since
+// the start
+func (p *PendingEnrichment) Stats() map[string]int64 {
+	return map[string]int64{
+		"enriched":  p.enriched.Load(),
+		"abandoned": p.abandoned.Load(),
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PlayRepository interface {
+	Record(ctx context.Context, songID uuid.UUID) error
+	Flush(ctx context.Context, bucket time.Time) (int, error)
+	ReadTrending(ctx context.Context, since time.Time, limit int) ([]*domain.TrendingSong, error)
+}
+
+// SongReader looks up songs, it is satisfied by the song Repository
+type SongReader interface {
+	Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+}
+
+// flushTimeout bounds the final flush on shutdown
+const flushTimeout = 5 * time.Second
+
+type PlayService struct {
+	Repo  PlayRepository
+	Songs SongReader
+	log   *slog.Logger
+
+	trendingWindow time.Duration
+	trendingLimit  int
+}
+
+// NewPlayService creates a PlayService, trendingWindow and trendingLimit are
+// used by Trending when the caller doesn't specify them.
+func NewPlayService(r PlayRepository, songs SongReader, trendingWindow time.Duration, trendingLimit int, log *slog.Logger) *PlayService {
+	return &PlayService{
+		Repo:           r,
+		Songs:          songs,
+		log:            log,
+		trendingWindow: trendingWindow,
+		trendingLimit:  trendingLimit,
+	}
+}
+
+// Record counts a play of the song. Plays are buffered and written to the
+// database by the flusher.
+func (s *PlayService) Record(ctx context.Context, songID uuid.UUID) error {
+	const op = "PlayService.Record"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to read song", sl.Err(err))
+		return fmt.Errorf("%s: failed to read song: %w", op, err)
+	}
+
+	if err := s.Repo.Record(ctx, songID); err != nil {
+		log.Error("failed to record play", sl.Err(err))
+		return fmt.Errorf("%s: failed to record play: %w", op, err)
+	}
+
+	log.Debug("play recorded")
+	return nil
+}
+
+// Trending returns the most played songs within the window. Zero values fall
+// back to the configured defaults.
+func (s *PlayService) Trending(ctx context.Context, window time.Duration, limit int) ([]*domain.TrendingSong, error) {
+	const op = "PlayService.Trending"
+
+	if window <= 0 {
+		window = s.trendingWindow
+	}
+	if limit <= 0 {
+		limit = s.trendingLimit
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Duration("window", window),
+		slog.Int("limit", limit),
+	)
+
+	log.Info("attempting to fetch trending songs")
+
+	trending, err := s.Repo.ReadTrending(ctx, time.Now().Add(-window), limit)
+	if err != nil {
+		log.Error("failed to fetch trending songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch trending songs: %w", op, err)
+	}
+
+	log.Info("trending songs successfully fetched", slog.Int("count", len(trending)))
+	return trending, nil
+}
+
+// RunFlusher writes the buffered plays to the database every interval until
+// ctx is cancelled, then flushes one last time.
+func (s *PlayService) RunFlusher(ctx context.Context, interval time.Duration) {
+	const op = "PlayService.RunFlusher"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Duration("interval", interval),
+	)
+
+	log.Info("play flusher started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(ctx, log)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			s.flush(flushCtx, log)
+			cancel()
+
+			log.Info("play flusher stopped")
+			return
+		}
+	}
+}
+
+func (s *PlayService) flush(ctx context.Context, log *slog.Logger) {
+	// Plays are stored in hourly buckets, the trending window is as precise as that
+	flushed, err := s.Repo.Flush(ctx, time.Now().Truncate(time.Hour))
+	if err != nil {
+		log.Error("failed to flush plays", sl.Err(err))
+		return
+	}
+
+	if flushed > 0 {
+		log.Debug("plays flushed", slog.Int("plays", flushed))
+	}
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayService_Record_SongNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPlayRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	playService := service.NewPlayService(mockRepo, mockSongs, time.Hour, 10, mockLog)
+
+	// Прослушивание несуществующей песни не попадает в буфер
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	err := playService.Record(context.Background(), songID)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestPlayService_Trending_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPlayRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	playService := service.NewPlayService(mockRepo, nil, 24*time.Hour, 5, mockLog)
+
+	before := time.Now().Add(-24 * time.Hour)
+	mockRepo.EXPECT().ReadTrending(gomock.Any(), gomock.Any(), 5).DoAndReturn(
+		func(_ context.Context, since time.Time, _ int) ([]*domain.TrendingSong, error) {
+			assert.False(t, since.Before(before))
+			assert.True(t, since.Before(time.Now().Add(-23*time.Hour)))
+			return nil, nil
+		},
+	)
+
+	_, err := playService.Trending(context.Background(), 0, 0)
+	assert.NoError(t, err)
+}
+
+func TestPlayService_RunFlusher_FlushesOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPlayRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	playService := service.NewPlayService(mockRepo, nil, time.Hour, 10, mockLog)
+
+	// При остановке выполняется последний перенос прослушиваний
+	mockRepo.EXPECT().Flush(gomock.Any(), gomock.Any()).Return(3, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		playService.RunFlusher(ctx, time.Hour)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("flusher did not stop")
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *domain.SmartPlaylist) error
+	Read(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error)
+	ReadAll(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error)
+	Update(ctx context.Context, playlist *domain.SmartPlaylist) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+// PlaylistService manages the smart playlists of users. The songs of a
+// playlist are listed by evaluating its filter against the library.
+type PlaylistService struct {
+	Repo     PlaylistRepository
+	SongRepo SongLister
+	log      *slog.Logger
+}
+
+func NewPlaylistService(r PlaylistRepository, songs SongLister, log *slog.Logger) *PlaylistService {
+	return &PlaylistService{
+		Repo:     r,
+		SongRepo: songs,
+		log:      log,
+	}
+}
+
+// Add saves a new smart playlist of playlist.UserID.
+func (s *PlaylistService) Add(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "PlaylistService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", playlist.UserID.String()),
+		slog.String("name", playlist.Name),
+	)
+
+	log.Info("attempting to add a new smart playlist")
+
+	if err := validatePlaylist(playlist); err != nil {
+		log.Warn("invalid smart playlist", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.Repo.Create(ctx, playlist); err != nil {
+		log.Error("failed to save smart playlist", sl.Err(err))
+		return fmt.Errorf("%s: failed to save smart playlist: %w", op, err)
+	}
+
+	log.Info("smart playlist successfully added", slog.String("playlist_id", playlist.ID.String()))
+	return nil
+}
+
+// Get fetches a smart playlist of the user by ID.
+func (s *PlaylistService) Get(ctx context.Context, userID, id uuid.UUID) (*domain.SmartPlaylist, error) {
+	const op = "PlaylistService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", id.String()),
+	)
+
+	log.Info("attempting to fetch smart playlist")
+
+	playlist, err := s.Repo.Read(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPlaylistNotFound) {
+			log.Warn("smart playlist not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: smart playlist not found: %w", op, domain.ErrPlaylistNotFound)
+		}
+		log.Error("failed to read smart playlist", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read smart playlist: %w", op, err)
+	}
+
+	log.Info("smart playlist successfully fetched")
+	return playlist, nil
+}
+
+// GetAll retrieves the smart playlists of the user.
+func (s *PlaylistService) GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.SmartPlaylist, error) {
+	const op = "PlaylistService.GetAll"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()))
+
+	log.Info("attempting to fetch smart playlists")
+
+	playlists, err := s.Repo.ReadAll(ctx, userID)
+	if err != nil {
+		log.Error("failed to fetch smart playlists", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch smart playlists: %w", op, err)
+	}
+
+	log.Info("smart playlists successfully fetched", slog.Int("count", len(playlists)))
+	return playlists, nil
+}
+
+// Update replaces the name and filter of a smart playlist of playlist.UserID.
+func (s *PlaylistService) Update(ctx context.Context, playlist *domain.SmartPlaylist) error {
+	const op = "PlaylistService.Update"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", playlist.UserID.String()),
+		slog.String("playlist_id", playlist.ID.String()),
+	)
+
+	log.Info("attempting to update smart playlist")
+
+	if err := validatePlaylist(playlist); err != nil {
+		log.Warn("invalid smart playlist", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.Repo.Update(ctx, playlist); err != nil {
+		if errors.Is(err, domain.ErrPlaylistNotFound) {
+			log.Warn("smart playlist not found during update", sl.Err(err))
+			return fmt.Errorf("%s: smart playlist not found: %w", op, domain.ErrPlaylistNotFound)
+		}
+		log.Error("failed to update smart playlist", sl.Err(err))
+		return fmt.Errorf("%s: failed to update smart playlist: %w", op, err)
+	}
+
+	log.Info("smart playlist successfully updated")
+	return nil
+}
+
+// Delete removes a smart playlist of the user.
+func (s *PlaylistService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "PlaylistService.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", id.String()),
+	)
+
+	log.Info("attempting to delete smart playlist")
+
+	if err := s.Repo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, domain.ErrPlaylistNotFound) {
+			log.Warn("smart playlist not found during deletion", sl.Err(err))
+			return fmt.Errorf("%s: smart playlist not found: %w", op, domain.ErrPlaylistNotFound)
+		}
+		log.Error("failed to delete smart playlist", sl.Err(err))
+		return fmt.Errorf("%s: failed to delete smart playlist: %w", op, err)
+	}
+
+	log.Info("smart playlist successfully deleted")
+	return nil
+}
+
+// Songs evaluates the filter of a smart playlist of the user and returns a
+// page of the matching songs, newest first.
+func (s *PlaylistService) Songs(ctx context.Context, userID, id uuid.UUID, page, pageSize int) ([]*domain.Song, error) {
+	const op = "PlaylistService.Songs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", id.String()),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	playlist, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch smart playlist songs", slog.Int("offset", offset))
+
+	songs, err := s.SongRepo.ReadAllWithFilter(ctx, playlist.Filter.SongFilter(), pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch smart playlist songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch smart playlist songs: %w", op, err)
+	}
+
+	log.Info("smart playlist songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
+
+// validatePlaylist trims the name and normalizes the tags of the filter. A
+// playlist must have a name and a filter that selects songs.
+func validatePlaylist(playlist *domain.SmartPlaylist) error {
+	playlist.Name = strings.TrimSpace(playlist.Name)
+	if playlist.Name == "" {
+		return domain.ErrPlaylistNameIsNull
+	}
+
+	filter := &playlist.Filter
+	filter.Group = strings.TrimSpace(filter.Group)
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	tags, err := domain.NormalizeTags(filter.Tags)
+	if err != nil {
+		return err
+	}
+	filter.Tags = tags
+	if filter.TagMode == "" {
+		filter.TagMode = domain.TagModeAll
+	}
+
+	if !filter.ReleasedFrom.IsZero() && !filter.ReleasedTo.IsZero() && filter.ReleasedFrom.After(filter.ReleasedTo) {
+		return domain.ErrPlaylistDateRangeInvalid
+	}
+	if filter.SongFilter().IsEmpty() {
+		return domain.ErrPlaylistFilterEmpty
+	}
+
+	return nil
+}
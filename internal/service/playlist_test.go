@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlaylistService(t *testing.T) (*service.PlaylistService, *mocks.MockPlaylistRepository, *mocks.MockSongLister) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockPlaylistRepository(ctrl)
+	mockSongs := mocks.NewMockSongLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewPlaylistService(mockRepo, mockSongs, mockLog), mockRepo, mockSongs
+}
+
+func TestPlaylistService_Add(t *testing.T) {
+	playlistService, mockRepo, _ := newPlaylistService(t)
+
+	playlist := &domain.SmartPlaylist{
+		UserID: uuid.New(),
+		Name:   "  Muse  ",
+		Filter: domain.PlaylistFilter{Tags: []string{" Rock", "rock"}},
+	}
+	mockRepo.EXPECT().Create(gomock.Any(), playlist).Return(nil)
+
+	require.NoError(t, playlistService.Add(context.Background(), playlist))
+
+	// Имя обрезается, теги нормализуются, режим тегов по умолчанию "all"
+	assert.Equal(t, "Muse", playlist.Name)
+	assert.Equal(t, []string{"rock"}, playlist.Filter.Tags)
+	assert.Equal(t, domain.TagModeAll, playlist.Filter.TagMode)
+}
+
+func TestPlaylistService_Add_Invalid(t *testing.T) {
+	playlistService, _, _ := newPlaylistService(t)
+
+	tests := []struct {
+		name     string
+		playlist *domain.SmartPlaylist
+		err      error
+	}{
+		{
+			name:     "без имени",
+			playlist: &domain.SmartPlaylist{Name: " ", Filter: domain.PlaylistFilter{Group: "Muse"}},
+			err:      domain.ErrPlaylistNameIsNull,
+		},
+		{
+			name:     "пустой фильтр",
+			playlist: &domain.SmartPlaylist{Name: "All", Filter: domain.PlaylistFilter{Query: " "}},
+			err:      domain.ErrPlaylistFilterEmpty,
+		},
+		{
+			name: "начало диапазона после конца",
+			playlist: &domain.SmartPlaylist{Name: "Muse", Filter: domain.PlaylistFilter{
+				ReleasedFrom: time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+				ReleasedTo:   time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC),
+			}},
+			err: domain.ErrPlaylistDateRangeInvalid,
+		},
+		{
+			name:     "неверный тег",
+			playlist: &domain.SmartPlaylist{Name: "Muse", Filter: domain.PlaylistFilter{Tags: []string{"a,b"}}},
+			err:      domain.ErrInvalidTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, playlistService.Add(context.Background(), tt.playlist), tt.err)
+		})
+	}
+}
+
+func TestPlaylistService_Songs(t *testing.T) {
+	playlistService, mockRepo, mockSongs := newPlaylistService(t)
+
+	userID, id := uuid.New(), uuid.New()
+	from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	playlist := &domain.SmartPlaylist{
+		ID:     id,
+		UserID: userID,
+		Name:   "Muse",
+		Filter: domain.PlaylistFilter{Group: "Muse", Tags: []string{"rock"}, TagMode: domain.TagModeAny, ReleasedFrom: from, Query: "bugging"},
+	}
+	mockRepo.EXPECT().Read(gomock.Any(), userID, id).Return(playlist, nil)
+
+	// Фильтр вычисляется заново, вторая страница по 10 песен начинается со смещения 10
+	filter := &domain.SongFilter{Group: "Muse", Tags: []string{"rock"}, TagMode: domain.TagModeAny, ReleasedFrom: from, Query: "bugging", ExcludeArchived: true}
+	mockSongs.EXPECT().ReadAllWithFilter(gomock.Any(), filter, 10, 10).
+		Return([]*domain.Song{{Name: "Hysteria", Group: "Muse"}}, nil)
+
+	songs, err := playlistService.Songs(context.Background(), userID, id, 2, 10)
+	require.NoError(t, err)
+	assert.Len(t, songs, 1)
+}
+
+func TestPlaylistService_Songs_NotFound(t *testing.T) {
+	playlistService, mockRepo, _ := newPlaylistService(t)
+
+	userID, id := uuid.New(), uuid.New()
+	mockRepo.EXPECT().Read(gomock.Any(), userID, id).Return(nil, domain.ErrPlaylistNotFound)
+
+	_, err := playlistService.Songs(context.Background(), userID, id, 0, 0)
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+}
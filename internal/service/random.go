@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RandomRepository interface {
+	ReadRandom(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error)
+	ReadOfTheDay(ctx context.Context, day string, ttl time.Duration) (uuid.UUID, error)
+	ForgetOfTheDay(ctx context.Context, day string) error
+}
+
+type RandomService struct {
+	Repo  RandomRepository
+	Songs SongReader
+	// Now returns the current time, the song of the day changes at midnight
+	// in its location
+	Now func() time.Time
+	log *slog.Logger
+}
+
+func NewRandomService(r RandomRepository, songs SongReader, log *slog.Logger) *RandomService {
+	return &RandomService{
+		Repo:  r,
+		Songs: songs,
+		Now:   time.Now,
+		log:   log,
+	}
+}
+
+// Random returns a random song matching the non-empty fields of filter
+func (s *RandomService) Random(ctx context.Context, filter *domain.SongFilter) (*domain.Song, error) {
+	const op = "RandomService.Random"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("group_name", filter.Group),
+		slog.Any("tags", filter.Tags),
+	)
+
+	song, err := s.Repo.ReadRandom(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Info("no song matches the filter")
+			return nil, fmt.Errorf("%s: no song matches the filter: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to fetch random song", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch random song: %w", op, err)
+	}
+
+	log.Debug("random song picked", slog.String("song_id", song.ID.String()))
+	return song, nil
+}
+
+// OfTheDay returns the song of the day. It is picked with the date as seed
+// and cached until midnight, so every caller gets the same song all day. If
+// the song was deleted, another one is picked.
+func (s *RandomService) OfTheDay(ctx context.Context) (*domain.Song, error) {
+	const op = "RandomService.OfTheDay"
+
+	now := s.Now()
+	day := now.Format(time.DateOnly)
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("day", day),
+	)
+
+	for attempt := 0; ; attempt++ {
+		songID, err := s.Repo.ReadOfTheDay(ctx, day, midnight.Sub(now))
+		if err != nil {
+			if errors.Is(err, domain.ErrSongNotFound) {
+				log.Info("library is empty, no song of the day")
+				return nil, fmt.Errorf("%s: library is empty: %w", op, domain.ErrSongNotFound)
+			}
+			log.Error("failed to pick song of the day", sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to pick song of the day: %w", op, err)
+		}
+
+		song, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID})
+		if err == nil {
+			log.Debug("song of the day fetched", slog.String("song_id", songID.String()))
+			return song, nil
+		}
+		if !errors.Is(err, domain.ErrSongNotFound) || attempt > 0 {
+			log.Error("failed to read song of the day", sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to read song of the day: %w", op, err)
+		}
+
+		log.Warn("song of the day was deleted, picking another one", slog.String("song_id", songID.String()))
+		if err := s.Repo.ForgetOfTheDay(ctx, day); err != nil {
+			return nil, fmt.Errorf("%s: failed to forget deleted song of the day: %w", op, err)
+		}
+	}
+}
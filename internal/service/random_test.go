@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomService_Random_NoMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRandomRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	randomService := service.NewRandomService(mockRepo, mockSongs, mockLog)
+
+	filter := &domain.SongFilter{Group: "Queen"}
+	mockRepo.EXPECT().ReadRandom(gomock.Any(), filter).Return(nil, domain.ErrSongNotFound)
+
+	_, err := randomService.Random(context.Background(), filter)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestRandomService_OfTheDay_CachedUntilMidnight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRandomRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	randomService := service.NewRandomService(mockRepo, mockSongs, mockLog)
+	randomService.Now = func() time.Time {
+		return time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC)
+	}
+
+	// Песня дня выбирается по дате и хранится в кэше до полуночи
+	song := &domain.Song{ID: uuid.New(), Name: "Hysteria", Group: "Muse"}
+	mockRepo.EXPECT().ReadOfTheDay(gomock.Any(), "2024-05-01", 150*time.Minute).Return(song.ID, nil)
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil)
+
+	result, err := randomService.OfTheDay(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, song, result)
+}
+
+func TestRandomService_OfTheDay_DeletedSong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRandomRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	randomService := service.NewRandomService(mockRepo, mockSongs, mockLog)
+
+	// Удалённая песня дня забывается, и выбирается другая
+	deletedID := uuid.New()
+	song := &domain.Song{ID: uuid.New(), Name: "Creep", Group: "Radiohead"}
+	gomock.InOrder(
+		mockRepo.EXPECT().ReadOfTheDay(gomock.Any(), gomock.Any(), gomock.Any()).Return(deletedID, nil),
+		mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: deletedID}).Return(nil, domain.ErrSongNotFound),
+		mockRepo.EXPECT().ForgetOfTheDay(gomock.Any(), gomock.Any()).Return(nil),
+		mockRepo.EXPECT().ReadOfTheDay(gomock.Any(), gomock.Any(), gomock.Any()).Return(song.ID, nil),
+		mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: song.ID}).Return(song, nil),
+	)
+
+	result, err := randomService.OfTheDay(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, song, result)
+}
+
+func TestRandomService_OfTheDay_EmptyLibrary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRandomRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	randomService := service.NewRandomService(mockRepo, mockSongs, mockLog)
+
+	mockRepo.EXPECT().ReadOfTheDay(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.Nil, domain.ErrSongNotFound)
+
+	_, err := randomService.OfTheDay(context.Background())
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
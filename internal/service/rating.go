@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type RatingRepository interface {
+	Set(ctx context.Context, rating *domain.Rating) error
+	Remove(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+	Read(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error)
+}
+
+type RatingService struct {
+	Repo RatingRepository
+	log  *slog.Logger
+}
+
+func NewRatingService(r RatingRepository, log *slog.Logger) *RatingService {
+	return &RatingService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Rate saves the rating from MinRating to MaxRating the user gives a song,
+// replacing the previous one, and returns it with the new rating summary of
+// the song.
+func (s *RatingService) Rate(ctx context.Context, userID, songID uuid.UUID, value int) (*domain.Rating, error) {
+	const op = "RatingService.Rate"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+		slog.Int("rating", value),
+	)
+
+	if value < domain.MinRating || value > domain.MaxRating {
+		log.Warn("invalid rating")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrInvalidRating)
+	}
+
+	log.Info("attempting to rate song")
+
+	rating := &domain.Rating{UserID: userID, SongID: songID, Value: value}
+	if err := s.Repo.Set(ctx, rating); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to rate song", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to rate song: %w", op, err)
+	}
+
+	log.Info("song successfully rated")
+	return rating, nil
+}
+
+// Get returns the rating the user gave a song with the rating summary of the
+// song, its value is zero if the user didn't rate the song.
+func (s *RatingService) Get(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "RatingService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+	)
+
+	log.Info("attempting to fetch rating")
+
+	rating, err := s.Repo.Read(ctx, userID, songID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to fetch rating", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch rating: %w", op, err)
+	}
+
+	log.Info("rating successfully fetched")
+	return rating, nil
+}
+
+// Remove drops the rating of the user for a song and returns the new rating
+// summary of the song.
+func (s *RatingService) Remove(ctx context.Context, userID, songID uuid.UUID) (*domain.Rating, error) {
+	const op = "RatingService.Remove"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+	)
+
+	log.Info("attempting to remove rating")
+
+	rating, err := s.Repo.Remove(ctx, userID, songID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to remove rating", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to remove rating: %w", op, err)
+	}
+
+	log.Info("rating successfully removed")
+	return rating, nil
+}
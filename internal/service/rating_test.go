@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingService_Rate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRatingRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	ratingService := service.NewRatingService(mockRepo, mockLog)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().Set(gomock.Any(), &domain.Rating{UserID: userID, SongID: songID, Value: 4}).
+		DoAndReturn(func(_ context.Context, rating *domain.Rating) error {
+			rating.Average, rating.Count = 4.5, 2
+			return nil
+		})
+
+	rating, err := ratingService.Rate(context.Background(), userID, songID, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, rating.Value)
+	assert.Equal(t, 4.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+}
+
+func TestRatingService_Rate_OutOfRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRatingRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	ratingService := service.NewRatingService(mockRepo, mockLog)
+
+	// Оценки вне диапазона 1–5 не доходят до репозитория
+	for _, value := range []int{0, 6, -1} {
+		_, err := ratingService.Rate(context.Background(), uuid.New(), uuid.New(), value)
+		assert.ErrorIs(t, err, domain.ErrInvalidRating, value)
+	}
+}
+
+func TestRatingService_Remove_SongNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRatingRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	ratingService := service.NewRatingService(mockRepo, mockLog)
+
+	userID, songID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().Remove(gomock.Any(), userID, songID).Return(nil, domain.ErrSongNotFound)
+
+	_, err := ratingService.Remove(context.Background(), userID, songID)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+// Limits of Recent, a feed only needs the latest songs
+const (
+	defaultRecentLimit = 20
+	maxRecentLimit     = 100
+)
+
+// SongLister lists the songs of the library matching a filter
+type SongLister interface {
+	ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error)
+}
+
+type RecentService struct {
+	Repo SongLister
+	log  *slog.Logger
+}
+
+func NewRecentService(r SongLister, log *slog.Logger) *RecentService {
+	return &RecentService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Recent returns up to limit songs most recently added, or most recently
+// updated when sort is SortByUpdatedAt. A zero limit selects the default
+// and larger limits are cut to the maximum, excludeExplicit drops the songs
+// flagged explicit.
+func (s *RecentService) Recent(ctx context.Context, sort domain.SongSort, excludeExplicit bool, limit int) ([]*domain.Song, error) {
+	const op = "RecentService.Recent"
+
+	if sort != domain.SortByUpdatedAt {
+		sort = domain.SortByCreatedAt
+	}
+	if limit <= 0 {
+		limit = defaultRecentLimit
+	}
+	limit = min(limit, maxRecentLimit)
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("sort", string(sort)),
+		slog.Bool("exclude_explicit", excludeExplicit),
+		slog.Int("limit", limit),
+	)
+
+	songs, err := s.Repo.ReadAllWithFilter(ctx, &domain.SongFilter{ExcludeExplicit: excludeExplicit, ExcludeArchived: true, Sort: sort}, limit, 0)
+	if err != nil {
+		log.Error("failed to fetch recent songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch recent songs: %w", op, err)
+	}
+
+	log.Debug("recent songs successfully fetched", slog.Int("count", len(songs)))
+	return songs, nil
+}
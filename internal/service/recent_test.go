@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentService_Recent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSongLister(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	recentService := service.NewRecentService(mockRepo, mockLog)
+
+	songs := []*domain.Song{{Name: "Hysteria", Group: "Muse"}}
+
+	// Без лимита берётся значение по умолчанию, порядок по умолчанию — по добавлению, архивные песни скрыты
+	mockRepo.EXPECT().ReadAllWithFilter(gomock.Any(), &domain.SongFilter{ExcludeArchived: true, Sort: domain.SortByCreatedAt}, 20, 0).Return(songs, nil)
+	result, err := recentService.Recent(context.Background(), "", false, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, songs, result)
+
+	// Большой лимит обрезается до максимума
+	mockRepo.EXPECT().ReadAllWithFilter(gomock.Any(), &domain.SongFilter{ExcludeExplicit: true, ExcludeArchived: true, Sort: domain.SortByUpdatedAt}, 100, 0).Return(nil, nil)
+	_, err = recentService.Recent(context.Background(), domain.SortByUpdatedAt, true, 1000)
+	assert.NoError(t, err)
+
+	mockRepo.EXPECT().ReadAllWithFilter(gomock.Any(), gomock.Any(), 5, 0).Return(nil, errors.New("connection refused"))
+	_, err = recentService.Recent(context.Background(), domain.SortByCreatedAt, false, 5)
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"songLibrary/pkg/textdiff"
+	"strings"
+)
+
+// Refresh fetches the details of an existing song from MusicInfo again and
+// takes the text, link, release date and metadata that changed there. Fields edited
+// locally are kept unless force is set, which also unlocks them. Returns the
+// song and the fields that changed; nothing is saved if none did.
+func (s *Service) Refresh(ctx context.Context, songInfo *domain.SongInfo, force bool) (*domain.Song, domain.SongFields, error) {
+	const op = "Service.Refresh"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songInfo.ID.String()),
+		slog.Bool("force", force),
+	)
+
+	log.Info("attempting to refresh song from MusicInfo")
+
+	current, err := s.Get(ctx, songInfo)
+	if err != nil {
+		log.Error("failed to fetch song", sl.Err(err))
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fetched, err := s.fetchMusicInfo(ctx, log, &domain.SongInfo{Name: current.Name, Group: current.Group})
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applyMusicInfo(ctx, log, op, songInfo, current, fetched, force)
+}
+
+// Enrich applies song details delivered by an enrichment worker instead of
+// fetched from MusicInfo, like Refresh without force. The details must name
+// the song they are for, details of a song renamed since are rejected with
+// domain.ErrEnrichmentMismatch.
+func (s *Service) Enrich(ctx context.Context, songInfo *domain.SongInfo, details *domain.Song) (*domain.Song, domain.SongFields, error) {
+	const op = "Service.Enrich"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songInfo.ID.String()),
+		slog.String("source", details.Source),
+	)
+
+	log.Info("attempting to enrich song")
+
+	current, err := s.Get(ctx, songInfo)
+	if err != nil {
+		log.Error("failed to fetch song", sl.Err(err))
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !strings.EqualFold(details.Name, current.Name) || !strings.EqualFold(details.Group, current.Group) {
+		log.Warn("enrichment result is for another song",
+			slog.String("song_name", current.Name), slog.String("group_name", current.Group),
+			slog.String("result_song_name", details.Name), slog.String("result_group_name", details.Group),
+		)
+		return nil, 0, fmt.Errorf("%s: %w", op, domain.ErrEnrichmentMismatch)
+	}
+
+	return s.applyMusicInfo(ctx, log, op, songInfo, current, details, false)
+}
+
+// applyMusicInfo merges the fetched details into the current song and saves
+// it if any field changed
+func (s *Service) applyMusicInfo(ctx context.Context, log *slog.Logger, op string, songInfo *domain.SongInfo, current, fetched *domain.Song, force bool) (*domain.Song, domain.SongFields, error) {
+	fetched.Text = NormalizeLyrics(fetched.Text)
+
+	refreshed := *current
+	changed := mergeMusicInfo(&refreshed, fetched, force)
+	// MusicInfo answered, so a song waiting for its details has them now
+	if current.Status.AwaitsEnrichment() {
+		refreshed.Status = domain.SongStatusEnriched
+	}
+	if changed == 0 && refreshed.LockedFields == current.LockedFields && refreshed.Status == current.Status {
+		log.Info("song is up to date")
+		return current, 0, nil
+	}
+
+	refreshed.Source = fetched.Source
+	refreshed.Lyrics = ParseLyrics(refreshed.Text)
+
+	if err := s.Repo.Update(ctx, songInfo, &refreshed); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found during refresh", sl.Err(err))
+			return nil, 0, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("song was updated concurrently", slog.Int("version", refreshed.Version), sl.Err(err))
+			return nil, 0, fmt.Errorf("%s: stale song version: %w", op, domain.ErrVersionConflict)
+		}
+		log.Error("failed to save refreshed song", sl.Err(err))
+		return nil, 0, fmt.Errorf("%s: failed to save refreshed song: %w", op, err)
+	}
+
+	attrs := []any{slog.Any("changed", changed.Names()), slog.String("source", refreshed.Source), slog.String("status", string(refreshed.Status))}
+	if changed.Has(domain.FieldText) {
+		inserted, deleted := countChangedLines(textdiff.Lines(current.Text, refreshed.Text))
+		attrs = append(attrs, slog.Int("lines_inserted", inserted), slog.Int("lines_deleted", deleted))
+	}
+	if changed.Has(domain.FieldLink) {
+		attrs = append(attrs, slog.String("old_link", current.Link), slog.String("new_link", refreshed.Link))
+	}
+	if changed.Has(domain.FieldReleaseDate) {
+		attrs = append(attrs, slog.Time("old_release_date", current.ReleaseDate), slog.Time("new_release_date", refreshed.ReleaseDate))
+	}
+	log.Info("song successfully refreshed", attrs...)
+
+	return &refreshed, changed, nil
+}
+
+// mergeMusicInfo copies the details MusicInfo supplied into song, skipping the
+// locked fields unless force is set, and returns the fields that changed.
+// Forced fields are unlocked.
+func mergeMusicInfo(song, fetched *domain.Song, force bool) domain.SongFields {
+	var changed domain.SongFields
+
+	take := func(field domain.SongFields, supplied, differs bool, apply func()) {
+		if !supplied || (song.LockedFields.Has(field) && !force) {
+			return
+		}
+		song.LockedFields &^= field
+		if differs {
+			apply()
+			changed |= field
+		}
+	}
+
+	take(domain.FieldText, fetched.Text != "", fetched.Text != song.Text, func() { song.Text = fetched.Text })
+	take(domain.FieldLink, fetched.Link != "", fetched.Link != song.Link, func() { song.Link = fetched.Link })
+	take(domain.FieldReleaseDate, !fetched.ReleaseDate.IsZero(), !fetched.ReleaseDate.Equal(song.ReleaseDate),
+		func() { song.ReleaseDate = fetched.ReleaseDate })
+	take(domain.FieldDuration, fetched.Duration != 0, fetched.Duration != song.Duration,
+		func() { song.Duration = fetched.Duration })
+	take(domain.FieldGenre, fetched.Genre != "", fetched.Genre != song.Genre, func() { song.Genre = fetched.Genre })
+	take(domain.FieldTrackNumber, fetched.TrackNumber != 0, fetched.TrackNumber != song.TrackNumber,
+		func() { song.TrackNumber = fetched.TrackNumber })
+	take(domain.FieldAlbum, fetched.Album != "", fetched.Album != song.Album, func() { song.Album = fetched.Album })
+	take(domain.FieldExplicit, fetched.Explicit != nil, !sameExplicit(fetched.Explicit, song.Explicit),
+		func() { song.Explicit = fetched.Explicit })
+
+	return changed
+}
+
+// editedFields returns the MusicInfo fields an update sets to a new value
+func editedFields(updatedSong, targetSong *domain.Song) domain.SongFields {
+	var edited domain.SongFields
+	if updatedSong.Text != "" && updatedSong.Text != targetSong.Text {
+		edited |= domain.FieldText
+	}
+	if updatedSong.Link != "" && updatedSong.Link != targetSong.Link {
+		edited |= domain.FieldLink
+	}
+	if !updatedSong.ReleaseDate.IsZero() && !updatedSong.ReleaseDate.Equal(targetSong.ReleaseDate) {
+		edited |= domain.FieldReleaseDate
+	}
+	if updatedSong.Duration != 0 && updatedSong.Duration != targetSong.Duration {
+		edited |= domain.FieldDuration
+	}
+	if updatedSong.Genre != "" && updatedSong.Genre != targetSong.Genre {
+		edited |= domain.FieldGenre
+	}
+	if updatedSong.TrackNumber != 0 && updatedSong.TrackNumber != targetSong.TrackNumber {
+		edited |= domain.FieldTrackNumber
+	}
+	if updatedSong.Album != "" && updatedSong.Album != targetSong.Album {
+		edited |= domain.FieldAlbum
+	}
+	if updatedSong.Explicit != nil && !sameExplicit(updatedSong.Explicit, targetSong.Explicit) {
+		edited |= domain.FieldExplicit
+	}
+	return edited
+}
+
+// sameExplicit reports whether two explicit flags are equal, unknown flags
+// only equal each other
+func sameExplicit(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func countChangedLines(diff []textdiff.Line) (inserted, deleted int) {
+	for _, line := range diff {
+		switch line.Op {
+		case textdiff.Insert:
+			inserted++
+		case textdiff.Delete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
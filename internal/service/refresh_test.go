@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRefreshService(t *testing.T) (*service.Service, *mocks.MockRepository, *mocks.MockMusicInfo) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewService(mockRepo, mockMusicInfo, mockLog), mockRepo, mockMusicInfo
+}
+
+func TestService_Refresh(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	releaseDate := time.Date(2003, 9, 15, 0, 0, 0, 0, time.UTC)
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "edited text", Link: "https://old", ReleaseDate: releaseDate, Version: 2, LockedFields: domain.FieldText}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), &domain.SongInfo{Name: "Hysteria", Group: "Muse"}).
+		Return(&domain.Song{Text: "[Chorus]\nfresh text", Link: "https://new", ReleaseDate: releaseDate, Source: "primary"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, refreshed *domain.Song) error {
+			// Отредактированный текст остаётся, ссылка берётся из MusicInfo
+			assert.Equal(t, "edited text", refreshed.Text)
+			assert.Equal(t, "https://new", refreshed.Link)
+			assert.Equal(t, "primary", refreshed.Source)
+			assert.Equal(t, domain.FieldText, refreshed.LockedFields)
+			assert.Equal(t, 2, refreshed.Version)
+			return nil
+		})
+
+	song, changed, err := svc.Refresh(context.Background(), songInfo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"link"}, changed.Names())
+	assert.Equal(t, "https://new", song.Link)
+}
+
+func TestService_Refresh_Force(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "edited text", Version: 2, LockedFields: domain.FieldText | domain.FieldLink}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), gomock.Any()).Return(&domain.Song{Text: "[Chorus]\nfresh text"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+
+	// С force поле перезаписывается и снимается с блокировки, ссылку MusicInfo не прислал
+	song, changed, err := svc.Refresh(context.Background(), songInfo, true)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FieldText, changed)
+	assert.Equal(t, domain.FieldLink, song.LockedFields)
+	assert.Equal(t, domain.SectionChorus, song.Lyrics.Sections[0].Type)
+}
+
+func TestService_Refresh_UpToDate(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "text", Link: "https://link", Version: 1}
+
+	// Без изменений песня не сохраняется
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), gomock.Any()).Return(&domain.Song{Text: "text", Link: "https://link"}, nil)
+
+	song, changed, err := svc.Refresh(context.Background(), songInfo, false)
+	assert.NoError(t, err)
+	assert.Zero(t, changed)
+	assert.Equal(t, current, song)
+}
+
+func TestService_Update_LocksEditedFields(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "text", Link: "https://link", Version: 1, LockedFields: domain.FieldReleaseDate, Source: "primary"}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, updated *domain.Song) error {
+			// Ссылка не изменилась и не блокируется, источник сохраняется
+			assert.Equal(t, domain.FieldText|domain.FieldReleaseDate, updated.LockedFields)
+			assert.Equal(t, "primary", updated.Source)
+			return nil
+		})
+
+	err := svc.Update(context.Background(), songInfo, &domain.Song{Text: "edited", Link: "https://link"})
+	assert.NoError(t, err)
+}
+
+func TestService_Refresh_Metadata(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	explicit := true
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "text", Genre: "Rock", TrackNumber: 3, Version: 1, LockedFields: domain.FieldGenre}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), gomock.Any()).Return(&domain.Song{
+		Text:     "text",
+		Duration: 227 * time.Second,
+		Genre:    "Alternative Rock",
+		Album:    "Absolution",
+		Explicit: &explicit,
+	}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+
+	// Жанр заблокирован, номер трека MusicInfo не прислал
+	song, changed, err := svc.Refresh(context.Background(), songInfo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"duration", "album", "explicit"}, changed.Names())
+	assert.Equal(t, 227*time.Second, song.Duration)
+	assert.Equal(t, "Rock", song.Genre)
+	assert.Equal(t, 3, song.TrackNumber)
+	assert.Equal(t, "Absolution", song.Album)
+	assert.Equal(t, &explicit, song.Explicit)
+}
+
+func TestService_Update_LocksEditedMetadata(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	explicit, notExplicit := true, false
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "text", Genre: "Rock", Album: "Absolution", Explicit: &explicit, Version: 1}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, updated *domain.Song) error {
+			// Незаданные поля сохраняются, изменённые блокируются
+			assert.Equal(t, domain.FieldGenre|domain.FieldExplicit, updated.LockedFields)
+			assert.Equal(t, "Alternative Rock", updated.Genre)
+			assert.Equal(t, "Absolution", updated.Album)
+			assert.Equal(t, &notExplicit, updated.Explicit)
+			return nil
+		})
+
+	err := svc.Update(context.Background(), songInfo, &domain.Song{Genre: "Alternative Rock", Album: "Absolution", Explicit: &notExplicit})
+	assert.NoError(t, err)
+}
+
+func TestService_Enrich(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "edited text", Version: 3, LockedFields: domain.FieldText}
+
+	// MusicInfo не запрашивается, данные пришли от обработчика из очереди
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, enriched *domain.Song) error {
+			assert.Equal(t, "edited text", enriched.Text)
+			assert.Equal(t, "Alternative rock", enriched.Genre)
+			assert.Equal(t, "worker", enriched.Source)
+			return nil
+		})
+
+	details := &domain.Song{Name: "hysteria", Group: "MUSE", Text: "fresh text", Genre: "Alternative rock", Source: "worker"}
+	_, changed, err := svc.Enrich(context.Background(), songInfo, details)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FieldGenre, changed)
+}
+
+func TestService_Enrich_Mismatch(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(&domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Version: 1}, nil)
+
+	// Результат для песни, переименованной после запроса, не применяется
+	_, _, err := svc.Enrich(context.Background(), songInfo, &domain.Song{Name: "Starlight", Group: "Muse", Text: "text"})
+	assert.ErrorIs(t, err, domain.ErrEnrichmentMismatch)
+}
+
+func TestService_Refresh_EnrichesPendingSong(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Version: 1, Status: domain.SongStatusFailed}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), gomock.Any()).Return(&domain.Song{Text: "It's bugging me"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+
+	// Песня, которую не удалось обогатить, получает детали и становится обогащённой
+	song, changed, err := svc.Refresh(context.Background(), songInfo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FieldText, changed)
+	assert.Equal(t, domain.SongStatusEnriched, song.Status)
+}
+
+func TestService_Refresh_KeepsArchivedStatus(t *testing.T) {
+	svc, mockRepo, mockMusicInfo := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Version: 1, Status: domain.SongStatusArchived}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), gomock.Any()).Return(&domain.Song{Text: "It's bugging me"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+
+	// Архивная песня остаётся в архиве
+	song, _, err := svc.Refresh(context.Background(), songInfo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SongStatusArchived, song.Status)
+}
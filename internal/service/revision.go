@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"songLibrary/pkg/textdiff"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevisionRepository interface {
+	ReadAll(ctx context.Context, songID uuid.UUID, limit, offset int) ([]*domain.SongRevision, error)
+	Read(ctx context.Context, songID uuid.UUID, revision int) (*domain.SongRevision, error)
+}
+
+// SongWriter reads and updates songs, it is satisfied by the song Repository
+type SongWriter interface {
+	SongReader
+	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
+}
+
+type RevisionService struct {
+	Repo  RevisionRepository
+	Songs SongWriter
+	log   *slog.Logger
+}
+
+func NewRevisionService(r RevisionRepository, songs SongWriter, log *slog.Logger) *RevisionService {
+	return &RevisionService{
+		Repo:  r,
+		Songs: songs,
+		log:   log,
+	}
+}
+
+// GetAll returns the previous states of a song, newest first.
+func (s *RevisionService) GetAll(ctx context.Context, songID uuid.UUID, page, pageSize int) ([]*domain.SongRevision, error) {
+	const op = "RevisionService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	// Песня без ревизий отличается от несуществующей
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch song revisions", slog.Int("offset", offset))
+
+	revisions, err := s.Repo.ReadAll(ctx, songID, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch song revisions", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song revisions: %w", op, err)
+	}
+
+	log.Info("song revisions successfully fetched", slog.Int("count", len(revisions)))
+	return revisions, nil
+}
+
+// Restore rolls the text, link and release date of a song back to a previous
+// revision. The restore is an update of its own, so the current state becomes
+// a revision too.
+func (s *RevisionService) Restore(ctx context.Context, songID uuid.UUID, revision int) (*domain.Song, error) {
+	const op = "RevisionService.Restore"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+		slog.Int("revision", revision),
+	)
+
+	log.Info("attempting to restore song revision")
+
+	songInfo := &domain.SongInfo{ID: songID}
+	current, err := s.Songs.Read(ctx, songInfo)
+	if err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	previous, err := s.Repo.Read(ctx, songID, revision)
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			log.Warn("song revision not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrRevisionNotFound)
+		}
+		log.Error("failed to fetch song revision", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song revision: %w", op, err)
+	}
+
+	restored := *current
+	restored.Text = NormalizeLyrics(previous.Song.Text)
+	restored.Lyrics = ParseLyrics(restored.Text)
+	restored.Link = previous.Song.Link
+	restored.ReleaseDate = previous.Song.ReleaseDate
+	restored.UpdatedAt = time.Now()
+
+	if err := s.Songs.Update(ctx, songInfo, &restored); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("song was updated concurrently", sl.Err(err))
+			return nil, fmt.Errorf("%s: stale song version: %w", op, domain.ErrVersionConflict)
+		}
+		log.Error("failed to restore song revision", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to restore song revision: %w", op, err)
+	}
+
+	log.Info("song revision successfully restored", slog.Int("version", restored.Version))
+	return &restored, nil
+}
+
+// Diff compares the text of a song between two revisions line by line. The
+// current version of the song can be compared as well, so an editor can see
+// what the last update changed.
+func (s *RevisionService) Diff(ctx context.Context, songID uuid.UUID, from, to int) ([]textdiff.Line, error) {
+	const op = "RevisionService.Diff"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+		slog.Int("from", from),
+		slog.Int("to", to),
+	)
+
+	current, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID})
+	if err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	texts := make([]string, 0, 2)
+	for _, revision := range []int{from, to} {
+		if revision == current.Version {
+			texts = append(texts, current.Text)
+			continue
+		}
+
+		previous, err := s.Repo.Read(ctx, songID, revision)
+		if err != nil {
+			if errors.Is(err, domain.ErrRevisionNotFound) {
+				log.Warn("song revision not found", slog.Int("revision", revision))
+				return nil, fmt.Errorf("%s: %w", op, domain.ErrRevisionNotFound)
+			}
+			log.Error("failed to fetch song revision", sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to fetch song revision: %w", op, err)
+		}
+		texts = append(texts, previous.Song.Text)
+	}
+
+	diff := textdiff.Lines(texts[0], texts[1])
+
+	log.Info("song revisions successfully compared", slog.Int("lines", len(diff)))
+	return diff, nil
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+	"songLibrary/pkg/textdiff"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRevisionService(t *testing.T) (*service.RevisionService, *mocks.MockRevisionRepository, *mocks.MockSongWriter) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockRevisionRepository(ctrl)
+	mockSongs := mocks.NewMockSongWriter(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewRevisionService(mockRepo, mockSongs, mockLog), mockRepo, mockSongs
+}
+
+func TestRevisionService_Restore(t *testing.T) {
+	revisionService, mockRepo, mockSongs := newRevisionService(t)
+
+	songID := uuid.New()
+	oldDate := time.Date(2003, 9, 15, 0, 0, 0, 0, time.UTC)
+	current := &domain.Song{ID: songID, Name: "Hysteria", Group: "Muse", Text: "new text", Link: "https://new", ReleaseDate: time.Now(), Version: 3}
+	previous := &domain.SongRevision{Revision: 1, Song: &domain.Song{ID: songID, Name: "Old name", Group: "Muse", Text: "old text", Link: "https://old", ReleaseDate: oldDate, Version: 1}}
+
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(current, nil)
+	mockRepo.EXPECT().Read(gomock.Any(), songID, 1).Return(previous, nil)
+	mockSongs.EXPECT().Update(gomock.Any(), &domain.SongInfo{ID: songID}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, restored *domain.Song) error {
+			// Восстанавливаются только текст, ссылка и дата выхода, обновление идёт от текущей версии
+			assert.Equal(t, "Hysteria", restored.Name)
+			assert.Equal(t, "old text", restored.Text)
+			assert.Equal(t, "https://old", restored.Link)
+			assert.Equal(t, oldDate, restored.ReleaseDate)
+			assert.Equal(t, 3, restored.Version)
+			restored.Version++
+			return nil
+		})
+
+	song, err := revisionService.Restore(context.Background(), songID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, song.Version)
+}
+
+func TestRevisionService_Restore_RevisionNotFound(t *testing.T) {
+	revisionService, mockRepo, mockSongs := newRevisionService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID, Version: 1}, nil)
+	mockRepo.EXPECT().Read(gomock.Any(), songID, 7).Return(nil, domain.ErrRevisionNotFound)
+
+	_, err := revisionService.Restore(context.Background(), songID, 7)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+}
+
+func TestRevisionService_GetAll_SongNotFound(t *testing.T) {
+	revisionService, _, mockSongs := newRevisionService(t)
+
+	// Для несуществующей песни возвращается ошибка, а не пустой список
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	_, err := revisionService.GetAll(context.Background(), songID, 1, 10)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestRevisionService_Diff(t *testing.T) {
+	revisionService, mockRepo, mockSongs := newRevisionService(t)
+
+	// Текущая версия песни сравнивается наравне с ревизиями
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).
+		Return(&domain.Song{ID: songID, Text: "It's bugging me\nGrating me", Version: 3}, nil)
+	mockRepo.EXPECT().Read(gomock.Any(), songID, 1).
+		Return(&domain.SongRevision{Revision: 1, Song: &domain.Song{ID: songID, Text: "It's bugging me\nTwisting me"}}, nil)
+
+	diff, err := revisionService.Diff(context.Background(), songID, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []textdiff.Line{
+		{Op: textdiff.Equal, Text: "It's bugging me"},
+		{Op: textdiff.Delete, Text: "Twisting me"},
+		{Op: textdiff.Insert, Text: "Grating me"},
+	}, diff)
+}
+
+func TestRevisionService_Diff_RevisionNotFound(t *testing.T) {
+	revisionService, mockRepo, mockSongs := newRevisionService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID, Version: 2}, nil)
+	mockRepo.EXPECT().Read(gomock.Any(), songID, 5).Return(nil, domain.ErrRevisionNotFound)
+
+	_, err := revisionService.Diff(context.Background(), songID, 5, 2)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+}
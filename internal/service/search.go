@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+)
+
+// defaultSemanticPageSize limits semantic search without a page size, the
+// nearest neighbors are only found quickly for a limited number of songs
+const defaultSemanticPageSize = 20
+
+type SearchRepository interface {
+	Search(ctx context.Context, query string, excludeExplicit bool, snippets, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+// VectorSearchRepository finds the songs whose embeddings are nearest to a
+// vector
+type VectorSearchRepository interface {
+	SearchEmbeddings(ctx context.Context, embedding []float32, excludeExplicit bool, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+type SearchService struct {
+	Repo SearchRepository
+	// Embedder and Vectors serve semantic search, it is disabled while they
+	// are nil
+	Embedder Embedder
+	Vectors  VectorSearchRepository
+	log      *slog.Logger
+
+	snippets    int
+	maxSnippets int
+}
+
+// NewSearchService creates a SearchService, snippets is the number of
+// highlighted snippets returned per song when the caller doesn't specify one
+// and larger numbers are cut to maxSnippets.
+func NewSearchService(r SearchRepository, snippets, maxSnippets int, log *slog.Logger) *SearchService {
+	return &SearchService{
+		Repo:        r,
+		log:         log,
+		snippets:    snippets,
+		maxSnippets: maxSnippets,
+	}
+}
+
+// Search returns the songs matching the full-text query, most relevant
+// first, with pagination. A negative snippets selects the default number of
+// snippets, zero returns none. excludeExplicit drops the songs flagged
+// explicit.
+func (s *SearchService) Search(ctx context.Context, query string, excludeExplicit bool, snippets, page, pageSize int) ([]*domain.SearchResult, error) {
+	const op = "SearchService.Search"
+
+	query = strings.Join(strings.Fields(query), " ")
+	if snippets < 0 {
+		snippets = s.snippets
+	}
+	snippets = min(snippets, s.maxSnippets)
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("query", query),
+		slog.Bool("exclude_explicit", excludeExplicit),
+		slog.Int("snippets", snippets),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	if query == "" {
+		log.Debug("empty query, nothing to search")
+		return nil, nil
+	}
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+
+	results, err := s.Repo.Search(ctx, query, excludeExplicit, snippets, pageSize, offset)
+	if err != nil {
+		log.Error("failed to search songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to search songs: %w", op, err)
+	}
+
+	log.Debug("songs successfully searched", slog.Int("count", len(results)))
+	return results, nil
+}
+
+// SemanticSearch returns the songs whose lyrics are nearest in meaning to
+// the query, with pagination. Rank is the cosine similarity of the query
+// and the song, no snippets are returned.
+func (s *SearchService) SemanticSearch(ctx context.Context, query string, excludeExplicit bool, page, pageSize int) ([]*domain.SearchResult, error) {
+	const op = "SearchService.SemanticSearch"
+
+	query = strings.Join(strings.Fields(query), " ")
+	if pageSize <= 0 {
+		pageSize = defaultSemanticPageSize
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("query", query),
+		slog.Bool("exclude_explicit", excludeExplicit),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	if s.Embedder == nil || s.Vectors == nil {
+		log.Warn("semantic search is disabled")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrSemanticSearchDisabled)
+	}
+
+	if query == "" {
+		log.Debug("empty query, nothing to search")
+		return nil, nil
+	}
+
+	embedding, err := s.Embedder.Embed(ctx, query)
+	if err != nil {
+		log.Error("failed to embed query", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to embed query: %w", op, err)
+	}
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+
+	results, err := s.Vectors.SearchEmbeddings(ctx, embedding, excludeExplicit, pageSize, offset)
+	if err != nil {
+		log.Error("failed to search embeddings", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to search embeddings: %w", op, err)
+	}
+
+	log.Debug("songs successfully searched", slog.Int("count", len(results)))
+	return results, nil
+}
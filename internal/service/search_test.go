@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchService_Search_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	searchService := service.NewSearchService(mockRepo, 3, 10, mockLog)
+
+	// Лишние пробелы убираются, число фрагментов по умолчанию из конфига, смещение по странице
+	results := []*domain.SearchResult{{Song: &domain.Song{Name: "Hysteria"}, Rank: 0.5}}
+	mockRepo.EXPECT().Search(gomock.Any(), "muse love", false, 3, 20, 20).Return(results, nil)
+
+	result, err := searchService.Search(context.Background(), "  muse   love ", false, -1, 2, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, results, result)
+}
+
+func TestSearchService_Search_CapsSnippets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	searchService := service.NewSearchService(mockRepo, 3, 10, mockLog)
+
+	mockRepo.EXPECT().Search(gomock.Any(), "love", false, 10, 0, 0).Return(nil, nil)
+	mockRepo.EXPECT().Search(gomock.Any(), "love", false, 0, 0, 0).Return(nil, nil)
+
+	_, err := searchService.Search(context.Background(), "love", false, 100, 0, 0)
+	assert.NoError(t, err)
+
+	// Ноль фрагментов означает поиск без подсветки
+	_, err = searchService.Search(context.Background(), "love", false, 0, 0, 0)
+	assert.NoError(t, err)
+}
+
+func TestSearchService_Search_EmptyQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	searchService := service.NewSearchService(mockRepo, 3, 10, mockLog)
+
+	// Пустой запрос не доходит до репозитория
+	result, err := searchService.Search(context.Background(), "   ", false, -1, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestSearchService_Search_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	searchService := service.NewSearchService(mockRepo, 3, 10, mockLog)
+
+	dbErr := errors.New("connection refused")
+	mockRepo.EXPECT().Search(gomock.Any(), "love", false, 3, 0, 0).Return(nil, dbErr)
+
+	_, err := searchService.Search(context.Background(), "love", false, -1, 0, 0)
+	assert.ErrorIs(t, err, dbErr)
+}
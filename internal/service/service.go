@@ -4,41 +4,88 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"net/http"
+	"slices"
 	"songLibrary/internal/domain"
 	"songLibrary/pkg/logger/sl"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults of GetDuplicates, 0.6 catches typos and different spellings of a
+// group without matching unrelated songs of the same group
+const (
+	defaultDuplicateThreshold = 0.6
+	defaultDuplicateLimit     = 20
 )
 
+// maxBatchSize is the number of song IDs GetByIDs resolves at once
+const maxBatchSize = 100
+
 type Repository interface {
 	Create(ctx context.Context, song *domain.Song) error
 	Read(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	ReadByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, error)
+	ReadByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
 	Delete(ctx context.Context, song *domain.SongInfo) error
+	UpdateAllWithFilter(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) ([]*domain.SongUpdate, error)
+
+	ReadAllWithFilter(ctx context.Context, filter *domain.SongFilter, limit, offset int) ([]*domain.Song, error)
+	ReadAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, limit int) ([]*domain.Song, error)
+	ReadDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error)
+	LibraryModified(ctx context.Context) (time.Time, error)
 
-	ReadAllWithFilter(ctx context.Context, song *domain.Song, limit, offset int) ([]*domain.Song, error)
+	// WithinTransaction runs fn atomically, the repository calls made with
+	// the context passed to fn are committed or rolled back together
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type MusicInfo interface {
 	FetchMusicInfo(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 }
 
+// Publisher receives the events of song mutations, they are relayed from the
+// outbox after the mutation is committed
+type Publisher interface {
+	Publish(event domain.SongEvent)
+}
+
 type IService interface {
-	Add(ctx context.Context, song *domain.SongInfo) error
+	Add(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Get(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, []uuid.UUID, error)
+	GetByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error)
 	Update(ctx context.Context, song *domain.SongInfo, updatedSong *domain.Song) error
 	Delete(ctx context.Context, song *domain.SongInfo) error
+	BulkUpdate(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) (int, error)
+
+	GetAllWithFilter(ctx context.Context, filter *domain.SongFilter, page, pageSize int) ([]*domain.Song, error)
+	GetAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, pageSize int) ([]*domain.Song, *domain.SongCursor, error)
+	LastModified(ctx context.Context) (time.Time, error)
+	GetDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error)
+	GetPaginatedText(ctx context.Context, song *domain.SongInfo, split domain.SplitStrategy) (*domain.Lyrics, error)
 
-	GetAllWithFilter(ctx context.Context, song *domain.Song, page, pageSize int) ([]*domain.Song, error)
-	GetPaginatedText(ctx context.Context, song *domain.SongInfo) ([]string, error)
+	Import(ctx context.Context, file io.Reader, dryRun bool) (*domain.ImportReport, error)
 }
 
 type Service struct {
 	Repo      Repository
 	MusicInfo MusicInfo
-	log       *slog.Logger
+	// MusicInfoTimeout limits fetching song details when a song is added,
+	// zero means no limit besides the request context
+	MusicInfoTimeout time.Duration
+	// Split selects how song texts are split into sections when they are read
+	Split LyricsSplit
+	// ContentFilter flags added and updated songs explicit, nil disables it
+	ContentFilter *ContentFilter
+	// Pending retries fetching the details of songs added while MusicInfo
+	// was unavailable, nil fails adding them instead
+	Pending *PendingEnrichment
+	log     *slog.Logger
 }
 
 func NewService(r Repository, mi MusicInfo, log *slog.Logger) *Service {
@@ -49,12 +96,15 @@ func NewService(r Repository, mi MusicInfo, log *slog.Logger) *Service {
 	}
 }
 
-// Add method to add a new song to the system.
-func (s *Service) Add(ctx context.Context, songInfo *domain.SongInfo) error {
+// Add method to add a new song to the system. While MusicInfo is
+// unavailable the song is added without details with the pending status if
+// Pending is set, its details are fetched later.
+func (s *Service) Add(ctx context.Context, songInfo *domain.SongInfo) (*domain.Song, error) {
 	const op = "Service.Add"
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("song_name", songInfo.Name),
 		slog.String("group_name", songInfo.Group),
 	)
@@ -62,34 +112,71 @@ func (s *Service) Add(ctx context.Context, songInfo *domain.SongInfo) error {
 	log.Info("attempting to add a new song")
 
 	// Fetch music info from external API
-	// Fetch music info from external API
-	song, err := s.MusicInfo.FetchMusicInfo(ctx, songInfo)
-	if err != nil {
-		var httpErr *domain.HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadRequest {
-			// Log and return a special error for bad request from MusicInfo
-			log.Warn("failed to fetch song info: bad request from MusicInfo", sl.Err(err))
-			return fmt.Errorf("%s: bad request from MusicInfo: %w", op, err)
-		}
-		log.Error("failed to fetch song info", sl.Err(err))
-		return fmt.Errorf("%s: failed to fetch song info: %w", op, err)
+	song, err := s.fetchMusicInfo(ctx, log, songInfo)
+	switch {
+	case err != nil && s.Pending != nil && musicInfoUnavailable(ctx, err):
+		log.Warn("MusicInfo is unavailable, adding song without details", sl.Err(err))
+		song = &domain.Song{Name: songInfo.Name, Group: songInfo.Group, Status: domain.SongStatusPending}
+	case err != nil:
+		return nil, fmt.Errorf("%s: %w", op, err)
+	default:
+		log.Debug("fetched song info successfully", slog.String("source", song.Source))
+		song.Status = domain.SongStatusEnriched
 	}
 
-	log.Debug("fetched song info successfully")
+	song.Text = NormalizeLyrics(song.Text)
+	song.Lyrics = ParseLyrics(song.Text)
+	if s.ContentFilter.Flag(song) {
+		log.Info("song flagged explicit by content filter")
+	}
 
 	// Save the song to the repository
 	err = s.Repo.Create(ctx, song)
 	if err != nil {
 		if errors.Is(err, domain.ErrSongExists) {
 			log.Warn("song already exists", sl.Err(err))
-			return fmt.Errorf("%s: song already exists: %w", op, domain.ErrSongExists)
+			return nil, fmt.Errorf("%s: song already exists: %w", op, domain.ErrSongExists)
 		}
 		log.Error("failed to save song", sl.Err(err))
-		return fmt.Errorf("%s: failed to save song: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to save song: %w", op, err)
+	}
+
+	if song.Status == domain.SongStatusPending {
+		s.Pending.Schedule(ctx, song.ID)
+		log.Info("song added, its details are pending", slog.String("song_id", song.ID.String()))
+		return song, nil
 	}
 
 	log.Info("song successfully added")
-	return nil
+	return song, nil
+}
+
+// fetchMusicInfo asks MusicInfo for the details of a song within MusicInfoTimeout
+func (s *Service) fetchMusicInfo(ctx context.Context, log *slog.Logger, songInfo *domain.SongInfo) (*domain.Song, error) {
+	fetchCtx := ctx
+	if s.MusicInfoTimeout > 0 {
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithTimeout(ctx, s.MusicInfoTimeout)
+		defer cancel()
+	}
+
+	song, err := s.MusicInfo.FetchMusicInfo(fetchCtx, songInfo)
+	if err != nil {
+		// Only our own deadline is reported as a timeout, a canceled request is not
+		if errors.Is(fetchCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			log.Warn("fetching song info timed out", sl.Err(err), slog.Duration("timeout", s.MusicInfoTimeout))
+			return nil, fmt.Errorf("%w: %w", domain.ErrMusicInfoTimeout, err)
+		}
+
+		if errors.Is(err, domain.ErrMusicInfoBadRequest) {
+			log.Warn("failed to fetch song info: bad request from MusicInfo", sl.Err(err))
+			return nil, fmt.Errorf("bad request from MusicInfo: %w", err)
+		}
+		log.Error("failed to fetch song info", sl.Err(err))
+		return nil, fmt.Errorf("failed to fetch song info: %w", err)
+	}
+
+	return song, nil
 }
 
 // Get method to fetch a song by group and name.
@@ -98,6 +185,7 @@ func (s *Service) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song,
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("song_name", song.Name),
 		slog.String("group_name", song.Group),
 	)
@@ -119,12 +207,86 @@ func (s *Service) Get(ctx context.Context, song *domain.SongInfo) (*domain.Song,
 	return targetSong, nil
 }
 
+// GetByIDs fetches the songs with the given IDs in the order of the IDs,
+// repeated IDs are resolved once. The IDs of songs that don't exist are
+// returned as not found instead of failing the whole batch.
+func (s *Service) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Song, []uuid.UUID, error) {
+	const op = "Service.GetByIDs"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx), slog.Int("count", len(ids)))
+
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxBatchSize {
+		log.Warn("too many song ids", slog.Int("max", maxBatchSize))
+		return nil, nil, fmt.Errorf("%s: %w", op, domain.ErrTooManySongIDs)
+	}
+
+	log.Info("attempting to fetch songs by ids")
+
+	found, err := s.Repo.ReadByIDs(ctx, unique)
+	if err != nil {
+		log.Error("failed to read songs", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: failed to read songs: %w", op, err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Song, len(found))
+	for _, song := range found {
+		byID[song.ID] = song
+	}
+
+	songs := make([]*domain.Song, 0, len(found))
+	var notFound []uuid.UUID
+	for _, id := range unique {
+		if song, ok := byID[id]; ok {
+			songs = append(songs, song)
+		} else {
+			notFound = append(notFound, id)
+		}
+	}
+
+	log.Info("songs successfully fetched", slog.Int("found", len(songs)), slog.Int("not_found", len(notFound)))
+	return songs, notFound, nil
+}
+
+// GetByNameAndGroup fetches a song by its name and group, ignoring case.
+func (s *Service) GetByNameAndGroup(ctx context.Context, song *domain.SongInfo) (*domain.Song, error) {
+	const op = "Service.GetByNameAndGroup"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_name", song.Name),
+		slog.String("group_name", song.Group),
+	)
+
+	log.Info("attempting to look up song")
+
+	targetSong, err := s.Repo.ReadByNameAndGroup(ctx, song)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to look up song", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to look up song: %w", op, err)
+	}
+
+	log.Info("song successfully looked up", slog.String("song_id", targetSong.ID.String()))
+	return targetSong, nil
+}
+
 // Update method to update an existing song's information.
 func (s *Service) Update(ctx context.Context, songInfo *domain.SongInfo, updatedSong *domain.Song) error {
 	const op = "Service.Update"
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("song_name", songInfo.Name),
 		slog.String("group_name", songInfo.Group),
 	)
@@ -141,6 +303,11 @@ func (s *Service) Update(ctx context.Context, songInfo *domain.SongInfo, updated
 	// Merge updatedSong with targetSong
 	mergedSong := mergeSongs(updatedSong, targetSong)
 
+	// An explicit flag set by the caller wins over the content filter
+	if updatedSong.Explicit == nil && s.ContentFilter.Flag(mergedSong) {
+		log.Info("song flagged explicit by content filter")
+	}
+
 	// Update the song in the repository
 	err = s.Repo.Update(ctx, songInfo, mergedSong)
 	if err != nil {
@@ -148,6 +315,14 @@ func (s *Service) Update(ctx context.Context, songInfo *domain.SongInfo, updated
 			log.Warn("song not found during update", sl.Err(err))
 			return fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
 		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("song was updated concurrently", slog.Int("version", mergedSong.Version), sl.Err(err))
+			return fmt.Errorf("%s: stale song version: %w", op, domain.ErrVersionConflict)
+		}
+		if errors.Is(err, domain.ErrSongExists) {
+			log.Warn("song with this name and group already exists", sl.Err(err))
+			return fmt.Errorf("%s: song already exists: %w", op, domain.ErrSongExists)
+		}
 		log.Error("failed to update song", sl.Err(err))
 		return fmt.Errorf("%s: failed to update song: %w", op, err)
 	}
@@ -156,12 +331,51 @@ func (s *Service) Update(ctx context.Context, songInfo *domain.SongInfo, updated
 	return nil
 }
 
+// BulkUpdate sets the changes on every song matching the filter of song
+// listings and returns how many songs were updated. An empty filter or
+// changes are rejected, so a mistake can't rewrite the whole library.
+func (s *Service) BulkUpdate(ctx context.Context, filter *domain.SongFilter, changes *domain.SongChanges) (int, error) {
+	const op = "Service.BulkUpdate"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_name", filter.Name),
+		slog.String("group_name", filter.Group),
+	)
+
+	if filter.IsEmpty() {
+		log.Warn("bulk update without filter")
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrBulkFilterEmpty)
+	}
+	if changes.IsEmpty() {
+		log.Warn("bulk update without changes")
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrBulkChangesEmpty)
+	}
+
+	log.Info("attempting to update songs with filter")
+
+	updates, err := s.Repo.UpdateAllWithFilter(ctx, filter, changes)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongExists) {
+			log.Warn("updated song clashes with an existing one", sl.Err(err))
+			return 0, fmt.Errorf("%s: song already exists: %w", op, domain.ErrSongExists)
+		}
+		log.Error("failed to update songs", sl.Err(err))
+		return 0, fmt.Errorf("%s: failed to update songs: %w", op, err)
+	}
+
+	log.Info("songs successfully updated", slog.Int("count", len(updates)))
+	return len(updates), nil
+}
+
 // Delete method to remove a song from the system.
 func (s *Service) Delete(ctx context.Context, songSearch *domain.SongInfo) error {
 	const op = "Service.Delete"
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("song_name", songSearch.Name),
 		slog.String("group_name", songSearch.Group),
 	)
@@ -184,11 +398,12 @@ func (s *Service) Delete(ctx context.Context, songSearch *domain.SongInfo) error
 }
 
 // GetAllWithFilter retrieves all songs with filtering and pagination.
-func (s *Service) GetAllWithFilter(ctx context.Context, song *domain.Song, page, pageSize int) ([]*domain.Song, error) {
+func (s *Service) GetAllWithFilter(ctx context.Context, filter *domain.SongFilter, page, pageSize int) ([]*domain.Song, error) {
 	const op = "Service.GetAllWithFilter"
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.Int("page", page),
 		slog.Int("pageSize", pageSize),
 	)
@@ -197,7 +412,7 @@ func (s *Service) GetAllWithFilter(ctx context.Context, song *domain.Song, page,
 	log.Info("attempting to fetch songs with filter", slog.Int("offset", offset))
 
 	// Fetch songs with filtering from the repository
-	songs, err := s.Repo.ReadAllWithFilter(ctx, song, pageSize, offset)
+	songs, err := s.Repo.ReadAllWithFilter(ctx, filter, pageSize, offset)
 	if err != nil {
 		log.Error("failed to fetch songs with filter", sl.Err(err))
 		return nil, fmt.Errorf("%s: failed to fetch songs with filter: %w", op, err)
@@ -207,12 +422,91 @@ func (s *Service) GetAllWithFilter(ctx context.Context, song *domain.Song, page,
 	return songs, nil
 }
 
-// GetPaginatedText retrieves the song's text with pagination by verses.
-func (s *Service) GetPaginatedText(ctx context.Context, song *domain.SongInfo) ([]string, error) {
+// GetAllAfter retrieves a page of songs with filtering, newest first, that
+// come after the cursor. The returned cursor points to the next page and is
+// nil on the last page.
+func (s *Service) GetAllAfter(ctx context.Context, filter *domain.SongFilter, after *domain.SongCursor, pageSize int) ([]*domain.Song, *domain.SongCursor, error) {
+	const op = "Service.GetAllAfter"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("pageSize", pageSize),
+	)
+
+	log.Info("attempting to fetch songs after cursor")
+
+	// One extra song tells whether there is a next page
+	songs, err := s.Repo.ReadAllAfter(ctx, filter, after, pageSize+1)
+	if err != nil {
+		log.Error("failed to fetch songs after cursor", sl.Err(err))
+		return nil, nil, fmt.Errorf("%s: failed to fetch songs after cursor: %w", op, err)
+	}
+
+	var next *domain.SongCursor
+	if len(songs) > pageSize {
+		songs = songs[:pageSize]
+		next = domain.CursorOf(songs[pageSize-1])
+	}
+
+	log.Info("songs successfully fetched", slog.Int("count", len(songs)), slog.Bool("has_next", next != nil))
+	return songs, next, nil
+}
+
+// LastModified returns the time the songs of the library were last
+// modified, listings don't change until the next modification
+func (s *Service) LastModified(ctx context.Context) (time.Time, error) {
+	const op = "Service.LastModified"
+
+	modified, err := s.Repo.LibraryModified(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: failed to fetch library modification time: %w", op, err)
+	}
+
+	return modified, nil
+}
+
+// GetDuplicates retrieves pairs of songs with similar names and groups.
+// Zero threshold and limit fall back to the defaults.
+func (s *Service) GetDuplicates(ctx context.Context, threshold float64, limit int) ([]*domain.DuplicateSongs, error) {
+	const op = "Service.GetDuplicates"
+
+	if threshold == 0 {
+		threshold = defaultDuplicateThreshold
+	}
+	if limit == 0 {
+		limit = defaultDuplicateLimit
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Float64("threshold", threshold),
+		slog.Int("limit", limit),
+	)
+
+	log.Info("attempting to fetch duplicate songs")
+
+	duplicates, err := s.Repo.ReadDuplicates(ctx, threshold, limit)
+	if err != nil {
+		log.Error("failed to fetch duplicate songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch duplicate songs: %w", op, err)
+	}
+
+	log.Info("duplicate songs successfully fetched", slog.Int("count", len(duplicates)))
+	return duplicates, nil
+}
+
+// GetPaginatedText retrieves the song's text split into sections with the
+// split strategy, the default of the library if it is empty. Stored lyrics
+// are split by blank lines, songs saved before lyrics were structured and
+// other strategies split the text on the fly.
+func (s *Service) GetPaginatedText(ctx context.Context, song *domain.SongInfo, split domain.SplitStrategy) (*domain.Lyrics, error) {
 	const op = "Service.GetPaginatedText"
 
 	log := s.log.With(
 		slog.String("op", op),
+		sl.RequestID(ctx),
 		slog.String("song_name", song.Name),
 		slog.String("group_name", song.Group),
 	)
@@ -230,23 +524,110 @@ func (s *Service) GetPaginatedText(ctx context.Context, song *domain.SongInfo) (
 		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
 	}
 
-	if targetSong.Text == "" {
+	if strings.TrimSpace(targetSong.Text) == "" {
 		log.Warn("song text is empty", slog.String("song_name", targetSong.Name), slog.String("group_name", targetSong.Group))
 		return nil, fmt.Errorf("%s: song text is empty", op)
 	}
 
-	// Split the song text into verses by detecting double newlines (\n\n)
-	verses := strings.Split(targetSong.Text, "\n\n")
+	split = s.Split.Strategy(ctx, split)
+	lyrics := targetSong.Lyrics
+	switch {
+	case split != domain.SplitBlankLines:
+		log.Debug("splitting song text", slog.String("split", string(split)))
+		lyrics = s.Split.Splitter(split).Split(targetSong.Text)
+	case lyrics == nil:
+		log.Debug("song lyrics aren't structured yet, parsing text")
+		lyrics = ParseLyrics(targetSong.Text)
+	}
+
+	log.Info("song text successfully paginated", slog.String("song_name", targetSong.Name), slog.Int("sections_count", len(lyrics.Sections)))
+
+	return lyrics, nil
+}
+
+// GetTextPage returns a page of the song text split into pages of
+// versesPerPage sections, for clients showing several sections per screen.
+// Pages are numbered from 1, a page past the last one is
+// domain.ErrLyricsPageNotFound.
+func (s *Service) GetTextPage(ctx context.Context, song *domain.SongInfo, split domain.SplitStrategy, page, versesPerPage int) (*domain.LyricsPage, error) {
+	const op = "Service.GetTextPage"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", song.ID.String()),
+		slog.Int("page", page),
+		slog.Int("verses_per_page", versesPerPage),
+	)
+
+	lyrics, err := s.GetPaginatedText(ctx, song, split)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lyricsPage, err := paginateLyrics(lyrics, page, versesPerPage)
+	if err != nil {
+		log.Warn("song text page not found", slog.Int("sections_count", len(lyrics.Sections)))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("song text page fetched", slog.Int("total_pages", lyricsPage.TotalPages))
+	return lyricsPage, nil
+}
+
+// paginateLyrics cuts the sections of page out of lyrics split into pages of
+// versesPerPage sections
+func paginateLyrics(lyrics *domain.Lyrics, page, versesPerPage int) (*domain.LyricsPage, error) {
+	if page < 1 || versesPerPage < 1 {
+		return nil, domain.ErrLyricsPageNotFound
+	}
 
-	log.Debug("successfully paginated song text", slog.Int("verses_count", len(verses)))
+	total := len(lyrics.Sections)
+	totalPages := (total + versesPerPage - 1) / versesPerPage
+	if page > totalPages {
+		return nil, domain.ErrLyricsPageNotFound
+	}
+
+	start := (page - 1) * versesPerPage
+	end := min(start+versesPerPage, total)
+	return &domain.LyricsPage{
+		Lyrics:        domain.Lyrics{Sections: lyrics.Sections[start:end]},
+		Number:        page,
+		VersesPerPage: versesPerPage,
+		TotalVerses:   total,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+// SearchText finds the sections of the song text with lines containing the
+// query, e.g. to seek to a quote.
+func (s *Service) SearchText(ctx context.Context, song *domain.SongInfo, query string, split domain.SplitStrategy) ([]*domain.VerseMatch, error) {
+	const op = "Service.SearchText"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", song.ID.String()),
+		slog.String("query", query),
+	)
+
+	lyrics, err := s.GetPaginatedText(ctx, song, split)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	log.Info("song text successfully paginated", slog.String("song_name", targetSong.Name), slog.Int("verses_count", len(verses)))
+	matches := MatchVerses(lyrics, query)
 
-	return verses, nil
+	log.Debug("song text successfully searched", slog.Int("count", len(matches)))
+	return matches, nil
 }
 
 func mergeSongs(updatedSong, targetSong *domain.Song) *domain.Song {
 	updatedSong.ID = targetSong.ID
+	updatedSong.Text = NormalizeLyrics(updatedSong.Text)
+
+	// Fields edited by hand are no longer overwritten by a refresh from MusicInfo
+	updatedSong.LockedFields = targetSong.LockedFields | editedFields(updatedSong, targetSong)
 
 	if updatedSong.Name == "" {
 		updatedSong.Name = targetSong.Name
@@ -257,6 +638,7 @@ func mergeSongs(updatedSong, targetSong *domain.Song) *domain.Song {
 	if updatedSong.Text == "" {
 		updatedSong.Text = targetSong.Text
 	}
+	updatedSong.Lyrics = ParseLyrics(updatedSong.Text)
 	if updatedSong.Link == "" {
 		updatedSong.Link = targetSong.Link
 	}
@@ -266,6 +648,31 @@ func mergeSongs(updatedSong, targetSong *domain.Song) *domain.Song {
 	if updatedSong.CreatedAt.IsZero() {
 		updatedSong.CreatedAt = targetSong.CreatedAt
 	}
+	if updatedSong.AlbumID == nil {
+		updatedSong.AlbumID = targetSong.AlbumID
+	}
+	if updatedSong.Duration == 0 {
+		updatedSong.Duration = targetSong.Duration
+	}
+	if updatedSong.Genre == "" {
+		updatedSong.Genre = targetSong.Genre
+	}
+	if updatedSong.TrackNumber == 0 {
+		updatedSong.TrackNumber = targetSong.TrackNumber
+	}
+	if updatedSong.Album == "" {
+		updatedSong.Album = targetSong.Album
+	}
+	if updatedSong.Explicit == nil {
+		updatedSong.Explicit = targetSong.Explicit
+	}
+	if updatedSong.Source == "" {
+		updatedSong.Source = targetSong.Source
+	}
+	// Without an explicit version the update is based on the revision read above
+	if updatedSong.Version == 0 {
+		updatedSong.Version = targetSong.Version
+	}
 	// UpdatedAt устанавливаем заново для актуального времени
 	updatedSong.UpdatedAt = time.Now()
 
@@ -3,7 +3,10 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestService_Add_Success(t *testing.T) {
@@ -44,9 +48,179 @@ func TestService_Add_Success(t *testing.T) {
 	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(song, nil)
 	mockRepo.EXPECT().Create(gomock.Any(), song).Return(nil)
 
-	err := service.Add(context.Background(), songInfo)
+	added, err := service.Add(context.Background(), songInfo)
 	assert.NoError(t, err)
+	assert.Equal(t, song, added)
+	assert.Equal(t, domain.SongStatusEnriched, added.Status)
 }
+
+func TestService_Add_MusicInfoTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	service := service.NewService(mockRepo, mockMusicInfo, mockLog)
+	service.MusicInfoTimeout = 10 * time.Millisecond
+
+	songInfo := &domain.SongInfo{
+		Name:  "Hysteria",
+		Group: "Muse",
+	}
+
+	// Внешнее API не отвечает, пока не истечет таймаут сервиса
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).DoAndReturn(
+		func(ctx context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	_, err := service.Add(context.Background(), songInfo)
+	assert.ErrorIs(t, err, domain.ErrMusicInfoTimeout)
+}
+
+func TestService_Add_MusicInfoBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	service := service.NewService(mockRepo, mockMusicInfo, mockLog)
+
+	songInfo := &domain.SongInfo{
+		Name:  "Hysteria",
+		Group: "Muse",
+	}
+
+	// Внешнее API не знает песню, в репозиторий ничего не сохраняется
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).
+		Return(nil, fmt.Errorf("MusicInfo.FetchMusicInfo: %w", domain.ErrMusicInfoBadRequest))
+
+	_, err := service.Add(context.Background(), songInfo)
+	assert.ErrorIs(t, err, domain.ErrMusicInfoBadRequest)
+}
+
+func TestService_Add_MusicInfoUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockRefresher := mocks.NewMockPendingSongs(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, mockMusicInfo, mockLog)
+	svc.Pending = service.NewPendingEnrichment(mockRefresher, time.Millisecond, 3, mockLog)
+
+	songInfo := &domain.SongInfo{
+		Name:  "Hysteria",
+		Group: "Muse",
+	}
+	songID := uuid.New()
+
+	// Внешнее API недоступно: песня сохраняется без текста и ждёт обогащения
+	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).
+		Return(nil, fmt.Errorf("MusicInfo.FetchMusicInfo: %w", &domain.HTTPError{StatusCode: http.StatusServiceUnavailable}))
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, song *domain.Song) error {
+		assert.Equal(t, "Hysteria", song.Name)
+		assert.Empty(t, song.Text)
+		song.ID = songID
+		return nil
+	})
+
+	added, err := svc.Add(context.Background(), songInfo)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SongStatusPending, added.Status)
+
+	// Повторная попытка обогащает песню, первая неудачная попытка не считается отказом
+	gomock.InOrder(
+		mockRefresher.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: songID}, false).
+			Return(nil, domain.SongFields(0), domain.ErrMusicInfoTimeout),
+		mockRefresher.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: songID}, false).
+			Return(&domain.Song{ID: songID}, domain.FieldText, nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.Pending.Run(ctx)
+	}()
+	assert.Eventually(t, func() bool { return svc.Pending.Stats()["enriched"] == 1 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+	assert.Zero(t, svc.Pending.Stats()["abandoned"])
+}
+
+func TestService_Add_MusicInfoRejectedNotPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMusicInfo := mocks.NewMockMusicInfo(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, mockMusicInfo, mockLog)
+	svc.Pending = service.NewPendingEnrichment(mocks.NewMockPendingSongs(ctrl), time.Millisecond, 3, mockLog)
+
+	songInfo := &domain.SongInfo{
+		Name:  "Hysteria",
+		Group: "Muse",
+	}
+
+	// Ответы о самой песне не откладываются: неизвестная песня не сохраняется
+	for _, err := range []error{
+		fmt.Errorf("MusicInfo.FetchMusicInfo: %w: %w", domain.ErrMusicInfoBadRequest, &domain.HTTPError{StatusCode: http.StatusBadRequest}),
+		fmt.Errorf("MusicInfo.FetchMusicInfo: %w", &domain.HTTPError{StatusCode: http.StatusNotFound}),
+		fmt.Errorf("MusicInfo.FetchMusicInfo: %w", domain.ErrMusicInfoMalformed),
+	} {
+		mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(nil, err)
+
+		_, addErr := svc.Add(context.Background(), songInfo)
+		assert.ErrorIs(t, addErr, err)
+	}
+}
+
+func TestPendingEnrichment_MarksAbandonedSongFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSongs := mocks.NewMockPendingSongs(ctrl)
+	pending := service.NewPendingEnrichment(mockSongs, time.Millisecond, 2, slog.New(slogdiscard.NewDiscardHandler()))
+	songID := uuid.New()
+
+	// После всех неудачных попыток песня получает статус failed
+	mockSongs.EXPECT().Refresh(gomock.Any(), &domain.SongInfo{ID: songID}, false).
+		Return(nil, domain.SongFields(0), domain.ErrMusicInfoTimeout).Times(2)
+	marked := make(chan struct{})
+	mockSongs.EXPECT().SetStatus(gomock.Any(), &domain.SongInfo{ID: songID}, domain.SongStatusFailed).
+		DoAndReturn(func(context.Context, *domain.SongInfo, domain.SongStatus) (*domain.Song, error) {
+			close(marked)
+			return &domain.Song{ID: songID, Status: domain.SongStatusFailed}, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pending.Run(ctx)
+	}()
+	pending.Schedule(ctx, songID)
+
+	select {
+	case <-marked:
+	case <-time.After(time.Second):
+		t.Fatal("song was not marked failed")
+	}
+	cancel()
+	<-done
+	assert.Equal(t, int64(1), pending.Stats()["abandoned"])
+}
+
 func TestService_Add_AlreadyExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -74,7 +248,7 @@ func TestService_Add_AlreadyExists(t *testing.T) {
 	mockMusicInfo.EXPECT().FetchMusicInfo(gomock.Any(), songInfo).Return(song, nil)
 	mockRepo.EXPECT().Create(gomock.Any(), song).Return(domain.ErrSongExists)
 
-	err := service.Add(context.Background(), songInfo)
+	_, err := service.Add(context.Background(), songInfo)
 	assert.ErrorIs(t, err, domain.ErrSongExists)
 }
 
@@ -256,7 +430,7 @@ func TestService_GetAllWithFilter(t *testing.T) {
 
 	svc := service.NewService(mockRepo, nil, mockLog)
 
-	songFilter := &domain.Song{
+	songFilter := &domain.SongFilter{
 		Name:  "Hysteria",
 		Group: "Muse",
 	}
@@ -300,7 +474,7 @@ func TestService_GetAllWithFilter_Error(t *testing.T) {
 
 	svc := service.NewService(mockRepo, nil, mockLog)
 
-	songFilter := &domain.Song{
+	songFilter := &domain.SongFilter{
 		Name:  "Hysteria",
 		Group: "Muse",
 	}
@@ -348,15 +522,133 @@ func TestService_GetPaginatedText(t *testing.T) {
 		Read(gomock.Any(), songInfo).
 		Return(expectedSong, nil)
 
-	// Выполняем тестируемую функцию
-	verses, err := svc.GetPaginatedText(context.Background(), songInfo)
+	// Выполняем тестируемую функцию, текст без разметки разбирается на лету
+	lyrics, err := svc.GetPaginatedText(context.Background(), songInfo, "")
 
 	assert.NoError(t, err)
-	assert.Len(t, verses, 2)
 	assert.Equal(t, []string{
 		"It's bugging me...",
 		"I can't control...",
-	}, verses)
+	}, lyrics.Verses())
+	assert.Equal(t, domain.SectionVerse, lyrics.Sections[1].Type)
+	assert.Equal(t, 2, lyrics.Sections[1].Index)
+}
+
+func TestService_SearchText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().
+		Read(gomock.Any(), songInfo).
+		Return(&domain.Song{ID: songInfo.ID, Text: "It's bugging me\nGrating me\n\nI can't control"}, nil)
+
+	matches, err := svc.SearchText(context.Background(), songInfo, "GRATING", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, 1, matches[0].Number)
+	assert.Equal(t, []domain.LineMatch{{Number: 2, Text: "Grating me"}}, matches[0].Lines)
+}
+
+func TestService_SearchText_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(nil, domain.ErrSongNotFound)
+
+	_, err := svc.SearchText(context.Background(), songInfo, "me", "")
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestService_GetPaginatedText_Split(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+	svc.Split = service.LyricsSplit{LinesPerVerse: 1}
+
+	text := "[Verse 1]\nIt's bugging me\n[Chorus]\n'Cause I want it now"
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().
+		Read(gomock.Any(), songInfo).
+		Return(&domain.Song{ID: songInfo.ID, Text: text, Lyrics: service.ParseLyrics(text)}, nil).
+		Times(2)
+
+	// Сохранённая разбивка по пустым строкам используется по умолчанию
+	lyrics, err := svc.GetPaginatedText(context.Background(), songInfo, "")
+	assert.NoError(t, err)
+	assert.Len(t, lyrics.Sections, 1)
+
+	// Другая стратегия разбивает текст заново
+	lyrics, err = svc.GetPaginatedText(context.Background(), songInfo, domain.SplitMarkers)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"It's bugging me", "'Cause I want it now"}, lyrics.Verses())
+}
+
+func TestService_GetTextPage(t *testing.T) {
+	text := "It's bugging me\n\nGrating me\n\nTwisting me around\n\nI can't control\n\nIt's bugging me"
+
+	tests := []struct {
+		name          string
+		page          int
+		versesPerPage int
+		want          []string
+		wantTotal     int
+		wantErr       error
+	}{
+		{name: "первая страница", page: 1, versesPerPage: 2, want: []string{"It's bugging me", "Grating me"}, wantTotal: 3},
+		{name: "неполная последняя страница", page: 3, versesPerPage: 2, want: []string{"It's bugging me"}, wantTotal: 3},
+		{name: "весь текст на одной странице", page: 1, versesPerPage: 10, want: []string{
+			"It's bugging me", "Grating me", "Twisting me around", "I can't control", "It's bugging me",
+		}, wantTotal: 1},
+		{name: "за концом текста", page: 4, versesPerPage: 2, wantErr: domain.ErrLyricsPageNotFound},
+		{name: "нулевая страница", page: 0, versesPerPage: 2, wantErr: domain.ErrLyricsPageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+			svc := service.NewService(mockRepo, nil, mockLog)
+
+			songInfo := &domain.SongInfo{ID: uuid.New()}
+			mockRepo.EXPECT().
+				Read(gomock.Any(), songInfo).
+				Return(&domain.Song{ID: songInfo.ID, Text: text}, nil)
+
+			page, err := svc.GetTextPage(context.Background(), songInfo, "", tt.page, tt.versesPerPage)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, page.Verses())
+			assert.Equal(t, tt.wantTotal, page.TotalPages)
+			assert.Equal(t, 5, page.TotalVerses)
+			assert.Equal(t, (tt.page-1)*tt.versesPerPage+1, page.FirstVerse())
+		})
+	}
 }
 
 func TestService_GetPaginatedText_EmptyText(t *testing.T) {
@@ -386,7 +678,7 @@ func TestService_GetPaginatedText_EmptyText(t *testing.T) {
 		Return(expectedSong, nil)
 
 	// Выполняем тестируемую функцию
-	verses, err := svc.GetPaginatedText(context.Background(), songInfo)
+	verses, err := svc.GetPaginatedText(context.Background(), songInfo, "")
 
 	assert.Error(t, err)
 	assert.Nil(t, verses)
@@ -413,9 +705,278 @@ func TestService_GetPaginatedText_SongNotFound(t *testing.T) {
 		Return(nil, domain.ErrSongNotFound)
 
 	// Выполняем тестируемую функцию
-	verses, err := svc.GetPaginatedText(context.Background(), songInfo)
+	verses, err := svc.GetPaginatedText(context.Background(), songInfo, "")
 
 	assert.Error(t, err)
 	assert.Nil(t, verses)
 	assert.Contains(t, err.Error(), "song not found")
 }
+
+func TestService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	file := strings.NewReader("name,group\nHysteria,Muse\n,Muse\nStarlight,Muse\n")
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domain.ErrSongExists)
+
+	report, err := svc.Import(context.Background(), file, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Imported)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, 3, report.Errors[0].Line)
+	assert.ErrorIs(t, report.Errors[0].Err, domain.ErrSongNameIsNull)
+	assert.Equal(t, 4, report.Errors[1].Line)
+	assert.ErrorIs(t, report.Errors[1].Err, domain.ErrSongExists)
+}
+
+func TestService_Import_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	file := strings.NewReader("name,group\nHysteria,Muse\n")
+
+	// Репозиторий не должен вызываться в режиме dry run
+	report, err := svc.Import(context.Background(), file, true)
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, 1, report.Imported)
+	assert.Empty(t, report.Errors)
+}
+
+func TestService_Update_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+
+	originalSong := &domain.Song{
+		ID:      songInfo.ID,
+		Name:    "Hysteria",
+		Group:   "Muse",
+		Version: 3,
+	}
+
+	updatedSong := &domain.Song{Text: "Updated text"}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(originalSong, nil)
+	mockRepo.EXPECT().
+		Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, song *domain.Song) error {
+			// Без явной версии обновление основано на прочитанной ревизии
+			assert.Equal(t, 3, song.Version)
+			return domain.ErrVersionConflict
+		})
+
+	err := svc.Update(context.Background(), songInfo, updatedSong)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+}
+
+type recordingPublisher struct {
+	events []domain.SongEvent
+}
+
+func (p *recordingPublisher) Publish(event domain.SongEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestService_BulkUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	group := "MUSE"
+	filter := &domain.SongFilter{Group: "Muse"}
+	changes := &domain.SongChanges{Group: &group}
+	updates := []*domain.SongUpdate{
+		{Old: &domain.Song{Name: "Hysteria", Group: "Muse"}, New: &domain.Song{Name: "Hysteria", Group: "MUSE"}},
+		{Old: &domain.Song{Name: "Bliss", Group: "Muse"}, New: &domain.Song{Name: "Bliss", Group: "MUSE"}},
+	}
+	mockRepo.EXPECT().UpdateAllWithFilter(gomock.Any(), filter, changes).Return(updates, nil)
+
+	updated, err := svc.BulkUpdate(context.Background(), filter, changes)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+}
+
+func TestService_BulkUpdate_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	// Пустой фильтр или пустые изменения не доходят до репозитория
+	genre := "rock"
+	_, err := svc.BulkUpdate(context.Background(), &domain.SongFilter{}, &domain.SongChanges{Genre: &genre})
+	assert.ErrorIs(t, err, domain.ErrBulkFilterEmpty)
+
+	_, err = svc.BulkUpdate(context.Background(), &domain.SongFilter{Group: "Muse"}, &domain.SongChanges{})
+	assert.ErrorIs(t, err, domain.ErrBulkChangesEmpty)
+}
+
+func TestService_BulkUpdate_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	group := "Radiohead"
+	mockRepo.EXPECT().UpdateAllWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("repository.SongDB.UpdateAllWithFilter: %w", domain.ErrSongExists))
+
+	_, err := svc.BulkUpdate(context.Background(), &domain.SongFilter{Group: "Stone"}, &domain.SongChanges{Group: &group})
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+}
+
+func TestService_GetAllAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	createdAt := time.Now()
+	songs := []*domain.Song{
+		{ID: uuid.New(), CreatedAt: createdAt},
+		{ID: uuid.New(), CreatedAt: createdAt.Add(-time.Minute)},
+		{ID: uuid.New(), CreatedAt: createdAt.Add(-2 * time.Minute)},
+	}
+
+	// Запрашивается на одну песню больше, чтобы узнать о следующей странице
+	mockRepo.EXPECT().ReadAllAfter(gomock.Any(), gomock.Any(), nil, 3).Return(songs, nil)
+
+	page, next, err := svc.GetAllAfter(context.Background(), &domain.SongFilter{}, nil, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, songs[:2], page)
+	assert.Equal(t, &domain.SongCursor{CreatedAt: songs[1].CreatedAt, ID: songs[1].ID}, next)
+
+	// На последней странице курсор не возвращается
+	mockRepo.EXPECT().ReadAllAfter(gomock.Any(), gomock.Any(), next, 3).Return(songs[2:], nil)
+
+	page, next, err = svc.GetAllAfter(context.Background(), &domain.SongFilter{}, next, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Nil(t, next)
+}
+
+func TestService_GetDuplicates_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	// Нулевые параметры заменяются значениями по умолчанию
+	mockRepo.EXPECT().ReadDuplicates(gomock.Any(), 0.6, 20).Return(nil, nil)
+
+	duplicates, err := svc.GetDuplicates(context.Background(), 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, duplicates)
+}
+
+func TestService_Update_DuplicateNameAndGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(&domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(domain.ErrSongExists)
+
+	err := svc.Update(context.Background(), songInfo, &domain.Song{Name: "Uprising"})
+	assert.ErrorIs(t, err, domain.ErrSongExists)
+}
+
+func TestService_GetByNameAndGroup_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	songInfo := &domain.SongInfo{Name: "Hysteria", Group: "Muse"}
+	mockRepo.EXPECT().ReadByNameAndGroup(gomock.Any(), songInfo).Return(nil, domain.ErrSongNotFound)
+
+	song, err := svc.GetByNameAndGroup(context.Background(), songInfo)
+	assert.Nil(t, song)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestService_GetByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	svc := service.NewService(mockRepo, nil, mockLog)
+
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+
+	// Повторы запрашиваются один раз, репозиторий возвращает песни в любом порядке
+	mockRepo.EXPECT().ReadByIDs(gomock.Any(), []uuid.UUID{first, missing, second}).
+		Return([]*domain.Song{{ID: second}, {ID: first}}, nil)
+
+	songs, notFound, err := svc.GetByIDs(context.Background(), []uuid.UUID{first, missing, first, second})
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 2) {
+		assert.Equal(t, first, songs[0].ID)
+		assert.Equal(t, second, songs[1].ID)
+	}
+	assert.Equal(t, []uuid.UUID{missing}, notFound)
+}
+
+func TestService_GetByIDs_TooMany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := service.NewService(mocks.NewMockRepository(ctrl), nil, slog.New(slogdiscard.NewDiscardHandler()))
+
+	ids := make([]uuid.UUID, 101)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	_, _, err := svc.GetByIDs(context.Background(), ids)
+	assert.ErrorIs(t, err, domain.ErrTooManySongIDs)
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShareRepository interface {
+	Create(ctx context.Context, share *domain.Share) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.Share, error)
+	ReadAll(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID, revokedAt time.Time) (*domain.Share, error)
+}
+
+// ShareService issues read-only links to songs and smart playlists. The
+// token of a link is the ID of the share signed with Secret, so tokens can't
+// be guessed and aren't stored.
+type ShareService struct {
+	Repo       ShareRepository
+	Songs      SongReader
+	SongRepo   SongLister
+	Playlists  PlaylistRepository
+	Secret     []byte
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+	log        *slog.Logger
+}
+
+func NewShareService(
+	r ShareRepository, songs SongReader, songLister SongLister, playlists PlaylistRepository,
+	secret []byte, defaultTTL, maxTTL time.Duration, log *slog.Logger,
+) *ShareService {
+	return &ShareService{
+		Repo:       r,
+		Songs:      songs,
+		SongRepo:   songLister,
+		Playlists:  playlists,
+		Secret:     secret,
+		DefaultTTL: defaultTTL,
+		MaxTTL:     maxTTL,
+		log:        log,
+	}
+}
+
+// ShareSong issues a link to a song of the library, valid for ttl or the
+// default when ttl is zero. It returns the share and its token.
+func (s *ShareService) ShareSong(ctx context.Context, userID, songID uuid.UUID, ttl time.Duration) (*domain.Share, string, error) {
+	const op = "ShareService.ShareSong"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("song_id", songID.String()),
+	)
+
+	log.Info("attempting to share song")
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, "", fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to read song", sl.Err(err))
+		return nil, "", fmt.Errorf("%s: failed to read song: %w", op, err)
+	}
+
+	share, token, err := s.create(ctx, log, &domain.Share{UserID: userID, SongID: &songID}, ttl)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	return share, token, nil
+}
+
+// SharePlaylist issues a link to a smart playlist of the user, valid for ttl
+// or the default when ttl is zero. It returns the share and its token.
+func (s *ShareService) SharePlaylist(ctx context.Context, userID, playlistID uuid.UUID, ttl time.Duration) (*domain.Share, string, error) {
+	const op = "ShareService.SharePlaylist"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("playlist_id", playlistID.String()),
+	)
+
+	log.Info("attempting to share smart playlist")
+
+	if _, err := s.Playlists.Read(ctx, userID, playlistID); err != nil {
+		if errors.Is(err, domain.ErrPlaylistNotFound) {
+			log.Warn("smart playlist not found", sl.Err(err))
+			return nil, "", fmt.Errorf("%s: smart playlist not found: %w", op, domain.ErrPlaylistNotFound)
+		}
+		log.Error("failed to read smart playlist", sl.Err(err))
+		return nil, "", fmt.Errorf("%s: failed to read smart playlist: %w", op, err)
+	}
+
+	share, token, err := s.create(ctx, log, &domain.Share{UserID: userID, PlaylistID: &playlistID}, ttl)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	return share, token, nil
+}
+
+func (s *ShareService) create(ctx context.Context, log *slog.Logger, share *domain.Share, ttl time.Duration) (*domain.Share, string, error) {
+	if ttl == 0 {
+		ttl = s.DefaultTTL
+	}
+	if ttl < 0 || (s.MaxTTL > 0 && ttl > s.MaxTTL) {
+		log.Warn("invalid share expiry", slog.Duration("ttl", ttl))
+		return nil, "", domain.ErrShareTTLInvalid
+	}
+	share.ExpiresAt = time.Now().Add(ttl)
+
+	if err := s.Repo.Create(ctx, share); err != nil {
+		log.Error("failed to save share", sl.Err(err))
+		return nil, "", fmt.Errorf("failed to save share: %w", err)
+	}
+
+	log.Info("share successfully created", slog.String("share_id", share.ID.String()))
+	return share, s.Token(share.ID), nil
+}
+
+// Open returns what the token links to: the song, or the smart playlist with
+// a page of its songs, newest first. The content is read in the library and
+// as the user the share was issued in and by.
+func (s *ShareService) Open(ctx context.Context, token string, page, pageSize int) (*domain.SharedContent, error) {
+	const op = "ShareService.Open"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	id, ok := s.verify(token)
+	if !ok {
+		log.Warn("invalid share token")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrShareNotFound)
+	}
+	log = log.With(slog.String("share_id", id.String()))
+
+	log.Info("attempting to open share")
+
+	share, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrShareNotFound) {
+			log.Warn("share not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: share not found: %w", op, domain.ErrShareNotFound)
+		}
+		log.Error("failed to read share", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read share: %w", op, err)
+	}
+	if !share.Active(time.Now()) {
+		log.Info("share is revoked or expired")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrShareExpired)
+	}
+
+	ctx = domain.WithLibraryID(ctx, share.LibraryID)
+	content := &domain.SharedContent{Share: share}
+
+	if share.SongID != nil {
+		content.Song, err = s.Songs.Read(ctx, &domain.SongInfo{ID: *share.SongID})
+		if err != nil {
+			log.Error("failed to read shared song", sl.Err(err))
+			return nil, fmt.Errorf("%s: failed to read shared song: %w", op, err)
+		}
+		log.Info("shared song successfully opened")
+		return content, nil
+	}
+
+	content.Playlist, err = s.Playlists.Read(ctx, share.UserID, *share.PlaylistID)
+	if err != nil {
+		log.Error("failed to read shared smart playlist", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read shared smart playlist: %w", op, err)
+	}
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	content.Songs, err = s.SongRepo.ReadAllWithFilter(ctx, content.Playlist.Filter.SongFilter(), pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch shared smart playlist songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch shared smart playlist songs: %w", op, err)
+	}
+
+	log.Info("shared smart playlist successfully opened", slog.Int("count", len(content.Songs)))
+	return content, nil
+}
+
+// GetAll retrieves the shares of the user, revoked and expired ones included.
+func (s *ShareService) GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error) {
+	const op = "ShareService.GetAll"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx), slog.String("user_id", userID.String()))
+
+	log.Info("attempting to fetch shares")
+
+	shares, err := s.Repo.ReadAll(ctx, userID)
+	if err != nil {
+		log.Error("failed to fetch shares", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch shares: %w", op, err)
+	}
+
+	log.Info("shares successfully fetched", slog.Int("count", len(shares)))
+	return shares, nil
+}
+
+// Revoke stops a share of the user from being opened, revoked shares are
+// kept so they can still be listed.
+func (s *ShareService) Revoke(ctx context.Context, userID, id uuid.UUID) (*domain.Share, error) {
+	const op = "ShareService.Revoke"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", userID.String()),
+		slog.String("share_id", id.String()),
+	)
+
+	log.Info("attempting to revoke share")
+
+	share, err := s.Repo.Revoke(ctx, userID, id, time.Now())
+	if err != nil {
+		if errors.Is(err, domain.ErrShareNotFound) {
+			log.Warn("share not found during revocation", sl.Err(err))
+			return nil, fmt.Errorf("%s: share not found: %w", op, domain.ErrShareNotFound)
+		}
+		log.Error("failed to revoke share", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to revoke share: %w", op, err)
+	}
+
+	log.Info("share successfully revoked")
+	return share, nil
+}
+
+// Token returns the token of the share link: the ID and its signature
+func (s *ShareService) Token(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:]) + "." + base64.RawURLEncoding.EncodeToString(s.sign(id))
+}
+
+// verify returns the share ID of a token signed with the secret
+func (s *ShareService) verify(token string) (uuid.UUID, bool) {
+	rawID, rawSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, false
+	}
+	idBytes, err := base64.RawURLEncoding.DecodeString(rawID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	id, err := uuid.FromBytes(idBytes)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(rawSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(id)) {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (s *ShareService) sign(id uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write(id[:])
+	return mac.Sum(nil)
+}
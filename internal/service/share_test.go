@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shareMocks struct {
+	repo      *mocks.MockShareRepository
+	songs     *mocks.MockSongReader
+	songList  *mocks.MockSongLister
+	playlists *mocks.MockPlaylistRepository
+}
+
+func newShareService(t *testing.T) (*service.ShareService, shareMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := shareMocks{
+		repo:      mocks.NewMockShareRepository(ctrl),
+		songs:     mocks.NewMockSongReader(ctrl),
+		songList:  mocks.NewMockSongLister(ctrl),
+		playlists: mocks.NewMockPlaylistRepository(ctrl),
+	}
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	s := service.NewShareService(m.repo, m.songs, m.songList, m.playlists, []byte("secret"), 24*time.Hour, 7*24*time.Hour, mockLog)
+	return s, m
+}
+
+func TestShareService_ShareSong(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	userID, songID := uuid.New(), uuid.New()
+	m.songs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, share *domain.Share) error {
+		share.ID = uuid.New()
+		return nil
+	})
+
+	share, token, err := shareService.ShareSong(context.Background(), userID, songID, 0)
+	require.NoError(t, err)
+
+	// Без срока ссылка действует срок по умолчанию
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), share.ExpiresAt, time.Minute)
+	assert.Equal(t, songID, *share.SongID)
+	assert.Nil(t, share.PlaylistID)
+	assert.Equal(t, shareService.Token(share.ID), token)
+}
+
+func TestShareService_ShareSong_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		read error
+		err  error
+	}{
+		{name: "песня не найдена", read: domain.ErrSongNotFound, err: domain.ErrSongNotFound},
+		{name: "срок больше максимального", ttl: 30 * 24 * time.Hour, err: domain.ErrShareTTLInvalid},
+		{name: "отрицательный срок", ttl: -time.Hour, err: domain.ErrShareTTLInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shareService, m := newShareService(t)
+			m.songs.EXPECT().Read(gomock.Any(), gomock.Any()).Return(&domain.Song{}, tt.read)
+
+			_, _, err := shareService.ShareSong(context.Background(), uuid.New(), uuid.New(), tt.ttl)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestShareService_SharePlaylist_NotFound(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	userID, playlistID := uuid.New(), uuid.New()
+	m.playlists.EXPECT().Read(gomock.Any(), userID, playlistID).Return(nil, domain.ErrPlaylistNotFound)
+
+	_, _, err := shareService.SharePlaylist(context.Background(), userID, playlistID, time.Hour)
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+}
+
+func TestShareService_Open_Song(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	libraryID, songID := uuid.New(), uuid.New()
+	share := &domain.Share{ID: uuid.New(), LibraryID: libraryID, SongID: &songID, ExpiresAt: time.Now().Add(time.Hour)}
+	m.repo.EXPECT().Read(gomock.Any(), share.ID).Return(share, nil)
+	// Песня читается в библиотеке ссылки, а не запроса
+	m.songs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).DoAndReturn(func(ctx context.Context, _ *domain.SongInfo) (*domain.Song, error) {
+		assert.Equal(t, libraryID, domain.LibraryIDFromContext(ctx))
+		return &domain.Song{ID: songID, Name: "Hysteria"}, nil
+	})
+
+	content, err := shareService.Open(context.Background(), shareService.Token(share.ID), 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Hysteria", content.Song.Name)
+	assert.Nil(t, content.Playlist)
+}
+
+func TestShareService_Open_Playlist(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	userID, playlistID := uuid.New(), uuid.New()
+	share := &domain.Share{ID: uuid.New(), UserID: userID, PlaylistID: &playlistID, ExpiresAt: time.Now().Add(time.Hour)}
+	playlist := &domain.SmartPlaylist{ID: playlistID, UserID: userID, Name: "Muse", Filter: domain.PlaylistFilter{Group: "muse"}}
+	songs := []*domain.Song{{Name: "Hysteria"}}
+	m.repo.EXPECT().Read(gomock.Any(), share.ID).Return(share, nil)
+	m.playlists.EXPECT().Read(gomock.Any(), userID, playlistID).Return(playlist, nil)
+	m.songList.EXPECT().ReadAllWithFilter(gomock.Any(), playlist.Filter.SongFilter(), 10, 10).Return(songs, nil)
+
+	content, err := shareService.Open(context.Background(), shareService.Token(share.ID), 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, playlist, content.Playlist)
+	assert.Equal(t, songs, content.Songs)
+}
+
+func TestShareService_Open_Invalid(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	id := uuid.New()
+	revokedAt := time.Now()
+	other := service.NewShareService(nil, nil, nil, nil, []byte("other"), time.Hour, time.Hour, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Токен с чужой подписью или испорченный не принимается без запроса к базе
+	for _, token := range []string{"", "bad", "bad.token", other.Token(id), shareService.Token(id) + "x"} {
+		_, err := shareService.Open(context.Background(), token, 0, 0)
+		assert.ErrorIs(t, err, domain.ErrShareNotFound, token)
+	}
+
+	m.repo.EXPECT().Read(gomock.Any(), id).Return(&domain.Share{ID: id, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+	_, err := shareService.Open(context.Background(), shareService.Token(id), 0, 0)
+	assert.ErrorIs(t, err, domain.ErrShareExpired)
+
+	m.repo.EXPECT().Read(gomock.Any(), id).Return(&domain.Share{ID: id, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	_, err = shareService.Open(context.Background(), shareService.Token(id), 0, 0)
+	assert.ErrorIs(t, err, domain.ErrShareExpired)
+}
+
+func TestShareService_Revoke_NotFound(t *testing.T) {
+	shareService, m := newShareService(t)
+
+	userID, id := uuid.New(), uuid.New()
+	m.repo.EXPECT().Revoke(gomock.Any(), userID, id, gomock.Any()).Return(nil, domain.ErrShareNotFound)
+
+	_, err := shareService.Revoke(context.Background(), userID, id)
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+)
+
+// Limits of Similar, a "you may also like" list needs only a few songs
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// SimilarRepository ranks the songs of the library by how alike they are to
+// a song. The database compares lyrics and metadata, another implementation
+// may compare embeddings.
+type SimilarRepository interface {
+	ReadSimilar(ctx context.Context, song *domain.Song, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error)
+}
+
+type SimilarService struct {
+	Repo  SimilarRepository
+	Songs SongReader
+	log   *slog.Logger
+}
+
+func NewSimilarService(r SimilarRepository, songs SongReader, log *slog.Logger) *SimilarService {
+	return &SimilarService{
+		Repo:  r,
+		Songs: songs,
+		log:   log,
+	}
+}
+
+// Similar returns up to limit songs most like the song, most alike first. A
+// zero limit selects the default and larger limits are cut to the maximum,
+// excludeExplicit drops the songs flagged explicit.
+func (s *SimilarService) Similar(ctx context.Context, songInfo *domain.SongInfo, excludeExplicit bool, limit int) ([]*domain.SimilarSong, error) {
+	const op = "SimilarService.Similar"
+
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	limit = min(limit, maxSimilarLimit)
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songInfo.ID.String()),
+		slog.Bool("exclude_explicit", excludeExplicit),
+		slog.Int("limit", limit),
+	)
+
+	song, err := s.Songs.Read(ctx, songInfo)
+	if err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	similar, err := s.Repo.ReadSimilar(ctx, song, excludeExplicit, limit)
+	if err != nil {
+		log.Error("failed to fetch similar songs", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch similar songs: %w", op, err)
+	}
+
+	log.Debug("similar songs successfully fetched", slog.Int("count", len(similar)))
+	return similar, nil
+}
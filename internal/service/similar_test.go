@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarService_Similar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSimilarRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	similarService := service.NewSimilarService(mockRepo, mockSongs, mockLog)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	song := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse"}
+	similar := []*domain.SimilarSong{{Song: &domain.Song{Name: "Starlight", Group: "Muse"}, Score: 0.4}}
+
+	// Без limit берётся значение по умолчанию, большие значения обрезаются
+	mockSongs.EXPECT().Read(gomock.Any(), songInfo).Return(song, nil).Times(2)
+	mockRepo.EXPECT().ReadSimilar(gomock.Any(), song, true, 10).Return(similar, nil)
+	mockRepo.EXPECT().ReadSimilar(gomock.Any(), song, false, 50).Return(nil, nil)
+
+	result, err := similarService.Similar(context.Background(), songInfo, true, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, similar, result)
+
+	_, err = similarService.Similar(context.Background(), songInfo, false, 1000)
+	assert.NoError(t, err)
+}
+
+func TestSimilarService_Similar_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	similarService := service.NewSimilarService(mocks.NewMockSimilarRepository(ctrl), mockSongs, mockLog)
+
+	mockSongs.EXPECT().Read(gomock.Any(), gomock.Any()).Return(nil, domain.ErrSongNotFound)
+
+	_, err := similarService.Similar(context.Background(), &domain.SongInfo{ID: uuid.New()}, false, 0)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
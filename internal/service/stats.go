@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+type StatsRepository interface {
+	Read(ctx context.Context, since time.Time) (*domain.LibraryStats, error)
+}
+
+type StatsService struct {
+	Repo StatsRepository
+	// Now returns the current time, days and weeks are counted in UTC and
+	// weeks start on Monday
+	Now func() time.Time
+	log *slog.Logger
+
+	days  int
+	weeks int
+}
+
+// NewStatsService creates a StatsService counting the songs added in each of
+// the last days days and weeks weeks, the current ones included
+func NewStatsService(r StatsRepository, days, weeks int, log *slog.Logger) *StatsService {
+	return &StatsService{
+		Repo:  r,
+		Now:   time.Now,
+		log:   log,
+		days:  days,
+		weeks: weeks,
+	}
+}
+
+// Get returns the library statistics. Days and weeks without new songs are
+// included with a count of zero.
+func (s *StatsService) Get(ctx context.Context) (*domain.LibraryStats, error) {
+	const op = "StatsService.Get"
+
+	now := s.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisWeek := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	firstDay := today.AddDate(0, 0, -(s.days - 1))
+	firstWeek := thisWeek.AddDate(0, 0, -7*(s.weeks-1))
+	since := firstDay
+	if firstWeek.Before(since) {
+		since = firstWeek
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Time("since", since),
+	)
+
+	stats, err := s.Repo.Read(ctx, since)
+	if err != nil {
+		log.Error("failed to fetch library stats", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch library stats: %w", op, err)
+	}
+
+	added := make(map[time.Time]int, len(stats.AddedPerDay))
+	for _, day := range stats.AddedPerDay {
+		added[day.Date.UTC()] = day.Songs
+	}
+
+	result := *stats
+	result.AddedPerDay = make([]domain.DateCount, 0, s.days)
+	for day := firstDay; !day.After(today); day = day.AddDate(0, 0, 1) {
+		result.AddedPerDay = append(result.AddedPerDay, domain.DateCount{Date: day, Songs: added[day]})
+	}
+
+	result.AddedPerWeek = make([]domain.DateCount, 0, s.weeks)
+	for week := firstWeek; !week.After(thisWeek); week = week.AddDate(0, 0, 7) {
+		count := domain.DateCount{Date: week}
+		for day := range 7 {
+			count.Songs += added[week.AddDate(0, 0, day)]
+		}
+		result.AddedPerWeek = append(result.AddedPerWeek, count)
+	}
+
+	log.Debug("library stats successfully fetched", slog.Int("songs", result.Songs))
+	return &result, nil
+}
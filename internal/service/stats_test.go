@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStatsService_Get_FillsDaysAndWeeks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStatsRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	statsService := service.NewStatsService(mockRepo, 3, 2, mockLog)
+	// Среда, 8 мая: текущая неделя началась в понедельник 6 мая
+	statsService.Now = func() time.Time {
+		return time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+	}
+
+	// Запрашиваются дни с начала самой ранней недели, пропуски заполняются нулями
+	mockRepo.EXPECT().Read(gomock.Any(), date(4, 29)).Return(&domain.LibraryStats{
+		Songs:  10,
+		Groups: 4,
+		AddedPerDay: []domain.DateCount{
+			{Date: date(4, 30), Songs: 2},
+			{Date: date(5, 6), Songs: 1},
+			{Date: date(5, 8), Songs: 3},
+		},
+	}, nil)
+
+	stats, err := statsService.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stats.Songs)
+	assert.Equal(t, 4, stats.Groups)
+	assert.Equal(t, []domain.DateCount{
+		{Date: date(5, 6), Songs: 1},
+		{Date: date(5, 7), Songs: 0},
+		{Date: date(5, 8), Songs: 3},
+	}, stats.AddedPerDay)
+	assert.Equal(t, []domain.DateCount{
+		{Date: date(4, 29), Songs: 2},
+		{Date: date(5, 6), Songs: 4},
+	}, stats.AddedPerWeek)
+}
+
+func TestStatsService_Get_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStatsRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	statsService := service.NewStatsService(mockRepo, 30, 12, mockLog)
+
+	dbErr := errors.New("connection refused")
+	mockRepo.EXPECT().Read(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+	_, err := statsService.Get(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+// SetStatus moves a song to another lifecycle status, a song that already
+// has it is returned unchanged
+func (s *Service) SetStatus(ctx context.Context, songInfo *domain.SongInfo, status domain.SongStatus) (*domain.Song, error) {
+	const op = "Service.SetStatus"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songInfo.ID.String()),
+		slog.String("status", string(status)),
+	)
+
+	if !status.Valid() {
+		log.Warn("invalid song status")
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrInvalidSongStatus)
+	}
+
+	current, err := s.Get(ctx, songInfo)
+	if err != nil {
+		log.Error("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.saveStatus(ctx, log, op, songInfo, current, status)
+}
+
+// saveStatus moves the current song to status unless it already has it
+func (s *Service) saveStatus(ctx context.Context, log *slog.Logger, op string, songInfo *domain.SongInfo, current *domain.Song, status domain.SongStatus) (*domain.Song, error) {
+	if current.Status == status {
+		log.Debug("song already has the status")
+		return current, nil
+	}
+
+	updated := *current
+	updated.Status = status
+	updated.UpdatedAt = time.Now()
+
+	if err := s.Repo.Update(ctx, songInfo, &updated); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found during status change", sl.Err(err))
+			return nil, fmt.Errorf("%s: song not found: %w", op, domain.ErrSongNotFound)
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("song was updated concurrently", slog.Int("version", updated.Version), sl.Err(err))
+			return nil, fmt.Errorf("%s: stale song version: %w", op, domain.ErrVersionConflict)
+		}
+		log.Error("failed to save song status", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to save song status: %w", op, err)
+	}
+
+	log.Info("song status changed", slog.String("old_status", string(current.Status)), slog.String("new_status", string(status)))
+	return &updated, nil
+}
+
+// Archive hides a song from listings and search without deleting it
+func (s *Service) Archive(ctx context.Context, songInfo *domain.SongInfo) (*domain.Song, error) {
+	const op = "Service.Archive"
+
+	song, err := s.SetStatus(ctx, songInfo, domain.SongStatusArchived)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return song, nil
+}
+
+// Unarchive brings an archived song back. It becomes enriched, or pending
+// if it has no text yet, in which case its details are fetched again when
+// Pending is set. Songs that aren't archived are returned unchanged.
+func (s *Service) Unarchive(ctx context.Context, songInfo *domain.SongInfo) (*domain.Song, error) {
+	const op = "Service.Unarchive"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songInfo.ID.String()),
+	)
+
+	current, err := s.Get(ctx, songInfo)
+	if err != nil {
+		log.Error("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if current.Status != domain.SongStatusArchived {
+		log.Debug("song is not archived")
+		return current, nil
+	}
+
+	status := domain.SongStatusEnriched
+	if current.Text == "" {
+		status = domain.SongStatusPending
+	}
+
+	song, err := s.saveStatus(ctx, log, op, songInfo, current, status)
+	if err != nil {
+		return nil, err
+	}
+	if song.Status == domain.SongStatusPending && s.Pending != nil {
+		s.Pending.Schedule(ctx, song.ID)
+	}
+	return song, nil
+}
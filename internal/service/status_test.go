@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"songLibrary/internal/domain"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetStatus(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Version: 3, Status: domain.SongStatusPending}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SongInfo, updated *domain.Song) error {
+			// Меняется только статус, версия берётся прочитанная
+			assert.Equal(t, domain.SongStatusFailed, updated.Status)
+			assert.Equal(t, "Hysteria", updated.Name)
+			assert.Equal(t, 3, updated.Version)
+			return nil
+		})
+
+	song, err := svc.SetStatus(context.Background(), songInfo, domain.SongStatusFailed)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SongStatusFailed, song.Status)
+	assert.Equal(t, domain.SongStatusPending, current.Status)
+}
+
+func TestService_SetStatus_Unchanged(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Status: domain.SongStatusEnriched}
+
+	// Песня уже в этом статусе, сохранять нечего
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+
+	song, err := svc.SetStatus(context.Background(), songInfo, domain.SongStatusEnriched)
+	require.NoError(t, err)
+	assert.Same(t, current, song)
+}
+
+func TestService_SetStatus_Invalid(t *testing.T) {
+	svc, _, _ := newRefreshService(t)
+
+	_, err := svc.SetStatus(context.Background(), &domain.SongInfo{ID: uuid.New()}, "deleted")
+	assert.ErrorIs(t, err, domain.ErrInvalidSongStatus)
+}
+
+func TestService_Archive(t *testing.T) {
+	svc, mockRepo, _ := newRefreshService(t)
+
+	songInfo := &domain.SongInfo{ID: uuid.New()}
+	current := &domain.Song{ID: songInfo.ID, Name: "Hysteria", Group: "Muse", Text: "It's bugging me", Version: 1, Status: domain.SongStatusEnriched}
+
+	mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+
+	song, err := svc.Archive(context.Background(), songInfo)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SongStatusArchived, song.Status)
+}
+
+func TestService_Unarchive(t *testing.T) {
+	tests := []struct {
+		name string
+		song *domain.Song
+		want domain.SongStatus
+		save bool
+	}{
+		{name: "с текстом", song: &domain.Song{Text: "It's bugging me", Status: domain.SongStatusArchived}, want: domain.SongStatusEnriched, save: true},
+		{name: "без текста", song: &domain.Song{Status: domain.SongStatusArchived}, want: domain.SongStatusPending, save: true},
+		{name: "не в архиве", song: &domain.Song{Status: domain.SongStatusFailed}, want: domain.SongStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo, _ := newRefreshService(t)
+
+			songInfo := &domain.SongInfo{ID: uuid.New()}
+			tt.song.ID = songInfo.ID
+			mockRepo.EXPECT().Read(gomock.Any(), songInfo).Return(tt.song, nil)
+			if tt.save {
+				mockRepo.EXPECT().Update(gomock.Any(), songInfo, gomock.Any()).Return(nil)
+			}
+
+			song, err := svc.Unarchive(context.Background(), songInfo)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, song.Status)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"strings"
+)
+
+type SuggestionRepository interface {
+	Read(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error)
+}
+
+type SuggestService struct {
+	Repo SuggestionRepository
+	log  *slog.Logger
+
+	limit    int
+	maxLimit int
+}
+
+// NewSuggestService creates a SuggestService, limit is used by Suggest when
+// the caller doesn't specify one and larger limits are cut to maxLimit.
+func NewSuggestService(r SuggestionRepository, limit, maxLimit int, log *slog.Logger) *SuggestService {
+	return &SuggestService{
+		Repo:     r,
+		log:      log,
+		limit:    limit,
+		maxLimit: maxLimit,
+	}
+}
+
+// Suggest returns song and group names matching the typed query. The query
+// is lower-cased and its spaces collapsed, so variants share cached results.
+func (s *SuggestService) Suggest(ctx context.Context, query string, limit int) ([]*domain.Suggestion, error) {
+	const op = "SuggestService.Suggest"
+
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if limit <= 0 {
+		limit = s.limit
+	}
+	limit = min(limit, s.maxLimit)
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("query", query),
+		slog.Int("limit", limit),
+	)
+
+	if query == "" {
+		log.Debug("empty query, nothing to suggest")
+		return nil, nil
+	}
+
+	suggestions, err := s.Repo.Read(ctx, query, limit)
+	if err != nil {
+		log.Error("failed to fetch suggestions", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch suggestions: %w", op, err)
+	}
+
+	log.Debug("suggestions successfully fetched", slog.Int("count", len(suggestions)))
+	return suggestions, nil
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestService_Suggest_NormalizesQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSuggestionRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	suggestService := service.NewSuggestService(mockRepo, 10, 50, mockLog)
+
+	// Регистр и лишние пробелы не влияют на запрос, лимит по умолчанию из конфига
+	suggestions := []*domain.Suggestion{{Kind: domain.SuggestionGroup, Text: "Muse", Score: 1}}
+	mockRepo.EXPECT().Read(gomock.Any(), "muse h", 10).Return(suggestions, nil)
+
+	result, err := suggestService.Suggest(context.Background(), "  Muse   H ", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, suggestions, result)
+}
+
+func TestSuggestService_Suggest_CapsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSuggestionRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	suggestService := service.NewSuggestService(mockRepo, 10, 50, mockLog)
+
+	mockRepo.EXPECT().Read(gomock.Any(), "hy", 50).Return(nil, nil)
+
+	_, err := suggestService.Suggest(context.Background(), "hy", 100)
+	assert.NoError(t, err)
+}
+
+func TestSuggestService_Suggest_EmptyQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSuggestionRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	suggestService := service.NewSuggestService(mockRepo, 10, 50, mockLog)
+
+	// Пустой запрос не доходит до репозитория
+	result, err := suggestService.Suggest(context.Background(), "   ", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestSuggestService_Suggest_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSuggestionRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	suggestService := service.NewSuggestService(mockRepo, 10, 50, mockLog)
+
+	dbErr := errors.New("connection refused")
+	mockRepo.EXPECT().Read(gomock.Any(), "hy", 10).Return(nil, dbErr)
+
+	_, err := suggestService.Suggest(context.Background(), "hy", 0)
+	assert.ErrorIs(t, err, dbErr)
+}
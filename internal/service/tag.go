@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type TagRepository interface {
+	Add(ctx context.Context, songID uuid.UUID, tags []string) error
+	Remove(ctx context.Context, songID uuid.UUID, tag string) error
+	ReadSongTags(ctx context.Context, songID uuid.UUID) ([]string, error)
+	ReadAll(ctx context.Context, limit, offset int) ([]*domain.Tag, error)
+}
+
+type TagService struct {
+	Repo  TagRepository
+	Songs SongReader
+	log   *slog.Logger
+}
+
+func NewTagService(r TagRepository, songs SongReader, log *slog.Logger) *TagService {
+	return &TagService{
+		Repo:  r,
+		Songs: songs,
+		log:   log,
+	}
+}
+
+// Add attaches tags to a song and returns all tags of the song.
+func (s *TagService) Add(ctx context.Context, songID uuid.UUID, tags []string) ([]string, error) {
+	const op = "TagService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	normalized, err := domain.NormalizeTags(tags)
+	if err != nil || len(normalized) == 0 {
+		log.Warn("invalid tags", slog.Any("tags", tags))
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrInvalidTag)
+	}
+
+	log.Info("attempting to add tags", slog.Any("tags", normalized))
+
+	if err := s.Repo.Add(ctx, songID, normalized); err != nil {
+		if errors.Is(err, domain.ErrSongNotFound) {
+			log.Warn("song not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrSongNotFound)
+		}
+		log.Error("failed to add tags", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to add tags: %w", op, err)
+	}
+
+	songTags, err := s.Repo.ReadSongTags(ctx, songID)
+	if err != nil {
+		log.Error("failed to fetch song tags", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song tags: %w", op, err)
+	}
+
+	log.Info("tags successfully added")
+	return songTags, nil
+}
+
+// Remove detaches a tag from a song and returns the remaining tags of the song.
+func (s *TagService) Remove(ctx context.Context, songID uuid.UUID, tag string) ([]string, error) {
+	const op = "TagService.Remove"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+		slog.String("tag", tag),
+	)
+
+	tag, err := domain.NormalizeTag(tag)
+	if err != nil {
+		log.Warn("invalid tag")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to remove tag")
+
+	if err := s.Repo.Remove(ctx, songID, tag); err != nil {
+		log.Error("failed to remove tag", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to remove tag: %w", op, err)
+	}
+
+	songTags, err := s.Repo.ReadSongTags(ctx, songID)
+	if err != nil {
+		log.Error("failed to fetch song tags", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song tags: %w", op, err)
+	}
+
+	log.Info("tag successfully removed")
+	return songTags, nil
+}
+
+// GetSongTags returns the tags of a song in alphabetical order.
+func (s *TagService) GetSongTags(ctx context.Context, songID uuid.UUID) ([]string, error) {
+	const op = "TagService.GetSongTags"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("song_id", songID.String()),
+	)
+
+	if _, err := s.Songs.Read(ctx, &domain.SongInfo{ID: songID}); err != nil {
+		log.Warn("failed to fetch song", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tags, err := s.Repo.ReadSongTags(ctx, songID)
+	if err != nil {
+		log.Error("failed to fetch song tags", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch song tags: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// GetAll returns the tags with their usage counts, most used first.
+func (s *TagService) GetAll(ctx context.Context, page, pageSize int) ([]*domain.Tag, error) {
+	const op = "TagService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Int("page", page),
+		slog.Int("pageSize", pageSize),
+	)
+
+	offset := 0
+	if page > 0 {
+		offset = (page - 1) * pageSize
+	}
+	log.Info("attempting to fetch tags", slog.Int("offset", offset))
+
+	tags, err := s.Repo.ReadAll(ctx, pageSize, offset)
+	if err != nil {
+		log.Error("failed to fetch tags", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch tags: %w", op, err)
+	}
+
+	log.Info("tags successfully fetched", slog.Int("count", len(tags)))
+	return tags, nil
+}
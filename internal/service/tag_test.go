@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTagService(t *testing.T) (*service.TagService, *mocks.MockTagRepository, *mocks.MockSongReader) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	mockSongs := mocks.NewMockSongReader(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	return service.NewTagService(mockRepo, mockSongs, mockLog), mockRepo, mockSongs
+}
+
+func TestTagService_Add(t *testing.T) {
+	tagService, mockRepo, _ := newTagService(t)
+
+	// Теги приводятся к нижнему регистру, повторы отбрасываются
+	songID := uuid.New()
+	mockRepo.EXPECT().Add(gomock.Any(), songID, []string{"rock", "live set"}).Return(nil)
+	mockRepo.EXPECT().ReadSongTags(gomock.Any(), songID).Return([]string{"live set", "rock"}, nil)
+
+	tags, err := tagService.Add(context.Background(), songID, []string{"Rock", "  live   Set ", "ROCK"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"live set", "rock"}, tags)
+}
+
+func TestTagService_Add_InvalidTag(t *testing.T) {
+	tagService, _, _ := newTagService(t)
+
+	for _, tags := range [][]string{nil, {"   "}, {"rock,live"}, {string(make([]rune, 51))}} {
+		_, err := tagService.Add(context.Background(), uuid.New(), tags)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag, "tags %q", tags)
+	}
+}
+
+func TestTagService_Add_SongNotFound(t *testing.T) {
+	tagService, mockRepo, _ := newTagService(t)
+
+	songID := uuid.New()
+	mockRepo.EXPECT().Add(gomock.Any(), songID, []string{"rock"}).Return(domain.ErrSongNotFound)
+
+	_, err := tagService.Add(context.Background(), songID, []string{"rock"})
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestTagService_Remove(t *testing.T) {
+	tagService, mockRepo, mockSongs := newTagService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(&domain.Song{ID: songID}, nil)
+	mockRepo.EXPECT().Remove(gomock.Any(), songID, "live").Return(nil)
+	mockRepo.EXPECT().ReadSongTags(gomock.Any(), songID).Return(nil, nil)
+
+	tags, err := tagService.Remove(context.Background(), songID, "Live")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestTagService_GetSongTags_SongNotFound(t *testing.T) {
+	tagService, _, mockSongs := newTagService(t)
+
+	songID := uuid.New()
+	mockSongs.EXPECT().Read(gomock.Any(), &domain.SongInfo{ID: songID}).Return(nil, domain.ErrSongNotFound)
+
+	_, err := tagService.GetSongTags(context.Background(), songID)
+	assert.ErrorIs(t, err, domain.ErrSongNotFound)
+}
+
+func TestTagService_GetAll(t *testing.T) {
+	tagService, mockRepo, _ := newTagService(t)
+
+	mockRepo.EXPECT().ReadAll(gomock.Any(), 10, 20).Return([]*domain.Tag{{Name: "rock", Songs: 3}}, nil)
+
+	tags, err := tagService.GetAll(context.Background(), 3, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Tag{{Name: "rock", Songs: 3}}, tags)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+type UserRepository interface {
+	Upsert(ctx context.Context, user *domain.User) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	ReadAll(ctx context.Context) ([]*domain.User, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type UserService struct {
+	Repo UserRepository
+	log  *slog.Logger
+}
+
+func NewUserService(r UserRepository, log *slog.Logger) *UserService {
+	return &UserService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// SetRole assigns the role to a user, replacing the previous one.
+func (s *UserService) SetRole(ctx context.Context, user *domain.User) error {
+	const op = "UserService.SetRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", user.ID.String()),
+		slog.String("role", string(user.Role)),
+	)
+
+	log.Info("attempting to set user role")
+
+	if !user.Role.Valid() {
+		log.Warn("unknown role")
+		return fmt.Errorf("%s: %w", op, domain.ErrInvalidRole)
+	}
+
+	if err := s.Repo.Upsert(ctx, user); err != nil {
+		log.Error("failed to save user role", sl.Err(err))
+		return fmt.Errorf("%s: failed to save user role: %w", op, err)
+	}
+
+	log.Info("user role successfully set")
+	return nil
+}
+
+// Get fetches the role of a user, ErrUserNotFound if it has none.
+func (s *UserService) Get(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	const op = "UserService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", id.String()),
+	)
+
+	user, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+		}
+		log.Error("failed to read user", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read user: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// GetAll retrieves the users with a role.
+func (s *UserService) GetAll(ctx context.Context) ([]*domain.User, error) {
+	const op = "UserService.GetAll"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+	)
+
+	log.Info("attempting to fetch users")
+
+	users, err := s.Repo.ReadAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch users", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch users: %w", op, err)
+	}
+
+	log.Info("users successfully fetched", slog.Int("count", len(users)))
+	return users, nil
+}
+
+// Delete removes the role of a user, the user gets the default role.
+func (s *UserService) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "UserService.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("user_id", id.String()),
+	)
+
+	log.Info("attempting to delete user")
+
+	if err := s.Repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("user not found during deletion", sl.Err(err))
+			return fmt.Errorf("%s: user not found: %w", op, domain.ErrUserNotFound)
+		}
+		log.Error("failed to delete user", sl.Err(err))
+		return fmt.Errorf("%s: failed to delete user: %w", op, err)
+	}
+
+	log.Info("user successfully deleted")
+	return nil
+}
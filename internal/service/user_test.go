@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_SetRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	userService := service.NewUserService(mockRepo, mockLog)
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleEditor}
+	mockRepo.EXPECT().Upsert(gomock.Any(), user).Return(nil)
+
+	assert.NoError(t, userService.SetRole(context.Background(), user))
+}
+
+func TestUserService_SetRole_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	userService := service.NewUserService(mockRepo, mockLog)
+
+	// Неизвестная роль не сохраняется
+	err := userService.SetRole(context.Background(), &domain.User{ID: uuid.New(), Role: "owner"})
+	assert.ErrorIs(t, err, domain.ErrInvalidRole)
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"songLibrary/internal/domain"
+	"songLibrary/pkg/logger/sl"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// secretSize is the number of random bytes in a generated webhook secret
+const secretSize = 32
+
+type WebhookRepository interface {
+	Create(ctx context.Context, hook *domain.Webhook) error
+	Read(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ReadAll(ctx context.Context) ([]*domain.Webhook, error)
+	ReadForEvent(ctx context.Context, eventType domain.SongEventType) ([]*domain.Webhook, error)
+	Update(ctx context.Context, hook *domain.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookSender delivers a single event to a webhook
+type WebhookSender interface {
+	Send(ctx context.Context, hook *domain.Webhook, event domain.SongEvent) error
+}
+
+type WebhookService struct {
+	Repo WebhookRepository
+	log  *slog.Logger
+}
+
+func NewWebhookService(r WebhookRepository, log *slog.Logger) *WebhookService {
+	return &WebhookService{
+		Repo: r,
+		log:  log,
+	}
+}
+
+// Add registers a new webhook, a secret is generated when none is given.
+func (s *WebhookService) Add(ctx context.Context, hook *domain.Webhook) error {
+	const op = "WebhookService.Add"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("url", hook.URL),
+	)
+
+	log.Info("attempting to add a new webhook")
+
+	if err := validateWebhook(hook); err != nil {
+		log.Warn("invalid webhook", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if hook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			log.Error("failed to generate webhook secret", sl.Err(err))
+			return fmt.Errorf("%s: failed to generate secret: %w", op, err)
+		}
+		hook.Secret = secret
+	}
+
+	if err := s.Repo.Create(ctx, hook); err != nil {
+		log.Error("failed to save webhook", sl.Err(err))
+		return fmt.Errorf("%s: failed to save webhook: %w", op, err)
+	}
+
+	log.Info("webhook successfully added", slog.String("webhook_id", hook.ID.String()))
+	return nil
+}
+
+// Get fetches a webhook by ID.
+func (s *WebhookService) Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "WebhookService.Get"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("webhook_id", id.String()),
+	)
+
+	log.Info("attempting to fetch webhook")
+
+	hook, err := s.Repo.Read(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Warn("webhook not found", sl.Err(err))
+			return nil, fmt.Errorf("%s: webhook not found: %w", op, domain.ErrWebhookNotFound)
+		}
+		log.Error("failed to read webhook", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to read webhook: %w", op, err)
+	}
+
+	log.Info("webhook successfully fetched")
+	return hook, nil
+}
+
+// GetAll retrieves all registered webhooks.
+func (s *WebhookService) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	const op = "WebhookService.GetAll"
+
+	log := s.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Info("attempting to fetch webhooks")
+
+	hooks, err := s.Repo.ReadAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch webhooks", sl.Err(err))
+		return nil, fmt.Errorf("%s: failed to fetch webhooks: %w", op, err)
+	}
+
+	log.Info("webhooks successfully fetched", slog.Int("count", len(hooks)))
+	return hooks, nil
+}
+
+// Update changes an existing webhook. Empty URL and secret keep their current
+// values, a nil Events list keeps the current subscription.
+func (s *WebhookService) Update(ctx context.Context, updatedHook *domain.Webhook) error {
+	const op = "WebhookService.Update"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("webhook_id", updatedHook.ID.String()),
+	)
+
+	log.Info("attempting to update webhook")
+
+	targetHook, err := s.Get(ctx, updatedHook.ID)
+	if err != nil {
+		log.Error("failed to fetch webhook", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	mergedHook := mergeWebhooks(updatedHook, targetHook)
+
+	if err := validateWebhook(mergedHook); err != nil {
+		log.Warn("invalid webhook", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.Repo.Update(ctx, mergedHook); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Warn("webhook not found during update", sl.Err(err))
+			return fmt.Errorf("%s: webhook not found: %w", op, domain.ErrWebhookNotFound)
+		}
+		log.Error("failed to update webhook", sl.Err(err))
+		return fmt.Errorf("%s: failed to update webhook: %w", op, err)
+	}
+
+	log.Info("webhook successfully updated")
+	return nil
+}
+
+// Delete removes a webhook.
+func (s *WebhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "WebhookService.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("webhook_id", id.String()),
+	)
+
+	log.Info("attempting to delete webhook")
+
+	if err := s.Repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Warn("webhook not found during deletion", sl.Err(err))
+			return fmt.Errorf("%s: webhook not found: %w", op, domain.ErrWebhookNotFound)
+		}
+		log.Error("failed to delete webhook", sl.Err(err))
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+
+	log.Info("webhook successfully deleted")
+	return nil
+}
+
+func validateWebhook(hook *domain.Webhook) error {
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.ErrWebhookURLIsInvalid
+	}
+
+	for _, event := range hook.Events {
+		if !event.Valid() {
+			return domain.ErrWebhookEventIsInvalid
+		}
+	}
+
+	return nil
+}
+
+func mergeWebhooks(updatedHook, targetHook *domain.Webhook) *domain.Webhook {
+	if updatedHook.URL == "" {
+		updatedHook.URL = targetHook.URL
+	}
+	if updatedHook.Secret == "" {
+		updatedHook.Secret = targetHook.Secret
+	}
+	if updatedHook.Events == nil {
+		updatedHook.Events = targetHook.Events
+	}
+
+	return updatedHook
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// WebhookDispatcher delivers song events to the subscribed webhooks. Failed
+// deliveries are retried with exponential backoff, deliveries that still fail
+// are dead-lettered to the log.
+type WebhookDispatcher struct {
+	Repo   WebhookRepository
+	Sender WebhookSender
+
+	maxAttempts int
+	backoff     time.Duration
+	log         *slog.Logger
+
+	wg sync.WaitGroup
+}
+
+func NewWebhookDispatcher(r WebhookRepository, sender WebhookSender, maxAttempts int, backoff time.Duration, log *slog.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Repo:        r,
+		Sender:      sender,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		log:         log,
+	}
+}
+
+// Run delivers the events received from events until ctx is cancelled or
+// events is closed, then waits for the deliveries in flight.
+func (d *WebhookDispatcher) Run(ctx context.Context, events <-chan domain.SongEvent) {
+	const op = "WebhookDispatcher.Run"
+
+	log := d.log.With(slog.String("op", op), sl.RequestID(ctx))
+
+	log.Info("webhook dispatcher started")
+	defer func() {
+		d.wg.Wait()
+		log.Info("webhook dispatcher stopped")
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			d.dispatch(ctx, log, event)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context, log *slog.Logger, event domain.SongEvent) {
+	hooks, err := d.Repo.ReadForEvent(ctx, event.Type)
+	if err != nil {
+		log.Error("failed to fetch webhooks, event is not delivered",
+			slog.String("event", string(event.Type)),
+			slog.String("song_id", event.Song.ID.String()),
+			sl.Err(err),
+		)
+		return
+	}
+
+	for _, hook := range hooks {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(ctx, hook, event)
+		}()
+	}
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, hook *domain.Webhook, event domain.SongEvent) {
+	const op = "WebhookDispatcher.deliver"
+
+	log := d.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.String("webhook_id", hook.ID.String()),
+		slog.String("url", hook.URL),
+		slog.String("event", string(event.Type)),
+		slog.String("song_id", event.Song.ID.String()),
+	)
+
+	for attempt := 1; ; attempt++ {
+		err := d.Sender.Send(ctx, hook, event)
+		if err == nil {
+			log.Debug("webhook delivered", slog.Int("attempt", attempt))
+			return
+		}
+
+		log.Warn("webhook delivery attempt failed", slog.Int("attempt", attempt), sl.Err(err))
+		if attempt == d.maxAttempts {
+			deadLetter(log, event, attempt, err)
+			return
+		}
+
+		select {
+		case <-time.After(d.backoff << (attempt - 1)):
+		case <-ctx.Done():
+			deadLetter(log, event, attempt, ctx.Err())
+			return
+		}
+	}
+}
+
+func deadLetter(log *slog.Logger, event domain.SongEvent, attempts int, err error) {
+	log.Error("webhook delivery failed, event dead-lettered",
+		slog.Int("attempts", attempts),
+		slog.Time("occurred_at", event.OccurredAt),
+		sl.Err(err),
+	)
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/domain"
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService_Add_GeneratesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	webhookService := service.NewWebhookService(mockRepo, mockLog)
+
+	hook := &domain.Webhook{URL: "https://example.com/hooks", Events: []domain.SongEventType{domain.SongCreated}}
+	mockRepo.EXPECT().Create(gomock.Any(), hook).Return(nil)
+
+	err := webhookService.Add(context.Background(), hook)
+	assert.NoError(t, err)
+	assert.Len(t, hook.Secret, 64)
+}
+
+func TestWebhookService_Add_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	webhookService := service.NewWebhookService(mockRepo, mockLog)
+
+	tests := []struct {
+		name string
+		hook *domain.Webhook
+		err  error
+	}{
+		{"relative url", &domain.Webhook{URL: "/hooks"}, domain.ErrWebhookURLIsInvalid},
+		{"unsupported scheme", &domain.Webhook{URL: "ftp://example.com"}, domain.ErrWebhookURLIsInvalid},
+		{"unknown event", &domain.Webhook{URL: "https://example.com", Events: []domain.SongEventType{"song.played"}}, domain.ErrWebhookEventIsInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Некорректный вебхук не сохраняется
+			err := webhookService.Add(context.Background(), tt.hook)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestWebhookDispatcher_Run_RetriesUntilDelivered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	dispatcher := service.NewWebhookDispatcher(mockRepo, mockSender, 3, time.Millisecond, mockLog)
+
+	hook := &domain.Webhook{ID: uuid.New(), URL: "https://example.com/hooks"}
+	event := domain.SongEvent{Type: domain.SongCreated, Song: &domain.Song{ID: uuid.New()}}
+
+	mockRepo.EXPECT().ReadForEvent(gomock.Any(), domain.SongCreated).Return([]*domain.Webhook{hook}, nil)
+	gomock.InOrder(
+		mockSender.EXPECT().Send(gomock.Any(), hook, event).Return(errors.New("connection refused")),
+		mockSender.EXPECT().Send(gomock.Any(), hook, event).Return(nil),
+	)
+
+	events := make(chan domain.SongEvent, 1)
+	events <- event
+	close(events)
+
+	// Run дожидается завершения всех доставок
+	dispatcher.Run(context.Background(), events)
+}
+
+func TestWebhookDispatcher_Run_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+	mockLog := slog.New(slogdiscard.NewDiscardHandler())
+
+	dispatcher := service.NewWebhookDispatcher(mockRepo, mockSender, 3, time.Millisecond, mockLog)
+
+	hook := &domain.Webhook{ID: uuid.New(), URL: "https://example.com/hooks"}
+	event := domain.SongEvent{Type: domain.SongDeleted, Song: &domain.Song{ID: uuid.New()}}
+
+	mockRepo.EXPECT().ReadForEvent(gomock.Any(), domain.SongDeleted).Return([]*domain.Webhook{hook}, nil)
+	mockSender.EXPECT().Send(gomock.Any(), hook, event).Return(errors.New("status 500")).Times(3)
+
+	events := make(chan domain.SongEvent, 1)
+	events <- event
+	close(events)
+
+	dispatcher.Run(context.Background(), events)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"songLibrary/pkg/logger/sl"
+	"time"
+)
+
+type WriteBehindRepository interface {
+	FlushWrites(ctx context.Context, batchSize int) (int, error)
+}
+
+// WriteBehindFlusher moves the song writes queued by the write-behind cache
+// to the database
+type WriteBehindFlusher struct {
+	Repo      WriteBehindRepository
+	batchSize int
+	log       *slog.Logger
+}
+
+func NewWriteBehindFlusher(r WriteBehindRepository, batchSize int, log *slog.Logger) *WriteBehindFlusher {
+	return &WriteBehindFlusher{
+		Repo:      r,
+		batchSize: batchSize,
+		log:       log,
+	}
+}
+
+// Run replays the writes left in the queue by a previous run, then flushes
+// the queue every interval until ctx is cancelled and one last time after.
+func (f *WriteBehindFlusher) Run(ctx context.Context, interval time.Duration) {
+	const op = "WriteBehindFlusher.Run"
+
+	log := f.log.With(
+		slog.String("op", op),
+		sl.RequestID(ctx),
+		slog.Duration("interval", interval),
+	)
+
+	log.Info("write-behind flusher started")
+	f.flush(ctx, log)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.flush(ctx, log)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			f.flush(flushCtx, log)
+			cancel()
+
+			log.Info("write-behind flusher stopped")
+			return
+		}
+	}
+}
+
+func (f *WriteBehindFlusher) flush(ctx context.Context, log *slog.Logger) {
+	flushed, err := f.Repo.FlushWrites(ctx, f.batchSize)
+	if err != nil {
+		log.Error("failed to flush song writes", slog.Int("flushed", flushed), sl.Err(err))
+		return
+	}
+
+	if flushed > 0 {
+		log.Debug("song writes flushed", slog.Int("writes", flushed))
+	}
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"songLibrary/internal/service"
+	"songLibrary/internal/service/mocks"
+	"songLibrary/pkg/logger/handlers/slogdiscard"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestWriteBehindFlusher_Run_ReplaysAndFlushesOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWriteBehindRepository(ctrl)
+	flusher := service.NewWriteBehindFlusher(mockRepo, 100, slog.New(slogdiscard.NewDiscardHandler()))
+
+	// Очередь, оставшаяся с прошлого запуска, переносится сразу при старте,
+	// ошибка не останавливает перенос, при остановке очередь переносится ещё раз
+	replayed := make(chan struct{})
+	gomock.InOrder(
+		mockRepo.EXPECT().FlushWrites(gomock.Any(), 100).DoAndReturn(func(context.Context, int) (int, error) {
+			close(replayed)
+			return 0, errors.New("connection refused")
+		}),
+		mockRepo.EXPECT().FlushWrites(gomock.Any(), 100).Return(2, nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		flusher.Run(ctx, time.Hour)
+	}()
+
+	<-replayed
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("flusher did not stop")
+	}
+}
@@ -0,0 +1,181 @@
+package songctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"songLibrary/internal/dto"
+	"strconv"
+	"strings"
+)
+
+// APIError is an error response of the song library API
+type APIError struct {
+	Status  int
+	Code    dto.ErrorCode
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// Client calls the HTTP API of the song library, Token is sent to the admin
+// routes. Admin routes are called at AdminURL if the server serves them on
+// a separate address.
+type Client struct {
+	BaseURL  string
+	AdminURL string
+	Token    string
+	HTTP     *http.Client
+}
+
+// NewClient creates a client of the API at address, given as host:port or as
+// a URL
+func NewClient(address, token string) *Client {
+	return &Client{
+		BaseURL: baseURL(address),
+		Token:   token,
+		HTTP:    http.DefaultClient,
+	}
+}
+
+// WithAdminAddress makes the client call the admin routes at address, given
+// like the address of the API
+func (c *Client) WithAdminAddress(address string) *Client {
+	c.AdminURL = baseURL(address)
+	return c
+}
+
+func baseURL(address string) string {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	return strings.TrimSuffix(address, "/")
+}
+
+// AddSong adds a song and returns it as stored, with the details filled in
+// by MusicInfo
+func (c *Client) AddSong(ctx context.Context, name, group string) (*dto.SongResponse, error) {
+	body, err := json.Marshal(dto.AddSongRequest{Name: name, Group: group})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.do(ctx, http.MethodPost, "/songs", "application/json", bytes.NewReader(body), nil); err != nil {
+		return nil, err
+	}
+
+	// adding a song doesn't return it, it is looked up by its name and group
+	query := url.Values{"name": {name}, "group": {group}}
+	var song dto.SongResponse
+	if err := c.do(ctx, http.MethodGet, "/songs/lookup?"+query.Encode(), "", nil, &song); err != nil {
+		return nil, err
+	}
+	return &song, nil
+}
+
+// Import uploads a CSV file of songs
+func (c *Client) Import(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*dto.ImportResponse, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	var report dto.ImportResponse
+	path := "/songs/import?dry_run=" + strconv.FormatBool(dryRun)
+	if err := c.do(ctx, http.MethodPost, path, form.FormDataContentType(), &body, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Export writes a song rendered in the format to w
+func (c *Client) Export(ctx context.Context, id, format string, w io.Writer) error {
+	path := "/songs/" + url.PathEscape(id) + "/export?format=" + url.QueryEscape(format)
+	return c.do(ctx, http.MethodGet, path, "", nil, w)
+}
+
+func (c *Client) CacheStats(ctx context.Context) (*dto.CacheStatsResponse, error) {
+	var stats dto.CacheStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/admin/cache", "", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *Client) FlushCache(ctx context.Context) (*dto.CacheFlushResponse, error) {
+	var flushed dto.CacheFlushResponse
+	if err := c.do(ctx, http.MethodDelete, "/admin/cache", "", nil, &flushed); err != nil {
+		return nil, err
+	}
+	return &flushed, nil
+}
+
+// RebuildCache starts rebuilding the cache, the server rebuilds it in the
+// background
+func (c *Client) RebuildCache(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/admin/cache/rebuild", "", nil, nil)
+}
+
+// do sends a request and decodes a successful JSON response into out, or
+// copies the response into out when it is an io.Writer
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+	base := c.BaseURL
+	if c.AdminURL != "" && strings.HasPrefix(path, "/admin/") {
+		base = c.AdminURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.Token != "" && strings.HasPrefix(path, "/admin/") {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{Status: resp.StatusCode}
+		var errResp dto.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil {
+			apiErr.Code = errResp.Code
+			apiErr.Message = errResp.Message
+		}
+		return apiErr
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err = io.Copy(out, resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
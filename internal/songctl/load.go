@@ -0,0 +1,152 @@
+package songctl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LoadOptions configure a load test. Rate is in requests per second, zero
+// sends them as fast as the workers can.
+type LoadOptions struct {
+	Paths       []string
+	Rate        int
+	Concurrency int
+	Duration    time.Duration
+}
+
+// LoadReport sums up a load test. Failed requests got no response, their
+// errors are not counted in Statuses.
+type LoadReport struct {
+	Requests int
+	Failed   int
+	Statuses map[int]int
+	Elapsed  time.Duration
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+// Throughput returns the requests completed per second
+func (r *LoadReport) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// Load sends GET requests for the paths in turn to the API for the duration
+// of the test and measures how long the responses take
+func (c *Client) Load(ctx context.Context, opts LoadOptions) (*LoadReport, error) {
+	if len(opts.Paths) == 0 || opts.Concurrency <= 0 || opts.Duration <= 0 || opts.Rate < 0 {
+		return nil, errors.New("load test needs paths, a positive concurrency and duration")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	requests := make(chan string)
+	go func() {
+		defer close(requests)
+
+		var tick <-chan time.Time
+		if opts.Rate > 0 {
+			ticker := time.NewTicker(time.Second / time.Duration(opts.Rate))
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for i := 0; ; i++ {
+			if tick != nil {
+				select {
+				case <-tick:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case requests <- opts.Paths[i%len(opts.Paths)]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies []time.Duration
+		report    = &LoadReport{Statuses: make(map[int]int)}
+	)
+	start := time.Now()
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range requests {
+				status, latency, err := c.get(ctx, path)
+				// requests cut off by the end of the test aren't counted
+				if ctx.Err() != nil {
+					return
+				}
+
+				mu.Lock()
+				report.Requests++
+				if err != nil {
+					report.Failed++
+				} else {
+					report.Statuses[status]++
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	report.Elapsed = time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report.P50 = percentile(latencies, 50)
+	report.P90 = percentile(latencies, 90)
+	report.P99 = percentile(latencies, 99)
+	if len(latencies) > 0 {
+		report.Max = slices.Max(latencies)
+	}
+	return report, nil
+}
+
+// get sends a GET request for path and reads the whole response
+func (c *Client) get(ctx context.Context, path string) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	start := time.Now()
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, 0, err
+	}
+	return resp.StatusCode, time.Since(start), nil
+}
+
+// percentile returns the p-th percentile of the sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := (len(sorted)*p+99)/100 - 1
+	return sorted[max(i, 0)]
+}
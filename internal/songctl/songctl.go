@@ -0,0 +1,267 @@
+package songctl
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"songLibrary/pkg/migrator"
+	"time"
+)
+
+// ErrUsage is returned for unknown commands and invalid arguments, the usage
+// has been printed already
+var ErrUsage = errors.New("invalid usage")
+
+// Usage describes the commands and global flags
+const Usage = `usage: songctl [-addr host:port] [-admin-addr host:port] [-token token] <command> [arguments]
+
+commands:
+  add -name <name> -group <group>           add a song, details come from MusicInfo
+  import [-dry-run] <file.csv>              import songs from a CSV file
+  export [-format txt|md|pdf] [-o file] <id> export a song, to stdout without -o
+  cache stats|flush|rebuild                 manage the song cache (needs the admin token)
+  migrate status                            compare the schema version with the binary
+  load [-duration 10s] [-rate n] [-c n] [path ...]
+                                            load test GET routes, /songs without paths
+
+The addresses and the admin token default to http.address, admin.address
+and admin.token of the server config (CONFIG_PATH). Admin routes are called
+at the admin address if it is set.
+`
+
+// CLI runs songctl commands. Songs and the cache are managed through the
+// HTTP API of a running server, migrations are read from the database.
+type CLI struct {
+	Client *Client
+	// MigrationStatus reads the schema version of the database
+	MigrationStatus func(ctx context.Context) (*migrator.Status, error)
+	Stdout          io.Writer
+	Stderr          io.Writer
+}
+
+// Run runs the command in args, the global flags are already parsed
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.usage()
+	}
+
+	switch command, args := args[0], args[1:]; command {
+	case "add":
+		return c.add(ctx, args)
+	case "import":
+		return c.importSongs(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "cache":
+		return c.cache(ctx, args)
+	case "migrate":
+		return c.migrate(ctx, args)
+	case "load":
+		return c.load(ctx, args)
+	case "help":
+		fmt.Fprint(c.Stdout, Usage)
+		return nil
+	default:
+		fmt.Fprintf(c.Stderr, "unknown command %q\n", command)
+		return c.usage()
+	}
+}
+
+func (c *CLI) usage() error {
+	fmt.Fprint(c.Stderr, Usage)
+	return ErrUsage
+}
+
+// flagSet creates the flags of a command, errors are reported to stderr
+func (c *CLI) flagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.Stderr)
+	return flags
+}
+
+func (c *CLI) add(ctx context.Context, args []string) error {
+	flags := c.flagSet("add")
+	name := flags.String("name", "", "song name")
+	group := flags.String("group", "", "group")
+	if err := flags.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if *name == "" || *group == "" || flags.NArg() != 0 {
+		fmt.Fprintln(c.Stderr, "add needs -name and -group")
+		return ErrUsage
+	}
+
+	song, err := c.Client.AddSong(ctx, *name, *group)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Stdout, "added %s: %s - %s\n", song.ID, song.Group, song.Name)
+	return nil
+}
+
+func (c *CLI) importSongs(ctx context.Context, args []string) error {
+	flags := c.flagSet("import")
+	dryRun := flags.Bool("dry-run", false, "validate the file without saving songs")
+	if err := flags.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(c.Stderr, "import needs a CSV file")
+		return ErrUsage
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := c.Client.Import(ctx, filepath.Base(file.Name()), file, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "imported"
+	if report.DryRun {
+		verb = "valid"
+	}
+	fmt.Fprintf(c.Stdout, "%s %d of %d songs, %d failed\n", verb, report.Imported, report.Total, report.Failed)
+	for _, rowErr := range report.Errors {
+		fmt.Fprintf(c.Stdout, "line %d: %s\n", rowErr.Line, rowErr.Error)
+	}
+	return nil
+}
+
+func (c *CLI) export(ctx context.Context, args []string) error {
+	flags := c.flagSet("export")
+	format := flags.String("format", "txt", "txt, md or pdf")
+	output := flags.String("o", "", "output file")
+	if err := flags.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(c.Stderr, "export needs a song id")
+		return ErrUsage
+	}
+
+	if *output == "" {
+		return c.Client.Export(ctx, flags.Arg(0), *format, c.Stdout)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := c.Client.Export(ctx, flags.Arg(0), *format, file); err != nil {
+		file.Close()
+		os.Remove(*output)
+		return err
+	}
+	return file.Close()
+}
+
+func (c *CLI) cache(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(c.Stderr, "cache needs one of stats, flush or rebuild")
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "stats":
+		stats, err := c.Client.CacheStats(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Stdout, "keys: %d\nhits: %d\nmisses: %d\nhit rate: %.2f\nused memory: %d bytes\n",
+			stats.Keys, stats.Hits, stats.Misses, stats.HitRate, stats.UsedMemoryBytes)
+	case "flush":
+		flushed, err := c.Client.FlushCache(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Stdout, "deleted %d keys\n", flushed.Deleted)
+	case "rebuild":
+		if err := c.Client.RebuildCache(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.Stdout, "cache rebuild started, progress is in the server log")
+	default:
+		fmt.Fprintf(c.Stderr, "unknown cache command %q\n", args[0])
+		return ErrUsage
+	}
+	return nil
+}
+
+func (c *CLI) migrate(ctx context.Context, args []string) error {
+	if len(args) != 1 || args[0] != "status" {
+		fmt.Fprintln(c.Stderr, "migrate supports status")
+		return ErrUsage
+	}
+
+	status, err := c.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Stdout, "database version: %d\nlatest migration: %d\n", status.Version, status.Latest)
+	switch {
+	case status.Dirty:
+		fmt.Fprintf(c.Stdout, "migration %d failed halfway, the schema needs fixing by hand\n", status.Version)
+	case status.Pending():
+		fmt.Fprintf(c.Stdout, "%d migrations pending, they are applied when the server starts\n", status.Latest-status.Version)
+	case status.Version > status.Latest:
+		fmt.Fprintln(c.Stdout, "the database is newer than this binary")
+	default:
+		fmt.Fprintln(c.Stdout, "up to date")
+	}
+	return nil
+}
+
+func (c *CLI) load(ctx context.Context, args []string) error {
+	flags := c.flagSet("load")
+	duration := flags.Duration("duration", 10*time.Second, "how long to send requests")
+	rate := flags.Int("rate", 0, "requests per second, 0 sends them as fast as possible")
+	concurrency := flags.Int("c", 8, "requests sent at once")
+	if err := flags.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if *duration <= 0 || *rate < 0 || *concurrency <= 0 {
+		fmt.Fprintln(c.Stderr, "load needs a positive -duration and -c and a -rate of at least 0")
+		return ErrUsage
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"/songs"}
+	}
+
+	report, err := c.Client.Load(ctx, LoadOptions{
+		Paths:       paths,
+		Rate:        *rate,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Stdout, "requests: %d in %s (%.1f/s), %d failed\n",
+		report.Requests, report.Elapsed.Round(time.Millisecond), report.Throughput(), report.Failed)
+	fmt.Fprintf(c.Stdout, "latency: p50 %s, p90 %s, p99 %s, max %s\n", report.P50, report.P90, report.P99, report.Max)
+
+	statuses := make([]int, 0, len(report.Statuses))
+	for status := range report.Statuses {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(c.Stdout, "status %d: %d\n", status, report.Statuses[status])
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package songctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"songLibrary/internal/dto"
+	"songLibrary/pkg/migrator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCLI(t *testing.T, handler http.HandlerFunc) (*CLI, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	var stdout, stderr bytes.Buffer
+	return &CLI{
+		Client: NewClient(srv.URL, "secret"),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}, &stdout, &stderr
+}
+
+func TestNewClient_Address(t *testing.T) {
+	assert.Equal(t, "http://localhost:8089", NewClient("localhost:8089", "").BaseURL)
+	assert.Equal(t, "https://songs.example.com", NewClient("https://songs.example.com/", "").BaseURL)
+}
+
+func TestCLI_Add(t *testing.T) {
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/songs":
+			var req dto.AddSongRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, dto.AddSongRequest{Name: "Hysteria", Group: "Muse"}, req)
+			// Токен администратора не отправляется на обычные маршруты
+			assert.Empty(t, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/songs/lookup":
+			assert.Equal(t, "Hysteria", r.URL.Query().Get("name"))
+			assert.Equal(t, "Muse", r.URL.Query().Get("group"))
+			json.NewEncoder(w).Encode(dto.SongResponse{ID: "42", Name: "Hysteria", Group: "Muse"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	require.NoError(t, cli.Run(context.Background(), []string{"add", "-name", "Hysteria", "-group", "Muse"}))
+	assert.Equal(t, "added 42: Muse - Hysteria\n", stdout.String())
+}
+
+func TestCLI_Add_APIError(t *testing.T) {
+	cli, _, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(dto.ErrorResponse{Code: dto.CodeSongExists, Message: "song already exists"})
+	})
+
+	err := cli.Run(context.Background(), []string{"add", "-name", "Hysteria", "-group", "Muse"})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusConflict, apiErr.Status)
+	assert.Equal(t, dto.CodeSongExists, apiErr.Code)
+	assert.Equal(t, "409 SONG_ALREADY_EXISTS: song already exists", err.Error())
+}
+
+func TestCLI_Import(t *testing.T) {
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/songs/import", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "songs.csv", header.Filename)
+		assert.Equal(t, "name,group\nHysteria,Muse\n", string(content))
+
+		json.NewEncoder(w).Encode(dto.ImportResponse{
+			Total: 2, Imported: 1, Failed: 1, DryRun: true,
+			Errors: []dto.ImportRowErrorResponse{{Line: 3, Error: "song group is null"}},
+		})
+	})
+
+	path := filepath.Join(t.TempDir(), "songs.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,group\nHysteria,Muse\n"), 0o644))
+
+	require.NoError(t, cli.Run(context.Background(), []string{"import", "-dry-run", path}))
+	assert.Equal(t, "valid 1 of 2 songs, 1 failed\nline 3: song group is null\n", stdout.String())
+}
+
+func TestCLI_Export(t *testing.T) {
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/songs/42/export", r.URL.Path)
+		assert.Equal(t, "md", r.URL.Query().Get("format"))
+		io.WriteString(w, "# Hysteria\n")
+	})
+
+	require.NoError(t, cli.Run(context.Background(), []string{"export", "-format", "md", "42"}))
+	assert.Equal(t, "# Hysteria\n", stdout.String())
+
+	// С -o результат записывается в файл
+	path := filepath.Join(t.TempDir(), "hysteria.md")
+	require.NoError(t, cli.Run(context.Background(), []string{"export", "-format", "md", "-o", path, "42"}))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Hysteria\n", string(content))
+}
+
+func TestCLI_Cache(t *testing.T) {
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /admin/cache":
+			json.NewEncoder(w).Encode(dto.CacheStatsResponse{Keys: 10, Hits: 3, Misses: 1, HitRate: 0.75, UsedMemoryBytes: 2048})
+		case "DELETE /admin/cache":
+			json.NewEncoder(w).Encode(dto.CacheFlushResponse{Deleted: 10})
+		case "POST /admin/cache/rebuild":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	for _, command := range []string{"stats", "flush", "rebuild"} {
+		require.NoError(t, cli.Run(context.Background(), []string{"cache", command}), command)
+	}
+	assert.Equal(t, "keys: 10\nhits: 3\nmisses: 1\nhit rate: 0.75\nused memory: 2048 bytes\n"+
+		"deleted 10 keys\n"+
+		"cache rebuild started, progress is in the server log\n", stdout.String())
+}
+
+func TestCLI_CacheAdminAddress(t *testing.T) {
+	// Маршруты /admin вызываются по отдельному адресу администрирования
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/cache", r.URL.Path)
+		json.NewEncoder(w).Encode(dto.CacheFlushResponse{Deleted: 3})
+	}))
+	t.Cleanup(admin.Close)
+
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to the API %s %s", r.Method, r.URL)
+	})
+	cli.Client.WithAdminAddress(admin.URL)
+
+	require.NoError(t, cli.Run(context.Background(), []string{"cache", "flush"}))
+	assert.Equal(t, "deleted 3 keys\n", stdout.String())
+}
+
+func TestCLI_MigrateStatus(t *testing.T) {
+	var stdout bytes.Buffer
+	cli := &CLI{
+		MigrationStatus: func(context.Context) (*migrator.Status, error) {
+			return &migrator.Status{Version: 17, Latest: 19}, nil
+		},
+		Stdout: &stdout,
+		Stderr: io.Discard,
+	}
+
+	require.NoError(t, cli.Run(context.Background(), []string{"migrate", "status"}))
+	assert.Equal(t, "database version: 17\nlatest migration: 19\n2 migrations pending, they are applied when the server starts\n", stdout.String())
+}
+
+func TestCLI_Load(t *testing.T) {
+	var mu sync.Mutex
+	paths := make(map[string]int)
+	cli, stdout, _ := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		mu.Lock()
+		paths[r.URL.RequestURI()]++
+		mu.Unlock()
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, "[]")
+	})
+
+	require.NoError(t, cli.Run(context.Background(), []string{"load", "-duration", "200ms", "-rate", "100", "-c", "2", "/songs?group=Muse", "/missing"}))
+
+	// Пути запрашиваются по очереди, отчёт содержит задержки и статусы
+	mu.Lock()
+	assert.Positive(t, paths["/songs?group=Muse"])
+	assert.Positive(t, paths["/missing"])
+	assert.Len(t, paths, 2)
+	mu.Unlock()
+	assert.Contains(t, stdout.String(), "latency: p50 ")
+	assert.Contains(t, stdout.String(), "status 200: ")
+	assert.Contains(t, stdout.String(), "status 404: ")
+}
+
+func TestCLI_Usage(t *testing.T) {
+	cli, _, stderr := newTestCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	for _, args := range [][]string{
+		nil,
+		{"play"},
+		{"add", "-name", "Hysteria"},
+		{"import"},
+		{"export"},
+		{"cache", "warm"},
+		{"migrate", "down"},
+		{"load", "-c", "0"},
+	} {
+		stderr.Reset()
+		assert.ErrorIs(t, cli.Run(context.Background(), args), ErrUsage, args)
+		assert.NotEmpty(t, stderr.String(), args)
+	}
+}
@@ -0,0 +1,164 @@
+// Package audiometa reads the format, duration and bitrate of MP3, WAV and
+// FLAC files from their headers without decoding the audio.
+package audiometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+// Formats of audio files
+const (
+	FormatMP3  = "mp3"
+	FormatWAV  = "wav"
+	FormatFLAC = "flac"
+)
+
+// contentTypes maps the formats to their MIME types
+var contentTypes = map[string]string{
+	FormatMP3:  "audio/mpeg",
+	FormatWAV:  "audio/wav",
+	FormatFLAC: "audio/flac",
+}
+
+// Metadata describes an audio file. Bitrate is the average number of bits
+// per second of the file.
+type Metadata struct {
+	Format      string
+	ContentType string
+	Duration    time.Duration
+	Bitrate     int
+	SampleRate  int
+}
+
+// Parse reads the metadata of an audio file of size bytes
+func Parse(r io.ReadSeeker, size int64) (*Metadata, error) {
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(r, head); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var (
+		meta *Metadata
+		err  error
+	)
+	switch {
+	case bytes.Equal(head[:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		meta, err = parseWAV(r)
+	case bytes.Equal(head[:4], []byte("fLaC")):
+		meta, err = parseFLAC(r, size)
+	default:
+		meta, err = parseMP3(r, size)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta.ContentType = contentTypes[meta.Format]
+	return meta, nil
+}
+
+// bitrate returns the average bitrate of size bytes played for duration
+func bitrate(size int64, duration time.Duration) int {
+	if duration <= 0 {
+		return 0
+	}
+	return int(float64(size*8) / duration.Seconds())
+}
+
+func parseWAV(r io.Reader) (*Metadata, error) {
+	// skip the RIFF header, Parse has read it already
+	if _, err := io.CopyN(io.Discard, r, 12); err != nil {
+		return nil, err
+	}
+
+	var (
+		byteRate   uint32
+		sampleRate uint32
+	)
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &chunk); err != nil {
+			return nil, fmt.Errorf("%w: wav has no data chunk", ErrUnsupportedFormat)
+		}
+
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			var format struct {
+				AudioFormat uint16
+				Channels    uint16
+				SampleRate  uint32
+				ByteRate    uint32
+			}
+			if chunk.Size < 12 {
+				return nil, fmt.Errorf("%w: invalid wav format chunk", ErrUnsupportedFormat)
+			}
+			if err := binary.Read(r, binary.LittleEndian, &format); err != nil {
+				return nil, fmt.Errorf("%w: truncated wav format chunk", ErrUnsupportedFormat)
+			}
+			byteRate, sampleRate = format.ByteRate, format.SampleRate
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size+chunk.Size%2)-12); err != nil {
+				return nil, fmt.Errorf("%w: truncated wav format chunk", ErrUnsupportedFormat)
+			}
+		case "data":
+			if byteRate == 0 {
+				return nil, fmt.Errorf("%w: wav data before format", ErrUnsupportedFormat)
+			}
+			duration := time.Duration(float64(chunk.Size) / float64(byteRate) * float64(time.Second))
+			return &Metadata{
+				Format:     FormatWAV,
+				Duration:   duration,
+				Bitrate:    int(byteRate) * 8,
+				SampleRate: int(sampleRate),
+			}, nil
+		default:
+			// chunks are padded to an even size
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size+chunk.Size%2)); err != nil {
+				return nil, fmt.Errorf("%w: wav has no data chunk", ErrUnsupportedFormat)
+			}
+		}
+	}
+}
+
+func parseFLAC(r io.Reader, size int64) (*Metadata, error) {
+	// the marker is followed by the STREAMINFO block, which comes first
+	header := make([]byte, 4+4+34)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: truncated flac header", ErrUnsupportedFormat)
+	}
+	if header[4]&0x7f != 0 {
+		return nil, fmt.Errorf("%w: flac has no stream info", ErrUnsupportedFormat)
+	}
+
+	// after the block and frame sizes: 20 bits of sample rate, 3 bits of
+	// channels, 5 bits of bits per sample and 36 bits of total samples
+	info := binary.BigEndian.Uint64(header[8+10 : 8+18])
+	sampleRate := int(info >> 44)
+	totalSamples := int64(info & (1<<36 - 1))
+	if sampleRate == 0 {
+		return nil, fmt.Errorf("%w: invalid flac sample rate", ErrUnsupportedFormat)
+	}
+
+	duration := time.Duration(float64(totalSamples) / float64(sampleRate) * float64(time.Second))
+	return &Metadata{
+		Format:     FormatFLAC,
+		Duration:   duration,
+		Bitrate:    bitrate(size, duration),
+		SampleRate: sampleRate,
+	}, nil
+}
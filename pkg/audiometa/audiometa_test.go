@@ -0,0 +1,156 @@
+package audiometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wavFile(t *testing.T, dataSize uint32) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	write := func(v any) {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, v))
+	}
+
+	buf.WriteString("RIFF")
+	write(uint32(0))
+	buf.WriteString("WAVE")
+
+	// Посторонний блок нечётного размера перед форматом
+	buf.WriteString("LIST")
+	write(uint32(3))
+	buf.Write([]byte{1, 2, 3, 0})
+
+	buf.WriteString("fmt ")
+	write(uint32(16))
+	write(struct {
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{1, 2, 44100, 176400, 4, 16})
+
+	buf.WriteString("data")
+	write(dataSize)
+	buf.Write(make([]byte, 16))
+	return buf.Bytes()
+}
+
+func flacFile(sampleRate, totalSamples uint64) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("fLaC")
+	// Последний блок метаданных, STREAMINFO длиной 34 байта
+	buf.Write([]byte{0x80, 0, 0, 34})
+	buf.Write(make([]byte, 10))
+
+	// 2 канала и 16 бит на сэмпл
+	info := sampleRate<<44 | 1<<41 | 15<<36 | totalSamples
+	buf.Write(binary.BigEndian.AppendUint64(nil, info))
+	buf.Write(make([]byte, 16))
+	return buf.Bytes()
+}
+
+// mp3Frames возвращает кадры MPEG 1 Layer III 128 кбит/с, 44100 Гц, стерео
+func mp3Frames(count int) []byte {
+	const frameLen = 417
+
+	var buf bytes.Buffer
+	for i := 0; i < count; i++ {
+		frame := make([]byte, frameLen)
+		copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func TestParse_WAV(t *testing.T) {
+	data := wavFile(t, 176400*2)
+
+	meta, err := Parse(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	assert.Equal(t, &Metadata{
+		Format:      FormatWAV,
+		ContentType: "audio/wav",
+		Duration:    2 * time.Second,
+		Bitrate:     1411200,
+		SampleRate:  44100,
+	}, meta)
+}
+
+func TestParse_FLAC(t *testing.T) {
+	data := flacFile(44100, 441000)
+
+	meta, err := Parse(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatFLAC, meta.Format)
+	assert.Equal(t, "audio/flac", meta.ContentType)
+	assert.Equal(t, 10*time.Second, meta.Duration)
+	assert.Equal(t, 44100, meta.SampleRate)
+	// Средний битрейт считается по размеру файла
+	assert.Equal(t, len(data)*8/10, meta.Bitrate)
+}
+
+func TestParse_MP3CBR(t *testing.T) {
+	// ID3v2 длиной 20 байт перед кадрами и ID3v1 в конце
+	tag := append([]byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, 20}, make([]byte, 20)...)
+	id3v1 := append([]byte("TAG"), make([]byte, 125)...)
+
+	data := append(append(tag, mp3Frames(10)...), id3v1...)
+
+	meta, err := Parse(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatMP3, meta.Format)
+	assert.Equal(t, "audio/mpeg", meta.ContentType)
+	assert.Equal(t, 44100, meta.SampleRate)
+	// 4170 байт аудио при 128 кбит/с
+	assert.Equal(t, 260625*time.Microsecond, meta.Duration)
+	assert.Equal(t, 128000, meta.Bitrate)
+}
+
+func TestParse_MP3Xing(t *testing.T) {
+	data := mp3Frames(3)
+	// Заголовок Xing следует за 32 байтами side info в первом кадре
+	copy(data[36:], "Xing")
+	binary.BigEndian.PutUint32(data[40:], 1)
+	binary.BigEndian.PutUint32(data[44:], 100)
+
+	meta, err := Parse(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	// 100 кадров по 1152 сэмпла
+	assert.InDelta(t, 2.612, meta.Duration.Seconds(), 0.001)
+	assert.Equal(t, bitrate(int64(len(data)), meta.Duration), meta.Bitrate)
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "text", data: bytes.Repeat([]byte("not an audio file "), 100)},
+		{name: "wav without data", data: wavFile(t, 0)[:12+12+8+16]},
+		{name: "truncated wav format", data: wavFile(t, 0)[:12+12+8+6]},
+		{name: "truncated flac", data: flacFile(44100, 1)[:20]},
+		// Одиночное слово синхронизации без следующего кадра
+		{name: "false sync", data: append([]byte{0xff, 0xfb, 0x90, 0x00}, bytes.Repeat([]byte{0xaa}, 1000)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(bytes.NewReader(tt.data), int64(len(tt.data)))
+			assert.ErrorIs(t, err, ErrUnsupportedFormat)
+		})
+	}
+}
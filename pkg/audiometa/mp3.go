@@ -0,0 +1,220 @@
+package audiometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// mp3ScanLen limits how far after the ID3 tag the first frame is looked for
+const mp3ScanLen = 64 << 10
+
+// MPEG versions as encoded in the frame header
+const (
+	mpeg25 = 0
+	mpeg2  = 2
+	mpeg1  = 3
+)
+
+// bitrates in kbit/s of MPEG 1 and of MPEG 2 and 2.5 by layer (1 to 3),
+// indexed by the bitrate bits of the frame header
+var (
+	mpeg1Bitrates = [4][16]int{
+		1: {0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		2: {0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		3: {0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	}
+	mpeg2Bitrates = [4][16]int{
+		1: {0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		2: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		3: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	}
+)
+
+var sampleRates = map[int][3]int{
+	mpeg1:  {44100, 48000, 32000},
+	mpeg2:  {22050, 24000, 16000},
+	mpeg25: {11025, 12000, 8000},
+}
+
+type mp3Frame struct {
+	version    int
+	layer      int
+	bitrate    int // bit/s
+	sampleRate int
+	padding    int
+	mono       bool
+}
+
+// samples returns the number of samples in a frame
+func (f mp3Frame) samples() int {
+	switch {
+	case f.layer == 1:
+		return 384
+	case f.layer == 3 && f.version != mpeg1:
+		return 576
+	default:
+		return 1152
+	}
+}
+
+// sideInfoLen returns the length of the layer III side information, the
+// Xing header follows it
+func (f mp3Frame) sideInfoLen() int {
+	switch {
+	case f.version == mpeg1 && f.mono:
+		return 17
+	case f.version == mpeg1:
+		return 32
+	case f.mono:
+		return 9
+	default:
+		return 17
+	}
+}
+
+func parseFrameHeader(b []byte) (mp3Frame, bool) {
+	if b[0] != 0xff || b[1]&0xe0 != 0xe0 {
+		return mp3Frame{}, false
+	}
+
+	version := int(b[1]>>3) & 3
+	layer := 4 - int(b[1]>>1)&3
+	bitrateIndex := int(b[2] >> 4)
+	sampleRateIndex := int(b[2]>>2) & 3
+	if version == 1 || layer == 4 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	kbps := mpeg2Bitrates[layer][bitrateIndex]
+	if version == mpeg1 {
+		kbps = mpeg1Bitrates[layer][bitrateIndex]
+	}
+
+	return mp3Frame{
+		version:    version,
+		layer:      layer,
+		bitrate:    kbps * 1000,
+		sampleRate: sampleRates[version][sampleRateIndex],
+		padding:    int(b[2]>>1) & 1,
+		mono:       b[3]>>6 == 3,
+	}, true
+}
+
+// length returns the length of the frame in bytes, header included
+func (f mp3Frame) length() int {
+	if f.layer == 1 {
+		return (12*f.bitrate/f.sampleRate + f.padding) * 4
+	}
+	return f.samples()/8*f.bitrate/f.sampleRate + f.padding
+}
+
+// parseMP3 finds the first frame after the ID3v2 tag. The duration of VBR
+// files is taken from the Xing or VBRI header, that of CBR files follows
+// from the size and the bitrate.
+func parseMP3(r io.ReadSeeker, size int64) (*Metadata, error) {
+	start, err := id3v2Len(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, mp3ScanLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	buf = buf[:n]
+
+	for i := 0; i+4 <= len(buf); i++ {
+		frame, ok := parseFrameHeader(buf[i:])
+		if !ok {
+			continue
+		}
+
+		// a sync word in other data is unlikely to be followed by another
+		// frame, unless the buffer ends before it
+		if next := i + frame.length(); next+4 <= len(buf) {
+			if _, ok := parseFrameHeader(buf[next:]); !ok {
+				continue
+			}
+		}
+
+		audioSize := size - start - int64(i) - id3v1Len(r, size)
+		duration := vbrDuration(buf[i:], frame)
+		if duration == 0 {
+			duration = time.Duration(float64(audioSize*8) / float64(frame.bitrate) * float64(time.Second))
+		}
+
+		return &Metadata{
+			Format:     FormatMP3,
+			Duration:   duration,
+			Bitrate:    bitrate(audioSize, duration),
+			SampleRate: frame.sampleRate,
+		}, nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// id3v2Len returns the length of the ID3v2 tag at the start of the file
+func id3v2Len(r io.ReadSeeker) (int64, error) {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, ErrUnsupportedFormat
+	}
+	if !bytes.Equal(header[:3], []byte("ID3")) {
+		return 0, nil
+	}
+
+	// the size is stored in 4 bytes of 7 bits
+	length := int64(header[6])<<21 | int64(header[7])<<14 | int64(header[8])<<7 | int64(header[9])
+	length += 10
+	if header[5]&0x10 != 0 {
+		length += 10 // footer
+	}
+	return length, nil
+}
+
+// id3v1Len returns the length of the ID3v1 tag at the end of the file
+func id3v1Len(r io.ReadSeeker, size int64) int64 {
+	if size < 128 {
+		return 0
+	}
+	if _, err := r.Seek(size-128, io.SeekStart); err != nil {
+		return 0
+	}
+
+	tag := make([]byte, 3)
+	if _, err := io.ReadFull(r, tag); err != nil || !bytes.Equal(tag, []byte("TAG")) {
+		return 0
+	}
+	return 128
+}
+
+// vbrDuration returns the duration from the Xing or VBRI header of the
+// first frame, zero if it has none
+func vbrDuration(frameData []byte, frame mp3Frame) time.Duration {
+	frames := 0
+
+	xing := 4 + frame.sideInfoLen()
+	vbri := 4 + 32
+	switch {
+	case len(frameData) >= xing+12 && (bytes.Equal(frameData[xing:xing+4], []byte("Xing")) || bytes.Equal(frameData[xing:xing+4], []byte("Info"))):
+		flags := binary.BigEndian.Uint32(frameData[xing+4:])
+		if flags&1 == 0 {
+			return 0
+		}
+		frames = int(binary.BigEndian.Uint32(frameData[xing+8:]))
+	case len(frameData) >= vbri+18 && bytes.Equal(frameData[vbri:vbri+4], []byte("VBRI")):
+		frames = int(binary.BigEndian.Uint32(frameData[vbri+14:]))
+	default:
+		return 0
+	}
+
+	samples := float64(frames) * float64(frame.samples())
+	return time.Duration(samples / float64(frame.sampleRate) * float64(time.Second))
+}
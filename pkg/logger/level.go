@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// Keys of the attributes naming the module a logger belongs to: an
+// operation such as "AlbumService.Get" belongs to module "AlbumService",
+// a component such as "middleware/ratelimit" is a module itself
+const (
+	opKey        = "op"
+	componentKey = "component"
+)
+
+// LevelHandler filters records by the level of the module they are logged
+// by. Modules without a level of their own use the default level.
+type LevelHandler struct {
+	next    slog.Handler
+	level   slog.Level
+	modules map[string]slog.Level
+	module  string
+}
+
+// NewLevelHandler returns a handler passing records of at least level to
+// next. modules sets levels of single modules, a level set for "repository"
+// also applies to "repository.AlbumDB" and one set for "middleware" to
+// "middleware/user". next must accept records of every level.
+func NewLevelHandler(next slog.Handler, level slog.Level, modules map[string]slog.Level) *LevelHandler {
+	return &LevelHandler{
+		next:    next,
+		level:   level,
+		modules: modules,
+	}
+}
+
+func (h *LevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.moduleLevel() && h.next.Enabled(ctx, level)
+}
+
+func (h *LevelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *LevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	module := h.module
+	for _, a := range attrs {
+		switch a.Key {
+		case opKey:
+			op := a.Value.String()
+			if i := strings.LastIndex(op, "."); i > 0 {
+				op = op[:i]
+			}
+			module = op
+		case componentKey:
+			module = a.Value.String()
+		}
+	}
+
+	return &LevelHandler{
+		next:    h.next.WithAttrs(attrs),
+		level:   h.level,
+		modules: h.modules,
+		module:  module,
+	}
+}
+
+func (h *LevelHandler) WithGroup(name string) slog.Handler {
+	return &LevelHandler{
+		next:    h.next.WithGroup(name),
+		level:   h.level,
+		modules: h.modules,
+		module:  h.module,
+	}
+}
+
+// moduleLevel returns the level of the most specific configured module the
+// handler's module belongs to
+func (h *LevelHandler) moduleLevel() slog.Level {
+	level, matched := h.level, -1
+	for name, moduleLevel := range h.modules {
+		if len(name) > matched && belongsTo(h.module, name) {
+			level, matched = moduleLevel, len(name)
+		}
+	}
+	return level
+}
+
+func belongsTo(module, name string) bool {
+	if !strings.HasPrefix(module, name) {
+		return false
+	}
+	if len(module) == len(name) {
+		return true
+	}
+	next := module[len(name)]
+	return next == '.' || next == '/'
+}
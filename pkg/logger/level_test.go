@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(buf *bytes.Buffer, level slog.Level, modules map[string]slog.Level) *slog.Logger {
+	base := slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(NewLevelHandler(base, level, modules))
+}
+
+func TestLevelHandler_Modules(t *testing.T) {
+	var buf bytes.Buffer
+	log := newTestLogger(&buf, slog.LevelInfo, map[string]slog.Level{
+		"Service":            slog.LevelDebug,
+		"repository":         slog.LevelWarn,
+		"repository.AlbumDB": slog.LevelDebug,
+		"middleware":         slog.LevelError,
+	})
+
+	tests := []struct {
+		name    string
+		log     *slog.Logger
+		level   slog.Level
+		written bool
+	}{
+		{name: "уровень по умолчанию", log: log, level: slog.LevelInfo, written: true},
+		{name: "debug по умолчанию отключён", log: log, level: slog.LevelDebug, written: false},
+		{name: "модуль по op", log: log.With(slog.String("op", "Service.Add")), level: slog.LevelDebug, written: true},
+		{name: "другой модуль с тем же префиксом", log: log.With(slog.String("op", "ServiceX.Add")), level: slog.LevelDebug, written: false},
+		{name: "родительский модуль", log: log.With(slog.String("op", "repository.SongDB.Create")), level: slog.LevelInfo, written: false},
+		{name: "более точный модуль", log: log.With(slog.String("op", "repository.AlbumDB.Create")), level: slog.LevelDebug, written: true},
+		{name: "модуль по component", log: log.With(slog.String("component", "middleware/user")), level: slog.LevelWarn, written: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log.Log(context.Background(), tt.level, "message")
+			assert.Equal(t, tt.written, strings.Contains(buf.String(), "message"))
+		})
+	}
+}
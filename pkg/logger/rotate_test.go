@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, 10, 2)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+
+	// Каждая запись превышает лимит вместе с предыдущей, хранятся две старые копии
+	assertFile(t, path, "fourth\n")
+	assertFile(t, path+".1", "third\n")
+	assertFile(t, path+".2", "second\n")
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRotatingFile_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(path, []byte("old\n"), 0o644))
+
+	f, err := NewRotatingFile(path, 0, 0)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("new\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	assertFile(t, path, "old\nnew\n")
+}
+
+func assertFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
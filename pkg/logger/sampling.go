@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SamplingOptions limit the number of Debug records with the same message:
+// in every Tick the first Initial records are written and after them every
+// Thereafter-th one
+type SamplingOptions struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// SamplingHandler drops repeating Debug records, records of higher levels
+// are always passed on
+type SamplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+func NewSamplingHandler(next slog.Handler, opts SamplingOptions) *SamplingHandler {
+	return &SamplingHandler{
+		next: next,
+		sampler: &sampler{
+			opts:   opts,
+			counts: make(map[string]int),
+		},
+	}
+}
+
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level <= slog.LevelDebug && !h.sampler.keep(r.Message, r.Time) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
+
+// sampler counts records by message, it is shared by the handlers derived
+// with WithAttrs and WithGroup
+type sampler struct {
+	opts SamplingOptions
+
+	mu        sync.Mutex
+	tickStart time.Time
+	counts    map[string]int
+}
+
+func (s *sampler) keep(msg string, t time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.Sub(s.tickStart) >= s.opts.Tick {
+		s.tickStart = t
+		clear(s.counts)
+	}
+
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.opts.Initial {
+		return true
+	}
+	return s.opts.Thereafter > 0 && (n-s.opts.Initial)%s.opts.Thereafter == 0
+}
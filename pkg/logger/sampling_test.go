@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	h := NewSamplingHandler(base, SamplingOptions{Initial: 2, Thereafter: 3, Tick: time.Second})
+
+	start := time.Now()
+	write := func(level slog.Level, msg string, at time.Time) {
+		assert.NoError(t, h.Handle(context.Background(), slog.NewRecord(at, level, msg, 0)))
+	}
+
+	// Из 8 одинаковых записей пишутся 1, 2, 5 и 8
+	for i := 0; i < 8; i++ {
+		write(slog.LevelDebug, "cache hit", start)
+	}
+	assert.Equal(t, 4, strings.Count(buf.String(), "cache hit"))
+
+	// Записи уровня info не прореживаются
+	for i := 0; i < 8; i++ {
+		write(slog.LevelInfo, "song added", start)
+	}
+	assert.Equal(t, 8, strings.Count(buf.String(), "song added"))
+
+	// В следующем интервале счёт начинается заново
+	buf.Reset()
+	write(slog.LevelDebug, "cache hit", start.Add(time.Second))
+	assert.Equal(t, 1, strings.Count(buf.String(), "cache hit"))
+}
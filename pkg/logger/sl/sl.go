@@ -1,7 +1,9 @@
 package sl
 
 import (
+	"context"
 	"log/slog"
+	"songLibrary/pkg/requestid"
 )
 
 func Err(err error) slog.Attr {
@@ -10,3 +12,13 @@ func Err(err error) slog.Attr {
 		Value: slog.StringValue(err.Error()),
 	}
 }
+
+// RequestID returns the ID of the request ctx belongs to as an attribute.
+// Outside of requests the attribute is empty and left out of the record.
+func RequestID(ctx context.Context) slog.Attr {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return slog.Attr{}
+	}
+	return slog.String("request_id", id)
+}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -12,10 +13,30 @@ import (
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
+// ErrInvalidSteps is returned when a number of migrations to roll back isn't positive
+var ErrInvalidSteps = errors.New("number of migrations must be positive")
+
+// Migrator runs the migrations of an embedded directory. Every command
+// closes the database it is given once done.
 type Migrator struct {
 	srcDriver source.Driver
 }
 
+// Status is the schema version of the database next to the latest
+// migration of the source
+type Status struct {
+	// Version is 0 when no migration has been applied
+	Version uint
+	// Dirty is set when the migration to Version failed halfway
+	Dirty  bool
+	Latest uint
+}
+
+// Pending reports whether the database is behind the source
+func (s *Status) Pending() bool {
+	return s.Version < s.Latest
+}
+
 func MustGetNewMigrator(sqlFiles embed.FS, dirName string) *Migrator {
 	d, err := iofs.New(sqlFiles, dirName)
 	if err != nil {
@@ -26,24 +47,95 @@ func MustGetNewMigrator(sqlFiles embed.FS, dirName string) *Migrator {
 	}
 }
 
+// ApplyMigrations applies all pending migrations
 func (m *Migrator) ApplyMigrations(db *sql.DB) error {
+	migrator, err := m.newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err = migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("unable to apply migrations %v", err)
+	}
+
+	return nil
+}
+
+// Down rolls back the last steps migrations
+func (m *Migrator) Down(db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return ErrInvalidSteps
+	}
+	return m.Steps(db, -steps)
+}
+
+// Steps applies n migrations up, or rolls back -n migrations when n is
+// negative. It fails without changes when fewer migrations are available.
+func (m *Migrator) Steps(db *sql.DB, n int) error {
+	migrator, err := m.newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err = migrator.Steps(n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("unable to migrate %d steps: %w", n, err)
+	}
+
+	return nil
+}
+
+// Status reads the schema version of the database
+func (m *Migrator) Status(db *sql.DB) (*Status, error) {
+	latest, err := m.latest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read migrations: %w", err)
+	}
+
+	migrator, err := m.newMigrate(db)
+	if err != nil {
+		return nil, err
+	}
+	defer migrator.Close()
+
+	status := &Status{Latest: latest}
+	status.Version, status.Dirty, err = migrator.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("unable to read schema version: %w", err)
+	}
+
+	return status, nil
+}
+
+func (m *Migrator) newMigrate(db *sql.DB) (*migrate.Migrate, error) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
-		return fmt.Errorf("unable to create db instance: %v", err)
+		return nil, fmt.Errorf("unable to create db instance: %v", err)
 	}
 
 	migrator, err := migrate.NewWithInstance("migration_embeded_sql_files", m.srcDriver, "psql_db", driver)
 	if err != nil {
-		return fmt.Errorf("unable to create migration: %v", err)
+		return nil, fmt.Errorf("unable to create migration: %v", err)
 	}
 
-	defer func() {
-		migrator.Close()
-	}()
+	return migrator, nil
+}
 
-	if err = migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("unable to apply migrations %v", err)
+// latest returns the version of the last migration of the source
+func (m *Migrator) latest() (uint, error) {
+	version, err := m.srcDriver.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := m.srcDriver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
 	}
-
-	return nil
 }
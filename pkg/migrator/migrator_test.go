@@ -0,0 +1,34 @@
+package migrator
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/*.sql
+var testMigrations embed.FS
+
+func TestMigrator_Latest(t *testing.T) {
+	m := MustGetNewMigrator(testMigrations, "testdata")
+
+	// Номера миграций не обязаны идти подряд
+	latest, err := m.latest()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), latest)
+}
+
+func TestMigrator_Down_InvalidSteps(t *testing.T) {
+	m := MustGetNewMigrator(testMigrations, "testdata")
+
+	// Откат всех миграций без явного числа не допускается, до базы дело не доходит
+	assert.ErrorIs(t, m.Down(nil, 0), ErrInvalidSteps)
+	assert.ErrorIs(t, m.Down(nil, -1), ErrInvalidSteps)
+}
+
+func TestStatus_Pending(t *testing.T) {
+	assert.True(t, (&Status{Version: 3, Latest: 5}).Pending())
+	assert.False(t, (&Status{Version: 5, Latest: 5}).Pending())
+	assert.False(t, (&Status{Version: 6, Latest: 5}).Pending())
+}
@@ -0,0 +1 @@
+CREATE TABLE t2 (id INT);
@@ -0,0 +1,22 @@
+// Package requestid carries the ID of the request being handled through the
+// layers of the application and on to downstream services.
+package requestid
+
+import "context"
+
+// Header is the HTTP header the ID is sent and received in
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// With returns a copy of ctx carrying the request ID
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, an empty string if the
+// context does not belong to a request
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
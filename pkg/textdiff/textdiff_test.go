@@ -0,0 +1,32 @@
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	diff := Lines("It's bugging me\nGrating me\nAnd twisting me around", "It's bugging me\nGrating me\r\nAnd turning me around\nYeah")
+
+	// Изменённая строка превращается в удаление и вставку
+	assert.Equal(t, []Line{
+		{Op: Equal, Text: "It's bugging me"},
+		{Op: Equal, Text: "Grating me"},
+		{Op: Delete, Text: "And twisting me around"},
+		{Op: Insert, Text: "And turning me around"},
+		{Op: Insert, Text: "Yeah"},
+	}, diff)
+}
+
+func TestLines_Equal(t *testing.T) {
+	diff := Lines("a\nb", "a\nb")
+
+	assert.Equal(t, []Line{{Op: Equal, Text: "a"}, {Op: Equal, Text: "b"}}, diff)
+}
+
+func TestLines_Empty(t *testing.T) {
+	assert.Equal(t, []Line{{Op: Insert, Text: "a"}}, Lines("", "a"))
+	assert.Equal(t, []Line{{Op: Delete, Text: "a"}}, Lines("a", ""))
+	assert.Empty(t, Lines("", ""))
+}